@@ -1,139 +1,266 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"strings"
 
+	// Contrib ecosystems register themselves with univers on import.
+	_ "github.com/alowayed/go-univers/pkg/contrib/opam"
 	"github.com/alowayed/go-univers/pkg/ecosystem/alpine"
 	"github.com/alowayed/go-univers/pkg/ecosystem/alpm"
 	"github.com/alowayed/go-univers/pkg/ecosystem/apache"
+	"github.com/alowayed/go-univers/pkg/ecosystem/bazel"
 	"github.com/alowayed/go-univers/pkg/ecosystem/cargo"
 	"github.com/alowayed/go-univers/pkg/ecosystem/composer"
 	"github.com/alowayed/go-univers/pkg/ecosystem/conan"
+	"github.com/alowayed/go-univers/pkg/ecosystem/cpan"
 	"github.com/alowayed/go-univers/pkg/ecosystem/cran"
 	"github.com/alowayed/go-univers/pkg/ecosystem/debian"
 	"github.com/alowayed/go-univers/pkg/ecosystem/gem"
+	"github.com/alowayed/go-univers/pkg/ecosystem/genericwin"
 	"github.com/alowayed/go-univers/pkg/ecosystem/gentoo"
 	"github.com/alowayed/go-univers/pkg/ecosystem/github"
 	"github.com/alowayed/go-univers/pkg/ecosystem/golang"
+	"github.com/alowayed/go-univers/pkg/ecosystem/gover"
 	"github.com/alowayed/go-univers/pkg/ecosystem/hex"
+	"github.com/alowayed/go-univers/pkg/ecosystem/luarocks"
 	"github.com/alowayed/go-univers/pkg/ecosystem/mattermost"
 	"github.com/alowayed/go-univers/pkg/ecosystem/maven"
+	"github.com/alowayed/go-univers/pkg/ecosystem/msver"
 	"github.com/alowayed/go-univers/pkg/ecosystem/npm"
 	"github.com/alowayed/go-univers/pkg/ecosystem/nuget"
 	"github.com/alowayed/go-univers/pkg/ecosystem/pypi"
 	"github.com/alowayed/go-univers/pkg/ecosystem/rpm"
 	"github.com/alowayed/go-univers/pkg/ecosystem/semver"
+	"github.com/alowayed/go-univers/pkg/spec/purl"
 	"github.com/alowayed/go-univers/pkg/univers"
 )
 
+// ecosystemToRun maps each core ecosystem name to its command runner
+var ecosystemToRun = map[string]func([]string) output{
+	alpine.Name: func(args []string) output {
+		return runEcosystem(&alpine.Ecosystem{}, args)
+	},
+	alpm.Name: func(args []string) output {
+		if len(args) > 0 && args[0] == "vercmp" {
+			return runVercmp(args[1:])
+		}
+		return runEcosystem(&alpm.Ecosystem{}, args)
+	},
+	apache.Name: func(args []string) output {
+		return runEcosystem(&apache.Ecosystem{}, args)
+	},
+	bazel.Name: func(args []string) output {
+		return runEcosystem(&bazel.Ecosystem{}, args)
+	},
+	cargo.Name: func(args []string) output {
+		return runEcosystem(&cargo.Ecosystem{}, args)
+	},
+	conan.Name: func(args []string) output {
+		return runEcosystem(&conan.Ecosystem{}, args)
+	},
+	composer.Name: func(args []string) output {
+		return runEcosystem(&composer.Ecosystem{}, args)
+	},
+	cpan.Name: func(args []string) output {
+		return runEcosystem(&cpan.Ecosystem{}, args)
+	},
+	cran.Name: func(args []string) output {
+		return runEcosystem(&cran.Ecosystem{}, args)
+	},
+	debian.Name: func(args []string) output {
+		return runEcosystem(&debian.Ecosystem{}, args)
+	},
+	gem.Name: func(args []string) output {
+		return runEcosystem(&gem.Ecosystem{}, args)
+	},
+	genericwin.Name: func(args []string) output {
+		return runEcosystem(&genericwin.Ecosystem{}, args)
+	},
+	gentoo.Name: func(args []string) output {
+		return runEcosystem(&gentoo.Ecosystem{}, args)
+	},
+	github.Name: func(args []string) output {
+		return runEcosystem(&github.Ecosystem{}, args)
+	},
+	golang.Name: func(args []string) output {
+		return runEcosystem(&golang.Ecosystem{}, args)
+	},
+	gover.Name: func(args []string) output {
+		return runEcosystem(&gover.Ecosystem{}, args)
+	},
+	hex.Name: func(args []string) output {
+		return runEcosystem(&hex.Ecosystem{}, args)
+	},
+	luarocks.Name: func(args []string) output {
+		return runEcosystem(&luarocks.Ecosystem{}, args)
+	},
+	mattermost.Name: func(args []string) output {
+		return runEcosystem(&mattermost.Ecosystem{}, args)
+	},
+	maven.Name: func(args []string) output {
+		return runEcosystem(&maven.Ecosystem{}, args)
+	},
+	msver.Name: func(args []string) output {
+		return runEcosystem(&msver.Ecosystem{}, args)
+	},
+	npm.Name: func(args []string) output {
+		return runEcosystem(&npm.Ecosystem{}, args)
+	},
+	nuget.Name: func(args []string) output {
+		return runEcosystem(&nuget.Ecosystem{}, args)
+	},
+	pypi.Name: func(args []string) output {
+		return runEcosystem(&pypi.Ecosystem{}, args)
+	},
+	rpm.Name: func(args []string) output {
+		return runEcosystem(&rpm.Ecosystem{}, args)
+	},
+	semver.Name: func(args []string) output {
+		return runEcosystem(&semver.Ecosystem{}, args)
+	},
+}
+
+// output is the result of a CLI command
+type output struct {
+	// text is printed on success and, outside JSON mode, on failure.
+	text string
+	// code is the exit code.
+	code int
+	// err is set when the command failed; text is then its message.
+	err error
+	// noMatch is set when a contains check found no match.
+	noMatch bool
+}
+
+// success returns the output of a command that succeeded
+func success(text string) output {
+	return output{text: text}
+}
+
+// failure returns the output of a command that failed with err
+func failure(code int, err error) output {
+	return output{text: err.Error(), code: code, err: err}
+}
+
+// flags are the global options accepted before the ecosystem or spec
+type flags struct {
+	// json writes errors as {"error": {...}} objects.
+	json bool
+	// quiet writes nothing and reports results through the exit code only:
+	// 0 for success or a match, 1 for no match and 2 for any error.
+	quiet bool
+}
+
+// parseFlags consumes the leading global options from args
+func parseFlags(args []string) (flags, []string, error) {
+	var f flags
+	for len(args) > 0 && strings.HasPrefix(args[0], "--") {
+		switch args[0] {
+		case "--json":
+			f.json = true
+		case "--quiet":
+			f.quiet = true
+		default:
+			return f, nil, fmt.Errorf("Unknown option: %s", args[0])
+		}
+		args = args[1:]
+	}
+	return f, args, nil
+}
+
 // run is the main entry point for the CLI
 func run(w io.Writer, args []string) int {
+	f, args, err := parseFlags(args)
+	if err != nil {
+		return f.write(w, failure(1, err))
+	}
+	return f.write(w, dispatch(args))
+}
+
+// write prints out as selected by the flags and returns the exit code
+func (f flags) write(w io.Writer, out output) int {
+	switch {
+	case f.quiet:
+		switch {
+		case out.err != nil || out.code != 0:
+			return 2
+		case out.noMatch:
+			return 1
+		default:
+			return 0
+		}
+	case f.json && out.err != nil:
+		fmt.Fprintf(w, "%s\n", univers.ErrorJSON(out.err))
+	default:
+		fmt.Fprintf(w, "%s\n", out.text)
+	}
+	return out.code
+}
+
+// dispatch runs the spec, top-level or ecosystem command named by args[0]
+func dispatch(args []string) output {
 	if len(args) == 0 {
-		s := "Usage: univers <ecosystem|spec> <command> [args]"
-		fmt.Fprintf(w, "%s\n", s)
-		return 1
+		return failure(1, errors.New("Usage: univers [--json] [--quiet] <ecosystem|spec> <command> [args]"))
 	}
 
-	// Handle spec commands first
-	specToRun := map[string]func([]string) (string, int){
-		"vers": runVers,
+	// Handle spec and top-level commands first
+	specToRun := map[string]func([]string) output{
+		"vers":       runVers,
+		"contains":   runContains,
+		"detect":     runDetect,
+		"ecosystems": runEcosystems,
+		"completion": runCompletion,
+		"report":     runReport,
 	}
 
 	if fn, ok := specToRun[args[0]]; ok {
-		out, code := fn(args[1:])
-		fmt.Fprintf(w, "%s\n", out)
-		return code
-	}
-
-	ecosystemToRun := map[string]func([]string) (string, int){
-		alpine.Name: func(args []string) (string, int) {
-			return runEcosystem(&alpine.Ecosystem{}, args)
-		},
-		alpm.Name: func(args []string) (string, int) {
-			return runEcosystem(&alpm.Ecosystem{}, args)
-		},
-		apache.Name: func(args []string) (string, int) {
-			return runEcosystem(&apache.Ecosystem{}, args)
-		},
-		cargo.Name: func(args []string) (string, int) {
-			return runEcosystem(&cargo.Ecosystem{}, args)
-		},
-		conan.Name: func(args []string) (string, int) {
-			return runEcosystem(&conan.Ecosystem{}, args)
-		},
-		composer.Name: func(args []string) (string, int) {
-			return runEcosystem(&composer.Ecosystem{}, args)
-		},
-		cran.Name: func(args []string) (string, int) {
-			return runEcosystem(&cran.Ecosystem{}, args)
-		},
-		debian.Name: func(args []string) (string, int) {
-			return runEcosystem(&debian.Ecosystem{}, args)
-		},
-		gem.Name: func(args []string) (string, int) {
-			return runEcosystem(&gem.Ecosystem{}, args)
-		},
-		gentoo.Name: func(args []string) (string, int) {
-			return runEcosystem(&gentoo.Ecosystem{}, args)
-		},
-		github.Name: func(args []string) (string, int) {
-			return runEcosystem(&github.Ecosystem{}, args)
-		},
-		golang.Name: func(args []string) (string, int) {
-			return runEcosystem(&golang.Ecosystem{}, args)
-		},
-		hex.Name: func(args []string) (string, int) {
-			return runEcosystem(&hex.Ecosystem{}, args)
-		},
-		mattermost.Name: func(args []string) (string, int) {
-			return runEcosystem(&mattermost.Ecosystem{}, args)
-		},
-		maven.Name: func(args []string) (string, int) {
-			return runEcosystem(&maven.Ecosystem{}, args)
-		},
-		npm.Name: func(args []string) (string, int) {
-			return runEcosystem(&npm.Ecosystem{}, args)
-		},
-		nuget.Name: func(args []string) (string, int) {
-			return runEcosystem(&nuget.Ecosystem{}, args)
-		},
-		pypi.Name: func(args []string) (string, int) {
-			return runEcosystem(&pypi.Ecosystem{}, args)
-		},
-		rpm.Name: func(args []string) (string, int) {
-			return runEcosystem(&rpm.Ecosystem{}, args)
-		},
-		semver.Name: func(args []string) (string, int) {
-			return runEcosystem(&semver.Ecosystem{}, args)
-		},
-	}
-
-	if fn, ok := ecosystemToRun[args[0]]; ok {
-		out, code := fn(args[1:])
-		fmt.Fprintf(w, "%s\n", out)
-		return code
-	}
-
-	s := fmt.Sprintf("Unknown ecosystem: %s", args[0])
-	fmt.Fprintf(w, "%s\n", s)
-	return 1
+		return fn(args[1:])
+	}
+
+	name := args[0]
+	if canonical, ok := univers.ParseEcosystemName(name); ok {
+		name = string(canonical)
+	}
+
+	if fn, ok := ecosystemToRun[name]; ok {
+		return fn(args[1:])
+	}
+
+	// RPM comparison profiles are selected as sub-schemes, such as "rpm:suse"
+	if strings.HasPrefix(name, rpm.Name+":") {
+		e, err := rpm.SchemeEcosystem(name)
+		if err != nil {
+			return failure(1, err)
+		}
+		return runEcosystem(e, args[1:])
+	}
+
+	// Fall back to ecosystems registered through univers.RegisterEcosystem
+	if e, ok := univers.LookupEcosystem(name); ok {
+		return runEcosystem(e, args[1:])
+	}
+
+	return failure(1, fmt.Errorf("Unknown ecosystem: %s", args[0]))
 }
 
 func runEcosystem[V univers.Version[V], VR univers.VersionRange[V]](
 	e univers.Ecosystem[V, VR],
 	args []string,
-) (string, int) {
+) output {
 	if len(args) == 0 {
-		s := fmt.Sprintf("No command specified for %s", e.Name())
-		return s, 1
+		return failure(1, fmt.Errorf("No command specified for %s", e.Name()))
 	}
 
 	command := args[0]
 	commandArgs := args[1:]
 
 	var result string
+	var noMatch bool
 	var err error
 	switch command {
 	case "compare":
@@ -151,23 +278,30 @@ func runEcosystem[V univers.Version[V], VR univers.VersionRange[V]](
 		var out bool
 		out, err = contains(e, commandArgs)
 		result = fmt.Sprintf("%t", out)
+		noMatch = !out
+	case "features":
+		var out []string
+		out, err = features(e, commandArgs)
+		result = strings.Join(out, "\n")
+	case "parse":
+		var out []string
+		out, err = parse(e, commandArgs)
+		result = strings.Join(out, "\n")
 	default:
-		s := fmt.Sprintf("Unknown %s command: %s", e.Name(), command)
-		return s, 1
+		return failure(1, fmt.Errorf("Unknown %s command: %s", e.Name(), command))
 	}
 
 	if err != nil {
-		s := fmt.Sprintf("Error running command '%s': %v", command, err)
-		return s, 1
+		return failure(1, fmt.Errorf("Error running command '%s': %w", command, err))
 	}
 
-	return result, 0
+	return output{text: result, noMatch: noMatch}
 }
 
 // runVers handles 'vers' spec commands
-func runVers(args []string) (string, int) {
+func runVers(args []string) output {
 	if len(args) == 0 {
-		return "Usage: univers vers <command> [args]", 1
+		return failure(1, errors.New("Usage: univers vers <command> [args]"))
 	}
 
 	command := args[0]
@@ -177,10 +311,200 @@ func runVers(args []string) (string, int) {
 	case "contains":
 		out, err := versContains(commandArgs)
 		if err != nil {
-			return fmt.Sprintf("Error running command 'vers %s': %v", command, err), 1
+			return failure(1, fmt.Errorf("Error running command 'vers %s': %w", command, err))
 		}
-		return fmt.Sprintf("%t", out), 0
+		return output{text: fmt.Sprintf("%t", out), noMatch: !out}
+	case "eval":
+		return runVersEval(commandArgs)
 	default:
-		return fmt.Sprintf("Unknown vers command: %s. Supported commands: contains", command), 1
+		return failure(1, fmt.Errorf("Unknown vers command: %s. Supported commands: contains, eval", command))
+	}
+}
+
+// versEvalUsage is printed when 'vers eval' is given the wrong arguments
+const versEvalUsage = "Usage: univers vers eval --input <file|-> [--format csv|ndjson] [--strict]"
+
+// runVersEval handles the 'vers eval' command. The format defaults to csv
+// for .csv files and ndjson otherwise, and "-" reads standard input. The exit
+// code is 1 if any record could not be evaluated. --strict makes records
+// with a probable versioning scheme mismatch errors.
+func runVersEval(args []string) output {
+	var input, format string
+	var strict bool
+	for len(args) > 0 {
+		if args[0] == "--strict" {
+			strict = true
+			args = args[1:]
+			continue
+		}
+		if len(args) < 2 {
+			return failure(1, errors.New(versEvalUsage))
+		}
+		switch args[0] {
+		case "--input":
+			input = args[1]
+		case "--format":
+			format = args[1]
+		default:
+			return failure(1, errors.New(versEvalUsage))
+		}
+		args = args[2:]
+	}
+	if input == "" {
+		return failure(1, errors.New(versEvalUsage))
+	}
+	if format == "" {
+		format = "ndjson"
+		if strings.EqualFold(filepath.Ext(input), ".csv") {
+			format = "csv"
+		}
+	}
+
+	r := io.Reader(os.Stdin)
+	if input != "-" {
+		f, err := os.Open(input)
+		if err != nil {
+			return failure(1, fmt.Errorf("Error running command 'vers eval': %w", err))
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var b strings.Builder
+	summary, err := versEval(r, format, strict, &b)
+	if err != nil {
+		return failure(1, fmt.Errorf("Error running command 'vers eval': %w", err))
+	}
+	b.WriteString(summary.String())
+
+	out := output{text: b.String(), noMatch: summary.affected == 0}
+	if summary.errors > 0 {
+		out.code = 1
+	}
+	return out
+}
+
+// vercmpUsage mirrors the usage text printed by pacman's vercmp(8)
+const vercmpUsage = `Usage: univers alpm vercmp [--ignore-pkgrel] <ver1> <ver2>
+
+Output values:
+  < 0 : if ver1 < ver2
+    0 : if ver1 == ver2
+  > 0 : if ver1 > ver2`
+
+// runVercmp handles the 'alpm vercmp' command. Output and exit codes follow
+// pacman's vercmp: no arguments exit 2, --help exits 0, a wrong argument
+// count exits 1, and any two versions compare successfully.
+func runVercmp(args []string) output {
+	if len(args) == 0 {
+		return failure(2, errors.New(vercmpUsage))
+	}
+	if args[0] == "-h" || args[0] == "--help" {
+		return success(vercmpUsage)
+	}
+
+	cmp := alpm.Vercmp
+	if args[0] == "--ignore-pkgrel" {
+		cmp = alpm.VercmpIgnoringPkgrel
+		args = args[1:]
+	}
+	if len(args) != 2 {
+		return failure(1, fmt.Errorf("error: %d argument(s) specified\n\n%s", len(args), vercmpUsage))
+	}
+
+	return success(fmt.Sprintf("%d", cmp(args[0], args[1])))
+}
+
+// runDetect handles the 'detect' command
+func runDetect(args []string) output {
+	if len(args) != 1 {
+		return failure(1, errors.New("Usage: univers detect <version-or-range>"))
+	}
+
+	out, err := detect(args)
+	if err != nil {
+		return failure(1, fmt.Errorf("Error running command 'detect': %w", err))
+	}
+	return success(strings.Join(out, "\n"))
+}
+
+// containsUsage is the usage message of the top-level 'contains' command
+const containsUsage = "Usage: univers contains --purl <purl> <range>"
+
+// runContains handles the top-level 'contains' command, which takes the
+// ecosystem and the version from a package URL such as
+// pkg:npm/lodash@4.17.21 and runs the ecosystem's contains command.
+// --ecosystem-from-purl is the long form of --purl.
+func runContains(args []string) output {
+	if len(args) != 3 || (args[0] != "--purl" && args[0] != "--ecosystem-from-purl") {
+		return failure(1, errors.New(containsUsage))
+	}
+
+	p, err := purl.Parse(args[1])
+	if err != nil {
+		return failure(1, fmt.Errorf("Error running command 'contains': %w", err))
+	}
+	if p.Version == "" {
+		return failure(1, fmt.Errorf("Error running command 'contains': purl %q has no version", args[1]))
+	}
+	name, ok := p.Ecosystem()
+	if !ok {
+		return failure(1, fmt.Errorf("Error running command 'contains': no ecosystem for purl type %q", p.Type))
+	}
+	return dispatch([]string{string(name), "contains", args[2], p.Version})
+}
+
+// reportUsage is the usage message of the 'report' command
+const reportUsage = "Usage: univers report [--ecosystems <name,...>] compare <version1> <version2>"
+
+// runReport handles the 'report' command
+func runReport(args []string) output {
+	var ecosystems []string
+	if len(args) > 0 && args[0] == "--ecosystems" {
+		if len(args) < 2 {
+			return failure(1, errors.New(reportUsage))
+		}
+		for name := range strings.SplitSeq(args[1], ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				ecosystems = append(ecosystems, name)
+			}
+		}
+		args = args[2:]
+	}
+	if len(args) == 0 {
+		return failure(1, errors.New(reportUsage))
+	}
+
+	switch args[0] {
+	case "compare":
+		rows, err := compareReport(ecosystems, args[1:])
+		if err != nil {
+			return failure(1, fmt.Errorf("Error running command 'report compare': %w", err))
+		}
+		return success(renderReport(rows, args[1], args[2]))
+	default:
+		return failure(1, fmt.Errorf("Unknown report command: %s. Supported commands: compare", args[0]))
+	}
+}
+
+// runEcosystems handles the 'ecosystems' command
+func runEcosystems(args []string) output {
+	if len(args) != 0 {
+		return failure(1, errors.New("Usage: univers ecosystems"))
+	}
+
+	return success(strings.Join(listEcosystems(), "\n"))
+}
+
+// runCompletion handles the 'completion' command
+func runCompletion(args []string) output {
+	if len(args) != 1 {
+		return failure(1, errors.New("Usage: univers completion <bash|zsh|fish>"))
+	}
+
+	out, err := completion(args[0])
+	if err != nil {
+		return failure(1, fmt.Errorf("Error running command 'completion': %w", err))
 	}
+	return success(out)
 }
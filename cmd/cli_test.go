@@ -104,7 +104,7 @@ func TestRun_Private(t *testing.T) {
 		{
 			name:     "no arguments",
 			args:     []string{},
-			wantOut:  "Usage: univers <ecosystem|spec> <command> [args]",
+			wantOut:  "Usage: univers [--json] [--quiet] <ecosystem|spec> <command> [args]",
 			wantCode: 1,
 		},
 		{
@@ -113,6 +113,24 @@ func TestRun_Private(t *testing.T) {
 			wantOut:  "Unknown ecosystem: unknown",
 			wantCode: 1,
 		},
+		{
+			name:     "rpm profile compare",
+			args:     []string{"rpm:suse", "compare", "2.4.1-bp155.3.3.1", "2.4.1-bp154.3.3.1"},
+			wantOut:  "0",
+			wantCode: 0,
+		},
+		{
+			name:     "rpm without profile compare",
+			args:     []string{"rpm", "compare", "2.4.1-bp155.3.3.1", "2.4.1-bp154.3.3.1"},
+			wantOut:  "1",
+			wantCode: 0,
+		},
+		{
+			name:     "rpm unknown profile",
+			args:     []string{"rpm:fedora", "compare", "1.0-1", "1.0-1"},
+			wantOut:  `invalid RPM scheme "rpm:fedora": unknown profile "fedora", want one of [amzn suse]`,
+			wantCode: 1,
+		},
 		{
 			name:     "npm ecosystem no command",
 			args:     []string{"npm"},
@@ -185,6 +203,66 @@ func TestRun_Private(t *testing.T) {
 			wantOut:  "Error running command 'sort': invalid version 'invalid': invalid NPM version: invalid",
 			wantCode: 1,
 		},
+		{
+			name:     "semver features",
+			args:     []string{"semver", "features"},
+			wantOut:  "comparison\t>=1.2.3\tOrdering operators >=, >, <=, < and =\nnot-equal\t!=1.2.4\tExcludes a single version\nexact\t1.2.3\tA bare version matches only itself\nand\t>=1.0.0 <2.0.0\tSpace- or comma-separated constraints must all match\nwildcard\t*\tMatches every version",
+			wantCode: 0,
+		},
+		{
+			name:     "npm features with args",
+			args:     []string{"npm", "features", "extra"},
+			wantOut:  "Error running command 'features': features takes no arguments",
+			wantCode: 1,
+		},
+		{
+			name:     "alpm vercmp less",
+			args:     []string{"alpm", "vercmp", "1.0rc", "1.0"},
+			wantOut:  "-1",
+			wantCode: 0,
+		},
+		{
+			name:     "arch vercmp with epoch",
+			args:     []string{"arch", "vercmp", "1:1.0", "2.0-1"},
+			wantOut:  "1",
+			wantCode: 0,
+		},
+		{
+			name:     "alpm vercmp mixed pkgrel",
+			args:     []string{"alpm", "vercmp", "1.5-1", "1.5"},
+			wantOut:  "0",
+			wantCode: 0,
+		},
+		{
+			name:     "alpm vercmp input NewVersion rejects",
+			args:     []string{"alpm", "vercmp", "1.0~rc1", "1.0"},
+			wantOut:  "1",
+			wantCode: 0,
+		},
+		{
+			name:     "alpm vercmp ignore pkgrel",
+			args:     []string{"alpm", "vercmp", "--ignore-pkgrel", "1.5-1", "1.5-2"},
+			wantOut:  "0",
+			wantCode: 0,
+		},
+		{
+			name:     "alpm vercmp no args",
+			args:     []string{"alpm", "vercmp"},
+			wantOut:  vercmpUsage,
+			wantCode: 2,
+		},
+		{
+			name:     "alpm vercmp help",
+			args:     []string{"alpm", "vercmp", "--help"},
+			wantOut:  vercmpUsage,
+			wantCode: 0,
+		},
+		{
+			name:     "alpm vercmp wrong arg count",
+			args:     []string{"alpm", "vercmp", "1.0"},
+			wantOut:  "error: 1 argument(s) specified\n\n" + vercmpUsage,
+			wantCode: 1,
+		},
 		{
 			name:     "npm contains success true",
 			args:     []string{"npm", "contains", "^1.0.0", "1.5.0"},
@@ -198,11 +276,53 @@ func TestRun_Private(t *testing.T) {
 			wantCode: 0,
 		},
 		{
-			name:     "npm contains invalid range",
-			args:     []string{"npm", "contains", "invalid", "1.0.0"},
+			name:     "contains purl true",
+			args:     []string{"contains", "--purl", "pkg:npm/lodash@4.17.21", "^4.17.0"},
+			wantOut:  "true",
+			wantCode: 0,
+		},
+		{
+			name:     "contains purl false",
+			args:     []string{"contains", "--ecosystem-from-purl", "pkg:pypi/django@5.0", ">=4.2,<5.0"},
 			wantOut:  "false",
 			wantCode: 0,
 		},
+		{
+			name:     "contains purl type alias",
+			args:     []string{"contains", "--purl", "pkg:deb/debian/curl@7.88.1-10%2Bdeb12u5?arch=amd64", ">= 7.88.1-10"},
+			wantOut:  "true",
+			wantCode: 0,
+		},
+		{
+			name:     "contains purl without version",
+			args:     []string{"contains", "--purl", "pkg:npm/lodash", "^4.17.0"},
+			wantOut:  "Error running command 'contains': purl \"pkg:npm/lodash\" has no version",
+			wantCode: 1,
+		},
+		{
+			name:     "contains purl unsupported type",
+			args:     []string{"contains", "--purl", "pkg:generic/openssl@3.0.0", ">=3.0.0"},
+			wantOut:  "Error running command 'contains': no ecosystem for purl type \"generic\"",
+			wantCode: 1,
+		},
+		{
+			name:     "contains purl invalid",
+			args:     []string{"contains", "--purl", "npm/lodash@4.17.21", "^4.17.0"},
+			wantOut:  "Error running command 'contains': invalid purl \"npm/lodash@4.17.21\": missing pkg: scheme",
+			wantCode: 1,
+		},
+		{
+			name:     "contains without purl",
+			args:     []string{"contains", "^4.17.0", "4.17.21"},
+			wantOut:  containsUsage,
+			wantCode: 1,
+		},
+		{
+			name:     "npm contains invalid range",
+			args:     []string{"npm", "contains", "invalid", "1.0.0"},
+			wantOut:  "Error running command 'contains': invalid range 'invalid': parsing \"invalid\" at position 0 (\"invalid\"): invalid NPM version: invalid",
+			wantCode: 1,
+		},
 		{
 			name:     "npm contains invalid version",
 			args:     []string{"npm", "contains", "^1.0.0", "invalid"},
@@ -365,6 +485,42 @@ func TestRun_Private(t *testing.T) {
 			wantOut:  "Error running command 'contains': invalid version 'invalid': invalid Ruby Gem version: invalid",
 			wantCode: 1,
 		},
+		{
+			name:     "opam contrib sort success",
+			args:     []string{"opam", "sort", "5.0.0", "5.0.0~beta1", "4.14.1"},
+			wantOut:  "\"4.14.1\" \"5.0.0~beta1\" \"5.0.0\"",
+			wantCode: 0,
+		},
+		{
+			name:     "opam contrib contains success",
+			args:     []string{"opam", "contains", ">= \"4.08\" & < \"5.0\"", "4.14.1"},
+			wantOut:  "true",
+			wantCode: 0,
+		},
+		{
+			name:     "ecosystem alias",
+			args:     []string{"deb", "compare", "1.0-1", "1.0-2"},
+			wantOut:  "-1",
+			wantCode: 0,
+		},
+		{
+			name:     "ecosystems unexpected args",
+			args:     []string{"ecosystems", "npm"},
+			wantOut:  "Usage: univers ecosystems",
+			wantCode: 1,
+		},
+		{
+			name:     "completion no shell",
+			args:     []string{"completion"},
+			wantOut:  "Usage: univers completion <bash|zsh|fish>",
+			wantCode: 1,
+		},
+		{
+			name:     "completion unsupported shell",
+			args:     []string{"completion", "powershell"},
+			wantOut:  "Error running command 'completion': unsupported shell \"powershell\" (supported: bash, fish, zsh)",
+			wantCode: 1,
+		},
 		{
 			name:     "vers no command",
 			args:     []string{"vers"},
@@ -374,7 +530,7 @@ func TestRun_Private(t *testing.T) {
 		{
 			name:     "vers unknown command",
 			args:     []string{"vers", "unknown"},
-			wantOut:  "Unknown vers command: unknown. Supported commands: contains",
+			wantOut:  "Unknown vers command: unknown. Supported commands: contains, eval",
 			wantCode: 1,
 		},
 		{
@@ -416,7 +572,175 @@ func TestRun_Private(t *testing.T) {
 		{
 			name:     "vers contains wrong number of args",
 			args:     []string{"vers", "contains", "vers:maven/>=1.0.0"},
-			wantOut:  "Error running command 'vers contains': contains requires exactly 2 arguments: <vers-range> <version>",
+			wantOut:  "Error running command 'vers contains': contains requires exactly 2 arguments: [--strict] <vers-range> <version>",
+			wantCode: 1,
+		},
+		{
+			name:     "vers contains strict",
+			args:     []string{"vers", "contains", "--strict", "vers:pypi/>=1.2.0|<=2.0.0", "1.5.0"},
+			wantOut:  "true",
+			wantCode: 0,
+		},
+		{
+			name:     "vers contains strict scheme mismatch",
+			args:     []string{"vers", "contains", "--strict", "vers:rpm/>=1.0", "1.0.0-rc1"},
+			wantOut:  "Error running command 'vers contains': strict mode: version \"1.0.0-rc1\" is written as a SemVer pre-release, which rpm reads differently; it may belong to another versioning scheme",
+			wantCode: 1,
+		},
+		{
+			name:     "vers eval no input",
+			args:     []string{"vers", "eval"},
+			wantOut:  "Usage: univers vers eval --input <file|-> [--format csv|ndjson] [--strict]",
+			wantCode: 1,
+		},
+		{
+			name:     "vers eval unknown option",
+			args:     []string{"vers", "eval", "--input", "findings.ndjson", "--verbose"},
+			wantOut:  "Usage: univers vers eval --input <file|-> [--format csv|ndjson] [--strict]",
+			wantCode: 1,
+		},
+		{
+			name:     "vers eval missing file",
+			args:     []string{"vers", "eval", "--input", "testdata/missing.ndjson"},
+			wantOut:  "Error running command 'vers eval': open testdata/missing.ndjson: no such file or directory",
+			wantCode: 1,
+		},
+		{
+			name:     "generic-win compare coerces build label",
+			args:     []string{"generic-win", "compare", "10.0.19041.1288 (WinBuild.160101.0800)", "10.0.19041.1415"},
+			wantOut:  "-1",
+			wantCode: 0,
+		},
+		{
+			name:     "unknown option",
+			args:     []string{"--verbose", "npm", "compare", "1.0.0", "2.0.0"},
+			wantOut:  "Unknown option: --verbose",
+			wantCode: 1,
+		},
+		{
+			name:     "json success output unchanged",
+			args:     []string{"--json", "npm", "contains", "^1.0.0", "1.5.0"},
+			wantOut:  "true",
+			wantCode: 0,
+		},
+		{
+			name:     "json parse error",
+			args:     []string{"--json", "cargo", "contains", ">=1.0.0, <bad", "1.5.0"},
+			wantOut:  `{"error":{"message":"Error running command 'contains': invalid range '>=1.0.0, <bad': parsing \">=1.0.0, <bad\" at position 9 (\"<bad\"): invalid version in < constraint: invalid Cargo version: bad","input":">=1.0.0, <bad","pos":9,"token":"<bad"}}`,
+			wantCode: 1,
+		},
+		{
+			name:     "json usage error",
+			args:     []string{"--json", "npm"},
+			wantOut:  `{"error":{"message":"No command specified for npm"}}`,
+			wantCode: 1,
+		},
+		{
+			name:     "json unknown ecosystem",
+			args:     []string{"--json", "unknown"},
+			wantOut:  `{"error":{"message":"Unknown ecosystem: unknown"}}`,
+			wantCode: 1,
+		},
+		{
+			name:     "quiet contains match",
+			args:     []string{"--quiet", "npm", "contains", "^1.0.0", "1.5.0"},
+			wantOut:  "",
+			wantCode: 0,
+		},
+		{
+			name:     "quiet contains no match",
+			args:     []string{"--quiet", "npm", "contains", "^1.0.0", "2.5.0"},
+			wantOut:  "",
+			wantCode: 1,
+		},
+		{
+			name:     "quiet contains error",
+			args:     []string{"--quiet", "npm", "contains", "^1.0.0", "invalid"},
+			wantOut:  "",
+			wantCode: 2,
+		},
+		{
+			name:     "quiet vers contains no match",
+			args:     []string{"--quiet", "vers", "contains", "vers:npm/>=1.2.0|<=2.0.0", "3.0.0"},
+			wantOut:  "",
+			wantCode: 1,
+		},
+		{
+			name:     "quiet compare success",
+			args:     []string{"--quiet", "npm", "compare", "2.0.0", "1.0.0"},
+			wantOut:  "",
+			wantCode: 0,
+		},
+		{
+			name:     "quiet usage error",
+			args:     []string{"--quiet"},
+			wantOut:  "",
+			wantCode: 2,
+		},
+		{
+			name:     "quiet overrides json",
+			args:     []string{"--json", "--quiet", "npm", "contains", "^1.0.0", "invalid"},
+			wantOut:  "",
+			wantCode: 2,
+		},
+		{
+			name:     "parse version and range",
+			args:     []string{"npm", "parse", "1.2.3"},
+			wantOut:  "version\t1.2.3\nrange\t1.2.3",
+			wantCode: 0,
+		},
+		{
+			name:     "parse normalizes range",
+			args:     []string{"npm", "parse", "^1.2.3"},
+			wantOut:  "range\t>=1.2.3 <2.0.0-0",
+			wantCode: 0,
+		},
+		{
+			name:     "parse traced range",
+			args:     []string{"semver", "parse", ">=1.0.0, <2.0.0"},
+			wantOut:  "range\t>=1.0.0 <2.0.0",
+			wantCode: 0,
+		},
+		{
+			name:     "parse invalid",
+			args:     []string{"npm", "parse", "~~1"},
+			wantOut:  "Error running command 'parse': '~~1' is not a valid npm version or range: parsing \"~~1\" at position 0 (\"~~1\"): invalid NPM version: ~1",
+			wantCode: 1,
+		},
+		{
+			name:     "detect usage",
+			args:     []string{"detect"},
+			wantOut:  "Usage: univers detect <version-or-range>",
+			wantCode: 1,
+		},
+		{
+			name:     "detect no ecosystem",
+			args:     []string{"detect", ""},
+			wantOut:  "Error running command 'detect': no ecosystem parses ''",
+			wantCode: 1,
+		},
+		{
+			name:     "report compare",
+			args:     []string{"report", "--ecosystems", "npm,maven", "compare", "1.0.0-rc1", "1.0.0"},
+			wantOut:  "ECOSYSTEM  RESULT\nnpm        1.0.0-rc1 < 1.0.0\nmaven      1.0.0-rc1 < 1.0.0",
+			wantCode: 0,
+		},
+		{
+			name:     "report usage",
+			args:     []string{"report", "--ecosystems"},
+			wantOut:  "Usage: univers report [--ecosystems <name,...>] compare <version1> <version2>",
+			wantCode: 1,
+		},
+		{
+			name:     "report unknown command",
+			args:     []string{"report", "sort"},
+			wantOut:  "Unknown report command: sort. Supported commands: compare",
+			wantCode: 1,
+		},
+		{
+			name:     "report unknown ecosystem",
+			args:     []string{"report", "--ecosystems", "bogus", "compare", "1.0", "2.0"},
+			wantOut:  "Error running command 'report compare': unknown ecosystem 'bogus'",
 			wantCode: 1,
 		},
 	}
@@ -1,8 +1,16 @@
 package main
 
 import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/alowayed/go-univers/pkg/spec/vers"
 	"github.com/alowayed/go-univers/pkg/univers"
@@ -31,7 +39,13 @@ func compare[V univers.Version[V], VR univers.VersionRange[V]](
 		return 0, err
 	}
 
-	return verl.Compare(verr), nil
+	switch {
+	case univers.Before(verl, verr):
+		return -1, nil
+	case univers.After(verl, verr):
+		return 1, nil
+	}
+	return 0, nil
 }
 
 func sort[V univers.Version[V], VR univers.VersionRange[V]](
@@ -85,14 +99,328 @@ func contains[V univers.Version[V], VR univers.VersionRange[V]](
 	return r.Contains(v), nil
 }
 
-// versContains implements the "vers contains" command
+// features implements the "features" command, listing the range syntax an
+// ecosystem declares as one "name<TAB>syntax<TAB>description" line per feature
+func features[V univers.Version[V], VR univers.VersionRange[V]](
+	e univers.Ecosystem[V, VR],
+	args []string,
+) ([]string, error) {
+	if len(args) != 0 {
+		return nil, fmt.Errorf("features takes no arguments")
+	}
+
+	f, ok := any(e).(univers.Featurer)
+	if !ok {
+		return nil, fmt.Errorf("%s does not declare its range features", e.Name())
+	}
+
+	lines := make([]string, 0, len(f.Features()))
+	for _, feature := range f.Features() {
+		lines = append(lines, fmt.Sprintf("%s\t%s\t%s", feature.Name, feature.Syntax, feature.Description))
+	}
+
+	return lines, nil
+}
+
+// parse implements the "parse" command, printing a "version<TAB>form" line if
+// the input parses as a version and a "range<TAB>form" line if it parses as a
+// range. The form is the ecosystem's normalized rendering where it has one,
+// such as npm's desugared comparators, and the input as written otherwise.
+func parse[V univers.Version[V], VR univers.VersionRange[V]](
+	e univers.Ecosystem[V, VR],
+	args []string,
+) ([]string, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("parse requires exactly 1 argument: <version-or-range>")
+	}
+
+	input := args[0]
+	var lines []string
+	v, versionErr := e.NewVersion(input)
+	if versionErr == nil {
+		lines = append(lines, "version\t"+canonicalVersion(v))
+	}
+	r, rangeErr := e.NewVersionRange(input)
+	if rangeErr == nil {
+		lines = append(lines, "range\t"+canonicalRange(r))
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("'%s' is not a valid %s version or range: %w", input, e.Name(), rangeErr)
+	}
+
+	return lines, nil
+}
+
+// canonicalVersion returns the normalized form of a version, if the
+// ecosystem defines one
+func canonicalVersion(v fmt.Stringer) string {
+	if n, ok := v.(interface{ Normal() string }); ok {
+		return n.Normal()
+	}
+	return strings.TrimSpace(v.String())
+}
+
+// canonicalRange returns the normalized form of a range, falling back to its
+// traced constraints and then to the range as written
+func canonicalRange(r fmt.Stringer) string {
+	switch r := r.(type) {
+	case interface{ Normalize() string }:
+		return r.Normalize()
+	case interface{ TraceConstraints() [][]string }:
+		groups := make([]string, 0, len(r.TraceConstraints()))
+		for _, group := range r.TraceConstraints() {
+			groups = append(groups, strings.Join(group, " "))
+		}
+		return strings.Join(groups, " || ")
+	}
+	return strings.TrimSpace(r.String())
+}
+
+// detect implements the "detect" command, running "parse" in every ecosystem
+// and returning an "ecosystem<TAB>kind<TAB>form" line for each result
+func detect(args []string) ([]string, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("detect requires exactly 1 argument: <version-or-range>")
+	}
+
+	var lines []string
+	for _, name := range ecosystemNames() {
+		out := dispatch([]string{name, "parse", args[0]})
+		if out.err != nil {
+			continue
+		}
+		for line := range strings.SplitSeq(out.text, "\n") {
+			lines = append(lines, name+"\t"+line)
+		}
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("no ecosystem parses '%s'", args[0])
+	}
+
+	return lines, nil
+}
+
+// reportRow is one ecosystem's result in a comparison report
+type reportRow struct {
+	ecosystem string
+	cmp       int
+	err       error
+}
+
+// compareReport implements the "report compare" command, comparing two
+// versions in each of the named ecosystems, or in every ecosystem when none
+// are named. A version an ecosystem rejects is reported in its row.
+func compareReport(ecosystems []string, args []string) ([]reportRow, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("compare requires exactly 2 version arguments")
+	}
+	if len(ecosystems) == 0 {
+		ecosystems = ecosystemNames()
+	}
+
+	rows := make([]reportRow, 0, len(ecosystems))
+	for _, name := range ecosystems {
+		if canonical, ok := univers.ParseEcosystemName(name); ok {
+			name = string(canonical)
+		}
+		if _, ok := ecosystemToRun[name]; !ok {
+			if _, ok := univers.LookupEcosystem(name); !ok {
+				return nil, fmt.Errorf("unknown ecosystem '%s'", name)
+			}
+		}
+
+		row := reportRow{ecosystem: name}
+		out := dispatch([]string{name, "compare", args[0], args[1]})
+		if out.err != nil {
+			// Drop the "Error running command" prefix added by runEcosystem
+			row.err = out.err
+			if inner := errors.Unwrap(out.err); inner != nil {
+				row.err = inner
+			}
+		} else if row.cmp, row.err = strconv.Atoi(out.text); row.err != nil {
+			return nil, fmt.Errorf("unexpected %s compare output %q", name, out.text)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// renderReport renders a comparison report of versions a and b as an aligned
+// table with a row per ecosystem, e.g. "npm  1.0.0-rc1 < 1.0.0"
+func renderReport(rows []reportRow, a, b string) string {
+	var sb strings.Builder
+	tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ECOSYSTEM\tRESULT")
+	for _, row := range rows {
+		if row.err != nil {
+			fmt.Fprintf(tw, "%s\terror: %v\n", row.ecosystem, row.err)
+			continue
+		}
+		symbol := "="
+		switch {
+		case row.cmp < 0:
+			symbol = "<"
+		case row.cmp > 0:
+			symbol = ">"
+		}
+		fmt.Fprintf(tw, "%s\t%s %s %s\n", row.ecosystem, a, symbol, b)
+	}
+	tw.Flush()
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// versContains implements the "vers contains" command. With a leading
+// --strict, a version or range that vers.CheckCompatibility warns about is an
+// error rather than a result.
 func versContains(args []string) (bool, error) {
+	strict := len(args) > 0 && args[0] == "--strict"
+	if strict {
+		args = args[1:]
+	}
 	if len(args) != 2 {
-		return false, fmt.Errorf("contains requires exactly 2 arguments: <vers-range> <version>")
+		return false, fmt.Errorf("contains requires exactly 2 arguments: [--strict] <vers-range> <version>")
 	}
 
 	versRange := args[0]
 	version := args[1]
 
+	if strict {
+		if err := checkStrict(versRange, version); err != nil {
+			return false, err
+		}
+	}
 	return vers.Contains(versRange, version)
 }
+
+// checkStrict returns an error listing the warnings of
+// vers.CheckCompatibility, if there are any
+func checkStrict(versRange, version string) error {
+	warnings, err := vers.CheckCompatibility(versRange, version)
+	if err != nil || len(warnings) == 0 {
+		return err
+	}
+	msgs := make([]string, 0, len(warnings))
+	for _, w := range warnings {
+		msgs = append(msgs, w.Message)
+	}
+	return fmt.Errorf("strict mode: %s", strings.Join(msgs, "; "))
+}
+
+// evalRecord is one finding read by "vers eval"
+type evalRecord struct {
+	Vers    string `json:"vers"`
+	Version string `json:"version"`
+}
+
+// evalSummary counts the results of "vers eval"
+type evalSummary struct {
+	records, affected, unaffected, errors int
+}
+
+// String returns the summary line printed after the results
+func (s evalSummary) String() string {
+	return fmt.Sprintf("summary\t%d records\t%d affected\t%d unaffected\t%d errors", s.records, s.affected, s.unaffected, s.errors)
+}
+
+// versEval implements the "vers eval" command. It reads findings from r in
+// the given format, "csv" with a header naming the vers and version columns
+// or "ndjson" with one {"vers": ..., "version": ...} object per line, and
+// writes a "line<TAB>result<TAB>vers<TAB>version" line to w for each as it is
+// read. The result is true, false or error, and an error line ends with the
+// reason. Only a failure to read the input stops the evaluation. In strict
+// mode, records that vers.CheckCompatibility warns about are errors.
+func versEval(r io.Reader, format string, strict bool, w io.Writer) (evalSummary, error) {
+	var s evalSummary
+	eval := func(line int, rec evalRecord, err error) {
+		s.records++
+		if err == nil && strict {
+			err = checkStrict(rec.Vers, rec.Version)
+		}
+		if err == nil {
+			var out bool
+			if out, err = vers.Contains(rec.Vers, rec.Version); err == nil {
+				if out {
+					s.affected++
+				} else {
+					s.unaffected++
+				}
+				fmt.Fprintf(w, "%d\t%t\t%s\t%s\n", line, out, rec.Vers, rec.Version)
+				return
+			}
+		}
+		s.errors++
+		fmt.Fprintf(w, "%d\terror\t%s\t%s\t%v\n", line, rec.Vers, rec.Version, err)
+	}
+
+	switch format {
+	case "csv":
+		return s, evalCSV(r, eval)
+	case "ndjson":
+		return s, evalNDJSON(r, eval)
+	}
+	return s, fmt.Errorf("unknown format %q (supported: csv, ndjson)", format)
+}
+
+// evalCSV passes each record of a CSV file with vers and version columns to eval
+func evalCSV(r io.Reader, eval func(int, evalRecord, error)) error {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return fmt.Errorf("reading CSV header: %w", err)
+	}
+	versCol, versionCol := -1, -1
+	for i, name := range header {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "vers":
+			versCol = i
+		case "version":
+			versionCol = i
+		}
+	}
+	if versCol < 0 || versionCol < 0 {
+		return fmt.Errorf("CSV header must name vers and version columns")
+	}
+
+	for {
+		fields, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			eval(parseErr.Line, evalRecord{}, err)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		line, _ := cr.FieldPos(0)
+		if versCol >= len(fields) || versionCol >= len(fields) {
+			eval(line, evalRecord{}, fmt.Errorf("record has %d fields, want vers and version", len(fields)))
+			continue
+		}
+		eval(line, evalRecord{Vers: fields[versCol], Version: fields[versionCol]}, nil)
+	}
+}
+
+// evalNDJSON passes each non-blank line of an NDJSON file to eval
+func evalNDJSON(r io.Reader, eval func(int, evalRecord, error)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var rec evalRecord
+		err := json.Unmarshal([]byte(text), &rec)
+		eval(line, rec, err)
+	}
+	return scanner.Err()
+}
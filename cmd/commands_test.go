@@ -1,6 +1,8 @@
 package main
 
 import (
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/alowayed/go-univers/pkg/ecosystem/golang"
@@ -453,7 +455,7 @@ func TestContains(t *testing.T) {
 			name:    "npm invalid range",
 			args:    []string{"invalid", "1.0.0"},
 			wantOut: false,
-			wantErr: false,
+			wantErr: true,
 		},
 		{
 			name:    "npm invalid version",
@@ -481,7 +483,7 @@ func TestContains(t *testing.T) {
 			name:    "pypi invalid range",
 			args:    []string{"invalid", "1.0.0"},
 			wantOut: false,
-			wantErr: false,
+			wantErr: true,
 		},
 	}
 
@@ -629,3 +631,255 @@ func TestContains(t *testing.T) {
 		testContains(t, &maven.Ecosystem{}, mavenTests)
 	})
 }
+
+func TestListEcosystems(t *testing.T) {
+	tests := []struct {
+		name     string
+		wantLine string
+	}{
+		{
+			name:     "core ecosystem without aliases",
+			wantLine: "npm",
+		},
+		{
+			name:     "core ecosystem with aliases",
+			wantLine: "debian (aliases: deb)",
+		},
+		{
+			name:     "registered contrib ecosystem",
+			wantLine: "opam",
+		},
+	}
+
+	got := listEcosystems()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !slices.Contains(got, tt.wantLine) {
+				t.Errorf("listEcosystems() = %v, want line %q", got, tt.wantLine)
+			}
+		})
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantLines []string
+		notLines  []string
+	}{
+		{
+			name:      "npm caret range",
+			input:     "^1.2.3",
+			wantLines: []string{"npm\trange\t>=1.2.3 <2.0.0-0", "cargo\trange\t^1.2.3"},
+			notLines:  []string{"npm\tversion\t^1.2.3", "pypi\trange\t^1.2.3"},
+		},
+		{
+			name:      "windows file version",
+			input:     "10.0.19041.1 (WinBuild.160101.0800)",
+			wantLines: []string{"generic-win\tversion\t10.0.19041.1 (WinBuild.160101.0800)"},
+			notLines:  []string{"msver\tversion\t10.0.19041.1 (WinBuild.160101.0800)"},
+		},
+		{
+			name:      "registered contrib ecosystem",
+			input:     "4.14.1",
+			wantLines: []string{"opam\tversion\t4.14.1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := detect([]string{tt.input})
+			if err != nil {
+				t.Fatalf("detect(%q) error = %v", tt.input, err)
+			}
+			if !slices.IsSortedFunc(got, func(a, b string) int {
+				return strings.Compare(strings.SplitN(a, "\t", 2)[0], strings.SplitN(b, "\t", 2)[0])
+			}) {
+				t.Errorf("detect(%q) = %v, want lines sorted by ecosystem", tt.input, got)
+			}
+			for _, line := range tt.wantLines {
+				if !slices.Contains(got, line) {
+					t.Errorf("detect(%q) = %v, want line %q", tt.input, got, line)
+				}
+			}
+			for _, line := range tt.notLines {
+				if slices.Contains(got, line) {
+					t.Errorf("detect(%q) = %v, want no line %q", tt.input, got, line)
+				}
+			}
+		})
+	}
+}
+
+func TestCompareReport(t *testing.T) {
+	tests := []struct {
+		name       string
+		ecosystems []string
+		args       []string
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:       "prerelease across schemes",
+			ecosystems: []string{"npm", "debian", "pypi"},
+			args:       []string{"1.0.0-rc1", "1.0.0"},
+			want: "ECOSYSTEM  RESULT\n" +
+				"npm        1.0.0-rc1 < 1.0.0\n" +
+				"debian     1.0.0-rc1 > 1.0.0\n" +
+				"pypi       1.0.0-rc1 < 1.0.0",
+		},
+		{
+			name:       "aliases and registered ecosystems",
+			ecosystems: []string{"deb", "opam"},
+			args:       []string{"1.0", "1.0"},
+			want: "ECOSYSTEM  RESULT\n" +
+				"debian     1.0 = 1.0\n" +
+				"opam       1.0 = 1.0",
+		},
+		{name: "unknown ecosystem", ecosystems: []string{"npm", "bogus"}, args: []string{"1.0.0", "2.0.0"}, wantErr: true},
+		{name: "wrong argument count", args: []string{"1.0.0"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := compareReport(tt.ecosystems, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("compareReport() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := renderReport(rows, tt.args[0], tt.args[1]); got != tt.want {
+				t.Errorf("renderReport() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompletion(t *testing.T) {
+	tests := []struct {
+		name     string
+		shell    string
+		wantSubs []string
+		wantErr  bool
+	}{
+		{
+			name:     "bash",
+			shell:    "bash",
+			wantSubs: []string{"complete -F _univers univers", "npm", "opam", "deb", "compare contains features parse sort", "detect"},
+		},
+		{
+			name:     "zsh",
+			shell:    "zsh",
+			wantSubs: []string{"#compdef univers", "npm", "opam", "deb", "compare contains features parse sort", "detect"},
+		},
+		{
+			name:     "fish",
+			shell:    "fish",
+			wantSubs: []string{"complete -c univers", "npm", "opam", "deb", "compare contains features parse sort", "detect"},
+		},
+		{
+			name:    "unsupported shell",
+			shell:   "powershell",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := completion(tt.shell)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("completion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, sub := range tt.wantSubs {
+				if !strings.Contains(got, sub) {
+					t.Errorf("completion(%q) missing %q", tt.shell, sub)
+				}
+			}
+		})
+	}
+}
+
+func TestVersEval(t *testing.T) {
+	tests := []struct {
+		name        string
+		format      string
+		strict      bool
+		input       string
+		wantOut     string
+		wantSummary evalSummary
+		wantErr     bool
+	}{
+		{
+			name:   "ndjson",
+			format: "ndjson",
+			input: `{"vers": "vers:npm/>=1.2.0|<2.0.0", "version": "1.5.0"}
+
+{"vers": "vers:pypi/<1.0", "version": "2.0", "id": "GHSA-1"}
+{"vers": "vers:npm/>=1.2.0", "version": "bogus"}
+not json
+`,
+			wantOut: "1\ttrue\tvers:npm/>=1.2.0|<2.0.0\t1.5.0\n" +
+				"3\tfalse\tvers:pypi/<1.0\t2.0\n" +
+				"4\terror\tvers:npm/>=1.2.0\tbogus\tinvalid npm version 'bogus': invalid NPM version: bogus\n" +
+				"5\terror\t\t\tinvalid character 'o' in literal null (expecting 'u')\n",
+			wantSummary: evalSummary{records: 4, affected: 1, unaffected: 1, errors: 2},
+		},
+		{
+			name:   "csv",
+			format: "csv",
+			input: `id,version,vers
+GHSA-1,1.5.0,vers:npm/>=1.2.0|<2.0.0
+GHSA-2,2.0
+GHSA-3,3.0.0,"vers:npm/<2.0.0"
+`,
+			wantOut: "2\ttrue\tvers:npm/>=1.2.0|<2.0.0\t1.5.0\n" +
+				"3\terror\t\t\trecord has 2 fields, want vers and version\n" +
+				"4\tfalse\tvers:npm/<2.0.0\t3.0.0\n",
+			wantSummary: evalSummary{records: 3, affected: 1, unaffected: 1, errors: 1},
+		},
+		{
+			name:   "strict",
+			format: "ndjson",
+			strict: true,
+			input: `{"vers": "vers:npm/>=1.2.0|<2.0.0", "version": "1.5.0"}
+{"vers": "vers:pypi/<1.0", "version": "0.9.0-beta.x"}
+`,
+			wantOut: "1\ttrue\tvers:npm/>=1.2.0|<2.0.0\t1.5.0\n" +
+				"2\terror\tvers:pypi/<1.0\t0.9.0-beta.x\tstrict mode: version \"0.9.0-beta.x\" is not a valid pypi version; it is valid in: alpine, cargo, deb, gem, generic, golang, luarocks, maven, npm, nuget, rpm\n",
+			wantSummary: evalSummary{records: 2, affected: 1, errors: 1},
+		},
+		{
+			name:    "csv without columns",
+			format:  "csv",
+			input:   "id,range\nGHSA-1,>=1.0\n",
+			wantErr: true,
+		},
+		{
+			name:    "unknown format",
+			format:  "yaml",
+			input:   "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			got, err := versEval(strings.NewReader(tt.input), tt.format, tt.strict, &b)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("versEval() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if b.String() != tt.wantOut {
+				t.Errorf("versEval() wrote %q, want %q", b.String(), tt.wantOut)
+			}
+			if got != tt.wantSummary {
+				t.Errorf("versEval() = %+v, want %+v", got, tt.wantSummary)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+var (
+	// topLevelCommands are the non-ecosystem first arguments accepted by the CLI
+	topLevelCommands = []string{"completion", "contains", "detect", "ecosystems", "report", "vers"}
+	// ecosystemCommands are the commands accepted by every ecosystem
+	ecosystemCommands = []string{"compare", "contains", "features", "parse", "sort"}
+	// versCommands are the commands accepted by the 'vers' spec
+	versCommands = []string{"contains", "eval"}
+	// containsFlags are the options of the top-level 'contains' command
+	containsFlags = []string{"--ecosystem-from-purl", "--purl"}
+	// completionShells are the shells supported by the 'completion' command
+	completionShells = []string{"bash", "fish", "zsh"}
+)
+
+// ecosystemNames returns the sorted names of all core and registered ecosystems
+func ecosystemNames() []string {
+	names := slices.Collect(maps.Keys(ecosystemToRun))
+	for _, name := range univers.RegisteredEcosystems() {
+		if _, ok := ecosystemToRun[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// listEcosystems implements the "ecosystems" command. Each line holds an
+// ecosystem name followed by its aliases, if any.
+func listEcosystems() []string {
+	var lines []string
+	for _, name := range ecosystemNames() {
+		line := name
+		if aliases := univers.EcosystemName(name).Aliases(); len(aliases) > 0 {
+			line += " (aliases: " + strings.Join(aliases, ", ") + ")"
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// completion implements the "completion" command, generating a completion
+// script for the given shell from the ecosystem registry.
+func completion(shell string) (string, error) {
+	// Candidates for the first argument: commands, ecosystems, and aliases
+	first := slices.Concat(topLevelCommands, ecosystemNames(), slices.Collect(maps.Keys(univers.EcosystemAliases())))
+	slices.Sort(first)
+	first = slices.Compact(first)
+
+	switch shell {
+	case "bash":
+		return bashCompletion(first), nil
+	case "zsh":
+		return zshCompletion(first), nil
+	case "fish":
+		return fishCompletion(first), nil
+	default:
+		return "", fmt.Errorf("unsupported shell %q (supported: %s)", shell, strings.Join(completionShells, ", "))
+	}
+}
+
+func bashCompletion(first []string) string {
+	var b strings.Builder
+	b.WriteString("# bash completion for univers\n")
+	b.WriteString("_univers() {\n")
+	b.WriteString("    local cur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
+	b.WriteString("    if [[ ${COMP_CWORD} -eq 1 ]]; then\n")
+	fmt.Fprintf(&b, "        COMPREPLY=($(compgen -W %q -- \"$cur\"))\n", strings.Join(first, " "))
+	b.WriteString("        return\n")
+	b.WriteString("    fi\n")
+	b.WriteString("    if [[ ${COMP_CWORD} -eq 2 ]]; then\n")
+	b.WriteString("        case \"${COMP_WORDS[1]}\" in\n")
+	fmt.Fprintf(&b, "            completion) COMPREPLY=($(compgen -W %q -- \"$cur\")) ;;\n", strings.Join(completionShells, " "))
+	fmt.Fprintf(&b, "            contains) COMPREPLY=($(compgen -W %q -- \"$cur\")) ;;\n", strings.Join(containsFlags, " "))
+	b.WriteString("            detect|ecosystems) COMPREPLY=() ;;\n")
+	fmt.Fprintf(&b, "            vers) COMPREPLY=($(compgen -W %q -- \"$cur\")) ;;\n", strings.Join(versCommands, " "))
+	fmt.Fprintf(&b, "            *) COMPREPLY=($(compgen -W %q -- \"$cur\")) ;;\n", strings.Join(ecosystemCommands, " "))
+	b.WriteString("        esac\n")
+	b.WriteString("    fi\n")
+	b.WriteString("}\n")
+	b.WriteString("complete -F _univers univers")
+	return b.String()
+}
+
+func zshCompletion(first []string) string {
+	var b strings.Builder
+	b.WriteString("#compdef univers\n")
+	b.WriteString("_univers() {\n")
+	b.WriteString("    if (( CURRENT == 2 )); then\n")
+	fmt.Fprintf(&b, "        compadd -- %s\n", strings.Join(first, " "))
+	b.WriteString("        return\n")
+	b.WriteString("    fi\n")
+	b.WriteString("    if (( CURRENT == 3 )); then\n")
+	b.WriteString("        case \"${words[2]}\" in\n")
+	fmt.Fprintf(&b, "            completion) compadd -- %s ;;\n", strings.Join(completionShells, " "))
+	fmt.Fprintf(&b, "            contains) compadd -- %s ;;\n", strings.Join(containsFlags, " "))
+	b.WriteString("            detect|ecosystems) ;;\n")
+	fmt.Fprintf(&b, "            vers) compadd -- %s ;;\n", strings.Join(versCommands, " "))
+	fmt.Fprintf(&b, "            *) compadd -- %s ;;\n", strings.Join(ecosystemCommands, " "))
+	b.WriteString("        esac\n")
+	b.WriteString("    fi\n")
+	b.WriteString("}\n")
+	b.WriteString("compdef _univers univers")
+	return b.String()
+}
+
+func fishCompletion(first []string) string {
+	var b strings.Builder
+	b.WriteString("# fish completion for univers\n")
+	b.WriteString("complete -c univers -f\n")
+	fmt.Fprintf(&b, "complete -c univers -n '__fish_is_nth_token 1' -a '%s'\n", strings.Join(first, " "))
+	fmt.Fprintf(&b, "complete -c univers -n '__fish_is_nth_token 2; and __fish_seen_subcommand_from completion' -a '%s'\n", strings.Join(completionShells, " "))
+	fmt.Fprintf(&b, "complete -c univers -n '__fish_is_nth_token 2; and __fish_seen_subcommand_from vers' -a '%s'\n", strings.Join(versCommands, " "))
+	fmt.Fprintf(&b, "complete -c univers -n '__fish_is_nth_token 2; and __fish_seen_subcommand_from contains' -a '%s'\n", strings.Join(containsFlags, " "))
+	fmt.Fprintf(&b, "complete -c univers -n '__fish_is_nth_token 2; and not __fish_seen_subcommand_from completion contains detect ecosystems vers' -a '%s'", strings.Join(ecosystemCommands, " "))
+	return b.String()
+}
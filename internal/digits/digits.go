@@ -0,0 +1,50 @@
+// Package digits holds the allocation-free checks on runs of ASCII digits
+// that the ecosystem ValidVersion functions share.
+package digits
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// maxInt is math.MaxInt in decimal, the longest number strconv.Atoi accepts
+var maxInt = strconv.Itoa(math.MaxInt)
+
+// Prefix returns the length of the leading run of ASCII digits in s
+func Prefix(s string) int {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return i
+}
+
+// FitsInt reports whether strconv.Atoi accepts the ASCII digit string s
+func FitsInt(s string) bool {
+	return AtMost(s, maxInt)
+}
+
+// AtMost reports whether the ASCII digit string s is a number no larger than
+// max, given in decimal without leading zeros
+func AtMost(s, max string) bool {
+	s = strings.TrimLeft(s, "0")
+	return len(s) < len(max) || (len(s) == len(max) && s <= max)
+}
+
+// FitsIntConcat reports whether strconv.Atoi accepts the ASCII digit string
+// a+b, without concatenating them
+func FitsIntConcat(a, b string) bool {
+	a = strings.TrimLeft(a, "0")
+	if a == "" {
+		return FitsInt(b)
+	}
+
+	if n := len(a) + len(b); n != len(maxInt) {
+		return n < len(maxInt)
+	}
+	if head := maxInt[:len(a)]; a != head {
+		return a < head
+	}
+	return b <= maxInt[len(a):]
+}
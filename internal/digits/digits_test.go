@@ -0,0 +1,54 @@
+package digits
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestFitsInt(t *testing.T) {
+	tests := []string{
+		"0", "7", "000123", maxInt, "0" + maxInt, maxInt + "0",
+		"9223372036854775808", "99999999999999999999",
+	}
+	for _, s := range tests {
+		_, err := strconv.Atoi(s)
+		if got, want := FitsInt(s), err == nil; got != want {
+			t.Errorf("FitsInt(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func TestFitsIntConcat(t *testing.T) {
+	tests := []struct{ a, b string }{
+		{"1", "23"},
+		{"", "42"},
+		{"000", "42"},
+		{maxInt[:5], maxInt[5:]},
+		{maxInt[:5], maxInt[5:] + "0"},
+		{"9", maxInt},
+		{"0", maxInt},
+	}
+	for _, tt := range tests {
+		_, err := strconv.Atoi(tt.a + tt.b)
+		if got, want := FitsIntConcat(tt.a, tt.b), err == nil; got != want {
+			t.Errorf("FitsIntConcat(%q, %q) = %v, want %v", tt.a, tt.b, got, want)
+		}
+	}
+}
+
+func TestPrefix(t *testing.T) {
+	tests := []struct {
+		s    string
+		want int
+	}{
+		{"", 0},
+		{"abc", 0},
+		{"123", 3},
+		{"12.3", 2},
+	}
+	for _, tt := range tests {
+		if got := Prefix(tt.s); got != tt.want {
+			t.Errorf("Prefix(%q) = %d, want %d", tt.s, got, tt.want)
+		}
+	}
+}
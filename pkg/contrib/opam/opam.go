@@ -0,0 +1,21 @@
+// Package opam provides functionality for working with opam (OCaml) package versions.
+//
+// opam is a contrib ecosystem: it is not wired into the core packages and instead
+// registers itself with univers.RegisterEcosystem when imported.
+package opam
+
+import "github.com/alowayed/go-univers/pkg/univers"
+
+const Name = "opam"
+
+type Ecosystem struct{}
+
+func (e *Ecosystem) Name() string {
+	return Name
+}
+
+func init() {
+	if err := univers.RegisterEcosystem(Name, &Ecosystem{}); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,34 @@
+package opam
+
+import (
+	"testing"
+
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+func TestEcosystem_Name(t *testing.T) {
+	e := &Ecosystem{}
+	want := "opam"
+	if got := e.Name(); got != want {
+		t.Errorf("Ecosystem.Name() = %v, want %v", got, want)
+	}
+}
+
+func TestEcosystem_Registered(t *testing.T) {
+	e, ok := univers.LookupEcosystem(Name)
+	if !ok {
+		t.Fatalf("LookupEcosystem(%q) not found", Name)
+	}
+
+	r, err := e.NewVersionRange(`>= "4.08" & < "5.0"`)
+	if err != nil {
+		t.Fatalf("NewVersionRange() error = %v", err)
+	}
+	v, err := e.NewVersion("4.14.1")
+	if err != nil {
+		t.Fatalf("NewVersion() error = %v", err)
+	}
+	if !r.Contains(v) {
+		t.Errorf("VersionRange.Contains() = false, want true")
+	}
+}
@@ -0,0 +1,158 @@
+package opam
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+// VersionRange represents an opam version formula such as {>= "4.08" & < "5.0"}.
+//
+// Constraints are joined with '&' (AND) and '|' (OR), where '&' binds tighter.
+// Versions may be quoted as in opam files or bare. Surrounding braces are optional.
+type VersionRange struct {
+	original         string
+	constraintGroups [][]*constraint // OR logic between groups, AND logic within groups
+}
+
+// constraint represents a single opam version constraint
+type constraint struct {
+	operator string
+	version  *Version
+}
+
+// features declares the range syntax accepted by NewVersionRange
+var features = []univers.Feature{
+	{Name: univers.FeatureComparison, Syntax: `>= "4.08"`, Description: "Ordering operators >=, >, <=, < and = against quoted or bare versions"},
+	{Name: univers.FeatureNotEqual, Syntax: `!= "4.09.0"`, Description: "Excludes a single version"},
+	{Name: univers.FeatureExact, Syntax: `"4.14.1"`, Description: "A version without an operator matches only itself"},
+	{Name: univers.FeatureAnd, Syntax: `{>= "4.08" & < "5.0"}`, Description: "Constraints joined with & must all match; braces are optional"},
+	{Name: univers.FeatureOr, Syntax: `< "4.08" | >= "5.0"`, Description: "Matches if any |-separated group matches; & binds tighter"},
+}
+
+// Features returns the range syntax features supported by NewVersionRange
+func (e *Ecosystem) Features() []univers.Feature {
+	return slices.Clone(features)
+}
+
+// NewVersionRange parses an opam version formula.
+func (e *Ecosystem) NewVersionRange(rangeStr string) (*VersionRange, error) {
+	if err := univers.CheckLength(rangeStr); err != nil {
+		return nil, err
+	}
+
+	trimmed := strings.TrimSpace(rangeStr)
+	if trimmed == "" {
+		return nil, univers.WrapParseError(rangeStr, fmt.Errorf("empty range string"))
+	}
+
+	formula := trimmed
+	if strings.HasPrefix(formula, "{") {
+		if !strings.HasSuffix(formula, "}") {
+			return nil, univers.WrapParseError(rangeStr, fmt.Errorf("unbalanced braces in range: %s", rangeStr))
+		}
+		formula = strings.TrimSpace(formula[1 : len(formula)-1])
+	}
+	if strings.ContainsAny(formula, "()") {
+		return nil, univers.WrapParseError(rangeStr, fmt.Errorf("parenthesized formulas are not supported: %s", rangeStr))
+	}
+
+	var groups [][]*constraint
+	for _, orPart := range strings.Split(formula, "|") {
+		var group []*constraint
+		for _, andPart := range strings.Split(orPart, "&") {
+			c, err := parseConstraint(e, strings.TrimSpace(andPart))
+			if err != nil {
+				return nil, univers.WrapParseError(rangeStr, univers.TokenError(strings.TrimSpace(andPart), err))
+			}
+			group = append(group, c)
+		}
+		groups = append(groups, group)
+	}
+
+	if err := univers.CheckConstraintGroups(groups); err != nil {
+		return nil, err
+	}
+
+	return &VersionRange{
+		original:         trimmed,
+		constraintGroups: groups,
+	}, nil
+}
+
+// parseConstraint parses a single constraint such as >= "1.2.0".
+func parseConstraint(e *Ecosystem, s string) (*constraint, error) {
+	if s == "" {
+		return nil, fmt.Errorf("empty constraint")
+	}
+
+	operator := "="
+	versionStr := s
+	// Check longer operators first
+	for _, op := range []string{">=", "<=", "!=", ">", "<", "="} {
+		if strings.HasPrefix(s, op) {
+			operator = op
+			versionStr = strings.TrimSpace(s[len(op):])
+			break
+		}
+	}
+	versionStr = strings.Trim(versionStr, `"`)
+
+	if versionStr == "" {
+		return nil, fmt.Errorf("missing version after operator %s", operator)
+	}
+
+	v, err := e.NewVersion(versionStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version in constraint %q: %w", s, err)
+	}
+
+	return &constraint{operator: operator, version: v}, nil
+}
+
+// String returns the string representation of the range
+func (r *VersionRange) String() string {
+	return r.original
+}
+
+// Contains checks if a version is within this range
+func (r *VersionRange) Contains(version *Version) bool {
+	// OR logic between groups: if ANY group is satisfied, return true
+	for _, group := range r.constraintGroups {
+		satisfied := true
+		for _, c := range group {
+			if !c.matches(version) {
+				satisfied = false
+				break
+			}
+		}
+		if satisfied {
+			return true
+		}
+	}
+	return false
+}
+
+// matches checks if the given version matches this constraint
+func (c *constraint) matches(version *Version) bool {
+	cmp := version.Compare(c.version)
+
+	switch c.operator {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,155 @@
+package opam
+
+import (
+	"testing"
+)
+
+func TestEcosystem_NewVersionRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{
+			name:  "bare version",
+			input: "1.0",
+		},
+		{
+			name:  "quoted constraint",
+			input: `>= "4.08"`,
+		},
+		{
+			name:  "braced formula",
+			input: `{>= "4.08" & < "5.0"}`,
+		},
+		{
+			name:  "or formula",
+			input: `< "4.0" | >= "4.08"`,
+		},
+		// Error cases
+		{
+			name:    "empty string",
+			input:   "",
+			wantErr: true,
+		},
+		{
+			name:    "missing version",
+			input:   ">=",
+			wantErr: true,
+		},
+		{
+			name:    "empty conjunct",
+			input:   `>= "1.0" &`,
+			wantErr: true,
+		},
+		{
+			name:    "unbalanced braces",
+			input:   `{>= "1.0"`,
+			wantErr: true,
+		},
+		{
+			name:    "parentheses unsupported",
+			input:   `(>= "1.0" | < "0.5") & != "0.3"`,
+			wantErr: true,
+		},
+	}
+
+	e := &Ecosystem{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := e.NewVersionRange(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Ecosystem.NewVersionRange() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.String() != tt.input {
+				t.Errorf("VersionRange.String() = %v, want %v", got.String(), tt.input)
+			}
+		})
+	}
+}
+
+func TestVersionRange_Contains(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		version  string
+		want     bool
+	}{
+		{
+			name:     "bare version matches",
+			rangeStr: "1.0",
+			version:  "1.0",
+			want:     true,
+		},
+		{
+			name:     "and formula inside",
+			rangeStr: `{>= "4.08" & < "5.0"}`,
+			version:  "4.14.1",
+			want:     true,
+		},
+		{
+			name:     "tilde prerelease sorts below upper bound",
+			rangeStr: `>= "4.08" & < "5.0"`,
+			version:  "5.0~beta1",
+			want:     true,
+		},
+		{
+			name:     "and formula above upper",
+			rangeStr: `>= "4.08" & < "5.0"`,
+			version:  "5.0",
+			want:     false,
+		},
+		{
+			name:     "or formula first branch",
+			rangeStr: `< "4.0" | >= "4.08"`,
+			version:  "3.12",
+			want:     true,
+		},
+		{
+			name:     "or formula gap",
+			rangeStr: `< "4.0" | >= "4.08"`,
+			version:  "4.05",
+			want:     false,
+		},
+		{
+			name:     "not equal",
+			rangeStr: `!= "1.2"`,
+			version:  "1.2",
+			want:     false,
+		},
+	}
+
+	e := &Ecosystem{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vr, err := e.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("Failed to parse range %s: %v", tt.rangeStr, err)
+			}
+
+			v, err := e.NewVersion(tt.version)
+			if err != nil {
+				t.Fatalf("Failed to parse version %s: %v", tt.version, err)
+			}
+
+			if got := vr.Contains(v); got != tt.want {
+				t.Errorf("VersionRange.Contains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEcosystem_Features(t *testing.T) {
+	e := &Ecosystem{}
+	for _, f := range e.Features() {
+		if _, err := e.NewVersionRange(f.Syntax); err != nil {
+			t.Errorf("Features() %s syntax %q does not parse: %v", f.Name, f.Syntax, err)
+		}
+	}
+}
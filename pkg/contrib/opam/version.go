@@ -0,0 +1,147 @@
+package opam
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+// versionPattern matches the characters opam allows in a version string.
+var versionPattern = regexp.MustCompile(`^[A-Za-z0-9_+.~-]+$`)
+
+// Version represents an opam package version.
+type Version struct {
+	original string
+}
+
+// NewVersion parses an opam version string.
+func (e *Ecosystem) NewVersion(version string) (*Version, error) {
+	if err := univers.CheckLength(version); err != nil {
+		return nil, err
+	}
+
+	trimmed := strings.TrimSpace(version)
+	if trimmed == "" {
+		return nil, fmt.Errorf("empty version string")
+	}
+
+	if !versionPattern.MatchString(trimmed) {
+		return nil, fmt.Errorf("invalid opam version: %s", version)
+	}
+
+	return &Version{original: trimmed}, nil
+}
+
+// String returns the original string representation of the version.
+func (v *Version) String() string {
+	return v.original
+}
+
+// Compare compares this version with another opam version.
+// Returns -1 if this < other, 0 if this == other, 1 if this > other.
+//
+// opam uses the Debian ordering algorithm without epochs or revisions: strings
+// are split into alternating non-digit and digit runs, digit runs compare
+// numerically and non-digit runs compare character by character where '~'
+// sorts before everything (including the end of the string) and letters sort
+// before other characters.
+func (v *Version) Compare(other *Version) int {
+	a, b := v.original, other.original
+	i, j := 0, 0
+
+	for i < len(a) || j < len(b) {
+		// Compare non-digit prefix
+		iStart := i
+		for i < len(a) && !isDigit(a[i]) {
+			i++
+		}
+		jStart := j
+		for j < len(b) && !isDigit(b[j]) {
+			j++
+		}
+		if c := compareNonDigits(a[iStart:i], b[jStart:j]); c != 0 {
+			return c
+		}
+
+		// Compare digit prefix
+		iStart = i
+		for i < len(a) && isDigit(a[i]) {
+			i++
+		}
+		jStart = j
+		for j < len(b) && isDigit(b[j]) {
+			j++
+		}
+		if c := compareDigits(a[iStart:i], b[jStart:j]); c != 0 {
+			return c
+		}
+	}
+
+	return 0
+}
+
+// compareNonDigits compares non-digit runs using opam character weights.
+func compareNonDigits(a, b string) int {
+	for i := range max(len(a), len(b)) {
+		aWeight, bWeight := 0, 0
+		if i < len(a) {
+			aWeight = charWeight(a[i])
+		}
+		if i < len(b) {
+			bWeight = charWeight(b[i])
+		}
+		if aWeight != bWeight {
+			if aWeight < bWeight {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// charWeight returns the sort weight of a character. The end of a string has weight 0.
+func charWeight(c byte) int {
+	switch {
+	case c == '~':
+		return -1
+	case (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+		return int(c)
+	default:
+		return int(c) + 256
+	}
+}
+
+// compareDigits compares digit runs numerically. A missing run counts as zero.
+func compareDigits(a, b string) int {
+	a = strings.TrimLeft(a, "0")
+	b = strings.TrimLeft(b, "0")
+
+	aNum, aErr := strconv.ParseUint(a, 10, 64)
+	bNum, bErr := strconv.ParseUint(b, 10, 64)
+	if (aErr == nil || a == "") && (bErr == nil || b == "") {
+		if aNum < bNum {
+			return -1
+		}
+		if aNum > bNum {
+			return 1
+		}
+		return 0
+	}
+
+	// Fallback for numbers that don't fit in uint64
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,154 @@
+package opam
+
+import (
+	"testing"
+)
+
+func TestEcosystem_NewVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    *Version
+		wantErr bool
+	}{
+		{
+			name:  "basic version",
+			input: "4.14.1",
+			want:  &Version{original: "4.14.1"},
+		},
+		{
+			name:  "tilde prerelease",
+			input: "5.0.0~beta1",
+			want:  &Version{original: "5.0.0~beta1"},
+		},
+		{
+			name:  "version with plus and underscore",
+			input: "v0.15.0+jst_1",
+			want:  &Version{original: "v0.15.0+jst_1"},
+		},
+		{
+			name:  "whitespace trimmed",
+			input: " 1.0 ",
+			want:  &Version{original: "1.0"},
+		},
+		// Error cases
+		{
+			name:    "empty string",
+			input:   "",
+			wantErr: true,
+		},
+		{
+			name:    "invalid characters",
+			input:   "1.0 beta",
+			wantErr: true,
+		},
+		{
+			name:    "quoted version",
+			input:   `"1.0"`,
+			wantErr: true,
+		},
+	}
+
+	e := &Ecosystem{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := e.NewVersion(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Ecosystem.NewVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if *got != *tt.want {
+				t.Errorf("Ecosystem.NewVersion() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersion_Compare(t *testing.T) {
+	tests := []struct {
+		name string
+		v1   string
+		v2   string
+		want int
+	}{
+		{
+			name: "equal versions",
+			v1:   "1.2.3",
+			v2:   "1.2.3",
+			want: 0,
+		},
+		{
+			name: "numeric comparison",
+			v1:   "4.9",
+			v2:   "4.10",
+			want: -1,
+		},
+		{
+			name: "leading zeros ignored",
+			v1:   "1.02",
+			v2:   "1.2",
+			want: 0,
+		},
+		{
+			name: "tilde sorts before release",
+			v1:   "5.0.0~beta1",
+			v2:   "5.0.0",
+			want: -1,
+		},
+		{
+			name: "tilde prereleases ordered",
+			v1:   "5.0.0~alpha1",
+			v2:   "5.0.0~beta1",
+			want: -1,
+		},
+		{
+			name: "letters sort before non-letters",
+			v1:   "1.0a",
+			v2:   "1.0+",
+			want: -1,
+		},
+		{
+			name: "longer version is greater",
+			v1:   "1.0",
+			v2:   "1.0.1",
+			want: -1,
+		},
+		{
+			name: "suffix after release is greater",
+			v1:   "1.0",
+			v2:   "1.0a",
+			want: -1,
+		},
+		{
+			name: "jane street style versions",
+			v1:   "v0.15.0",
+			v2:   "v0.16.0",
+			want: -1,
+		},
+	}
+
+	e := &Ecosystem{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v1, err := e.NewVersion(tt.v1)
+			if err != nil {
+				t.Fatalf("Failed to parse v1 %s: %v", tt.v1, err)
+			}
+			v2, err := e.NewVersion(tt.v2)
+			if err != nil {
+				t.Fatalf("Failed to parse v2 %s: %v", tt.v2, err)
+			}
+
+			if got := v1.Compare(v2); got != tt.want {
+				t.Errorf("Version.Compare() = %v, want %v", got, tt.want)
+			}
+			if got := v2.Compare(v1); got != -tt.want {
+				t.Errorf("Version.Compare() reversed = %v, want %v", got, -tt.want)
+			}
+		})
+	}
+}
@@ -1,11 +1,20 @@
 // Package alpine provides functionality for working with Alpine Linux package versions.
 package alpine
 
+import "github.com/alowayed/go-univers/pkg/univers"
+
 const (
-	Name = "alpine"
+	Name = string(univers.Alpine)
 )
 
-type Ecosystem struct{}
+type Ecosystem struct {
+	// MissingRevisionAsR0 compares a version without an -r revision as -r0,
+	// so 1.2.3 equals 1.2.3-r0, as some security feeds and scanners assume.
+	// By default it sorts before every revision, as apk does. The option
+	// applies to ranges parsed by the Ecosystem and to comparisons between
+	// versions that were both parsed with it.
+	MissingRevisionAsR0 bool
+}
 
 func (e *Ecosystem) Name() string {
 	return Name
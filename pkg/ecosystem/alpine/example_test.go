@@ -0,0 +1,51 @@
+package alpine_test
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/alpine"
+)
+
+// Suffixes such as _alpha and _rc sort before the release, _p after it, and
+// -r revisions break ties.
+func ExampleVersion_Compare() {
+	e := &alpine.Ecosystem{}
+
+	var versions []*alpine.Version
+	for _, s := range []string{"1.2.3_p1", "1.2.3-r1", "1.2.3_rc1", "1.2.3", "1.2.3_alpha2"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		versions = append(versions, v)
+	}
+	slices.SortFunc(versions, (*alpine.Version).Compare)
+	fmt.Println(versions)
+	// Output:
+	// [1.2.3_alpha2 1.2.3_rc1 1.2.3 1.2.3-r1 1.2.3_p1]
+}
+
+// Ranges are comparators separated by spaces.
+func ExampleVersionRange_Contains() {
+	e := &alpine.Ecosystem{}
+
+	r, err := e.NewVersionRange(">=1.2 <2.0")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, s := range []string{"1.2.3-r1", "2.0_rc1", "2.0-r0"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(s, r.Contains(v))
+	}
+	// Output:
+	// 1.2.3-r1 true
+	// 2.0_rc1 true
+	// 2.0-r0 false
+}
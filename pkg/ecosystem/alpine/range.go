@@ -2,56 +2,83 @@ package alpine
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 // VersionRange represents an Alpine version range with Alpine-specific syntax support
 type VersionRange struct {
 	constraints []*constraint
 	original    string
+
+	// revisionR0 compares a version without -r as -r0
+	revisionR0 bool
 }
 
 // constraint represents a single Alpine version constraint
 type constraint struct {
 	operator string
 	version  string
+	// parsed is the constraint version, or nil if it is not a valid
+	// version, in which case the constraint matches nothing
+	parsed *Version
+}
+
+// features declares the range syntax accepted by NewVersionRange
+var features = []univers.Feature{
+	{Name: univers.FeatureComparison, Syntax: ">=1.2.3-r0 <2.0", Description: "Ordering operators >=, >, <=, < and = against apk versions"},
+	{Name: univers.FeatureNotEqual, Syntax: "!=1.2.3-r1", Description: "Excludes a single version"},
+	{Name: univers.FeatureExact, Syntax: "1.2.3-r0", Description: "A bare version matches only itself"},
+	{Name: univers.FeatureAnd, Syntax: ">=1.2 <2.0", Description: "Space-separated constraints must all match"},
+	{Name: univers.FeatureFuzzy, Syntax: "~1.2", Description: "Matches versions starting with the given components, as apk ~ does"},
+}
+
+// Features returns the range syntax features supported by NewVersionRange
+func (e *Ecosystem) Features() []univers.Feature {
+	return slices.Clone(features)
 }
 
 // NewVersionRange creates a new Alpine version range from a range string
 func (e *Ecosystem) NewVersionRange(rangeStr string) (*VersionRange, error) {
+	if err := univers.CheckLength(rangeStr); err != nil {
+		return nil, err
+	}
+
 	original := rangeStr
 	rangeStr = strings.TrimSpace(rangeStr)
 	if rangeStr == "" {
-		return nil, fmt.Errorf("empty range string")
+		return nil, univers.WrapParseError(original, fmt.Errorf("empty range string"))
 	}
 
-	constraints, err := parseConstraints(rangeStr)
+	constraints, err := parseConstraints(rangeStr, e)
 	if err != nil {
+		return nil, univers.WrapParseError(original, err)
+	}
+
+	if err := univers.CheckConstraints(len(constraints)); err != nil {
 		return nil, err
 	}
 
 	return &VersionRange{
 		constraints: constraints,
 		original:    original,
+		revisionR0:  e.MissingRevisionAsR0,
 	}, nil
 }
 
 // parseConstraints parses Alpine constraint syntax
-func parseConstraints(rangeStr string) ([]*constraint, error) {
-	// Handle multiple constraints separated by spaces (AND logic)
-	parts := strings.Fields(rangeStr)
+func parseConstraints(rangeStr string, e *Ecosystem) ([]*constraint, error) {
+	// Handle multiple constraints separated by spaces (AND logic). An
+	// operator may be followed by a space, as in ">= 1.2.3".
 	var constraints []*constraint
-
-	for _, part := range parts {
-		part = strings.TrimSpace(part)
-		if part == "" {
-			continue
-		}
-
-		constraint, err := parseConstraint(part)
+	for _, f := range univers.ConstraintFields(rangeStr, nil) {
+		constraint, err := parseConstraint(f.Text)
 		if err != nil {
-			return nil, err
+			return nil, univers.TokenErrorAt(rangeStr, f.Offset, f.Text, err)
 		}
+		constraint.parsed, _ = e.NewVersion(constraint.version)
 		constraints = append(constraints, constraint)
 	}
 
@@ -66,8 +93,8 @@ func parseConstraints(rangeStr string) ([]*constraint, error) {
 func parseConstraint(constraintStr string) (*constraint, error) {
 	constraintStr = strings.TrimSpace(constraintStr)
 
-	// Alpine supports standard comparison operators
-	operators := []string{">=", "<=", "!=", ">", "<", "="}
+	// Alpine supports standard comparison operators plus the fuzzy operator (~)
+	operators := []string{">=", "<=", "!=", ">", "<", "=", "~"}
 	for _, op := range operators {
 		if strings.HasPrefix(constraintStr, op) {
 			version := strings.TrimSpace(constraintStr[len(op):])
@@ -87,13 +114,13 @@ func (vr *VersionRange) String() string {
 	return vr.original
 }
 
-// Contains checks if a version satisfies this range
+// Contains checks if a version satisfies this range. A version without -r
+// compares as -r0 if the range was parsed by an Ecosystem with
+// MissingRevisionAsR0 set, whatever the setting the version was parsed with.
 func (vr *VersionRange) Contains(version *Version) bool {
-	ecosystem := &Ecosystem{}
-
 	// All constraints must be satisfied (AND logic)
 	for _, c := range vr.constraints {
-		if !satisfiesConstraint(version, c, ecosystem) {
+		if !satisfiesConstraint(version, c, vr.revisionR0) {
 			return false
 		}
 	}
@@ -101,14 +128,24 @@ func (vr *VersionRange) Contains(version *Version) bool {
 	return true
 }
 
+// Comparators returns the constraints as a single AND group. The fuzzy "~"
+// operator has no comparator equivalent and is listed as is.
+func (vr *VersionRange) Comparators() [][]univers.Comparator {
+	group := make([]univers.Comparator, 0, len(vr.constraints))
+	for _, c := range vr.constraints {
+		group = append(group, univers.Comparator{Operator: c.operator, Version: c.version})
+	}
+	return [][]univers.Comparator{group}
+}
+
 // satisfiesConstraint checks if a version satisfies a single constraint
-func satisfiesConstraint(version *Version, c *constraint, ecosystem *Ecosystem) bool {
-	constraintVersion, err := ecosystem.NewVersion(c.version)
-	if err != nil {
+func satisfiesConstraint(version *Version, c *constraint, revisionR0 bool) bool {
+	constraintVersion := c.parsed
+	if constraintVersion == nil {
 		return false
 	}
 
-	cmp := version.Compare(constraintVersion)
+	cmp := version.compare(constraintVersion, revisionR0)
 
 	switch c.operator {
 	case "=":
@@ -123,7 +160,22 @@ func satisfiesConstraint(version *Version, c *constraint, ecosystem *Ecosystem)
 		return cmp < 0
 	case "<=":
 		return cmp <= 0
+	case "~":
+		return fuzzyMatches(version, constraintVersion)
 	default:
 		return false
 	}
 }
+
+// fuzzyMatches implements apk's fuzzy operator (~): the version matches when it
+// agrees with every component present in the constraint version, ignoring any
+// further components. For example ~1.2 matches 1.2, 1.2.3, 1.2a and 1.2-r1 but
+// not 1.20 or 1.3.
+func fuzzyMatches(version, c *Version) bool {
+	// String-only versions fall back to a prefix match
+	if version.numeric == nil || c.numeric == nil {
+		return strings.HasPrefix(version.original, c.original)
+	}
+
+	return fuzzyTokens(strings.TrimSpace(version.original), strings.TrimSpace(c.original))
+}
@@ -1,6 +1,7 @@
 package alpine
 
 import (
+	"fmt"
 	"testing"
 )
 
@@ -61,6 +62,14 @@ func TestEcosystem_NewVersionRange(t *testing.T) {
 				original:    "!=1.5.0",
 			},
 		},
+		{
+			name:  "fuzzy",
+			input: "~1.2",
+			want: &VersionRange{
+				constraints: []*constraint{{operator: "~", version: "1.2"}},
+				original:    "~1.2",
+			},
+		},
 		{
 			name:  "multiple constraints",
 			input: ">=1.0.0 <2.0.0",
@@ -167,6 +176,9 @@ func TestVersionRange_Contains(t *testing.T) {
 		{name: "gte satisfied equal", rangeStr: ">=1.2.3", version: "1.2.3", want: true},
 		{name: "gte satisfied greater", rangeStr: ">=1.2.3", version: "1.2.4", want: true},
 		{name: "gte not satisfied", rangeStr: ">=1.2.3", version: "1.2.2", want: false},
+		{name: "gte space after operator", rangeStr: ">= 1.2.3", version: "1.2.3", want: true},
+		{name: "space after operators in a range", rangeStr: ">= 1.2.3 <  2.0", version: "2.0", want: false},
+		{name: "fuzzy space after operator", rangeStr: "~ 1.2", version: "1.2.9-r1", want: true},
 
 		// Less than
 		{name: "less than satisfied", rangeStr: "<2.0.0", version: "1.9.9", want: true},
@@ -200,6 +212,28 @@ func TestVersionRange_Contains(t *testing.T) {
 		// Edge cases
 		{name: "release vs alpha", rangeStr: ">1.0.0_alpha", version: "1.0.0", want: true},
 		{name: "alpha vs release", rangeStr: ">1.0.0", version: "1.0.0_alpha", want: false},
+
+		// Pre-release suffixes
+		{name: "greater than pre satisfied by later pre", rangeStr: ">1.0_pre1", version: "1.0_pre2", want: true},
+		{name: "greater than pre satisfied by rc", rangeStr: ">1.0_pre1", version: "1.0_rc1", want: true},
+		{name: "greater than pre satisfied by release", rangeStr: ">1.0_pre1", version: "1.0", want: true},
+		{name: "greater than pre not satisfied by alpha", rangeStr: ">1.0_pre1", version: "1.0_alpha1", want: false},
+		{name: "greater than pre not satisfied by beta", rangeStr: ">1.0_pre1", version: "1.0_beta3", want: false},
+
+		// Fuzzy matching
+		{name: "fuzzy matches exact", rangeStr: "~1.2", version: "1.2", want: true},
+		{name: "fuzzy matches patch", rangeStr: "~1.2", version: "1.2.3", want: true},
+		{name: "fuzzy matches build", rangeStr: "~1.2", version: "1.2.3-r4", want: true},
+		{name: "fuzzy matches letter", rangeStr: "~1.2", version: "1.2a", want: true},
+		{name: "fuzzy matches suffix", rangeStr: "~1.2", version: "1.2_rc1", want: true},
+		{name: "fuzzy does not match longer component", rangeStr: "~1.2", version: "1.20", want: false},
+		{name: "fuzzy does not match next minor", rangeStr: "~1.2", version: "1.3.0", want: false},
+		{name: "fuzzy does not match shorter", rangeStr: "~1.2", version: "1", want: false},
+		{name: "fuzzy with suffix matches", rangeStr: "~1.2_rc1", version: "1.2_rc1-r3", want: true},
+		{name: "fuzzy with suffix does not match release", rangeStr: "~1.2_rc1", version: "1.2", want: false},
+		{name: "fuzzy with build matches", rangeStr: "~1.2.3-r1", version: "1.2.3-r1", want: true},
+		{name: "fuzzy with build does not match other build", rangeStr: "~1.2.3-r1", version: "1.2.3-r2", want: false},
+		{name: "fuzzy combined with exclusion", rangeStr: "~1.2 !=1.2.5", version: "1.2.5", want: false},
 	}
 
 	for _, tt := range tests {
@@ -221,3 +255,77 @@ func TestVersionRange_Contains(t *testing.T) {
 		})
 	}
 }
+
+func TestVersionRange_Contains_MissingRevisionAsR0(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		version  string
+		// plain parses version without the option; the range's setting
+		// still decides
+		plain bool
+		want  bool
+	}{
+		{name: "missing equals r0 constraint", rangeStr: "1.2.3-r0", version: "1.2.3", want: true},
+		{name: "r0 equals missing constraint", rangeStr: "1.2.3", version: "1.2.3-r0", want: true},
+		{name: "not greater than r0", rangeStr: ">1.2.3-r0", version: "1.2.3", want: false},
+		{name: "below r1", rangeStr: "<1.2.3-r1", version: "1.2.3", want: true},
+		{name: "version parsed without option", rangeStr: ">=1.2.3-r0", version: "1.2.3", plain: true, want: true},
+	}
+
+	e := &Ecosystem{MissingRevisionAsR0: true}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vr, err := e.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("NewVersionRange(%q) error: %v", tt.rangeStr, err)
+			}
+
+			versionEcosystem := e
+			if tt.plain {
+				versionEcosystem = &Ecosystem{}
+			}
+			v, err := versionEcosystem.NewVersion(tt.version)
+			if err != nil {
+				t.Fatalf("NewVersion(%q) error: %v", tt.version, err)
+			}
+
+			if got := vr.Contains(v); got != tt.want {
+				t.Errorf("VersionRange.Contains(%q in %q) = %t, want %t", tt.version, tt.rangeStr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEcosystem_Features(t *testing.T) {
+	e := &Ecosystem{}
+	for _, f := range e.Features() {
+		if _, err := e.NewVersionRange(f.Syntax); err != nil {
+			t.Errorf("Features() %s syntax %q does not parse: %v", f.Name, f.Syntax, err)
+		}
+	}
+}
+
+func TestVersionRange_Comparators(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		want     string
+	}{
+		{name: "comparisons", rangeStr: ">=1.2.3-r0 <2.0", want: "[[>=1.2.3-r0 <2.0]]"},
+		{name: "fuzzy", rangeStr: "~1.2", want: "[[~1.2]]"},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vr, err := e.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("Ecosystem.NewVersionRange() error = %v", err)
+			}
+			if got := fmt.Sprint(vr.Comparators()); got != tt.want {
+				t.Errorf("VersionRange.Comparators() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
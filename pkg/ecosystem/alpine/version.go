@@ -1,20 +1,20 @@
 package alpine
 
 import (
+	"cmp"
 	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/alowayed/go-univers/internal/digits"
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 // versionPattern matches Alpine version strings
 // Format: number{.number}...{letter}{_suffix{number}}...{~hash}{-r#}
 var versionPattern = regexp.MustCompile(`^(\d+(?:\.\d+)*)([a-z]?)((?:_[a-z]+\d*)*)(\~[a-f0-9]+)?(-r\d+)?$`)
 
-// unknownSuffixPrecedence is the precedence value assigned to unknown suffixes
-// Unknown suffixes are ordered after all known suffixes but before each other lexicographically
-const unknownSuffixPrecedence = 1000
-
 // Version represents an Alpine Linux package version
 type Version struct {
 	numeric  []numericComponent // numeric components: 1.2.3 (with leading zero info)
@@ -23,6 +23,9 @@ type Version struct {
 	hash     string             // commit hash: ~abc123...
 	build    int                // build component: -r1, -r2, etc.
 	original string             // original version string
+
+	// revisionR0 compares a version without -r as -r0
+	revisionR0 bool
 }
 
 // suffix represents a version suffix like _alpha1, _beta, etc.
@@ -31,23 +34,12 @@ type suffix struct {
 	number int    // optional number after suffix name
 }
 
-// Suffix precedence order (lower index = lower precedence)
-// Based on Alpine apk-tools version comparison logic
-var suffixOrder = map[string]int{
-	"alpha": 0,
-	"beta":  1,
-	"pre":   2,
-	"rc":    3,
-	"":      4, // no suffix (release)
-	"cvs":   5,
-	"svn":   6,
-	"git":   7,
-	"hg":    8,
-	"p":     9,
-}
-
 // NewVersion creates a new Alpine version from a string
 func (e *Ecosystem) NewVersion(version string) (*Version, error) {
+	if err := univers.CheckLength(version); err != nil {
+		return nil, err
+	}
+
 	original := version
 	version = strings.TrimSpace(version)
 
@@ -69,12 +61,13 @@ func (e *Ecosystem) NewVersion(version string) (*Version, error) {
 		// If version has digits but doesn't match standard pattern, create a special "string-only" version
 		// This handles cases like "1.0bc" mentioned in the test data comment "# invalid. do string sort"
 		return &Version{
-			numeric:  nil,
-			letter:   "",
-			suffixes: nil,
-			hash:     "",
-			build:    0,
-			original: original,
+			numeric:    nil,
+			letter:     "",
+			suffixes:   nil,
+			hash:       "",
+			build:      0,
+			original:   original,
+			revisionR0: e.MissingRevisionAsR0,
 		}, nil
 	}
 
@@ -113,15 +106,61 @@ func (e *Ecosystem) NewVersion(version string) (*Version, error) {
 	}
 
 	return &Version{
-		numeric:  numeric,
-		letter:   letterPart,
-		suffixes: suffixes,
-		hash:     hash,
-		build:    build,
-		original: original,
+		numeric:    numeric,
+		letter:     letterPart,
+		suffixes:   suffixes,
+		hash:       hash,
+		build:      build,
+		original:   original,
+		revisionR0: e.MissingRevisionAsR0,
 	}, nil
 }
 
+// ValidVersion reports whether NewVersion accepts version, without
+// allocating. Like NewVersion it accepts any string with a digit that does
+// not follow the apk version format, as such versions compare as strings.
+func ValidVersion(version string) bool {
+	if univers.CheckLength(version) != nil {
+		return false
+	}
+	version = strings.TrimSpace(version)
+	if version == "" {
+		return false
+	}
+	if !versionPattern.MatchString(version) {
+		return strings.ContainsAny(version, "0123456789")
+	}
+
+	// Every number but the hash must fit an int: the numeric components, the
+	// suffix numbers and the -r build
+	rest := version
+	for {
+		n := digits.Prefix(rest)
+		if !digits.FitsInt(rest[:n]) {
+			return false
+		}
+		rest = rest[n:]
+		if !strings.HasPrefix(rest, ".") {
+			break
+		}
+		rest = rest[1:]
+	}
+	const letters = "abcdefghijklmnopqrstuvwxyz"
+	rest = strings.TrimLeft(rest, letters)
+	for strings.HasPrefix(rest, "_") {
+		rest = strings.TrimLeft(rest[1:], letters)
+		n := digits.Prefix(rest)
+		if !digits.FitsInt(rest[:n]) {
+			return false
+		}
+		rest = rest[n:]
+	}
+	if i := strings.Index(rest, "-r"); i >= 0 {
+		return digits.FitsInt(rest[i+len("-r"):])
+	}
+	return true
+}
+
 // numericComponent represents a numeric component with leading zero information
 type numericComponent struct {
 	value       int    // The actual numeric value
@@ -177,8 +216,8 @@ func parseSuffixes(s string) ([]suffix, error) {
 		name := matches[1]
 		numberStr := matches[2]
 
-		// Allow unknown suffixes - they will be treated as having a very high precedence
-		// This handles cases like "_foo" which should be compared lexicographically
+		// Allow unknown suffixes such as "_foo": apk still compares them,
+		// ordering them below the release
 
 		number := 0
 		if numberStr != "" {
@@ -203,176 +242,244 @@ func (v *Version) String() string {
 	return v.original
 }
 
-// Compare compares this version with another Alpine version
+// Compare compares this version with another Alpine version using the
+// token comparison of apk-tools. Each version is read as a sequence of
+// numeric components, an optional letter, suffixes with optional numbers, an
+// optional commit hash and an optional -r revision, and the first differing
+// token decides. When one version runs out of tokens first, the longer one is
+// greater unless its next token is a pre-release suffix (_alpha, _beta, _pre
+// or _rc), so 1.0_p1 > 1.0 > 1.0_rc1 and 1.0_alpha_pre < 1.0_alpha.
+//
+// A version without -r sorts before every revision of it, as apk does, unless
+// both sides were parsed by an Ecosystem with MissingRevisionAsR0 set.
+// Versions parsed with different settings compare as apk does: applying the
+// option when only one side has it would not be transitive, as 1.0 would
+// equal 1.0-r0 but not a 1.0 parsed without it.
+//
+// Versions that do not follow the apk format, such as 1.0bc, sort after every
+// version that does, and compare with each other as strings, ignoring
+// surrounding whitespace. Comparing them as strings against apk versions
+// would not be transitive: 10.0 < 1.0bc by bytes, and 1.0bc < 9.0, but 9.0 <
+// 10.0.
 func (v *Version) Compare(other *Version) int {
-	// Handle invalid versions (no numeric components) - use string comparison
-	if v.numeric == nil || other.numeric == nil {
-		return strings.Compare(v.original, other.original)
-	}
-
-	// 1. Compare numeric components (leading zeros are ignored - use actual numeric values)
-	numericCmp := compareNumericArraysNumeric(v.numeric, other.numeric)
-	if numericCmp != 0 {
-		return numericCmp
-	}
+	return v.compare(other, v.revisionR0 && other.revisionR0)
+}
 
-	// 2. Compare letters (empty letter comes before any letter)
-	letterCmp := compareLetters(v.letter, other.letter)
-	if letterCmp != 0 {
-		return letterCmp
+// compare is Compare with the MissingRevisionAsR0 setting given explicitly
+func (v *Version) compare(other *Version, revisionR0 bool) int {
+	switch {
+	case v.numeric == nil && other.numeric == nil:
+		return strings.Compare(strings.TrimSpace(v.original), strings.TrimSpace(other.original))
+	case v.numeric == nil:
+		return 1
+	case other.numeric == nil:
+		return -1
 	}
+	return compareTokens(strings.TrimSpace(v.original), strings.TrimSpace(other.original), revisionR0)
+}
 
-	// 3. Compare suffixes
-	suffixCmp := compareSuffixArrays(v.suffixes, other.suffixes)
-	if suffixCmp != 0 {
-		return suffixCmp
-	}
+// apk version token kinds, in the order they may appear in a version. A
+// token may only be followed by a later kind, or restart a component.
+const (
+	tokenInvalid = iota - 1
+	tokenDigitOrZero
+	tokenDigit
+	tokenLetter
+	tokenSuffix
+	tokenSuffixNo
+	tokenHash
+	tokenRevisionNo
+	tokenEnd
+)
 
-	// 4. Compare hashes (lexicographically)
-	hashCmp := strings.Compare(v.hash, other.hash)
-	if hashCmp != 0 {
-		return hashCmp
-	}
+// Suffixes known to apk. Pre-release suffixes are valued below zero, in this
+// order, and post-release suffixes from zero up, so _rc1 < release < _cvs < _p.
+var (
+	preSuffixes  = []string{"alpha", "beta", "pre", "rc"}
+	postSuffixes = []string{"cvs", "svn", "git", "hg", "p"}
+)
 
-	// 5. Compare build components
-	return compareInt(v.build, other.build)
+// tokenizer reads the tokens of an apk version, porting get_token and
+// next_token from apk-tools
+type tokenizer struct {
+	s    string // the unread part of the version
+	kind int    // the kind of the next token
 }
 
-// compareLetters compares optional letters
-func compareLetters(a, b string) int {
-	// Empty letter (no letter) comes before any letter
-	if a == "" && b == "" {
-		return 0
-	}
-	if a == "" {
-		return -1
-	}
-	if b == "" {
-		return 1
+// next reads the next token, returning its value and, for a commit hash, its
+// text
+func (t *tokenizer) next() (int, string) {
+	if t.s == "" {
+		t.kind = tokenEnd
+		return 0, ""
 	}
 
-	// Lexical comparison for letters
-	return strings.Compare(a, b)
-}
-
-// compareSuffixArrays compares suffix arrays
-func compareSuffixArrays(a, b []suffix) int {
-	if len(a) == 0 && len(b) == 0 {
-		return 0
+	v, i, hash := 0, 0, ""
+	nextKind := tokenInvalid
+	switch t.kind {
+	case tokenDigitOrZero:
+		// Leading zeros after a dot are a token of their own, valued below any
+		// number and followed by the remaining digits, so 1.001 < 1.01 < 1.1
+		if t.s[0] == '0' {
+			for i < len(t.s) && t.s[i] == '0' {
+				i++
+			}
+			v = -i
+			nextKind = tokenDigit
+			break
+		}
+		fallthrough
+	case tokenDigit, tokenSuffixNo, tokenRevisionNo:
+		for i < len(t.s) && t.s[i] >= '0' && t.s[i] <= '9' {
+			v = v*10 + int(t.s[i]-'0')
+			i++
+		}
+	case tokenLetter:
+		v, i = int(t.s[0]), 1
+	case tokenSuffix:
+		var ok bool
+		if v, i, ok = suffixValue(t.s); !ok {
+			t.kind = tokenInvalid
+			return -1, ""
+		}
+	case tokenHash:
+		for i < len(t.s) && strings.IndexByte("0123456789abcdef", t.s[i]) >= 0 {
+			i++
+		}
+		hash = t.s[:i]
+	default:
+		t.kind = tokenInvalid
+		return -1, ""
 	}
 
-	// Handle cases where one side has no suffixes (release version)
-	// Compare against empty suffix (weight 4)
-	if len(a) == 0 {
-		// Compare release vs first suffix of b
-		return compareSuffixes(suffix{name: "", number: 0}, b[0])
-	}
-	if len(b) == 0 {
-		// Compare first suffix of a vs release
-		return compareSuffixes(a[0], suffix{name: "", number: 0})
+	t.s = t.s[i:]
+	switch {
+	case nextKind != tokenInvalid:
+		// The digits after the zeros, which read as 0 when there are none
+		t.kind = nextKind
+	case t.s == "":
+		t.kind = tokenEnd
+	default:
+		t.advance()
 	}
+	return v, hash
+}
 
-	// Compare suffix by suffix up to the minimum length
-	minLen := min(len(a), len(b))
-
-	for i := 0; i < minLen; i++ {
-		cmp := compareSuffixes(a[i], b[i])
-		if cmp != 0 {
-			return cmp
+// advance determines the kind of the next token, consuming its separator
+func (t *tokenizer) advance() {
+	n := tokenInvalid
+	c := t.s[0]
+	switch {
+	case (t.kind == tokenDigit || t.kind == tokenDigitOrZero) && c >= 'a' && c <= 'z':
+		n = tokenLetter
+	case t.kind == tokenLetter && c >= '0' && c <= '9':
+		n = tokenDigit
+	case t.kind == tokenSuffix && c >= '0' && c <= '9':
+		n = tokenSuffixNo
+	default:
+		switch c {
+		case '.':
+			n = tokenDigitOrZero
+		case '_':
+			n = tokenSuffix
+		case '~':
+			n = tokenHash
+		case '-':
+			if strings.HasPrefix(t.s, "-r") {
+				n = tokenRevisionNo
+				t.s = t.s[1:]
+			}
 		}
+		t.s = t.s[1:]
 	}
 
-	// If all compared suffixes are equal, the longer array is "smaller"
-	// This means "alpha_pre" < "alpha" (more suffixes = less stable)
-	return compareInt(len(b), len(a))
-}
-
-// compareSuffixes compares two individual suffixes
-func compareSuffixes(a, b suffix) int {
-	// Get suffix precedence order, defaulting to a high value for unknown suffixes
-	aOrder, aExists := suffixOrder[a.name]
-	bOrder, bExists := suffixOrder[b.name]
-
-	// Unknown suffixes get a higher precedence (come after known suffixes)
-	if !aExists {
-		aOrder = unknownSuffixPrecedence
-	}
-	if !bExists {
-		bOrder = unknownSuffixPrecedence
+	// Going back is only allowed to start a new component or suffix
+	if n < t.kind && !(n == tokenDigitOrZero && t.kind == tokenDigit ||
+		n == tokenSuffix && t.kind == tokenSuffixNo ||
+		n == tokenDigit && t.kind == tokenLetter) {
+		n = tokenInvalid
 	}
+	t.kind = n
+}
 
-	// If both are unknown, compare lexicographically by name
-	if !aExists && !bExists {
-		nameCmp := strings.Compare(a.name, b.name)
-		if nameCmp != 0 {
-			return nameCmp
+// suffixValue returns the value of the suffix name s starts with and the
+// name's length. Like apk, a suffix is recognized by its prefix.
+func suffixValue(s string) (int, int, bool) {
+	for i, name := range preSuffixes {
+		if strings.HasPrefix(s, name) {
+			return i - len(preSuffixes), len(name), true
 		}
-		// If same name, compare numbers
-		return compareInt(a.number, b.number)
 	}
-
-	// Compare by suffix precedence order first
-	orderCmp := compareInt(aOrder, bOrder)
-	if orderCmp != 0 {
-		return orderCmp
+	for i, name := range postSuffixes {
+		if strings.HasPrefix(s, name) {
+			return i, len(name), true
+		}
 	}
-
-	// If same suffix type, compare numbers
-	return compareInt(a.number, b.number)
+	return 0, 0, false
 }
 
-// compareNumericArraysNumeric compares numeric arrays using Alpine's rules
-func compareNumericArraysNumeric(a, b []numericComponent) int {
-	maxLen := max(len(a), len(b))
+// compareTokens compares two apk versions token by token, as
+// apk_version_compare does. With revisionR0, a version without -r compares
+// as -r0.
+func compareTokens(a, b string, revisionR0 bool) int {
+	ta := tokenizer{s: a, kind: tokenDigit}
+	tb := tokenizer{s: b, kind: tokenDigit}
+	av, bv := 0, 0
+	ah, bh := "", ""
+	for ta.kind == tb.kind && ta.kind != tokenEnd && ta.kind != tokenInvalid && av == bv && ah == bh {
+		av, ah = ta.next()
+		bv, bh = tb.next()
+	}
 
-	for i := range maxLen {
-		var aComp, bComp numericComponent
+	if c := cmp.Compare(av, bv); c != 0 {
+		return c
+	}
+	if c := strings.Compare(ah, bh); c != 0 {
+		return c
+	}
+	if ta.kind == tb.kind {
+		return 0
+	}
 
-		if i < len(a) {
-			aComp = a[i]
-		} else {
-			aComp = numericComponent{value: 0, originalStr: "0"}
+	// The leading tokens are equal, so the version with more tokens is greater
+	// unless they start with a pre-release suffix
+	if peek := ta; peek.kind == tokenSuffix {
+		if v, _ := peek.next(); v < 0 {
+			return -1
 		}
-		if i < len(b) {
-			bComp = b[i]
-		} else {
-			bComp = numericComponent{value: 0, originalStr: "0"}
+	}
+	if peek := tb; peek.kind == tokenSuffix {
+		if v, _ := peek.next(); v < 0 {
+			return 1
 		}
-
-		var cmp int
-		if i == 0 {
-			// Major component: always compare numerically (ignore leading zeros)
-			cmp = compareInt(aComp.value, bComp.value)
-		} else {
-			// Minor/patch components: if either has leading zeros, use string comparison
-			if hasLeadingZero(aComp.originalStr) || hasLeadingZero(bComp.originalStr) {
-				cmp = strings.Compare(aComp.originalStr, bComp.originalStr)
-			} else {
-				// Both have no leading zeros, use numeric comparison
-				cmp = compareInt(aComp.value, bComp.value)
-			}
+	}
+	if revisionR0 {
+		if ta.kind == tokenEnd && tb.kind == tokenRevisionNo {
+			v, _ := tb.next()
+			return cmp.Compare(0, v)
 		}
-
-		if cmp != 0 {
-			return cmp
+		if tb.kind == tokenEnd && ta.kind == tokenRevisionNo {
+			v, _ := ta.next()
+			return cmp.Compare(v, 0)
 		}
 	}
-
-	return 0
+	return cmp.Compare(tb.kind, ta.kind)
 }
 
-// hasLeadingZero checks if a numeric string has leading zeros
-func hasLeadingZero(s string) bool {
-	return len(s) > 1 && s[0] == '0'
-}
-
-// compareInt returns -1 if a < b, 0 if a == b, 1 if a > b
-func compareInt(a, b int) int {
-	if a < b {
-		return -1
-	}
-	if a > b {
-		return 1
+// fuzzyTokens reports whether version agrees with every token of prefix, the
+// way apk's fuzzy (~) comparison ignores the tokens prefix lacks
+func fuzzyTokens(version, prefix string) bool {
+	ta := tokenizer{s: version, kind: tokenDigit}
+	tb := tokenizer{s: prefix, kind: tokenDigit}
+	for tb.kind != tokenEnd {
+		if ta.kind != tb.kind || tb.kind == tokenInvalid {
+			return false
+		}
+		av, ah := ta.next()
+		bv, bh := tb.next()
+		if av != bv || ah != bh {
+			return false
+		}
 	}
-	return 0
+	return true
 }
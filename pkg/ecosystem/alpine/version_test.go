@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -253,6 +254,24 @@ func TestVersion_Compare(t *testing.T) {
 
 		// Invalid format handling (the key fix)
 		{name: "standard vs invalid format", v1: "1.0", v2: "1.0bc", want: -1},
+		{name: "invalid format after higher version", v1: "1.0bc", v2: "10.0", want: 1},
+		{name: "invalid formats as strings", v1: "1.0bc", v2: "1.0bd", want: -1},
+		{name: "invalid format ignores whitespace", v1: " 1.0bc", v2: "1.0bc ", want: 0},
+
+		// Suffix token ordering
+		{name: "post-release vs release", v1: "1.0_p1", v2: "1.0", want: 1},
+		{name: "post-release without number", v1: "1.0_p", v2: "1.0_p0", want: -1},
+		{name: "post-release order", v1: "1.0_cvs", v2: "1.0_p", want: -1},
+		{name: "pre-release after suffix", v1: "1.0_alpha_pre", v2: "1.0_alpha", want: -1},
+		{name: "post-release after suffix", v1: "1.0_alpha_p", v2: "1.0_alpha", want: 1},
+		{name: "unknown suffix vs release", v1: "1.0_foo", v2: "1.0", want: -1},
+		{name: "leading zeros", v1: "1.001", v2: "1.01", want: -1},
+		{name: "extra component", v1: "1.0", v2: "1.0.0", want: -1},
+		{name: "commit hashes", v1: "1.0~abc", v2: "1.0~abd", want: -1},
+
+		// Missing revisions sort first, as in apk
+		{name: "missing revision", v1: "1.0", v2: "1.0-r0", want: -1},
+		{name: "post-release vs revision", v1: "1.0_p1", v2: "1.0-r5", want: 1},
 	}
 
 	for _, tt := range tests {
@@ -276,6 +295,108 @@ func TestVersion_Compare(t *testing.T) {
 	}
 }
 
+// TestVersion_Compare_TotalOrder checks that Compare is a total order over a
+// mix of apk versions and versions that fall back to string comparison, and
+// that sorting is independent of the input order.
+func TestVersion_Compare_TotalOrder(t *testing.T) {
+	inputs := []string{
+		"1.0", "1.0-r1", "1.0_rc1", "1.0_p1", "1.0a", "1.0.0", "1.001", "2.0",
+		"9.0", "10.0", "23_foo", "1.0~abc", "1.0bc", "1.0bd", "10.0x!", "1x",
+		"2.0+git", " 9.0.0-beta ", "0.9..1",
+	}
+
+	e := &Ecosystem{}
+	versions := make([]*Version, len(inputs))
+	for i, input := range inputs {
+		v, err := e.NewVersion(input)
+		if err != nil {
+			t.Fatalf("NewVersion(%q) error: %v", input, err)
+		}
+		versions[i] = v
+	}
+
+	for _, a := range versions {
+		for _, b := range versions {
+			if ab, ba := a.Compare(b), b.Compare(a); ab != -ba {
+				t.Errorf("Compare(%q, %q) = %d but Compare(%q, %q) = %d", a, b, ab, b, a, ba)
+			}
+			for _, c := range versions {
+				if a.Compare(b) <= 0 && b.Compare(c) <= 0 && a.Compare(c) > 0 {
+					t.Errorf("%q <= %q <= %q but Compare(%q, %q) > 0", a, b, c, a, c)
+				}
+			}
+		}
+	}
+
+	sorted := slices.SortedFunc(slices.Values(versions), (*Version).Compare)
+	reversed := slices.Clone(versions)
+	slices.Reverse(reversed)
+	slices.SortFunc(reversed, (*Version).Compare)
+	for i := range sorted {
+		if sorted[i].Compare(reversed[i]) != 0 {
+			t.Fatalf("sorting depends on input order: %v vs %v", sorted, reversed)
+		}
+	}
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i-1].numeric == nil && sorted[i].numeric != nil {
+			t.Errorf("%q sorts after %q, want apk versions before string versions", sorted[i], sorted[i-1])
+		}
+	}
+}
+
+func TestVersion_Compare_MissingRevisionAsR0(t *testing.T) {
+	tests := []struct {
+		name string
+		v1   string
+		v2   string
+		want int
+	}{
+		{name: "equal to r0", v1: "1.0", v2: "1.0-r0", want: 0},
+		{name: "r0 equal to missing", v1: "1.0-r0", v2: "1.0", want: 0},
+		{name: "less than r1", v1: "1.0", v2: "1.0-r1", want: -1},
+		{name: "greater than r1", v1: "1.0-r1", v2: "1.0", want: 1},
+		{name: "suffix still precedes", v1: "1.0_rc1", v2: "1.0-r0", want: -1},
+	}
+
+	e := &Ecosystem{MissingRevisionAsR0: true}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v1, err := e.NewVersion(tt.v1)
+			if err != nil {
+				t.Fatalf("NewVersion(%q) error: %v", tt.v1, err)
+			}
+			v2, err := e.NewVersion(tt.v2)
+			if err != nil {
+				t.Fatalf("NewVersion(%q) error: %v", tt.v2, err)
+			}
+			if got := v1.Compare(v2); got != tt.want {
+				t.Errorf("Compare(%q, %q) = %d, want %d", tt.v1, tt.v2, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersion_Compare_MixedMissingRevisionAsR0(t *testing.T) {
+	withOption := &Ecosystem{MissingRevisionAsR0: true}
+	without := &Ecosystem{}
+
+	// Only one side parsed with the option: compare as apk does
+	v1, err := withOption.NewVersion("1.0")
+	if err != nil {
+		t.Fatalf("NewVersion(%q) error: %v", "1.0", err)
+	}
+	v2, err := without.NewVersion("1.0-r0")
+	if err != nil {
+		t.Fatalf("NewVersion(%q) error: %v", "1.0-r0", err)
+	}
+	if got := v1.Compare(v2); got != -1 {
+		t.Errorf("Compare(%q, %q) = %d, want -1", v1, v2, got)
+	}
+	if got := v2.Compare(v1); got != 1 {
+		t.Errorf("Compare(%q, %q) = %d, want 1", v2, v1, got)
+	}
+}
+
 func TestVersion_Compare_Fixture(t *testing.T) {
 	e := &Ecosystem{}
 
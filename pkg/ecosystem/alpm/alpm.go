@@ -1,10 +1,12 @@
 package alpm
 
+import "github.com/alowayed/go-univers/pkg/univers"
+
 // Ecosystem represents the ALPM (Arch Linux Package Manager) versioning ecosystem
 type Ecosystem struct{}
 
 // Name returns the name of this ecosystem
-const Name = "alpm"
+const Name = string(univers.ALPM)
 
 func (e *Ecosystem) Name() string {
 	return Name
@@ -0,0 +1,75 @@
+package alpm_test
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/alpm"
+)
+
+// An epoch outranks the rest of the version, and the pkgrel after the last
+// dash breaks ties.
+func ExampleVersion_Compare() {
+	e := &alpm.Ecosystem{}
+
+	var versions []*alpm.Version
+	for _, s := range []string{"1:1.0-1", "2.0-1", "1.2.3-2", "1.2.3-1", "1.2.3rc1-1"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		versions = append(versions, v)
+	}
+	slices.SortFunc(versions, (*alpm.Version).Compare)
+	fmt.Println(versions)
+	// Output:
+	// [1.2.3rc1-1 1.2.3-1 1.2.3-2 2.0-1 1:1.0-1]
+}
+
+// Ranges are comparators separated by spaces.
+func ExampleVersionRange_Contains() {
+	e := &alpm.Ecosystem{}
+
+	r, err := e.NewVersionRange(">=1.2.3-1 <2.0")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, s := range []string{"1.2.3-2", "1.9-1", "2.1-1"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(s, r.Contains(v))
+	}
+	// Output:
+	// 1.2.3-2 true
+	// 1.9-1 true
+	// 2.1-1 false
+}
+
+// Vercmp compares strings as pacman's vercmp does, without rejecting any input.
+func ExampleVercmp() {
+	fmt.Println(alpm.Vercmp("1.0-1", "1.0-2"))
+	fmt.Println(alpm.VercmpIgnoringPkgrel("1.0-1", "1.0-2"))
+	fmt.Println(alpm.Vercmp("1.0a", "1.0"))
+	// Output:
+	// -1
+	// 0
+	// -1
+}
+
+func ExampleVersion_WithoutPkgrel() {
+	v, err := (&alpm.Ecosystem{}).NewVersion("1:2.0.1-3")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(v.Epoch(), v.Pkgver(), v.Pkgrel())
+	fmt.Println(v.WithoutPkgrel())
+	// Output:
+	// 1 2.0.1 3
+	// 1:2.0.1
+}
@@ -3,7 +3,10 @@ package alpm
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
+
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 type VersionRange struct {
@@ -22,20 +25,40 @@ var (
 	constraintPattern = regexp.MustCompile(`^(>=|<=|>|<|=)?(.+)$`)
 )
 
+// features declares the range syntax accepted by NewVersionRange
+var features = []univers.Feature{
+	{Name: univers.FeatureComparison, Syntax: ">=1.2.3-1 <2.0", Description: "Ordering operators >=, >, <=, < and = against pacman versions"},
+	{Name: univers.FeatureExact, Syntax: "1:1.2.3-1", Description: "A bare version matches only itself"},
+	{Name: univers.FeatureAnd, Syntax: ">=1.0 and <2.0", Description: "Space-separated constraints must all match; an optional \"and\" may join them"},
+}
+
+// Features returns the range syntax features supported by NewVersionRange
+func (e *Ecosystem) Features() []univers.Feature {
+	return slices.Clone(features)
+}
+
 func (e *Ecosystem) NewVersionRange(rangeStr string) (*VersionRange, error) {
+	if err := univers.CheckLength(rangeStr); err != nil {
+		return nil, err
+	}
+
 	if rangeStr == "" {
-		return nil, fmt.Errorf("range string cannot be empty")
+		return nil, univers.WrapParseError(rangeStr, fmt.Errorf("range string cannot be empty"))
 	}
 
 	// Trim leading and trailing whitespace
 	trimmed := strings.TrimSpace(rangeStr)
 	if trimmed == "" {
-		return nil, fmt.Errorf("range string cannot be empty or only whitespace")
+		return nil, univers.WrapParseError(rangeStr, fmt.Errorf("range string cannot be empty or only whitespace"))
 	}
 
 	// Parse constraints by splitting on spaces
 	constraints, err := parseConstraints(trimmed, e)
 	if err != nil {
+		return nil, univers.WrapParseError(rangeStr, err)
+	}
+
+	if err := univers.CheckConstraints(len(constraints)); err != nil {
 		return nil, err
 	}
 
@@ -54,7 +77,11 @@ func parseConstraints(rangeStr string, ecosystem *Ecosystem) ([]*constraint, err
 
 	var constraints []*constraint
 
+	offset := 0
 	for _, part := range parts {
+		start := offset + strings.Index(rangeStr[offset:], part)
+		offset = start + len(part)
+
 		// Skip "and" keywords
 		if strings.ToLower(part) == "and" {
 			continue
@@ -62,7 +89,7 @@ func parseConstraints(rangeStr string, ecosystem *Ecosystem) ([]*constraint, err
 
 		constraint, err := parseConstraint(part, ecosystem)
 		if err != nil {
-			return nil, err
+			return nil, univers.TokenErrorAt(rangeStr, start, part, err)
 		}
 
 		constraints = append(constraints, constraint)
@@ -107,6 +134,15 @@ func (r *VersionRange) Contains(version *Version) bool {
 	return true
 }
 
+// Comparators returns the constraints as a single AND group
+func (r *VersionRange) Comparators() [][]univers.Comparator {
+	group := make([]univers.Comparator, 0, len(r.constraints))
+	for _, c := range r.constraints {
+		group = append(group, univers.Comparator{Operator: c.operator, Version: c.version.String()})
+	}
+	return [][]univers.Comparator{group}
+}
+
 func (r *VersionRange) String() string {
 	return r.original
 }
@@ -1,6 +1,7 @@
 package alpm
 
 import (
+	"fmt"
 	"testing"
 )
 
@@ -358,3 +359,36 @@ func TestVersionRange_String(t *testing.T) {
 		})
 	}
 }
+
+func TestEcosystem_Features(t *testing.T) {
+	e := &Ecosystem{}
+	for _, f := range e.Features() {
+		if _, err := e.NewVersionRange(f.Syntax); err != nil {
+			t.Errorf("Features() %s syntax %q does not parse: %v", f.Name, f.Syntax, err)
+		}
+	}
+}
+
+func TestVersionRange_Comparators(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		want     string
+	}{
+		{name: "comparisons", rangeStr: ">=1.0-1 <2.0", want: "[[>=1.0-1 <2.0]]"},
+		{name: "exact", rangeStr: "1.2.3-1", want: "[[=1.2.3-1]]"},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vr, err := e.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("Ecosystem.NewVersionRange() error = %v", err)
+			}
+			if got := fmt.Sprint(vr.Comparators()); got != tt.want {
+				t.Errorf("VersionRange.Comparators() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
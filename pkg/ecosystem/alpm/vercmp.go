@@ -0,0 +1,177 @@
+package alpm
+
+import "strings"
+
+// Vercmp compares two version strings exactly as pacman's vercmp(8) and
+// alpm_pkg_vercmp do, returning -1, 0 or 1. Unlike NewVersion it never
+// rejects its input: any string is split into epoch, version and release
+// and compared segment by segment, so callers can replace the external
+// vercmp binary without changing results for malformed versions.
+func Vercmp(a, b string) int {
+	return vercmp(a, b, false)
+}
+
+// VercmpIgnoringPkgrel is Vercmp without the release comparison, so
+// rebuilds of the same upstream version compare equal.
+func VercmpIgnoringPkgrel(a, b string) int {
+	return vercmp(a, b, true)
+}
+
+// vercmp implements Vercmp, optionally skipping the release comparison
+func vercmp(a, b string, ignorePkgrel bool) int {
+	if a == b {
+		return 0
+	}
+
+	epoch1, ver1, rel1, hasRel1 := parseEVR(a)
+	epoch2, ver2, rel2, hasRel2 := parseEVR(b)
+
+	ret := rpmvercmp(epoch1, epoch2)
+	if ret == 0 {
+		ret = rpmvercmp(ver1, ver2)
+		if ret == 0 && hasRel1 && hasRel2 && !ignorePkgrel {
+			ret = rpmvercmp(rel1, rel2)
+		}
+	}
+	return ret
+}
+
+// CompareIgnoringPkgrel compares this version with another ALPM version by
+// epoch and pkgver only, so rebuilds of the same upstream release compare
+// equal.
+func (v *Version) CompareIgnoringPkgrel(other *Version) int {
+	if v.epoch != other.epoch {
+		if v.epoch < other.epoch {
+			return -1
+		}
+		return 1
+	}
+	return compareALMPVersionString(v.pkgver, other.pkgver)
+}
+
+// parseEVR splits a version into epoch, version and release the way libalpm
+// does: the epoch is a leading run of digits terminated by ':', defaulting to
+// "0", and the release follows the last '-' after the epoch, if any.
+func parseEVR(evr string) (epoch, version, release string, hasRelease bool) {
+	s := 0
+	for s < len(evr) && isDigit(evr[s]) {
+		s++
+	}
+
+	epoch, version = "0", evr
+	if s < len(evr) && evr[s] == ':' {
+		if s > 0 {
+			epoch = evr[:s]
+		}
+		version = evr[s+1:]
+	}
+
+	if i := strings.LastIndexByte(version, '-'); i != -1 {
+		return epoch, version[:i], version[i+1:], true
+	}
+	return epoch, version, "", false
+}
+
+// rpmvercmp is a port of libalpm's rpmvercmp. Strings are compared as
+// alternating alphabetic and numeric segments; separators only matter when
+// their lengths differ.
+func rpmvercmp(a, b string) int {
+	if a == b {
+		return 0
+	}
+
+	// one and two index the current position; p1 and p2 the end of the
+	// previous segment, used to measure separator lengths.
+	one, two := 0, 0
+	p1, p2 := 0, 0
+	for one < len(a) && two < len(b) {
+		for one < len(a) && !isAlnum(a[one]) {
+			one++
+		}
+		for two < len(b) && !isAlnum(b[two]) {
+			two++
+		}
+
+		// If we ran to the end of either, we are finished with the loop
+		if one >= len(a) || two >= len(b) {
+			break
+		}
+
+		// If the separator lengths were different, we are also finished
+		if one-p1 != two-p2 {
+			if one-p1 < two-p2 {
+				return -1
+			}
+			return 1
+		}
+
+		p1, p2 = one, two
+
+		// Grab the first completely alpha or completely numeric segment
+		isNum := isDigit(a[p1])
+		class := isAlpha
+		if isNum {
+			class = isDigit
+		}
+		for p1 < len(a) && class(a[p1]) {
+			p1++
+		}
+		for p2 < len(b) && class(b[p2]) {
+			p2++
+		}
+
+		// Segments of different types: numeric is always newer than alpha
+		if two == p2 {
+			if isNum {
+				return 1
+			}
+			return -1
+		}
+
+		seg1, seg2 := a[one:p1], b[two:p2]
+		if isNum {
+			seg1 = strings.TrimLeft(seg1, "0")
+			seg2 = strings.TrimLeft(seg2, "0")
+
+			// Whichever number has more digits wins
+			if len(seg1) != len(seg2) {
+				if len(seg1) > len(seg2) {
+					return 1
+				}
+				return -1
+			}
+		}
+
+		if c := strings.Compare(seg1, seg2); c != 0 {
+			return c
+		}
+
+		one, two = p1, p2
+	}
+
+	// All segments compared identically but the separators differed
+	if one >= len(a) && two >= len(b) {
+		return 0
+	}
+
+	// The final showdown: a remaining alpha string never beats an empty string
+	if (one >= len(a) && !isAlpha(b[two])) || (one < len(a) && isAlpha(a[one])) {
+		return -1
+	}
+	return 1
+}
+
+// isDigit reports whether c is an ASCII digit
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// isAlpha reports whether c is an ASCII letter
+func isAlpha(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// isAlnum reports whether c is an ASCII letter or digit
+func isAlnum(c byte) bool {
+	return isDigit(c) || isAlpha(c)
+}
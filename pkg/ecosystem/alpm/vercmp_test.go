@@ -0,0 +1,139 @@
+package alpm
+
+import "testing"
+
+func TestVercmp(t *testing.T) {
+	// Cases from pacman's test/util/vercmptest.sh
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		// all similar length, no pkgrel
+		{"1.5.0", "1.5.0", 0},
+		{"1.5.1", "1.5.0", 1},
+		// mixed length
+		{"1.5.1", "1.5", 1},
+		// with pkgrel, simple
+		{"1.5.0-1", "1.5.0-1", 0},
+		{"1.5.0-1", "1.5.0-2", -1},
+		{"1.5.0-1", "1.5.1-1", -1},
+		{"1.5.0-2", "1.5.1-1", -1},
+		// with pkgrel, mixed lengths
+		{"1.5-1", "1.5.1-1", -1},
+		{"1.5-2", "1.5.1-1", -1},
+		{"1.5-2", "1.5.1-2", -1},
+		// mixed pkgrel inclusion
+		{"1.5", "1.5-1", 0},
+		{"1.5-1", "1.5", 0},
+		{"1.1-1", "1.1", 0},
+		{"1.0-1", "1.1", -1},
+		{"1.1-1", "1.0", 1},
+		// alphanumeric versions
+		{"1.5b-1", "1.5-1", -1},
+		{"1.5b", "1.5", -1},
+		{"1.5b-1", "1.5", -1},
+		{"1.5b", "1.5.1", -1},
+		// from the manpage
+		{"1.0a", "1.0alpha", -1},
+		{"1.0alpha", "1.0b", -1},
+		{"1.0b", "1.0beta", -1},
+		{"1.0beta", "1.0rc", -1},
+		{"1.0rc", "1.0", -1},
+		// alpha-dotted versions
+		{"1.5.a", "1.5", 1},
+		{"1.5.b", "1.5.a", 1},
+		{"1.5.1", "1.5.b", 1},
+		// alpha dots and dashes
+		{"1.5.b-1", "1.5.b", 0},
+		{"1.5-1", "1.5.b", -1},
+		// same/similar content, differing separators
+		{"2.0", "2_0", 0},
+		{"2.0_a", "2_0.a", 0},
+		{"2.0a", "2.0.a", -1},
+		{"2___a", "2_a", 1},
+		// epoch included version comparisons
+		{"0:1.0", "0:1.0", 0},
+		{"0:1.0", "0:1.1", -1},
+		{"1:1.0", "0:1.0", 1},
+		{"1:1.0", "0:1.1", 1},
+		{"1:1.0", "2:1.1", -1},
+		// epoch + sometimes present pkgrel
+		{"1:1.0", "0:1.0-1", 1},
+		{"1:1.0-1", "0:1.1-1", 1},
+		// epoch included on one version
+		{"0:1.0", "1.0", 0},
+		{"0:1.0", "1.1", -1},
+		{"0:1.1", "1.0", 1},
+		{"1:1.0", "1.0", 1},
+		{"1:1.0", "1.1", 1},
+		{"1:1.1", "1.1", 1},
+		// leading zeros and input NewVersion rejects
+		{"1.05", "1.5", 0},
+		{"1.0~rc1", "1.0", 1}, // no tilde rule, unlike rpm
+		{"", "0", -1},
+		{":1.0", "1.0", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"_vs_"+tt.b, func(t *testing.T) {
+			if got := Vercmp(tt.a, tt.b); got != tt.want {
+				t.Errorf("Vercmp(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+			if got := Vercmp(tt.b, tt.a); got != -tt.want {
+				t.Errorf("Vercmp(%q, %q) = %d, want %d", tt.b, tt.a, got, -tt.want)
+			}
+		})
+	}
+}
+
+func TestVercmpIgnoringPkgrel(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.5.0-1", "1.5.0-2", 0},
+		{"1.5.0-2", "1.5.1-1", -1},
+		{"1:1.0-1", "1.0-2", 1},
+		{"1.0rc-9", "1.0-1", -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"_vs_"+tt.b, func(t *testing.T) {
+			if got := VercmpIgnoringPkgrel(tt.a, tt.b); got != tt.want {
+				t.Errorf("VercmpIgnoringPkgrel(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersion_CompareIgnoringPkgrel(t *testing.T) {
+	tests := []struct {
+		name string
+		v1   string
+		v2   string
+		want int
+	}{
+		{"different pkgrel", "1.2.3-1", "1.2.3-2", 0},
+		{"missing pkgrel", "1.2.3", "1.2.3-5", 0},
+		{"pkgver decides", "1.2.3-9", "1.2.4-1", -1},
+		{"epoch still counts", "1:1.0-1", "2.0-1", 1},
+		{"prerelease suffix", "1.0rc1-3", "1.0-1", -1},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v1, err := e.NewVersion(tt.v1)
+			if err != nil {
+				t.Fatalf("NewVersion(%q) error = %v", tt.v1, err)
+			}
+			v2, err := e.NewVersion(tt.v2)
+			if err != nil {
+				t.Fatalf("NewVersion(%q) error = %v", tt.v2, err)
+			}
+			if got := v1.CompareIgnoringPkgrel(v2); got != tt.want {
+				t.Errorf("CompareIgnoringPkgrel() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"strconv"
 	"strings"
 	"unicode"
+
+	"github.com/alowayed/go-univers/internal/digits"
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 // Version represents an ALMP package version
@@ -18,6 +21,10 @@ type Version struct {
 
 // NewVersion creates a new ALMP version from a string
 func (e *Ecosystem) NewVersion(version string) (*Version, error) {
+	if err := univers.CheckLength(version); err != nil {
+		return nil, err
+	}
+
 	original := version
 	version = strings.TrimSpace(version)
 
@@ -108,6 +115,60 @@ func (e *Ecosystem) NewVersion(version string) (*Version, error) {
 	}, nil
 }
 
+// ValidVersion reports whether NewVersion accepts version. It splits and
+// checks the epoch, pkgver and pkgrel in place, so nothing is allocated.
+func ValidVersion(version string) bool {
+	if univers.CheckLength(version) != nil {
+		return false
+	}
+	version = strings.TrimSpace(version)
+	if version == "" {
+		return false
+	}
+
+	epoch, pkgver, found := strings.Cut(version, ":")
+	if !found {
+		epoch, pkgver = "", version
+	}
+	if epoch != "" && !validEpoch(epoch) {
+		return false
+	}
+
+	// Only the last hyphen can start an all-digit pkgrel
+	if i := strings.LastIndexByte(pkgver, '-'); i >= 0 && isAllDigits(pkgver[i+1:]) {
+		pkgrel := pkgver[i+1:]
+		if digits.Prefix(pkgrel) != len(pkgrel) || !digits.FitsInt(pkgrel) {
+			return false
+		}
+		pkgver = pkgver[:i]
+	}
+
+	if pkgver == "" {
+		return false
+	}
+	for _, r := range pkgver {
+		if !isValidALMPVersionChar(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// validEpoch reports whether strconv.Atoi accepts s as a non-negative number
+func validEpoch(s string) bool {
+	sign := byte('+')
+	if s[0] == '+' || s[0] == '-' {
+		sign, s = s[0], s[1:]
+	}
+	if s == "" || digits.Prefix(s) != len(s) {
+		return false
+	}
+	if sign == '-' {
+		return strings.Trim(s, "0") == ""
+	}
+	return digits.FitsInt(s)
+}
+
 // validateALMPVersionString validates that a version string contains only allowed characters
 // ALMP allows: alphanumerics, periods, underscores, plus signs, hyphens
 func validateALMPVersionString(s, part string) error {
@@ -143,6 +204,40 @@ func (v *Version) String() string {
 	return v.original
 }
 
+// Epoch returns the epoch, or 0 if the version has none
+func (v *Version) Epoch() int {
+	return v.epoch
+}
+
+// Pkgver returns the upstream version, without epoch or pkgrel
+func (v *Version) Pkgver() string {
+	return v.pkgver
+}
+
+// Pkgrel returns the package release, or 0 if the version has none
+func (v *Version) Pkgrel() int {
+	return v.pkgrel
+}
+
+// HasPkgrel reports whether the version has an explicit package release
+func (v *Version) HasPkgrel() bool {
+	return v.hasPkgrel
+}
+
+// WithoutPkgrel returns the version with its package release removed, keeping
+// the epoch as written. For example "1:2.0.1-3" becomes "1:2.0.1". Builds of
+// the same upstream release share the result.
+func (v *Version) WithoutPkgrel() *Version {
+	stripped := *v
+	stripped.pkgrel = 0
+	stripped.hasPkgrel = false
+	if v.hasPkgrel {
+		trimmed := strings.TrimSpace(v.original)
+		stripped.original = trimmed[:strings.LastIndex(trimmed, "-")]
+	}
+	return &stripped
+}
+
 // Compare compares this version with another ALMP version using vercmp rules
 // Follows Arch Linux vercmp(8) algorithm:
 // 1. Compare epochs first (higher epoch wins)
@@ -186,212 +281,12 @@ func (v *Version) Compare(other *Version) int {
 	return 0
 }
 
-// compareALMPVersionString compares two ALMP version strings using vercmp rules
-// This implements the Arch Linux vercmp algorithm based on the precedence:
-// 1.0a < 1.0b < 1.0beta < 1.0p < 1.0pre < 1.0rc < 1.0 < 1.0.a < 1.0.1
+// compareALMPVersionString compares two pkgver strings with libalpm's
+// rpmvercmp, so 1.0a < 1.0b < 1.0beta < 1.0p < 1.0pre < 1.0rc < 1.0 < 1.0.a <
+// 1.0.1
 func compareALMPVersionString(a, b string) int {
-	// Handle the specific documented precedence cases first
 	if a == b {
 		return 0
 	}
-
-	// Check if this is a direct suffix comparison (no dots separating)
-	if isDirectSuffixComparison(a, b) {
-		return compareDirectSuffixes(a, b)
-	}
-
-	// Otherwise use standard segment-by-segment comparison
-	return compareSegmentBySegment(a, b)
-}
-
-// isDirectSuffixComparison checks if we're comparing like "1.0" vs "1.0rc"
-func isDirectSuffixComparison(a, b string) bool {
-	// Simple heuristic: if one is a prefix of the other without separators
-	if len(a) < len(b) && b[:len(a)] == a {
-		// Check if remainder is alpha (no separators)
-		remainder := b[len(a):]
-		return len(remainder) > 0 && unicode.IsLetter(rune(remainder[0])) &&
-			!strings.ContainsAny(remainder[:1], ".+-_")
-	}
-	if len(b) < len(a) && a[:len(b)] == b {
-		// Check if remainder is alpha (no separators)
-		remainder := a[len(b):]
-		return len(remainder) > 0 && unicode.IsLetter(rune(remainder[0])) &&
-			!strings.ContainsAny(remainder[:1], ".+-_")
-	}
-	return false
-}
-
-// compareDirectSuffixes handles cases like "1.0" vs "1.0rc"
-func compareDirectSuffixes(a, b string) int {
-	if len(a) < len(b) && b[:len(a)] == a {
-		// a is prefix of b, b has direct suffix -> a wins (1.0 > 1.0rc)
-		return 1
-	}
-	if len(b) < len(a) && a[:len(b)] == b {
-		// b is prefix of a, a has direct suffix -> b wins
-		return -1
-	}
-	// Both have suffixes, compare lexicographically
-	return strings.Compare(a, b)
-}
-
-// compareSegmentBySegment does standard version segment comparison
-// This implements a more accurate vercmp-style algorithm
-func compareSegmentBySegment(a, b string) int {
-	// Convert to segments first, handling delimiters properly
-	aSegments := splitToSegments(a)
-	bSegments := splitToSegments(b)
-
-	// Compare segment by segment
-	maxLen := len(aSegments)
-	if len(bSegments) > maxLen {
-		maxLen = len(bSegments)
-	}
-
-	for i := 0; i < maxLen; i++ {
-		var aSeg, bSeg string
-		var aMissing, bMissing bool
-
-		if i < len(aSegments) {
-			aSeg = aSegments[i]
-		} else {
-			aMissing = true
-		}
-		if i < len(bSegments) {
-			bSeg = bSegments[i]
-		} else {
-			bMissing = true
-		}
-
-		// Handle missing segments differently from empty segments
-		if aMissing && bMissing {
-			continue // both missing, equal
-		}
-		if aMissing {
-			return -1 // missing < present (even if empty)
-		}
-		if bMissing {
-			return 1 // present (even if empty) > missing
-		}
-
-		// Compare segments (both present)
-		cmp := compareSegments(aSeg, bSeg)
-		if cmp != 0 {
-			return cmp
-		}
-	}
-
-	return 0
-}
-
-// splitToSegments splits a version string into segments following vercmp rules
-// vercmp alternates between alpha and numeric segments
-func splitToSegments(version string) []string {
-	var segments []string
-	var current strings.Builder
-	var lastWasAlpha *bool // nil = no character yet, true = alpha, false = numeric
-
-	for _, r := range version {
-		if unicode.IsLetter(r) || unicode.IsDigit(r) {
-			isAlpha := unicode.IsLetter(r)
-
-			// Check if we need to split due to alpha/numeric transition
-			if lastWasAlpha != nil && *lastWasAlpha != isAlpha {
-				// Transition between alpha and numeric - split here
-				segments = append(segments, current.String())
-				current.Reset()
-			}
-
-			current.WriteRune(r)
-			lastWasAlpha = &isAlpha
-		} else {
-			// Delimiter found - end current segment
-			if current.Len() > 0 {
-				segments = append(segments, current.String())
-				current.Reset()
-				lastWasAlpha = nil
-			}
-			// Add empty segment for delimiter (preserving empty segments)
-			segments = append(segments, "")
-		}
-	}
-
-	// Add final segment if any content remains
-	if current.Len() > 0 {
-		segments = append(segments, current.String())
-	}
-
-	return segments
-}
-
-// compareSegments compares individual segments using vercmp rules
-func compareSegments(a, b string) int {
-	// Handle empty segments according to vercmp "final showdown" rules
-	if a == "" && b == "" {
-		return 0
-	}
-	if a == "" {
-		// Empty segment vs non-empty segment
-		// In vercmp, empty segments can be greater than non-empty in certain contexts
-		return 1 // empty > non-empty
-	}
-	if b == "" {
-		// Non-empty vs empty segment
-		return -1 // non-empty < empty
-	}
-
-	// Both non-empty segments
-	aIsNum := len(a) > 0 && unicode.IsDigit(rune(a[0]))
-	bIsNum := len(b) > 0 && unicode.IsDigit(rune(b[0]))
-
-	if aIsNum && bIsNum {
-		return compareALMPDigits(a, b)
-	} else if aIsNum {
-		return 1 // numeric > alpha
-	} else if bIsNum {
-		return -1 // alpha < numeric
-	} else {
-		return strings.Compare(a, b) // both alpha
-	}
-}
-
-// compareALMPDigits compares digit strings numerically
-func compareALMPDigits(a, b string) int {
-	// Empty string is treated as 0
-	if a == "" && b == "" {
-		return 0
-	}
-	if a == "" {
-		return -1
-	}
-	if b == "" {
-		return 1
-	}
-
-	// Convert to integers for comparison
-	aNum, aErr := strconv.ParseUint(a, 10, 64)
-	bNum, bErr := strconv.ParseUint(b, 10, 64)
-
-	if aErr == nil && bErr == nil {
-		if aNum < bNum {
-			return -1
-		}
-		if aNum > bNum {
-			return 1
-		}
-		return 0
-	}
-
-	// Fallback for very large numbers that don't fit in uint64
-	// Compare by length first (longer number is larger)
-	if len(a) < len(b) {
-		return -1
-	}
-	if len(a) > len(b) {
-		return 1
-	}
-
-	// If lengths are equal, string comparison works for digits
-	return strings.Compare(a, b)
+	return rpmvercmp(a, b)
 }
@@ -308,6 +308,24 @@ func TestVersion_Compare(t *testing.T) {
 			v2:   "1.0.0-2",
 			want: -1,
 		},
+		{
+			name: "letter before longer word",
+			v1:   "0b-1",
+			v2:   "0beta-3",
+			want: -1,
+		},
+		{
+			name: "letter before letter and number",
+			v1:   "0b-1",
+			v2:   "0b1-1",
+			want: -1,
+		},
+		{
+			name: "word after letter and number",
+			v1:   "0beta1-3",
+			v2:   "0b1-1",
+			want: 1,
+		},
 		{
 			name: "different epoch",
 			v1:   "1:1.0.0-1",
@@ -611,3 +629,59 @@ func TestVersion_String(t *testing.T) {
 		})
 	}
 }
+
+func TestVersion_Accessors(t *testing.T) {
+	tests := []struct {
+		input         string
+		wantEpoch     int
+		wantPkgver    string
+		wantPkgrel    int
+		wantHasPkgrel bool
+		wantWithout   string
+	}{
+		{input: "1.0.0-1", wantPkgver: "1.0.0", wantPkgrel: 1, wantHasPkgrel: true, wantWithout: "1.0.0"},
+		{input: "2:1.5.3-12", wantEpoch: 2, wantPkgver: "1.5.3", wantPkgrel: 12, wantHasPkgrel: true, wantWithout: "2:1.5.3"},
+		{input: "3.2.1", wantPkgver: "3.2.1", wantWithout: "3.2.1"},
+		{input: "1:2.4_rc1+git20220101-5", wantEpoch: 1, wantPkgver: "2.4_rc1+git20220101", wantPkgrel: 5, wantHasPkgrel: true, wantWithout: "1:2.4_rc1+git20220101"},
+		{input: "1.0-beta-2", wantPkgver: "1.0-beta", wantPkgrel: 2, wantHasPkgrel: true, wantWithout: "1.0-beta"},
+		{input: " 1.0-3 ", wantPkgver: "1.0", wantPkgrel: 3, wantHasPkgrel: true, wantWithout: "1.0"},
+	}
+
+	ecosystem := &Ecosystem{}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			v, err := ecosystem.NewVersion(tt.input)
+			if err != nil {
+				t.Fatalf("NewVersion(%q) error = %v", tt.input, err)
+			}
+
+			if got := v.Epoch(); got != tt.wantEpoch {
+				t.Errorf("Epoch() = %d, want %d", got, tt.wantEpoch)
+			}
+			if got := v.Pkgver(); got != tt.wantPkgver {
+				t.Errorf("Pkgver() = %q, want %q", got, tt.wantPkgver)
+			}
+			if got := v.Pkgrel(); got != tt.wantPkgrel {
+				t.Errorf("Pkgrel() = %d, want %d", got, tt.wantPkgrel)
+			}
+			if got := v.HasPkgrel(); got != tt.wantHasPkgrel {
+				t.Errorf("HasPkgrel() = %v, want %v", got, tt.wantHasPkgrel)
+			}
+
+			without := v.WithoutPkgrel()
+			if got := without.String(); got != tt.wantWithout {
+				t.Errorf("WithoutPkgrel().String() = %q, want %q", got, tt.wantWithout)
+			}
+			if without.HasPkgrel() || without.Pkgrel() != 0 {
+				t.Errorf("WithoutPkgrel() = pkgrel %d, %v, want none", without.Pkgrel(), without.HasPkgrel())
+			}
+			if v.CompareIgnoringPkgrel(without) != 0 {
+				t.Errorf("CompareIgnoringPkgrel(%q, %q) != 0", v, without)
+			}
+			if v.String() != tt.input {
+				t.Errorf("WithoutPkgrel() modified the receiver to %q", v)
+			}
+		})
+	}
+}
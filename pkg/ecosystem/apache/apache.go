@@ -1,7 +1,9 @@
 package apache
 
+import "github.com/alowayed/go-univers/pkg/univers"
+
 const (
-	Name = "apache"
+	Name = string(univers.Apache)
 )
 
 type Ecosystem struct{}
@@ -0,0 +1,50 @@
+package apache_test
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/apache"
+)
+
+// Milestones and release candidates sort before the release.
+func ExampleVersion_Compare() {
+	e := &apache.Ecosystem{}
+
+	var versions []*apache.Version
+	for _, s := range []string{"9.0.10", "9.0.0", "9.0.0.M1", "9.0.9", "9.0.0-RC1"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		versions = append(versions, v)
+	}
+	slices.SortFunc(versions, (*apache.Version).Compare)
+	fmt.Println(versions)
+	// Output:
+	// [9.0.0.M1 9.0.0-RC1 9.0.0 9.0.9 9.0.10]
+}
+
+// Ranges are comparators separated by spaces.
+func ExampleVersionRange_Contains() {
+	e := &apache.Ecosystem{}
+
+	r, err := e.NewVersionRange(">=9.0.0 <9.0.50")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, s := range []string{"9.0.49", "9.0.0.M1", "9.0.50"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(s, r.Contains(v))
+	}
+	// Output:
+	// 9.0.49 true
+	// 9.0.0.M1 false
+	// 9.0.50 false
+}
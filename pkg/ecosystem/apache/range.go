@@ -3,7 +3,10 @@ package apache
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
+
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 type VersionRange struct {
@@ -21,20 +24,40 @@ var (
 	constraintPattern = regexp.MustCompile(`^(>=|<=|>|<|=)?(.+)$`)
 )
 
+// features declares the range syntax accepted by NewVersionRange
+var features = []univers.Feature{
+	{Name: univers.FeatureComparison, Syntax: ">=9.0.0 <10.0.0", Description: "Ordering operators >=, >, <=, < and ="},
+	{Name: univers.FeatureExact, Syntax: "9.0.0.M1", Description: "A bare version matches only itself"},
+	{Name: univers.FeatureAnd, Syntax: ">=9.0.0 <9.0.50", Description: "Space-separated constraints must all match"},
+}
+
+// Features returns the range syntax features supported by NewVersionRange
+func (e *Ecosystem) Features() []univers.Feature {
+	return slices.Clone(features)
+}
+
 func (e *Ecosystem) NewVersionRange(rangeStr string) (*VersionRange, error) {
+	if err := univers.CheckLength(rangeStr); err != nil {
+		return nil, err
+	}
+
 	if rangeStr == "" {
-		return nil, fmt.Errorf("range string cannot be empty")
+		return nil, univers.WrapParseError(rangeStr, fmt.Errorf("range string cannot be empty"))
 	}
 
 	// Trim leading and trailing whitespace
 	trimmed := strings.TrimSpace(rangeStr)
 	if trimmed == "" {
-		return nil, fmt.Errorf("range string cannot be empty or only whitespace")
+		return nil, univers.WrapParseError(rangeStr, fmt.Errorf("range string cannot be empty or only whitespace"))
 	}
 
 	// Parse constraints by splitting on spaces
 	constraints, err := parseConstraints(trimmed)
 	if err != nil {
+		return nil, univers.WrapParseError(rangeStr, err)
+	}
+
+	if err := univers.CheckConstraints(len(constraints)); err != nil {
 		return nil, err
 	}
 
@@ -54,10 +77,14 @@ func parseConstraints(rangeStr string) ([]*constraint, error) {
 	var constraints []*constraint
 	ecosystem := &Ecosystem{}
 
+	offset := 0
 	for _, part := range parts {
+		start := offset + strings.Index(rangeStr[offset:], part)
+		offset = start + len(part)
+
 		constraint, err := parseConstraint(part, ecosystem)
 		if err != nil {
-			return nil, err
+			return nil, univers.TokenErrorAt(rangeStr, start, part, err)
 		}
 		constraints = append(constraints, constraint)
 	}
@@ -101,6 +128,15 @@ func (r *VersionRange) Contains(version *Version) bool {
 	return true
 }
 
+// Comparators returns the constraints as a single AND group
+func (r *VersionRange) Comparators() [][]univers.Comparator {
+	group := make([]univers.Comparator, 0, len(r.constraints))
+	for _, c := range r.constraints {
+		group = append(group, univers.Comparator{Operator: c.operator, Version: c.version.String()})
+	}
+	return [][]univers.Comparator{group}
+}
+
 func (r *VersionRange) String() string {
 	return r.original
 }
@@ -1,6 +1,7 @@
 package apache
 
 import (
+	"fmt"
 	"testing"
 )
 
@@ -185,6 +186,19 @@ func TestVersionRange_Contains(t *testing.T) {
 			version:  "2.4.42",
 			want:     false,
 		},
+		// Tomcat milestones
+		{
+			name:     "milestone range - dot-separated milestone in range",
+			rangeStr: ">=9.0.0.M1 <9.0.0",
+			version:  "9.0.0.M27",
+			want:     true,
+		},
+		{
+			name:     "milestone range - release excluded",
+			rangeStr: ">=9.0.0.M1 <9.0.0",
+			version:  "9.0.0",
+			want:     false,
+		},
 		// Range constraints
 		{
 			name:     "range - version in range",
@@ -349,3 +363,35 @@ func TestVersionRange_String(t *testing.T) {
 		})
 	}
 }
+
+func TestEcosystem_Features(t *testing.T) {
+	e := &Ecosystem{}
+	for _, f := range e.Features() {
+		if _, err := e.NewVersionRange(f.Syntax); err != nil {
+			t.Errorf("Features() %s syntax %q does not parse: %v", f.Name, f.Syntax, err)
+		}
+	}
+}
+
+func TestVersionRange_Comparators(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		want     string
+	}{
+		{name: "comparisons", rangeStr: ">=2.4.0 <2.5.0", want: "[[>=2.4.0 <2.5.0]]"},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vr, err := e.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("Ecosystem.NewVersionRange() error = %v", err)
+			}
+			if got := fmt.Sprint(vr.Comparators()); got != tt.want {
+				t.Errorf("VersionRange.Comparators() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/alowayed/go-univers/internal/digits"
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 type Version struct {
@@ -22,13 +25,18 @@ var (
 	// - Release candidates: 2.4.41-RC1, 9.0.0-RC2
 	// - Beta/Alpha releases: 2.4.0-beta, 3.0.0-alpha
 	// - Development versions: 2.5.0-dev
-	// - Milestone versions: 2.5.0-M4, 3.0.0-milestone-2
+	// - Milestone versions: 2.5.0-M4, 3.0.0-milestone2
+	// - Tomcat dot-separated qualifiers: 9.0.0.M1, 8.0.0.RC1
 	// - Snapshot versions: 2.4.0-SNAPSHOT
 	// - Date versions: 2.4.41-v20230415 (Apache Directory Project format)
-	apacheVersionPattern = regexp.MustCompile(`^(\d+)\.(\d+)\.(\d+)(?:-([A-Za-z]+)(\d*|v\d{8})?)?$`)
+	apacheVersionPattern = regexp.MustCompile(`^(\d+)\.(\d+)\.(\d+)(?:[-.]([A-Za-z]+)(\d*|v\d{8})?)?$`)
 )
 
 func (e *Ecosystem) NewVersion(version string) (*Version, error) {
+	if err := univers.CheckLength(version); err != nil {
+		return nil, err
+	}
+
 	if version == "" {
 		return nil, fmt.Errorf("version string cannot be empty")
 	}
@@ -100,6 +108,31 @@ func (e *Ecosystem) NewVersion(version string) (*Version, error) {
 	}, nil
 }
 
+// ValidVersion reports whether NewVersion accepts version. It matches the
+// same pattern but skips building a Version, so it does not allocate.
+func ValidVersion(version string) bool {
+	if univers.CheckLength(version) != nil {
+		return false
+	}
+	trimmed := strings.TrimSpace(version)
+	if !apacheVersionPattern.MatchString(trimmed) {
+		return false
+	}
+
+	major, rest, _ := strings.Cut(trimmed, ".")
+	minor, rest, _ := strings.Cut(rest, ".")
+	n := digits.Prefix(rest)
+	if !digits.FitsInt(major) || !digits.FitsInt(minor) || !digits.FitsInt(rest[:n]) {
+		return false
+	}
+
+	// A qualifier is a separator and letters, then an optional number
+	if rest = rest[n:]; rest != "" {
+		rest = strings.TrimLeft(rest[1:], "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz")
+	}
+	return digits.FitsInt(rest)
+}
+
 func (v *Version) Compare(other *Version) int {
 	// Compare major.minor.patch first
 	if v.major != other.major {
@@ -156,6 +156,30 @@ func TestEcosystem_NewVersion(t *testing.T) {
 				original:  "2.4.41-v20230415",
 			},
 		},
+		{
+			name:  "Apache Tomcat dot-separated milestone",
+			input: "9.0.0.M1",
+			want: &Version{
+				major:     9,
+				minor:     0,
+				patch:     0,
+				qualifier: "m",
+				number:    1,
+				original:  "9.0.0.M1",
+			},
+		},
+		{
+			name:  "Apache Tomcat dot-separated RC",
+			input: "8.0.0.RC10",
+			want: &Version{
+				major:     8,
+				minor:     0,
+				patch:     0,
+				qualifier: "rc",
+				number:    10,
+				original:  "8.0.0.RC10",
+			},
+		},
 		// Error cases
 		{
 			name:    "empty string",
@@ -227,6 +251,31 @@ func TestVersion_Compare(t *testing.T) {
 			v2:   "2.4.41",
 			want: 0,
 		},
+		// Tomcat milestones
+		{
+			name: "tomcat milestones compare numerically",
+			v1:   "9.0.0.M9",
+			v2:   "9.0.0.M10",
+			want: -1,
+		},
+		{
+			name: "tomcat milestone before release",
+			v1:   "10.1.0-M17",
+			v2:   "10.1.0",
+			want: -1,
+		},
+		{
+			name: "tomcat milestone before rc",
+			v1:   "8.0.0.M1",
+			v2:   "8.0.0.RC1",
+			want: -1,
+		},
+		{
+			name: "dot and hyphen separators are equivalent",
+			v1:   "9.0.0.M1",
+			v2:   "9.0.0-M1",
+			want: 0,
+		},
 		{
 			name: "major version difference",
 			v1:   "2.4.41",
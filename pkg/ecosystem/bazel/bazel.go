@@ -0,0 +1,12 @@
+// Package bazel provides functionality for working with Bazel module (bzlmod) versions.
+package bazel
+
+import "github.com/alowayed/go-univers/pkg/univers"
+
+const Name = string(univers.Bazel)
+
+type Ecosystem struct{}
+
+func (e *Ecosystem) Name() string {
+	return Name
+}
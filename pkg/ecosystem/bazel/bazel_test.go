@@ -0,0 +1,11 @@
+package bazel
+
+import "testing"
+
+func TestEcosystem_Name(t *testing.T) {
+	e := &Ecosystem{}
+	want := "bazel"
+	if got := e.Name(); got != want {
+		t.Errorf("Ecosystem.Name() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,50 @@
+package bazel_test
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/bazel"
+)
+
+// A Bazel Central Registry patch such as 1.2.0.bcr.1 sorts after the version
+// it patches.
+func ExampleVersion_Compare() {
+	e := &bazel.Ecosystem{}
+
+	var versions []*bazel.Version
+	for _, s := range []string{"1.10.0", "1.2.0.bcr.1", "1.2.0", "1.2.0.bcr.2"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		versions = append(versions, v)
+	}
+	slices.SortFunc(versions, (*bazel.Version).Compare)
+	fmt.Println(versions)
+	// Output:
+	// [1.2.0 1.2.0.bcr.1 1.2.0.bcr.2 1.10.0]
+}
+
+// Ranges are comparators separated by commas.
+func ExampleVersionRange_Contains() {
+	e := &bazel.Ecosystem{}
+
+	r, err := e.NewVersionRange(">=1.0,<2.0")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, s := range []string{"1.2.0.bcr.1", "2.0.0"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(s, r.Contains(v))
+	}
+	// Output:
+	// 1.2.0.bcr.1 true
+	// 2.0.0 false
+}
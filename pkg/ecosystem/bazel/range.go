@@ -0,0 +1,163 @@
+package bazel
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+// VersionRange represents a Bazel module version range.
+//
+// Bazel itself resolves dependencies with Minimal Version Selection and has no
+// native range syntax, so ranges use the common comparison operators
+// (>=, <=, >, <, =, !=) separated by spaces or commas, all of which must match.
+type VersionRange struct {
+	original    string
+	constraints []*constraint
+}
+
+// constraint represents a single Bazel module version constraint
+type constraint struct {
+	operator string
+	version  *Version
+}
+
+// features declares the range syntax accepted by NewVersionRange
+var features = []univers.Feature{
+	{Name: univers.FeatureComparison, Syntax: ">=1.2.0 <2.0.0", Description: "Ordering operators >=, >, <=, < and ="},
+	{Name: univers.FeatureNotEqual, Syntax: "!=1.2.1", Description: "Excludes a single version"},
+	{Name: univers.FeatureExact, Syntax: "1.2.0.bcr.1", Description: "A bare version matches only itself"},
+	{Name: univers.FeatureAnd, Syntax: ">=1.0,<2.0", Description: "Comma- or space-separated constraints must all match"},
+	{Name: univers.FeatureWildcard, Syntax: "*", Description: "Matches every version"},
+}
+
+// Features returns the range syntax features supported by NewVersionRange
+func (e *Ecosystem) Features() []univers.Feature {
+	return slices.Clone(features)
+}
+
+// NewVersionRange parses a Bazel module version range string.
+func (e *Ecosystem) NewVersionRange(rangeStr string) (*VersionRange, error) {
+	if err := univers.CheckLength(rangeStr); err != nil {
+		return nil, err
+	}
+
+	trimmed := strings.TrimSpace(rangeStr)
+	if trimmed == "" {
+		return nil, univers.WrapParseError(rangeStr, fmt.Errorf("empty range string"))
+	}
+
+	parts := strings.FieldsFunc(trimmed, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	if len(parts) == 0 {
+		return nil, univers.WrapParseError(rangeStr, fmt.Errorf("no constraints found in range: %s", rangeStr))
+	}
+
+	constraints := make([]*constraint, 0, len(parts))
+	offset := 0
+	for _, part := range parts {
+		start := offset + strings.Index(trimmed[offset:], part)
+		offset = start + len(part)
+
+		c, err := parseConstraint(e, part)
+		if err != nil {
+			return nil, univers.WrapParseError(rangeStr, univers.TokenErrorAt(trimmed, start, part, err))
+		}
+		constraints = append(constraints, c)
+	}
+
+	if err := univers.CheckConstraints(len(constraints)); err != nil {
+		return nil, err
+	}
+
+	return &VersionRange{
+		original:    trimmed,
+		constraints: constraints,
+	}, nil
+}
+
+// parseConstraint parses a single constraint such as ">=1.2.0".
+func parseConstraint(e *Ecosystem, s string) (*constraint, error) {
+	if s == "*" {
+		return &constraint{operator: "*"}, nil
+	}
+
+	operator := "="
+	versionStr := s
+	// Check longer operators first
+	for _, op := range []string{">=", "<=", "!=", ">", "<", "="} {
+		if strings.HasPrefix(s, op) {
+			operator = op
+			versionStr = s[len(op):]
+			break
+		}
+	}
+
+	if versionStr == "" {
+		return nil, fmt.Errorf("missing version after operator %s", operator)
+	}
+
+	v, err := e.NewVersion(versionStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version in constraint %q: %w", s, err)
+	}
+
+	return &constraint{operator: operator, version: v}, nil
+}
+
+// String returns the string representation of the range
+func (r *VersionRange) String() string {
+	return r.original
+}
+
+// Contains checks if a version is within this range
+func (r *VersionRange) Contains(version *Version) bool {
+	// ALL constraints must be satisfied (AND logic)
+	for _, c := range r.constraints {
+		if !c.matches(version) {
+			return false
+		}
+	}
+	return true
+}
+
+// Comparators returns the constraints as a single AND group, which is empty
+// for the wildcard
+func (r *VersionRange) Comparators() [][]univers.Comparator {
+	group := make([]univers.Comparator, 0, len(r.constraints))
+	for _, c := range r.constraints {
+		if c.operator != "*" {
+			group = append(group, univers.Comparator{Operator: c.operator, Version: c.version.String()})
+		}
+	}
+	return [][]univers.Comparator{group}
+}
+
+// matches checks if the given version matches this constraint
+func (c *constraint) matches(version *Version) bool {
+	if c.operator == "*" {
+		return true
+	}
+
+	cmp := version.Compare(c.version)
+
+	switch c.operator {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,201 @@
+package bazel
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestEcosystem_NewVersionRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{
+			name:  "exact version",
+			input: "1.2.0",
+		},
+		{
+			name:  "greater than or equal",
+			input: ">=1.2.0",
+		},
+		{
+			name:  "space separated",
+			input: ">=1.2.0 <2.0.0",
+		},
+		{
+			name:  "comma separated",
+			input: ">=1.2.0,<2.0.0",
+		},
+		{
+			name:  "not equal",
+			input: "!=1.2.1",
+		},
+		{
+			name:  "wildcard",
+			input: "*",
+		},
+		// Error cases
+		{
+			name:    "empty string",
+			input:   "",
+			wantErr: true,
+		},
+		{
+			name:    "whitespace only",
+			input:   "   ",
+			wantErr: true,
+		},
+		{
+			name:    "missing version",
+			input:   ">=",
+			wantErr: true,
+		},
+		{
+			name:    "invalid version",
+			input:   ">=1..0",
+			wantErr: true,
+		},
+	}
+
+	e := &Ecosystem{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := e.NewVersionRange(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Ecosystem.NewVersionRange() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.String() != tt.input {
+				t.Errorf("VersionRange.String() = %v, want %v", got.String(), tt.input)
+			}
+		})
+	}
+}
+
+func TestVersionRange_Contains(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		version  string
+		want     bool
+	}{
+		{
+			name:     "lower bound satisfied",
+			rangeStr: ">=1.2.0",
+			version:  "1.2.1",
+			want:     true,
+		},
+		{
+			name:     "lower bound at boundary",
+			rangeStr: ">=1.2.0",
+			version:  "1.2.0",
+			want:     true,
+		},
+		{
+			name:     "lower bound not satisfied",
+			rangeStr: ">=1.2.0",
+			version:  "1.1.9",
+			want:     false,
+		},
+		{
+			name:     "prerelease below release bound",
+			rangeStr: ">=1.2.0",
+			version:  "1.2.0-rc1",
+			want:     false,
+		},
+		{
+			name:     "bounded range inside",
+			rangeStr: ">=1.2.0 <2.0.0",
+			version:  "1.9.9",
+			want:     true,
+		},
+		{
+			name:     "bounded range at exclusive upper",
+			rangeStr: ">=1.2.0,<2.0.0",
+			version:  "2.0.0",
+			want:     false,
+		},
+		{
+			name:     "exact match ignores build metadata",
+			rangeStr: "1.2.0",
+			version:  "1.2.0+bcr.1",
+			want:     true,
+		},
+		{
+			name:     "not equal excludes version",
+			rangeStr: ">=1.0 !=1.2",
+			version:  "1.2",
+			want:     false,
+		},
+		{
+			name:     "date based release",
+			rangeStr: ">20210324.2",
+			version:  "20230125.3",
+			want:     true,
+		},
+		{
+			name:     "wildcard",
+			rangeStr: "*",
+			version:  "0.0.1",
+			want:     true,
+		},
+	}
+
+	e := &Ecosystem{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vr, err := e.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("Failed to parse range %s: %v", tt.rangeStr, err)
+			}
+
+			v, err := e.NewVersion(tt.version)
+			if err != nil {
+				t.Fatalf("Failed to parse version %s: %v", tt.version, err)
+			}
+
+			if got := vr.Contains(v); got != tt.want {
+				t.Errorf("VersionRange.Contains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEcosystem_Features(t *testing.T) {
+	e := &Ecosystem{}
+	for _, f := range e.Features() {
+		if _, err := e.NewVersionRange(f.Syntax); err != nil {
+			t.Errorf("Features() %s syntax %q does not parse: %v", f.Name, f.Syntax, err)
+		}
+	}
+}
+
+func TestVersionRange_Comparators(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		want     string
+	}{
+		{name: "comparisons", rangeStr: ">=1.0.0 <2.0.0", want: "[[>=1.0.0 <2.0.0]]"},
+		{name: "wildcard", rangeStr: "*", want: "[[]]"},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vr, err := e.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("Ecosystem.NewVersionRange() error = %v", err)
+			}
+			if got := fmt.Sprint(vr.Comparators()); got != tt.want {
+				t.Errorf("VersionRange.Comparators() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
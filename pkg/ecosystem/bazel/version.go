@@ -0,0 +1,204 @@
+package bazel
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/alowayed/go-univers/internal/digits"
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+// versionPattern matches Bazel module versions of the form RELEASE[-PRERELEASE][+BUILD].
+// Unlike SemVer, RELEASE may have any number of dot-separated identifiers and
+// identifiers may contain letters (e.g. "1.0a", "20210324.2").
+// Group 1: release, Group 2: prerelease (optional), Group 3: build metadata (optional)
+var versionPattern = regexp.MustCompile(`^([A-Za-z0-9_]+(?:\.[A-Za-z0-9_]+)*)(?:-([A-Za-z0-9_-]+(?:\.[A-Za-z0-9_-]+)*))?(?:\+([A-Za-z0-9_-]+(?:\.[A-Za-z0-9_-]+)*))?$`)
+
+// Version represents a Bazel module version as defined by the bzlmod specification.
+//
+// The empty version, which bzlmod uses for non-registry overrides, is not accepted.
+// Compatibility levels are a module attribute rather than part of the version string
+// and are therefore not modelled here.
+type Version struct {
+	original   string
+	release    []identifier
+	prerelease []identifier
+	build      string
+}
+
+// identifier is a single dot-separated component of a release or prerelease.
+type identifier struct {
+	value     string
+	numeric   bool
+	numberVal int
+}
+
+// NewVersion parses a Bazel module version string.
+func (e *Ecosystem) NewVersion(version string) (*Version, error) {
+	if err := univers.CheckLength(version); err != nil {
+		return nil, err
+	}
+
+	trimmed := strings.TrimSpace(version)
+	if trimmed == "" {
+		return nil, fmt.Errorf("empty version string")
+	}
+
+	matches := versionPattern.FindStringSubmatch(trimmed)
+	if matches == nil {
+		return nil, fmt.Errorf("invalid Bazel module version: %s", version)
+	}
+
+	release, err := parseIdentifiers(matches[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid release %q: %w", matches[1], err)
+	}
+
+	var prerelease []identifier
+	if matches[2] != "" {
+		prerelease, err = parseIdentifiers(matches[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid prerelease %q: %w", matches[2], err)
+		}
+	}
+
+	return &Version{
+		original:   trimmed,
+		release:    release,
+		prerelease: prerelease,
+		build:      matches[3],
+	}, nil
+}
+
+// ValidVersion reports whether NewVersion accepts version, without
+// allocating. A valid version matches versionPattern and has no numeric
+// release or prerelease identifier too large for an int.
+func ValidVersion(version string) bool {
+	if univers.CheckLength(version) != nil {
+		return false
+	}
+	trimmed := strings.TrimSpace(version)
+	if !versionPattern.MatchString(trimmed) {
+		return false
+	}
+
+	// Build metadata follows the only "+", and the prerelease the first "-"
+	rest, _, _ := strings.Cut(trimmed, "+")
+	release, prerelease, _ := strings.Cut(rest, "-")
+	return identifiersFit(release) && identifiersFit(prerelease)
+}
+
+// identifiersFit reports whether every numeric identifier in the dot-separated
+// identifiers s fits an int
+func identifiersFit(s string) bool {
+	for s != "" {
+		var id string
+		id, s, _ = strings.Cut(s, ".")
+		if isDigits(id) && !digits.FitsInt(id) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseIdentifiers splits a dot-separated string into identifiers.
+func parseIdentifiers(s string) ([]identifier, error) {
+	parts := strings.Split(s, ".")
+	identifiers := make([]identifier, 0, len(parts))
+	for _, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("empty identifier")
+		}
+		id := identifier{value: part}
+		if isDigits(part) {
+			n, err := strconv.Atoi(part)
+			if err != nil {
+				return nil, fmt.Errorf("numeric identifier out of range: %s", part)
+			}
+			id.numeric = true
+			id.numberVal = n
+		}
+		identifiers = append(identifiers, id)
+	}
+	return identifiers, nil
+}
+
+// isDigits reports whether s consists only of ASCII digits.
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// String returns the original string representation of the version.
+func (v *Version) String() string {
+	return v.original
+}
+
+// Compare compares this version with another Bazel module version.
+// Returns -1 if this < other, 0 if this == other, 1 if this > other.
+//
+// Release identifiers are compared left to right using SemVer identifier rules,
+// with a longer release winning when all shared identifiers are equal.
+// A version without a prerelease is higher than one with a prerelease.
+// Build metadata is ignored.
+func (v *Version) Compare(other *Version) int {
+	if c := compareIdentifiers(v.release, other.release); c != 0 {
+		return c
+	}
+
+	// No prerelease has higher precedence than any prerelease
+	switch {
+	case len(v.prerelease) == 0 && len(other.prerelease) == 0:
+		return 0
+	case len(v.prerelease) == 0:
+		return 1
+	case len(other.prerelease) == 0:
+		return -1
+	}
+
+	return compareIdentifiers(v.prerelease, other.prerelease)
+}
+
+// compareIdentifiers compares identifier lists left to right. A longer list
+// has higher precedence when all preceding identifiers are equal.
+func compareIdentifiers(a, b []identifier) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if c := compareIdentifier(a[i], b[i]); c != 0 {
+			return c
+		}
+	}
+	return compareInt(len(a), len(b))
+}
+
+// compareIdentifier compares two identifiers. Numeric identifiers compare
+// numerically and always have lower precedence than alphanumeric identifiers,
+// which compare lexically in ASCII order.
+func compareIdentifier(a, b identifier) int {
+	switch {
+	case a.numeric && b.numeric:
+		return compareInt(a.numberVal, b.numberVal)
+	case a.numeric:
+		return -1
+	case b.numeric:
+		return 1
+	default:
+		return strings.Compare(a.value, b.value)
+	}
+}
+
+// compareInt returns -1 if a < b, 0 if a == b, 1 if a > b
+func compareInt(a, b int) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,254 @@
+package bazel
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEcosystem_NewVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    *Version
+		wantErr bool
+	}{
+		{
+			name:  "semver release",
+			input: "1.2.3",
+			want: &Version{
+				original: "1.2.3",
+				release: []identifier{
+					{value: "1", numeric: true, numberVal: 1},
+					{value: "2", numeric: true, numberVal: 2},
+					{value: "3", numeric: true, numberVal: 3},
+				},
+			},
+		},
+		{
+			name:  "single segment release",
+			input: "20210324",
+			want: &Version{
+				original: "20210324",
+				release: []identifier{
+					{value: "20210324", numeric: true, numberVal: 20210324},
+				},
+			},
+		},
+		{
+			name:  "alphanumeric release identifier",
+			input: "1.0a",
+			want: &Version{
+				original: "1.0a",
+				release: []identifier{
+					{value: "1", numeric: true, numberVal: 1},
+					{value: "0a"},
+				},
+			},
+		},
+		{
+			name:  "prerelease and build",
+			input: "1.0.0-rc.1+bcr.2",
+			want: &Version{
+				original: "1.0.0-rc.1+bcr.2",
+				release: []identifier{
+					{value: "1", numeric: true, numberVal: 1},
+					{value: "0", numeric: true, numberVal: 0},
+					{value: "0", numeric: true, numberVal: 0},
+				},
+				prerelease: []identifier{
+					{value: "rc"},
+					{value: "1", numeric: true, numberVal: 1},
+				},
+				build: "bcr.2",
+			},
+		},
+		{
+			name:  "whitespace trimmed",
+			input: "  0.5.1  ",
+			want: &Version{
+				original: "0.5.1",
+				release: []identifier{
+					{value: "0", numeric: true, numberVal: 0},
+					{value: "5", numeric: true, numberVal: 5},
+					{value: "1", numeric: true, numberVal: 1},
+				},
+			},
+		},
+		// Error cases
+		{
+			name:    "empty string",
+			input:   "",
+			wantErr: true,
+		},
+		{
+			name:    "empty identifier",
+			input:   "1..2",
+			wantErr: true,
+		},
+		{
+			name:    "hyphen in release",
+			input:   "-1.0",
+			wantErr: true,
+		},
+		{
+			name:    "empty prerelease",
+			input:   "1.0-",
+			wantErr: true,
+		},
+		{
+			name:    "invalid characters",
+			input:   "1.0@beta",
+			wantErr: true,
+		},
+	}
+
+	e := &Ecosystem{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := e.NewVersion(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Ecosystem.NewVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Ecosystem.NewVersion() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersion_Compare(t *testing.T) {
+	tests := []struct {
+		name string
+		v1   string
+		v2   string
+		want int
+	}{
+		{
+			name: "equal versions",
+			v1:   "1.2.3",
+			v2:   "1.2.3",
+			want: 0,
+		},
+		{
+			name: "numeric release comparison",
+			v1:   "1.2.9",
+			v2:   "1.2.10",
+			want: -1,
+		},
+		{
+			name: "longer release is greater",
+			v1:   "1.2",
+			v2:   "1.2.0",
+			want: -1,
+		},
+		{
+			name: "numeric identifier lower than alphanumeric",
+			v1:   "1.9",
+			v2:   "1.a",
+			want: -1,
+		},
+		{
+			name: "alphanumeric identifiers compared lexically",
+			v1:   "1.0a",
+			v2:   "1.0b",
+			want: -1,
+		},
+		{
+			name: "release greater than prerelease",
+			v1:   "1.0.0",
+			v2:   "1.0.0-rc1",
+			want: 1,
+		},
+		{
+			name: "prerelease ordering",
+			v1:   "1.0.0-alpha",
+			v2:   "1.0.0-beta",
+			want: -1,
+		},
+		{
+			name: "longer prerelease is greater",
+			v1:   "1.0.0-rc",
+			v2:   "1.0.0-rc.1",
+			want: -1,
+		},
+		{
+			name: "build metadata ignored",
+			v1:   "1.0.0+bcr.1",
+			v2:   "1.0.0+bcr.2",
+			want: 0,
+		},
+		{
+			name: "date based versions",
+			v1:   "20210324.2",
+			v2:   "20220623.0",
+			want: -1,
+		},
+		{
+			name: "leading zeros compare numerically",
+			v1:   "1.01",
+			v2:   "1.1",
+			want: 0,
+		},
+	}
+
+	e := &Ecosystem{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v1, err := e.NewVersion(tt.v1)
+			if err != nil {
+				t.Fatalf("Failed to parse v1 %s: %v", tt.v1, err)
+			}
+			v2, err := e.NewVersion(tt.v2)
+			if err != nil {
+				t.Fatalf("Failed to parse v2 %s: %v", tt.v2, err)
+			}
+
+			if got := v1.Compare(v2); got != tt.want {
+				t.Errorf("Version.Compare() = %v, want %v", got, tt.want)
+			}
+			if got := v2.Compare(v1); got != -tt.want {
+				t.Errorf("Version.Compare() reversed = %v, want %v", got, -tt.want)
+			}
+		})
+	}
+}
+
+func TestVersion_String(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "basic version",
+			input: "1.2.3",
+			want:  "1.2.3",
+		},
+		{
+			name:  "version with build metadata",
+			input: "1.0.0-rc.1+bcr.2",
+			want:  "1.0.0-rc.1+bcr.2",
+		},
+		{
+			name:  "whitespace trimmed",
+			input: " 1.0 ",
+			want:  "1.0",
+		},
+	}
+
+	e := &Ecosystem{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := e.NewVersion(tt.input)
+			if err != nil {
+				t.Fatalf("Failed to parse version %s: %v", tt.input, err)
+			}
+			if got := v.String(); got != tt.want {
+				t.Errorf("Version.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
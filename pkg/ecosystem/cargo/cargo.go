@@ -1,8 +1,10 @@
 // Package cargo provides functionality for working with Cargo (Rust) package versions.
 package cargo
 
+import "github.com/alowayed/go-univers/pkg/univers"
+
 const (
-	Name = "cargo"
+	Name = string(univers.Cargo)
 )
 
 type Ecosystem struct{}
@@ -0,0 +1,96 @@
+package cargo
+
+import (
+	"fmt"
+)
+
+// Cargo requirements may leave out trailing version components, and what a
+// missing component means depends on the operator. As in the semver crate
+// Cargo uses:
+//
+//	1.2.3  := ^1.2.3             (a bare version is a caret requirement)
+//	1.2    := ^1.2    := >=1.2.0, <2.0.0
+//	=1.2   := >=1.2.0, <1.3.0    (equal on the components given)
+//	=1     := >=1.0.0, <2.0.0
+//	>1.2   := >=1.3.0            (above every 1.2.x)
+//	>=1.2  := >=1.2.0
+//	<1.2   := <1.2.0
+//	<=1.2  := <1.3.0             (up to every 1.2.x)
+//
+// npm differs: there a bare version is an exact match. The functions below
+// turn each form into a constraint on a full version.
+
+// desugarBare returns the constraint of a requirement without an operator,
+// which Cargo reads as a caret requirement
+func desugarBare(version string, ecosystem *Ecosystem) (*constraint, error) {
+	c, err := desugarCaret(version, ecosystem)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version in default (caret) constraint: %v", err)
+	}
+	return c, nil
+}
+
+// desugarCaret returns the constraint of ^version
+func desugarCaret(version string, ecosystem *Ecosystem) (*constraint, error) {
+	parsed, err := parsePartial(version, ecosystem)
+	if err != nil {
+		return nil, err
+	}
+	return &constraint{operator: "^", version: parsed, precision: caretPrecision(parsed, countVersionComponents(version))}, nil
+}
+
+// desugarComparison returns the constraint of a comparison requirement such
+// as ">=1.2.3" or "=1.2". "!=" requires a full version.
+func desugarComparison(op, version string, ecosystem *Ecosystem) (*constraint, error) {
+	components := countVersionComponents(version)
+	if components >= 3 || op == "!=" {
+		parsed, err := ecosystem.NewVersion(version)
+		if err != nil {
+			return nil, err
+		}
+		return &constraint{operator: op, version: parsed, precision: 3}, nil
+	}
+
+	parsed, err := parsePartial(version, ecosystem)
+	if err != nil {
+		return nil, err
+	}
+	switch op {
+	case "=":
+		// Equal on the given components is a tilde requirement: =1.2 is ~1.2
+		// and =1 is ~1
+		return &constraint{operator: "~", version: parsed, precision: components}, nil
+	case ">", "<=":
+		// Past the last given component: >1.2 is >=1.3.0, <=1.2 is <1.3.0
+		next, err := ecosystem.NewVersion(nextPartial(parsed, components))
+		if err != nil {
+			return nil, err
+		}
+		if op == ">" {
+			return &constraint{operator: ">=", version: next, precision: 3}, nil
+		}
+		return &constraint{operator: "<", version: next, precision: 3}, nil
+	default:
+		return &constraint{operator: op, version: parsed, precision: 3}, nil
+	}
+}
+
+// parsePartial parses version with any missing components set to 0,
+// reporting errors against the version as written
+func parsePartial(version string, ecosystem *Ecosystem) (*Version, error) {
+	normalized := normalizePartialVersion(version)
+	parsed, err := ecosystem.NewVersion(normalized)
+	if err != nil && normalized != version {
+		return nil, fmt.Errorf("invalid Cargo version: %s", version)
+	}
+	return parsed, err
+}
+
+// nextPartial returns the first version after every version matching the
+// leading components of v, such as 1.3.0 for the two components of 1.2.0
+func nextPartial(v *Version, components int) string {
+	if components == 1 {
+		return fmt.Sprintf("%d.0.0", v.major+1)
+	}
+	return fmt.Sprintf("%d.%d.0", v.major, v.minor+1)
+}
@@ -0,0 +1,72 @@
+package cargo
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestDesugar checks each requirement against its expansion, taken from the
+// tables in Cargo's "Specifying Dependencies" documentation and the semver
+// crate's partial comparison rules.
+func TestDesugar(t *testing.T) {
+	tests := []struct {
+		requirement string
+		equivalent  string
+		comparators []string
+	}{
+		// Default (caret) requirements
+		{"1.2.3", ">=1.2.3, <2.0.0", []string{">=1.2.3", "<2.0.0-0"}},
+		{"1.2", ">=1.2.0, <2.0.0", []string{">=1.2.0", "<2.0.0-0"}},
+		{"1", ">=1.0.0, <2.0.0", []string{">=1.0.0", "<2.0.0-0"}},
+		{"0.2.3", ">=0.2.3, <0.3.0", []string{">=0.2.3", "<0.3.0-0"}},
+		{"0.2", ">=0.2.0, <0.3.0", []string{">=0.2.0", "<0.3.0-0"}},
+		{"0.0.3", ">=0.0.3, <0.0.4", []string{">=0.0.3", "<0.0.4-0"}},
+		{"0.0", ">=0.0.0, <0.1.0", []string{">=0.0.0", "<0.1.0-0"}},
+		{"0", ">=0.0.0, <1.0.0", []string{">=0.0.0", "<1.0.0-0"}},
+
+		// Comparison requirements with partial versions
+		{"=1.2.3", "=1.2.3", []string{"=1.2.3"}},
+		{"=1.2", ">=1.2.0, <1.3.0", []string{">=1.2.0", "<1.3.0-0"}},
+		{"=1", ">=1.0.0, <2.0.0", []string{">=1.0.0", "<2.0.0-0"}},
+		{"=0.0", ">=0.0.0, <0.1.0", []string{">=0.0.0", "<0.1.0-0"}},
+		{">1.2", ">=1.3.0", []string{">=1.3.0"}},
+		{">1", ">=2.0.0", []string{">=2.0.0"}},
+		{">=1.2", ">=1.2.0", []string{">=1.2.0"}},
+		{"<1.2", "<1.2.0", []string{"<1.2.0"}},
+		{"<=1.2", "<1.3.0", []string{"<1.3.0"}},
+		{"<=1", "<2.0.0", []string{"<2.0.0"}},
+	}
+
+	e := &Ecosystem{}
+	versions := []string{"0.0.0", "0.0.3", "0.0.4", "0.1.0", "0.2.0", "0.2.3", "0.2.9", "0.3.0", "0.9.0",
+		"1.0.0", "1.1.9", "1.2.0-rc.1", "1.2.0", "1.2.3", "1.2.9", "1.3.0", "1.9.0", "2.0.0-alpha", "2.0.0", "3.0.0"}
+	for _, tt := range tests {
+		t.Run(tt.requirement, func(t *testing.T) {
+			got, err := e.NewVersionRange(tt.requirement)
+			if err != nil {
+				t.Fatalf("NewVersionRange(%q) error = %v", tt.requirement, err)
+			}
+			want, err := e.NewVersionRange(tt.equivalent)
+			if err != nil {
+				t.Fatalf("NewVersionRange(%q) error = %v", tt.equivalent, err)
+			}
+			for _, s := range versions {
+				v, err := e.NewVersion(s)
+				if err != nil {
+					t.Fatalf("NewVersion(%q) error = %v", s, err)
+				}
+				if got.Contains(v) != want.Contains(v) {
+					t.Errorf("%q contains %s = %v, but %q contains it = %v", tt.requirement, s, got.Contains(v), tt.equivalent, want.Contains(v))
+				}
+			}
+
+			var comparators []string
+			for _, c := range got.Comparators()[0] {
+				comparators = append(comparators, c.String())
+			}
+			if !slices.Equal(comparators, tt.comparators) {
+				t.Errorf("Comparators() = %v, want %v", comparators, tt.comparators)
+			}
+		})
+	}
+}
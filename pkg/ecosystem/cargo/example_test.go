@@ -0,0 +1,66 @@
+package cargo_test
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/cargo"
+)
+
+// Versions follow SemVer: prereleases sort before the release.
+func ExampleVersion_Compare() {
+	e := &cargo.Ecosystem{}
+
+	var versions []*cargo.Version
+	for _, s := range []string{"0.10.0", "1.0.0", "0.2.3", "1.0.0-alpha.1", "1.0.0-beta"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		versions = append(versions, v)
+	}
+	slices.SortFunc(versions, (*cargo.Version).Compare)
+	fmt.Println(versions)
+	// Output:
+	// [0.2.3 0.10.0 1.0.0-alpha.1 1.0.0-beta 1.0.0]
+}
+
+// A caret range on a 0.x version only allows patch updates.
+func ExampleVersionRange_Contains() {
+	e := &cargo.Ecosystem{}
+
+	r, err := e.NewVersionRange("^0.2.3")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, s := range []string{"0.2.9", "0.3.0"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(s, r.Contains(v))
+	}
+	// Output:
+	// 0.2.9 true
+	// 0.3.0 false
+}
+
+func ExampleVersion_PrereleaseChannel() {
+	e := &cargo.Ecosystem{}
+
+	for _, s := range []string{"1.0.0-beta.2", "1.0.0"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		n, ok := v.PrereleaseNumber()
+		fmt.Printf("%s %q %d %t\n", s, v.PrereleaseChannel(), n, ok)
+	}
+	// Output:
+	// 1.0.0-beta.2 "beta" 2 true
+	// 1.0.0 "" 0 false
+}
@@ -0,0 +1,20 @@
+package cargo
+
+import "github.com/alowayed/go-univers/pkg/univers"
+
+// Hash returns a content hash of the constraints. It does not change with
+// whitespace, constraint order, duplicates or partial version spellings that
+// mean the same thing, so "^1.2, <1.5" and "<1.5.0, ^1.2.0" hash the same.
+// Tilde and caret requirements of different precision that mean different
+// things, such as "~1" and "~1.0" or "^0" and "^0.0", hash differently.
+func (vr *VersionRange) Hash() uint64 {
+	group := make([]univers.HashedConstraint, 0, len(vr.constraints))
+	for _, c := range vr.constraints {
+		key := c.version.SortKey()
+		if c.operator == "~" || c.operator == "^" {
+			key = append(key, byte(c.precision))
+		}
+		group = append(group, univers.HashedConstraint{Operator: c.operator, Key: key})
+	}
+	return univers.HashConstraints([][]univers.HashedConstraint{group})
+}
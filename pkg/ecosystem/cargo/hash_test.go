@@ -0,0 +1,39 @@
+package cargo
+
+import "testing"
+
+func TestVersionRange_Hash(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		wantSame bool
+	}{
+		{name: "identical", a: "^1.2.3", b: "^1.2.3", wantSame: true},
+		{name: "constraint order", a: ">=1.0.0, <2.0.0", b: "<2.0.0, >=1.0.0", wantSame: true},
+		{name: "partial caret", a: "^1.2", b: "^1.2.0", wantSame: true},
+		{name: "duplicates", a: "^1.2, ^1.2", b: "^1.2", wantSame: true},
+		{name: "tilde precision", a: "~1", b: "~1.0"},
+		{name: "tilde partial", a: "~1.2", b: "~1.2.0"},
+		{name: "zero caret precision", a: "^0", b: "^0.0"},
+		{name: "partial zero-major caret", a: "^0.1", b: "^0.1.0", wantSame: true},
+		{name: "different version", a: "^1.2.3", b: "^1.2.4"},
+		{name: "caret versus tilde", a: "^1.2.3", b: "~1.2.3"},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := e.NewVersionRange(tt.a)
+			if err != nil {
+				t.Fatalf("NewVersionRange(%q) error = %v", tt.a, err)
+			}
+			b, err := e.NewVersionRange(tt.b)
+			if err != nil {
+				t.Fatalf("NewVersionRange(%q) error = %v", tt.b, err)
+			}
+			if got := a.Hash() == b.Hash(); got != tt.wantSame {
+				t.Errorf("Hash(%q) == Hash(%q) = %v, want %v", tt.a, tt.b, got, tt.wantSame)
+			}
+		})
+	}
+}
@@ -2,7 +2,10 @@ package cargo
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 // VersionRange represents a Cargo version range with Cargo-specific syntax support
@@ -15,19 +18,43 @@ type VersionRange struct {
 type constraint struct {
 	operator  string
 	version   *Version
-	precision int // number of version components in original constraint (for tilde)
+	precision int // number of version components that matter (for tilde and caret)
+}
+
+// features declares the range syntax accepted by NewVersionRange
+var features = []univers.Feature{
+	{Name: univers.FeatureComparison, Syntax: ">=1.2.0", Description: "Ordering operators >=, >, <=, < and ="},
+	{Name: univers.FeatureNotEqual, Syntax: "!=1.2.1", Description: "Excludes a single version"},
+	{Name: univers.FeatureExact, Syntax: "=1.2.3", Description: "Matches only that version; =1.2 matches any 1.2.x"},
+	{Name: univers.FeatureCaret, Syntax: "^1.2.3", Description: "Allows changes that keep the left-most non-zero component; a bare 1.2.3 means the same"},
+	{Name: univers.FeatureTilde, Syntax: "~1.2", Description: "Allows changes to components after the ones given"},
+	{Name: univers.FeatureWildcard, Syntax: "1.2.*", Description: "Matches any value for the starred component"},
+	{Name: univers.FeatureAnd, Syntax: ">=1.2.0, <1.5.0", Description: "Comma-separated constraints must all match"},
+}
+
+// Features returns the range syntax features supported by NewVersionRange
+func (e *Ecosystem) Features() []univers.Feature {
+	return slices.Clone(features)
 }
 
 // NewVersionRange creates a new Cargo version range from a range string
 func (e *Ecosystem) NewVersionRange(rangeStr string) (*VersionRange, error) {
+	if err := univers.CheckLength(rangeStr); err != nil {
+		return nil, err
+	}
+
 	original := rangeStr
 	rangeStr = strings.TrimSpace(rangeStr)
 	if rangeStr == "" {
-		return nil, fmt.Errorf("empty range string")
+		return nil, univers.WrapParseError(original, fmt.Errorf("empty range string"))
 	}
 
 	constraints, err := parseConstraints(rangeStr, e)
 	if err != nil {
+		return nil, univers.WrapParseError(original, err)
+	}
+
+	if err := univers.CheckConstraints(len(constraints)); err != nil {
 		return nil, err
 	}
 
@@ -43,7 +70,11 @@ func parseConstraints(rangeStr string, ecosystem *Ecosystem) ([]*constraint, err
 	parts := strings.Split(rangeStr, ",")
 	var constraints []*constraint
 
+	offset := 0
 	for _, part := range parts {
+		start := offset + strings.Index(rangeStr[offset:], part)
+		offset = start + len(part)
+
 		part = strings.TrimSpace(part)
 		if part == "" {
 			continue
@@ -51,7 +82,7 @@ func parseConstraints(rangeStr string, ecosystem *Ecosystem) ([]*constraint, err
 
 		constraint, err := parseConstraint(part, ecosystem)
 		if err != nil {
-			return nil, err
+			return nil, univers.TokenErrorAt(rangeStr, start, part, err)
 		}
 		constraints = append(constraints, constraint)
 	}
@@ -69,13 +100,11 @@ func parseConstraint(constraintStr string, ecosystem *Ecosystem) (*constraint, e
 
 	// Handle caret constraints: ^1.2.3, ^1.2, ^1
 	if strings.HasPrefix(constraintStr, "^") {
-		version := strings.TrimSpace(constraintStr[1:])
-		normalizedVersion := normalizePartialVersion(version)
-		parsedVersion, err := ecosystem.NewVersion(normalizedVersion)
+		c, err := desugarCaret(strings.TrimSpace(constraintStr[1:]), ecosystem)
 		if err != nil {
 			return nil, fmt.Errorf("invalid version in caret constraint: %v", err)
 		}
-		return &constraint{operator: "^", version: parsedVersion, precision: 3}, nil
+		return c, nil
 	}
 
 	// Handle tilde constraints: ~1.2.3, ~1.2, ~1
@@ -91,7 +120,7 @@ func parseConstraint(constraintStr string, ecosystem *Ecosystem) (*constraint, e
 		return &constraint{operator: "~", version: parsedVersion, precision: precision}, nil
 	}
 
-	// Handle comparison operators
+	// Handle comparison operators, whose versions may be partial
 	operators := []string{">=", "<=", "!=", ">", "<", "="}
 	for _, op := range operators {
 		if strings.HasPrefix(constraintStr, op) {
@@ -99,11 +128,11 @@ func parseConstraint(constraintStr string, ecosystem *Ecosystem) (*constraint, e
 			if version == "" {
 				return nil, fmt.Errorf("constraint %s requires version", op)
 			}
-			parsedVersion, err := ecosystem.NewVersion(version)
+			c, err := desugarComparison(op, version, ecosystem)
 			if err != nil {
 				return nil, fmt.Errorf("invalid version in %s constraint: %v", op, err)
 			}
-			return &constraint{operator: op, version: parsedVersion, precision: 3}, nil
+			return c, nil
 		}
 	}
 
@@ -112,12 +141,8 @@ func parseConstraint(constraintStr string, ecosystem *Ecosystem) (*constraint, e
 		return convertWildcardToStandardConstraint(constraintStr, ecosystem)
 	}
 
-	// Default to exact match
-	parsedVersion, err := ecosystem.NewVersion(constraintStr)
-	if err != nil {
-		return nil, fmt.Errorf("invalid version in exact constraint: %v", err)
-	}
-	return &constraint{operator: "=", version: parsedVersion, precision: 3}, nil
+	// A bare version defaults to caret
+	return desugarBare(constraintStr, ecosystem)
 }
 
 // convertWildcardToStandardConstraint converts wildcard patterns to equivalent standard constraints
@@ -144,13 +169,13 @@ func convertWildcardToStandardConstraint(constraintStr string, ecosystem *Ecosys
 	components := strings.Split(baseVersion, ".")
 
 	switch len(components) {
-	case 1: // 1.* is equivalent to ^1.0.0
+	case 1: // 1.* is equivalent to ^1, and 0.* to ^0
 		normalizedVersion := normalizePartialVersion(baseVersion)
 		parsedVersion, err := ecosystem.NewVersion(normalizedVersion)
 		if err != nil {
 			return nil, fmt.Errorf("invalid wildcard constraint: %v", err)
 		}
-		return &constraint{operator: "^", version: parsedVersion, precision: 3}, nil
+		return &constraint{operator: "^", version: parsedVersion, precision: caretPrecision(parsedVersion, 1)}, nil
 
 	case 2: // 1.2.* is equivalent to ~1.2.0
 		normalizedVersion := normalizePartialVersion(baseVersion)
@@ -170,7 +195,11 @@ func (vr *VersionRange) String() string {
 	return vr.original
 }
 
-// Contains checks if a version satisfies this range
+// Contains checks if a version satisfies this range. As in Cargo, a
+// pre-release only satisfies a range that opts into pre-releases of its
+// release: some constraint must name a pre-release with the same major, minor
+// and patch, so ">=1.0.0-alpha" matches "1.0.0-beta" but ">=1.0.0" and
+// ">=0.9.0-alpha" do not match "1.1.0-alpha".
 func (vr *VersionRange) Contains(version *Version) bool {
 	// All constraints must be satisfied (AND logic)
 	for _, c := range vr.constraints {
@@ -179,7 +208,19 @@ func (vr *VersionRange) Contains(version *Version) bool {
 		}
 	}
 
-	return true
+	return version.prerelease == "" || vr.allowsPrerelease(version)
+}
+
+// allowsPrerelease reports whether some constraint names a pre-release of
+// the same major.minor.patch as version
+func (vr *VersionRange) allowsPrerelease(version *Version) bool {
+	for _, c := range vr.constraints {
+		v := c.version
+		if v.prerelease != "" && v.major == version.major && v.minor == version.minor && v.patch == version.patch {
+			return true
+		}
+	}
+	return false
 }
 
 // satisfiesConstraint checks if a version satisfies a single constraint
@@ -198,7 +239,7 @@ func satisfiesConstraint(version *Version, c *constraint) bool {
 	case "<=":
 		return version.Compare(c.version) <= 0
 	case "^":
-		return satisfiesCaretConstraint(version, c.version)
+		return satisfiesCaretConstraint(version, c.version, c.precision)
 	case "~":
 		return satisfiesTildeConstraint(version, c.version, c.precision)
 	default:
@@ -207,8 +248,10 @@ func satisfiesConstraint(version *Version, c *constraint) bool {
 }
 
 // satisfiesCaretConstraint checks if version satisfies caret constraint (^1.2.3)
-// Caret allows changes that do not modify the left-most non-zero digit
-func satisfiesCaretConstraint(version, constraint *Version) bool {
+// Caret allows changes that do not modify the left-most non-zero digit, or
+// the last given one when all are zero: ^0.0 := >=0.0.0 <0.1.0 and
+// ^0 := >=0.0.0 <1.0.0
+func satisfiesCaretConstraint(version, constraint *Version, precision int) bool {
 	// Must be >= constraint version
 	if version.Compare(constraint) < 0 {
 		return false
@@ -220,7 +263,7 @@ func satisfiesCaretConstraint(version, constraint *Version) bool {
 	}
 
 	// If major > 0, minor and patch can be anything >= constraint
-	if constraint.major > 0 {
+	if constraint.major > 0 || precision == 1 {
 		return true
 	}
 
@@ -230,7 +273,7 @@ func satisfiesCaretConstraint(version, constraint *Version) bool {
 	}
 
 	// If major == 0 and minor > 0, patch can be anything >= constraint
-	if constraint.minor > 0 {
+	if constraint.minor > 0 || precision == 2 {
 		return true
 	}
 
@@ -267,17 +310,64 @@ func satisfiesTildeConstraint(version, constraint *Version, precision int) bool
 	}
 }
 
+// Comparators returns the constraints as a single AND group, with caret and
+// tilde constraints expanded to a lower and an upper bound. Upper bounds carry
+// a "-0" prerelease so that prereleases of the excluded release stay excluded.
+func (vr *VersionRange) Comparators() [][]univers.Comparator {
+	group := make([]univers.Comparator, 0, len(vr.constraints))
+	for _, c := range vr.constraints {
+		v := c.version
+		lower := univers.Comparator{Operator: ">=", Version: v.String()}
+		switch {
+		case c.operator == "^" && (v.major > 0 || c.precision == 1):
+			group = append(group, lower, univers.Comparator{Operator: "<", Version: fmt.Sprintf("%d.0.0-0", v.major+1)})
+		case c.operator == "^" && (v.minor > 0 || c.precision == 2):
+			group = append(group, lower, univers.Comparator{Operator: "<", Version: fmt.Sprintf("0.%d.0-0", v.minor+1)})
+		case c.operator == "^":
+			group = append(group, lower, univers.Comparator{Operator: "<", Version: fmt.Sprintf("0.0.%d-0", v.patch+1)})
+		case c.operator == "~" && c.precision == 1:
+			group = append(group, lower, univers.Comparator{Operator: "<", Version: fmt.Sprintf("%d.0.0-0", v.major+1)})
+		case c.operator == "~":
+			group = append(group, lower, univers.Comparator{Operator: "<", Version: fmt.Sprintf("%d.%d.0-0", v.major, v.minor+1)})
+		default:
+			group = append(group, univers.Comparator{Operator: c.operator, Version: v.String()})
+		}
+	}
+	return [][]univers.Comparator{group}
+}
+
 // normalizePartialVersion converts partial versions to full versions
-// e.g., "1.2" -> "1.2.0", "1" -> "1.0.0"
+// e.g., "1.2" -> "1.2.0", "1" -> "1.0.0". A pre-release or build suffix is
+// kept, so "1.2.3-beta.2" is unchanged.
 func normalizePartialVersion(version string) string {
-	parts := strings.Split(version, ".")
+	release, suffix := splitRelease(version)
+	parts := strings.Split(release, ".")
 
 	// Ensure we have exactly 3 parts
 	for len(parts) < 3 {
 		parts = append(parts, "0")
 	}
 
-	return strings.Join(parts[:3], ".")
+	return strings.Join(parts[:3], ".") + suffix
+}
+
+// splitRelease splits version before its pre-release or build suffix
+func splitRelease(version string) (release, suffix string) {
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		return version[:i], version[i:]
+	}
+	return version, ""
+}
+
+// caretPrecision returns the number of components of a caret requirement
+// that affect its meaning: only "^0" and "^0.0" differ from the same
+// requirement with all three components given, so every other caret
+// requirement has precision 3
+func caretPrecision(v *Version, components int) int {
+	if v.major > 0 || (components >= 2 && v.minor > 0) {
+		return 3
+	}
+	return min(components, 3)
 }
 
 // countVersionComponents counts the number of version components in a string
@@ -286,5 +376,6 @@ func countVersionComponents(version string) int {
 	if version == "" {
 		return 0
 	}
-	return len(strings.Split(version, "."))
+	release, _ := splitRelease(version)
+	return len(strings.Split(release, "."))
 }
@@ -1,7 +1,10 @@
 package cargo
 
 import (
+	"slices"
 	"testing"
+
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 func TestEcosystem_NewVersionRange(t *testing.T) {
@@ -11,7 +14,8 @@ func TestEcosystem_NewVersionRange(t *testing.T) {
 		wantErr bool
 	}{
 		// Basic ranges
-		{name: "exact version", input: "1.2.3", wantErr: false},
+		{name: "bare version", input: "1.2.3", wantErr: false},
+		{name: "exact version", input: "=1.2.3", wantErr: false},
 		{name: "caret range", input: "^1.2.3", wantErr: false},
 		{name: "tilde range", input: "~1.2.3", wantErr: false},
 		{name: "greater than", input: ">1.2.3", wantErr: false},
@@ -29,6 +33,11 @@ func TestEcosystem_NewVersionRange(t *testing.T) {
 		{name: "tilde partial ~1", input: "~1", wantErr: false},
 		{name: "caret partial ^1.2", input: "^1.2", wantErr: false},
 		{name: "caret partial ^1", input: "^1", wantErr: false},
+		{name: "bare partial", input: "1.2", wantErr: false},
+		{name: "equal partial", input: "=1.2", wantErr: false},
+		{name: "greater partial", input: ">1", wantErr: false},
+		{name: "not equal partial", input: "!=1.2", wantErr: true},
+		{name: "invalid partial", input: "<=1.x", wantErr: true},
 
 		// Wildcard constraints
 		{name: "wildcard 1.2.*", input: "1.2.*", wantErr: false},
@@ -61,8 +70,10 @@ func TestVersionRange_Contains(t *testing.T) {
 		want     bool
 	}{
 		// Exact matches
-		{name: "exact match", rangeStr: "1.2.3", version: "1.2.3", want: true},
-		{name: "exact no match", rangeStr: "1.2.3", version: "1.2.4", want: false},
+		{name: "exact match", rangeStr: "=1.2.3", version: "1.2.3", want: true},
+		{name: "exact no match", rangeStr: "=1.2.3", version: "1.2.4", want: false},
+		{name: "bare version is caret", rangeStr: "1.2.3", version: "1.9.0", want: true},
+		{name: "bare version is caret below", rangeStr: "1.2.3", version: "1.2.2", want: false},
 
 		// Comparison operators
 		{name: "greater than - true", rangeStr: ">1.2.3", version: "1.2.4", want: true},
@@ -145,3 +156,151 @@ func TestVersionRange_Contains(t *testing.T) {
 		})
 	}
 }
+
+// TestVersionRange_Contains_SemverCrate mirrors the requirement tests of the
+// semver crate Cargo uses.
+func TestVersionRange_Contains_SemverCrate(t *testing.T) {
+	tests := []struct {
+		rangeStr string
+		matches  []string
+		rejects  []string
+	}{
+		// test_exact
+		{"=1.0.0", []string{"1.0.0"}, []string{"1.0.1", "0.9.9", "0.10.0", "0.1.0", "1.0.0-pre"}},
+		{"=0.9.0", []string{"0.9.0"}, []string{"0.9.1", "1.9.0", "0.0.9", "0.9.0-pre"}},
+		{"=0.0.2", []string{"0.0.2"}, []string{"0.0.1", "0.0.3", "0.0.2-pre"}},
+		{"=0.1.0-beta2.a", []string{"0.1.0-beta2.a"}, []string{"0.9.1", "0.1.0", "0.1.1-beta2.a", "0.1.0-beta2"}},
+		{"=0.1.0+meta", []string{"0.1.0", "0.1.0+meta", "0.1.0+any"}, nil},
+		// test_greater_than
+		{">= 1.0.0", []string{"1.0.0", "2.0.0"}, []string{"0.1.0", "0.0.1", "1.0.0-pre", "2.0.0-pre"}},
+		{">= 2.1.0-alpha2", []string{"2.1.0-alpha2", "2.1.0-alpha3", "2.1.0", "3.0.0"}, []string{"2.0.0", "2.1.0-alpha1", "2.0.0-alpha2", "3.0.0-alpha2"}},
+		// test_less_than
+		{"< 1.0.0", []string{"0.1.0", "0.0.1"}, []string{"1.0.0", "1.0.0-beta", "1.0.1", "0.9.9-alpha"}},
+		{"<= 2.1.0-alpha2", []string{"2.1.0-alpha2", "2.1.0-alpha1", "2.0.0", "1.0.0"}, []string{"2.1.0", "2.2.0-alpha1", "2.0.0-alpha2", "1.0.0-alpha2"}},
+		{">1.0.0-alpha, <1.0.0", []string{"1.0.0-beta"}, nil},
+		// test_multiple
+		{"> 0.0.9, <= 2.5.3", []string{"0.0.10", "1.0.0", "2.5.3"}, []string{"0.0.8", "2.5.4"}},
+		{"<= 0.2.0, >= 0.5.0", nil, []string{"0.0.0", "0.3.0", "0.6.0"}},
+		{">= 0.5.1-alpha3, < 0.6.0", []string{"0.5.1-alpha3", "0.5.1-alpha4", "0.5.1-beta", "0.5.1", "0.5.5"}, []string{"0.5.1-alpha1", "0.5.2-alpha3", "0.5.5-pre", "0.5.0-pre", "0.6.0", "0.6.0-pre"}},
+		// test_pre
+		{"=2.1.1-really.0", []string{"2.1.1-really.0"}, nil},
+		// test_tilde
+		{"~1", []string{"1.0.0", "1.0.1", "1.1.1"}, []string{"0.9.1", "2.9.0", "0.0.9"}},
+		{"~1.2", []string{"1.2.0", "1.2.1"}, []string{"1.1.1", "1.3.0", "0.0.9"}},
+		{"~1.2.2", []string{"1.2.2", "1.2.4"}, []string{"1.2.1", "1.9.0", "1.0.9", "2.0.1", "0.1.3"}},
+		{"~1.2.3-beta.2", []string{"1.2.3", "1.2.4", "1.2.3-beta.2", "1.2.3-beta.4"}, []string{"1.3.3", "1.1.4", "1.2.3-beta.1", "1.2.4-beta.2"}},
+		// test_caret
+		{"^1", []string{"1.1.2", "1.1.0", "1.2.1", "1.0.1"}, []string{"0.9.1", "2.9.0", "0.1.4"}},
+		{"^1.1", []string{"1.1.2", "1.1.0", "1.2.1"}, []string{"0.9.1", "2.9.0", "1.0.1", "0.1.4"}},
+		{"^1.1.2", []string{"1.1.2", "1.1.4", "1.2.1"}, []string{"0.9.1", "2.9.0", "1.1.1", "0.0.1", "1.1.2-alpha1", "1.1.3-alpha1", "2.9.0-alpha1"}},
+		{"^0.1.2", []string{"0.1.2", "0.1.4"}, []string{"0.9.1", "2.9.0", "1.1.1", "0.1.0", "0.1.2-beta", "0.1.3-alpha", "0.2.0-pre"}},
+		{"^0.5.1-alpha3", []string{"0.5.1-alpha3", "0.5.1-alpha4", "0.5.1-beta", "0.5.1", "0.5.5"}, []string{"0.5.1-alpha1", "0.5.2-alpha3", "0.5.5-pre", "0.5.0-pre", "0.6.0"}},
+		{"^0.0.2", []string{"0.0.2"}, []string{"0.9.1", "2.9.0", "1.1.1", "0.0.1", "0.1.4"}},
+		{"^0.0", []string{"0.0.2", "0.0.0"}, []string{"0.9.1", "2.9.0", "1.1.1", "0.1.4"}},
+		{"^0", []string{"0.9.1", "0.0.2", "0.0.0"}, []string{"2.9.0", "1.1.1"}},
+		{"^1.4.2-beta.5", []string{"1.4.2", "1.4.3", "1.4.2-beta.5", "1.4.2-beta.6", "1.4.2-c"}, []string{"0.9.9", "2.0.0", "1.4.2-alpha", "1.4.2-beta.4", "1.4.3-beta.5"}},
+		// test_wildcard
+		{"*", []string{"0.9.1", "2.9.0", "0.0.9", "1.0.1", "1.1.1"}, []string{"1.0.0-pre"}},
+		{"1.*", []string{"1.2.0", "1.2.1", "1.1.1", "1.3.0"}, []string{"0.0.9", "1.2.0-pre"}},
+		{"1.2.*", []string{"1.2.0", "1.2.2", "1.2.4"}, []string{"1.9.0", "1.0.9", "2.0.1", "0.1.3"}},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.rangeStr, func(t *testing.T) {
+			vr, err := e.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("Ecosystem.NewVersionRange() error = %v", err)
+			}
+			for _, want := range []bool{true, false} {
+				versions := tt.matches
+				if !want {
+					versions = tt.rejects
+				}
+				for _, s := range versions {
+					v, err := e.NewVersion(s)
+					if err != nil {
+						t.Fatalf("Ecosystem.NewVersion(%q) error = %v", s, err)
+					}
+					if got := vr.Contains(v); got != want {
+						t.Errorf("VersionRange.Contains(%s) = %v, want %v", s, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestEcosystem_Features(t *testing.T) {
+	e := &Ecosystem{}
+	for _, f := range e.Features() {
+		if _, err := e.NewVersionRange(f.Syntax); err != nil {
+			t.Errorf("Features() %s syntax %q does not parse: %v", f.Name, f.Syntax, err)
+		}
+	}
+}
+
+func TestVersionRange_Comparators(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		want     []string
+	}{
+		{name: "caret", rangeStr: "^1.2.3", want: []string{">=1.2.3", "<2.0.0-0"}},
+		{name: "caret zero major", rangeStr: "^0.2.3", want: []string{">=0.2.3", "<0.3.0-0"}},
+		{name: "caret zero minor", rangeStr: "^0.0.3", want: []string{">=0.0.3", "<0.0.4-0"}},
+		{name: "caret zero major only", rangeStr: "^0", want: []string{">=0.0.0", "<1.0.0-0"}},
+		{name: "caret zero minor only", rangeStr: "^0.0", want: []string{">=0.0.0", "<0.1.0-0"}},
+		{name: "tilde major only", rangeStr: "~1", want: []string{">=1.0.0", "<2.0.0-0"}},
+		{name: "tilde", rangeStr: "~1.2.3", want: []string{">=1.2.3", "<1.3.0-0"}},
+		{name: "wildcard", rangeStr: "1.2.*", want: []string{">=1.2.0", "<1.3.0-0"}},
+		{name: "comparisons", rangeStr: ">=1.0.0, !=1.2.0", want: []string{">=1.0.0", "!=1.2.0"}},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vr, err := e.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("Ecosystem.NewVersionRange() error = %v", err)
+			}
+			groups := vr.Comparators()
+			if len(groups) != 1 {
+				t.Fatalf("VersionRange.Comparators() = %v, want one group", groups)
+			}
+			var got []string
+			for _, c := range groups[0] {
+				got = append(got, c.String())
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("VersionRange.Comparators() = %v, want %v", got, tt.want)
+			}
+
+			// The expanded comparators match exactly what the range does
+			for _, s := range []string{"0.0.3", "0.0.4-alpha", "0.2.9", "0.3.0-0", "1.0.0", "1.2.0", "1.2.9", "1.3.0-rc.1", "1.9.0", "2.0.0-0", "2.0.0"} {
+				v, err := e.NewVersion(s)
+				if err != nil {
+					t.Fatalf("Failed to parse version %s: %v", s, err)
+				}
+				if got, want := comparatorsContain(t, e, groups[0], v), vr.Contains(v); got != want {
+					t.Errorf("comparators contain %s = %v, range contains %v", s, got, want)
+				}
+			}
+		})
+	}
+}
+
+// comparatorsContain reports whether v satisfies every comparator
+func comparatorsContain(t *testing.T, e *Ecosystem, group []univers.Comparator, v *Version) bool {
+	t.Helper()
+	for _, c := range group {
+		vr, err := e.NewVersionRange(c.String())
+		if err != nil {
+			t.Fatalf("Ecosystem.NewVersionRange(%q) error = %v", c.String(), err)
+		}
+		if !vr.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
@@ -5,6 +5,9 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/alowayed/go-univers/internal/digits"
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 // versionPattern matches Cargo version strings following SemVer 2.0 specification
@@ -23,6 +26,10 @@ type Version struct {
 
 // NewVersion creates a new Cargo version from a string
 func (e *Ecosystem) NewVersion(version string) (*Version, error) {
+	if err := univers.CheckLength(version); err != nil {
+		return nil, err
+	}
+
 	original := version
 	// Trim whitespace first
 	version = strings.TrimSpace(version)
@@ -64,11 +71,48 @@ func (e *Ecosystem) NewVersion(version string) (*Version, error) {
 	}, nil
 }
 
+// ValidVersion reports whether NewVersion accepts version, without
+// allocating or building a Version. Use it to filter crate versions at high
+// throughput when they are not going to be compared.
+func ValidVersion(version string) bool {
+	if univers.CheckLength(version) != nil {
+		return false
+	}
+	version = strings.TrimSpace(version)
+	if !versionPattern.MatchString(version) {
+		return false
+	}
+
+	// The pattern guarantees that major, minor and patch lead the version
+	major, rest, _ := strings.Cut(version, ".")
+	minor, rest, _ := strings.Cut(rest, ".")
+	patch := rest
+	if i := strings.IndexAny(rest, "-+"); i >= 0 {
+		patch = rest[:i]
+	}
+	return digits.FitsInt(major) && digits.FitsInt(minor) && digits.FitsInt(patch)
+}
+
 // String returns the string representation of the version
 func (v *Version) String() string {
 	return v.original
 }
 
+// PrereleaseChannel returns the normalized channel of the prerelease, such as
+// "alpha" for 0.12.0-alpha.4, or "" for a release. See
+// univers.SplitPrerelease.
+func (v *Version) PrereleaseChannel() string {
+	channel, _, _ := univers.SplitPrerelease(v.prerelease)
+	return channel
+}
+
+// PrereleaseNumber returns the number after the prerelease channel, such as 4
+// for 0.12.0-alpha.4, and whether there is one.
+func (v *Version) PrereleaseNumber() (int, bool) {
+	_, number, ok := univers.SplitPrerelease(v.prerelease)
+	return number, ok
+}
+
 // Compare compares this version with another Cargo version following SemVer 2.0 rules
 func (v *Version) Compare(other *Version) int {
 	// 1. Compare major.minor.patch numerically
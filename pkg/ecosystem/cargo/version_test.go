@@ -273,3 +273,33 @@ func equalVersions(a, b *Version) bool {
 		a.build == b.build &&
 		a.original == b.original
 }
+
+func TestVersion_PrereleaseChannel(t *testing.T) {
+	tests := []struct {
+		version     string
+		wantChannel string
+		wantNumber  int
+		wantOK      bool
+	}{
+		{"0.12.0", "", 0, false},
+		{"0.12.0-alpha.4", "alpha", 4, true},
+		{"1.0.0-rc1", "rc", 1, true},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			v, err := e.NewVersion(tt.version)
+			if err != nil {
+				t.Fatalf("NewVersion(%q) error = %v", tt.version, err)
+			}
+			if got := v.PrereleaseChannel(); got != tt.wantChannel {
+				t.Errorf("PrereleaseChannel() = %q, want %q", got, tt.wantChannel)
+			}
+			number, ok := v.PrereleaseNumber()
+			if number != tt.wantNumber || ok != tt.wantOK {
+				t.Errorf("PrereleaseNumber() = %d, %v, want %d, %v", number, ok, tt.wantNumber, tt.wantOK)
+			}
+		})
+	}
+}
@@ -1,8 +1,10 @@
 // Package composer provides functionality for working with Composer (PHP) package versions.
 package composer
 
+import "github.com/alowayed/go-univers/pkg/univers"
+
 const (
-	Name = "composer"
+	Name = string(univers.Composer)
 )
 
 type Ecosystem struct{}
@@ -0,0 +1,62 @@
+package composer_test
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/composer"
+)
+
+// Stability flags order dev < alpha < beta < RC < stable.
+func ExampleVersion_Compare() {
+	e := &composer.Ecosystem{}
+
+	var versions []*composer.Version
+	for _, s := range []string{"1.0.0", "1.0.0-RC1", "1.0.0-beta2", "1.0.0-alpha1", "1.0.0-beta10"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		versions = append(versions, v)
+	}
+	slices.SortFunc(versions, (*composer.Version).Compare)
+	fmt.Println(versions)
+	// Output:
+	// [1.0.0-alpha1 1.0.0-beta2 1.0.0-beta10 1.0.0-RC1 1.0.0]
+}
+
+// Alternatives are separated by ||.
+func ExampleVersionRange_Contains() {
+	e := &composer.Ecosystem{}
+
+	r, err := e.NewVersionRange("^1.2 || ^2.0")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, s := range []string{"1.9.0", "2.5.1", "3.0.0"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(s, r.Contains(v))
+	}
+	// Output:
+	// 1.9.0 true
+	// 2.5.1 true
+	// 3.0.0 false
+}
+
+func ExampleVersion_PrereleaseChannel() {
+	v, err := (&composer.Ecosystem{}).NewVersion("2.0.0-RC3")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	n, ok := v.PrereleaseNumber()
+	fmt.Println(v.PrereleaseChannel(), n, ok)
+	// Output:
+	// rc 3 true
+}
@@ -2,8 +2,13 @@ package composer
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 // VersionRange represents a Composer version range with Composer-specific syntax support
@@ -17,17 +22,78 @@ type constraint struct {
 	operator  string
 	version   *Version // Store parsed version to avoid re-parsing in matches()
 	stability string   // Store stability flag for stability-only constraints
+
+	// derive computes version on first use for bounds derived from the
+	// constraint (e.g. the <2.0.0 of ~1.2), so parsing never builds them
+	derive func() *Version
+	once   sync.Once
+}
+
+// bound returns the constraint version, deriving it on first use if needed
+func (c *constraint) bound() *Version {
+	if c.derive != nil {
+		c.once.Do(func() { c.version = c.derive() })
+	}
+	return c.version
+}
+
+// derivedBound returns a constraint whose stable major.minor.patch version is
+// only built when the constraint is first matched
+func derivedBound(operator string, major, minor, patch int) *constraint {
+	return &constraint{
+		operator: operator,
+		derive:   func() *Version { return stableVersion(major, minor, patch) },
+	}
+}
+
+// stableVersion builds a stable major.minor.patch version without parsing
+func stableVersion(major, minor, patch int) *Version {
+	return &Version{
+		major:     major,
+		minor:     minor,
+		patch:     patch,
+		stability: stabilityStable,
+		original:  strconv.Itoa(major) + "." + strconv.Itoa(minor) + "." + strconv.Itoa(patch),
+	}
+}
+
+// features declares the range syntax accepted by NewVersionRange
+var features = []univers.Feature{
+	{Name: univers.FeatureComparison, Syntax: ">=1.0 <2.0", Description: "Ordering operators >=, >, <=, <, = and =="},
+	{Name: univers.FeatureNotEqual, Syntax: "!=1.0.1", Description: "Excludes a single version; <> is an alias"},
+	{Name: univers.FeatureExact, Syntax: "1.0.2", Description: "A bare version matches only itself, as does an aliased one such as dev-main as 1.0.x-dev"},
+	{Name: univers.FeatureAnd, Syntax: ">=1.0, <1.1", Description: "Space- or comma-separated constraints must all match"},
+	{Name: univers.FeatureOr, Syntax: "^1.0 || ^2.0", Description: "Matches if any group separated by || or | matches"},
+	{Name: univers.FeatureCaret, Syntax: "^1.2.3", Description: "Allows changes up to the next major release, or next minor for 0.x"},
+	{Name: univers.FeatureTilde, Syntax: "~1.2", Description: "Allows the last given component to increase"},
+	{Name: univers.FeatureWildcard, Syntax: "1.0.*", Description: "Matches any value for the starred component"},
+	{Name: univers.FeatureHyphen, Syntax: "1.0 - 2.0", Description: "Inclusive range between two versions"},
+	{Name: univers.FeatureStability, Syntax: "@stable", Description: "Matches versions with the given stability flag, optionally pinned to a version as in 1.2.3@dev"},
+}
+
+// Features returns the range syntax features supported by NewVersionRange
+func (e *Ecosystem) Features() []univers.Feature {
+	return slices.Clone(features)
 }
 
 // NewVersionRange creates a new Composer version range from a range string
 func (e *Ecosystem) NewVersionRange(rangeStr string) (*VersionRange, error) {
+	if err := univers.CheckLength(rangeStr); err != nil {
+		return nil, err
+	}
+
+	input := rangeStr
 	rangeStr = strings.TrimSpace(rangeStr)
 	if rangeStr == "" {
-		return nil, fmt.Errorf("empty range string")
+		return nil, univers.WrapParseError(input, fmt.Errorf("empty range string"))
 	}
 
-	constraintGroups, err := parseRangeGroups(rangeStr)
+	constraintGroups, err := e.parseRangeGroups(rangeStr)
 	if err != nil {
+		return nil, univers.WrapParseError(input, err)
+	}
+
+	if err := univers.CheckConstraintGroups(constraintGroups); err != nil {
 		return nil, err
 	}
 
@@ -37,50 +103,65 @@ func (e *Ecosystem) NewVersionRange(rangeStr string) (*VersionRange, error) {
 	}, nil
 }
 
-// parseRangeGroups parses Composer range syntax into constraint groups for OR logic
-func parseRangeGroups(rangeStr string) ([][]*constraint, error) {
-	// Handle OR logic (||) - each OR'd part becomes a separate group
-	if strings.Contains(rangeStr, "||") {
-		parts := strings.Split(rangeStr, "||")
-		var constraintGroups [][]*constraint
-		for _, part := range parts {
-			constraints, err := parseRange(strings.TrimSpace(part))
-			if err != nil {
-				return nil, err
-			}
-			constraintGroups = append(constraintGroups, constraints)
+// parseRangeGroups parses Composer range syntax into constraint groups for OR
+// logic. Composer separates groups with "||" or a single "|", and older
+// composer.json files still use the latter.
+func (e *Ecosystem) parseRangeGroups(rangeStr string) ([][]*constraint, error) {
+	// Single group (no OR logic), the common case, avoids splitting
+	if !strings.Contains(rangeStr, "|") {
+		constraints, err := e.parseRange(rangeStr)
+		if err != nil {
+			return nil, err
 		}
-		return constraintGroups, nil
+		return [][]*constraint{constraints}, nil
 	}
 
-	// Single group (no OR logic)
-	constraints, err := parseRange(rangeStr)
-	if err != nil {
-		return nil, err
+	// Handle OR logic (|| or |) - each OR'd part becomes a separate group
+	constraintGroups := make([][]*constraint, 0, strings.Count(rangeStr, "|")+1)
+	for offset := 0; ; {
+		end := strings.Index(rangeStr[offset:], "|")
+		part := rangeStr[offset:]
+		if end >= 0 {
+			part = rangeStr[offset : offset+end]
+		}
+
+		trimmed := strings.TrimSpace(part)
+		constraints, err := e.parseRange(trimmed)
+		if err != nil {
+			return nil, univers.TokenErrorAt(rangeStr, offset, trimmed, err)
+		}
+		constraintGroups = append(constraintGroups, constraints)
+
+		if end < 0 {
+			return constraintGroups, nil
+		}
+		offset += end + len("|")
+		if strings.HasPrefix(rangeStr[offset:], "|") {
+			offset += len("|")
+		}
 	}
-	return [][]*constraint{constraints}, nil
 }
 
 // parseRange parses Composer range syntax into constraints
-func parseRange(rangeStr string) ([]*constraint, error) {
+func (e *Ecosystem) parseRange(rangeStr string) ([]*constraint, error) {
 	rangeStr = strings.TrimSpace(rangeStr)
 
 	// Handle hyphen ranges (1.2.3 - 2.3.4)
 	if strings.Contains(rangeStr, " - ") {
-		return parseHyphenRange(rangeStr)
+		return e.parseHyphenRange(rangeStr)
 	}
 
 	// Handle space/comma-separated constraints (>=1.0.0 <2.0.0 or >=1.0.0, <2.0.0)
-	if strings.Contains(rangeStr, " ") || strings.Contains(rangeStr, ",") {
-		return parseSpaceSeparatedConstraints(rangeStr)
+	if strings.ContainsAny(rangeStr, " ,") {
+		return e.parseSpaceSeparatedConstraints(rangeStr)
 	}
 
 	// Handle single constraint
-	return parseSingleConstraint(rangeStr)
+	return e.parseSingleConstraint(rangeStr)
 }
 
 // parseSingleConstraint parses a single Composer constraint
-func parseSingleConstraint(c string) ([]*constraint, error) {
+func (e *Ecosystem) parseSingleConstraint(c string) ([]*constraint, error) {
 	c = strings.TrimSpace(c)
 
 	// Handle wildcard
@@ -90,16 +171,17 @@ func parseSingleConstraint(c string) ([]*constraint, error) {
 
 	// Handle caret constraint (^1.2.3)
 	if strings.HasPrefix(c, "^") {
-		return parseCaretConstraint(c[1:])
+		return e.parseCaretConstraint(c[1:])
 	}
 
 	// Handle tilde constraint (~1.2.3)
 	if strings.HasPrefix(c, "~") {
-		return parseTildeConstraint(c[1:])
+		return e.parseTildeConstraint(c[1:])
 	}
 
-	// Handle wildcard constraint (1.2.* or 1.x)
-	if strings.Contains(c, "*") || strings.Contains(c, "x") {
+	// Handle wildcard constraint (1.2.* or 1.x), but not a branch version
+	// such as 1.0.x-dev, which matches only itself
+	if strings.ContainsAny(c, "*x") && !strings.HasSuffix(c, "-dev") {
 		return parseWildcardConstraint(c)
 	}
 
@@ -110,9 +192,8 @@ func parseSingleConstraint(c string) ([]*constraint, error) {
 			versionStr := strings.TrimSpace(c[len(op):])
 			// Handle stability flags (@dev, @stable, etc.)
 			if strings.Contains(versionStr, "@") {
-				return parseStabilityConstraint(versionStr)
+				return e.parseStabilityConstraint(versionStr)
 			}
-			e := &Ecosystem{}
 			version, err := e.NewVersion(versionStr)
 			if err != nil {
 				return nil, fmt.Errorf("invalid version in constraint '%s': %v", c, err)
@@ -123,11 +204,10 @@ func parseSingleConstraint(c string) ([]*constraint, error) {
 
 	// Handle stability flags (@dev, @stable)
 	if strings.Contains(c, "@") {
-		return parseStabilityConstraint(c)
+		return e.parseStabilityConstraint(c)
 	}
 
 	// Default to exact match - parse the version
-	e := &Ecosystem{}
 	version, err := e.NewVersion(c)
 	if err != nil {
 		return nil, fmt.Errorf("invalid version in constraint '%s': %v", c, err)
@@ -148,8 +228,7 @@ func normalizeOperator(op string) string {
 }
 
 // parseCaretConstraint handles caret constraints (^1.2.3)
-func parseCaretConstraint(version string) ([]*constraint, error) {
-	e := &Ecosystem{}
+func (e *Ecosystem) parseCaretConstraint(version string) ([]*constraint, error) {
 	v, err := e.NewVersion(version)
 	if err != nil {
 		return nil, err
@@ -168,75 +247,35 @@ func parseCaretConstraint(version string) ([]*constraint, error) {
 		// For stable versions like ^1.0.0, also allow prereleases like 1.0b1
 		if v.stability == stabilityStable {
 			// Allow prereleases of the exact same version and above
-			baseVersionStr := fmt.Sprintf("%d.%d.%d", v.major, v.minor, v.patch)
-			baseVersion, err := e.NewVersion(baseVersionStr)
-			if err != nil {
-				return nil, err
-			}
-			return []*constraint{
-				{operator: "caret", version: baseVersion},
-			}, nil
-		} else {
-			upperVersionStr := fmt.Sprintf("%d.0.0", v.major+1)
-			upperVersion, err := e.NewVersion(upperVersionStr)
-			if err != nil {
-				return nil, err
-			}
-			return []*constraint{
-				{operator: ">=", version: v},
-				{operator: "<", version: upperVersion},
-			}, nil
+			return []*constraint{derivedBound("caret", v.major, v.minor, v.patch)}, nil
 		}
+		return []*constraint{
+			{operator: ">=", version: v},
+			derivedBound("<", v.major+1, 0, 0),
+		}, nil
 	} else if v.minor > 0 {
 		// Compatible changes within the same minor version for 0.x
 		if v.stability == stabilityStable {
-			baseVersionStr := fmt.Sprintf("0.%d.%d", v.minor, v.patch)
-			baseVersion, err := e.NewVersion(baseVersionStr)
-			if err != nil {
-				return nil, err
-			}
-			return []*constraint{
-				{operator: "caret-0x", version: baseVersion},
-			}, nil
-		} else {
-			upperVersionStr := fmt.Sprintf("0.%d.0", v.minor+1)
-			upperVersion, err := e.NewVersion(upperVersionStr)
-			if err != nil {
-				return nil, err
-			}
-			return []*constraint{
-				{operator: ">=", version: v},
-				{operator: "<", version: upperVersion},
-			}, nil
-		}
-	} else {
-		// Compatible changes within the same patch version for 0.0.x
-		if v.stability == stabilityStable {
-			baseVersionStr := fmt.Sprintf("0.0.%d", v.patch)
-			baseVersion, err := e.NewVersion(baseVersionStr)
-			if err != nil {
-				return nil, err
-			}
-			return []*constraint{
-				{operator: "caret-00x", version: baseVersion},
-			}, nil
-		} else {
-			upperVersionStr := fmt.Sprintf("0.0.%d", v.patch+1)
-			upperVersion, err := e.NewVersion(upperVersionStr)
-			if err != nil {
-				return nil, err
-			}
-			return []*constraint{
-				{operator: ">=", version: v},
-				{operator: "<", version: upperVersion},
-			}, nil
+			return []*constraint{derivedBound("caret-0x", 0, v.minor, v.patch)}, nil
 		}
+		return []*constraint{
+			{operator: ">=", version: v},
+			derivedBound("<", 0, v.minor+1, 0),
+		}, nil
+	}
+
+	// Compatible changes within the same patch version for 0.0.x
+	if v.stability == stabilityStable {
+		return []*constraint{derivedBound("caret-00x", 0, 0, v.patch)}, nil
 	}
+	return []*constraint{
+		{operator: ">=", version: v},
+		derivedBound("<", 0, 0, v.patch+1),
+	}, nil
 }
 
 // parseTildeConstraint handles tilde constraints (~1.2.3)
-func parseTildeConstraint(version string) ([]*constraint, error) {
-	e := &Ecosystem{}
+func (e *Ecosystem) parseTildeConstraint(version string) ([]*constraint, error) {
 	v, err := e.NewVersion(version)
 	if err != nil {
 		return nil, err
@@ -249,50 +288,24 @@ func parseTildeConstraint(version string) ([]*constraint, error) {
 
 	// ~1.2.3 means >=1.2.3 <1.3.0
 	// ~1.2 means >=1.2.0 <2.0.0
-	parts := strings.Split(version, ".")
-	switch len(parts) {
-	case 1:
+	switch strings.Count(version, ".") {
+	case 0:
 		// ~1 means >=1.0.0 <2.0.0
-		lowerVersionStr := fmt.Sprintf("%d.0.0", v.major)
-		lowerVersion, err := e.NewVersion(lowerVersionStr)
-		if err != nil {
-			return nil, err
-		}
-		upperVersionStr := fmt.Sprintf("%d.0.0", v.major+1)
-		upperVersion, err := e.NewVersion(upperVersionStr)
-		if err != nil {
-			return nil, err
-		}
 		return []*constraint{
-			{operator: ">=", version: lowerVersion},
-			{operator: "<", version: upperVersion},
+			derivedBound(">=", v.major, 0, 0),
+			derivedBound("<", v.major+1, 0, 0),
 		}, nil
-	case 2:
+	case 1:
 		// ~1.2 means >=1.2.0 <2.0.0
-		lowerVersionStr := fmt.Sprintf("%d.%d.0", v.major, v.minor)
-		lowerVersion, err := e.NewVersion(lowerVersionStr)
-		if err != nil {
-			return nil, err
-		}
-		upperVersionStr := fmt.Sprintf("%d.0.0", v.major+1)
-		upperVersion, err := e.NewVersion(upperVersionStr)
-		if err != nil {
-			return nil, err
-		}
 		return []*constraint{
-			{operator: ">=", version: lowerVersion},
-			{operator: "<", version: upperVersion},
+			derivedBound(">=", v.major, v.minor, 0),
+			derivedBound("<", v.major+1, 0, 0),
 		}, nil
 	default:
 		// ~1.2.3 means >=1.2.3 <1.3.0
-		upperVersionStr := fmt.Sprintf("%d.%d.0", v.major, v.minor+1)
-		upperVersion, err := e.NewVersion(upperVersionStr)
-		if err != nil {
-			return nil, err
-		}
 		return []*constraint{
 			{operator: ">=", version: v},
-			{operator: "<", version: upperVersion},
+			derivedBound("<", v.major, v.minor+1, 0),
 		}, nil
 	}
 }
@@ -300,7 +313,6 @@ func parseTildeConstraint(version string) ([]*constraint, error) {
 // parseWildcardConstraint handles wildcard constraints (1.2.* or 1.x)
 func parseWildcardConstraint(rangeStr string) ([]*constraint, error) {
 	parts := strings.Split(rangeStr, ".")
-	e := &Ecosystem{}
 
 	// Replace * or x with appropriate range
 	for i, part := range parts {
@@ -311,19 +323,9 @@ func parseWildcardConstraint(rangeStr string) ([]*constraint, error) {
 				if err != nil {
 					return nil, fmt.Errorf("invalid major version: %s", parts[0])
 				}
-				lowerVersionStr := fmt.Sprintf("%d.0.0", major)
-				lowerVersion, err := e.NewVersion(lowerVersionStr)
-				if err != nil {
-					return nil, err
-				}
-				upperVersionStr := fmt.Sprintf("%d.0.0", major+1)
-				upperVersion, err := e.NewVersion(upperVersionStr)
-				if err != nil {
-					return nil, err
-				}
 				return []*constraint{
-					{operator: ">=", version: lowerVersion},
-					{operator: "<", version: upperVersion},
+					derivedBound(">=", major, 0, 0),
+					derivedBound("<", major+1, 0, 0),
 				}, nil
 			case 2: // 1.2.* or 1.2.x
 				major, err := strconv.Atoi(parts[0])
@@ -334,19 +336,9 @@ func parseWildcardConstraint(rangeStr string) ([]*constraint, error) {
 				if err != nil {
 					return nil, fmt.Errorf("invalid minor version: %s", parts[1])
 				}
-				lowerVersionStr := fmt.Sprintf("%d.%d.0", major, minor)
-				lowerVersion, err := e.NewVersion(lowerVersionStr)
-				if err != nil {
-					return nil, err
-				}
-				upperVersionStr := fmt.Sprintf("%d.%d.0", major, minor+1)
-				upperVersion, err := e.NewVersion(upperVersionStr)
-				if err != nil {
-					return nil, err
-				}
 				return []*constraint{
-					{operator: ">=", version: lowerVersion},
-					{operator: "<", version: upperVersion},
+					derivedBound(">=", major, minor, 0),
+					derivedBound("<", major, minor+1, 0),
 				}, nil
 			default:
 				return nil, fmt.Errorf("unsupported wildcard position: %s", rangeStr)
@@ -358,14 +350,14 @@ func parseWildcardConstraint(rangeStr string) ([]*constraint, error) {
 }
 
 // parseStabilityConstraint handles stability flag constraints (@dev, @stable)
-func parseStabilityConstraint(version string) ([]*constraint, error) {
-	parts := strings.Split(version, "@")
-	if len(parts) != 2 {
+func (e *Ecosystem) parseStabilityConstraint(version string) ([]*constraint, error) {
+	versionPart, stabilityPart, _ := strings.Cut(version, "@")
+	if strings.Contains(stabilityPart, "@") {
 		return nil, fmt.Errorf("invalid stability constraint: %s", version)
 	}
 
-	versionPart := strings.TrimSpace(parts[0])
-	stabilityPart := strings.TrimSpace(parts[1])
+	versionPart = strings.TrimSpace(versionPart)
+	stabilityPart = strings.TrimSpace(stabilityPart)
 
 	// If no version part, match any version with specified stability
 	if versionPart == "" {
@@ -373,7 +365,6 @@ func parseStabilityConstraint(version string) ([]*constraint, error) {
 	}
 
 	// Match specific version with specific stability
-	e := &Ecosystem{}
 	versionWithStability := versionPart + "-" + stabilityPart
 	parsedVersion, err := e.NewVersion(versionWithStability)
 	if err != nil {
@@ -383,7 +374,7 @@ func parseStabilityConstraint(version string) ([]*constraint, error) {
 }
 
 // parseHyphenRange handles hyphen ranges (1.2.3 - 2.3.4)
-func parseHyphenRange(rangeStr string) ([]*constraint, error) {
+func (e *Ecosystem) parseHyphenRange(rangeStr string) ([]*constraint, error) {
 	// Check for malformed hyphen ranges like "1.2.3 -" (trailing dash)
 	if strings.HasSuffix(rangeStr, " -") {
 		return nil, fmt.Errorf("invalid hyphen range: %s", rangeStr)
@@ -402,7 +393,6 @@ func parseHyphenRange(rangeStr string) ([]*constraint, error) {
 	}
 
 	// Parse and validate versions
-	e := &Ecosystem{}
 	startVersion, err := e.NewVersion(start)
 	if err != nil {
 		return nil, fmt.Errorf("invalid start version in hyphen range: %s", start)
@@ -419,18 +409,32 @@ func parseHyphenRange(rangeStr string) ([]*constraint, error) {
 }
 
 // parseSpaceSeparatedConstraints handles space/comma-separated constraints
-func parseSpaceSeparatedConstraints(rangeStr string) ([]*constraint, error) {
-	// Replace commas with spaces and split
-	rangeStr = strings.ReplaceAll(rangeStr, ",", " ")
-	parts := strings.Fields(rangeStr)
-	var constraints []*constraint
-
-	for _, part := range parts {
-		partConstraints, err := parseSingleConstraint(part)
+func (e *Ecosystem) parseSpaceSeparatedConstraints(rangeStr string) ([]*constraint, error) {
+	// Commas and spaces both separate constraints, except after an operator:
+	// Composer reads ">= 1.0" as ">=1.0"
+	fields := univers.ConstraintFields(rangeStr, func(r rune) bool { return r == ',' || unicode.IsSpace(r) })
+	constraints := make([]*constraint, 0, len(fields))
+
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+		partConstraints, err := e.parseSingleConstraint(f.Text)
 		if err != nil {
-			return nil, err
+			return nil, univers.TokenErrorAt(rangeStr, f.Offset, f.Text, err)
 		}
 		constraints = append(constraints, partConstraints...)
+
+		// "dev-main as 1.0.x-dev" requires dev-main and lets it satisfy
+		// other packages' constraints on 1.0.x-dev; only dev-main matches
+		if i+1 < len(fields) && fields[i+1].Text == "as" {
+			if i+2 == len(fields) {
+				return nil, univers.TokenErrorAt(rangeStr, fields[i+1].Offset, "as", fmt.Errorf("missing alias version"))
+			}
+			alias := fields[i+2]
+			if _, err := e.NewVersion(alias.Text); err != nil {
+				return nil, univers.TokenErrorAt(rangeStr, alias.Offset, alias.Text, fmt.Errorf("invalid alias version: %v", err))
+			}
+			i += 2
+		}
 	}
 
 	return constraints, nil
@@ -460,6 +464,30 @@ func (pr *VersionRange) Contains(version *Version) bool {
 	return false
 }
 
+// Comparators returns the OR groups of the range. Tilde, wildcard and hyphen
+// ranges are listed as their bounds. A caret on a stable version is listed as
+// "^", as it also excludes pre-releases a plain interval admits, and a
+// stability flag such as "@dev" as the "@" operator with the flag as version.
+func (pr *VersionRange) Comparators() [][]univers.Comparator {
+	groups := make([][]univers.Comparator, 0, len(pr.constraintGroups))
+	for _, constraintGroup := range pr.constraintGroups {
+		group := make([]univers.Comparator, 0, len(constraintGroup))
+		for _, c := range constraintGroup {
+			switch c.operator {
+			case "*":
+			case "@":
+				group = append(group, univers.Comparator{Operator: "@", Version: c.stability})
+			case "caret", "caret-0x", "caret-00x":
+				group = append(group, univers.Comparator{Operator: "^", Version: c.bound().String()})
+			default:
+				group = append(group, univers.Comparator{Operator: c.operator, Version: c.bound().String()})
+			}
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
 // matches checks if the given version matches this constraint
 func (c *constraint) matches(version *Version) bool {
 	if c.operator == "*" {
@@ -486,12 +514,12 @@ func (c *constraint) matches(version *Version) bool {
 		return c.matchesCaretZeroZeroX(version)
 	}
 
-	// c.version is now already parsed, no need to re-parse
-	if c.version == nil {
+	bound := c.bound()
+	if bound == nil {
 		return false
 	}
 
-	comparison := version.Compare(c.version)
+	comparison := version.Compare(bound)
 
 	switch c.operator {
 	case "=":
@@ -513,7 +541,7 @@ func (c *constraint) matches(version *Version) bool {
 
 // matchesCaret handles caret constraints for major version > 0
 func (c *constraint) matchesCaret(version *Version) bool {
-	constraintVersion := c.version
+	constraintVersion := c.bound()
 	if constraintVersion == nil {
 		return false
 	}
@@ -554,7 +582,7 @@ func (c *constraint) matchesCaret(version *Version) bool {
 
 // matchesCaretZeroX handles caret constraints for 0.x versions
 func (c *constraint) matchesCaretZeroX(version *Version) bool {
-	constraintVersion := c.version
+	constraintVersion := c.bound()
 	if constraintVersion == nil {
 		return false
 	}
@@ -577,7 +605,7 @@ func (c *constraint) matchesCaretZeroX(version *Version) bool {
 
 // matchesCaretZeroZeroX handles caret constraints for 0.0.x versions
 func (c *constraint) matchesCaretZeroZeroX(version *Version) bool {
-	constraintVersion := c.version
+	constraintVersion := c.bound()
 	if constraintVersion == nil {
 		return false
 	}
@@ -1,6 +1,7 @@
 package composer
 
 import (
+	"fmt"
 	"testing"
 )
 
@@ -20,6 +21,9 @@ func TestNewVersionRange(t *testing.T) {
 		{"space separated", ">=1.0.0 <2.0.0", false},
 		{"comma separated", ">=1.0.0, <2.0.0", false},
 		{"OR logic", "1.x || 2.x", false},
+		{"single pipe OR", "1.x | 2.x", false},
+		{"alias", "dev-main as 1.0.x-dev", false},
+		{"branch alias", "1.0.x-dev as 1.0.0", false},
 		{"stability constraint", "1.2.3@dev", false},
 		{"stability only", "@stable", false},
 		{"wildcard", "*", false},
@@ -28,6 +32,10 @@ func TestNewVersionRange(t *testing.T) {
 		{"empty string", "", true},
 		{"invalid version in caret", "^invalid", true},
 		{"invalid hyphen range", "1.2.3 - ", true},
+		{"empty OR group", "1.x ||| 2.x", true},
+		{"alias without version", "dev-main as", true},
+		{"invalid alias version", "dev-main as not a version", true},
+		{"alias of invalid version", "^invalid as 1.0.0", true},
 	}
 
 	e := &Ecosystem{}
@@ -60,6 +68,10 @@ func TestVersionRangeContains(t *testing.T) {
 		{"less than - no match", "<2.0.0", "2.0.0", false},
 		{"not equal - different", "!=1.2.3", "1.2.4", true},
 		{"not equal - same", "!=1.2.3", "1.2.3", false},
+		{"space after operator", ">= 1.2.3", "1.2.3", true},
+		{"space after operators - in range", ">= 1.2.3 < 2.0.0", "1.9.9", true},
+		{"space after operators - comma", ">= 1.2.3, < 2.0.0", "2.0.0", false},
+		{"space after caret", "^ 1.2.3", "1.9.0", true},
 
 		// Caret constraints
 		{"caret major match", "^1.2.3", "1.2.3", true},
@@ -105,6 +117,15 @@ func TestVersionRangeContains(t *testing.T) {
 		{"OR match first", "1.x || 2.x", "1.2.3", true},
 		{"OR match second", "1.x || 2.x", "2.3.4", true},
 		{"OR no match", "1.x || 2.x", "3.0.0", false},
+		{"single pipe OR match second", "^1.0|^2.0", "2.3.4", true},
+		{"single pipe OR no match", "^1.0 | ^2.0", "3.0.0", false},
+		{"mixed OR separators", "^1.0 | ^2.0 || ^3.0", "3.1.0", true},
+
+		// Aliases match the aliased version, not the alias
+		{"alias match", "dev-main as 1.0.x-dev", "dev-main", true},
+		{"alias no match", "dev-main as 1.0.x-dev", "1.0.x-dev", false},
+		{"alias with other constraints", "dev-main as 1.0.0 || ^2.0", "2.1.0", true},
+		{"branch version", "1.0.x-dev", "1.0.x-dev", true},
 
 		// Stability constraints
 		{"stability exact match", "1.2.3-alpha", "1.2.3-alpha", true},
@@ -267,3 +288,67 @@ func TestTildeConstraintVariations(t *testing.T) {
 		})
 	}
 }
+
+func BenchmarkNewVersionRange(b *testing.B) {
+	e := &Ecosystem{}
+	for i := 0; i < b.N; i++ {
+		if _, err := e.NewVersionRange("^1.2 || ~2.3.4 || 3.1.* || >=4.0, <4.5"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkVersionRangeContains(b *testing.B) {
+	e := &Ecosystem{}
+	vr, err := e.NewVersionRange("^1.2 || ~2.3.4 || 3.1.* || >=4.0, <4.5")
+	if err != nil {
+		b.Fatal(err)
+	}
+	v, err := e.NewVersion("4.2.0")
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		vr.Contains(v)
+	}
+}
+
+func TestEcosystem_Features(t *testing.T) {
+	e := &Ecosystem{}
+	for _, f := range e.Features() {
+		if _, err := e.NewVersionRange(f.Syntax); err != nil {
+			t.Errorf("Features() %s syntax %q does not parse: %v", f.Name, f.Syntax, err)
+		}
+	}
+}
+
+func TestVersionRange_Comparators(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		want     string
+	}{
+		{name: "caret", rangeStr: "^1.2.3", want: "[[^1.2.3]]"},
+		{name: "tilde", rangeStr: "~1.2", want: "[[>=1.2.0 <2.0.0]]"},
+		{name: "wildcard", rangeStr: "1.2.*", want: "[[>=1.2.0 <1.3.0]]"},
+		{name: "alternatives", rangeStr: ">=1.0 <1.1 || >=2.0", want: "[[>=1.0 <1.1] [>=2.0]]"},
+		{name: "stability", rangeStr: "@dev", want: "[[@dev]]"},
+		{name: "not equal", rangeStr: "<>1.0", want: "[[!=1.0]]"},
+		{name: "single pipe alternatives", rangeStr: ">=1.0 <1.1 | >=2.0", want: "[[>=1.0 <1.1] [>=2.0]]"},
+		{name: "alias", rangeStr: "dev-main as 1.0.x-dev", want: "[[=dev-main]]"},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vr, err := e.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("Ecosystem.NewVersionRange() error = %v", err)
+			}
+			if got := fmt.Sprint(vr.Comparators()); got != tt.want {
+				t.Errorf("VersionRange.Comparators() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -6,6 +6,9 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+
+	"github.com/alowayed/go-univers/internal/digits"
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 // Composer version patterns - matches Composer version specification
@@ -40,20 +43,25 @@ var stabilityMap = map[string]int{
 
 // Version represents a Composer package version following Composer specification
 type Version struct {
-	major        int
-	minor        int
-	patch        int
-	extra        int    // Fourth component (for .NET-style versions)
-	stability    int    // Stability level (dev, alpha, beta, RC, stable)
-	stabilityNum int    // Stability version number (e.g., alpha.2)
-	build        string // Build metadata
-	isDev        bool   // True for dev- prefixed versions
-	devBranch    string // Branch name for dev versions
-	original     string
+	major           int
+	minor           int
+	patch           int
+	extra           int    // Fourth component (for .NET-style versions)
+	stability       int    // Stability level (dev, alpha, beta, RC, stable)
+	stabilityNum    int    // Stability version number (e.g., alpha.2)
+	hasStabilityNum bool   // True when the stability carries a number
+	build           string // Build metadata
+	isDev           bool   // True for dev- prefixed versions
+	devBranch       string // Branch name for dev versions
+	original        string
 }
 
 // NewVersion creates a new Composer version from a string
 func (e *Ecosystem) NewVersion(version string) (*Version, error) {
+	if err := univers.CheckLength(version); err != nil {
+		return nil, err
+	}
+
 	original := version
 	version = strings.TrimSpace(version)
 
@@ -133,6 +141,7 @@ func (e *Ecosystem) NewVersion(version string) (*Version, error) {
 					return nil, fmt.Errorf("invalid stability number: %s", matches[7])
 				}
 				v.stabilityNum = stabilityNum
+				v.hasStabilityNum = true
 			}
 		} else if matches[8] != "" { // Direct format: alpha1
 			stabilityStr := strings.ToLower(matches[8])
@@ -151,6 +160,7 @@ func (e *Ecosystem) NewVersion(version string) (*Version, error) {
 					return nil, fmt.Errorf("invalid stability number: %s", matches[9])
 				}
 				v.stabilityNum = stabilityNum
+				v.hasStabilityNum = true
 			}
 		} else {
 			v.stability = stabilityStable
@@ -175,6 +185,44 @@ func (e *Ecosystem) NewVersion(version string) (*Version, error) {
 	return nil, fmt.Errorf("invalid Composer version: %s", original)
 }
 
+// ValidVersion reports whether NewVersion accepts version. It recognizes the
+// same dev versions, semantic versions and branch names without allocating,
+// for filters that never compare the versions they let through.
+func ValidVersion(version string) bool {
+	if univers.CheckLength(version) != nil {
+		return false
+	}
+	version = strings.TrimSpace(version)
+	if version == "" {
+		return false
+	}
+	if devVersionPattern.MatchString(version) {
+		return true
+	}
+	if !semanticVersionPattern.MatchString(version) {
+		return isBranchName(version)
+	}
+
+	// Up to four numeric components are parsed; a fifth is ignored
+	rest := strings.TrimPrefix(version, "v")
+	for i := 0; ; i++ {
+		n := digits.Prefix(rest)
+		if i < 4 && !digits.FitsInt(rest[:n]) {
+			return false
+		}
+		rest = rest[n:]
+		if !strings.HasPrefix(rest, ".") {
+			break
+		}
+		rest = rest[1:]
+	}
+
+	// So is the number after a stability suffix such as "-alpha.1" or "RC2"
+	rest = strings.TrimLeft(strings.TrimPrefix(rest, "-"), "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz")
+	rest = strings.TrimPrefix(rest, ".")
+	return digits.FitsInt(rest[:digits.Prefix(rest)])
+}
+
 // isBranchName checks if a string looks like a valid branch name following Composer conventions
 func isBranchName(version string) bool {
 	// Empty strings are not valid branch names
@@ -184,7 +232,7 @@ func isBranchName(version string) bool {
 
 	// Don't accept strings that look too much like malformed versions
 	// This prevents false positives for invalid version strings
-	if strings.Contains(version, ".") && (len(strings.Split(version, ".")) >= 2) {
+	if strings.Contains(version, ".") {
 		// If it contains dots and looks like it could be a version, be more strict
 		if !strings.Contains(version, "/") && !strings.Contains(version, "-") {
 			return false
@@ -232,6 +280,30 @@ func (v *Version) String() string {
 	return v.original
 }
 
+// PrereleaseChannel returns the stability of the version as a channel:
+// "alpha", "beta", "rc" or "dev", including dev branches such as
+// dev-main, or "" for a stable version. Composer's "a", "b" and "RC"
+// spellings are normalized.
+func (v *Version) PrereleaseChannel() string {
+	switch v.stability {
+	case stabilityDev:
+		return "dev"
+	case stabilityAlpha:
+		return "alpha"
+	case stabilityBeta:
+		return "beta"
+	case stabilityRC:
+		return "rc"
+	}
+	return ""
+}
+
+// PrereleaseNumber returns the number following the stability, such as 2 for
+// 1.0.0-beta.2 or 1.0.0beta2, and whether the version has one.
+func (v *Version) PrereleaseNumber() (int, bool) {
+	return v.stabilityNum, v.hasStabilityNum
+}
+
 // Compare compares this version with another Composer version following Composer rules
 func (v *Version) Compare(other *Version) int {
 	// Dev versions are always less than stable versions
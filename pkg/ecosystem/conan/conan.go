@@ -1,8 +1,10 @@
 // Package conan provides functionality for working with Conan C/C++ package manager versions.
 package conan
 
+import "github.com/alowayed/go-univers/pkg/univers"
+
 const (
-	Name = "conan"
+	Name = string(univers.Conan)
 )
 
 type Ecosystem struct{}
@@ -0,0 +1,49 @@
+package conan_test
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/conan"
+)
+
+// Versions compare component by component, numerically.
+func ExampleVersion_Compare() {
+	e := &conan.Ecosystem{}
+
+	var versions []*conan.Version
+	for _, s := range []string{"1.10", "1.2.3", "1.2", "2.0"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		versions = append(versions, v)
+	}
+	slices.SortFunc(versions, (*conan.Version).Compare)
+	fmt.Println(versions)
+	// Output:
+	// [1.2 1.2.3 1.10 2.0]
+}
+
+// Ranges are comparators separated by spaces.
+func ExampleVersionRange_Contains() {
+	e := &conan.Ecosystem{}
+
+	r, err := e.NewVersionRange(">=1.0 <2.0")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, s := range []string{"1.10", "2.0"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(s, r.Contains(v))
+	}
+	// Output:
+	// 1.10 true
+	// 2.0 false
+}
@@ -3,7 +3,10 @@ package conan
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
+
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 // Package-level compiled regular expressions for range parsing
@@ -25,13 +28,33 @@ type constraint struct {
 	version  *Version
 }
 
+// features declares the range syntax accepted by NewVersionRange
+var features = []univers.Feature{
+	{Name: univers.FeatureComparison, Syntax: ">=1.0 <2.0", Description: "Ordering operators >=, >, <=, < and ="},
+	{Name: univers.FeatureNotEqual, Syntax: "!=1.5", Description: "Excludes a single version"},
+	{Name: univers.FeatureExact, Syntax: "1.2.3", Description: "A bare version matches only itself"},
+	{Name: univers.FeatureAnd, Syntax: ">=1.0, <2.0", Description: "Space- or comma-separated constraints must all match"},
+	{Name: univers.FeatureOr, Syntax: "<1.0 || >=2.0", Description: "Matches if any ||-separated group matches"},
+	{Name: univers.FeatureCaret, Syntax: "^1.2", Description: "Allows changes up to the next major release"},
+	{Name: univers.FeatureTilde, Syntax: "~1.2", Description: "Allows changes up to the next minor release"},
+}
+
+// Features returns the range syntax features supported by NewVersionRange
+func (e *Ecosystem) Features() []univers.Feature {
+	return slices.Clone(features)
+}
+
 // NewVersionRange creates a new Conan version range from a string
 func (e *Ecosystem) NewVersionRange(rangeStr string) (*VersionRange, error) {
+	if err := univers.CheckLength(rangeStr); err != nil {
+		return nil, err
+	}
+
 	original := rangeStr
 	rangeStr = strings.TrimSpace(strings.ToLower(rangeStr))
 
 	if rangeStr == "" {
-		return nil, fmt.Errorf("empty range string")
+		return nil, univers.WrapParseError(original, fmt.Errorf("empty range string"))
 	}
 
 	// Handle OR logic (||)
@@ -56,7 +79,7 @@ func (e *Ecosystem) NewVersionRange(rangeStr string) (*VersionRange, error) {
 
 			constraint, err := parseConstraint(andPart, e)
 			if err != nil {
-				return nil, fmt.Errorf("invalid constraint '%s' in range '%s': %v", andPart, original, err)
+				return nil, univers.WrapParseError(original, univers.TokenError(andPart, fmt.Errorf("invalid constraint '%s': %w", andPart, err)))
 			}
 
 			andConstraints = append(andConstraints, constraint)
@@ -68,7 +91,11 @@ func (e *Ecosystem) NewVersionRange(rangeStr string) (*VersionRange, error) {
 	}
 
 	if len(orGroups) == 0 {
-		return nil, fmt.Errorf("no valid constraints found in range: %s", original)
+		return nil, univers.WrapParseError(original, fmt.Errorf("no valid constraints found in range: %s", original))
+	}
+
+	if err := univers.CheckConstraintGroups(orGroups); err != nil {
+		return nil, err
 	}
 
 	return &VersionRange{
@@ -197,6 +224,20 @@ func (r *VersionRange) Contains(version *Version) bool {
 	return false
 }
 
+// Comparators returns the OR groups of the range. Conan's "~" and "^" have no
+// comparator equivalent and are listed as is.
+func (r *VersionRange) Comparators() [][]univers.Comparator {
+	groups := make([][]univers.Comparator, 0, len(r.orGroups))
+	for _, orGroup := range r.orGroups {
+		group := make([]univers.Comparator, 0, len(orGroup))
+		for _, c := range orGroup {
+			group = append(group, univers.Comparator{Operator: c.operator, Version: c.version.String()})
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
 // groupSatisfied checks if all constraints in a group are satisfied
 func (r *VersionRange) groupSatisfied(group []constraint, version *Version) bool {
 	for _, c := range group {
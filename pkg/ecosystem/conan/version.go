@@ -5,6 +5,9 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"unicode"
+
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 // Package-level compiled regular expressions for performance
@@ -30,6 +33,10 @@ type Version struct {
 
 // NewVersion creates a new Conan version from a string
 func (e *Ecosystem) NewVersion(version string) (*Version, error) {
+	if err := univers.CheckLength(version); err != nil {
+		return nil, err
+	}
+
 	original := version
 	version = strings.TrimSpace(strings.ToLower(version))
 
@@ -81,6 +88,49 @@ func (e *Ecosystem) NewVersion(version string) (*Version, error) {
 	}, nil
 }
 
+// ValidVersion reports whether NewVersion accepts version. It lowercases and
+// checks the version one rune at a time rather than building a lowercased
+// copy, so it does not allocate.
+func ValidVersion(version string) bool {
+	if univers.CheckLength(version) != nil {
+		return false
+	}
+	var (
+		section  = 'm' // 'm' for the main version, then '-' or '+'
+		idLen    int
+		idDigits bool
+		idZero   bool
+	)
+	// endIdentifier reports whether the identifier just read is valid
+	endIdentifier := func() bool {
+		leadingZero := section != 'm' && idDigits && idZero && idLen > 1
+		return idLen > 0 && !leadingZero
+	}
+
+	for _, r := range strings.TrimSpace(version) {
+		c := unicode.ToLower(r)
+		switch {
+		case (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c == '-' && section != 'm'):
+			if idLen == 0 {
+				idDigits, idZero = true, c == '0'
+			}
+			idDigits = idDigits && c >= '0' && c <= '9'
+			idLen++
+		case c == '.', c == '-', c == '+' && section != '+':
+			if !endIdentifier() {
+				return false
+			}
+			if c != '.' {
+				section = c
+			}
+			idLen = 0
+		default:
+			return false
+		}
+	}
+	return endIdentifier()
+}
+
 // validateIdentifiers validates prerelease or build metadata identifiers
 func validateIdentifiers(identifiers, identifierType string) error {
 	parts := strings.Split(identifiers, ".")
@@ -0,0 +1,14 @@
+// Package cpan provides functionality for working with Perl CPAN versions.
+package cpan
+
+import "github.com/alowayed/go-univers/pkg/univers"
+
+const (
+	Name = string(univers.CPAN)
+)
+
+type Ecosystem struct{}
+
+func (e *Ecosystem) Name() string {
+	return Name
+}
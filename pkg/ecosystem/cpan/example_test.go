@@ -0,0 +1,66 @@
+package cpan_test
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/cpan"
+)
+
+// Decimal versions compare as numbers, so 1.23 is below 1.3.
+func ExampleVersion_Compare() {
+	e := &cpan.Ecosystem{}
+
+	var versions []*cpan.Version
+	for _, s := range []string{"1.3", "1.23", "1.002003", "v1.10.0"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		versions = append(versions, v)
+	}
+	slices.SortFunc(versions, (*cpan.Version).Compare)
+	fmt.Println(versions)
+	// Output:
+	// [1.002003 v1.10.0 1.23 1.3]
+}
+
+// Ranges are comparators separated by commas, as in CPAN::Meta.
+func ExampleVersionRange_Contains() {
+	e := &cpan.Ecosystem{}
+
+	r, err := e.NewVersionRange(">= 1.23, < 2.0")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, s := range []string{"1.5", "2.01"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(s, r.Contains(v))
+	}
+	// Output:
+	// 1.5 true
+	// 2.01 false
+}
+
+func ExampleVersion_Normal() {
+	e := &cpan.Ecosystem{}
+
+	for _, s := range []string{"1.002003", "v1.2.3", "1.23_01"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(s, v.Normal(), v.IsDotted(), v.IsAlpha())
+	}
+	// Output:
+	// 1.002003 v1.2.3 false false
+	// v1.2.3 v1.2.3 true false
+	// 1.23_01 v1.230.100 false true
+}
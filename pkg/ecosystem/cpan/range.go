@@ -0,0 +1,160 @@
+package cpan
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+// VersionRange represents a CPAN::Meta::Spec version range such as ">= 1.23, < 2.0"
+type VersionRange struct {
+	constraints []*constraint
+	original    string
+}
+
+// constraint represents a single CPAN version constraint
+type constraint struct {
+	operator string
+	version  *Version
+}
+
+// features declares the range syntax accepted by NewVersionRange
+var features = []univers.Feature{
+	{Name: univers.FeatureComparison, Syntax: ">= 1.23", Description: "Ordering operators >=, >, <=, < and =="},
+	{Name: univers.FeatureNotEqual, Syntax: "!= 1.5", Description: "Excludes a single version"},
+	{Name: univers.FeatureMinimum, Syntax: "1.23", Description: "A bare version matches itself and any later version"},
+	{Name: univers.FeatureAnd, Syntax: ">= 1.23, < 2.0", Description: "Comma-separated constraints must all match"},
+}
+
+// Features returns the range syntax features supported by NewVersionRange
+func (e *Ecosystem) Features() []univers.Feature {
+	return slices.Clone(features)
+}
+
+// NewVersionRange creates a new CPAN version range from a range string. A bare
+// version is a minimum, so "0" matches every version.
+func (e *Ecosystem) NewVersionRange(rangeStr string) (*VersionRange, error) {
+	if err := univers.CheckLength(rangeStr); err != nil {
+		return nil, err
+	}
+
+	original := rangeStr
+	rangeStr = strings.TrimSpace(rangeStr)
+	if rangeStr == "" {
+		return nil, univers.WrapParseError(original, fmt.Errorf("empty range string"))
+	}
+
+	constraints, err := e.parseConstraints(rangeStr)
+	if err != nil {
+		return nil, univers.WrapParseError(original, err)
+	}
+
+	if err := univers.CheckConstraints(len(constraints)); err != nil {
+		return nil, err
+	}
+
+	return &VersionRange{
+		constraints: constraints,
+		original:    original,
+	}, nil
+}
+
+// parseConstraints parses comma-separated constraints, all of which must match
+func (e *Ecosystem) parseConstraints(rangeStr string) ([]*constraint, error) {
+	var constraints []*constraint
+
+	offset := 0
+	for _, part := range strings.Split(rangeStr, ",") {
+		start := offset
+		offset += len(part) + 1
+
+		trimmed := strings.TrimSpace(part)
+		if trimmed == "" {
+			return nil, univers.TokenErrorAt(rangeStr, start, part, fmt.Errorf("empty constraint"))
+		}
+		start += strings.Index(part, trimmed)
+
+		c, err := e.parseConstraint(trimmed)
+		if err != nil {
+			return nil, univers.TokenErrorAt(rangeStr, start, trimmed, err)
+		}
+		constraints = append(constraints, c)
+	}
+
+	return constraints, nil
+}
+
+// parseConstraint parses a single constraint
+func (e *Ecosystem) parseConstraint(constraintStr string) (*constraint, error) {
+	operator := ">="
+	versionStr := constraintStr
+	for _, op := range []string{">=", "<=", "==", "!=", ">", "<"} {
+		if strings.HasPrefix(constraintStr, op) {
+			operator = op
+			versionStr = strings.TrimSpace(constraintStr[len(op):])
+			break
+		}
+	}
+
+	if versionStr == "" {
+		return nil, fmt.Errorf("constraint %s requires version", operator)
+	}
+
+	version, err := e.NewVersion(versionStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version in constraint %s: %w", constraintStr, err)
+	}
+	return &constraint{operator: operator, version: version}, nil
+}
+
+// String returns the string representation of the version range
+func (vr *VersionRange) String() string {
+	return vr.original
+}
+
+// Contains checks if a version satisfies this range
+func (vr *VersionRange) Contains(version *Version) bool {
+	for _, c := range vr.constraints {
+		if !c.matches(version) {
+			return false
+		}
+	}
+	return true
+}
+
+// Comparators returns the constraints as a single AND group, with "==" spelled "="
+func (vr *VersionRange) Comparators() [][]univers.Comparator {
+	group := make([]univers.Comparator, 0, len(vr.constraints))
+	for _, c := range vr.constraints {
+		op := c.operator
+		if op == "==" {
+			op = "="
+		}
+		group = append(group, univers.Comparator{Operator: op, Version: c.version.String()})
+	}
+	return [][]univers.Comparator{group}
+}
+
+// matches checks if a version satisfies the constraint
+func (c *constraint) matches(version *Version) bool {
+	cmp := version.Compare(c.version)
+
+	switch c.operator {
+	case "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	default:
+		return false
+	}
+}
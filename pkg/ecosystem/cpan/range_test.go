@@ -0,0 +1,215 @@
+package cpan
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+func TestEcosystem_NewVersionRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		// Valid ranges
+		{
+			name:  "bare minimum",
+			input: "1.23",
+		},
+		{
+			name:  "any version",
+			input: "0",
+		},
+		{
+			name:  "operator with space",
+			input: ">= 1.23",
+		},
+		{
+			name:  "operator without space",
+			input: ">=1.23",
+		},
+		{
+			name:  "exact",
+			input: "== v1.2.3",
+		},
+		{
+			name:  "multiple constraints",
+			input: ">= 1.23, < 2.0, != 1.5",
+		},
+		// Error cases
+		{
+			name:    "empty string",
+			input:   "",
+			wantErr: true,
+		},
+		{
+			name:    "operator without version",
+			input:   ">=",
+			wantErr: true,
+		},
+		{
+			name:    "invalid version",
+			input:   ">= 1.x",
+			wantErr: true,
+		},
+		{
+			name:    "empty constraint",
+			input:   ">= 1.0,, < 2.0",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported operator",
+			input:   "~> 1.0",
+			wantErr: true,
+		},
+	}
+
+	ecosystem := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ecosystem.NewVersionRange(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Ecosystem.NewVersionRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEcosystem_NewVersionRange_ParseError(t *testing.T) {
+	ecosystem := &Ecosystem{}
+	_, err := ecosystem.NewVersionRange(">= 1.0, < 2.x")
+
+	var pe *univers.ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("NewVersionRange() error = %v, want *univers.ParseError", err)
+	}
+	if pe.Token != "< 2.x" || pe.Pos != 8 {
+		t.Errorf("ParseError token = %q pos = %d, want %q pos 8", pe.Token, pe.Pos, "< 2.x")
+	}
+}
+
+func TestVersionRange_Contains(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		version  string
+		want     bool
+	}{
+		{
+			name:     "bare version is a minimum",
+			rangeStr: "1.23",
+			version:  "1.5",
+			want:     true,
+		},
+		{
+			name:     "bare version excludes older",
+			rangeStr: "1.23",
+			version:  "1.229",
+			want:     false,
+		},
+		{
+			name:     "zero matches everything",
+			rangeStr: "0",
+			version:  "v0.0.1",
+			want:     true,
+		},
+		{
+			name:     "decimal bound against dotted version",
+			rangeStr: ">= 1.002003",
+			version:  "v1.2.3",
+			want:     true,
+		},
+		{
+			name:     "upper bound exclusive",
+			rangeStr: ">= 1.23, < 2.0",
+			version:  "2.0",
+			want:     false,
+		},
+		{
+			name:     "inside bounds",
+			rangeStr: ">= 1.23, < 2.0",
+			version:  "1.99",
+			want:     true,
+		},
+		{
+			name:     "not equal excludes",
+			rangeStr: ">= 1.0, != 1.5",
+			version:  "1.500",
+			want:     false,
+		},
+		{
+			name:     "exact match across forms",
+			rangeStr: "== v5.10.1",
+			version:  "5.010001",
+			want:     true,
+		},
+		{
+			name:     "greater than",
+			rangeStr: "> 1.0",
+			version:  "1.0",
+			want:     false,
+		},
+		{
+			name:     "less than or equal",
+			rangeStr: "<= 1.0",
+			version:  "1.000",
+			want:     true,
+		},
+	}
+
+	ecosystem := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vr, err := ecosystem.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("Failed to parse range %s: %v", tt.rangeStr, err)
+			}
+
+			v, err := ecosystem.NewVersion(tt.version)
+			if err != nil {
+				t.Fatalf("Failed to parse version %s: %v", tt.version, err)
+			}
+
+			got := vr.Contains(v)
+			if got != tt.want {
+				t.Errorf("VersionRange.Contains() = %v, want %v (range=%s, version=%s)", got, tt.want, tt.rangeStr, tt.version)
+			}
+		})
+	}
+}
+
+func TestEcosystem_Features(t *testing.T) {
+	e := &Ecosystem{}
+	for _, f := range e.Features() {
+		if _, err := e.NewVersionRange(f.Syntax); err != nil {
+			t.Errorf("Features() %s syntax %q does not parse: %v", f.Name, f.Syntax, err)
+		}
+	}
+}
+
+func TestVersionRange_Comparators(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		want     string
+	}{
+		{name: "comparisons", rangeStr: ">= 1.0, != 1.5, < 2.0", want: "[[>=1.0 !=1.5 <2.0]]"},
+		{name: "exact", rangeStr: "== 1.23", want: "[[=1.23]]"},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vr, err := e.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("Ecosystem.NewVersionRange() error = %v", err)
+			}
+			if got := fmt.Sprint(vr.Comparators()); got != tt.want {
+				t.Errorf("VersionRange.Comparators() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package cpan
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/alowayed/go-univers/internal/digits"
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+// Version represents a Perl module version as interpreted by version.pm.
+//
+// Two forms exist. Decimal versions such as 1.002003 split their fraction into
+// groups of three digits, so 1.002003 equals the dotted-decimal v1.2.3 and 1.5
+// equals v1.500.0. Dotted-decimal versions start with a "v" or contain at least
+// two dots. An underscore marks a developer (alpha) release; like version.pm
+// 0.9913 and later, it is dropped before comparison.
+type Version struct {
+	parts    []int
+	dotted   bool
+	alpha    bool
+	original string
+}
+
+// NewVersion creates a new CPAN version from a string
+func (e *Ecosystem) NewVersion(version string) (*Version, error) {
+	if err := univers.CheckLength(version); err != nil {
+		return nil, err
+	}
+
+	original := version
+	version = strings.TrimSpace(version)
+
+	if version == "" {
+		return nil, fmt.Errorf("invalid CPAN version: empty string")
+	}
+
+	dotted := false
+	if strings.HasPrefix(version, "v") {
+		dotted = true
+		version = version[1:]
+	}
+
+	alpha := strings.Contains(version, "_")
+	if alpha {
+		i := strings.Index(version, "_")
+		if strings.Count(version, "_") > 1 || !isDigitAt(version, i-1) || !isDigitAt(version, i+1) {
+			return nil, fmt.Errorf("invalid CPAN version: %s (misplaced underscore)", original)
+		}
+		version = strings.Replace(version, "_", "", 1)
+	}
+
+	fields := strings.Split(version, ".")
+	if len(fields) > 2 {
+		dotted = true
+	}
+	for _, f := range fields {
+		if f == "" || strings.Trim(f, "0123456789") != "" {
+			return nil, fmt.Errorf("invalid CPAN version: %s", original)
+		}
+	}
+
+	var parts []int
+	var err error
+	if dotted {
+		parts, err = dottedParts(fields)
+	} else {
+		parts, err = decimalParts(fields)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CPAN version: %s (%w)", original, err)
+	}
+
+	return &Version{
+		parts:    parts,
+		dotted:   dotted,
+		alpha:    alpha,
+		original: original,
+	}, nil
+}
+
+// ValidVersion reports whether NewVersion accepts version, checking the
+// fields in place instead of splitting them, so nothing is allocated.
+func ValidVersion(version string) bool {
+	if univers.CheckLength(version) != nil {
+		return false
+	}
+	version = strings.TrimSpace(version)
+	if version == "" {
+		return false
+	}
+
+	dotted := strings.HasPrefix(version, "v")
+	version = strings.TrimPrefix(version, "v")
+	if i := strings.Index(version, "_"); i >= 0 {
+		if strings.Count(version, "_") > 1 || !isDigitAt(version, i-1) || !isDigitAt(version, i+1) {
+			return false
+		}
+	}
+	dotted = dotted || strings.Count(version, ".") > 1
+
+	// Every field must be digits; all of a dotted version's fields must fit
+	// an int, but only the integer part of a decimal version
+	for i := 0; ; i++ {
+		field, rest, more := strings.Cut(version, ".")
+		if field == "" || strings.Trim(field, "0123456789_") != "" {
+			return false
+		}
+		if (dotted || i == 0) && !fitsInt(field) {
+			return false
+		}
+		if !more {
+			return true
+		}
+		version = rest
+	}
+}
+
+// fitsInt reports whether strconv.Atoi accepts the ASCII digit string s once
+// its alpha underscore, if any, is removed
+func fitsInt(s string) bool {
+	before, after, _ := strings.Cut(s, "_")
+	return digits.FitsIntConcat(before, after)
+}
+
+// dottedParts converts dotted-decimal fields to components, padded to at
+// least three the way version.pm normalizes v1.2 to v1.2.0
+func dottedParts(fields []string) ([]int, error) {
+	parts := make([]int, 0, max(len(fields), 3))
+	for _, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, fmt.Errorf("component too large: %s", f)
+		}
+		parts = append(parts, n)
+	}
+	for len(parts) < 3 {
+		parts = append(parts, 0)
+	}
+	return parts, nil
+}
+
+// decimalParts converts a decimal version to components by splitting the
+// fraction into groups of three digits, right-padding the last group with zeros
+func decimalParts(fields []string) ([]int, error) {
+	n, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return nil, fmt.Errorf("component too large: %s", fields[0])
+	}
+	parts := []int{n}
+	if len(fields) == 1 {
+		return parts, nil
+	}
+
+	fraction := fields[1]
+	for len(fraction)%3 != 0 {
+		fraction += "0"
+	}
+	for i := 0; i < len(fraction); i += 3 {
+		n, _ := strconv.Atoi(fraction[i : i+3])
+		parts = append(parts, n)
+	}
+	return parts, nil
+}
+
+// isDigitAt reports whether s has an ASCII digit at index i
+func isDigitAt(s string, i int) bool {
+	return i >= 0 && i < len(s) && s[i] >= '0' && s[i] <= '9'
+}
+
+// String returns the string representation of the version
+func (v *Version) String() string {
+	return v.original
+}
+
+// Normal returns the dotted-decimal form of the version with at least three
+// components, e.g. v1.2.3 for 1.002003, matching version.pm's normal method
+func (v *Version) Normal() string {
+	parts := slices.Clone(v.parts)
+	for len(parts) < 3 {
+		parts = append(parts, 0)
+	}
+
+	strs := make([]string, len(parts))
+	for i, p := range parts {
+		strs[i] = strconv.Itoa(p)
+	}
+	return "v" + strings.Join(strs, ".")
+}
+
+// IsDotted reports whether the version was written in dotted-decimal form
+func (v *Version) IsDotted() bool {
+	return v.dotted
+}
+
+// IsAlpha reports whether the version is a developer release marked with an underscore
+func (v *Version) IsAlpha() bool {
+	return v.alpha
+}
+
+// Compare compares this version with another CPAN version. Missing components
+// count as zero, so 1.5, 1.500 and v1.500.0 are equal.
+func (v *Version) Compare(other *Version) int {
+	n := max(len(v.parts), len(other.parts))
+	for i := 0; i < n; i++ {
+		var a, b int
+		if i < len(v.parts) {
+			a = v.parts[i]
+		}
+		if i < len(other.parts) {
+			b = other.parts[i]
+		}
+		if a != b {
+			return compareInt(a, b)
+		}
+	}
+	return 0
+}
+
+// compareInt returns -1 if a < b, 0 if a == b, 1 if a > b
+func compareInt(a, b int) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
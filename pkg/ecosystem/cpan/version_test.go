@@ -0,0 +1,296 @@
+package cpan
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestEcosystem_NewVersion(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantParts []int
+		wantDot   bool
+		wantAlpha bool
+		wantErr   bool
+	}{
+		// Decimal versions
+		{
+			name:      "integer",
+			input:     "5",
+			wantParts: []int{5},
+		},
+		{
+			name:      "decimal",
+			input:     "1.23",
+			wantParts: []int{1, 230},
+		},
+		{
+			name:      "decimal with three digit groups",
+			input:     "1.002003",
+			wantParts: []int{1, 2, 3},
+		},
+		{
+			name:      "decimal with partial last group",
+			input:     "5.0101",
+			wantParts: []int{5, 10, 100},
+		},
+		{
+			name:      "decimal developer release",
+			input:     "1.002_003",
+			wantParts: []int{1, 2, 3},
+			wantAlpha: true,
+		},
+		{
+			name:      "decimal with whitespace",
+			input:     "  1.5  ",
+			wantParts: []int{1, 500},
+		},
+		// Dotted-decimal versions
+		{
+			name:      "v-string",
+			input:     "v5.10.1",
+			wantParts: []int{5, 10, 1},
+			wantDot:   true,
+		},
+		{
+			name:      "v-string with two components",
+			input:     "v1.2",
+			wantParts: []int{1, 2, 0},
+			wantDot:   true,
+		},
+		{
+			name:      "v-string with one component",
+			input:     "v1",
+			wantParts: []int{1, 0, 0},
+			wantDot:   true,
+		},
+		{
+			name:      "dotted without v",
+			input:     "1.2.3",
+			wantParts: []int{1, 2, 3},
+			wantDot:   true,
+		},
+		{
+			name:      "dotted with four components",
+			input:     "v1.2.3.4",
+			wantParts: []int{1, 2, 3, 4},
+			wantDot:   true,
+		},
+		{
+			name:      "dotted developer release",
+			input:     "v1.2.3_4",
+			wantParts: []int{1, 2, 34},
+			wantDot:   true,
+			wantAlpha: true,
+		},
+		// Error cases
+		{
+			name:    "empty string",
+			input:   "",
+			wantErr: true,
+		},
+		{
+			name:    "bare v",
+			input:   "v",
+			wantErr: true,
+		},
+		{
+			name:    "trailing dot",
+			input:   "1.",
+			wantErr: true,
+		},
+		{
+			name:    "leading dot",
+			input:   ".5",
+			wantErr: true,
+		},
+		{
+			name:    "letters",
+			input:   "1.2a",
+			wantErr: true,
+		},
+		{
+			name:    "multiple underscores",
+			input:   "1.2_3_4",
+			wantErr: true,
+		},
+		{
+			name:    "trailing underscore",
+			input:   "1.23_",
+			wantErr: true,
+		},
+		{
+			name:    "underscore after dot",
+			input:   "1._23",
+			wantErr: true,
+		},
+		{
+			name:    "negative",
+			input:   "-1.0",
+			wantErr: true,
+		},
+		{
+			name:    "component too large",
+			input:   "v1.99999999999999999999",
+			wantErr: true,
+		},
+	}
+
+	ecosystem := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ecosystem.NewVersion(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Ecosystem.NewVersion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+
+			if got.String() != tt.input {
+				t.Errorf("Version.String() = %q, want %q", got.String(), tt.input)
+			}
+			if !slices.Equal(got.parts, tt.wantParts) {
+				t.Errorf("Version parts = %v, want %v", got.parts, tt.wantParts)
+			}
+			if got.IsDotted() != tt.wantDot {
+				t.Errorf("Version.IsDotted() = %v, want %v", got.IsDotted(), tt.wantDot)
+			}
+			if got.IsAlpha() != tt.wantAlpha {
+				t.Errorf("Version.IsAlpha() = %v, want %v", got.IsAlpha(), tt.wantAlpha)
+			}
+		})
+	}
+}
+
+func TestVersion_Normal(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "5", want: "v5.0.0"},
+		{input: "1.23", want: "v1.230.0"},
+		{input: "1.002003", want: "v1.2.3"},
+		{input: "5.010001", want: "v5.10.1"},
+		{input: "v1.2", want: "v1.2.0"},
+		{input: "1.2.3.4", want: "v1.2.3.4"},
+		{input: "v1.02.3", want: "v1.2.3"},
+	}
+
+	ecosystem := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			v, err := ecosystem.NewVersion(tt.input)
+			if err != nil {
+				t.Fatalf("Failed to parse version %s: %v", tt.input, err)
+			}
+
+			if got := v.Normal(); got != tt.want {
+				t.Errorf("Version.Normal() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersion_Compare(t *testing.T) {
+	tests := []struct {
+		name string
+		v1   string
+		v2   string
+		want int
+	}{
+		{
+			name: "equal decimals",
+			v1:   "1.23",
+			v2:   "1.23",
+			want: 0,
+		},
+		{
+			name: "trailing zeros are insignificant",
+			v1:   "1.5",
+			v2:   "1.500",
+			want: 0,
+		},
+		{
+			name: "decimal equals dotted",
+			v1:   "1.002003",
+			v2:   "v1.2.3",
+			want: 0,
+		},
+		{
+			name: "perl version forms",
+			v1:   "5.010001",
+			v2:   "v5.10.1",
+			want: 0,
+		},
+		{
+			name: "decimal fractions compare as decimals",
+			v1:   "1.10",
+			v2:   "1.9",
+			want: -1,
+		},
+		{
+			name: "dotted components compare numerically",
+			v1:   "v1.10.0",
+			v2:   "v1.9.0",
+			want: 1,
+		},
+		{
+			name: "short decimal versus dotted",
+			v1:   "1.2",
+			v2:   "v1.2.0",
+			want: 1,
+		},
+		{
+			name: "integer equals padded dotted",
+			v1:   "1",
+			v2:   "v1.0.0",
+			want: 0,
+		},
+		{
+			name: "developer release ignores underscore",
+			v1:   "1.002_003",
+			v2:   "1.002003",
+			want: 0,
+		},
+		{
+			name: "major wins",
+			v1:   "2.0",
+			v2:   "1.999",
+			want: 1,
+		},
+		{
+			name: "extra dotted component",
+			v1:   "v1.2.3",
+			v2:   "v1.2.3.1",
+			want: -1,
+		},
+	}
+
+	ecosystem := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v1, err := ecosystem.NewVersion(tt.v1)
+			if err != nil {
+				t.Fatalf("Failed to parse v1 %s: %v", tt.v1, err)
+			}
+
+			v2, err := ecosystem.NewVersion(tt.v2)
+			if err != nil {
+				t.Fatalf("Failed to parse v2 %s: %v", tt.v2, err)
+			}
+
+			got := v1.Compare(v2)
+			if got != tt.want {
+				t.Errorf("Version.Compare() = %v, want %v (v1=%s, v2=%s)", got, tt.want, tt.v1, tt.v2)
+			}
+
+			reverse := v2.Compare(v1)
+			if reverse != -tt.want {
+				t.Errorf("Reverse comparison failed: v2.Compare(v1) = %v, want %v", reverse, -tt.want)
+			}
+		})
+	}
+}
@@ -1,8 +1,10 @@
 // Package cran provides functionality for working with CRAN (R package) versions.
 package cran
 
+import "github.com/alowayed/go-univers/pkg/univers"
+
 const (
-	Name = "cran"
+	Name = string(univers.CRAN)
 )
 
 type Ecosystem struct{}
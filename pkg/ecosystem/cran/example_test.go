@@ -0,0 +1,49 @@
+package cran_test
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/cran"
+)
+
+// Dashes and dots both separate components: 1.2-3 is 1.2.3.
+func ExampleVersion_Compare() {
+	e := &cran.Ecosystem{}
+
+	var versions []*cran.Version
+	for _, s := range []string{"1.2.10", "1.2-3", "1.10-0", "0.9.1"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		versions = append(versions, v)
+	}
+	slices.SortFunc(versions, (*cran.Version).Compare)
+	fmt.Println(versions)
+	// Output:
+	// [0.9.1 1.2-3 1.2.10 1.10-0]
+}
+
+// Ranges are comparators separated by commas, as in DESCRIPTION files.
+func ExampleVersionRange_Contains() {
+	e := &cran.Ecosystem{}
+
+	r, err := e.NewVersionRange(">= 1.0, < 2.0")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, s := range []string{"1.9-1", "2.0-0"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(s, r.Contains(v))
+	}
+	// Output:
+	// 1.9-1 true
+	// 2.0-0 false
+}
@@ -2,7 +2,10 @@ package cran
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 // VersionRange represents a CRAN version range with CRAN-specific syntax support
@@ -17,16 +20,37 @@ type constraint struct {
 	version  *Version
 }
 
+// features declares the range syntax accepted by NewVersionRange
+var features = []univers.Feature{
+	{Name: univers.FeatureComparison, Syntax: ">= 1.2.0", Description: "Ordering operators >=, >, <=, < and ="},
+	{Name: univers.FeatureNotEqual, Syntax: "!= 1.2.1", Description: "Excludes a single version"},
+	{Name: univers.FeatureExact, Syntax: "1.2-3", Description: "A bare version matches only itself"},
+	{Name: univers.FeatureAnd, Syntax: ">= 1.0, < 2.0", Description: "Comma-separated constraints must all match"},
+}
+
+// Features returns the range syntax features supported by NewVersionRange
+func (e *Ecosystem) Features() []univers.Feature {
+	return slices.Clone(features)
+}
+
 // NewVersionRange creates a new CRAN version range from a range string
 func (e *Ecosystem) NewVersionRange(rangeStr string) (*VersionRange, error) {
+	if err := univers.CheckLength(rangeStr); err != nil {
+		return nil, err
+	}
+
 	original := rangeStr
 	rangeStr = strings.TrimSpace(rangeStr)
 	if rangeStr == "" {
-		return nil, fmt.Errorf("empty range string")
+		return nil, univers.WrapParseError(original, fmt.Errorf("empty range string"))
 	}
 
 	constraints, err := parseConstraints(rangeStr, e)
 	if err != nil {
+		return nil, univers.WrapParseError(original, err)
+	}
+
+	if err := univers.CheckConstraints(len(constraints)); err != nil {
 		return nil, err
 	}
 
@@ -42,7 +66,11 @@ func parseConstraints(rangeStr string, e *Ecosystem) ([]*constraint, error) {
 	parts := strings.Split(rangeStr, ",")
 	var constraints []*constraint
 
+	offset := 0
 	for _, part := range parts {
+		start := offset + strings.Index(rangeStr[offset:], part)
+		offset = start + len(part)
+
 		part = strings.TrimSpace(part)
 		if part == "" {
 			continue
@@ -50,7 +78,7 @@ func parseConstraints(rangeStr string, e *Ecosystem) ([]*constraint, error) {
 
 		constraint, err := parseConstraint(part, e)
 		if err != nil {
-			return nil, err
+			return nil, univers.TokenErrorAt(rangeStr, start, part, err)
 		}
 		constraints = append(constraints, constraint)
 	}
@@ -108,6 +136,15 @@ func (vr *VersionRange) Contains(version *Version) bool {
 	return true
 }
 
+// Comparators returns the constraints as a single AND group
+func (vr *VersionRange) Comparators() [][]univers.Comparator {
+	group := make([]univers.Comparator, 0, len(vr.constraints))
+	for _, c := range vr.constraints {
+		group = append(group, univers.Comparator{Operator: c.operator, Version: c.version.String()})
+	}
+	return [][]univers.Comparator{group}
+}
+
 // satisfiesConstraint checks if a version satisfies a single constraint
 func satisfiesConstraint(version *Version, c *constraint) bool {
 	cmp := version.Compare(c.version)
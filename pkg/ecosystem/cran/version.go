@@ -6,6 +6,9 @@ import (
 	"math/big"
 	"regexp"
 	"strings"
+
+	"github.com/alowayed/go-univers/internal/digits"
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 // versionPattern matches CRAN version strings - at least two non-negative integers separated by . or -
@@ -19,6 +22,10 @@ type Version struct {
 
 // NewVersion creates a new CRAN version from a string
 func (e *Ecosystem) NewVersion(version string) (*Version, error) {
+	if err := univers.CheckLength(version); err != nil {
+		return nil, err
+	}
+
 	original := version
 	// Trim whitespace
 	version = strings.TrimSpace(version)
@@ -76,6 +83,31 @@ func (e *Ecosystem) NewVersion(version string) (*Version, error) {
 	}, nil
 }
 
+// ValidVersion reports whether NewVersion accepts version, without
+// allocating: the version must match versionPattern and every component
+// must fit an int.
+func ValidVersion(version string) bool {
+	if univers.CheckLength(version) != nil {
+		return false
+	}
+	version = strings.TrimSpace(version)
+	if !versionPattern.MatchString(version) {
+		return false
+	}
+
+	// The pattern alternates digit runs and single separators
+	for rest := version; rest != ""; {
+		n := digits.Prefix(rest)
+		if !digits.FitsInt(rest[:n]) {
+			return false
+		}
+		if rest = rest[n:]; rest != "" {
+			rest = rest[1:]
+		}
+	}
+	return true
+}
+
 // String returns the string representation of the version
 func (v *Version) String() string {
 	return v.original
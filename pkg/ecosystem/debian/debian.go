@@ -1,8 +1,10 @@
 // Package debian provides functionality for working with Debian package versions.
 package debian
 
+import "github.com/alowayed/go-univers/pkg/univers"
+
 const (
-	Name = "debian"
+	Name = string(univers.Debian)
 )
 
 type Ecosystem struct{}
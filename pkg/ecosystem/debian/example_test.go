@@ -0,0 +1,72 @@
+package debian_test
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/debian"
+)
+
+// A tilde sorts before anything, even the end of the version, and an epoch
+// outranks the rest.
+func ExampleVersion_Compare() {
+	e := &debian.Ecosystem{}
+
+	var versions []*debian.Version
+	for _, s := range []string{"2.0-1", "1:1.0-1", "1.0-1", "1.0~rc1-1", "1.0+dfsg-1"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		versions = append(versions, v)
+	}
+	slices.SortFunc(versions, (*debian.Version).Compare)
+	fmt.Println(versions)
+	// Output:
+	// [1.0~rc1-1 1.0-1 1.0+dfsg-1 2.0-1 1:1.0-1]
+}
+
+// Ranges use dpkg's relations, separated by commas.
+func ExampleVersionRange_Contains() {
+	e := &debian.Ecosystem{}
+
+	r, err := e.NewVersionRange(">= 1.0-1, << 2.0")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, s := range []string{"1.5-2", "2.0~beta1-1", "2.0-1"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(s, r.Contains(v))
+	}
+	// Output:
+	// 1.5-2 true
+	// 2.0~beta1-1 true
+	// 2.0-1 false
+}
+
+// Ubuntu rebuilds of a fixed Debian version are fixed as well.
+func ExampleVersionRange_ContainsWith() {
+	e := &debian.Ecosystem{}
+
+	affected, err := e.NewVersionRange("<= 1.2-3")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	v, err := e.NewVersion("1.2-3build1")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(affected.Contains(v))
+	fmt.Println(affected.ContainsWith(v, debian.CompareOptions{UbuntuRebuilds: true}))
+	// Output:
+	// false
+	// true
+}
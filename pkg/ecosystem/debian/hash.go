@@ -0,0 +1,22 @@
+package debian
+
+import "github.com/alowayed/go-univers/pkg/univers"
+
+// Hash returns a content hash of the constraints. It does not change with
+// whitespace, constraint order, duplicates or the choice between the strict
+// operators and their aliases, so ">> 1.0, << 2.0" and "< 2.0, > 1.0" hash
+// the same.
+func (vr *VersionRange) Hash() uint64 {
+	group := make([]univers.HashedConstraint, 0, len(vr.constraints))
+	for _, c := range vr.constraints {
+		operator := c.operator
+		switch operator {
+		case ">>":
+			operator = ">"
+		case "<<":
+			operator = "<"
+		}
+		group = append(group, univers.HashedConstraint{Operator: operator, Key: c.version.SortKey()})
+	}
+	return univers.HashConstraints([][]univers.HashedConstraint{group})
+}
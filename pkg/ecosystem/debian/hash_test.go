@@ -0,0 +1,37 @@
+package debian
+
+import "testing"
+
+func TestVersionRange_Hash(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		wantSame bool
+	}{
+		{name: "identical", a: ">= 1.0-1", b: ">= 1.0-1", wantSame: true},
+		{name: "whitespace", a: ">= 1.0-1, << 2.0", b: ">=1.0-1,<<2.0", wantSame: true},
+		{name: "constraint order", a: ">= 1.0, << 2.0", b: "<< 2.0, >= 1.0", wantSame: true},
+		{name: "strict aliases", a: ">> 1.0, << 2.0", b: "> 1.0, < 2.0", wantSame: true},
+		{name: "implicit epoch", a: ">= 0:1.0", b: ">= 1.0", wantSame: true},
+		{name: "different version", a: ">= 1.0-1", b: ">= 1.0-2"},
+		{name: "tilde", a: ">= 1.0~rc1", b: ">= 1.0"},
+		{name: "different operator", a: ">> 1.0", b: ">= 1.0"},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := e.NewVersionRange(tt.a)
+			if err != nil {
+				t.Fatalf("NewVersionRange(%q) error = %v", tt.a, err)
+			}
+			b, err := e.NewVersionRange(tt.b)
+			if err != nil {
+				t.Fatalf("NewVersionRange(%q) error = %v", tt.b, err)
+			}
+			if got := a.Hash() == b.Hash(); got != tt.wantSame {
+				t.Errorf("Hash(%q) == Hash(%q) = %v, want %v", tt.a, tt.b, got, tt.wantSame)
+			}
+		})
+	}
+}
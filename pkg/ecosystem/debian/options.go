@@ -0,0 +1,70 @@
+package debian
+
+import "strings"
+
+// CompareOptions adjusts version comparison for distribution-specific suffix
+// conventions. The zero value compares exactly like dpkg --compare-versions.
+type CompareOptions struct {
+	// UbuntuRebuilds ignores a trailing Ubuntu no-change rebuild suffix, such
+	// as "build1" in "1.2-3build1" or "build0.22.04.1" in
+	// "1.1.1n-0+deb11u5build0.22.04.1". A rebuild ships the same source as
+	// the version it was built from, so a Debian security-tracker entry fixed
+	// in "1.2-3" also covers "1.2-3build1", and a last-affected "<= 1.2-3"
+	// still matches it. The suffix is taken from the revision, or from the
+	// upstream version of native packages.
+	UbuntuRebuilds bool
+}
+
+// CompareWith compares this version with another Debian version using the
+// given options. With the zero CompareOptions it is equivalent to Compare.
+func (v *Version) CompareWith(other *Version, opts CompareOptions) int {
+	return v.withOptions(opts).Compare(other.withOptions(opts))
+}
+
+// ContainsWith checks if a version satisfies this range, comparing the
+// version and every constraint version using the given options.
+func (vr *VersionRange) ContainsWith(version *Version, opts CompareOptions) bool {
+	version = version.withOptions(opts)
+	for _, c := range vr.constraints {
+		if !satisfiesConstraint(version, &constraint{operator: c.operator, version: c.version.withOptions(opts)}) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// withOptions returns the version as it compares under opts
+func (v *Version) withOptions(opts CompareOptions) *Version {
+	if !opts.UbuntuRebuilds {
+		return v
+	}
+
+	trimmed := *v
+	if trimmed.revision != "" {
+		trimmed.revision = trimRebuild(trimmed.revision)
+	} else {
+		trimmed.upstream = trimRebuild(trimmed.upstream)
+	}
+	return &trimmed
+}
+
+// trimRebuild removes a trailing "buildN" or "buildN.M..." suffix following
+// a digit, as in "3build1" or "ubuntu1build1", and leaves any other s as is
+func trimRebuild(s string) string {
+	idx := strings.LastIndex(s, "build")
+	if idx <= 0 || s[idx-1] < '0' || s[idx-1] > '9' {
+		return s
+	}
+
+	rest := s[idx+len("build"):]
+	if rest == "" || rest[0] < '0' || rest[0] > '9' {
+		return s
+	}
+	for _, r := range rest {
+		if (r < '0' || r > '9') && r != '.' {
+			return s
+		}
+	}
+	return s[:idx]
+}
@@ -0,0 +1,81 @@
+package debian
+
+import "testing"
+
+func TestVersion_CompareWith(t *testing.T) {
+	tests := []struct {
+		name string
+		v1   string
+		v2   string
+		opts CompareOptions
+		want int
+	}{
+		{name: "zero options match dpkg", v1: "1.2-3build1", v2: "1.2-3", want: 1},
+		{name: "rebuild ignored", v1: "1.2-3build1", v2: "1.2-3", opts: CompareOptions{UbuntuRebuilds: true}, want: 0},
+		{name: "release rebuild ignored", v1: "1.1.1n-0+deb11u5build0.22.04.1", v2: "1.1.1n-0+deb11u5", opts: CompareOptions{UbuntuRebuilds: true}, want: 0},
+		{name: "native rebuild ignored", v1: "0.9.8build1", v2: "0.9.8", opts: CompareOptions{UbuntuRebuilds: true}, want: 0},
+		{name: "source change kept", v1: "1.2-3build1", v2: "1.2-3ubuntu1", opts: CompareOptions{UbuntuRebuilds: true}, want: -1},
+		{name: "non-numeric suffix kept", v1: "1.2-3rebuild1", v2: "1.2-3", opts: CompareOptions{UbuntuRebuilds: true}, want: 1},
+		{name: "revision made only of rebuild kept", v1: "1.2-build1", v2: "1.2-0", opts: CompareOptions{UbuntuRebuilds: true}, want: 1},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v1, err := e.NewVersion(tt.v1)
+			if err != nil {
+				t.Fatalf("NewVersion(%q) error = %v", tt.v1, err)
+			}
+			v2, err := e.NewVersion(tt.v2)
+			if err != nil {
+				t.Fatalf("NewVersion(%q) error = %v", tt.v2, err)
+			}
+			if got := v1.CompareWith(v2, tt.opts); got != tt.want {
+				t.Errorf("CompareWith(%q, %q, %+v) = %d, want %d", tt.v1, tt.v2, tt.opts, got, tt.want)
+			}
+			if v1.String() != tt.v1 {
+				t.Errorf("CompareWith() changed version to %q", v1.String())
+			}
+		})
+	}
+}
+
+func TestVersion_CompareWith_Fixture(t *testing.T) {
+	opts := CompareOptions{UbuntuRebuilds: true}
+	testCompareFixture(t, "testdata/compare_ubuntu_rebuilds.txt", func(v1, v2 *Version) int {
+		return v1.CompareWith(v2, opts)
+	})
+}
+
+func TestVersionRange_ContainsWith(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		version  string
+		opts     CompareOptions
+		want     bool
+	}{
+		{name: "last affected excludes rebuild", rangeStr: "<= 1.2-3", version: "1.2-3build1", want: false},
+		{name: "last affected includes rebuild", rangeStr: "<= 1.2-3", version: "1.2-3build1", opts: CompareOptions{UbuntuRebuilds: true}, want: true},
+		{name: "fixed in rebuild", rangeStr: "<< 1.2-3build1", version: "1.2-3", opts: CompareOptions{UbuntuRebuilds: true}, want: false},
+		{name: "fixed in later source", rangeStr: "<< 1.2-3ubuntu1", version: "1.2-3build2", opts: CompareOptions{UbuntuRebuilds: true}, want: true},
+		{name: "exact", rangeStr: "= 1.1.1n-0+deb11u5", version: "1.1.1n-0+deb11u5build0.22.04.1", opts: CompareOptions{UbuntuRebuilds: true}, want: true},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vr, err := e.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("NewVersionRange(%q) error = %v", tt.rangeStr, err)
+			}
+			v, err := e.NewVersion(tt.version)
+			if err != nil {
+				t.Fatalf("NewVersion(%q) error = %v", tt.version, err)
+			}
+			if got := vr.ContainsWith(v, tt.opts); got != tt.want {
+				t.Errorf("ContainsWith(%q, %+v) = %v, want %v", tt.version, tt.opts, got, tt.want)
+			}
+		})
+	}
+}
@@ -2,7 +2,10 @@ package debian
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 // VersionRange represents a Debian version range with Debian-specific syntax support
@@ -17,16 +20,37 @@ type constraint struct {
 	version  *Version
 }
 
+// features declares the range syntax accepted by NewVersionRange
+var features = []univers.Feature{
+	{Name: univers.FeatureComparison, Syntax: ">= 1.0-1, << 2.0", Description: "Ordering operators >=, <=, =, the strict >> and << and their aliases > and <"},
+	{Name: univers.FeatureNotEqual, Syntax: "!= 1.0-1", Description: "Excludes a single version"},
+	{Name: univers.FeatureExact, Syntax: "1:1.0-1", Description: "A bare version matches only itself"},
+	{Name: univers.FeatureAnd, Syntax: ">= 1.0, << 2.0", Description: "Comma-separated constraints must all match"},
+}
+
+// Features returns the range syntax features supported by NewVersionRange
+func (e *Ecosystem) Features() []univers.Feature {
+	return slices.Clone(features)
+}
+
 // NewVersionRange creates a new Debian version range from a range string
 func (e *Ecosystem) NewVersionRange(rangeStr string) (*VersionRange, error) {
+	if err := univers.CheckLength(rangeStr); err != nil {
+		return nil, err
+	}
+
 	original := rangeStr
 	rangeStr = strings.TrimSpace(rangeStr)
 	if rangeStr == "" {
-		return nil, fmt.Errorf("empty range string")
+		return nil, univers.WrapParseError(original, fmt.Errorf("empty range string"))
 	}
 
 	constraints, err := parseConstraints(rangeStr, e)
 	if err != nil {
+		return nil, univers.WrapParseError(original, err)
+	}
+
+	if err := univers.CheckConstraints(len(constraints)); err != nil {
 		return nil, err
 	}
 
@@ -42,7 +66,11 @@ func parseConstraints(rangeStr string, ecosystem *Ecosystem) ([]*constraint, err
 	parts := strings.Split(rangeStr, ",")
 	var constraints []*constraint
 
+	offset := 0
 	for _, part := range parts {
+		start := offset + strings.Index(rangeStr[offset:], part)
+		offset = start + len(part)
+
 		part = strings.TrimSpace(part)
 		if part == "" {
 			continue
@@ -50,7 +78,7 @@ func parseConstraints(rangeStr string, ecosystem *Ecosystem) ([]*constraint, err
 
 		constraint, err := parseConstraint(part, ecosystem)
 		if err != nil {
-			return nil, err
+			return nil, univers.TokenErrorAt(rangeStr, start, part, err)
 		}
 		constraints = append(constraints, constraint)
 	}
@@ -111,6 +139,23 @@ func (vr *VersionRange) Contains(version *Version) bool {
 	return true
 }
 
+// Comparators returns the constraints as a single AND group, with the strict
+// ">>" and "<<" spelled ">" and "<"
+func (vr *VersionRange) Comparators() [][]univers.Comparator {
+	group := make([]univers.Comparator, 0, len(vr.constraints))
+	for _, c := range vr.constraints {
+		op := c.operator
+		switch op {
+		case ">>":
+			op = ">"
+		case "<<":
+			op = "<"
+		}
+		group = append(group, univers.Comparator{Operator: op, Version: c.version.String()})
+	}
+	return [][]univers.Comparator{group}
+}
+
 // satisfiesConstraint checks if a version satisfies a single constraint
 func satisfiesConstraint(version *Version, c *constraint) bool {
 	cmp := version.Compare(c.version)
@@ -0,0 +1,65 @@
+package debian
+
+import (
+	"encoding/binary"
+	"strings"
+	"unicode"
+)
+
+// SortKey returns a byte key whose lexicographic order matches Compare.
+//
+// The key holds the epoch followed by encodings of the upstream version and
+// the revision (an empty revision is encoded as "0"). Each is split into the
+// same alternating non-digit and digit runs that Compare walks. Non-digit runs
+// keep their letters, lift other characters above all letters and remap '~'
+// below the run terminator so that it sorts before the end of a run; digit runs are encoded by magnitude so that "10"
+// sorts after "9" and "007" equals "7".
+func (v *Version) SortKey() []byte {
+	key := make([]byte, 0, 2*len(v.original)+16)
+	key = binary.BigEndian.AppendUint64(key, uint64(v.epoch)^(1<<63))
+	key = appendDebianSortKey(key, v.upstream)
+
+	revision := v.revision
+	if revision == "" {
+		revision = "0"
+	}
+	return appendDebianSortKey(key, revision)
+}
+
+// appendDebianSortKey appends the key for a single upstream or revision string.
+func appendDebianSortKey(key []byte, s string) []byte {
+	i := 0
+	for i < len(s) {
+		// Non-digit run: '~' sorts before the terminator, everything else after
+		// it, with letters before other characters
+		for i < len(s) && !unicode.IsDigit(rune(s[i])) {
+			switch {
+			case s[i] == '~':
+				key = append(key, 0x01)
+			case unicode.IsLetter(rune(s[i])):
+				key = append(key, s[i])
+			default:
+				key = append(key, 0x80|s[i])
+			}
+			i++
+		}
+		key = append(key, 0x02)
+
+		// Digit run: empty sorts before any number, numbers compare by magnitude
+		start := i
+		for i < len(s) && unicode.IsDigit(rune(s[i])) {
+			i++
+		}
+		if start == i {
+			key = append(key, 0x00)
+			continue
+		}
+		digits := strings.TrimLeft(s[start:i], "0")
+		key = append(key, 0x01)
+		key = binary.BigEndian.AppendUint16(key, uint16(len(digits)))
+		key = append(key, digits...)
+	}
+
+	// The end of the string compares like an empty non-digit and digit run
+	return append(key, 0x02, 0x00)
+}
@@ -0,0 +1,62 @@
+package debian
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestVersion_SortKey(t *testing.T) {
+	versions := []string{
+		"0",
+		"1.0~~",
+		"1.0~~a",
+		"1.0~",
+		"1.0~beta1",
+		"1.0",
+		"1.0-0",
+		"1.0-1",
+		"1.0-1~bpo1",
+		"1.0-1+deb10u1",
+		"1.0-1.1",
+		"1.2-3build1",
+		"1.2-3+deb12u1",
+		"0.9.8build1",
+		"0.9.8.1",
+		"1.0-2",
+		"1.0a",
+		"1.0.0",
+		"1.00",
+		"1.1",
+		"1.9",
+		"1.10",
+		"1.a",
+		"1.a0",
+		"1.a1",
+		"1:0.1",
+		"1:1.0-1",
+		"2:0",
+		"2.0+dfsg-1ubuntu1",
+		"007",
+		"7",
+		"99999999999999999999",
+	}
+
+	e := &Ecosystem{}
+	parsed := make([]*Version, len(versions))
+	for i, s := range versions {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			t.Fatalf("NewVersion(%q) error = %v", s, err)
+		}
+		parsed[i] = v
+	}
+
+	for _, a := range parsed {
+		for _, b := range parsed {
+			want := a.Compare(b)
+			if got := bytes.Compare(a.SortKey(), b.SortKey()); got != want {
+				t.Errorf("bytes.Compare(SortKey(%q), SortKey(%q)) = %d, want %d", a, b, got, want)
+			}
+		}
+	}
+}
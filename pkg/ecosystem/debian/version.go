@@ -6,6 +6,9 @@ import (
 	"strconv"
 	"strings"
 	"unicode"
+
+	"github.com/alowayed/go-univers/internal/digits"
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 // versionPattern matches Debian version strings
@@ -22,6 +25,10 @@ type Version struct {
 
 // NewVersion creates a new Debian version from a string
 func (e *Ecosystem) NewVersion(version string) (*Version, error) {
+	if err := univers.CheckLength(version); err != nil {
+		return nil, err
+	}
+
 	original := version
 	version = strings.TrimSpace(version)
 
@@ -79,6 +86,38 @@ func (e *Ecosystem) NewVersion(version string) (*Version, error) {
 	}, nil
 }
 
+// ValidVersion reports whether NewVersion accepts version. It applies the
+// same rules without allocating, which suits filters over large package
+// indexes that only need to accept or reject versions.
+func ValidVersion(version string) bool {
+	if univers.CheckLength(version) != nil {
+		return false
+	}
+	version = strings.TrimSpace(version)
+	if version == "" {
+		return false
+	}
+
+	if n := digits.Prefix(version); n > 0 && n < len(version)-1 && version[n] == ':' {
+		if !digits.FitsInt(version[:n]) {
+			return false
+		}
+		version = version[n+1:]
+	}
+
+	// The upstream version leads and must start with a digit; it and the
+	// revision after the last hyphen share one character set
+	if version[0] < '0' || version[0] > '9' {
+		return false
+	}
+	for _, r := range version {
+		if !isValidVersionChar(r) {
+			return false
+		}
+	}
+	return true
+}
+
 // validateVersionString validates that a version string contains only allowed characters
 func validateVersionString(s, part string) error {
 	for _, r := range s {
@@ -215,15 +254,17 @@ func compareDebianNonDigits(a, b string) int {
 }
 
 // getDebianCharWeight returns the sort weight for a character per Debian rules
-// Tilde (~) sorts earliest, then null, then letters/other chars
+// Tilde (~) sorts earliest, then null, then letters, then other chars
 func getDebianCharWeight(r rune) int {
-	switch r {
-	case '~':
+	switch {
+	case r == '~':
 		return -1 // Tilde sorts before everything else
-	case 0:
+	case r == 0:
 		return 0 // Null/missing character
+	case unicode.IsLetter(r):
+		return int(r) // Letters sort by their Unicode value
 	default:
-		return int(r) // Use Unicode value for other characters
+		return int(r) + 256 // Non-letters such as '+' and '.' sort after letters
 	}
 }
 
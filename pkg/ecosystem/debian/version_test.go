@@ -1,6 +1,10 @@
 package debian
 
 import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
 	"testing"
 )
 
@@ -305,3 +309,77 @@ func TestVersion_String(t *testing.T) {
 		})
 	}
 }
+
+func TestVersion_Compare_Fixture(t *testing.T) {
+	testCompareFixture(t, "testdata/compare.txt", func(v1, v2 *Version) int {
+		return v1.Compare(v2)
+	})
+}
+
+// testCompareFixture checks every "v1 [<|=|>] v2" line of a fixture file
+// against compare
+func testCompareFixture(t *testing.T, filename string, compare func(v1, v2 *Version) int) {
+	t.Helper()
+	e := &Ecosystem{}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		t.Fatalf("Failed to read fixture file %q: %v", filename, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := removeComments(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		parts := strings.Fields(line)
+		name := fmt.Sprintf("%s:%d: %s", filename, lineNumber, line)
+
+		t.Run(name, func(t *testing.T) {
+			if len(parts) != 3 {
+				t.Fatalf("Invalid line format. Expected \"v1 [<|=|>] v2\", got: %q", line)
+			}
+			symbolToCompare := map[string]int{
+				"<": -1,
+				"=": 0,
+				">": 1,
+			}
+			want, ok := symbolToCompare[parts[1]]
+			if !ok {
+				t.Fatalf("Invalid comparison operator in line: %q", line)
+			}
+
+			v1, err := e.NewVersion(parts[0])
+			if err != nil {
+				t.Fatalf("NewVersion(%q) error: %v", parts[0], err)
+			}
+			v2, err := e.NewVersion(parts[2])
+			if err != nil {
+				t.Fatalf("NewVersion(%q) error: %v", parts[2], err)
+			}
+
+			if got := compare(v1, v2); got != want {
+				t.Errorf("Compare(%q, %q) = %d, want %d", v1, v2, got, want)
+			}
+			if got := compare(v2, v1); got != -want {
+				t.Errorf("Compare(%q, %q) = %d, want %d", v2, v1, got, -want)
+			}
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("Error reading fixture file: %v", err)
+	}
+}
+
+func removeComments(line string) string {
+	if idx := strings.Index(line, "#"); idx != -1 {
+		line = line[:idx]
+	}
+	return strings.TrimSpace(line)
+}
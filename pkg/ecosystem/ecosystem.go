@@ -4,18 +4,24 @@ import (
 	"github.com/alowayed/go-univers/pkg/ecosystem/alpine"
 	"github.com/alowayed/go-univers/pkg/ecosystem/alpm"
 	"github.com/alowayed/go-univers/pkg/ecosystem/apache"
+	"github.com/alowayed/go-univers/pkg/ecosystem/bazel"
 	"github.com/alowayed/go-univers/pkg/ecosystem/cargo"
 	"github.com/alowayed/go-univers/pkg/ecosystem/composer"
 	"github.com/alowayed/go-univers/pkg/ecosystem/conan"
+	"github.com/alowayed/go-univers/pkg/ecosystem/cpan"
 	"github.com/alowayed/go-univers/pkg/ecosystem/cran"
 	"github.com/alowayed/go-univers/pkg/ecosystem/debian"
 	"github.com/alowayed/go-univers/pkg/ecosystem/gem"
+	"github.com/alowayed/go-univers/pkg/ecosystem/genericwin"
 	"github.com/alowayed/go-univers/pkg/ecosystem/gentoo"
 	"github.com/alowayed/go-univers/pkg/ecosystem/github"
 	"github.com/alowayed/go-univers/pkg/ecosystem/golang"
+	"github.com/alowayed/go-univers/pkg/ecosystem/gover"
 	"github.com/alowayed/go-univers/pkg/ecosystem/hex"
+	"github.com/alowayed/go-univers/pkg/ecosystem/luarocks"
 	"github.com/alowayed/go-univers/pkg/ecosystem/mattermost"
 	"github.com/alowayed/go-univers/pkg/ecosystem/maven"
+	"github.com/alowayed/go-univers/pkg/ecosystem/msver"
 	"github.com/alowayed/go-univers/pkg/ecosystem/npm"
 	"github.com/alowayed/go-univers/pkg/ecosystem/nuget"
 	"github.com/alowayed/go-univers/pkg/ecosystem/pypi"
@@ -32,99 +38,193 @@ var (
 	_ univers.Version[*alpine.Version]                         = &alpine.Version{}
 	_ univers.VersionRange[*alpine.Version]                    = &alpine.VersionRange{}
 	_ univers.Ecosystem[*alpine.Version, *alpine.VersionRange] = &alpine.Ecosystem{}
+	_ univers.Featurer                                         = &alpine.Ecosystem{}
+	_ univers.ComparatorRange                                  = &alpine.VersionRange{}
 
 	// alpm
 	_ univers.Version[*alpm.Version]                       = &alpm.Version{}
 	_ univers.VersionRange[*alpm.Version]                  = &alpm.VersionRange{}
 	_ univers.Ecosystem[*alpm.Version, *alpm.VersionRange] = &alpm.Ecosystem{}
+	_ univers.Featurer                                     = &alpm.Ecosystem{}
+	_ univers.ComparatorRange                              = &alpm.VersionRange{}
 
 	// apache
 	_ univers.Version[*apache.Version]                         = &apache.Version{}
 	_ univers.VersionRange[*apache.Version]                    = &apache.VersionRange{}
 	_ univers.Ecosystem[*apache.Version, *apache.VersionRange] = &apache.Ecosystem{}
+	_ univers.Featurer                                         = &apache.Ecosystem{}
+	_ univers.ComparatorRange                                  = &apache.VersionRange{}
+
+	// bazel
+	_ univers.Version[*bazel.Version]                        = &bazel.Version{}
+	_ univers.VersionRange[*bazel.Version]                   = &bazel.VersionRange{}
+	_ univers.Ecosystem[*bazel.Version, *bazel.VersionRange] = &bazel.Ecosystem{}
+	_ univers.Featurer                                       = &bazel.Ecosystem{}
+	_ univers.ComparatorRange                                = &bazel.VersionRange{}
 
 	// cargo
 	_ univers.Version[*cargo.Version]                        = &cargo.Version{}
 	_ univers.VersionRange[*cargo.Version]                   = &cargo.VersionRange{}
 	_ univers.Ecosystem[*cargo.Version, *cargo.VersionRange] = &cargo.Ecosystem{}
+	_ univers.Featurer                                       = &cargo.Ecosystem{}
+	_ univers.SortKeyer                                      = &cargo.Version{}
+	_ univers.Hasher                                         = &cargo.VersionRange{}
+	_ univers.ComparatorRange                                = &cargo.VersionRange{}
 
 	// conan
 	_ univers.Version[*conan.Version]                        = &conan.Version{}
 	_ univers.VersionRange[*conan.Version]                   = &conan.VersionRange{}
 	_ univers.Ecosystem[*conan.Version, *conan.VersionRange] = &conan.Ecosystem{}
+	_ univers.Featurer                                       = &conan.Ecosystem{}
+	_ univers.ComparatorRange                                = &conan.VersionRange{}
 
 	// composer
 	_ univers.Version[*composer.Version]                           = &composer.Version{}
 	_ univers.VersionRange[*composer.Version]                      = &composer.VersionRange{}
 	_ univers.Ecosystem[*composer.Version, *composer.VersionRange] = &composer.Ecosystem{}
+	_ univers.Featurer                                             = &composer.Ecosystem{}
+	_ univers.ComparatorRange                                      = &composer.VersionRange{}
+
+	// cpan
+	_ univers.Version[*cpan.Version]                       = &cpan.Version{}
+	_ univers.VersionRange[*cpan.Version]                  = &cpan.VersionRange{}
+	_ univers.Ecosystem[*cpan.Version, *cpan.VersionRange] = &cpan.Ecosystem{}
+	_ univers.Featurer                                     = &cpan.Ecosystem{}
+	_ univers.ComparatorRange                              = &cpan.VersionRange{}
 
 	// cran
 	_ univers.Version[*cran.Version]                       = &cran.Version{}
 	_ univers.VersionRange[*cran.Version]                  = &cran.VersionRange{}
 	_ univers.Ecosystem[*cran.Version, *cran.VersionRange] = &cran.Ecosystem{}
+	_ univers.Featurer                                     = &cran.Ecosystem{}
+	_ univers.ComparatorRange                              = &cran.VersionRange{}
 
 	// debian
 	_ univers.Version[*debian.Version]                         = &debian.Version{}
 	_ univers.VersionRange[*debian.Version]                    = &debian.VersionRange{}
 	_ univers.Ecosystem[*debian.Version, *debian.VersionRange] = &debian.Ecosystem{}
+	_ univers.Featurer                                         = &debian.Ecosystem{}
+	_ univers.SortKeyer                                        = &debian.Version{}
+	_ univers.Hasher                                           = &debian.VersionRange{}
+	_ univers.ComparatorRange                                  = &debian.VersionRange{}
 
 	// gem
 	_ univers.Version[*gem.Version]                      = &gem.Version{}
 	_ univers.VersionRange[*gem.Version]                 = &gem.VersionRange{}
 	_ univers.Ecosystem[*gem.Version, *gem.VersionRange] = &gem.Ecosystem{}
+	_ univers.Featurer                                   = &gem.Ecosystem{}
+	_ univers.ComparatorRange                            = &gem.VersionRange{}
+
+	// genericwin
+	_ univers.Version[*genericwin.Version]                             = &genericwin.Version{}
+	_ univers.VersionRange[*genericwin.Version]                        = &genericwin.VersionRange{}
+	_ univers.Ecosystem[*genericwin.Version, *genericwin.VersionRange] = &genericwin.Ecosystem{}
+	_ univers.Featurer                                                 = &genericwin.Ecosystem{}
+	_ univers.ComparatorRange                                          = &genericwin.VersionRange{}
 
 	// gentoo
 	_ univers.Version[*gentoo.Version]                         = &gentoo.Version{}
 	_ univers.VersionRange[*gentoo.Version]                    = &gentoo.VersionRange{}
 	_ univers.Ecosystem[*gentoo.Version, *gentoo.VersionRange] = &gentoo.Ecosystem{}
+	_ univers.Featurer                                         = &gentoo.Ecosystem{}
+	_ univers.ComparatorRange                                  = &gentoo.VersionRange{}
 
 	// github
 	_ univers.Version[*github.Version]                         = &github.Version{}
 	_ univers.VersionRange[*github.Version]                    = &github.VersionRange{}
 	_ univers.Ecosystem[*github.Version, *github.VersionRange] = &github.Ecosystem{}
+	_ univers.Featurer                                         = &github.Ecosystem{}
+	_ univers.ComparatorRange                                  = &github.VersionRange{}
 
 	// golang
 	_ univers.Version[*golang.Version]                         = &golang.Version{}
 	_ univers.VersionRange[*golang.Version]                    = &golang.VersionRange{}
 	_ univers.Ecosystem[*golang.Version, *golang.VersionRange] = &golang.Ecosystem{}
+	_ univers.Featurer                                         = &golang.Ecosystem{}
+	_ univers.ComparatorRange                                  = &golang.VersionRange{}
+
+	// gover
+	_ univers.Version[*gover.Version]                        = &gover.Version{}
+	_ univers.VersionRange[*gover.Version]                   = &gover.VersionRange{}
+	_ univers.Ecosystem[*gover.Version, *gover.VersionRange] = &gover.Ecosystem{}
+	_ univers.Featurer                                       = &gover.Ecosystem{}
+	_ univers.ComparatorRange                                = &gover.VersionRange{}
 
 	// hex
 	_ univers.Version[*hex.Version]                      = &hex.Version{}
 	_ univers.VersionRange[*hex.Version]                 = &hex.VersionRange{}
 	_ univers.Ecosystem[*hex.Version, *hex.VersionRange] = &hex.Ecosystem{}
+	_ univers.Featurer                                   = &hex.Ecosystem{}
+	_ univers.ComparatorRange                            = &hex.VersionRange{}
+
+	// luarocks
+	_ univers.Version[*luarocks.Version]                           = &luarocks.Version{}
+	_ univers.VersionRange[*luarocks.Version]                      = &luarocks.VersionRange{}
+	_ univers.Ecosystem[*luarocks.Version, *luarocks.VersionRange] = &luarocks.Ecosystem{}
+	_ univers.Featurer                                             = &luarocks.Ecosystem{}
+	_ univers.ComparatorRange                                      = &luarocks.VersionRange{}
 
 	// mattermost
 	_ univers.Version[*mattermost.Version]                             = &mattermost.Version{}
 	_ univers.VersionRange[*mattermost.Version]                        = &mattermost.VersionRange{}
 	_ univers.Ecosystem[*mattermost.Version, *mattermost.VersionRange] = &mattermost.Ecosystem{}
+	_ univers.Featurer                                                 = &mattermost.Ecosystem{}
+	_ univers.ComparatorRange                                          = &mattermost.VersionRange{}
 
 	// maven
 	_ univers.Version[*maven.Version]                        = &maven.Version{}
 	_ univers.VersionRange[*maven.Version]                   = &maven.VersionRange{}
 	_ univers.Ecosystem[*maven.Version, *maven.VersionRange] = &maven.Ecosystem{}
+	_ univers.Featurer                                       = &maven.Ecosystem{}
+	_ univers.ComparatorRange                                = &maven.VersionRange{}
+
+	// msver
+	_ univers.Version[*msver.Version]                        = &msver.Version{}
+	_ univers.VersionRange[*msver.Version]                   = &msver.VersionRange{}
+	_ univers.Ecosystem[*msver.Version, *msver.VersionRange] = &msver.Ecosystem{}
+	_ univers.Featurer                                       = &msver.Ecosystem{}
+	_ univers.ComparatorRange                                = &msver.VersionRange{}
 
 	// npm
 	_ univers.Version[*npm.Version]                      = &npm.Version{}
 	_ univers.VersionRange[*npm.Version]                 = &npm.VersionRange{}
 	_ univers.Ecosystem[*npm.Version, *npm.VersionRange] = &npm.Ecosystem{}
+	_ univers.Featurer                                   = &npm.Ecosystem{}
+	_ univers.Traceable[*npm.Version]                    = &npm.VersionRange{}
+	_ univers.SortKeyer                                  = &npm.Version{}
+	_ univers.Hasher                                     = &npm.VersionRange{}
+	_ univers.ComparatorRange                            = &npm.VersionRange{}
 
 	// nuget
 	_ univers.Version[*nuget.Version]                        = &nuget.Version{}
 	_ univers.VersionRange[*nuget.Version]                   = &nuget.VersionRange{}
 	_ univers.Ecosystem[*nuget.Version, *nuget.VersionRange] = &nuget.Ecosystem{}
+	_ univers.Featurer                                       = &nuget.Ecosystem{}
+	_ univers.ComparatorRange                                = &nuget.VersionRange{}
 
 	// pypi
 	_ univers.Version[*pypi.Version]                       = &pypi.Version{}
 	_ univers.VersionRange[*pypi.Version]                  = &pypi.VersionRange{}
 	_ univers.Ecosystem[*pypi.Version, *pypi.VersionRange] = &pypi.Ecosystem{}
+	_ univers.Featurer                                     = &pypi.Ecosystem{}
+	_ univers.SortKeyer                                    = &pypi.Version{}
+	_ univers.Hasher                                       = &pypi.VersionRange{}
+	_ univers.ComparatorRange                              = &pypi.VersionRange{}
 
 	// rpm
 	_ univers.Version[*rpm.Version]                      = &rpm.Version{}
 	_ univers.VersionRange[*rpm.Version]                 = &rpm.VersionRange{}
 	_ univers.Ecosystem[*rpm.Version, *rpm.VersionRange] = &rpm.Ecosystem{}
+	_ univers.Featurer                                   = &rpm.Ecosystem{}
+	_ univers.ComparatorRange                            = &rpm.VersionRange{}
 
 	// semver
 	_ univers.Version[*semver.Version]                         = &semver.Version{}
 	_ univers.VersionRange[*semver.Version]                    = &semver.VersionRange{}
 	_ univers.Ecosystem[*semver.Version, *semver.VersionRange] = &semver.Ecosystem{}
+	_ univers.Featurer                                         = &semver.Ecosystem{}
+	_ univers.Traceable[*semver.Version]                       = &semver.VersionRange{}
+	_ univers.SortKeyer                                        = &semver.Version{}
+	_ univers.Hasher                                           = &semver.VersionRange{}
+	_ univers.ComparatorRange                                  = &semver.VersionRange{}
 )
@@ -0,0 +1,106 @@
+package gem_test
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/gem"
+)
+
+// Segments compare numerically, and a letter starts a pre-release.
+func ExampleVersion_Compare() {
+	e := &gem.Ecosystem{}
+
+	var versions []*gem.Version
+	for _, s := range []string{"1.10", "1.9", "1.10.b", "1.2", "1.10.a"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		versions = append(versions, v)
+	}
+	slices.SortFunc(versions, (*gem.Version).Compare)
+	fmt.Println(versions)
+	// Output:
+	// [1.2 1.9 1.10.a 1.10.b 1.10]
+}
+
+// ~> allows the last given segment to grow.
+func ExampleVersionRange_Contains() {
+	e := &gem.Ecosystem{}
+
+	r, err := e.NewVersionRange("~> 2.2")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, s := range []string{"2.9", "3.0"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(s, r.Contains(v))
+	}
+	// Output:
+	// 2.9 true
+	// 3.0 false
+}
+
+func ExampleEcosystem_Desugar() {
+	s, err := (&gem.Ecosystem{}).Desugar("~> 1.2.3, != 1.2.5")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(s)
+	// Output:
+	// >= 1.2.3, < 1.3.0, != 1.2.5
+}
+
+func ExampleEcosystem_ParseGemfileRequirement() {
+	e := &gem.Ecosystem{}
+
+	name, r, err := e.ParseGemfileRequirement(`gem 'rails', '>= 6.0', '< 7.1', require: false`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	v, err := e.NewVersion("7.0.8")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(name, r, r.Contains(v))
+	// Output:
+	// rails >= 6.0, < 7.1 true
+}
+
+func ExampleVersion_Bump() {
+	e := &gem.Ecosystem{}
+
+	for _, s := range []string{"5.3.1", "5.3.1.b2", "5"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(s, v.Bump())
+	}
+	// Output:
+	// 5.3.1 5.4
+	// 5.3.1.b2 5.4
+	// 5 6
+}
+
+func ExampleVersion_Platform() {
+	v, err := (&gem.Ecosystem{}).NewVersion("1.15.4-x86_64-linux")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(v.Platform())
+	// Output:
+	// x86_64-linux
+}
@@ -1,8 +1,10 @@
 // Package gem provides functionality for working with Ruby Gem package versions.
 package gem
 
+import "github.com/alowayed/go-univers/pkg/univers"
+
 const (
-	Name = "gem"
+	Name = string(univers.Gem)
 )
 
 type Ecosystem struct{}
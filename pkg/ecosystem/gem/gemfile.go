@@ -0,0 +1,73 @@
+package gem
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// gemfileDirectivePattern matches the method call that introduces a dependency
+// in a Gemfile (gem) or gemspec (add_dependency and friends)
+var gemfileDirectivePattern = regexp.MustCompile(`^(?:gem|[A-Za-z_]\w*\.add_(?:runtime_|development_)?dependency)(?:\s*\(\s*|\s+)`)
+
+// ParseGemfileRequirement parses a Gemfile or gemspec dependency line such as
+// `gem 'rails', '>= 6.0', '< 7.1'` or `spec.add_dependency "rack", ["~> 2.0"]`.
+// It returns the gem name and a single VersionRange combining every quoted
+// requirement. Trailing options such as `require: false` or `platforms: :ruby`
+// and comments are ignored. A line without requirements yields ">= 0", which
+// matches every version, mirroring RubyGems' default requirement.
+func (e *Ecosystem) ParseGemfileRequirement(line string) (string, *VersionRange, error) {
+	original := line
+	line = strings.TrimSpace(line)
+
+	loc := gemfileDirectivePattern.FindStringIndex(line)
+	if loc == nil {
+		return "", nil, fmt.Errorf("not a gem dependency line: %q", original)
+	}
+
+	args, err := parseGemfileArgs(line[loc[1]:])
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid gem dependency line %q: %w", original, err)
+	}
+	if len(args) == 0 {
+		return "", nil, fmt.Errorf("missing gem name in %q", original)
+	}
+
+	name, requirements := args[0], args[1:]
+	if len(requirements) == 0 {
+		requirements = []string{">= 0"}
+	}
+
+	vr, err := e.NewVersionRange(strings.Join(requirements, ", "))
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid requirements for gem %q: %w", name, err)
+	}
+
+	return name, vr, nil
+}
+
+// parseGemfileArgs returns the leading quoted string arguments of a call,
+// flattening array literals and stopping at the first non-string argument
+// (keyword options, symbols, hashes) or comment.
+func parseGemfileArgs(s string) ([]string, error) {
+	var args []string
+
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == ' ' || c == '\t' || c == ',' || c == '[' || c == ']':
+			i++
+		case c == '\'' || c == '"':
+			end := strings.IndexByte(s[i+1:], c)
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated string at offset %d", i)
+			}
+			args = append(args, strings.TrimSpace(s[i+1:i+1+end]))
+			i += end + 2
+		default:
+			// Closing parenthesis, comment, or a non-string argument
+			return args, nil
+		}
+	}
+
+	return args, nil
+}
@@ -0,0 +1,136 @@
+package gem
+
+import (
+	"testing"
+)
+
+func TestEcosystem_ParseGemfileRequirement(t *testing.T) {
+	tests := []struct {
+		name      string
+		line      string
+		wantName  string
+		wantRange string
+		wantErr   bool
+	}{
+		{
+			name:      "multiple requirements",
+			line:      "gem 'rails', '>= 6.0', '< 7.1'",
+			wantName:  "rails",
+			wantRange: ">= 6.0, < 7.1",
+		},
+		{
+			name:      "double quotes",
+			line:      `gem "puma", "~> 5.6"`,
+			wantName:  "puma",
+			wantRange: "~> 5.6",
+		},
+		{
+			name:      "no requirement",
+			line:      "gem 'bootsnap'",
+			wantName:  "bootsnap",
+			wantRange: ">= 0",
+		},
+		{
+			name:      "trailing options",
+			line:      "gem 'bootsnap', '>= 1.4.4', require: false",
+			wantName:  "bootsnap",
+			wantRange: ">= 1.4.4",
+		},
+		{
+			name:      "hash rocket options",
+			line:      "gem 'pg', '~> 1.1', :platforms => :ruby",
+			wantName:  "pg",
+			wantRange: "~> 1.1",
+		},
+		{
+			name:      "trailing comment",
+			line:      "  gem 'sidekiq', '< 8' # background jobs",
+			wantName:  "sidekiq",
+			wantRange: "< 8",
+		},
+		{
+			name:      "parenthesized call",
+			line:      "gem('nokogiri', '>= 1.13', '!= 1.13.2')",
+			wantName:  "nokogiri",
+			wantRange: ">= 1.13, != 1.13.2",
+		},
+		{
+			name:      "gemspec dependency with array",
+			line:      `spec.add_dependency "rack", [">= 2.0", "< 4"]`,
+			wantName:  "rack",
+			wantRange: ">= 2.0, < 4",
+		},
+		{
+			name:      "gemspec development dependency",
+			line:      "s.add_development_dependency 'rspec', '~> 3.12'",
+			wantName:  "rspec",
+			wantRange: "~> 3.12",
+		},
+		{
+			name:    "not a dependency line",
+			line:    "source 'https://rubygems.org'",
+			wantErr: true,
+		},
+		{
+			name:    "missing name",
+			line:    "gem require: false",
+			wantErr: true,
+		},
+		{
+			name:    "unterminated string",
+			line:    "gem 'rails', '>= 6.0",
+			wantErr: true,
+		},
+		{
+			name:    "invalid requirement",
+			line:    "gem 'rails', '>='",
+			wantErr: true,
+		},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotName, gotRange, err := e.ParseGemfileRequirement(tt.line)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseGemfileRequirement() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if gotName != tt.wantName {
+				t.Errorf("ParseGemfileRequirement() name = %q, want %q", gotName, tt.wantName)
+			}
+			if gotRange.String() != tt.wantRange {
+				t.Errorf("ParseGemfileRequirement() range = %q, want %q", gotRange.String(), tt.wantRange)
+			}
+		})
+	}
+}
+
+func TestEcosystem_ParseGemfileRequirement_Contains(t *testing.T) {
+	e := &Ecosystem{}
+	_, r, err := e.ParseGemfileRequirement("gem 'rails', '>= 6.0', '< 7.1'")
+	if err != nil {
+		t.Fatalf("ParseGemfileRequirement() error = %v", err)
+	}
+
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"5.2.8", false},
+		{"6.0.0", true},
+		{"7.0.8", true},
+		{"7.1.0", false},
+	}
+	for _, tt := range tests {
+		v, err := e.NewVersion(tt.version)
+		if err != nil {
+			t.Fatalf("NewVersion(%q) error = %v", tt.version, err)
+		}
+		if got := r.Contains(v); got != tt.want {
+			t.Errorf("Contains(%q) = %v, want %v", tt.version, got, tt.want)
+		}
+	}
+}
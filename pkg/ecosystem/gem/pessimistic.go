@@ -0,0 +1,139 @@
+package gem
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Bump returns the next release series of the version, as Gem::Version#bump
+// does: pre-release segments are dropped, then the last remaining segment is
+// dropped and the new last one incremented. For example 5.3.1 bumps to 5.4,
+// 5.3.1.b2 and 5.3.1-rc1 to 5.4, and 5 to 6.
+func (v *Version) Bump() *Version {
+	original := strings.TrimSpace(v.original)
+	if v.platform != "" {
+		original = strings.TrimSuffix(original, "-"+v.platform)
+	}
+
+	var release []int
+	for _, s := range rubySegments(original) {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			// The first string segment starts the pre-release
+			break
+		}
+		release = append(release, n)
+	}
+	if len(release) > 1 {
+		release = release[:len(release)-1]
+	}
+	release[len(release)-1]++
+
+	next := joinSegments(release)
+	segments, _ := parseSegments(canonicalizeVersion(next))
+	return &Version{segments: segments, original: next}
+}
+
+// rubySegments splits a version into its digit and letter runs the way
+// Gem::Version#segments does, after RubyGems' rewrite of '-' to ".pre.".
+// Build metadata is not part of a Ruby version and is dropped.
+func rubySegments(version string) []string {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.Index(version, "+"); i != -1 {
+		version = version[:i]
+	}
+	version = strings.ReplaceAll(version, "-", ".pre.")
+
+	var segments []string
+	start := -1
+	flush := func(end int) {
+		if start >= 0 {
+			segments = append(segments, version[start:end])
+			start = -1
+		}
+	}
+	for i := 0; i < len(version); i++ {
+		c := version[i]
+		switch {
+		case !isAlnum(c):
+			flush(i)
+		case start < 0:
+			start = i
+		case isDigit(c) != isDigit(version[start]):
+			flush(i)
+			start = i
+		}
+	}
+	flush(len(version))
+	return segments
+}
+
+// isDigit reports whether c is an ASCII digit
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isAlnum reports whether c is an ASCII letter or digit
+func isAlnum(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// joinSegments formats numeric segments as a dotted version
+func joinSegments(segments []int) string {
+	parts := make([]string, len(segments))
+	for i, n := range segments {
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, ".")
+}
+
+// Desugar rewrites every pessimistic constraint of a range into the explicit
+// bounds it stands for and returns the constraints joined by ", ". For
+// example "~> 1.2.3, != 1.2.5" becomes ">= 1.2.3, < 1.3.0, != 1.2.5" and
+// "~> 1.2" becomes ">= 1.2, < 2.0". The upper bound is the constraint's Bump,
+// padded to the precision of the original version, so ~> 1.0.0-alpha becomes
+// ">= 1.0.0-alpha, < 1.1.0". Note that ~> also excludes pre-releases of the
+// upper bound, such as 1.3.0-rc1, which a plain < admits.
+func (e *Ecosystem) Desugar(rangeStr string) (string, error) {
+	vr, err := e.NewVersionRange(rangeStr)
+	if err != nil {
+		return "", err
+	}
+
+	var parts []string
+	for _, c := range vr.constraints {
+		if c.operator != "~>" {
+			parts = append(parts, c.operator+" "+c.version)
+			continue
+		}
+
+		v, err := e.NewVersion(c.version)
+		if err != nil {
+			return "", fmt.Errorf("invalid version in ~> constraint: %w", err)
+		}
+		parts = append(parts, ">= "+c.version, "< "+pessimisticUpperBound(v))
+	}
+
+	return strings.Join(parts, ", "), nil
+}
+
+// pessimisticUpperBound returns the lowest release excluded by a ~>
+// constraint, its Bump, padded with zeros to the number of release segments
+// written in the constraint
+func pessimisticUpperBound(constraint *Version) string {
+	written := 0
+	for _, s := range rubySegments(constraint.original) {
+		if _, err := strconv.Atoi(s); err != nil {
+			break
+		}
+		written++
+	}
+
+	upper := constraint.Bump().segments
+	bound := make([]int, max(written, len(upper)))
+	for i, seg := range upper {
+		bound[i] = seg.numValue
+	}
+	return joinSegments(bound)
+}
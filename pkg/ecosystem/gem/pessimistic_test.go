@@ -0,0 +1,107 @@
+package gem
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVersion_Bump(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{version: "5.3.1", want: "5.4"},
+		{version: "5.3.1.b2", want: "5.4"},
+		{version: "5.3.1-rc1", want: "5.4"},
+		{version: "5.3", want: "6"},
+		{version: "5", want: "6"},
+		{version: "1.0.0", want: "1.1"},
+		{version: "1.2.3.4", want: "1.2.4"},
+		{version: "1.2.a", want: "2"},
+		{version: "v1.9.3", want: "1.10"},
+		{version: "1.2.3+build.7", want: "1.3"},
+		{version: "1.2.3-x86_64-linux", want: "1.3"},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			v, err := e.NewVersion(tt.version)
+			if err != nil {
+				t.Fatalf("NewVersion(%q) error = %v", tt.version, err)
+			}
+			got := v.Bump()
+			if got.String() != tt.want {
+				t.Errorf("Bump() = %q, want %q", got, tt.want)
+			}
+			if got.Compare(v) <= 0 {
+				t.Errorf("Bump() = %q, want greater than %q", got, tt.version)
+			}
+		})
+	}
+}
+
+func TestEcosystem_Desugar(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		want     string
+		wantErr  bool
+	}{
+		{name: "patch", rangeStr: "~> 1.2.3", want: ">= 1.2.3, < 1.3.0"},
+		{name: "minor", rangeStr: "~> 1.2", want: ">= 1.2, < 2.0"},
+		{name: "major", rangeStr: "~> 1", want: ">= 1, < 2"},
+		{name: "trailing zeros", rangeStr: "~> 1.0.0", want: ">= 1.0.0, < 1.1.0"},
+		{name: "four segments", rangeStr: "~> 1.2.3.4", want: ">= 1.2.3.4, < 1.2.4.0"},
+		{name: "prerelease", rangeStr: "~> 1.0.0-alpha", want: ">= 1.0.0-alpha, < 1.1.0"},
+		{name: "dotted prerelease", rangeStr: "~> 1.2.3.pre", want: ">= 1.2.3.pre, < 1.3.0"},
+		{name: "combined", rangeStr: "~> 1.2.3, != 1.2.5", want: ">= 1.2.3, < 1.3.0, != 1.2.5"},
+		{name: "no pessimistic", rangeStr: ">=1.0,<2.0", want: ">= 1.0, < 2.0"},
+		{name: "bare version", rangeStr: "1.0", want: "= 1.0"},
+		{name: "empty", rangeStr: "", wantErr: true},
+		{name: "invalid version", rangeStr: "~> bogus", wantErr: true},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := e.Desugar(tt.rangeStr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Desugar(%q) error = %v, wantErr %v", tt.rangeStr, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Desugar(%q) = %q, want %q", tt.rangeStr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEcosystem_Desugar_MatchesContains(t *testing.T) {
+	rangeStrs := []string{"~> 1.2.3", "~> 1.2", "~> 1", "~> 0.1.0", "~> 1.0.0-alpha", "~> 1.2.3.pre"}
+	versions := []string{"0.1.0", "0.1.9", "0.2.0", "1.0.0-alpha", "1.0.0-beta", "1.0.0", "1.0.1", "1.2.2", "1.2.3.pre", "1.2.3", "1.2.9", "1.3.0", "1.9.9", "2.0.0"}
+
+	e := &Ecosystem{}
+	for _, rangeStr := range rangeStrs {
+		desugared, err := e.Desugar(rangeStr)
+		if err != nil {
+			t.Fatalf("Desugar(%q) error = %v", rangeStr, err)
+		}
+		original, _ := e.NewVersionRange(rangeStr)
+		explicit, err := e.NewVersionRange(desugared)
+		if err != nil {
+			t.Fatalf("NewVersionRange(%q) error = %v", desugared, err)
+		}
+		for _, version := range versions {
+			v, _ := e.NewVersion(version)
+			if original.Contains(v) != explicit.Contains(v) {
+				t.Errorf("%q and %q disagree on %q", rangeStr, desugared, version)
+			}
+		}
+
+		// The upper bound is the constraint's Bump
+		constraint, _ := e.NewVersion(strings.TrimPrefix(rangeStr, "~> "))
+		if bump := constraint.Bump(); original.Contains(bump) {
+			t.Errorf("%q contains its Bump %q", rangeStr, bump)
+		}
+	}
+}
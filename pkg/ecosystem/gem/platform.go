@@ -0,0 +1,70 @@
+package gem
+
+import "strings"
+
+// platformCPUs are the CPU names that start a gem platform such as
+// "x86_64-linux". ARM variants ("arm64", "armv7l", ...) are matched by prefix.
+var platformCPUs = map[string]bool{
+	"x86_64": true, "x86": true, "x64": true, "i386": true, "i486": true, "i586": true, "i686": true,
+	"universal": true, "aarch64": true, "powerpc": true, "ppc": true, "ppc64": true, "ppc64le": true,
+	"s390": true, "s390x": true, "sparc": true, "sparc64": true, "mips": true, "mips64": true,
+	"riscv64": true, "loongarch64": true, "wasm32": true,
+}
+
+// platformOSes are the prefixes of the operating system that follows the CPU
+// in a gem platform, as in "arm64-darwin" or "x64-mingw-ucrt"
+var platformOSes = []string{
+	"linux", "darwin", "mingw", "mswin", "cygwin", "freebsd", "openbsd", "netbsd",
+	"solaris", "aix", "java", "dalvik", "wasi", "hpux",
+}
+
+// platformNames are the gem platforms written without a CPU
+var platformNames = map[string]bool{
+	"java": true, "jruby": true, "dalvik": true, "mswin32": true, "mswin64": true, "mingw32": true,
+}
+
+// Platform returns the platform of a platform-specific gem version, such as
+// "x86_64-linux" for "1.15.4-x86_64-linux", or "" for a pure Ruby gem.
+func (v *Version) Platform() string {
+	return v.platform
+}
+
+// splitPlatform splits a trailing gem platform off version. Anything after
+// a hyphen that does not read as a platform is left in place, as a
+// pre-release.
+func splitPlatform(version string) (string, string) {
+	for i := 0; i < len(version); i++ {
+		if version[i] == '-' && i > 0 && isPlatform(version[i+1:]) {
+			return version[:i], version[i+1:]
+		}
+	}
+	return version, ""
+}
+
+// isPlatform reports whether s is a gem platform: a CPU-less name such as
+// "java", or a known CPU and operating system optionally followed by more
+// hyphenated parts, such as "x86_64-linux-musl" or "x86_64-darwin-19".
+func isPlatform(s string) bool {
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_' || r == '-' || r == '.') {
+			return false
+		}
+	}
+	if platformNames[s] {
+		return true
+	}
+
+	cpu, rest, ok := strings.Cut(s, "-")
+	if !ok || strings.Contains(s, "--") || strings.HasSuffix(s, "-") {
+		return false
+	}
+	if !platformCPUs[cpu] && !strings.HasPrefix(cpu, "arm") {
+		return false
+	}
+	for _, os := range platformOSes {
+		if strings.HasPrefix(rest, os) {
+			return true
+		}
+	}
+	return false
+}
@@ -2,7 +2,10 @@ package gem
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 // VersionRange represents a Ruby Gem version range with Gem-specific syntax support
@@ -17,16 +20,38 @@ type constraint struct {
 	version  string
 }
 
+// features declares the range syntax accepted by NewVersionRange
+var features = []univers.Feature{
+	{Name: univers.FeatureComparison, Syntax: ">= 1.0", Description: "Ordering operators >=, >, <=, < and ="},
+	{Name: univers.FeatureNotEqual, Syntax: "!= 1.0.1", Description: "Excludes a single version"},
+	{Name: univers.FeatureExact, Syntax: "1.0.0", Description: "A bare version matches only itself"},
+	{Name: univers.FeatureAnd, Syntax: ">= 1.0, < 2.0", Description: "Comma-separated constraints must all match"},
+	{Name: univers.FeaturePessimistic, Syntax: "~> 1.2", Description: "Allows the last given component to increase"},
+}
+
+// Features returns the range syntax features supported by NewVersionRange
+func (e *Ecosystem) Features() []univers.Feature {
+	return slices.Clone(features)
+}
+
 // NewVersionRange creates a new Ruby Gem version range from a range string
 func (e *Ecosystem) NewVersionRange(rangeStr string) (*VersionRange, error) {
+	if err := univers.CheckLength(rangeStr); err != nil {
+		return nil, err
+	}
+
 	original := rangeStr
 	rangeStr = strings.TrimSpace(rangeStr)
 	if rangeStr == "" {
-		return nil, fmt.Errorf("empty range string")
+		return nil, univers.WrapParseError(original, fmt.Errorf("empty range string"))
 	}
 
 	constraints, err := parseConstraints(rangeStr)
 	if err != nil {
+		return nil, univers.WrapParseError(original, err)
+	}
+
+	if err := univers.CheckConstraints(len(constraints)); err != nil {
 		return nil, err
 	}
 
@@ -42,7 +67,11 @@ func parseConstraints(rangeStr string) ([]*constraint, error) {
 	parts := strings.Split(rangeStr, ",")
 	var constraints []*constraint
 
+	offset := 0
 	for _, part := range parts {
+		start := offset + strings.Index(rangeStr[offset:], part)
+		offset = start + len(part)
+
 		part = strings.TrimSpace(part)
 		if part == "" {
 			continue
@@ -50,7 +79,7 @@ func parseConstraints(rangeStr string) ([]*constraint, error) {
 
 		constraint, err := parseConstraint(part)
 		if err != nil {
-			return nil, err
+			return nil, univers.TokenErrorAt(rangeStr, start, part, err)
 		}
 		constraints = append(constraints, constraint)
 	}
@@ -110,6 +139,30 @@ func (vr *VersionRange) Contains(version *Version) bool {
 	return true
 }
 
+// Comparators returns the constraints as a single AND group, with pessimistic
+// constraints expanded to the bounds Desugar writes. As with Desugar, ~> also
+// excludes pre-releases of its upper bound, which the plain "<" admits.
+func (vr *VersionRange) Comparators() [][]univers.Comparator {
+	e := &Ecosystem{}
+	group := make([]univers.Comparator, 0, len(vr.constraints))
+	for _, c := range vr.constraints {
+		if c.operator != "~>" {
+			group = append(group, univers.Comparator{Operator: c.operator, Version: c.version})
+			continue
+		}
+		v, err := e.NewVersion(c.version)
+		if err != nil {
+			group = append(group, univers.Comparator{Operator: c.operator, Version: c.version})
+			continue
+		}
+		group = append(group,
+			univers.Comparator{Operator: ">=", Version: c.version},
+			univers.Comparator{Operator: "<", Version: pessimisticUpperBound(v)},
+		)
+	}
+	return [][]univers.Comparator{group}
+}
+
 // satisfiesConstraint checks if a version satisfies a single constraint
 func satisfiesConstraint(version *Version, c *constraint, ecosystem *Ecosystem) bool {
 	constraintVersion, err := ecosystem.NewVersion(c.version)
@@ -117,7 +170,7 @@ func satisfiesConstraint(version *Version, c *constraint, ecosystem *Ecosystem)
 		return false
 	}
 
-	cmp := version.Compare(constraintVersion)
+	cmp := version.compareRelease(constraintVersion)
 
 	switch c.operator {
 	case "=":
@@ -140,57 +193,23 @@ func satisfiesConstraint(version *Version, c *constraint, ecosystem *Ecosystem)
 }
 
 // satisfiesPessimistic implements the Ruby Gem pessimistic constraint (~>)
+// as RubyGems does: the version must be at least the constraint, and its
+// release, without any pre-release, below the constraint's Bump. So ~> 1.2.3
+// means >= 1.2.3 and < 1.3, and also excludes 1.3.0.rc1.
 func satisfiesPessimistic(version, constraint *Version) bool {
-	// ~> 1.2.3 means >= 1.2.3 and < 1.3.0
-	// ~> 1.2 means >= 1.2.0 and < 2.0.0
-
-	// Must be >= constraint version
-	if version.Compare(constraint) < 0 {
+	if version.compareRelease(constraint) < 0 {
 		return false
 	}
+	return compareSegmentArrays(version.releaseSegments(), constraint.Bump().segments) < 0
+}
 
-	// Get the numeric parts of both version and constraint for comparison
-	versionNumeric, _ := version.splitNumericAndPrerelease()
-	constraintNumeric, constraintPrerelease := constraint.splitNumericAndPrerelease()
-
-	// For range calculations, we need to understand the original precision
-	// Count numeric segments from the original constraint string
-	constraintStr := constraint.String()
-	mainPart := constraintStr
-	if dashIndex := strings.Index(constraintStr, "-"); dashIndex != -1 {
-		mainPart = constraintStr[:dashIndex]
-	}
-	originalSegments := strings.Split(mainPart, ".")
-	numericSegments := len(originalSegments)
-
-	// For pessimistic constraints, all segments except the last must match exactly
-	numSegmentsToCheck := numericSegments - 1
-
-	// Special case: single segment constraint (~> 1)
-	if numericSegments == 1 {
-		numSegmentsToCheck = 1
-	}
-
-	// Special case: constraint has prerelease (~> 1.0.0-alpha)
-	// When constraint has prerelease, all numeric segments must match exactly
-	if len(constraintPrerelease) > 0 {
-		numSegmentsToCheck = numericSegments
-	}
-
-	// Check that the required segments match exactly
-	for i := 0; i < numSegmentsToCheck; i++ {
-		var vSeg, cSeg int
-		if i < len(versionNumeric) {
-			vSeg = versionNumeric[i].numValue
-		}
-		if i < len(constraintNumeric) {
-			cSeg = constraintNumeric[i].numValue
-		}
-
-		if vSeg != cSeg {
-			return false
+// releaseSegments returns the numeric segments before the first string
+// segment, which starts the pre-release
+func (v *Version) releaseSegments() []segment {
+	for i, seg := range v.segments {
+		if !seg.isNumeric {
+			return v.segments[:i]
 		}
 	}
-
-	return true
+	return v.segments
 }
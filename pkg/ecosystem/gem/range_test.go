@@ -1,7 +1,11 @@
 package gem
 
 import (
+	"errors"
+	"fmt"
 	"testing"
+
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 func TestEcosystem_NewVersionRange(t *testing.T) {
@@ -55,6 +59,34 @@ func TestEcosystem_NewVersionRange(t *testing.T) {
 	}
 }
 
+func TestEcosystem_NewVersionRange_ParseError(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantPos   int
+		wantToken string
+	}{
+		{name: "missing version after repeated operator", input: ">= 1.0, >=", wantPos: 8, wantToken: ">="},
+		{name: "pessimistic without version", input: " ~> 2.0, ~>", wantPos: 9, wantToken: "~>"},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := e.NewVersionRange(tt.input)
+
+			var pe *univers.ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("NewVersionRange(%q) error = %v, want *univers.ParseError", tt.input, err)
+			}
+			if pe.Input != tt.input || pe.Pos != tt.wantPos || pe.Token != tt.wantToken {
+				t.Errorf("NewVersionRange(%q) error = {Input: %q, Pos: %d, Token: %q}, want {Input: %q, Pos: %d, Token: %q}",
+					tt.input, pe.Input, pe.Pos, pe.Token, tt.input, tt.wantPos, tt.wantToken)
+			}
+		})
+	}
+}
+
 func TestVersionRange_Contains(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -78,6 +110,8 @@ func TestVersionRange_Contains(t *testing.T) {
 		{"lte greater", "<= 1.2.3", "1.2.4", false},
 		{"lt less", "< 1.2.3", "1.2.2", true},
 		{"lt equal", "< 1.2.3", "1.2.3", false},
+		{"gte without space", ">=1.2.3", "1.2.3", true},
+		{"pessimistic without space", "~>1.2", "1.9", true},
 		{"ne different", "!= 1.2.3", "1.2.4", true},
 		{"ne same", "!= 1.2.3", "1.2.3", false},
 
@@ -95,6 +129,12 @@ func TestVersionRange_Contains(t *testing.T) {
 		{"pessimistic minor major bump", "~> 1.2", "2.0.0", false},
 		{"pessimistic minor below", "~> 1.2", "1.1.9", false},
 
+		// Requirements ignore platforms
+		{"exact match platform gem", "= 1.15.4", "1.15.4-x86_64-linux", true},
+		{"ne platform gem", "!= 1.15.4", "1.15.4-arm64-darwin", false},
+		{"lt platform gem", "< 1.15.4", "1.15.4-x86_64-linux", false},
+		{"pessimistic platform gem", "~> 1.15.0", "1.15.4-java", true},
+
 		{"pessimistic major exact", "~> 1", "1.0.0", true},
 		{"pessimistic major ok", "~> 1", "1.9.9", true},
 		{"pessimistic major bump", "~> 1", "2.0.0", false},
@@ -113,7 +153,10 @@ func TestVersionRange_Contains(t *testing.T) {
 		{"pessimistic prerelease exact", "~> 1.0.0-alpha", "1.0.0-alpha", true},
 		{"pessimistic prerelease beta", "~> 1.0.0-alpha", "1.0.0-beta", true},
 		{"pessimistic prerelease release", "~> 1.0.0-alpha", "1.0.0", true},
-		{"pessimistic prerelease patch bump", "~> 1.0.0-alpha", "1.0.1", false},
+		{"pessimistic prerelease patch bump", "~> 1.0.0-alpha", "1.0.1", true},
+		{"pessimistic prerelease minor bump", "~> 1.0.0-alpha", "1.1.0", false},
+		{"pessimistic dotted prerelease patch bump", "~> 1.2.3.pre", "1.2.9", true},
+		{"pessimistic dotted prerelease minor bump", "~> 1.2.3.pre", "1.3", false},
 
 		// Prerelease handling
 		{"prerelease gte", ">= 1.0.0-alpha", "1.0.0-alpha", true},
@@ -153,3 +196,37 @@ func mustNewVersionRange(t *testing.T, s string) *VersionRange {
 	}
 	return vr
 }
+
+func TestEcosystem_Features(t *testing.T) {
+	e := &Ecosystem{}
+	for _, f := range e.Features() {
+		if _, err := e.NewVersionRange(f.Syntax); err != nil {
+			t.Errorf("Features() %s syntax %q does not parse: %v", f.Name, f.Syntax, err)
+		}
+	}
+}
+
+func TestVersionRange_Comparators(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		want     string
+	}{
+		{name: "pessimistic", rangeStr: "~> 1.2.3", want: "[[>=1.2.3 <1.3.0]]"},
+		{name: "pessimistic two segments", rangeStr: "~> 1.2", want: "[[>=1.2 <2.0]]"},
+		{name: "comparisons", rangeStr: ">= 1.0, != 1.5", want: "[[>=1.0 !=1.5]]"},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vr, err := e.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("Ecosystem.NewVersionRange() error = %v", err)
+			}
+			if got := fmt.Sprint(vr.Comparators()); got != tt.want {
+				t.Errorf("VersionRange.Comparators() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 // versionPattern matches Ruby Gem version strings
@@ -14,6 +16,7 @@ var versionPattern = regexp.MustCompile(`^v?(\d+(?:\.\d+)*(?:\.[a-zA-Z]+\d*)*(?:
 // Version represents a Ruby Gem package version
 type Version struct {
 	segments []segment
+	platform string // platform of a platform-specific gem, "" for pure Ruby
 	original string
 }
 
@@ -26,6 +29,10 @@ type segment struct {
 
 // NewVersion creates a new Ruby Gem version from a string
 func (e *Ecosystem) NewVersion(version string) (*Version, error) {
+	if err := univers.CheckLength(version); err != nil {
+		return nil, err
+	}
+
 	original := version
 	version = strings.TrimSpace(version)
 
@@ -36,6 +43,9 @@ func (e *Ecosystem) NewVersion(version string) (*Version, error) {
 		return nil, fmt.Errorf("empty version string")
 	}
 
+	// A platform such as "x86_64-linux" is not a pre-release
+	version, platform := splitPlatform(version)
+
 	// Basic validation
 	if !versionPattern.MatchString("v" + version) {
 		return nil, fmt.Errorf("invalid Ruby Gem version: %s", original)
@@ -50,10 +60,24 @@ func (e *Ecosystem) NewVersion(version string) (*Version, error) {
 
 	return &Version{
 		segments: segments,
+		platform: platform,
 		original: original,
 	}, nil
 }
 
+// ValidVersion reports whether NewVersion accepts version. It only matches
+// versionPattern, skipping canonicalization, so it does not allocate.
+func ValidVersion(version string) bool {
+	if univers.CheckLength(version) != nil {
+		return false
+	}
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	version, _ = splitPlatform(version)
+
+	// NewVersion matches "v"+version, which fails if another "v" follows
+	return version != "" && !strings.HasPrefix(version, "v") && versionPattern.MatchString(version)
+}
+
 // canonicalizeVersion transforms version string to canonical form
 func canonicalizeVersion(version string) string {
 	// Handle prerelease indicators (-, +)
@@ -217,8 +241,58 @@ func (v *Version) String() string {
 	return v.original
 }
 
-// Compare compares this version with another Ruby Gem version
+// PrereleaseChannel returns the normalized channel of a prerelease gem, the
+// first word of the version such as "rc" for 7.1.0.rc2 or "pre" for 2.0.0.pre,
+// or "" for a release. See univers.SplitPrerelease.
+func (v *Version) PrereleaseChannel() string {
+	channel, _, _ := univers.SplitPrerelease(v.prereleaseLabel())
+	return channel
+}
+
+// PrereleaseNumber returns the number after the prerelease channel, such as 2
+// for 7.1.0.rc2, and whether there is one.
+func (v *Version) PrereleaseNumber() (int, bool) {
+	_, number, ok := univers.SplitPrerelease(v.prereleaseLabel())
+	return number, ok
+}
+
+// prereleaseLabel returns the segments from the first one with a letter on,
+// joined by dots, or "" for a release
+func (v *Version) prereleaseLabel() string {
+	for i, seg := range v.segments {
+		if containsLetter(seg.value) {
+			parts := make([]string, 0, len(v.segments)-i)
+			for _, s := range v.segments[i:] {
+				parts = append(parts, s.value)
+			}
+			return strings.Join(parts, ".")
+		}
+	}
+	return ""
+}
+
+// Compare compares this version with another Ruby Gem version. Builds of the
+// same version for different platforms are ordered as RubyGems lists them:
+// the pure Ruby gem is preferred and sorts last, and platform-specific gems
+// sort before it by platform name.
 func (v *Version) Compare(other *Version) int {
+	if cmp := v.compareRelease(other); cmp != 0 {
+		return cmp
+	}
+	switch {
+	case v.platform == other.platform:
+		return 0
+	case v.platform == "":
+		return 1
+	case other.platform == "":
+		return -1
+	}
+	return strings.Compare(v.platform, other.platform)
+}
+
+// compareRelease compares the versions ignoring their platforms, as
+// requirements do
+func (v *Version) compareRelease(other *Version) int {
 	// First compare the numeric parts
 	vNumeric, vPrerelease := v.splitNumericAndPrerelease()
 	oNumeric, oPrerelease := other.splitNumericAndPrerelease()
@@ -29,6 +29,14 @@ func TestEcosystem_NewVersion(t *testing.T) {
 		{"build metadata", "1.0.0+build.1", false},
 		{"prerelease with build", "1.0.0-alpha+build", false},
 
+		// Platforms
+		{"linux platform", "1.15.4-x86_64-linux", false},
+		{"musl platform", "1.15.4-x86_64-linux-musl", false},
+		{"darwin platform", "1.15.4-arm64-darwin", false},
+		{"platform with os version", "1.0.0-x86_64-darwin-19", false},
+		{"java platform", "9.4.3.0-java", false},
+		{"prerelease with platform", "1.16.0.rc1-x86_64-linux", false},
+
 		// Edge cases
 		{"v prefix", "v1.0.0", false},
 		{"single digit", "1", false},
@@ -102,6 +110,15 @@ func TestVersion_Compare(t *testing.T) {
 		// Complex versions
 		{"build number difference", "1.2.3.4", "1.2.3.5", -1},
 
+		// Platforms
+		{"platform vs pure ruby", "1.15.4-x86_64-linux", "1.15.4", -1},
+		{"pure ruby vs platform", "1.15.4", "1.15.4-arm64-darwin", 1},
+		{"platforms by name", "1.15.4-arm64-darwin", "1.15.4-x86_64-linux", -1},
+		{"same platform", "1.15.4-java", "1.15.4-java", 0},
+		{"platform is not a prerelease", "1.15.4-x86_64-linux", "1.15.4-rc1", 1},
+		{"version before platform", "1.15.4-x86_64-linux", "1.15.5", -1},
+		{"prerelease with platform", "1.16.0-rc1-x86_64-linux", "1.16.0", -1},
+
 		// Edge cases
 		{"implicit zero", "1.0", "1.0.0", 0},
 		{"single vs triple", "1", "1.0.0", 0},
@@ -138,3 +155,61 @@ func mustNewVersion(t *testing.T, version string) *Version {
 	}
 	return v
 }
+
+func TestVersion_Platform(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		want    string
+	}{
+		{"pure ruby", "1.15.4", ""},
+		{"prerelease", "1.0.0-alpha", ""},
+		{"unknown cpu", "1.0.0-foo-linux", ""},
+		{"linux", "1.15.4-x86_64-linux", "x86_64-linux"},
+		{"linux gnu", "1.15.4-aarch64-linux-gnu", "aarch64-linux-gnu"},
+		{"darwin", "v1.15.4-arm64-darwin", "arm64-darwin"},
+		{"windows ucrt", "1.15.4-x64-mingw-ucrt", "x64-mingw-ucrt"},
+		{"java", "9.4.3.0-java", "java"},
+		{"prerelease and platform", "1.0.0-rc1-x86_64-linux", "x86_64-linux"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mustNewVersion(t, tt.version).Platform(); got != tt.want {
+				t.Errorf("Version.Platform() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersion_PrereleaseChannel(t *testing.T) {
+	tests := []struct {
+		version     string
+		wantChannel string
+		wantNumber  int
+		wantOK      bool
+	}{
+		{"7.1.0", "", 0, false},
+		{"7.1.0.rc2", "rc", 2, true},
+		{"2.0.0.pre", "pre", 0, false},
+		{"1.0.0-beta.3", "beta", 3, true},
+		{"1.15.4-x86_64-linux", "", 0, false},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			v, err := e.NewVersion(tt.version)
+			if err != nil {
+				t.Fatalf("NewVersion(%q) error = %v", tt.version, err)
+			}
+			if got := v.PrereleaseChannel(); got != tt.wantChannel {
+				t.Errorf("PrereleaseChannel() = %q, want %q", got, tt.wantChannel)
+			}
+			number, ok := v.PrereleaseNumber()
+			if number != tt.wantNumber || ok != tt.wantOK {
+				t.Errorf("PrereleaseNumber() = %d, %v, want %d, %v", number, ok, tt.wantNumber, tt.wantOK)
+			}
+		})
+	}
+}
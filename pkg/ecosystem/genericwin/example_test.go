@@ -0,0 +1,60 @@
+package genericwin_test
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/genericwin"
+)
+
+// Windows versions have up to four numeric components.
+func ExampleVersion_Compare() {
+	e := &genericwin.Ecosystem{}
+
+	var versions []*genericwin.Version
+	for _, s := range []string{"10.0.19041.1415", "6.3.9600", "10.0", "10.0.19041.1288"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		versions = append(versions, v)
+	}
+	slices.SortFunc(versions, (*genericwin.Version).Compare)
+	fmt.Println(versions)
+	// Output:
+	// [6.3.9600 10.0 10.0.19041.1288 10.0.19041.1415]
+}
+
+// Ranges are comparators separated by commas.
+func ExampleVersionRange_Contains() {
+	e := &genericwin.Ecosystem{}
+
+	r, err := e.NewVersionRange(">= 10.0.19041.0, < 10.0.19041.1415")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, s := range []string{"10.0.19041.1288", "10.0.19041.1415"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(s, r.Contains(v))
+	}
+	// Output:
+	// 10.0.19041.1288 true
+	// 10.0.19041.1415 false
+}
+
+func ExampleVersion_Components() {
+	v, err := (&genericwin.Ecosystem{}).NewVersion("10.0.14393.0 (WinBuild.160101.0800)")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(v.Components(), v.Suffix())
+	// Output:
+	// [10 0 14393 0] (WinBuild.160101.0800)
+}
@@ -0,0 +1,16 @@
+// Package genericwin provides functionality for working with Windows file and
+// product versions such as 10.0.19041.1288, as reported by version resources
+// and OS build strings.
+package genericwin
+
+import "github.com/alowayed/go-univers/pkg/univers"
+
+const (
+	Name = string(univers.GenericWin)
+)
+
+type Ecosystem struct{}
+
+func (e *Ecosystem) Name() string {
+	return Name
+}
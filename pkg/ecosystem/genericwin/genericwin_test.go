@@ -0,0 +1,15 @@
+package genericwin
+
+import (
+	"testing"
+)
+
+func TestEcosystem_Name(t *testing.T) {
+	ecosystem := &Ecosystem{}
+	want := "generic-win"
+
+	got := ecosystem.Name()
+	if got != want {
+		t.Errorf("Ecosystem.Name() = %q, want %q", got, want)
+	}
+}
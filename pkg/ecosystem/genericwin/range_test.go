@@ -0,0 +1,157 @@
+package genericwin
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestEcosystem_NewVersionRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		// Valid ranges
+		{
+			name:  "exact version",
+			input: "10.0.19041.1415",
+		},
+		{
+			name:  "comparison",
+			input: ">=10.0.19041.0",
+		},
+		{
+			name:  "multiple constraints",
+			input: ">= 10.0.19041.0, < 10.0.19041.1415",
+		},
+		{
+			name:  "not equal",
+			input: "!=6.3.9600",
+		},
+		{
+			name:  "coerced build label",
+			input: "< 10.0.19041.1288 (WinBuild.160101.0800)",
+		},
+		// Error cases
+		{
+			name:    "empty string",
+			input:   "",
+			wantErr: true,
+		},
+		{
+			name:    "operator without version",
+			input:   ">=",
+			wantErr: true,
+		},
+		{
+			name:    "invalid version",
+			input:   ">=10.0-beta",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported operator",
+			input:   "^10.0",
+			wantErr: true,
+		},
+		{
+			name:    "space-separated constraints",
+			input:   ">=1.0.0 >=2.0.0",
+			wantErr: true,
+		},
+		{
+			name:    "text after constraint version",
+			input:   ">=1.0.0 <=x.y!! >=2.0.0",
+			wantErr: true,
+		},
+	}
+
+	ecosystem := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ecosystem.NewVersionRange(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Ecosystem.NewVersionRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVersionRange_Contains(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		version  string
+		want     bool
+	}{
+		{
+			name:     "patched build excluded",
+			rangeStr: ">= 10.0.19041.0, < 10.0.19041.1415",
+			version:  "10.0.19041.1415",
+			want:     false,
+		},
+		{
+			name:     "vulnerable build included",
+			rangeStr: ">= 10.0.19041.0, < 10.0.19041.1415",
+			version:  "10.0.19041.1288 (WinBuild.160101.0800)",
+			want:     true,
+		},
+		{
+			name:     "exact matches zero-padded spelling",
+			rangeStr: "6.3.9600",
+			version:  "6.3.9600.0",
+			want:     true,
+		},
+		{
+			name:     "five part build",
+			rangeStr: "> 10.0.19041.1288.1",
+			version:  "10.0.19041.1288.2",
+			want:     true,
+		},
+		{
+			name:     "not equal",
+			rangeStr: "!= 6.3.9600",
+			version:  "6.3.9600",
+			want:     false,
+		},
+	}
+
+	ecosystem := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vr, err := ecosystem.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("NewVersionRange(%q) error = %v", tt.rangeStr, err)
+			}
+			v, err := ecosystem.NewVersion(tt.version)
+			if err != nil {
+				t.Fatalf("NewVersion(%q) error = %v", tt.version, err)
+			}
+			if got := vr.Contains(v); got != tt.want {
+				t.Errorf("VersionRange(%q).Contains(%q) = %v, want %v", tt.rangeStr, tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersionRange_Comparators(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		want     string
+	}{
+		{name: "comparisons", rangeStr: ">=10.0.19041, <10.0.22000", want: "[[>=10.0.19041 <10.0.22000]]"},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vr, err := e.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("Ecosystem.NewVersionRange() error = %v", err)
+			}
+			if got := fmt.Sprint(vr.Comparators()); got != tt.want {
+				t.Errorf("VersionRange.Comparators() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
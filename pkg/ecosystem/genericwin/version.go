@@ -0,0 +1,165 @@
+package genericwin
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+// Version represents a Windows file or product version: two or more dotted
+// numeric components, optionally followed by descriptive text such as
+// "(WinBuild.160101.0800)". Missing components compare as zero, so 6.3.9600
+// equals 6.3.9600.0.
+type Version struct {
+	components []uint64
+	suffix     string
+	original   string
+}
+
+// NewVersion creates a new Windows version from a string. Text after the
+// numeric components is kept as the suffix and ignored in comparisons, as
+// long as it is separated by whitespace or starts with '('.
+func (e *Ecosystem) NewVersion(version string) (*Version, error) {
+	if err := univers.CheckLength(version); err != nil {
+		return nil, err
+	}
+
+	original := version
+	version = strings.TrimSpace(version)
+
+	if version == "" {
+		return nil, fmt.Errorf("invalid Windows version: empty string")
+	}
+
+	// Split off descriptive text such as "(WinBuild.160101.0800)"
+	numeric, suffix := version, ""
+	if end := strings.IndexFunc(version, isNotNumeric); end != -1 {
+		numeric, suffix = version[:end], strings.TrimLeft(version[end:], " \t")
+		if suffix == version[end:] && !strings.HasPrefix(suffix, "(") {
+			return nil, fmt.Errorf("invalid Windows version: %s (unexpected text %q)", original, suffix)
+		}
+	}
+
+	parts := strings.Split(numeric, ".")
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("invalid Windows version: %s (must have at least 2 components)", original)
+	}
+
+	components := make([]uint64, len(parts))
+	for i, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("invalid Windows version: %s (empty component)", original)
+		}
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Windows version: %s (component too large: %s)", original, part)
+		}
+		components[i] = n
+	}
+
+	return &Version{
+		components: components,
+		suffix:     suffix,
+		original:   original,
+	}, nil
+}
+
+// ValidVersion reports whether NewVersion accepts version, without
+// allocating or building a Version.
+func ValidVersion(version string) bool {
+	if univers.CheckLength(version) != nil {
+		return false
+	}
+	version = strings.TrimSpace(version)
+	if version == "" {
+		return false
+	}
+
+	numeric := version
+	if end := strings.IndexFunc(version, isNotNumeric); end != -1 {
+		numeric = version[:end]
+		if text := version[end:]; text == strings.TrimLeft(text, " \t") && !strings.HasPrefix(text, "(") {
+			return false
+		}
+	}
+
+	// At least two components, none empty or larger than a uint64
+	if !strings.Contains(numeric, ".") {
+		return false
+	}
+	for {
+		part, rest, more := strings.Cut(numeric, ".")
+		if part == "" || !fitsUint64(part) {
+			return false
+		}
+		if !more {
+			return true
+		}
+		numeric = rest
+	}
+}
+
+// maxUint64Digits is math.MaxUint64 in decimal
+var maxUint64Digits = strconv.FormatUint(math.MaxUint64, 10)
+
+// fitsUint64 reports whether strconv.ParseUint accepts the ASCII digit
+// string s as a 64-bit number
+func fitsUint64(s string) bool {
+	s = strings.TrimLeft(s, "0")
+	return len(s) < len(maxUint64Digits) || (len(s) == len(maxUint64Digits) && s <= maxUint64Digits)
+}
+
+// isNotNumeric reports whether r is neither a digit nor a dot
+func isNotNumeric(r rune) bool {
+	return (r < '0' || r > '9') && r != '.'
+}
+
+// String returns the string representation of the version
+func (v *Version) String() string {
+	return v.original
+}
+
+// Components returns the numeric components as written
+func (v *Version) Components() []uint64 {
+	return slices.Clone(v.components)
+}
+
+// Suffix returns the descriptive text after the numeric components, such as
+// "(WinBuild.160101.0800)", or "" if there is none
+func (v *Version) Suffix() string {
+	return v.suffix
+}
+
+// Compare compares this version with another Windows version component by
+// component, treating missing components as zero. The suffix is ignored.
+func (v *Version) Compare(other *Version) int {
+	for i := range max(len(v.components), len(other.components)) {
+		if c := compareUint(v.component(i), other.component(i)); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// component returns the i-th component, or zero if the version is shorter
+func (v *Version) component(i int) uint64 {
+	if i < len(v.components) {
+		return v.components[i]
+	}
+	return 0
+}
+
+// compareUint returns -1 if a < b, 0 if a == b, 1 if a > b
+func compareUint(a, b uint64) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
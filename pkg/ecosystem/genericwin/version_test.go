@@ -0,0 +1,167 @@
+package genericwin
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestEcosystem_NewVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		want       []uint64
+		wantSuffix string
+		wantErr    bool
+	}{
+		// Valid versions
+		{
+			name:  "two components",
+			input: "6.3",
+			want:  []uint64{6, 3},
+		},
+		{
+			name:  "three components",
+			input: "6.3.9600",
+			want:  []uint64{6, 3, 9600},
+		},
+		{
+			name:  "four components",
+			input: "10.0.19041.1288",
+			want:  []uint64{10, 0, 19041, 1288},
+		},
+		{
+			name:  "five components",
+			input: "10.0.19041.1288.1",
+			want:  []uint64{10, 0, 19041, 1288, 1},
+		},
+		{
+			name:  "leading zeros",
+			input: "6.03.09600",
+			want:  []uint64{6, 3, 9600},
+		},
+		{
+			name:  "beyond int32",
+			input: "1.4294967296",
+			want:  []uint64{1, 4294967296},
+		},
+		{
+			name:       "build label coerced",
+			input:      "10.0.19041.1288 (WinBuild.160101.0800)",
+			want:       []uint64{10, 0, 19041, 1288},
+			wantSuffix: "(WinBuild.160101.0800)",
+		},
+		{
+			name:       "attached build label",
+			input:      "6.1.7601.17514(win7sp1_rtm.101119-1850)",
+			want:       []uint64{6, 1, 7601, 17514},
+			wantSuffix: "(win7sp1_rtm.101119-1850)",
+		},
+		{
+			name:       "trailing words",
+			input:      "6.3.9600.17031 built by: winblue_gdr",
+			want:       []uint64{6, 3, 9600, 17031},
+			wantSuffix: "built by: winblue_gdr",
+		},
+		{
+			name:  "surrounding whitespace",
+			input: "  10.0.22621.1  ",
+			want:  []uint64{10, 0, 22621, 1},
+		},
+		// Error cases
+		{
+			name:    "empty string",
+			input:   "",
+			wantErr: true,
+		},
+		{
+			name:    "single component",
+			input:   "10",
+			wantErr: true,
+		},
+		{
+			name:    "empty component",
+			input:   "10..1",
+			wantErr: true,
+		},
+		{
+			name:    "trailing dot",
+			input:   "10.0.",
+			wantErr: true,
+		},
+		{
+			name:    "attached text",
+			input:   "10.0.19041.1288-beta",
+			wantErr: true,
+		},
+		{
+			name:    "leading text",
+			input:   "Build 10.0.19041",
+			wantErr: true,
+		},
+		{
+			name:    "component overflow",
+			input:   "1.18446744073709551616",
+			wantErr: true,
+		},
+	}
+
+	ecosystem := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ecosystem.NewVersion(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Ecosystem.NewVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !slices.Equal(got.Components(), tt.want) {
+				t.Errorf("Components() = %v, want %v", got.Components(), tt.want)
+			}
+			if got.Suffix() != tt.wantSuffix {
+				t.Errorf("Suffix() = %q, want %q", got.Suffix(), tt.wantSuffix)
+			}
+			if got.String() != tt.input {
+				t.Errorf("String() = %q, want %q", got.String(), tt.input)
+			}
+		})
+	}
+}
+
+func TestVersion_Compare(t *testing.T) {
+	tests := []struct {
+		name string
+		v1   string
+		v2   string
+		want int
+	}{
+		{name: "equal", v1: "10.0.19041.1288", v2: "10.0.19041.1288", want: 0},
+		{name: "revision", v1: "10.0.19041.1288", v2: "10.0.19041.1415", want: -1},
+		{name: "build", v1: "10.0.22000.1", v2: "10.0.19041.9999", want: 1},
+		{name: "numeric not lexical", v1: "6.3.9600", v2: "6.3.10240", want: -1},
+		{name: "missing components are zero", v1: "6.3.9600", v2: "6.3.9600.0", want: 0},
+		{name: "shorter is lower", v1: "6.3.9600", v2: "6.3.9600.1", want: -1},
+		{name: "fifth component", v1: "10.0.19041.1288.2", v2: "10.0.19041.1288.10", want: -1},
+		{name: "suffix ignored", v1: "10.0.19041.1288 (WinBuild.160101.0800)", v2: "10.0.19041.1288", want: 0},
+	}
+
+	ecosystem := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v1, err := ecosystem.NewVersion(tt.v1)
+			if err != nil {
+				t.Fatalf("NewVersion(%q) error = %v", tt.v1, err)
+			}
+			v2, err := ecosystem.NewVersion(tt.v2)
+			if err != nil {
+				t.Fatalf("NewVersion(%q) error = %v", tt.v2, err)
+			}
+			if got := v1.Compare(v2); got != tt.want {
+				t.Errorf("Compare(%q, %q) = %d, want %d", tt.v1, tt.v2, got, tt.want)
+			}
+			if got := v2.Compare(v1); got != -tt.want {
+				t.Errorf("Compare(%q, %q) = %d, want %d", tt.v2, tt.v1, got, -tt.want)
+			}
+		})
+	}
+}
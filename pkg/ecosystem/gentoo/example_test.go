@@ -0,0 +1,50 @@
+package gentoo_test
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/gentoo"
+)
+
+// Suffixes such as _rc1 sort before the release, _p1 and -r revisions after it.
+func ExampleVersion_Compare() {
+	e := &gentoo.Ecosystem{}
+
+	var versions []*gentoo.Version
+	for _, s := range []string{"1.0-r1", "1.0_p1", "1.0_rc1", "1.0", "1.0_alpha"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		versions = append(versions, v)
+	}
+	slices.SortFunc(versions, (*gentoo.Version).Compare)
+	fmt.Println(versions)
+	// Output:
+	// [1.0_alpha 1.0_rc1 1.0 1.0-r1 1.0_p1]
+}
+
+// Ranges are comparators separated by spaces.
+func ExampleVersionRange_Contains() {
+	e := &gentoo.Ecosystem{}
+
+	r, err := e.NewVersionRange(">=1.0 <2.0")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, s := range []string{"1.0-r1", "2.0_rc1", "2.0"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(s, r.Contains(v))
+	}
+	// Output:
+	// 1.0-r1 true
+	// 2.0_rc1 true
+	// 2.0 false
+}
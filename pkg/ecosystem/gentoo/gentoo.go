@@ -1,8 +1,10 @@
 // Package gentoo provides functionality for working with Gentoo package versions.
 package gentoo
 
+import "github.com/alowayed/go-univers/pkg/univers"
+
 const (
-	Name = "gentoo"
+	Name = string(univers.Gentoo)
 )
 
 type Ecosystem struct{}
@@ -2,7 +2,10 @@ package gentoo
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 // VersionRange represents a Gentoo version range with Gentoo-specific syntax support
@@ -17,15 +20,37 @@ type constraint struct {
 	version  *Version
 }
 
+// features declares the range syntax accepted by NewVersionRange
+var features = []univers.Feature{
+	{Name: univers.FeatureComparison, Syntax: ">=1.0 <2.0", Description: "Ordering operators >=, >, <=, < and ="},
+	{Name: univers.FeatureNotEqual, Syntax: "!=1.0_rc1", Description: "Excludes a single version"},
+	{Name: univers.FeatureExact, Syntax: "1.0-r1", Description: "A bare version matches only itself"},
+	{Name: univers.FeatureAnd, Syntax: ">=1.0, <2.0", Description: "Space- or comma-separated constraints must all match"},
+}
+
+// Features returns the range syntax features supported by NewVersionRange
+func (e *Ecosystem) Features() []univers.Feature {
+	return slices.Clone(features)
+}
+
 // NewVersionRange creates a new Gentoo version range from a range string
 func (e *Ecosystem) NewVersionRange(rangeStr string) (*VersionRange, error) {
+	if err := univers.CheckLength(rangeStr); err != nil {
+		return nil, err
+	}
+
+	input := rangeStr
 	rangeStr = strings.TrimSpace(rangeStr)
 	if rangeStr == "" {
-		return nil, fmt.Errorf("empty range string")
+		return nil, univers.WrapParseError(input, fmt.Errorf("empty range string"))
 	}
 
 	constraints, err := parseRange(e, rangeStr)
 	if err != nil {
+		return nil, univers.WrapParseError(input, err)
+	}
+
+	if err := univers.CheckConstraints(len(constraints)); err != nil {
 		return nil, err
 	}
 
@@ -50,10 +75,14 @@ func parseRange(e *Ecosystem, rangeStr string) ([]*constraint, error) {
 	}
 
 	var constraints []*constraint
+	offset := 0
 	for _, part := range parts {
+		start := offset + strings.Index(rangeStr[offset:], part)
+		offset = start + len(part)
+
 		partConstraints, err := parseSingleConstraint(e, part)
 		if err != nil {
-			return nil, err
+			return nil, univers.TokenErrorAt(rangeStr, start, part, err)
 		}
 		constraints = append(constraints, partConstraints...)
 	}
@@ -105,6 +134,15 @@ func (gr *VersionRange) Contains(version *Version) bool {
 	return true
 }
 
+// Comparators returns the constraints as a single AND group
+func (gr *VersionRange) Comparators() [][]univers.Comparator {
+	group := make([]univers.Comparator, 0, len(gr.constraints))
+	for _, c := range gr.constraints {
+		group = append(group, univers.Comparator{Operator: c.operator, Version: c.version.String()})
+	}
+	return [][]univers.Comparator{group}
+}
+
 // matches checks if the given version matches this constraint
 func (c *constraint) matches(version *Version) bool {
 	comparison := version.Compare(c.version)
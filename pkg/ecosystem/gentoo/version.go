@@ -5,6 +5,9 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/alowayed/go-univers/internal/digits"
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 // versionPattern matches Gentoo version strings
@@ -32,6 +35,10 @@ type Version struct {
 
 // NewVersion creates a new Gentoo version from a string
 func (e *Ecosystem) NewVersion(version string) (*Version, error) {
+	if err := univers.CheckLength(version); err != nil {
+		return nil, err
+	}
+
 	original := version
 	version = strings.TrimSpace(version)
 
@@ -87,6 +94,43 @@ func (e *Ecosystem) NewVersion(version string) (*Version, error) {
 	}, nil
 }
 
+// ValidVersion reports whether NewVersion accepts version, without
+// allocating. Besides matching versionPattern, the numeric components, the
+// suffix number and the revision must each fit an int.
+func ValidVersion(version string) bool {
+	if univers.CheckLength(version) != nil {
+		return false
+	}
+	version = strings.TrimSpace(version)
+	if !versionPattern.MatchString(version) {
+		return false
+	}
+
+	rest := version
+	for {
+		n := digits.Prefix(rest)
+		if !digits.FitsInt(rest[:n]) {
+			return false
+		}
+		rest = rest[n:]
+		if !strings.HasPrefix(rest, ".") {
+			break
+		}
+		rest = rest[1:]
+	}
+
+	if _, suffix, found := strings.Cut(rest, "_"); found {
+		suffix = strings.TrimLeft(suffix, "abcdefghijklmnopqrstuvwxyz")
+		if !digits.FitsInt(suffix[:digits.Prefix(suffix)]) {
+			return false
+		}
+	}
+	if _, revision, found := strings.Cut(rest, "-r"); found {
+		return digits.FitsInt(revision)
+	}
+	return true
+}
+
 // String returns the string representation of the version
 func (v *Version) String() string {
 	return v.original
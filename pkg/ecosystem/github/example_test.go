@@ -0,0 +1,49 @@
+package github_test
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/github"
+)
+
+// Release tags keep their v prefix, and prereleases sort before the release.
+func ExampleVersion_Compare() {
+	e := &github.Ecosystem{}
+
+	var versions []*github.Version
+	for _, s := range []string{"v2.0.0", "v1.10.0", "v2.0.0-rc.1", "v1.2.3"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		versions = append(versions, v)
+	}
+	slices.SortFunc(versions, (*github.Version).Compare)
+	fmt.Println(versions)
+	// Output:
+	// [v1.2.3 v1.10.0 v2.0.0-rc.1 v2.0.0]
+}
+
+// Ranges are comparators separated by spaces.
+func ExampleVersionRange_Contains() {
+	e := &github.Ecosystem{}
+
+	r, err := e.NewVersionRange(">=v1.0.0 <v2.0.0")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, s := range []string{"v1.10.0", "v2.0.0"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(s, r.Contains(v))
+	}
+	// Output:
+	// v1.10.0 true
+	// v2.0.0 false
+}
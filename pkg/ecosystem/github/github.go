@@ -1,7 +1,9 @@
 package github
 
+import "github.com/alowayed/go-univers/pkg/univers"
+
 const (
-	Name = "github"
+	Name = string(univers.GitHub)
 )
 
 type Ecosystem struct{}
@@ -5,6 +5,9 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/alowayed/go-univers/internal/digits"
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 type Version struct {
@@ -31,6 +34,10 @@ var (
 )
 
 func (e *Ecosystem) NewVersion(version string) (*Version, error) {
+	if err := univers.CheckLength(version); err != nil {
+		return nil, err
+	}
+
 	if version == "" {
 		return nil, fmt.Errorf("version string cannot be empty")
 	}
@@ -55,6 +62,54 @@ func (e *Ecosystem) NewVersion(version string) (*Version, error) {
 	return parseSemanticVersion(trimmed, matches)
 }
 
+// ValidVersion reports whether NewVersion accepts version. It matches the
+// same date-based and semantic patterns without building a Version, so it
+// does not allocate.
+func ValidVersion(version string) bool {
+	if univers.CheckLength(version) != nil {
+		return false
+	}
+	trimmed := strings.TrimSpace(version)
+
+	if githubDatePattern.MatchString(trimmed) {
+		_, date, _ := strings.Cut(trimmed, ".")
+		month, day, _ := strings.Cut(date, ".")
+		return between(month, 1, 12) && between(day, 1, 31)
+	}
+
+	if !githubVersionPattern.MatchString(trimmed) {
+		return false
+	}
+	rest := strings.TrimLeft(trimmed, "vrelas-")
+	for i := range 3 {
+		n := digits.Prefix(rest)
+		if !digits.FitsInt(rest[:n]) {
+			return false
+		}
+		rest = rest[n:]
+		if i < 2 {
+			rest = rest[1:]
+		}
+	}
+
+	// An optional qualifier: a separator, letters, an optional dot and a number
+	if rest != "" {
+		rest = strings.TrimLeft(rest[1:], "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz")
+		rest = strings.TrimPrefix(rest, ".")
+	}
+	return digits.FitsInt(rest)
+}
+
+// between reports whether the one or two ASCII digits of s are a number in
+// the range [lo, hi]
+func between(s string, lo, hi int) bool {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		n = n*10 + int(s[i]-'0')
+	}
+	return n >= lo && n <= hi
+}
+
 func parseDateBasedVersion(original string, matches []string) (*Version, error) {
 	prefix := matches[1]
 	year, _ := strconv.Atoi(matches[2])
@@ -0,0 +1,105 @@
+package golang_test
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/golang"
+)
+
+// Module versions carry a v prefix; pseudo-versions sort before the release
+// they precede.
+func ExampleVersion_Compare() {
+	e := &golang.Ecosystem{}
+
+	var versions []*golang.Version
+	for _, s := range []string{"v1.5.0", "v1.5.0-rc.1", "v1.10.0", "v1.5.0-0.20240101120000-abcdef123456", "v1.2.3"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		versions = append(versions, v)
+	}
+	slices.SortFunc(versions, (*golang.Version).Compare)
+	fmt.Println(versions)
+	// Output:
+	// [v1.2.3 v1.5.0-0.20240101120000-abcdef123456 v1.5.0-rc.1 v1.5.0 v1.10.0]
+}
+
+// Ranges are comparators separated by spaces.
+func ExampleVersionRange_Contains() {
+	e := &golang.Ecosystem{}
+
+	r, err := e.NewVersionRange(">=v1.2.0 <v2.0.0")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, s := range []string{"v1.5.0", "v2.0.0"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(s, r.Contains(v))
+	}
+	// Output:
+	// v1.5.0 true
+	// v2.0.0 false
+}
+
+// Queries resolve as "go get module@query" would.
+func ExampleQuery_Resolve() {
+	e := &golang.Ecosystem{}
+
+	var versions []*golang.Version
+	for _, s := range []string{"v1.2.0", "v1.2.5", "v1.3.0", "v1.4.0-rc.1"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		versions = append(versions, v)
+	}
+
+	current := versions[0]
+	for _, query := range []string{"latest", "patch", "v1.2"} {
+		q, err := golang.ParseQuery(query)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		v, err := q.Resolve(versions, golang.QueryOptions{Current: current})
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(query, v)
+	}
+	// Output:
+	// latest v1.3.0
+	// patch v1.2.5
+	// v1.2 v1.2.5
+}
+
+func ExampleParseRetractions() {
+	r, err := golang.ParseRetractions(`module example.com/m
+
+retract (
+	v1.0.1 // published by mistake
+	[v1.1.0, v1.1.3]
+)
+`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, v := range []string{"v1.0.1", "v1.1.2", "v1.2.0"} {
+		fmt.Println(v, r.Retracted(v))
+	}
+	// Output:
+	// v1.0.1 true
+	// v1.1.2 true
+	// v1.2.0 false
+}
@@ -1,8 +1,10 @@
 // Package golang provides functionality for working with Go module versions following semantic versioning with Go-specific extensions.
 package golang
 
+import "github.com/alowayed/go-univers/pkg/univers"
+
 const (
-	Name = "golang"
+	Name = string(univers.Golang)
 )
 
 type Ecosystem struct{}
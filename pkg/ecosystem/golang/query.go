@@ -0,0 +1,308 @@
+package golang
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// QueryKind identifies the form of a module version query
+type QueryKind int
+
+const (
+	// QueryLatest is "latest": the highest release, else the highest prerelease.
+	QueryLatest QueryKind = iota
+	// QueryUpgrade is "upgrade": like latest, but never below the current version.
+	QueryUpgrade
+	// QueryPatch is "patch": the highest release with the current major and minor version.
+	QueryPatch
+	// QueryNone is "none", which removes a dependency.
+	QueryNone
+	// QueryVersion is a full semantic version such as "v1.2.3".
+	QueryVersion
+	// QueryPrefix is a version prefix such as "v1" or "v1.2".
+	QueryPrefix
+	// QueryComparison is a comparison such as "<v1.2.3" or ">=v1.2".
+	QueryComparison
+	// QueryRevision is a branch, tag or commit such as "master" or "abc1234".
+	QueryRevision
+)
+
+// Query is a module version query as accepted by "go get module@query"
+type Query struct {
+	kind     QueryKind
+	raw      string
+	operator string   // for QueryComparison
+	version  *Version // for QueryVersion and QueryComparison
+	prefix   []int    // for QueryPrefix: major, and optionally minor
+}
+
+// QueryOptions provides the context a query is resolved in
+type QueryOptions struct {
+	// Current is the version currently required, used by "upgrade" and
+	// "patch". It may be nil.
+	Current *Version
+	// ModulePath restricts candidates to the major version implied by the
+	// path: "example.com/m/v2" and "gopkg.in/yaml.v2" accept only v2, and a
+	// path without a major version suffix accepts v0, v1 and +incompatible
+	// versions. An empty path accepts every version.
+	ModulePath string
+	// Retractions lists the versions the module retracts. Like cmd/go,
+	// queries never select a retracted version unless it is asked for by a
+	// full version query or is the current version. It may be nil.
+	Retractions *Retractions
+}
+
+// ParseQuery parses a module version query, with or without a leading "@",
+// following cmd/go: the keywords latest, upgrade, patch and none, a full
+// version, a version prefix, a comparison against a version, or otherwise a
+// revision such as a branch name. As in cmd/go, versions must start with "v";
+// "1.2.3" is a revision.
+func ParseQuery(query string) (*Query, error) {
+	raw := strings.TrimPrefix(strings.TrimSpace(query), "@")
+	if raw == "" {
+		return nil, fmt.Errorf("empty version query")
+	}
+	if strings.ContainsAny(raw, " \t\n@") {
+		return nil, fmt.Errorf("invalid version query %q", query)
+	}
+
+	q := &Query{raw: raw}
+	switch raw {
+	case "latest":
+		q.kind = QueryLatest
+		return q, nil
+	case "upgrade":
+		q.kind = QueryUpgrade
+		return q, nil
+	case "patch":
+		q.kind = QueryPatch
+		return q, nil
+	case "none":
+		q.kind = QueryNone
+		return q, nil
+	}
+
+	for _, op := range []string{"<=", ">=", "<", ">"} {
+		if strings.HasPrefix(raw, op) {
+			v, err := parseQueryVersion(raw[len(op):])
+			if err != nil {
+				return nil, fmt.Errorf("invalid version in query %q: %w", query, err)
+			}
+			q.kind, q.operator, q.version = QueryComparison, op, v
+			return q, nil
+		}
+	}
+
+	if prefix, ok := parsePrefix(raw); ok {
+		q.kind, q.prefix = QueryPrefix, prefix
+		return q, nil
+	}
+
+	if strings.HasPrefix(raw, "v") {
+		if v, err := (&Ecosystem{}).NewVersion(raw); err == nil {
+			q.kind, q.version = QueryVersion, v
+			return q, nil
+		}
+	}
+
+	q.kind = QueryRevision
+	return q, nil
+}
+
+// parseQueryVersion parses the version of a comparison query, which may be
+// shortened as in "v1.2"
+func parseQueryVersion(s string) (*Version, error) {
+	if !strings.HasPrefix(s, "v") {
+		return nil, fmt.Errorf("version %q must start with 'v'", s)
+	}
+	if prefix, ok := parsePrefix(s); ok {
+		for len(prefix) < 3 {
+			prefix = append(prefix, 0)
+		}
+		s = fmt.Sprintf("v%d.%d.%d", prefix[0], prefix[1], prefix[2])
+	}
+	return (&Ecosystem{}).NewVersion(s)
+}
+
+// parsePrefix parses a version prefix "vN" or "vN.M"
+func parsePrefix(s string) ([]int, bool) {
+	if !strings.HasPrefix(s, "v") {
+		return nil, false
+	}
+	parts := strings.Split(s[1:], ".")
+	if len(parts) > 2 {
+		return nil, false
+	}
+	prefix := make([]int, 0, len(parts))
+	for _, p := range parts {
+		if p == "" || strings.Trim(p, "0123456789") != "" || (len(p) > 1 && p[0] == '0') {
+			return nil, false
+		}
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, false
+		}
+		prefix = append(prefix, n)
+	}
+	return prefix, true
+}
+
+// Kind returns the form of the query
+func (q *Query) Kind() QueryKind {
+	return q.kind
+}
+
+// String returns the query without a leading "@"
+func (q *Query) String() string {
+	return q.raw
+}
+
+// Resolve selects the version the query denotes among the available
+// versions, mirroring cmd/go. Queries that select among several versions
+// prefer releases, falling back to prereleases only if no release matches.
+// Pseudo-versions are only selected by a full version query or as the current
+// version. The latest, upgrade, patch, prefix, "<" and "<=" queries select the
+// highest candidate; ">" and ">=" select the lowest.
+//
+// A full version resolves only if it is among the available versions, and
+// is the only query that selects a version listed in opts.Retractions.
+// "none" resolves to nil. Revisions cannot be resolved from a version list
+// and return an error.
+func (q *Query) Resolve(versions []*Version, opts QueryOptions) (*Version, error) {
+	var allowed, candidates []*Version
+	for _, v := range versions {
+		if !allowedByPath(v, opts.ModulePath) {
+			continue
+		}
+		allowed = append(allowed, v)
+		if !opts.Retractions.Contains(v) {
+			candidates = append(candidates, v)
+		}
+	}
+
+	current := opts.Current
+	switch q.kind {
+	case QueryNone:
+		return nil, nil
+	case QueryRevision:
+		return nil, fmt.Errorf("revision query %q cannot be resolved from a version list", q.raw)
+	case QueryVersion:
+		if !allowedByPath(q.version, opts.ModulePath) {
+			return nil, fmt.Errorf("version %s does not match the major version of module path %s", q.raw, opts.ModulePath)
+		}
+		for _, v := range allowed {
+			if v.Compare(q.version) == 0 {
+				return v, nil
+			}
+		}
+		return nil, fmt.Errorf("version %s not found", q.raw)
+	case QueryLatest:
+		return selectVersion(q, candidates, func(*Version) bool { return true }, false)
+	case QueryUpgrade, QueryPatch:
+		if current == nil {
+			return selectVersion(q, candidates, func(*Version) bool { return true }, false)
+		}
+		v, err := selectVersion(q, candidates, func(v *Version) bool {
+			if q.kind == QueryPatch && (v.major != current.major || v.minor != current.minor) {
+				return false
+			}
+			return v.Compare(current) >= 0
+		}, false)
+		if err != nil {
+			return current, nil // nothing newer: keep the current version
+		}
+		return v, nil
+	case QueryPrefix:
+		return selectVersion(q, candidates, func(v *Version) bool {
+			return v.major == q.prefix[0] && (len(q.prefix) < 2 || v.minor == q.prefix[1])
+		}, false)
+	case QueryComparison:
+		return selectVersion(q, candidates, func(v *Version) bool {
+			c := v.Compare(q.version)
+			switch q.operator {
+			case "<":
+				return c < 0
+			case "<=":
+				return c <= 0
+			case ">":
+				return c > 0
+			}
+			return c >= 0
+		}, strings.HasPrefix(q.operator, ">"))
+	}
+	return nil, fmt.Errorf("unknown query kind %d", q.kind)
+}
+
+// selectVersion returns the highest, or with preferLower the lowest, release
+// matching filter, else the highest or lowest matching prerelease.
+// Pseudo-versions are never selected.
+func selectVersion(q *Query, versions []*Version, filter func(*Version) bool, preferLower bool) (*Version, error) {
+	var release, prerelease *Version
+	better := func(a, b *Version) bool {
+		if b == nil {
+			return true
+		}
+		if preferLower {
+			return a.Compare(b) < 0
+		}
+		return a.Compare(b) > 0
+	}
+	for _, v := range versions {
+		if v.pseudo != nil || !filter(v) {
+			continue
+		}
+		if v.prerelease == "" {
+			if better(v, release) {
+				release = v
+			}
+		} else if better(v, prerelease) {
+			prerelease = v
+		}
+	}
+	if release != nil {
+		return release, nil
+	}
+	if prerelease != nil {
+		return prerelease, nil
+	}
+	return nil, fmt.Errorf("no matching versions for query %q", q.raw)
+}
+
+// allowedByPath reports whether v's major version is permitted by the
+// module path, as cmd/go checks with module.CheckPathMajor
+func allowedByPath(v *Version, modulePath string) bool {
+	if modulePath == "" {
+		return true
+	}
+	incompatible := v.build == "incompatible"
+	if major, ok := pathMajor(modulePath); ok {
+		return v.major == major && !incompatible
+	}
+	if incompatible {
+		return v.major >= 2
+	}
+	return v.major <= 1
+}
+
+// pathMajor returns the major version required by a module path's suffix:
+// "/vN" with N >= 2, or ".vN" and ".vN-unstable" for gopkg.in paths
+func pathMajor(modulePath string) (int, bool) {
+	elem := modulePath[strings.LastIndexByte(modulePath, '/')+1:]
+	if strings.HasPrefix(modulePath, "gopkg.in/") {
+		i := strings.LastIndex(elem, ".v")
+		if i == -1 {
+			return 0, false
+		}
+		n, err := strconv.Atoi(strings.TrimSuffix(elem[i+2:], "-unstable"))
+		return n, err == nil
+	}
+	if elem == modulePath || !strings.HasPrefix(elem, "v") {
+		return 0, false
+	}
+	n, err := strconv.Atoi(elem[1:])
+	if err != nil || n < 2 || elem[1] == '0' {
+		return 0, false
+	}
+	return n, true
+}
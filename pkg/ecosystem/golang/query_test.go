@@ -0,0 +1,163 @@
+package golang
+
+import "testing"
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		query   string
+		want    QueryKind
+		wantErr bool
+	}{
+		{query: "latest", want: QueryLatest},
+		{query: "@latest", want: QueryLatest},
+		{query: "upgrade", want: QueryUpgrade},
+		{query: "patch", want: QueryPatch},
+		{query: "none", want: QueryNone},
+		{query: "v1.2.3", want: QueryVersion},
+		{query: "v1.2.3-pre.1", want: QueryVersion},
+		{query: "v0.0.0-20230102150405-abcdefabcdef", want: QueryVersion},
+		{query: "v1", want: QueryPrefix},
+		{query: "@v1.2", want: QueryPrefix},
+		{query: "<v1.2.3", want: QueryComparison},
+		{query: ">=v1.2", want: QueryComparison},
+		{query: "master", want: QueryRevision},
+		{query: "abc1234", want: QueryRevision},
+		{query: "1.2.3", want: QueryRevision},
+		{query: "v1.02", want: QueryRevision},
+		{query: "", wantErr: true},
+		{query: "@", wantErr: true},
+		{query: "master branch", wantErr: true},
+		{query: ">=1.2.3", wantErr: true},
+		{query: "<vX", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got, err := ParseQuery(tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseQuery(%q) error = %v, wantErr %v", tt.query, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Kind() != tt.want {
+				t.Errorf("ParseQuery(%q).Kind() = %v, want %v", tt.query, got.Kind(), tt.want)
+			}
+		})
+	}
+}
+
+func TestQuery_Resolve(t *testing.T) {
+	available := []string{
+		"v1.0.0",
+		"v1.1.0",
+		"v1.1.1",
+		"v1.2.0",
+		"v1.3.0-rc.1",
+		"v0.9.0",
+		"v2.0.0+incompatible",
+		"v2.1.0",
+		"v3.0.0-beta.1",
+		"v1.4.0-0.20240101000000-abcdefabcdef",
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		current    string
+		modulePath string
+		versions   []string
+		retract    string
+		want       string
+		wantNil    bool
+		wantErr    bool
+	}{
+		{name: "latest prefers releases", query: "latest", want: "v2.1.0"},
+		{name: "latest falls back to prerelease", query: "latest", versions: []string{"v1.0.0-rc.1", "v1.0.0-rc.2"}, want: "v1.0.0-rc.2"},
+		{name: "latest with no versions", query: "latest", versions: []string{}, wantErr: true},
+		{name: "latest skips pseudo-versions", query: "latest", versions: []string{"v1.0.0", "v1.4.0-0.20240101000000-abcdefabcdef"}, want: "v1.0.0"},
+		{name: "latest for plain module path", query: "latest", modulePath: "example.com/m", want: "v2.0.0+incompatible"},
+		{name: "latest for major version path", query: "latest", modulePath: "example.com/m/v2", want: "v2.1.0"},
+		{name: "latest for gopkg.in path", query: "latest", modulePath: "gopkg.in/yaml.v1", want: "v1.2.0"},
+		{name: "latest for gopkg.in prerelease major", query: "latest", modulePath: "gopkg.in/yaml.v3", want: "v3.0.0-beta.1"},
+		{name: "upgrade without current", query: "upgrade", want: "v2.1.0"},
+		{name: "upgrade from older", query: "upgrade", current: "v1.1.0", modulePath: "example.com/m", want: "v2.0.0+incompatible"},
+		{name: "upgrade keeps newer prerelease", query: "upgrade", current: "v1.3.0-rc.1", modulePath: "gopkg.in/yaml.v1", want: "v1.3.0-rc.1"},
+		{name: "upgrade keeps newer pseudo-version", query: "upgrade", current: "v1.4.0-0.20240101000000-abcdefabcdef", modulePath: "gopkg.in/yaml.v1", want: "v1.4.0-0.20240101000000-abcdefabcdef"},
+		{name: "patch", query: "patch", current: "v1.1.0", want: "v1.1.1"},
+		{name: "patch at newest patch", query: "patch", current: "v1.2.0", want: "v1.2.0"},
+		{name: "patch without current", query: "patch", want: "v2.1.0"},
+		{name: "major prefix", query: "v1", want: "v1.2.0"},
+		{name: "minor prefix", query: "v1.1", want: "v1.1.1"},
+		{name: "prefix falls back to prerelease", query: "v3", want: "v3.0.0-beta.1"},
+		{name: "prefix without match", query: "v4", wantErr: true},
+		{name: "less than", query: "<v1.2.0", want: "v1.1.1"},
+		{name: "less than or equal", query: "<=v1.2", want: "v1.2.0"},
+		{name: "greater than selects lowest", query: ">v1.0.0", want: "v1.1.0"},
+		{name: "greater than or equal", query: ">=v1.1", want: "v1.1.0"},
+		{name: "exact version", query: "v1.1.0", want: "v1.1.0"},
+		{name: "exact pseudo-version", query: "v1.4.0-0.20240101000000-abcdefabcdef", want: "v1.4.0-0.20240101000000-abcdefabcdef"},
+		{name: "exact version not listed", query: "v1.5.0", wantErr: true},
+		{name: "exact version wrong major for path", query: "v2.1.0", modulePath: "example.com/m", wantErr: true},
+		{name: "none", query: "none", wantNil: true},
+		{name: "revision", query: "master", wantErr: true},
+		{name: "latest skips retracted", query: "latest", retract: "retract v2.1.0", want: "v2.0.0+incompatible"},
+		{name: "prefix skips retracted interval", query: "v1", retract: "retract [v1.1.0, v1.2.0]", want: "v1.0.0"},
+		{name: "patch keeps current over retracted", query: "patch", current: "v1.1.0", retract: "retract v1.1.1", want: "v1.1.0"},
+		{name: "prefix with only retracted versions", query: "v1.1", retract: "retract [v1.1.0, v1.1.1]", wantErr: true},
+		{name: "exact retracted version", query: "v1.1.0", retract: "retract v1.1.0", want: "v1.1.0"},
+	}
+
+	e := &Ecosystem{}
+	parse := func(t *testing.T, s string) *Version {
+		t.Helper()
+		v, err := e.NewVersion(s)
+		if err != nil {
+			t.Fatalf("NewVersion(%q) error = %v", s, err)
+		}
+		return v
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("ParseQuery(%q) error = %v", tt.query, err)
+			}
+			list := available
+			if tt.versions != nil {
+				list = tt.versions
+			}
+			versions := make([]*Version, 0, len(list))
+			for _, s := range list {
+				versions = append(versions, parse(t, s))
+			}
+			opts := QueryOptions{ModulePath: tt.modulePath}
+			if tt.retract != "" {
+				if opts.Retractions, err = ParseRetractions(tt.retract); err != nil {
+					t.Fatalf("ParseRetractions(%q) error = %v", tt.retract, err)
+				}
+			}
+			if tt.current != "" {
+				opts.Current = parse(t, tt.current)
+			}
+
+			got, err := q.Resolve(versions, opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Resolve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if tt.wantNil {
+				if got != nil {
+					t.Errorf("Resolve() = %v, want nil", got)
+				}
+				return
+			}
+			if got == nil || got.String() != tt.want {
+				t.Errorf("Resolve() = %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
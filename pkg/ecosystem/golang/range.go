@@ -2,7 +2,10 @@ package golang
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 // VersionRange represents a Go module version range
@@ -17,17 +20,39 @@ type constraint struct {
 	version  string
 }
 
+// features declares the range syntax accepted by NewVersionRange
+var features = []univers.Feature{
+	{Name: univers.FeatureComparison, Syntax: ">=v1.2.0 <v2.0.0", Description: "Ordering operators >=, >, <=, < and ="},
+	{Name: univers.FeatureNotEqual, Syntax: "!=v1.2.1", Description: "Excludes a single version"},
+	{Name: univers.FeatureExact, Syntax: "v1.2.3", Description: "A bare version matches only itself"},
+	{Name: univers.FeatureAnd, Syntax: ">=v1.0.0 <v2.0.0", Description: "Space-separated constraints must all match"},
+}
+
+// Features returns the range syntax features supported by NewVersionRange
+func (e *Ecosystem) Features() []univers.Feature {
+	return slices.Clone(features)
+}
+
 // NewVersionRange creates a new Go module version range from a range string
 func (e *Ecosystem) NewVersionRange(rangeStr string) (*VersionRange, error) {
+	if err := univers.CheckLength(rangeStr); err != nil {
+		return nil, err
+	}
+
+	input := rangeStr
 	rangeStr = strings.TrimSpace(rangeStr)
 	if rangeStr == "" {
-		return nil, fmt.Errorf("empty range string")
+		return nil, univers.WrapParseError(input, fmt.Errorf("empty range string"))
 	}
 
 	// For Go modules, ranges are typically simple comparisons
 	// Common patterns: >=v1.2.3, >v1.2.3, <v2.0.0, <=v1.9.9, v1.2.3
 	constraints, err := parseGoRange(rangeStr)
 	if err != nil {
+		return nil, univers.WrapParseError(input, err)
+	}
+
+	if err := univers.CheckConstraints(len(constraints)); err != nil {
 		return nil, err
 	}
 
@@ -43,10 +68,14 @@ func parseGoRange(rangeStr string) ([]*constraint, error) {
 	if strings.Contains(rangeStr, " ") {
 		parts := strings.Fields(rangeStr)
 		var constraints []*constraint
+		offset := 0
 		for _, part := range parts {
+			start := offset + strings.Index(rangeStr[offset:], part)
+			offset = start + len(part)
+
 			partConstraints, err := parseSingleGoConstraint(part)
 			if err != nil {
-				return nil, err
+				return nil, univers.TokenErrorAt(rangeStr, start, part, err)
 			}
 			constraints = append(constraints, partConstraints...)
 		}
@@ -88,6 +117,15 @@ func (gr *VersionRange) Contains(version *Version) bool {
 	return true
 }
 
+// Comparators returns the constraints as a single AND group
+func (gr *VersionRange) Comparators() [][]univers.Comparator {
+	group := make([]univers.Comparator, 0, len(gr.constraints))
+	for _, c := range gr.constraints {
+		group = append(group, univers.Comparator{Operator: c.operator, Version: c.version})
+	}
+	return [][]univers.Comparator{group}
+}
+
 // matches checks if a version matches this constraint
 func (c *constraint) matches(version *Version) bool {
 	e := &Ecosystem{}
@@ -0,0 +1,114 @@
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+// Retractions holds the versions a module retracts with retract directives in
+// its go.mod
+type Retractions struct {
+	intervals []retraction
+}
+
+// retraction is a single retracted version or closed interval of versions
+type retraction struct {
+	low, high *Version
+}
+
+// ParseRetractions parses the retract directives of a go.mod file, both
+// single-line and in parenthesized blocks, each retracting a version such as
+// "v1.0.0" or a closed interval such as "[v1.0.0, v1.0.5]". Other directives
+// and comments are ignored.
+func ParseRetractions(gomod string) (*Retractions, error) {
+	r := &Retractions{}
+	inBlock := false
+	for i, line := range strings.Split(gomod, "\n") {
+		if j := strings.Index(line, "//"); j >= 0 {
+			line = line[:j]
+		}
+		line = strings.TrimSpace(line)
+
+		switch {
+		case inBlock && line == ")":
+			inBlock = false
+			continue
+		case inBlock:
+		case line == "retract (":
+			inBlock = true
+			continue
+		case strings.HasPrefix(line, "retract ") || strings.HasPrefix(line, "retract\t"):
+			line = strings.TrimSpace(line[len("retract"):])
+		default:
+			continue
+		}
+		if line == "" {
+			continue
+		}
+
+		ret, err := parseRetraction(line)
+		if err != nil {
+			return nil, fmt.Errorf("go.mod line %d: %w", i+1, err)
+		}
+		r.intervals = append(r.intervals, ret)
+	}
+	if inBlock {
+		return nil, fmt.Errorf("unterminated retract block")
+	}
+	return r, nil
+}
+
+// parseRetraction parses a retracted version or "[low, high]" interval
+func parseRetraction(s string) (retraction, error) {
+	e := &Ecosystem{}
+	if !strings.HasPrefix(s, "[") {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			return retraction{}, fmt.Errorf("invalid retracted version %q: %w", s, err)
+		}
+		return retraction{low: v, high: v}, nil
+	}
+
+	low, high, ok := strings.Cut(strings.TrimSuffix(strings.TrimPrefix(s, "["), "]"), ",")
+	if !ok || !strings.HasSuffix(s, "]") {
+		return retraction{}, fmt.Errorf("invalid retracted interval %q", s)
+	}
+	lv, err := e.NewVersion(strings.TrimSpace(low))
+	if err != nil {
+		return retraction{}, fmt.Errorf("invalid retracted interval %q: %w", s, err)
+	}
+	hv, err := e.NewVersion(strings.TrimSpace(high))
+	if err != nil {
+		return retraction{}, fmt.Errorf("invalid retracted interval %q: %w", s, err)
+	}
+	if lv.Compare(hv) > 0 {
+		return retraction{}, fmt.Errorf("invalid retracted interval %q: lower bound above upper bound", s)
+	}
+	return retraction{low: lv, high: hv}, nil
+}
+
+// Contains reports whether v is retracted. A nil Retractions retracts
+// nothing.
+func (r *Retractions) Contains(v *Version) bool {
+	if r == nil {
+		return false
+	}
+	for _, ret := range r.intervals {
+		if univers.Between(v, ret.low, ret.high, univers.IncludeBoth) {
+			return true
+		}
+	}
+	return false
+}
+
+// Retracted reports whether the version string is retracted, and is false
+// for versions that fail to parse. It can be passed to univers.WithYanked so
+// that univers.MaxSatisfying skips retracted versions unless the range pins
+// one exactly, as cmd/go only selects a retracted version when asked for it
+// by name.
+func (r *Retractions) Retracted(version string) bool {
+	v, err := (&Ecosystem{}).NewVersion(version)
+	return err == nil && r.Contains(v)
+}
@@ -0,0 +1,71 @@
+package golang
+
+import "testing"
+
+func TestParseRetractions(t *testing.T) {
+	gomod := `module example.com/m
+
+go 1.21
+
+require example.com/dep v1.0.0
+
+// Accidentally published.
+retract v1.0.0
+
+retract [v1.1.0, v1.1.3] // Broken build.
+
+retract (
+	v1.2.0-rc.1 // Tagged in error.
+	[v1.3.0, v1.3.9]
+)
+`
+	r, err := ParseRetractions(gomod)
+	if err != nil {
+		t.Fatalf("ParseRetractions() error = %v", err)
+	}
+
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{version: "v1.0.0", want: true},
+		{version: "v1.0.1", want: false},
+		{version: "v1.1.0", want: true},
+		{version: "v1.1.2", want: true},
+		{version: "v1.1.3", want: true},
+		{version: "v1.1.4", want: false},
+		{version: "v1.2.0-rc.1", want: true},
+		{version: "v1.2.0", want: false},
+		{version: "v1.3.5", want: true},
+		{version: "v1.4.0", want: false},
+		{version: "not-a-version", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			if got := r.Retracted(tt.version); got != tt.want {
+				t.Errorf("Retracted(%q) = %v, want %v", tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRetractions_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		gomod string
+	}{
+		{name: "invalid version", gomod: "retract bogus"},
+		{name: "invalid interval", gomod: "retract [v1.0.0 v1.1.0]"},
+		{name: "reversed interval", gomod: "retract [v1.1.0, v1.0.0]"},
+		{name: "unterminated block", gomod: "retract (\n\tv1.0.0\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseRetractions(tt.gomod); err == nil {
+				t.Errorf("ParseRetractions(%q) error = nil, want error", tt.gomod)
+			}
+		})
+	}
+}
@@ -6,6 +6,9 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/alowayed/go-univers/internal/digits"
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 // Regular expressions for Go version parsing
@@ -44,6 +47,10 @@ type pseudoVersion struct {
 
 // NewVersion creates a new Go module version from a string
 func (e *Ecosystem) NewVersion(version string) (*Version, error) {
+	if err := univers.CheckLength(version); err != nil {
+		return nil, err
+	}
+
 	original := version
 	version = strings.TrimSpace(version)
 
@@ -98,6 +105,91 @@ func (e *Ecosystem) NewVersion(version string) (*Version, error) {
 	}, nil
 }
 
+// ValidVersion reports whether NewVersion accepts version. It matches the
+// pseudo-version and semantic version forms by hand, so unlike NewVersion it
+// neither allocates nor builds a Version.
+func ValidVersion(version string) bool {
+	if univers.CheckLength(version) != nil {
+		return false
+	}
+	version = strings.TrimSpace(version)
+	if version == "" {
+		return false
+	}
+
+	// NewVersion adds a missing "v", so every form follows exactly one "v"
+	rest := strings.TrimPrefix(version, "v")
+	if timestamp, ok := pseudoTimestamp(rest); ok && validTimestamp(timestamp) {
+		return true
+	}
+
+	if strings.HasPrefix(rest, "v") || !semverPattern.MatchString(rest) {
+		return false
+	}
+	major, rest, _ := strings.Cut(rest, ".")
+	minor, rest, _ := strings.Cut(rest, ".")
+	patch := rest[:digits.Prefix(rest)]
+	return digits.FitsInt(major) && digits.FitsInt(minor) && digits.FitsInt(patch)
+}
+
+// pseudoTimestamp returns the timestamp of s, a version without its "v"
+// prefix, if s has the shape of one of the pseudo-version patterns
+func pseudoTimestamp(s string) (string, bool) {
+	var nums [3]string
+	for i, sep := range [3]string{".", ".", "-"} {
+		n := digits.Prefix(s)
+		if n == 0 || !strings.HasPrefix(s[n:], sep) {
+			return "", false
+		}
+		nums[i], s = s[:n], s[n+1:]
+	}
+
+	// The rest ends in "yyyymmddhhmmss-abcdefabcdef"
+	const tail = 14 + 1 + 12
+	if len(s) < tail {
+		return "", false
+	}
+	base, timestamp, revision := s[:len(s)-tail], s[len(s)-tail:len(s)-13], s[len(s)-12:]
+	if digits.Prefix(timestamp) != 14 || s[len(s)-13] != '-' {
+		return "", false
+	}
+	for i := 0; i < len(revision); i++ {
+		if (revision[i] < '0' || revision[i] > '9') && (revision[i] < 'a' || revision[i] > 'f') {
+			return "", false
+		}
+	}
+
+	switch {
+	case base == "" && nums[1] == "0" && nums[2] == "0": // pseudoPattern1
+	case len(base) > len(".0.") && strings.HasSuffix(base, ".0.") && !strings.Contains(base[:len(base)-len(".0.")], "."): // pseudoPattern2
+	case base == "0.": // pseudoPattern3
+	default:
+		return "", false
+	}
+	return timestamp, true
+}
+
+// validTimestamp reports whether time.Parse accepts the 14 ASCII digits of ts
+// in the pseudo-version layout yyyymmddhhmmss
+func validTimestamp(ts string) bool {
+	field := func(i, n int) int {
+		v := 0
+		for _, c := range []byte(ts[i : i+n]) {
+			v = v*10 + int(c-'0')
+		}
+		return v
+	}
+
+	year, month, day := field(0, 4), field(4, 2), field(6, 2)
+	if month < 1 || month > 12 || day < 1 {
+		return false
+	}
+	if daysIn := time.Date(year, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day(); day > daysIn {
+		return false
+	}
+	return field(8, 2) < 24 && field(10, 2) < 60 && field(12, 2) < 60
+}
+
 // parsePseudoVersion attempts to parse a pseudo-version
 func parsePseudoVersion(version string) (*struct {
 	major, minor, patch int
@@ -220,6 +312,22 @@ func (v *Version) String() string {
 	return v.original
 }
 
+// PrereleaseChannel returns the normalized channel of the prerelease, such as
+// "rc" for v1.5.0-rc.1, or "" for a release. Pseudo-versions have no
+// prerelease label of their own, so their channel is "" too. See
+// univers.SplitPrerelease.
+func (v *Version) PrereleaseChannel() string {
+	channel, _, _ := univers.SplitPrerelease(v.prerelease)
+	return channel
+}
+
+// PrereleaseNumber returns the number after the prerelease channel, such as 1
+// for v1.5.0-rc.1, and whether there is one.
+func (v *Version) PrereleaseNumber() (int, bool) {
+	_, number, ok := univers.SplitPrerelease(v.prerelease)
+	return number, ok
+}
+
 // compareInt returns -1 if a < b, 0 if a == b, 1 if a > b
 func compareInt(a, b int) int {
 	if a < b {
@@ -287,3 +287,70 @@ func mustNewVersion(t *testing.T, input string) *Version {
 	}
 	return v
 }
+
+func TestValidVersion_PseudoVersions(t *testing.T) {
+	// Pseudo-versions, with every single-character substitution that matters
+	// to the patterns or to the timestamp
+	pseudos := []string{
+		"v0.0.0-20191109021931-daa7c04131f5",
+		"1.2.4-0.20240229235959-abcdefabcdef",
+		"v1.2.3-pre.0.20230101000000-abcdefabcdef",
+		"v99999999999999999999.0.0-20230101000000-abcdefabcdef",
+		"v1.2.3-a_b.0.20230101000000-abcdefabcdef",
+		"v1.0.0-20230229000000-abcdefabcdef",
+		"v1.2.4-0.20231301000000-abcdefabcdef",
+	}
+	inputs := pseudos
+	for _, pseudo := range pseudos {
+		for i := range pseudo {
+			for _, c := range []string{"0", "1", "2", "3", "6", "9", ".", "-", "a", "A", "g", "_"} {
+				inputs = append(inputs, pseudo[:i]+c+pseudo[i+1:])
+			}
+		}
+	}
+
+	e := &Ecosystem{}
+	for _, input := range inputs {
+		_, err := e.NewVersion(input)
+		if got, want := ValidVersion(input), err == nil; got != want {
+			t.Errorf("ValidVersion(%q) = %v, want %v", input, got, want)
+		}
+	}
+
+	for _, input := range pseudos {
+		if allocs := testing.AllocsPerRun(10, func() { ValidVersion(input) }); allocs != 0 {
+			t.Errorf("ValidVersion(%q) allocates %v times, want 0", input, allocs)
+		}
+	}
+}
+
+func TestVersion_PrereleaseChannel(t *testing.T) {
+	tests := []struct {
+		version     string
+		wantChannel string
+		wantNumber  int
+		wantOK      bool
+	}{
+		{"v1.5.0", "", 0, false},
+		{"v1.5.0-rc.1", "rc", 1, true},
+		{"v2.0.0-beta", "beta", 0, false},
+		{"v0.0.0-20191109021931-daa7c04131f5", "", 0, false},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			v, err := e.NewVersion(tt.version)
+			if err != nil {
+				t.Fatalf("NewVersion(%q) error = %v", tt.version, err)
+			}
+			if got := v.PrereleaseChannel(); got != tt.wantChannel {
+				t.Errorf("PrereleaseChannel() = %q, want %q", got, tt.wantChannel)
+			}
+			number, ok := v.PrereleaseNumber()
+			if number != tt.wantNumber || ok != tt.wantOK {
+				t.Errorf("PrereleaseNumber() = %d, %v, want %d, %v", number, ok, tt.wantNumber, tt.wantOK)
+			}
+		})
+	}
+}
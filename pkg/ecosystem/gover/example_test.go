@@ -0,0 +1,65 @@
+package gover_test
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/gover"
+)
+
+// A language version such as go1.22 sorts before its release candidates.
+func ExampleVersion_Compare() {
+	e := &gover.Ecosystem{}
+
+	var versions []*gover.Version
+	for _, s := range []string{"go1.22.0", "go1.21.0", "go1.22rc1", "go1.22", "go1.21rc2"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		versions = append(versions, v)
+	}
+	slices.SortFunc(versions, (*gover.Version).Compare)
+	fmt.Println(versions)
+	// Output:
+	// [go1.21rc2 go1.21.0 go1.22 go1.22rc1 go1.22.0]
+}
+
+// Ranges are comparators separated by commas.
+func ExampleVersionRange_Contains() {
+	e := &gover.Ecosystem{}
+
+	r, err := e.NewVersionRange(">= go1.21.5, < go1.22")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, s := range []string{"go1.21.13", "go1.22rc1"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(s, r.Contains(v))
+	}
+	// Output:
+	// go1.21.13 true
+	// go1.22rc1 false
+}
+
+func ExampleVersion_Lang() {
+	e := &gover.Ecosystem{}
+
+	for _, s := range []string{"go1.22.3", "go1.22rc1"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(s, v.Lang(), v.IsPrerelease())
+	}
+	// Output:
+	// go1.22.3 1.22 false
+	// go1.22rc1 1.22 true
+}
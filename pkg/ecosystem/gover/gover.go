@@ -0,0 +1,16 @@
+// Package gover provides functionality for working with Go toolchain versions
+// (go1.22.3, go1.21rc2) and Go language versions (1.22), ordered the way the
+// standard library's go/version package orders them.
+package gover
+
+import "github.com/alowayed/go-univers/pkg/univers"
+
+const (
+	Name = string(univers.GoVer)
+)
+
+type Ecosystem struct{}
+
+func (e *Ecosystem) Name() string {
+	return Name
+}
@@ -0,0 +1,169 @@
+package gover
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+// VersionRange represents a Go version range of comma-separated comparisons
+type VersionRange struct {
+	constraints []*constraint
+	original    string
+}
+
+// constraint represents a single Go version constraint
+type constraint struct {
+	operator string
+	version  *Version
+}
+
+// features declares the range syntax accepted by NewVersionRange
+var features = []univers.Feature{
+	{Name: univers.FeatureComparison, Syntax: ">= go1.21.5", Description: "Ordering operators >=, >, <=, < and ="},
+	{Name: univers.FeatureNotEqual, Syntax: "!= go1.22.0", Description: "Excludes a single version"},
+	{Name: univers.FeatureExact, Syntax: "go1.22.3", Description: "A bare version matches only itself"},
+	{Name: univers.FeatureAnd, Syntax: ">= go1.21.5, < go1.22", Description: "Comma-separated constraints must all match"},
+}
+
+// Features returns the range syntax features supported by NewVersionRange
+func (e *Ecosystem) Features() []univers.Feature {
+	return slices.Clone(features)
+}
+
+// NewVersionRange creates a new Go version range from a range string, such
+// as ">= go1.21.5, < go1.22"
+func (e *Ecosystem) NewVersionRange(rangeStr string) (*VersionRange, error) {
+	if err := univers.CheckLength(rangeStr); err != nil {
+		return nil, err
+	}
+
+	original := rangeStr
+	rangeStr = strings.TrimSpace(rangeStr)
+	if rangeStr == "" {
+		return nil, univers.WrapParseError(original, fmt.Errorf("empty range string"))
+	}
+
+	constraints, err := parseConstraints(rangeStr, e)
+	if err != nil {
+		return nil, univers.WrapParseError(original, err)
+	}
+
+	if err := univers.CheckConstraints(len(constraints)); err != nil {
+		return nil, err
+	}
+
+	return &VersionRange{
+		constraints: constraints,
+		original:    original,
+	}, nil
+}
+
+// parseConstraints parses comma-separated comparison constraints
+func parseConstraints(rangeStr string, e *Ecosystem) ([]*constraint, error) {
+	// Handle multiple constraints separated by comma (AND logic)
+	parts := strings.Split(rangeStr, ",")
+	var constraints []*constraint
+
+	offset := 0
+	for _, part := range parts {
+		start := offset + strings.Index(rangeStr[offset:], part)
+		offset = start + len(part)
+
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		constraint, err := parseConstraint(part, e)
+		if err != nil {
+			return nil, univers.TokenErrorAt(rangeStr, start, part, err)
+		}
+		constraints = append(constraints, constraint)
+	}
+
+	if len(constraints) == 0 {
+		return nil, fmt.Errorf("no valid constraints found")
+	}
+
+	return constraints, nil
+}
+
+// parseConstraint parses a single constraint
+func parseConstraint(constraintStr string, e *Ecosystem) (*constraint, error) {
+	constraintStr = strings.TrimSpace(constraintStr)
+
+	// Ranges use the standard comparison operators
+	operators := []string{">=", "<=", "!=", ">", "<", "="}
+	for _, op := range operators {
+		if strings.HasPrefix(constraintStr, op) {
+			versionStr := strings.TrimSpace(constraintStr[len(op):])
+			if versionStr == "" {
+				return nil, fmt.Errorf("constraint %s requires version", op)
+			}
+			// Parse and store the version object
+			version, err := e.NewVersion(versionStr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid version in constraint %s: %w", constraintStr, err)
+			}
+			return &constraint{operator: op, version: version}, nil
+		}
+	}
+
+	// Default to exact match - parse and store the version
+	version, err := e.NewVersion(constraintStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version in constraint %s: %w", constraintStr, err)
+	}
+	return &constraint{operator: "=", version: version}, nil
+}
+
+// String returns the string representation of the version range
+func (vr *VersionRange) String() string {
+	return vr.original
+}
+
+// Contains checks if a version satisfies this range
+func (vr *VersionRange) Contains(version *Version) bool {
+	// All constraints must be satisfied (AND logic)
+	for _, c := range vr.constraints {
+		if !satisfiesConstraint(version, c) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Comparators returns the constraints as a single AND group
+func (vr *VersionRange) Comparators() [][]univers.Comparator {
+	group := make([]univers.Comparator, 0, len(vr.constraints))
+	for _, c := range vr.constraints {
+		group = append(group, univers.Comparator{Operator: c.operator, Version: c.version.String()})
+	}
+	return [][]univers.Comparator{group}
+}
+
+// satisfiesConstraint checks if a version satisfies a single constraint
+func satisfiesConstraint(version *Version, c *constraint) bool {
+	cmp := version.Compare(c.version)
+
+	switch c.operator {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	default:
+		return false
+	}
+}
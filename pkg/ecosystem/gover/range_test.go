@@ -0,0 +1,168 @@
+package gover
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestEcosystem_NewVersionRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		// Valid ranges
+		{
+			name:  "exact version",
+			input: "go1.22.3",
+		},
+		{
+			name:  "comparison",
+			input: ">=go1.21.5",
+		},
+		{
+			name:  "multiple constraints",
+			input: ">= go1.21.5, < 1.23",
+		},
+		{
+			name:  "not equal",
+			input: "!=1.22rc1",
+		},
+		// Error cases
+		{
+			name:    "empty string",
+			input:   "",
+			wantErr: true,
+		},
+		{
+			name:    "operator without version",
+			input:   ">=",
+			wantErr: true,
+		},
+		{
+			name:    "invalid version",
+			input:   ">=go1.21.3rc1",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported operator",
+			input:   "~1.22",
+			wantErr: true,
+		},
+	}
+
+	ecosystem := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ecosystem.NewVersionRange(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Ecosystem.NewVersionRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVersionRange_Contains(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		version  string
+		want     bool
+	}{
+		{
+			name:     "toolchain policy satisfied",
+			rangeStr: ">= go1.21.5",
+			version:  "go1.22.0",
+			want:     true,
+		},
+		{
+			name:     "toolchain policy violated",
+			rangeStr: ">= go1.21.5",
+			version:  "go1.21.4",
+			want:     false,
+		},
+		{
+			name:     "language version below its release candidates",
+			rangeStr: ">= go1.21.5, < 1.22",
+			version:  "go1.22rc1",
+			want:     false,
+		},
+		{
+			name:     "release candidate below release",
+			rangeStr: "< go1.22.0",
+			version:  "go1.22rc2",
+			want:     true,
+		},
+		{
+			name:     "old language version equals .0 release",
+			rangeStr: "1.20",
+			version:  "go1.20.0",
+			want:     true,
+		},
+		{
+			name:     "custom toolchain suffix ignored",
+			rangeStr: ">= go1.21.5",
+			version:  "go1.21.5-bigcorp",
+			want:     true,
+		},
+		{
+			name:     "not equal",
+			rangeStr: ">= 1.21, != 1.21.1",
+			version:  "go1.21.1",
+			want:     false,
+		},
+	}
+
+	ecosystem := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vr, err := ecosystem.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("Failed to parse range %s: %v", tt.rangeStr, err)
+			}
+
+			v, err := ecosystem.NewVersion(tt.version)
+			if err != nil {
+				t.Fatalf("Failed to parse version %s: %v", tt.version, err)
+			}
+
+			got := vr.Contains(v)
+			if got != tt.want {
+				t.Errorf("VersionRange.Contains() = %v, want %v (range=%s, version=%s)", got, tt.want, tt.rangeStr, tt.version)
+			}
+		})
+	}
+}
+
+func TestEcosystem_Features(t *testing.T) {
+	e := &Ecosystem{}
+	for _, f := range e.Features() {
+		if _, err := e.NewVersionRange(f.Syntax); err != nil {
+			t.Errorf("Features() %s syntax %q does not parse: %v", f.Name, f.Syntax, err)
+		}
+	}
+}
+
+func TestVersionRange_Comparators(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		want     string
+	}{
+		{name: "comparisons", rangeStr: ">= go1.21.5, < go1.22", want: "[[>=go1.21.5 <go1.22]]"},
+		{name: "not equal", rangeStr: "!= go1.22.0", want: "[[!=go1.22.0]]"},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vr, err := e.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("Ecosystem.NewVersionRange() error = %v", err)
+			}
+			if got := fmt.Sprint(vr.Comparators()); got != tt.want {
+				t.Errorf("VersionRange.Comparators() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package gover
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+// Version represents a Go toolchain or language version. Numbers are kept as
+// decimal strings, as the go command does, so any length compares correctly.
+type Version struct {
+	major    string
+	minor    string
+	patch    string // empty for language versions from Go 1.21 on, e.g. "1.21"
+	kind     string // prerelease kind: "alpha", "beta" or "rc"
+	pre      string // prerelease number, e.g. "2" in "1.21rc2"
+	original string
+}
+
+// NewVersion creates a new Go version from a string. The "go" prefix of
+// toolchain names is optional, and a custom toolchain suffix such as
+// "-bigcorp" in "go1.21.5-bigcorp" is ignored, as go/version does.
+func (e *Ecosystem) NewVersion(version string) (*Version, error) {
+	if err := univers.CheckLength(version); err != nil {
+		return nil, err
+	}
+
+	original := version
+	version = strings.TrimSpace(version)
+
+	if version == "" {
+		return nil, fmt.Errorf("invalid Go version: empty string")
+	}
+
+	v, ok := parseParts(trimVersion(version))
+	if !ok {
+		return nil, fmt.Errorf("invalid Go version: %s", original)
+	}
+	v.original = original
+	return &v, nil
+}
+
+// ValidVersion reports whether NewVersion accepts version. It does not allocate.
+func ValidVersion(version string) bool {
+	if univers.CheckLength(version) != nil {
+		return false
+	}
+	_, ok := parseParts(trimVersion(strings.TrimSpace(version)))
+	return ok
+}
+
+// trimVersion strips the "go" prefix and any custom toolchain suffix
+func trimVersion(version string) string {
+	version, _, _ = strings.Cut(version, "-")
+	return strings.TrimPrefix(version, "go")
+}
+
+// parseParts parses a version without the "go" prefix as the go command
+// does: "1" means 1.0.0, a missing patch before Go 1.21 means .0, and only
+// X.Y versions take a prerelease
+func parseParts(x string) (Version, bool) {
+	var v Version
+	var ok bool
+
+	v.major, x, ok = cutInt(x)
+	if !ok {
+		return Version{}, false
+	}
+	if x == "" {
+		v.minor, v.patch = "0", "0"
+		return v, true
+	}
+
+	if x[0] != '.' {
+		return Version{}, false
+	}
+	v.minor, x, ok = cutInt(x[1:])
+	if !ok {
+		return Version{}, false
+	}
+	if x == "" {
+		// Starting in Go 1.21 the language version 1.21 sorts before
+		// its release candidates and the 1.21.0 release
+		if compareInt(v.minor, "21") < 0 {
+			v.patch = "0"
+		}
+		return v, true
+	}
+
+	if x[0] == '.' {
+		v.patch, x, ok = cutInt(x[1:])
+		if !ok || x != "" {
+			return Version{}, false
+		}
+		return v, true
+	}
+
+	i := 0
+	for i < len(x) && (x[i] < '0' || x[i] > '9') {
+		if x[i] < 'a' || x[i] > 'z' {
+			return Version{}, false
+		}
+		i++
+	}
+	if i == 0 {
+		return Version{}, false
+	}
+	v.kind, x = x[:i], x[i:]
+	if x == "" {
+		return v, true
+	}
+	v.pre, x, ok = cutInt(x)
+	if !ok || x != "" {
+		return Version{}, false
+	}
+	return v, true
+}
+
+// cutInt splits a leading decimal number without leading zeros off x
+func cutInt(x string) (n, rest string, ok bool) {
+	i := 0
+	for i < len(x) && x[i] >= '0' && x[i] <= '9' {
+		i++
+	}
+	if i == 0 || (x[0] == '0' && i != 1) {
+		return "", "", false
+	}
+	return x[:i], x[i:], true
+}
+
+// String returns the string representation of the version
+func (v *Version) String() string {
+	return v.original
+}
+
+// Lang returns the Go language version of v, such as "1.22" for go1.22.3 or
+// go1.22rc1. Versions before Go 1.1 all have language version "1".
+func (v *Version) Lang() string {
+	if v.major == "1" && v.minor == "0" {
+		return v.major
+	}
+	return v.major + "." + v.minor
+}
+
+// IsPrerelease reports whether v is an alpha, beta or release candidate
+func (v *Version) IsPrerelease() bool {
+	return v.kind != ""
+}
+
+// PrereleaseChannel returns "alpha", "beta" or "rc" for a prerelease such as
+// go1.21rc2, or "" for a release.
+func (v *Version) PrereleaseChannel() string {
+	return v.kind
+}
+
+// PrereleaseNumber returns the number of a prerelease, such as 2 for
+// go1.21rc2, and whether v is one.
+func (v *Version) PrereleaseNumber() (int, bool) {
+	if v.kind == "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(v.pre)
+	return n, err == nil
+}
+
+// Compare compares this version with another Go version. Language versions
+// sort before their prereleases and releases: 1.21 < 1.21rc1 < 1.21.0.
+func (v *Version) Compare(other *Version) int {
+	if c := compareInt(v.major, other.major); c != 0 {
+		return c
+	}
+	if c := compareInt(v.minor, other.minor); c != 0 {
+		return c
+	}
+	if c := compareInt(v.patch, other.patch); c != 0 {
+		return c
+	}
+	// "" < "alpha" < "beta" < "rc"
+	if c := strings.Compare(v.kind, other.kind); c != 0 {
+		return c
+	}
+	return compareInt(v.pre, other.pre)
+}
+
+// compareInt compares two decimal strings without leading zeros, where the
+// empty string sorts before every number
+func compareInt(a, b string) int {
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a, b)
+}
@@ -0,0 +1,268 @@
+package gover
+
+import (
+	"go/version"
+	"testing"
+)
+
+func TestEcosystem_NewVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Version
+		wantErr bool
+	}{
+		// Valid versions
+		{
+			name:  "toolchain",
+			input: "go1.22.3",
+			want:  Version{major: "1", minor: "22", patch: "3"},
+		},
+		{
+			name:  "language version",
+			input: "1.22",
+			want:  Version{major: "1", minor: "22"},
+		},
+		{
+			name:  "old language version",
+			input: "1.20",
+			want:  Version{major: "1", minor: "20", patch: "0"},
+		},
+		{
+			name:  "release candidate",
+			input: "go1.21rc2",
+			want:  Version{major: "1", minor: "21", kind: "rc", pre: "2"},
+		},
+		{
+			name:  "prerelease without number",
+			input: "go1.21beta",
+			want:  Version{major: "1", minor: "21", kind: "beta"},
+		},
+		{
+			name:  "major only",
+			input: "go1",
+			want:  Version{major: "1", minor: "0", patch: "0"},
+		},
+		{
+			name:  "custom toolchain suffix",
+			input: "go1.21.5-bigcorp",
+			want:  Version{major: "1", minor: "21", patch: "5"},
+		},
+		{
+			name:  "whitespace",
+			input: "  go1.22.0  ",
+			want:  Version{major: "1", minor: "22", patch: "0"},
+		},
+		// Error cases
+		{
+			name:    "empty string",
+			input:   "",
+			wantErr: true,
+		},
+		{
+			name:    "prefix only",
+			input:   "go",
+			wantErr: true,
+		},
+		{
+			name:    "prerelease of patch release",
+			input:   "go1.21.3rc1",
+			wantErr: true,
+		},
+		{
+			name:    "leading zero",
+			input:   "go1.021",
+			wantErr: true,
+		},
+		{
+			name:    "four components",
+			input:   "1.21.0.1",
+			wantErr: true,
+		},
+		{
+			name:    "trailing dot",
+			input:   "1.",
+			wantErr: true,
+		},
+		{
+			name:    "uppercase prerelease",
+			input:   "1.21RC1",
+			wantErr: true,
+		},
+	}
+
+	ecosystem := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ecosystem.NewVersion(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Ecosystem.NewVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			tt.want.original = tt.input
+			if *got != tt.want {
+				t.Errorf("Ecosystem.NewVersion() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersion_Compare(t *testing.T) {
+	tests := []struct {
+		name string
+		v1   string
+		v2   string
+		want int
+	}{
+		{
+			name: "patch releases",
+			v1:   "go1.21.4",
+			v2:   "go1.21.5",
+			want: -1,
+		},
+		{
+			name: "minor numerically",
+			v1:   "go1.9",
+			v2:   "go1.10",
+			want: -1,
+		},
+		{
+			name: "language version before release candidate",
+			v1:   "1.21",
+			v2:   "go1.21rc1",
+			want: -1,
+		},
+		{
+			name: "release candidate before release",
+			v1:   "go1.21rc2",
+			v2:   "go1.21.0",
+			want: -1,
+		},
+		{
+			name: "beta before release candidate",
+			v1:   "go1.21beta1",
+			v2:   "go1.21rc1",
+			want: -1,
+		},
+		{
+			name: "old language version equals .0 release",
+			v1:   "1.20",
+			v2:   "go1.20.0",
+			want: 0,
+		},
+		{
+			name: "major only equals 1.0.0",
+			v1:   "go1",
+			v2:   "1.0.0",
+			want: 0,
+		},
+		{
+			name: "prefix does not matter",
+			v1:   "1.22.3",
+			v2:   "go1.22.3",
+			want: 0,
+		},
+		{
+			name: "custom suffix does not matter",
+			v1:   "go1.22.3-bigcorp",
+			v2:   "go1.22.3",
+			want: 0,
+		},
+		{
+			name: "long numbers",
+			v1:   "go1.99999999999999999999",
+			v2:   "go2",
+			want: -1,
+		},
+	}
+
+	ecosystem := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v1, err := ecosystem.NewVersion(tt.v1)
+			if err != nil {
+				t.Fatalf("Failed to parse v1 %s: %v", tt.v1, err)
+			}
+			v2, err := ecosystem.NewVersion(tt.v2)
+			if err != nil {
+				t.Fatalf("Failed to parse v2 %s: %v", tt.v2, err)
+			}
+
+			if got := v1.Compare(v2); got != tt.want {
+				t.Errorf("Version.Compare() = %d, want %d", got, tt.want)
+			}
+			if got := v2.Compare(v1); got != -tt.want {
+				t.Errorf("Version.Compare() reversed = %d, want %d", got, -tt.want)
+			}
+		})
+	}
+}
+
+// TestVersion_Compare_GoVersion checks parsing and ordering against the
+// standard library's go/version package
+func TestVersion_Compare_GoVersion(t *testing.T) {
+	inputs := []string{
+		"go1", "go1.0", "go1.0.0", "go1.2", "go1.2.1", "go1.9", "go1.10",
+		"go1.20", "go1.20.0", "go1.20rc1", "go1.21", "go1.21alpha", "go1.21beta1",
+		"go1.21rc1", "go1.21rc2", "go1.21rc10", "go1.21.0", "go1.21.5",
+		"go1.21.5-bigcorp", "go1.22", "go2", "go2.0.0", "go1.21.3rc1",
+		"go1.021", "go1.", "go1.21.", "go1.21rc", "go1.21rc01", "go1.21RC1",
+		"go", "go1.21.0.1", "go01",
+	}
+
+	ecosystem := &Ecosystem{}
+	for _, x := range inputs {
+		vx, err := ecosystem.NewVersion(x)
+		if got, want := err == nil, version.IsValid(x); got != want {
+			t.Errorf("NewVersion(%q) valid = %v, go/version.IsValid = %v", x, got, want)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if got, want := vx.Lang(), version.Lang(x); "go"+got != want {
+			t.Errorf("Version.Lang() of %q = %q, go/version.Lang = %q", x, got, want)
+		}
+		for _, y := range inputs {
+			vy, err := ecosystem.NewVersion(y)
+			if err != nil {
+				continue
+			}
+			if got, want := vx.Compare(vy), version.Compare(x, y); got != want {
+				t.Errorf("Compare(%q, %q) = %d, go/version.Compare = %d", x, y, got, want)
+			}
+		}
+	}
+}
+
+func TestVersion_PrereleaseChannel(t *testing.T) {
+	tests := []struct {
+		version     string
+		wantChannel string
+		wantNumber  int
+		wantOK      bool
+	}{
+		{"go1.21.0", "", 0, false},
+		{"go1.21rc2", "rc", 2, true},
+		{"go1.20beta1", "beta", 1, true},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			v, err := e.NewVersion(tt.version)
+			if err != nil {
+				t.Fatalf("NewVersion(%q) error = %v", tt.version, err)
+			}
+			if got := v.PrereleaseChannel(); got != tt.wantChannel {
+				t.Errorf("PrereleaseChannel() = %q, want %q", got, tt.wantChannel)
+			}
+			number, ok := v.PrereleaseNumber()
+			if number != tt.wantNumber || ok != tt.wantOK {
+				t.Errorf("PrereleaseNumber() = %d, %v, want %d, %v", number, ok, tt.wantNumber, tt.wantOK)
+			}
+		})
+	}
+}
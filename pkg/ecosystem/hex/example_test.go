@@ -0,0 +1,61 @@
+package hex_test
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/hex"
+)
+
+// Versions follow SemVer: prereleases sort before the release.
+func ExampleVersion_Compare() {
+	e := &hex.Ecosystem{}
+
+	var versions []*hex.Version
+	for _, s := range []string{"1.10.0", "1.0.0", "1.0.0-rc.1", "1.2.3"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		versions = append(versions, v)
+	}
+	slices.SortFunc(versions, (*hex.Version).Compare)
+	fmt.Println(versions)
+	// Output:
+	// [1.0.0-rc.1 1.0.0 1.2.3 1.10.0]
+}
+
+// ~> with a patch version allows patch updates only.
+func ExampleVersionRange_Contains() {
+	e := &hex.Ecosystem{}
+
+	r, err := e.NewVersionRange("~> 1.2.3")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, s := range []string{"1.2.9", "1.3.0"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(s, r.Contains(v))
+	}
+	// Output:
+	// 1.2.9 true
+	// 1.3.0 false
+}
+
+func ExampleVersion_PrereleaseChannel() {
+	v, err := (&hex.Ecosystem{}).NewVersion("1.0.0-rc.2")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	n, ok := v.PrereleaseNumber()
+	fmt.Println(v.PrereleaseChannel(), n, ok)
+	// Output:
+	// rc 2 true
+}
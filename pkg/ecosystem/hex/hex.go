@@ -1,6 +1,8 @@
 package hex
 
-const Name = "hex"
+import "github.com/alowayed/go-univers/pkg/univers"
+
+const Name = string(univers.Hex)
 
 type Ecosystem struct{}
 
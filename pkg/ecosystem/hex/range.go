@@ -3,7 +3,10 @@ package hex
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
+
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 type VersionRange struct {
@@ -22,20 +25,41 @@ var (
 	constraintPattern = regexp.MustCompile(`^(>=|<=|>|<|=|~>)?(.+)$`)
 )
 
+// features declares the range syntax accepted by NewVersionRange
+var features = []univers.Feature{
+	{Name: univers.FeatureComparison, Syntax: ">=1.0.0 <2.0.0", Description: "Ordering operators >=, >, <=, < and ="},
+	{Name: univers.FeatureExact, Syntax: "1.2.3", Description: "A bare version matches only itself"},
+	{Name: univers.FeatureAnd, Syntax: ">=1.0.0 and <2.0.0", Description: "Space-separated constraints must all match; an optional \"and\" may join them"},
+	{Name: univers.FeaturePessimistic, Syntax: "~>1.2", Description: "Allows the last given component to increase"},
+}
+
+// Features returns the range syntax features supported by NewVersionRange
+func (e *Ecosystem) Features() []univers.Feature {
+	return slices.Clone(features)
+}
+
 func (e *Ecosystem) NewVersionRange(rangeStr string) (*VersionRange, error) {
+	if err := univers.CheckLength(rangeStr); err != nil {
+		return nil, err
+	}
+
 	if rangeStr == "" {
-		return nil, fmt.Errorf("range string cannot be empty")
+		return nil, univers.WrapParseError(rangeStr, fmt.Errorf("range string cannot be empty"))
 	}
 
 	// Trim leading and trailing whitespace
 	trimmed := strings.TrimSpace(rangeStr)
 	if trimmed == "" {
-		return nil, fmt.Errorf("range string cannot be empty or only whitespace")
+		return nil, univers.WrapParseError(rangeStr, fmt.Errorf("range string cannot be empty or only whitespace"))
 	}
 
 	// Parse constraints by splitting on spaces or "and" keywords
 	constraints, err := parseConstraints(trimmed, e)
 	if err != nil {
+		return nil, univers.WrapParseError(rangeStr, err)
+	}
+
+	if err := univers.CheckConstraints(len(constraints)); err != nil {
 		return nil, err
 	}
 
@@ -46,23 +70,24 @@ func (e *Ecosystem) NewVersionRange(rangeStr string) (*VersionRange, error) {
 }
 
 func parseConstraints(rangeStr string, ecosystem *Ecosystem) ([]*constraint, error) {
-	// Split by spaces and "and" keywords to handle multiple constraints
-	parts := strings.Fields(rangeStr)
-	if len(parts) == 0 {
+	// Split by spaces and "and" keywords to handle multiple constraints.
+	// Mix files usually put a space after the operator, as in "~> 1.2".
+	fields := univers.ConstraintFields(rangeStr, nil)
+	if len(fields) == 0 {
 		return nil, fmt.Errorf("no constraints found")
 	}
 
 	var constraints []*constraint
 
-	for _, part := range parts {
+	for _, f := range fields {
 		// Skip "and" keywords
-		if strings.ToLower(part) == "and" {
+		if strings.ToLower(f.Text) == "and" {
 			continue
 		}
 
-		constraint, err := parseConstraint(part, ecosystem)
+		constraint, err := parseConstraint(f.Text, ecosystem)
 		if err != nil {
-			return nil, err
+			return nil, univers.TokenErrorAt(rangeStr, f.Offset, f.Text, err)
 		}
 
 		// Handle pessimistic operator (~>) by converting to range
@@ -164,6 +189,15 @@ func (r *VersionRange) Contains(version *Version) bool {
 	return true
 }
 
+// Comparators returns the constraints as a single AND group
+func (r *VersionRange) Comparators() [][]univers.Comparator {
+	group := make([]univers.Comparator, 0, len(r.constraints))
+	for _, c := range r.constraints {
+		group = append(group, univers.Comparator{Operator: c.operator, Version: c.version.String()})
+	}
+	return [][]univers.Comparator{group}
+}
+
 func (r *VersionRange) String() string {
 	return r.original
 }
@@ -1,6 +1,7 @@
 package hex
 
 import (
+	"fmt"
 	"testing"
 )
 
@@ -115,6 +116,25 @@ func TestVersionRange_Contains(t *testing.T) {
 			version:  "1.0.1",
 			want:     false,
 		},
+		// Space after the operator, as written in mix.exs
+		{
+			name:     "pessimistic with space",
+			rangeStr: "~> 1.2",
+			version:  "1.2.5",
+			want:     true,
+		},
+		{
+			name:     "pessimistic with space - next major",
+			rangeStr: "~> 1.2",
+			version:  "2.0.0",
+			want:     false,
+		},
+		{
+			name:     "and with spaces after operators",
+			rangeStr: ">= 1.0.0 and < 2.0.0",
+			version:  "1.5.0",
+			want:     true,
+		},
 		// Greater than
 		{
 			name:     "greater than - true",
@@ -415,3 +435,37 @@ func TestVersionRange_String(t *testing.T) {
 		})
 	}
 }
+
+func TestEcosystem_Features(t *testing.T) {
+	e := &Ecosystem{}
+	for _, f := range e.Features() {
+		if _, err := e.NewVersionRange(f.Syntax); err != nil {
+			t.Errorf("Features() %s syntax %q does not parse: %v", f.Name, f.Syntax, err)
+		}
+	}
+}
+
+func TestVersionRange_Comparators(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		want     string
+	}{
+		{name: "pessimistic", rangeStr: "~>1.2.3", want: "[[>=1.2.3 <1.3.0]]"},
+		{name: "pessimistic minor", rangeStr: "~>1.2", want: "[[>=1.2 <1.3.0]]"},
+		{name: "comparisons", rangeStr: ">=1.0.0 and <2.0.0", want: "[[>=1.0.0 <2.0.0]]"},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vr, err := e.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("Ecosystem.NewVersionRange() error = %v", err)
+			}
+			if got := fmt.Sprint(vr.Comparators()); got != tt.want {
+				t.Errorf("VersionRange.Comparators() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
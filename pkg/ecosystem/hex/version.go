@@ -5,6 +5,9 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/alowayed/go-univers/internal/digits"
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 type Version struct {
@@ -26,6 +29,10 @@ var (
 )
 
 func (e *Ecosystem) NewVersion(version string) (*Version, error) {
+	if err := univers.CheckLength(version); err != nil {
+		return nil, err
+	}
+
 	if version == "" {
 		return nil, fmt.Errorf("version string cannot be empty")
 	}
@@ -51,6 +58,42 @@ func (e *Ecosystem) NewVersion(version string) (*Version, error) {
 	return nil, fmt.Errorf("invalid Hex version format: %s", trimmed)
 }
 
+// ValidVersion reports whether NewVersion accepts version, full or partial,
+// without allocating.
+func ValidVersion(version string) bool {
+	if univers.CheckLength(version) != nil {
+		return false
+	}
+	trimmed := strings.TrimSpace(version)
+	full := hexVersionPattern.MatchString(trimmed)
+	if !full && !hexPartialVersionPattern.MatchString(trimmed) {
+		return false
+	}
+
+	numbers := 2
+	if full {
+		numbers = 3
+	}
+	rest := trimmed
+	for i := range numbers {
+		n := digits.Prefix(rest)
+		if !digits.FitsInt(rest[:n]) {
+			return false
+		}
+		rest = rest[n:]
+		if i < numbers-1 {
+			rest = rest[1:]
+		}
+	}
+
+	// Pre-release identifiers, between "-" and "+", cannot be empty
+	if prerelease, ok := strings.CutPrefix(rest, "-"); ok {
+		prerelease, _, _ = strings.Cut(prerelease, "+")
+		return !strings.HasPrefix(prerelease, ".") && !strings.HasSuffix(prerelease, ".") && !strings.Contains(prerelease, "..")
+	}
+	return true
+}
+
 func parseSemanticVersion(original string, matches []string) (*Version, error) {
 	// Parse major version
 	major, err := strconv.Atoi(matches[1])
@@ -141,6 +184,20 @@ func (v *Version) String() string {
 	return v.original
 }
 
+// PrereleaseChannel returns the normalized channel of the pre-release, such as
+// "rc" for 1.15.0-rc.2, or "" for a release. See univers.SplitPrerelease.
+func (v *Version) PrereleaseChannel() string {
+	channel, _, _ := univers.SplitPrerelease(strings.Join(v.preRelease, "."))
+	return channel
+}
+
+// PrereleaseNumber returns the number after the pre-release channel, such as
+// 2 for 1.15.0-rc.2, and whether there is one.
+func (v *Version) PrereleaseNumber() (int, bool) {
+	_, number, ok := univers.SplitPrerelease(strings.Join(v.preRelease, "."))
+	return number, ok
+}
+
 // comparePreRelease compares pre-release versions following SemVer 2.0 rules
 func comparePreRelease(pr1, pr2 []string) int {
 	// No pre-release (release) is higher than any pre-release
@@ -0,0 +1,65 @@
+package luarocks_test
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/luarocks"
+)
+
+// The rockspec revision after the dash breaks ties.
+func ExampleVersion_Compare() {
+	e := &luarocks.Ecosystem{}
+
+	var versions []*luarocks.Version
+	for _, s := range []string{"1.0-2", "1.10-1", "1.0-1", "1.2-1"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		versions = append(versions, v)
+	}
+	slices.SortFunc(versions, (*luarocks.Version).Compare)
+	fmt.Println(versions)
+	// Output:
+	// [1.0-1 1.0-2 1.2-1 1.10-1]
+}
+
+// Ranges are comparators separated by commas, as in rockspec dependencies.
+func ExampleVersionRange_Contains() {
+	e := &luarocks.Ecosystem{}
+
+	r, err := e.NewVersionRange(">= 1.0, < 2.0")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, s := range []string{"1.9-1", "2.0-1"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(s, r.Contains(v))
+	}
+	// Output:
+	// 1.9-1 true
+	// 2.0-1 false
+}
+
+func ExampleVersion_Revision() {
+	e := &luarocks.Ecosystem{}
+
+	for _, s := range []string{"3.9.2-1", "3.9.2"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(v.Revision())
+	}
+	// Output:
+	// 1 true
+	// 0 false
+}
@@ -0,0 +1,14 @@
+// Package luarocks provides functionality for working with Lua LuaRocks versions.
+package luarocks
+
+import "github.com/alowayed/go-univers/pkg/univers"
+
+const (
+	Name = string(univers.LuaRocks)
+)
+
+type Ecosystem struct{}
+
+func (e *Ecosystem) Name() string {
+	return Name
+}
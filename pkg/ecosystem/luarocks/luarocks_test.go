@@ -0,0 +1,15 @@
+package luarocks
+
+import (
+	"testing"
+)
+
+func TestEcosystem_Name(t *testing.T) {
+	ecosystem := &Ecosystem{}
+	want := "luarocks"
+
+	got := ecosystem.Name()
+	if got != want {
+		t.Errorf("Ecosystem.Name() = %q, want %q", got, want)
+	}
+}
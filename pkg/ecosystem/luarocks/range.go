@@ -0,0 +1,211 @@
+package luarocks
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+// VersionRange represents a LuaRocks dependency constraint such as ">= 1.0, < 2.0"
+type VersionRange struct {
+	constraints []*constraint
+	original    string
+}
+
+// constraint represents a single LuaRocks version constraint
+type constraint struct {
+	operator string
+	version  *Version
+}
+
+// operatorAliases maps accepted operator spellings to their canonical form
+var operatorAliases = map[string]string{
+	"":   "==",
+	"=":  "==",
+	"==": "==",
+	"~=": "~=",
+	"!=": "~=",
+	"<":  "<",
+	"<=": "<=",
+	">":  ">",
+	">=": ">=",
+	"~>": "~>",
+}
+
+// features declares the range syntax accepted by NewVersionRange
+var features = []univers.Feature{
+	{Name: univers.FeatureComparison, Syntax: ">= 1.0", Description: "Ordering operators >=, >, <=, < and =="},
+	{Name: univers.FeatureNotEqual, Syntax: "~= 1.0.1", Description: "Excludes a single version, also spelled !="},
+	{Name: univers.FeatureExact, Syntax: "1.0-1", Description: "A bare version matches only itself"},
+	{Name: univers.FeaturePessimistic, Syntax: "~> 1.2", Description: "Matches versions that start with the given components"},
+	{Name: univers.FeatureAnd, Syntax: ">= 1.0, < 2.0", Description: "Comma- or space-separated constraints must all match"},
+}
+
+// Features returns the range syntax features supported by NewVersionRange
+func (e *Ecosystem) Features() []univers.Feature {
+	return slices.Clone(features)
+}
+
+// NewVersionRange creates a new LuaRocks version range from a range string
+func (e *Ecosystem) NewVersionRange(rangeStr string) (*VersionRange, error) {
+	if err := univers.CheckLength(rangeStr); err != nil {
+		return nil, err
+	}
+
+	original := rangeStr
+	rangeStr = strings.TrimSpace(rangeStr)
+	if rangeStr == "" {
+		return nil, univers.WrapParseError(original, fmt.Errorf("empty range string"))
+	}
+
+	constraints, err := e.parseConstraints(rangeStr)
+	if err != nil {
+		return nil, univers.WrapParseError(original, err)
+	}
+
+	if err := univers.CheckConstraints(len(constraints)); err != nil {
+		return nil, err
+	}
+
+	return &VersionRange{
+		constraints: constraints,
+		original:    original,
+	}, nil
+}
+
+// parseConstraints scans operator and version pairs separated by commas or
+// whitespace, following LuaRocks' parse_constraints
+func (e *Ecosystem) parseConstraints(rangeStr string) ([]*constraint, error) {
+	var constraints []*constraint
+
+	pos := 0
+	for {
+		pos += prefixLen(rangeStr[pos:], isConstraintSeparator)
+		if pos == len(rangeStr) {
+			break
+		}
+		start := pos
+
+		opLen := prefixLen(rangeStr[pos:], isOperatorChar)
+		op := rangeStr[pos : pos+opLen]
+		pos += opLen
+		pos += prefixLen(rangeStr[pos:], isSpace)
+		verLen := prefixLen(rangeStr[pos:], isVersionChar)
+		versionStr := rangeStr[pos : pos+verLen]
+		pos += verLen
+		token := rangeStr[start:pos]
+
+		operator, ok := operatorAliases[op]
+		if !ok {
+			return nil, univers.TokenErrorAt(rangeStr, start, op, fmt.Errorf("unknown operator %q", op))
+		}
+		if versionStr == "" {
+			if token == "" {
+				token = rangeStr[start:]
+			}
+			return nil, univers.TokenErrorAt(rangeStr, start, token, fmt.Errorf("constraint %s requires version", operator))
+		}
+		version, err := e.NewVersion(versionStr)
+		if err != nil {
+			return nil, univers.TokenErrorAt(rangeStr, start, token, fmt.Errorf("invalid version in constraint %s: %w", token, err))
+		}
+
+		constraints = append(constraints, &constraint{operator: operator, version: version})
+	}
+
+	return constraints, nil
+}
+
+func isConstraintSeparator(c byte) bool {
+	return c == ',' || isSpace(c)
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t'
+}
+
+func isOperatorChar(c byte) bool {
+	return strings.IndexByte("<>=~!", c) >= 0
+}
+
+func isVersionChar(c byte) bool {
+	return isDigit(c) || isLetter(c) || isSeparator(c)
+}
+
+// String returns the string representation of the version range
+func (vr *VersionRange) String() string {
+	return vr.original
+}
+
+// Contains checks if a version satisfies this range
+func (vr *VersionRange) Contains(version *Version) bool {
+	for _, c := range vr.constraints {
+		if !c.matches(version) {
+			return false
+		}
+	}
+	return true
+}
+
+// Comparators returns the constraints as a single AND group, with "==" spelled
+// "=" and "~=" spelled "!=". The partial match "~>" has no comparator
+// equivalent and is listed as is.
+func (vr *VersionRange) Comparators() [][]univers.Comparator {
+	group := make([]univers.Comparator, 0, len(vr.constraints))
+	for _, c := range vr.constraints {
+		op := c.operator
+		switch op {
+		case "==":
+			op = "="
+		case "~=":
+			op = "!="
+		}
+		group = append(group, univers.Comparator{Operator: op, Version: c.version.String()})
+	}
+	return [][]univers.Comparator{group}
+}
+
+// matches checks if a version satisfies the constraint
+func (c *constraint) matches(version *Version) bool {
+	if c.operator == "~>" {
+		return partialMatch(version, c.version)
+	}
+
+	cmp := version.Compare(c.version)
+	switch c.operator {
+	case "==":
+		return cmp == 0
+	case "~=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	default:
+		return false
+	}
+}
+
+// partialMatch reports whether version starts with every component of
+// requested, and has the same revision when requested has one
+func partialMatch(version, requested *Version) bool {
+	for i, rc := range requested.components {
+		var vc component
+		if i < len(version.components) {
+			vc = version.components[i]
+		}
+		if vc != rc {
+			return false
+		}
+	}
+	if requested.hasRev {
+		return version.hasRev && version.revision == requested.revision
+	}
+	return true
+}
@@ -0,0 +1,226 @@
+package luarocks
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+func TestEcosystem_NewVersionRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		// Valid ranges
+		{
+			name:  "bare version",
+			input: "1.0-1",
+		},
+		{
+			name:  "comma separated",
+			input: ">= 1.0, < 2.0",
+		},
+		{
+			name:  "space separated",
+			input: ">=1.0 <2.0",
+		},
+		{
+			name:  "pessimistic",
+			input: "~> 1.2",
+		},
+		{
+			name:  "lua not equal",
+			input: "~= 1.0",
+		},
+		{
+			name:  "bang not equal",
+			input: "!= 1.0",
+		},
+		{
+			name:  "single equals",
+			input: "= 1.0",
+		},
+		// Error cases
+		{
+			name:    "empty string",
+			input:   "",
+			wantErr: true,
+		},
+		{
+			name:    "unknown operator",
+			input:   "=> 1.0",
+			wantErr: true,
+		},
+		{
+			name:    "operator without version",
+			input:   ">= 1.0, <",
+			wantErr: true,
+		},
+		{
+			name:    "invalid character",
+			input:   ">= 1.0 @",
+			wantErr: true,
+		},
+	}
+
+	ecosystem := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ecosystem.NewVersionRange(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Ecosystem.NewVersionRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEcosystem_NewVersionRange_ParseError(t *testing.T) {
+	ecosystem := &Ecosystem{}
+	_, err := ecosystem.NewVersionRange(">= 1.0, => 2.0")
+
+	var pe *univers.ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("NewVersionRange() error = %v, want *univers.ParseError", err)
+	}
+	if pe.Token != "=>" || pe.Pos != 8 {
+		t.Errorf("ParseError token = %q pos = %d, want %q pos 8", pe.Token, pe.Pos, "=>")
+	}
+}
+
+func TestVersionRange_Contains(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		version  string
+		want     bool
+	}{
+		{
+			name:     "bare version matches any revision",
+			rangeStr: "1.0",
+			version:  "1.0-3",
+			want:     true,
+		},
+		{
+			name:     "bare version with revision",
+			rangeStr: "1.0-1",
+			version:  "1.0-2",
+			want:     false,
+		},
+		{
+			name:     "inside bounds",
+			rangeStr: ">= 1.0, < 2.0",
+			version:  "1.5-1",
+			want:     true,
+		},
+		{
+			name:     "upper bound excludes release",
+			rangeStr: ">= 1.0, < 2.0",
+			version:  "2.0-1",
+			want:     false,
+		},
+		{
+			name:     "upper bound includes release candidate",
+			rangeStr: ">= 1.0, < 2.0",
+			version:  "2.0rc1-1",
+			want:     true,
+		},
+		{
+			name:     "scm satisfies lower bound",
+			rangeStr: ">= 3.0",
+			version:  "scm-1",
+			want:     true,
+		},
+		{
+			name:     "pessimistic matches prefix",
+			rangeStr: "~> 1.2",
+			version:  "1.2.9-1",
+			want:     true,
+		},
+		{
+			name:     "pessimistic rejects next minor",
+			rangeStr: "~> 1.2",
+			version:  "1.3-1",
+			want:     false,
+		},
+		{
+			name:     "pessimistic with revision",
+			rangeStr: "~> 1.2-1",
+			version:  "1.2-2",
+			want:     false,
+		},
+		{
+			name:     "not equal",
+			rangeStr: ">= 1.0 ~= 1.1",
+			version:  "1.1",
+			want:     false,
+		},
+		{
+			name:     "greater than",
+			rangeStr: "> 1.0",
+			version:  "1.0.1",
+			want:     true,
+		},
+		{
+			name:     "less than or equal",
+			rangeStr: "<= 1.0",
+			version:  "1.0.0-5",
+			want:     true,
+		},
+	}
+
+	ecosystem := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vr, err := ecosystem.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("Failed to parse range %s: %v", tt.rangeStr, err)
+			}
+
+			v, err := ecosystem.NewVersion(tt.version)
+			if err != nil {
+				t.Fatalf("Failed to parse version %s: %v", tt.version, err)
+			}
+
+			got := vr.Contains(v)
+			if got != tt.want {
+				t.Errorf("VersionRange.Contains() = %v, want %v (range=%s, version=%s)", got, tt.want, tt.rangeStr, tt.version)
+			}
+		})
+	}
+}
+
+func TestEcosystem_Features(t *testing.T) {
+	e := &Ecosystem{}
+	for _, f := range e.Features() {
+		if _, err := e.NewVersionRange(f.Syntax); err != nil {
+			t.Errorf("Features() %s syntax %q does not parse: %v", f.Name, f.Syntax, err)
+		}
+	}
+}
+
+func TestVersionRange_Comparators(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		want     string
+	}{
+		{name: "aliases", rangeStr: "== 1.0, ~= 1.1", want: "[[=1.0 !=1.1]]"},
+		{name: "partial", rangeStr: "~> 1.2", want: "[[~>1.2]]"},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vr, err := e.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("Ecosystem.NewVersionRange() error = %v", err)
+			}
+			if got := fmt.Sprint(vr.Comparators()); got != tt.want {
+				t.Errorf("VersionRange.Comparators() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
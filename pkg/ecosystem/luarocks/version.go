@@ -0,0 +1,242 @@
+package luarocks
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/alowayed/go-univers/internal/digits"
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+// wordDeltas are the values LuaRocks gives to known words in a version. Words
+// that are not listed count as their first byte divided by 1000.
+var wordDeltas = map[string]int64{
+	"dev":   120000000,
+	"scm":   110000000,
+	"cvs":   100000000,
+	"rc":    -1000,
+	"pre":   -10000,
+	"beta":  -100000,
+	"alpha": -1000000,
+}
+
+// maxNumber bounds numeric components so that they fit a component value
+const maxNumber = 1 << 50
+
+// Version represents a LuaRocks version such as 1.0.0-1, where the number
+// after the last dash is the rockspec revision.
+type Version struct {
+	components []component
+	revision   int
+	hasRev     bool
+	original   string
+}
+
+// component is one element of a parsed version. LuaRocks stores components
+// as floats: a number n is n, and a word followed by a number n is the word's
+// delta plus n/100000. value holds thousandths so that word bytes divided by
+// 1000 stay exact, and sub holds the number following a word.
+type component struct {
+	value int64
+	sub   int64
+}
+
+// NewVersion creates a new LuaRocks version from a string
+func (e *Ecosystem) NewVersion(version string) (*Version, error) {
+	if err := univers.CheckLength(version); err != nil {
+		return nil, err
+	}
+
+	original := version
+	version = strings.TrimSpace(version)
+
+	if version == "" {
+		return nil, fmt.Errorf("invalid LuaRocks version: empty string")
+	}
+
+	v := &Version{original: original}
+
+	// Store the revision separately if any
+	if i := strings.LastIndex(version, "-"); i >= 0 && isDigits(version[i+1:]) {
+		rev, err := strconv.Atoi(version[i+1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid LuaRocks version: %s (revision too large)", original)
+		}
+		v.revision = rev
+		v.hasRev = true
+		version = version[:i]
+	}
+	if version == "" {
+		return nil, fmt.Errorf("invalid LuaRocks version: %s (missing version before revision)", original)
+	}
+
+	components, err := parseComponents(version)
+	if err != nil {
+		return nil, fmt.Errorf("invalid LuaRocks version: %s (%w)", original, err)
+	}
+	v.components = components
+
+	return v, nil
+}
+
+// ValidVersion reports whether NewVersion accepts version, walking the
+// components in place instead of collecting them, so it does not allocate.
+func ValidVersion(version string) bool {
+	if univers.CheckLength(version) != nil {
+		return false
+	}
+	version = strings.TrimSpace(version)
+	if version == "" {
+		return false
+	}
+
+	if i := strings.LastIndex(version, "-"); i >= 0 && isDigits(version[i+1:]) {
+		if !digits.FitsInt(version[i+1:]) {
+			return false
+		}
+		version = version[:i]
+	}
+	if version == "" {
+		return false
+	}
+
+	// Components are runs of digits or letters, each optionally followed
+	// by separators
+	for version != "" {
+		n := prefixLen(version, isDigit)
+		if n > 0 {
+			if !digits.AtMost(version[:n], maxNumberDigits) {
+				return false
+			}
+		} else if n = prefixLen(version, isLetter); n == 0 {
+			return false
+		}
+		version = version[n:]
+		version = version[prefixLen(version, isSeparator):]
+	}
+	return true
+}
+
+// maxNumberDigits is maxNumber in decimal, the largest numeric component
+var maxNumberDigits = strconv.Itoa(maxNumber)
+
+// parseComponents splits a version without revision into numbers and words
+// separated by '.', '-' or '_', following LuaRocks' parse_version
+func parseComponents(s string) ([]component, error) {
+	var components []component
+	i := 0
+	for len(s) > 0 {
+		n := prefixLen(s, isDigit)
+		if n > 0 {
+			num, err := strconv.ParseInt(s[:n], 10, 64)
+			if err != nil || num > maxNumber {
+				return nil, fmt.Errorf("component too large: %s", s[:n])
+			}
+			if i < len(components) {
+				components[i].sub = num
+			} else {
+				components = append(components, component{value: num * 1000})
+			}
+			i++
+		} else {
+			n = prefixLen(s, isLetter)
+			if n == 0 {
+				return nil, fmt.Errorf("unexpected character %q", s[0])
+			}
+			word := s[:n]
+			value := int64(word[0])
+			if delta, ok := wordDeltas[word]; ok {
+				value = delta * 1000
+			}
+			if i < len(components) {
+				components[i] = component{value: value}
+			} else {
+				components = append(components, component{value: value})
+			}
+		}
+		s = s[n:]
+		s = s[prefixLen(s, isSeparator):]
+	}
+	return components, nil
+}
+
+// prefixLen returns the length of the longest prefix of s whose bytes satisfy f
+func prefixLen(s string, f func(byte) bool) int {
+	n := 0
+	for n < len(s) && f(s[n]) {
+		n++
+	}
+	return n
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isSeparator(c byte) bool {
+	return c == '.' || c == '-' || c == '_'
+}
+
+// isDigits reports whether s is a non-empty string of ASCII digits
+func isDigits(s string) bool {
+	return s != "" && prefixLen(s, isDigit) == len(s)
+}
+
+// String returns the string representation of the version
+func (v *Version) String() string {
+	return v.original
+}
+
+// Revision returns the rockspec revision and whether the version has one
+func (v *Version) Revision() (int, bool) {
+	return v.revision, v.hasRev
+}
+
+// Compare compares this version with another LuaRocks version. Missing
+// components count as zero. Revisions break ties only when both versions
+// have one, so 1.0 equals both 1.0-1 and 1.0-2.
+func (v *Version) Compare(other *Version) int {
+	if c := compareComponents(v.components, other.components); c != 0 {
+		return c
+	}
+	if v.hasRev && other.hasRev {
+		return compareInt(int64(v.revision), int64(other.revision))
+	}
+	return 0
+}
+
+// compareComponents compares component lists, padding the shorter with zeros
+func compareComponents(a, b []component) int {
+	for i := 0; i < max(len(a), len(b)); i++ {
+		var ca, cb component
+		if i < len(a) {
+			ca = a[i]
+		}
+		if i < len(b) {
+			cb = b[i]
+		}
+		if c := compareInt(ca.value, cb.value); c != 0 {
+			return c
+		}
+		if c := compareInt(ca.sub, cb.sub); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// compareInt returns -1 if a < b, 0 if a == b, 1 if a > b
+func compareInt(a, b int64) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,220 @@
+package luarocks
+
+import (
+	"testing"
+)
+
+func TestEcosystem_NewVersion(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		wantRevision int
+		wantHasRev   bool
+		wantErr      bool
+	}{
+		// Valid versions
+		{
+			name:  "plain version",
+			input: "1.0.0",
+		},
+		{
+			name:         "version with revision",
+			input:        "1.0.0-1",
+			wantRevision: 1,
+			wantHasRev:   true,
+		},
+		{
+			name:         "scm pseudo-version",
+			input:        "scm-1",
+			wantRevision: 1,
+			wantHasRev:   true,
+		},
+		{
+			name:         "dev pseudo-version",
+			input:        "dev-2",
+			wantRevision: 2,
+			wantHasRev:   true,
+		},
+		{
+			name:  "prerelease word",
+			input: "2.0rc1",
+		},
+		{
+			name:         "underscore separator",
+			input:        "5.4_2-1",
+			wantRevision: 1,
+			wantHasRev:   true,
+		},
+		{
+			name:  "whitespace",
+			input: "  1.2  ",
+		},
+		// Error cases
+		{
+			name:    "empty string",
+			input:   "",
+			wantErr: true,
+		},
+		{
+			name:    "revision only",
+			input:   "-1",
+			wantErr: true,
+		},
+		{
+			name:    "plus sign",
+			input:   "1.0+build",
+			wantErr: true,
+		},
+		{
+			name:    "inner whitespace",
+			input:   "1.0 2",
+			wantErr: true,
+		},
+		{
+			name:    "component too large",
+			input:   "99999999999999999999",
+			wantErr: true,
+		},
+	}
+
+	ecosystem := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ecosystem.NewVersion(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Ecosystem.NewVersion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+
+			if got.String() != tt.input {
+				t.Errorf("Version.String() = %q, want %q", got.String(), tt.input)
+			}
+			rev, hasRev := got.Revision()
+			if rev != tt.wantRevision || hasRev != tt.wantHasRev {
+				t.Errorf("Version.Revision() = %d, %v, want %d, %v", rev, hasRev, tt.wantRevision, tt.wantHasRev)
+			}
+		})
+	}
+}
+
+func TestVersion_Compare(t *testing.T) {
+	tests := []struct {
+		name string
+		v1   string
+		v2   string
+		want int
+	}{
+		{
+			name: "equal",
+			v1:   "1.0.0-1",
+			v2:   "1.0.0-1",
+			want: 0,
+		},
+		{
+			name: "missing components are zero",
+			v1:   "1.0",
+			v2:   "1.0.0",
+			want: 0,
+		},
+		{
+			name: "numeric components",
+			v1:   "1.10",
+			v2:   "1.9",
+			want: 1,
+		},
+		{
+			name: "revision breaks ties",
+			v1:   "1.0.0-2",
+			v2:   "1.0.0-1",
+			want: 1,
+		},
+		{
+			name: "version before revision",
+			v1:   "1.0.1-1",
+			v2:   "1.0.0-10",
+			want: 1,
+		},
+		{
+			name: "revision ignored when one side has none",
+			v1:   "1.0.0",
+			v2:   "1.0.0-3",
+			want: 0,
+		},
+		{
+			name: "scm above releases",
+			v1:   "scm-1",
+			v2:   "99.0-1",
+			want: 1,
+		},
+		{
+			name: "dev above scm",
+			v1:   "dev-1",
+			v2:   "scm-1",
+			want: 1,
+		},
+		{
+			name: "release candidate below release",
+			v1:   "2.0rc1",
+			v2:   "2.0",
+			want: -1,
+		},
+		{
+			name: "release candidates ordered",
+			v1:   "2.0rc2",
+			v2:   "2.0rc1",
+			want: 1,
+		},
+		{
+			name: "beta below release candidate",
+			v1:   "2.0beta",
+			v2:   "2.0rc",
+			want: -1,
+		},
+		{
+			name: "alpha below beta",
+			v1:   "2.0alpha3",
+			v2:   "2.0beta1",
+			want: -1,
+		},
+		{
+			name: "prerelease above previous release",
+			v1:   "2.0alpha1",
+			v2:   "1.9",
+			want: 1,
+		},
+		{
+			name: "separators are interchangeable",
+			v1:   "5.4_2",
+			v2:   "5.4.2",
+			want: 0,
+		},
+	}
+
+	ecosystem := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v1, err := ecosystem.NewVersion(tt.v1)
+			if err != nil {
+				t.Fatalf("Failed to parse v1 %s: %v", tt.v1, err)
+			}
+
+			v2, err := ecosystem.NewVersion(tt.v2)
+			if err != nil {
+				t.Fatalf("Failed to parse v2 %s: %v", tt.v2, err)
+			}
+
+			got := v1.Compare(v2)
+			if got != tt.want {
+				t.Errorf("Version.Compare() = %v, want %v (v1=%s, v2=%s)", got, tt.want, tt.v1, tt.v2)
+			}
+
+			reverse := v2.Compare(v1)
+			if reverse != -tt.want {
+				t.Errorf("Reverse comparison failed: v2.Compare(v1) = %v, want %v", reverse, -tt.want)
+			}
+		})
+	}
+}
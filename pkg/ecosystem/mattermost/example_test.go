@@ -0,0 +1,49 @@
+package mattermost_test
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/mattermost"
+)
+
+// Server versions carry a v prefix and compare numerically.
+func ExampleVersion_Compare() {
+	e := &mattermost.Ecosystem{}
+
+	var versions []*mattermost.Version
+	for _, s := range []string{"v10.0.0", "v9.11.0", "v9.5.1"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		versions = append(versions, v)
+	}
+	slices.SortFunc(versions, (*mattermost.Version).Compare)
+	fmt.Println(versions)
+	// Output:
+	// [v9.5.1 v9.11.0 v10.0.0]
+}
+
+// Ranges are comparators separated by spaces.
+func ExampleVersionRange_Contains() {
+	e := &mattermost.Ecosystem{}
+
+	r, err := e.NewVersionRange(">=v9.0.0 <v9.6.0")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, s := range []string{"v9.5.1", "v9.11.0"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(s, r.Contains(v))
+	}
+	// Output:
+	// v9.5.1 true
+	// v9.11.0 false
+}
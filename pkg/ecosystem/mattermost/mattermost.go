@@ -1,6 +1,8 @@
 package mattermost
 
-const Name = "mattermost"
+import "github.com/alowayed/go-univers/pkg/univers"
+
+const Name = string(univers.Mattermost)
 
 type Ecosystem struct{}
 
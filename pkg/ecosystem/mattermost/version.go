@@ -5,6 +5,9 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/alowayed/go-univers/internal/digits"
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 type Version struct {
@@ -33,6 +36,10 @@ var (
 )
 
 func (e *Ecosystem) NewVersion(version string) (*Version, error) {
+	if err := univers.CheckLength(version); err != nil {
+		return nil, err
+	}
+
 	if version == "" {
 		return nil, fmt.Errorf("version string cannot be empty")
 	}
@@ -52,6 +59,30 @@ func (e *Ecosystem) NewVersion(version string) (*Version, error) {
 	return parseSemanticVersion(trimmed, matches)
 }
 
+// ValidVersion reports whether version is a well-formed Mattermost version,
+// exactly when NewVersion would succeed, without allocating.
+func ValidVersion(version string) bool {
+	if univers.CheckLength(version) != nil {
+		return false
+	}
+	trimmed := strings.TrimSpace(version)
+	if !mattermostVersionPattern.MatchString(trimmed) {
+		return false
+	}
+
+	// The pattern guarantees v?X.Y.Z with an optional -esrN or -rcN, so
+	// only the numbers are left to check against the int range
+	rest := strings.TrimPrefix(trimmed, "v")
+	for range 3 {
+		n := digits.Prefix(rest)
+		if !digits.FitsInt(rest[:n]) {
+			return false
+		}
+		rest = rest[min(n+1, len(rest)):]
+	}
+	return digits.FitsInt(strings.TrimLeft(rest, "ecrs"))
+}
+
 func parseSemanticVersion(original string, matches []string) (*Version, error) {
 	prefix := matches[1]
 
@@ -0,0 +1,145 @@
+package maven
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RangeBuilder composes a Maven version range from bounds, emitting the
+// bracket syntax so callers never format it by hand:
+//
+//	r, err := maven.NewRangeBuilder().GTE("1.0").LT("2.0").Build() // [1.0,2.0)
+//
+// Errors from any step are reported by Build.
+type RangeBuilder struct {
+	lower *builderBound
+	upper *builderBound
+	err   error
+}
+
+// builderBound is one end of the range being built
+type builderBound struct {
+	version   string
+	inclusive bool
+}
+
+// NewRangeBuilder returns a builder for a range with no bounds
+func NewRangeBuilder() *RangeBuilder {
+	return &RangeBuilder{}
+}
+
+// GT sets an exclusive lower bound
+func (b *RangeBuilder) GT(version string) *RangeBuilder {
+	return b.setLower(version, false)
+}
+
+// GTE sets an inclusive lower bound
+func (b *RangeBuilder) GTE(version string) *RangeBuilder {
+	return b.setLower(version, true)
+}
+
+// LT sets an exclusive upper bound
+func (b *RangeBuilder) LT(version string) *RangeBuilder {
+	return b.setUpper(version, false)
+}
+
+// LTE sets an inclusive upper bound
+func (b *RangeBuilder) LTE(version string) *RangeBuilder {
+	return b.setUpper(version, true)
+}
+
+// EQ restricts the range to exactly one version
+func (b *RangeBuilder) EQ(version string) *RangeBuilder {
+	return b.setLower(version, true).setUpper(version, true)
+}
+
+// setLower records the lower bound, failing if one is already set
+func (b *RangeBuilder) setLower(version string, inclusive bool) *RangeBuilder {
+	if b.lower != nil {
+		b.fail(fmt.Errorf("lower bound already set to %s", b.lower.version))
+		return b
+	}
+	b.lower = b.bound(version, inclusive)
+	return b
+}
+
+// setUpper records the upper bound, failing if one is already set
+func (b *RangeBuilder) setUpper(version string, inclusive bool) *RangeBuilder {
+	if b.upper != nil {
+		b.fail(fmt.Errorf("upper bound already set to %s", b.upper.version))
+		return b
+	}
+	b.upper = b.bound(version, inclusive)
+	return b
+}
+
+// bound validates a version for use as a bound. Characters that are part of
+// the range syntax are rejected rather than escaped, since Maven has no
+// escaping.
+func (b *RangeBuilder) bound(version string, inclusive bool) *builderBound {
+	version = strings.TrimSpace(version)
+	if strings.ContainsAny(version, "[](),") {
+		b.fail(fmt.Errorf("version %q contains range syntax characters", version))
+	}
+	return &builderBound{version: version, inclusive: inclusive}
+}
+
+// fail records the first error encountered while building
+func (b *RangeBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// String returns the range in Maven bracket syntax, without validating it
+func (b *RangeBuilder) String() string {
+	if b.lower != nil && b.upper != nil && b.lower.version == b.upper.version &&
+		b.lower.inclusive && b.upper.inclusive {
+		return "[" + b.lower.version + "]"
+	}
+
+	lowerBracket, upperBracket := "(", ")"
+	var lower, upper string
+	if b.lower != nil {
+		lower = b.lower.version
+		if b.lower.inclusive {
+			lowerBracket = "["
+		}
+	}
+	if b.upper != nil {
+		upper = b.upper.version
+		if b.upper.inclusive {
+			upperBracket = "]"
+		}
+	}
+	return lowerBracket + lower + "," + upper + upperBracket
+}
+
+// Build validates the bounds and returns the range. It fails if no bound was
+// set, a version is invalid, or the bounds admit no version.
+func (b *RangeBuilder) Build() (*VersionRange, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if b.lower == nil && b.upper == nil {
+		return nil, fmt.Errorf("range has no bounds")
+	}
+
+	e := &Ecosystem{}
+	if b.lower != nil && b.upper != nil {
+		lower, err := e.NewVersion(b.lower.version)
+		if err != nil {
+			return nil, fmt.Errorf("invalid lower bound: %w", err)
+		}
+		upper, err := e.NewVersion(b.upper.version)
+		if err != nil {
+			return nil, fmt.Errorf("invalid upper bound: %w", err)
+		}
+		cmp := lower.Compare(upper)
+		if cmp > 0 || (cmp == 0 && !(b.lower.inclusive && b.upper.inclusive)) {
+			return nil, fmt.Errorf("empty range: lower bound %s is not below upper bound %s", b.lower.version, b.upper.version)
+		}
+	}
+
+	return e.NewVersionRange(b.String())
+}
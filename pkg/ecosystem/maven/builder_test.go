@@ -0,0 +1,117 @@
+package maven
+
+import "testing"
+
+func TestRangeBuilder_Build(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func() *RangeBuilder
+		want    string
+		in      []string
+		out     []string
+		wantErr bool
+	}{
+		{
+			name:  "half-open interval",
+			build: func() *RangeBuilder { return NewRangeBuilder().GTE("1.0").LT("2.0") },
+			want:  "[1.0,2.0)",
+			in:    []string{"1.0", "1.5", "2.0-SNAPSHOT"},
+			out:   []string{"0.9", "2.0"},
+		},
+		{
+			name:  "exclusive lower inclusive upper",
+			build: func() *RangeBuilder { return NewRangeBuilder().LTE("2.0").GT("1.0") },
+			want:  "(1.0,2.0]",
+			in:    []string{"1.0.1", "2.0"},
+			out:   []string{"1.0", "2.1"},
+		},
+		{
+			name:  "lower bound only",
+			build: func() *RangeBuilder { return NewRangeBuilder().GTE("1.5") },
+			want:  "[1.5,)",
+			in:    []string{"1.5", "99"},
+			out:   []string{"1.4"},
+		},
+		{
+			name:  "upper bound only",
+			build: func() *RangeBuilder { return NewRangeBuilder().LT("1.0") },
+			want:  "(,1.0)",
+			in:    []string{"0.9"},
+			out:   []string{"1.0"},
+		},
+		{
+			name:  "exact version",
+			build: func() *RangeBuilder { return NewRangeBuilder().EQ("1.2.3") },
+			want:  "[1.2.3]",
+			in:    []string{"1.2.3"},
+			out:   []string{"1.2.4"},
+		},
+		{
+			name:  "equal inclusive bounds",
+			build: func() *RangeBuilder { return NewRangeBuilder().GTE("1.0").LTE("1.0") },
+			want:  "[1.0]",
+			in:    []string{"1.0"},
+		},
+		{
+			name:    "no bounds",
+			build:   NewRangeBuilder,
+			wantErr: true,
+		},
+		{
+			name:    "lower bound set twice",
+			build:   func() *RangeBuilder { return NewRangeBuilder().GTE("1.0").GT("1.1") },
+			wantErr: true,
+		},
+		{
+			name:    "exact after upper bound",
+			build:   func() *RangeBuilder { return NewRangeBuilder().LT("2.0").EQ("1.0") },
+			wantErr: true,
+		},
+		{
+			name:    "range syntax in version",
+			build:   func() *RangeBuilder { return NewRangeBuilder().GTE("1.0,2.0") },
+			wantErr: true,
+		},
+		{
+			name:    "invalid version",
+			build:   func() *RangeBuilder { return NewRangeBuilder().GTE("").LT("2.0") },
+			wantErr: true,
+		},
+		{
+			name:    "inverted bounds",
+			build:   func() *RangeBuilder { return NewRangeBuilder().GTE("2.0").LT("1.0") },
+			wantErr: true,
+		},
+		{
+			name:    "empty exclusive point",
+			build:   func() *RangeBuilder { return NewRangeBuilder().GT("1.0").LTE("1.0") },
+			wantErr: true,
+		},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.build().Build()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Build() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.String() != tt.want {
+				t.Errorf("Build() = %q, want %q", got.String(), tt.want)
+			}
+			for _, s := range tt.in {
+				if v, _ := e.NewVersion(s); !got.Contains(v) {
+					t.Errorf("Build().Contains(%q) = false, want true", s)
+				}
+			}
+			for _, s := range tt.out {
+				if v, _ := e.NewVersion(s); got.Contains(v) {
+					t.Errorf("Build().Contains(%q) = true, want false", s)
+				}
+			}
+		})
+	}
+}
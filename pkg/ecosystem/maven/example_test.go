@@ -0,0 +1,94 @@
+package maven_test
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/maven"
+)
+
+// Qualifiers such as alpha and SNAPSHOT sort before the release, and sp after it.
+func ExampleVersion_Compare() {
+	e := &maven.Ecosystem{}
+
+	var versions []*maven.Version
+	for _, s := range []string{"1.0-sp1", "1.0", "1.0-SNAPSHOT", "1.0-alpha-1", "1.0-rc1"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		versions = append(versions, v)
+	}
+	slices.SortFunc(versions, (*maven.Version).Compare)
+	fmt.Println(versions)
+	// Output:
+	// [1.0-alpha-1 1.0-rc1 1.0-SNAPSHOT 1.0 1.0-sp1]
+}
+
+// Ranges use interval notation.
+func ExampleVersionRange_Contains() {
+	e := &maven.Ecosystem{}
+
+	r, err := e.NewVersionRange("[1.0,2.0)")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, s := range []string{"1.5", "2.0"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(s, r.Contains(v))
+	}
+	// Output:
+	// 1.5 true
+	// 2.0 false
+}
+
+func ExampleNewRangeBuilder() {
+	r, err := maven.NewRangeBuilder().GTE("1.0").LT("2.0").Build()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(r)
+	// Output:
+	// [1.0,2.0)
+}
+
+// A Gradle rich version prefers 1.4 but rejects it, so 1.3 is selected.
+func ExampleRichConstraint_Select() {
+	e := &maven.Ecosystem{}
+
+	rc, err := e.NewRichConstraint(maven.RichVersion{Require: "[1.0,2.0)", Prefer: "1.4", Reject: []string{"1.4"}})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	var candidates []*maven.Version
+	for _, s := range []string{"1.2", "1.3", "1.4", "2.0"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		candidates = append(candidates, v)
+	}
+	fmt.Println(rc.Select(candidates))
+	// Output:
+	// 1.3 true
+}
+
+func ExampleVersion_SnapshotTimestamp() {
+	v, err := (&maven.Ecosystem{}).NewVersion("1.0-20240101.123456-7")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(v.SnapshotTimestamp())
+	// Output:
+	// 2024-01-01 12:34:56 +0000 UTC 7 true
+}
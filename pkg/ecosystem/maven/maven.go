@@ -1,11 +1,19 @@
 // Package maven provides functionality for working with Maven package versions.
 package maven
 
+import "github.com/alowayed/go-univers/pkg/univers"
+
 const (
-	Name = "maven"
+	Name = string(univers.Maven)
 )
 
-type Ecosystem struct{}
+type Ecosystem struct {
+	// PropertyResolver optionally substitutes Maven property placeholders such as
+	// ${project.version} before versions and ranges are parsed. It returns the
+	// property value and whether the property is known. When nil, or when it does
+	// not know a property, parsing fails with ErrUnresolvedProperty.
+	PropertyResolver func(name string) (string, bool)
+}
 
 func (e *Ecosystem) Name() string {
 	return Name
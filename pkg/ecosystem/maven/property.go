@@ -0,0 +1,52 @@
+package maven
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrUnresolvedProperty is returned when a version or range contains a Maven
+// property placeholder (e.g. ${project.version}) that could not be resolved.
+var ErrUnresolvedProperty = errors.New("unresolved Maven property")
+
+// maxPropertyDepth bounds nested property substitution to guard against cycles
+const maxPropertyDepth = 10
+
+// propertyPattern matches Maven property placeholders: ${name}
+var propertyPattern = regexp.MustCompile(`\$\{([^{}]*)\}`)
+
+// resolveProperties substitutes property placeholders in s using the
+// ecosystem's PropertyResolver. Strings without placeholders are returned unchanged.
+func (e *Ecosystem) resolveProperties(s string) (string, error) {
+	for range maxPropertyDepth {
+		if !strings.Contains(s, "${") {
+			return s, nil
+		}
+
+		match := propertyPattern.FindStringSubmatch(s)
+		if match == nil {
+			return "", fmt.Errorf("%w: malformed placeholder in %q", ErrUnresolvedProperty, s)
+		}
+
+		var unresolved string
+		s = propertyPattern.ReplaceAllStringFunc(s, func(placeholder string) string {
+			name := placeholder[2 : len(placeholder)-1]
+			if e.PropertyResolver != nil {
+				if value, ok := e.PropertyResolver(name); ok {
+					return value
+				}
+			}
+			if unresolved == "" {
+				unresolved = placeholder
+			}
+			return placeholder
+		})
+		if unresolved != "" {
+			return "", fmt.Errorf("%w: %s", ErrUnresolvedProperty, unresolved)
+		}
+	}
+
+	return "", fmt.Errorf("%w: property substitution exceeded depth %d in %q", ErrUnresolvedProperty, maxPropertyDepth, s)
+}
@@ -3,7 +3,10 @@ package maven
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
+
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 type VersionRange struct {
@@ -17,26 +20,63 @@ type constraint struct {
 	isLower   bool // true for lower bound, false for upper bound
 }
 
+// features declares the range syntax accepted by NewVersionRange
+var features = []univers.Feature{
+	{Name: univers.FeatureExact, Syntax: "1.0", Description: "A bare version matches only itself"},
+	{Name: univers.FeatureInterval, Syntax: "[1.0,2.0)", Description: "Bounds in brackets; [ and ] are inclusive, ( and ) exclusive, and either bound may be empty"},
+}
+
+// Features returns the range syntax features supported by NewVersionRange
+func (e *Ecosystem) Features() []univers.Feature {
+	return slices.Clone(features)
+}
+
 func (e *Ecosystem) NewVersionRange(rangeStr string) (*VersionRange, error) {
+	vr := &VersionRange{}
+	if err := e.ParseRangeInto(vr, rangeStr); err != nil {
+		return nil, err
+	}
+	return vr, nil
+}
+
+// ParseRangeInto parses a range string like NewVersionRange, but stores the
+// result in dst and reuses the constraint storage dst already holds. Hot loops
+// that check many short-lived ranges can parse them all into one VersionRange
+// to cut allocations. The range previously held by dst is overwritten, so it
+// must no longer be in use. On error dst matches no version.
+func (e *Ecosystem) ParseRangeInto(dst *VersionRange, rangeStr string) error {
+	constraints := dst.constraints[:0]
+	dst.original, dst.constraints = "", constraints
+	if err := univers.CheckLength(rangeStr); err != nil {
+		return err
+	}
 	if rangeStr == "" {
-		return nil, fmt.Errorf("range string cannot be empty")
+		return univers.WrapParseError(rangeStr, fmt.Errorf("range string cannot be empty"))
 	}
 
 	// Trim whitespace
 	trimmed := strings.TrimSpace(rangeStr)
 	if trimmed == "" {
-		return nil, fmt.Errorf("range string cannot be empty or only whitespace")
+		return univers.WrapParseError(rangeStr, fmt.Errorf("range string cannot be empty or only whitespace"))
 	}
 
-	constraints, err := parseVersionRange(trimmed, e)
+	// Substitute property placeholders before parsing
+	resolved, err := e.resolveProperties(trimmed)
 	if err != nil {
-		return nil, err
+		return univers.WrapParseError(rangeStr, err)
 	}
 
-	return &VersionRange{
-		original:    rangeStr,
-		constraints: constraints,
-	}, nil
+	constraints, err = parseVersionRange(constraints, strings.TrimSpace(resolved), e)
+	if err != nil {
+		return univers.WrapParseError(rangeStr, err)
+	}
+
+	if err := univers.CheckConstraints(len(constraints)); err != nil {
+		return err
+	}
+
+	dst.original, dst.constraints = rangeStr, constraints
+	return nil
 }
 
 func (vr *VersionRange) Contains(version *Version) bool {
@@ -53,16 +93,34 @@ func (vr *VersionRange) Contains(version *Version) bool {
 	return true
 }
 
+// Comparators returns the bounds of the range as a single AND group, so
+// "[1.0,2.0)" is ">=1.0" and "<2.0", and a bare version is ">=v" and "<=v"
+func (vr *VersionRange) Comparators() [][]univers.Comparator {
+	group := make([]univers.Comparator, 0, len(vr.constraints))
+	for _, c := range vr.constraints {
+		op := "<"
+		if c.isLower {
+			op = ">"
+		}
+		if c.inclusive {
+			op += "="
+		}
+		group = append(group, univers.Comparator{Operator: op, Version: c.version.String()})
+	}
+	return [][]univers.Comparator{group}
+}
+
 func (vr *VersionRange) String() string {
 	return vr.original
 }
 
-func parseVersionRange(rangeStr string, e *Ecosystem) ([]constraint, error) {
-	var constraints []constraint
+// bracketPattern matches a bracket range: [1.0], [1.0,2.0], (1.0,2.0), etc.
+var bracketPattern = regexp.MustCompile(`^[\[\(]([^,\]\)]*)(,([^,\]\)]*))?[\]\)]$`)
 
+// parseVersionRange parses a range into constraints appended to constraints
+func parseVersionRange(constraints []constraint, rangeStr string, e *Ecosystem) ([]constraint, error) {
 	// Check if it's a bracket range: [1.0], [1.0,2.0], (1.0,2.0), etc.
-	bracketRegex := regexp.MustCompile(`^[\[\(]([^,\]\)]*)(,([^,\]\)]*))?[\]\)]$`)
-	matches := bracketRegex.FindStringSubmatch(rangeStr)
+	matches := bracketPattern.FindStringSubmatch(rangeStr)
 
 	if matches != nil {
 		// This is a bracket range
@@ -71,6 +129,7 @@ func parseVersionRange(rangeStr string, e *Ecosystem) ([]constraint, error) {
 		lowerInclusive := lowerBracket == '['
 		upperInclusive := upperBracket == ']'
 
+		start := len(constraints)
 		lowerVersionStr := strings.TrimSpace(matches[1])
 		upperVersionStr := ""
 		if len(matches) > 3 && matches[3] != "" {
@@ -86,7 +145,7 @@ func parseVersionRange(rangeStr string, e *Ecosystem) ([]constraint, error) {
 		if matches[2] == "" && lowerVersionStr != "" {
 			version, err := e.NewVersion(lowerVersionStr)
 			if err != nil {
-				return nil, fmt.Errorf("invalid version in range: %v", err)
+				return nil, univers.TokenError(lowerVersionStr, fmt.Errorf("invalid version in range: %v", err))
 			}
 			// Exact version means both upper and lower bounds are the same
 			constraints = append(constraints, constraint{
@@ -106,7 +165,7 @@ func parseVersionRange(rangeStr string, e *Ecosystem) ([]constraint, error) {
 		if lowerVersionStr != "" {
 			version, err := e.NewVersion(lowerVersionStr)
 			if err != nil {
-				return nil, fmt.Errorf("invalid lower bound version: %v", err)
+				return nil, univers.TokenError(lowerVersionStr, fmt.Errorf("invalid lower bound version: %v", err))
 			}
 			constraints = append(constraints, constraint{
 				version:   version,
@@ -119,7 +178,7 @@ func parseVersionRange(rangeStr string, e *Ecosystem) ([]constraint, error) {
 		if upperVersionStr != "" {
 			version, err := e.NewVersion(upperVersionStr)
 			if err != nil {
-				return nil, fmt.Errorf("invalid upper bound version: %v", err)
+				return nil, univers.TokenError(upperVersionStr, fmt.Errorf("invalid upper bound version: %v", err))
 			}
 			constraints = append(constraints, constraint{
 				version:   version,
@@ -129,7 +188,7 @@ func parseVersionRange(rangeStr string, e *Ecosystem) ([]constraint, error) {
 		}
 
 		// Validate that we have at least one constraint
-		if len(constraints) == 0 {
+		if len(constraints) == start {
 			return nil, fmt.Errorf("invalid range format")
 		}
 
@@ -1,6 +1,8 @@
 package maven
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 )
 
@@ -64,6 +66,11 @@ func TestVersionRange_Contains(t *testing.T) {
 		{"exact with qualifier", "[1.0.0-alpha]", "1.0.0-alpha", true},
 		{"exact qualifier no match", "[1.0.0-alpha]", "1.0.0-beta", false},
 
+		// Timestamped snapshot tests
+		{"timestamped snapshot from plain snapshot", "[1.0-SNAPSHOT,1.0)", "1.0-20240101.123456-7", true},
+		{"timestamped snapshot below release", "[1.0,)", "1.0-20240101.123456-7", false},
+		{"timestamped snapshot after last build", "(1.0-20240101.123456-7,1.0)", "1.0-20240101.123456-8", true},
+
 		// Lower bound inclusive tests
 		{"lower bound inclusive match", "[1.0.0,)", "1.0.0", true},
 		{"lower bound inclusive higher", "[1.0.0,)", "1.0.1", true},
@@ -133,6 +140,111 @@ func TestVersionRange_Contains(t *testing.T) {
 	}
 }
 
+func TestEcosystem_NewVersionRange_Properties(t *testing.T) {
+	properties := map[string]string{
+		"project.version": "1.5.0",
+		"spring.version":  "5.3.0",
+		"spring.range":    "[${spring.version},6.0)",
+		"loop":            "${loop}",
+	}
+	resolver := func(name string) (string, bool) {
+		v, ok := properties[name]
+		return v, ok
+	}
+
+	tests := []struct {
+		name       string
+		resolver   func(string) (string, bool)
+		rangeStr   string
+		version    string
+		want       bool
+		wantErrIs  error
+		wantString string
+	}{
+		{
+			name:      "placeholder without resolver",
+			rangeStr:  "[${project.version},2.0)",
+			version:   "1.5.0",
+			wantErrIs: ErrUnresolvedProperty,
+		},
+		{
+			name:      "placeholder version without resolver",
+			rangeStr:  "${project.version}",
+			version:   "1.5.0",
+			wantErrIs: ErrUnresolvedProperty,
+		},
+		{
+			name:      "unknown property",
+			resolver:  resolver,
+			rangeStr:  "[${unknown.version},2.0)",
+			version:   "1.5.0",
+			wantErrIs: ErrUnresolvedProperty,
+		},
+		{
+			name:      "malformed placeholder",
+			resolver:  resolver,
+			rangeStr:  "[${project.version,2.0)",
+			version:   "1.5.0",
+			wantErrIs: ErrUnresolvedProperty,
+		},
+		{
+			name:      "cyclic property",
+			resolver:  resolver,
+			rangeStr:  "${loop}",
+			version:   "1.5.0",
+			wantErrIs: ErrUnresolvedProperty,
+		},
+		{
+			name:       "resolved lower bound",
+			resolver:   resolver,
+			rangeStr:   "[${project.version},2.0)",
+			version:    "1.6.0",
+			want:       true,
+			wantString: "[${project.version},2.0)",
+		},
+		{
+			name:       "resolved exact version",
+			resolver:   resolver,
+			rangeStr:   "${project.version}",
+			version:    "1.5.0",
+			want:       true,
+			wantString: "${project.version}",
+		},
+		{
+			name:       "nested property",
+			resolver:   resolver,
+			rangeStr:   "${spring.range}",
+			version:    "6.0.0",
+			want:       false,
+			wantString: "${spring.range}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &Ecosystem{PropertyResolver: tt.resolver}
+			vr, err := e.NewVersionRange(tt.rangeStr)
+			if tt.wantErrIs != nil {
+				if !errors.Is(err, tt.wantErrIs) {
+					t.Fatalf("NewVersionRange(%q) error = %v, want %v", tt.rangeStr, err, tt.wantErrIs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewVersionRange(%q) unexpected error: %v", tt.rangeStr, err)
+			}
+			if got := vr.String(); got != tt.wantString {
+				t.Errorf("VersionRange.String() = %q, want %q", got, tt.wantString)
+			}
+
+			v := mustNewVersion(t, tt.version)
+			if got := vr.Contains(v); got != tt.want {
+				t.Errorf("VersionRange{%q}.Contains(%q) = %v, want %v", tt.rangeStr, tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
 // mustNewVersionRange is a helper function to create a new VersionRange.
 func mustNewVersionRange(t *testing.T, s string) *VersionRange {
 	t.Helper()
@@ -143,3 +255,60 @@ func mustNewVersionRange(t *testing.T, s string) *VersionRange {
 	}
 	return vr
 }
+
+func TestEcosystem_Features(t *testing.T) {
+	e := &Ecosystem{}
+	for _, f := range e.Features() {
+		if _, err := e.NewVersionRange(f.Syntax); err != nil {
+			t.Errorf("Features() %s syntax %q does not parse: %v", f.Name, f.Syntax, err)
+		}
+	}
+}
+
+func BenchmarkEcosystem_ParseRangeInto(b *testing.B) {
+	const rangeStr = "[1.0,2.0)"
+	e := &Ecosystem{}
+
+	b.Run("NewVersionRange", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := e.NewVersionRange(rangeStr); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("ParseRangeInto", func(b *testing.B) {
+		b.ReportAllocs()
+		dst := &VersionRange{}
+		for b.Loop() {
+			if err := e.ParseRangeInto(dst, rangeStr); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestVersionRange_Comparators(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		want     string
+	}{
+		{name: "interval", rangeStr: "[1.0,2.0)", want: "[[>=1.0 <2.0]]"},
+		{name: "open upper", rangeStr: "(1.0,)", want: "[[>1.0]]"},
+		{name: "bare version", rangeStr: "1.0", want: "[[>=1.0 <=1.0]]"},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vr, err := e.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("Ecosystem.NewVersionRange() error = %v", err)
+			}
+			if got := fmt.Sprint(vr.Comparators()); got != tt.want {
+				t.Errorf("VersionRange.Comparators() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package maven
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RichVersion is a Gradle rich version declaration, the strictly, require,
+// prefer and reject entries of a dependency's version { } block. Versions
+// and ranges use Maven syntax; Gradle dynamic versions such as "1.+" are not
+// supported. Empty fields are not declared.
+type RichVersion struct {
+	// Strictly admits only the given version or range. A bare version
+	// admits exactly that version. When set, Require is not consulted, as
+	// Gradle also uses a strict version as the required one.
+	Strictly string
+	// Require sets the lowest acceptable version: a bare version admits
+	// itself and anything newer, a range admits the versions in it.
+	Require string
+	// Prefer is the version to select when it is acceptable. It never
+	// makes a version unacceptable.
+	Prefer string
+	// Reject lists versions and ranges that are never acceptable.
+	Reject []string
+}
+
+// Verdict is the outcome of evaluating a version against a RichConstraint.
+type Verdict int
+
+const (
+	// VerdictAccepted means the version satisfies every declared entry.
+	VerdictAccepted Verdict = iota
+	// VerdictRejected means the version matches a reject entry.
+	VerdictRejected
+	// VerdictNotStrictly means the version is outside the strictly entry.
+	VerdictNotStrictly
+	// VerdictNotRequired means the version does not satisfy the require entry.
+	VerdictNotRequired
+)
+
+// String returns the lowercase name of the verdict.
+func (v Verdict) String() string {
+	switch v {
+	case VerdictRejected:
+		return "rejected"
+	case VerdictNotStrictly:
+		return "not strictly"
+	case VerdictNotRequired:
+		return "not required"
+	}
+	return "accepted"
+}
+
+// RichConstraint is a parsed RichVersion that evaluates candidate versions.
+type RichConstraint struct {
+	strictly *VersionRange
+	require  *VersionRange
+	prefer   *Version
+	reject   []*VersionRange
+}
+
+// NewRichConstraint parses a Gradle rich version declaration. At least one
+// entry must be declared, and Prefer must be a single version.
+func (e *Ecosystem) NewRichConstraint(rv RichVersion) (*RichConstraint, error) {
+	rc := &RichConstraint{}
+
+	if strings.TrimSpace(rv.Strictly) == "" && strings.TrimSpace(rv.Require) == "" &&
+		strings.TrimSpace(rv.Prefer) == "" && len(rv.Reject) == 0 {
+		return nil, fmt.Errorf("rich version declares no constraints")
+	}
+
+	var err error
+	if strings.TrimSpace(rv.Strictly) != "" {
+		if rc.strictly, err = e.NewVersionRange(rv.Strictly); err != nil {
+			return nil, fmt.Errorf("invalid strictly version: %w", err)
+		}
+	}
+	if strings.TrimSpace(rv.Require) != "" {
+		if rc.require, err = e.newRequireRange(rv.Require); err != nil {
+			return nil, fmt.Errorf("invalid require version: %w", err)
+		}
+	}
+	if strings.TrimSpace(rv.Prefer) != "" {
+		if strings.ContainsAny(rv.Prefer, "[](),") {
+			return nil, fmt.Errorf("invalid prefer version: %s is not a single version", rv.Prefer)
+		}
+		if rc.prefer, err = e.NewVersion(rv.Prefer); err != nil {
+			return nil, fmt.Errorf("invalid prefer version: %w", err)
+		}
+	}
+	for _, reject := range rv.Reject {
+		vr, err := e.NewVersionRange(reject)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reject version: %w", err)
+		}
+		rc.reject = append(rc.reject, vr)
+	}
+
+	return rc, nil
+}
+
+// newRequireRange parses a require entry, where a bare version is a lower
+// bound rather than an exact match
+func (e *Ecosystem) newRequireRange(s string) (*VersionRange, error) {
+	vr, err := e.NewVersionRange(s)
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := e.resolveProperties(strings.TrimSpace(s))
+	if err != nil {
+		return nil, err
+	}
+	if resolved = strings.TrimSpace(resolved); resolved[0] == '[' || resolved[0] == '(' {
+		return vr, nil
+	}
+
+	// A bare version parses as an exact [v,v] match; keep only its floor
+	vr.constraints = vr.constraints[:1]
+	return vr, nil
+}
+
+// Evaluate reports whether version is acceptable and, if not, which entry
+// excludes it. Reject entries are checked first, then strictly, then require.
+func (rc *RichConstraint) Evaluate(version *Version) Verdict {
+	for _, reject := range rc.reject {
+		if reject.Contains(version) {
+			return VerdictRejected
+		}
+	}
+	if rc.strictly != nil {
+		if !rc.strictly.Contains(version) {
+			return VerdictNotStrictly
+		}
+		return VerdictAccepted
+	}
+	if rc.require != nil && !rc.require.Contains(version) {
+		return VerdictNotRequired
+	}
+	return VerdictAccepted
+}
+
+// Accepts reports whether version satisfies the constraint.
+func (rc *RichConstraint) Accepts(version *Version) bool {
+	return rc.Evaluate(version) == VerdictAccepted
+}
+
+// Select picks the version Gradle would resolve to among candidates: the
+// preferred version when it is a candidate and acceptable, otherwise the
+// highest acceptable candidate. It returns false if none is acceptable.
+func (rc *RichConstraint) Select(candidates []*Version) (*Version, bool) {
+	var best *Version
+	for _, candidate := range candidates {
+		if !rc.Accepts(candidate) {
+			continue
+		}
+		if rc.prefer != nil && candidate.Compare(rc.prefer) == 0 {
+			return candidate, true
+		}
+		if best == nil || candidate.Compare(best) > 0 {
+			best = candidate
+		}
+	}
+	return best, best != nil
+}
@@ -0,0 +1,210 @@
+package maven
+
+import (
+	"testing"
+)
+
+func TestEcosystem_NewRichConstraint(t *testing.T) {
+	tests := []struct {
+		name    string
+		rv      RichVersion
+		wantErr bool
+	}{
+		{
+			name: "strictly range with prefer",
+			rv:   RichVersion{Strictly: "[1.7,1.8)", Prefer: "1.7.25"},
+		},
+		{
+			name: "require with rejects",
+			rv:   RichVersion{Require: "1.5", Reject: []string{"1.6", "[2.0,)"}},
+		},
+		{
+			name: "reject only",
+			rv:   RichVersion{Reject: []string{"1.4"}},
+		},
+		{
+			name:    "nothing declared",
+			rv:      RichVersion{Strictly: " "},
+			wantErr: true,
+		},
+		{
+			name:    "invalid strictly",
+			rv:      RichVersion{Strictly: "[1.0,2.0"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid require",
+			rv:      RichVersion{Require: "${missing}"},
+			wantErr: true,
+		},
+		{
+			name:    "prefer range",
+			rv:      RichVersion{Prefer: "[1.0,2.0)"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid reject",
+			rv:      RichVersion{Require: "1.0", Reject: []string{""}},
+			wantErr: true,
+		},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := e.NewRichConstraint(tt.rv)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Ecosystem.NewRichConstraint() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRichConstraint_Evaluate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rv      RichVersion
+		version string
+		want    Verdict
+	}{
+		{
+			name:    "require bare version accepts newer",
+			rv:      RichVersion{Require: "1.5"},
+			version: "1.9",
+			want:    VerdictAccepted,
+		},
+		{
+			name:    "require bare version excludes older",
+			rv:      RichVersion{Require: "1.5"},
+			version: "1.4",
+			want:    VerdictNotRequired,
+		},
+		{
+			name:    "require range excludes above range",
+			rv:      RichVersion{Require: "[1.5,2.0)"},
+			version: "2.0",
+			want:    VerdictNotRequired,
+		},
+		{
+			name:    "strictly bare version is exact",
+			rv:      RichVersion{Strictly: "1.5"},
+			version: "1.6",
+			want:    VerdictNotStrictly,
+		},
+		{
+			name:    "strictly range accepts inside",
+			rv:      RichVersion{Strictly: "[1.7,1.8)", Prefer: "1.7.25"},
+			version: "1.7.30",
+			want:    VerdictAccepted,
+		},
+		{
+			name:    "strictly takes precedence over require",
+			rv:      RichVersion{Strictly: "[1.0,2.0)", Require: "1.5"},
+			version: "1.2",
+			want:    VerdictAccepted,
+		},
+		{
+			name:    "reject wins over require",
+			rv:      RichVersion{Require: "1.5", Reject: []string{"1.6"}},
+			version: "1.6",
+			want:    VerdictRejected,
+		},
+		{
+			name:    "reject range wins over strictly",
+			rv:      RichVersion{Strictly: "[1.0,2.0)", Reject: []string{"[1.3,1.4)"}},
+			version: "1.3.2",
+			want:    VerdictRejected,
+		},
+		{
+			name:    "prefer alone accepts anything",
+			rv:      RichVersion{Prefer: "1.5"},
+			version: "3.0",
+			want:    VerdictAccepted,
+		},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc, err := e.NewRichConstraint(tt.rv)
+			if err != nil {
+				t.Fatalf("Ecosystem.NewRichConstraint() error = %v", err)
+			}
+			v, err := e.NewVersion(tt.version)
+			if err != nil {
+				t.Fatalf("Failed to parse version %s: %v", tt.version, err)
+			}
+
+			if got := rc.Evaluate(v); got != tt.want {
+				t.Errorf("RichConstraint.Evaluate(%s) = %v, want %v", tt.version, got, tt.want)
+			}
+			if got, want := rc.Accepts(v), tt.want == VerdictAccepted; got != want {
+				t.Errorf("RichConstraint.Accepts(%s) = %v, want %v", tt.version, got, want)
+			}
+		})
+	}
+}
+
+func TestRichConstraint_Select(t *testing.T) {
+	tests := []struct {
+		name       string
+		rv         RichVersion
+		candidates []string
+		want       string
+		wantOK     bool
+	}{
+		{
+			name:       "preferred candidate",
+			rv:         RichVersion{Strictly: "[1.7,1.8)", Prefer: "1.7.25"},
+			candidates: []string{"1.7.20", "1.7.25", "1.7.30", "1.8.0"},
+			want:       "1.7.25",
+			wantOK:     true,
+		},
+		{
+			name:       "highest acceptable without prefer",
+			rv:         RichVersion{Require: "[1.0,2.0)", Reject: []string{"1.9"}},
+			candidates: []string{"1.5", "1.9", "2.0", "1.8"},
+			want:       "1.8",
+			wantOK:     true,
+		},
+		{
+			name:       "rejected prefer is ignored",
+			rv:         RichVersion{Require: "1.0", Prefer: "1.5", Reject: []string{"1.5"}},
+			candidates: []string{"1.5", "1.4", "1.2"},
+			want:       "1.4",
+			wantOK:     true,
+		},
+		{
+			name:       "nothing acceptable",
+			rv:         RichVersion{Strictly: "2.0"},
+			candidates: []string{"1.0", "3.0"},
+			wantOK:     false,
+		},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc, err := e.NewRichConstraint(tt.rv)
+			if err != nil {
+				t.Fatalf("Ecosystem.NewRichConstraint() error = %v", err)
+			}
+			var candidates []*Version
+			for _, c := range tt.candidates {
+				v, err := e.NewVersion(c)
+				if err != nil {
+					t.Fatalf("Failed to parse version %s: %v", c, err)
+				}
+				candidates = append(candidates, v)
+			}
+
+			got, ok := rc.Select(candidates)
+			if ok != tt.wantOK {
+				t.Fatalf("RichConstraint.Select() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && got.String() != tt.want {
+				t.Errorf("RichConstraint.Select() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
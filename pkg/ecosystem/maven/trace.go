@@ -0,0 +1,123 @@
+package maven
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Reasons reported by CompareStep for the rule that decided a pair
+const (
+	reasonNumeric              = "numbers compare numerically"
+	reasonNumberAfterQualifier = "number sorts after a qualifier"
+	reasonQualifierOrder       = "known qualifiers compare by alpha < beta < milestone < rc < snapshot < release < sp"
+	reasonUnknownAfterKnown    = "unknown qualifier sorts after known qualifiers"
+	reasonLexical              = "unknown qualifiers compare lexically"
+)
+
+// Token is a normalized element of a version as Compare sees it: qualifier
+// aliases are expanded ("a1" becomes alpha, 1), case is folded and the
+// release qualifiers "ga", "final" and "release" become "". Null tokens (0 and
+// "") at the end or before a qualifier are dropped, so 1.0-alpha is 1, alpha.
+type Token struct {
+	// Value is the number in decimal or the normalized qualifier.
+	Value string
+	// IsNumber reports whether the token is numeric.
+	IsNumber bool
+}
+
+// String returns the token value, with the release qualifier shown as ""
+func (t Token) String() string {
+	if !t.IsNumber && t.Value == "" {
+		return `""`
+	}
+	return t.Value
+}
+
+// CompareStep is one element pair examined by Compare.
+type CompareStep struct {
+	// Index is the position of the pair in the token lists.
+	Index int
+	// Left and Right are the tokens compared. A side past the end of its
+	// version is padded with the null token: 0 against a number and the
+	// release qualifier "" against a qualifier.
+	Left, Right             Token
+	LeftPadded, RightPadded bool
+	// Result is the comparison of Left with Right: -1, 0 or 1.
+	Result int
+	// Reason names the ordering rule that produced Result.
+	Reason string
+}
+
+// CompareTrace explains a Compare call, for debugging orderings that differ
+// from Maven's ComparableVersion.
+type CompareTrace struct {
+	// Left and Right are the normalized tokens of both versions, with
+	// trailing null tokens removed.
+	Left, Right []Token
+	// Steps lists the pairs examined, ending with the deciding pair unless
+	// the versions are equal.
+	Steps []CompareStep
+	// Result is the same as Compare: -1, 0 or 1.
+	Result int
+}
+
+// Tokens returns the normalized tokens Compare uses for the version.
+func (v *Version) Tokens() []Token {
+	tokens := make([]Token, 0, len(v.elements))
+	for _, e := range v.elements {
+		tokens = append(tokens, e.token())
+	}
+	return tokens
+}
+
+// TraceCompare compares this version with another like Compare and records
+// the token lists and every pairwise decision. For example tracing "1.0-alpha-1"
+// against "1.0a1" shows both normalize to [1 0 alpha 1] and compare equal.
+func (v *Version) TraceCompare(other *Version) CompareTrace {
+	trace := CompareTrace{Left: v.Tokens(), Right: other.Tokens()}
+	trace.Result = v.compare(other, func(step CompareStep) {
+		trace.Steps = append(trace.Steps, step)
+	})
+	return trace
+}
+
+// String renders the trace as one line per token list and step
+func (t CompareTrace) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "left:  %v\n", t.Left)
+	fmt.Fprintf(&b, "right: %v\n", t.Right)
+	for _, s := range t.Steps {
+		fmt.Fprintf(&b, "[%d] %s %s %s: %s\n", s.Index, padded(s.Left, s.LeftPadded), resultSymbol(s.Result), padded(s.Right, s.RightPadded), s.Reason)
+	}
+	fmt.Fprintf(&b, "result: %d", t.Result)
+	return b.String()
+}
+
+// padded renders a token, marking null padding
+func padded(t Token, isPadding bool) string {
+	if isPadding {
+		return t.String() + " (padding)"
+	}
+	return t.String()
+}
+
+// resultSymbol renders a comparison result as <, = or >
+func resultSymbol(cmp int) string {
+	switch {
+	case cmp < 0:
+		return "<"
+	case cmp > 0:
+		return ">"
+	default:
+		return "="
+	}
+}
+
+// token returns the exported form of the element
+func (e element) token() Token {
+	if e.isNumber {
+		return Token{Value: strconv.Itoa(e.value.(int)), IsNumber: true}
+	}
+	return Token{Value: e.value.(string)}
+}
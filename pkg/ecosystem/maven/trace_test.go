@@ -0,0 +1,120 @@
+package maven
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestVersion_Tokens(t *testing.T) {
+	tests := []struct {
+		version string
+		want    []Token
+	}{
+		{"1.0-alpha-1", []Token{{"1", true}, {"alpha", false}, {"1", true}}},
+		{"1.0a1", []Token{{"1", true}, {"alpha", false}, {"1", true}}},
+		{"2.0.Final", []Token{{"2", true}}},
+		{"1.0-CR2", []Token{{"1", true}, {"rc", false}, {"2", true}}},
+		{"1-ga-1", []Token{{"1", true}, {"", false}, {"1", true}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got := mustNewVersion(t, tt.version).Tokens()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Tokens() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersion_TraceCompare(t *testing.T) {
+	tests := []struct {
+		name       string
+		v1, v2     string
+		wantSteps  int
+		wantLast   CompareStep
+		wantResult int
+	}{
+		{
+			name:       "alias spellings are equal",
+			v1:         "1.0-alpha-1",
+			v2:         "1.0a1",
+			wantSteps:  3,
+			wantLast:   CompareStep{Index: 2, Left: Token{"1", true}, Right: Token{"1", true}, Reason: reasonNumeric},
+			wantResult: 0,
+		},
+		{
+			name:       "shorter version is padded",
+			v1:         "1.0",
+			v2:         "1.0.1",
+			wantSteps:  3,
+			wantLast:   CompareStep{Index: 2, Left: Token{"0", true}, Right: Token{"1", true}, LeftPadded: true, Result: -1, Reason: reasonNumeric},
+			wantResult: -1,
+		},
+		{
+			name:       "known qualifier order",
+			v1:         "1.0-SNAPSHOT",
+			v2:         "1.0-rc1",
+			wantSteps:  2,
+			wantLast:   CompareStep{Index: 1, Left: Token{"snapshot", false}, Right: Token{"rc", false}, Result: 1, Reason: reasonQualifierOrder},
+			wantResult: 1,
+		},
+		{
+			name:       "sp after release",
+			v1:         "1.0-sp",
+			v2:         "1.0",
+			wantSteps:  2,
+			wantLast:   CompareStep{Index: 1, Left: Token{"sp", false}, Right: Token{"", false}, RightPadded: true, Result: 1, Reason: reasonQualifierOrder},
+			wantResult: 1,
+		},
+		{
+			name:       "pre-release qualifier before number",
+			v1:         "1-beta",
+			v2:         "1-1",
+			wantSteps:  2,
+			wantLast:   CompareStep{Index: 1, Left: Token{"beta", false}, Right: Token{"1", true}, Result: -1, Reason: reasonNumberAfterQualifier},
+			wantResult: -1,
+		},
+		{
+			name:       "unknown qualifiers",
+			v1:         "1.0-foo",
+			v2:         "1.0-bar",
+			wantSteps:  2,
+			wantLast:   CompareStep{Index: 1, Left: Token{"foo", false}, Right: Token{"bar", false}, Result: 1, Reason: reasonLexical},
+			wantResult: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v1 := mustNewVersion(t, tt.v1)
+			v2 := mustNewVersion(t, tt.v2)
+
+			got := v1.TraceCompare(v2)
+			if got.Result != tt.wantResult || got.Result != v1.Compare(v2) {
+				t.Errorf("TraceCompare(%q, %q).Result = %d, want %d", tt.v1, tt.v2, got.Result, tt.wantResult)
+			}
+			if len(got.Steps) != tt.wantSteps {
+				t.Fatalf("TraceCompare(%q, %q) has %d steps, want %d:\n%s", tt.v1, tt.v2, len(got.Steps), tt.wantSteps, got)
+			}
+			if last := got.Steps[len(got.Steps)-1]; last != tt.wantLast {
+				t.Errorf("TraceCompare(%q, %q) last step = %+v, want %+v", tt.v1, tt.v2, last, tt.wantLast)
+			}
+		})
+	}
+}
+
+func TestCompareTrace_String(t *testing.T) {
+	got := mustNewVersion(t, "1.0").TraceCompare(mustNewVersion(t, "1.0-sp")).String()
+	want := strings.Join([]string{
+		"left:  [1]",
+		"right: [1 sp]",
+		"[0] 1 = 1: numbers compare numerically",
+		`[1] "" (padding) < sp: known qualifiers compare by alpha < beta < milestone < rc < snapshot < release < sp`,
+		"result: -1",
+	}, "\n")
+	if got != want {
+		t.Errorf("String() =\n%s\nwant\n%s", got, want)
+	}
+}
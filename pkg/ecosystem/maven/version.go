@@ -2,14 +2,23 @@ package maven
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
+
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 type Version struct {
 	original string
 	elements []element
+
+	// snapshotTime and snapshotBuild are set for a timestamped SNAPSHOT
+	// such as 1.0-20240101.123456-7
+	snapshotTime  time.Time
+	snapshotBuild int
 }
 
 type element struct {
@@ -18,6 +27,10 @@ type element struct {
 }
 
 func (e *Ecosystem) NewVersion(version string) (*Version, error) {
+	if err := univers.CheckLength(version); err != nil {
+		return nil, err
+	}
+
 	if version == "" {
 		return nil, fmt.Errorf("version string cannot be empty")
 	}
@@ -28,11 +41,33 @@ func (e *Ecosystem) NewVersion(version string) (*Version, error) {
 		return nil, fmt.Errorf("version string cannot be empty or only whitespace")
 	}
 
+	// Substitute property placeholders before validation
+	resolved, err := e.resolveProperties(trimmed)
+	if err != nil {
+		return nil, err
+	}
+	if resolved != trimmed {
+		trimmed = strings.TrimSpace(resolved)
+		version = trimmed
+	}
+
 	// Basic validation - Maven versions should contain at least one digit or known qualifier
 	if !isValidMavenVersion(trimmed) {
 		return nil, fmt.Errorf("invalid Maven version format: %s", trimmed)
 	}
 
+	if base, ts, build, ok := splitSnapshotTimestamp(trimmed); ok {
+		// A deployed snapshot sorts as a SNAPSHOT of its base version
+		// followed by the timestamp and build number, so it is above the
+		// plain SNAPSHOT, below the release and ordered by deployment
+		return &Version{
+			original:      version,
+			elements:      parseVersionString(base + "-SNAPSHOT" + trimmed[len(base):]),
+			snapshotTime:  ts,
+			snapshotBuild: build,
+		}, nil
+	}
+
 	elements := parseVersionString(trimmed)
 
 	return &Version{
@@ -41,60 +76,129 @@ func (e *Ecosystem) NewVersion(version string) (*Version, error) {
 	}, nil
 }
 
+// snapshotLayout is the UTC timestamp Maven writes into the version of a
+// deployed SNAPSHOT, as in 1.0-20240101.123456-7
+const snapshotLayout = "20060102.150405"
+
+// splitSnapshotTimestamp splits a timestamped SNAPSHOT version such as
+// 1.0-20240101.123456-7 into its base version, deployment time and build
+// number. ok is false unless the version ends in a valid timestamp and build
+// number after a non-empty base version.
+func splitSnapshotTimestamp(version string) (base string, ts time.Time, build int, ok bool) {
+	dash := strings.LastIndexByte(version, '-')
+	if dash < 0 {
+		return "", time.Time{}, 0, false
+	}
+	build, err := strconv.Atoi(version[dash+1:])
+	if err != nil || build < 0 || version[dash+1] == '+' {
+		return "", time.Time{}, 0, false
+	}
+
+	rest := version[:dash]
+	if len(rest) < len(snapshotLayout)+2 || rest[len(rest)-len(snapshotLayout)-1] != '-' {
+		return "", time.Time{}, 0, false
+	}
+	ts, err = time.Parse(snapshotLayout, rest[len(rest)-len(snapshotLayout):])
+	if err != nil {
+		return "", time.Time{}, 0, false
+	}
+	return rest[:len(rest)-len(snapshotLayout)-1], ts, build, true
+}
+
+// SnapshotTimestamp returns the deployment time, in UTC, and build number of
+// a timestamped SNAPSHOT version such as 1.0-20240101.123456-7, the form a
+// repository gives each deployment of 1.0-SNAPSHOT. ok is false for any
+// other version, including the plain 1.0-SNAPSHOT.
+//
+// Compare orders timestamped snapshots after the plain SNAPSHOT of the same
+// base version and before its release, by time and then build number.
+func (v *Version) SnapshotTimestamp() (t time.Time, build int, ok bool) {
+	if v.snapshotTime.IsZero() {
+		return time.Time{}, 0, false
+	}
+	return v.snapshotTime, v.snapshotBuild, true
+}
+
 func isValidMavenVersion(version string) bool {
 	// Maven versions should contain at least one digit or be a known qualifier
-	hasDigit := false
-	hasKnownQualifier := false
-
 	for _, r := range version {
 		if unicode.IsDigit(r) {
-			hasDigit = true
-			break
+			return true
 		}
 	}
 
 	// Check for known qualifiers
-	lower := strings.ToLower(version)
-	knownQualifiers := []string{"alpha", "beta", "milestone", "rc", "snapshot", "ga", "final", "release", "sp"}
 	for _, qualifier := range knownQualifiers {
-		if strings.Contains(lower, qualifier) {
-			hasKnownQualifier = true
-			break
+		if containsLower(version, qualifier) {
+			return true
 		}
 	}
 
 	// Also accept single-letter qualifiers
-	if len(version) == 1 && (version == "a" || version == "b" || version == "m") {
-		hasKnownQualifier = true
+	return version == "a" || version == "b" || version == "m"
+}
+
+// knownQualifiers are the qualifiers that make a version valid without digits
+var knownQualifiers = []string{"alpha", "beta", "milestone", "rc", "snapshot", "ga", "final", "release", "sp"}
+
+// containsLower reports whether strings.ToLower(s) contains the lowercase
+// ASCII substr, lowering s a rune at a time instead of copying it
+func containsLower(s, substr string) bool {
+	for start := range s {
+		rest := s[start:]
+		matched := 0
+		for _, r := range rest {
+			if matched == len(substr) || unicode.ToLower(r) != rune(substr[matched]) {
+				break
+			}
+			matched++
+		}
+		if matched == len(substr) {
+			return true
+		}
 	}
+	return false
+}
 
-	return hasDigit || hasKnownQualifier
+// ValidVersion reports whether version parses as a Maven version with no
+// property resolver configured, without allocating. Placeholders such as
+// ${project.version} are never valid here, since nothing can resolve them.
+func ValidVersion(version string) bool {
+	if univers.CheckLength(version) != nil {
+		return false
+	}
+	trimmed := strings.TrimSpace(version)
+	return trimmed != "" && !strings.Contains(trimmed, "${") && isValidMavenVersion(trimmed)
 }
 
+// Compare compares this version with another Maven version element by
+// element, padding the shorter one with null (zero) elements
 func (v *Version) Compare(other *Version) int {
-	// Compare elements one by one
-	maxLen := len(v.elements)
-	if len(other.elements) > maxLen {
-		maxLen = len(other.elements)
-	}
+	return v.compare(other, nil)
+}
 
-	for i := 0; i < maxLen; i++ {
-		var elem1, elem2 element
+// compare implements Compare, reporting every element pair it examines to
+// step when step is not nil
+func (v *Version) compare(other *Version, step func(CompareStep)) int {
+	maxLen := max(len(v.elements), len(other.elements))
 
+	for i := range maxLen {
 		// Get element or use "null" element if past end
-		if i < len(v.elements) {
-			elem1 = v.elements[i]
-		} else {
-			elem1 = element{value: 0, isNumber: true} // null element
+		elem1, padded1 := elementAt(v.elements, i, other.elements)
+		elem2, padded2 := elementAt(other.elements, i, v.elements)
+
+		cmp, reason := explainElements(elem1, elem2)
+		if step != nil {
+			step(CompareStep{
+				Index:       i,
+				Left:        elem1.token(),
+				Right:       elem2.token(),
+				LeftPadded:  padded1,
+				RightPadded: padded2,
+				Result:      cmp,
+				Reason:      reason,
+			})
 		}
-
-		if i < len(other.elements) {
-			elem2 = other.elements[i]
-		} else {
-			elem2 = element{value: 0, isNumber: true} // null element
-		}
-
-		cmp := compareElements(elem1, elem2)
 		if cmp != 0 {
 			return cmp
 		}
@@ -103,47 +207,45 @@ func (v *Version) Compare(other *Version) int {
 	return 0 // versions are equal
 }
 
+// elementAt returns the i-th element, or the null element and true past the
+// end. As in Maven, null is 0 against a number and the release qualifier
+// against a qualifier, so 1-alpha < 1 < 1-sp.
+func elementAt(elements []element, i int, other []element) (element, bool) {
+	if i < len(elements) {
+		return elements[i], false
+	}
+	if i < len(other) && !other[i].isNumber {
+		return element{value: "", isNumber: false}, true
+	}
+	return element{value: 0, isNumber: true}, true
+}
+
 func compareElements(e1, e2 element) int {
+	cmp, _ := explainElements(e1, e2)
+	return cmp
+}
+
+// explainElements compares two elements and names the rule that decided
+func explainElements(e1, e2 element) (int, string) {
 	// If both are numbers, compare numerically
 	if e1.isNumber && e2.isNumber {
 		n1 := e1.value.(int)
 		n2 := e2.value.(int)
 		if n1 < n2 {
-			return -1
+			return -1, reasonNumeric
 		}
 		if n1 > n2 {
-			return 1
-		}
-		return 0
-	}
-
-	// If one is number and other is string, number comes first (unless string is empty/release)
-	if e1.isNumber && !e2.isNumber {
-		s2 := e2.value.(string)
-		if s2 == "" {
-			// number vs empty string: empty string (release) is greater
-			return -1
-		}
-		if s2 == "sp" {
-			// number vs sp: sp is greater
-			return -1
+			return 1, reasonNumeric
 		}
-		// number vs other qualifier: number is greater
-		return 1
+		return 0, reasonNumeric
 	}
 
-	if !e1.isNumber && e2.isNumber {
-		s1 := e1.value.(string)
-		if s1 == "" {
-			// empty string (release) vs number: empty string is greater
-			return 1
-		}
-		if s1 == "sp" {
-			// sp vs number: sp is greater
-			return 1
+	// A number sorts after any qualifier, including release and sp
+	if e1.isNumber != e2.isNumber {
+		if e1.isNumber {
+			return 1, reasonNumberAfterQualifier
 		}
-		// other qualifier vs number: number is greater
-		return -1
+		return -1, reasonNumberAfterQualifier
 	}
 
 	// Both are strings - compare by qualifier order
@@ -157,30 +259,30 @@ func compareElements(e1, e2 element) int {
 	if !exists1 && !exists2 {
 		// Both unknown - lexicographic comparison
 		if s1 < s2 {
-			return -1
+			return -1, reasonLexical
 		}
 		if s1 > s2 {
-			return 1
+			return 1, reasonLexical
 		}
-		return 0
+		return 0, reasonLexical
 	}
 
 	if !exists1 {
-		return 1 // unknown qualifier comes after known
+		return 1, reasonUnknownAfterKnown // unknown qualifier comes after known
 	}
 
 	if !exists2 {
-		return -1 // known qualifier comes before unknown
+		return -1, reasonUnknownAfterKnown // known qualifier comes before unknown
 	}
 
 	// Both are known qualifiers
 	if order1 < order2 {
-		return -1
+		return -1, reasonQualifierOrder
 	}
 	if order1 > order2 {
-		return 1
+		return 1, reasonQualifierOrder
 	}
-	return 0
+	return 0, reasonQualifierOrder
 }
 
 func (v *Version) String() string {
@@ -227,8 +329,9 @@ func parseVersionString(version string) []element {
 		}
 	}
 
-	// Trim trailing null elements (0, "", "final", "ga")
-	elements = trimTrailingNulls(elements)
+	// Trim null elements (0, "", "final", "ga") at the end and before a
+	// qualifier
+	elements = trimNulls(elements)
 
 	return elements
 }
@@ -290,17 +393,24 @@ func normalizeQualifier(s string) string {
 	return lower
 }
 
-func trimTrailingNulls(elements []element) []element {
-	// Remove trailing elements that are equivalent to "null"
-	for len(elements) > 0 {
-		last := elements[len(elements)-1]
-		if isNullElement(last) {
-			elements = elements[:len(elements)-1]
-		} else {
-			break
+// trimNulls removes trailing null elements and null elements that precede a
+// qualifier. Maven does the same before a "-" and treats ".X" like "-X" for a
+// qualifier X, so 1.0.alpha equals 1-alpha. Without this a 0 kept before a
+// qualifier would sort after sp while equalling the null element sp sorts
+// after, and Compare would not be transitive.
+func trimNulls(elements []element) []element {
+	trimmed := make([]element, 0, len(elements))
+	dropNulls := true
+	for i := len(elements) - 1; i >= 0; i-- {
+		e := elements[i]
+		if dropNulls && isNullElement(e) {
+			continue
 		}
+		trimmed = append(trimmed, e)
+		dropNulls = !e.isNumber
 	}
-	return elements
+	slices.Reverse(trimmed)
+	return trimmed
 }
 
 func isNullElement(e element) bool {
@@ -2,6 +2,7 @@ package maven
 
 import (
 	"testing"
+	"time"
 )
 
 func TestEcosystem_NewVersion(t *testing.T) {
@@ -30,6 +31,7 @@ func TestEcosystem_NewVersion(t *testing.T) {
 		{"only tabs", "\t\t\t", true},
 		{"only newlines", "\n\n\n", true},
 		{"mixed whitespace only", " \t\n ", true},
+		{"unresolved property", "${project.version}", true},
 	}
 
 	for _, tt := range tests {
@@ -85,9 +87,35 @@ func TestVersion_Compare(t *testing.T) {
 		{"qualified numbers", "1.0.0-alpha-1", "1.0.0-alpha-2", -1},
 		{"beta with number", "1.0.0-beta-1", "1.0.0-beta-10", -1},
 
+		// Timestamped snapshots
+		{"timestamped vs plain snapshot", "1.0-20240101.123456-7", "1.0-SNAPSHOT", 1},
+		{"timestamped vs release", "1.0-20240101.123456-7", "1.0", -1},
+		{"timestamped vs rc", "1.0-20240101.123456-7", "1.0-rc-1", 1},
+		{"timestamped vs previous release", "1.0-20240101.123456-7", "0.9", 1},
+		{"timestamped by date", "1.0-20240101.123456-7", "1.0-20240102.000000-8", -1},
+		{"timestamped by time", "1.0-20240101.090000-1", "1.0-20240101.100000-1", -1},
+		{"timestamped by build", "1.0-20240101.123456-7", "1.0-20240101.123456-8", -1},
+		{"timestamped with qualifier", "2.1-beta-1-20240101.123456-3", "2.1-beta-1-SNAPSHOT", 1},
+
 		// Mixed types
 		{"number vs qualifier", "1.0.1", "1.0.0-alpha", 1},
 		{"different lengths", "1.0", "1.0.0.1", -1},
+		{"unknown qualifier after release", "1-foo", "1", 1},
+		{"unknown qualifier after sp", "1-foo", "1-sp", 1},
+		{"unknown qualifier after pre-release", "1-foo", "1-rc", 1},
+		{"sp after release", "1-sp", "1", 1},
+		{"sp before sp with number", "1-sp", "1-sp2", -1},
+		{"sp numbers compare numerically", "1-sp2", "1-sp123", -1},
+		{"sp before unknown qualifier", "1-sp123", "1-abc", -1},
+		{"unknown qualifiers compare lexically", "1-abc", "1-def", -1},
+		{"unknown qualifier before later unknown qualifier", "1-def", "1-pom-1", -1},
+		{"unknown qualifier before number", "3.foo-0-1", "3.0.3", -1},
+		{"unknown qualifier before next patch", "1.0-foo", "1.0.1", -1},
+		{"sp before next minor", "1-sp", "1.1", -1},
+		{"unknown qualifier before next major", "1-foo", "2", -1},
+		{"release with number before unknown qualifier", "3.final1", "3.foo-0-1", -1},
+		{"zero before qualifier is dropped", "1.0.alpha", "1-alpha", 0},
+		{"zero before sp is dropped", "1.0.sp", "1-sp", 0},
 
 		// Whitespace handling in comparison
 		{"whitespace vs normal", " 1.0.0 ", "1.0.0", 0},
@@ -120,3 +148,31 @@ func mustNewVersion(t *testing.T, version string) *Version {
 	}
 	return v
 }
+
+func TestVersion_SnapshotTimestamp(t *testing.T) {
+	tests := []struct {
+		name      string
+		version   string
+		wantTime  time.Time
+		wantBuild int
+		wantOK    bool
+	}{
+		{"timestamped", "1.0-20240101.123456-7", time.Date(2024, 1, 1, 12, 34, 56, 0, time.UTC), 7, true},
+		{"timestamped with qualifier", "2.1-beta-1-20231231.235959-12", time.Date(2023, 12, 31, 23, 59, 59, 0, time.UTC), 12, true},
+		{"plain snapshot", "1.0-SNAPSHOT", time.Time{}, 0, false},
+		{"release", "1.0", time.Time{}, 0, false},
+		{"invalid date", "1.0-20241301.123456-7", time.Time{}, 0, false},
+		{"no base version", "20240101.123456-7", time.Time{}, 0, false},
+		{"missing build number", "1.0-20240101.123456", time.Time{}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotTime, gotBuild, gotOK := mustNewVersion(t, tt.version).SnapshotTimestamp()
+			if !gotTime.Equal(tt.wantTime) || gotBuild != tt.wantBuild || gotOK != tt.wantOK {
+				t.Errorf("SnapshotTimestamp() = %v, %d, %v, want %v, %d, %v",
+					gotTime, gotBuild, gotOK, tt.wantTime, tt.wantBuild, tt.wantOK)
+			}
+		})
+	}
+}
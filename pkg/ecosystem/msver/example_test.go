@@ -0,0 +1,60 @@
+package msver_test
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/msver"
+)
+
+// System.Version has major, minor, build and revision components.
+func ExampleVersion_Compare() {
+	e := &msver.Ecosystem{}
+
+	var versions []*msver.Version
+	for _, s := range []string{"10.0.19041.1415", "6.3.9600", "10.0", "10.0.19041.1288"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		versions = append(versions, v)
+	}
+	slices.SortFunc(versions, (*msver.Version).Compare)
+	fmt.Println(versions)
+	// Output:
+	// [6.3.9600 10.0 10.0.19041.1288 10.0.19041.1415]
+}
+
+// Ranges are comparators separated by commas.
+func ExampleVersionRange_Contains() {
+	e := &msver.Ecosystem{}
+
+	r, err := e.NewVersionRange(">= 10.0.19041.0, < 10.0.19041.1415")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, s := range []string{"10.0.19041.1288", "10.0.19041.1415"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(s, r.Contains(v))
+	}
+	// Output:
+	// 10.0.19041.1288 true
+	// 10.0.19041.1415 false
+}
+
+func ExampleVersion_Major() {
+	v, err := (&msver.Ecosystem{}).NewVersion("6.3.9600")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(v.Major(), v.Minor(), v.Build(), v.Revision())
+	// Output:
+	// 6 3 9600 -1
+}
@@ -0,0 +1,15 @@
+// Package msver provides functionality for working with four-part Microsoft
+// versions (major.minor[.build[.revision]]) as used by System.Version.
+package msver
+
+import "github.com/alowayed/go-univers/pkg/univers"
+
+const (
+	Name = string(univers.MSVer)
+)
+
+type Ecosystem struct{}
+
+func (e *Ecosystem) Name() string {
+	return Name
+}
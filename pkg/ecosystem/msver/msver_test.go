@@ -0,0 +1,15 @@
+package msver
+
+import (
+	"testing"
+)
+
+func TestEcosystem_Name(t *testing.T) {
+	ecosystem := &Ecosystem{}
+	want := "msver"
+
+	got := ecosystem.Name()
+	if got != want {
+		t.Errorf("Ecosystem.Name() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,173 @@
+package msver
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestEcosystem_NewVersionRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		// Valid ranges
+		{
+			name:  "exact version",
+			input: "10.0.19041.1415",
+		},
+		{
+			name:  "comparison",
+			input: ">=10.0.19041.0",
+		},
+		{
+			name:  "multiple constraints",
+			input: ">= 10.0.19041.0, < 10.0.19041.1415",
+		},
+		{
+			name:  "not equal",
+			input: "!=1.2.3.4",
+		},
+		// Error cases
+		{
+			name:    "empty string",
+			input:   "",
+			wantErr: true,
+		},
+		{
+			name:    "operator without version",
+			input:   ">=",
+			wantErr: true,
+		},
+		{
+			name:    "invalid version",
+			input:   ">=1.2.3-beta",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported operator",
+			input:   "^1.2",
+			wantErr: true,
+		},
+	}
+
+	ecosystem := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ecosystem.NewVersionRange(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Ecosystem.NewVersionRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVersionRange_Contains(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		version  string
+		want     bool
+	}{
+		{
+			name:     "patched build excluded",
+			rangeStr: ">= 10.0.19041.0, < 10.0.19041.1415",
+			version:  "10.0.19041.1415",
+			want:     false,
+		},
+		{
+			name:     "vulnerable build included",
+			rangeStr: ">= 10.0.19041.0, < 10.0.19041.1415",
+			version:  "10.0.19041.1288",
+			want:     true,
+		},
+		{
+			name:     "other branch excluded",
+			rangeStr: ">= 10.0.19041.0, < 10.0.19041.1415",
+			version:  "10.0.19042.1",
+			want:     false,
+		},
+		{
+			name:     "exact match",
+			rangeStr: "4.8.4084.0",
+			version:  "4.8.4084.0",
+			want:     true,
+		},
+		{
+			name:     "exact match requires same component count",
+			rangeStr: "4.8",
+			version:  "4.8.0.0",
+			want:     false,
+		},
+		{
+			name:     "short lower bound includes longer versions",
+			rangeStr: ">=4.8",
+			version:  "4.8.0",
+			want:     true,
+		},
+		{
+			name:     "not equal",
+			rangeStr: ">=1.0, !=1.0.1",
+			version:  "1.0.1",
+			want:     false,
+		},
+		{
+			name:     "less than or equal",
+			rangeStr: "<=6.2.9200.0",
+			version:  "6.2.9200",
+			want:     true,
+		},
+	}
+
+	ecosystem := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vr, err := ecosystem.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("Failed to parse range %s: %v", tt.rangeStr, err)
+			}
+
+			v, err := ecosystem.NewVersion(tt.version)
+			if err != nil {
+				t.Fatalf("Failed to parse version %s: %v", tt.version, err)
+			}
+
+			got := vr.Contains(v)
+			if got != tt.want {
+				t.Errorf("VersionRange.Contains() = %v, want %v (range=%s, version=%s)", got, tt.want, tt.rangeStr, tt.version)
+			}
+		})
+	}
+}
+
+func TestEcosystem_Features(t *testing.T) {
+	e := &Ecosystem{}
+	for _, f := range e.Features() {
+		if _, err := e.NewVersionRange(f.Syntax); err != nil {
+			t.Errorf("Features() %s syntax %q does not parse: %v", f.Name, f.Syntax, err)
+		}
+	}
+}
+
+func TestVersionRange_Comparators(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		want     string
+	}{
+		{name: "comparisons", rangeStr: ">= 10.0.19041.0, < 10.0.19041.1415", want: "[[>=10.0.19041.0 <10.0.19041.1415]]"},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vr, err := e.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("Ecosystem.NewVersionRange() error = %v", err)
+			}
+			if got := fmt.Sprint(vr.Comparators()); got != tt.want {
+				t.Errorf("VersionRange.Comparators() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package msver
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/alowayed/go-univers/internal/digits"
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+// Version represents a System.Version: two to four non-negative 32-bit
+// components with no prerelease or build metadata. Missing build and revision
+// components are -1, so 1.0 sorts before 1.0.0 and 1.0.0 before 1.0.0.0.
+type Version struct {
+	major    int
+	minor    int
+	build    int
+	revision int
+	original string
+}
+
+// NewVersion creates a new Microsoft version from a string
+func (e *Ecosystem) NewVersion(version string) (*Version, error) {
+	if err := univers.CheckLength(version); err != nil {
+		return nil, err
+	}
+
+	original := version
+	version = strings.TrimSpace(version)
+
+	if version == "" {
+		return nil, fmt.Errorf("invalid Microsoft version: empty string")
+	}
+
+	parts := strings.Split(version, ".")
+	if len(parts) < 2 || len(parts) > 4 {
+		return nil, fmt.Errorf("invalid Microsoft version: %s (must have 2 to 4 components)", original)
+	}
+
+	components := []int{-1, -1, -1, -1}
+	for i, part := range parts {
+		if part == "" || strings.Trim(part, "0123456789") != "" {
+			return nil, fmt.Errorf("invalid Microsoft version: %s (non-numeric component: %q)", original, part)
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil || n > math.MaxInt32 {
+			return nil, fmt.Errorf("invalid Microsoft version: %s (component too large: %s)", original, part)
+		}
+		components[i] = n
+	}
+
+	return &Version{
+		major:    components[0],
+		minor:    components[1],
+		build:    components[2],
+		revision: components[3],
+		original: original,
+	}, nil
+}
+
+// ValidVersion reports whether version is a valid Microsoft version, the
+// same check NewVersion makes but without allocating.
+func ValidVersion(version string) bool {
+	if univers.CheckLength(version) != nil {
+		return false
+	}
+	version = strings.TrimSpace(version)
+	for count := 1; ; count++ {
+		part, rest, more := strings.Cut(version, ".")
+		if part == "" || strings.Trim(part, "0123456789") != "" || !digits.AtMost(part, maxInt32Digits) {
+			return false
+		}
+		if !more {
+			return count >= 2
+		}
+		if count == 4 {
+			return false
+		}
+		version = rest
+	}
+}
+
+// maxInt32Digits is math.MaxInt32 in decimal, the largest component
+var maxInt32Digits = strconv.Itoa(math.MaxInt32)
+
+// String returns the string representation of the version
+func (v *Version) String() string {
+	return v.original
+}
+
+// Major returns the major component
+func (v *Version) Major() int {
+	return v.major
+}
+
+// Minor returns the minor component
+func (v *Version) Minor() int {
+	return v.minor
+}
+
+// Build returns the build component, or -1 if it is not set
+func (v *Version) Build() int {
+	return v.build
+}
+
+// Revision returns the revision component, or -1 if it is not set
+func (v *Version) Revision() int {
+	return v.revision
+}
+
+// Compare compares this version with another Microsoft version component by
+// component, with unset components (-1) sorting before zero
+func (v *Version) Compare(other *Version) int {
+	if c := compareInt(v.major, other.major); c != 0 {
+		return c
+	}
+	if c := compareInt(v.minor, other.minor); c != 0 {
+		return c
+	}
+	if c := compareInt(v.build, other.build); c != 0 {
+		return c
+	}
+	return compareInt(v.revision, other.revision)
+}
+
+// compareInt returns -1 if a < b, 0 if a == b, 1 if a > b
+func compareInt(a, b int) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,192 @@
+package msver
+
+import (
+	"testing"
+)
+
+func TestEcosystem_NewVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    [4]int
+		wantErr bool
+	}{
+		// Valid versions
+		{
+			name:  "two components",
+			input: "1.2",
+			want:  [4]int{1, 2, -1, -1},
+		},
+		{
+			name:  "three components",
+			input: "1.2.3",
+			want:  [4]int{1, 2, 3, -1},
+		},
+		{
+			name:  "four components",
+			input: "10.0.19041.1415",
+			want:  [4]int{10, 0, 19041, 1415},
+		},
+		{
+			name:  "leading zeros",
+			input: "1.02.003.0004",
+			want:  [4]int{1, 2, 3, 4},
+		},
+		{
+			name:  "max int32",
+			input: "2147483647.0",
+			want:  [4]int{2147483647, 0, -1, -1},
+		},
+		{
+			name:  "whitespace",
+			input: "  1.2.3.4  ",
+			want:  [4]int{1, 2, 3, 4},
+		},
+		// Error cases
+		{
+			name:    "empty string",
+			input:   "",
+			wantErr: true,
+		},
+		{
+			name:    "single component",
+			input:   "1",
+			wantErr: true,
+		},
+		{
+			name:    "five components",
+			input:   "1.2.3.4.5",
+			wantErr: true,
+		},
+		{
+			name:    "empty component",
+			input:   "1..3",
+			wantErr: true,
+		},
+		{
+			name:    "negative component",
+			input:   "1.-2",
+			wantErr: true,
+		},
+		{
+			name:    "prerelease",
+			input:   "1.2.3-beta",
+			wantErr: true,
+		},
+		{
+			name:    "v prefix",
+			input:   "v1.2",
+			wantErr: true,
+		},
+		{
+			name:    "component too large",
+			input:   "2147483648.0",
+			wantErr: true,
+		},
+	}
+
+	ecosystem := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ecosystem.NewVersion(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Ecosystem.NewVersion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+
+			if got.String() != tt.input {
+				t.Errorf("Version.String() = %q, want %q", got.String(), tt.input)
+			}
+			parts := [4]int{got.Major(), got.Minor(), got.Build(), got.Revision()}
+			if parts != tt.want {
+				t.Errorf("Version components = %v, want %v", parts, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersion_Compare(t *testing.T) {
+	tests := []struct {
+		name string
+		v1   string
+		v2   string
+		want int
+	}{
+		{
+			name: "equal",
+			v1:   "10.0.19041.1415",
+			v2:   "10.0.19041.1415",
+			want: 0,
+		},
+		{
+			name: "leading zeros are insignificant",
+			v1:   "1.02",
+			v2:   "1.2",
+			want: 0,
+		},
+		{
+			name: "missing build sorts before zero",
+			v1:   "1.0",
+			v2:   "1.0.0",
+			want: -1,
+		},
+		{
+			name: "missing revision sorts before zero",
+			v1:   "1.0.0",
+			v2:   "1.0.0.0",
+			want: -1,
+		},
+		{
+			name: "revision",
+			v1:   "10.0.19041.1415",
+			v2:   "10.0.19041.1288",
+			want: 1,
+		},
+		{
+			name: "build outranks revision",
+			v1:   "10.0.19042.1",
+			v2:   "10.0.19041.9999",
+			want: 1,
+		},
+		{
+			name: "numeric comparison",
+			v1:   "6.10",
+			v2:   "6.9",
+			want: 1,
+		},
+		{
+			name: "major outranks minor",
+			v1:   "2.0",
+			v2:   "1.99",
+			want: 1,
+		},
+	}
+
+	ecosystem := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v1, err := ecosystem.NewVersion(tt.v1)
+			if err != nil {
+				t.Fatalf("Failed to parse v1 %s: %v", tt.v1, err)
+			}
+
+			v2, err := ecosystem.NewVersion(tt.v2)
+			if err != nil {
+				t.Fatalf("Failed to parse v2 %s: %v", tt.v2, err)
+			}
+
+			got := v1.Compare(v2)
+			if got != tt.want {
+				t.Errorf("Version.Compare() = %v, want %v (v1=%s, v2=%s)", got, tt.want, tt.v1, tt.v2)
+			}
+
+			reverse := v2.Compare(v1)
+			if reverse != -tt.want {
+				t.Errorf("Reverse comparison failed: v2.Compare(v1) = %v, want %v", reverse, -tt.want)
+			}
+		})
+	}
+}
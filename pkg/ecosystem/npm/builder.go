@@ -0,0 +1,103 @@
+package npm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RangeBuilder composes an npm version range from comparators so callers never
+// format range syntax by hand:
+//
+//	r, err := npm.NewRangeBuilder().GTE("1.2.3").LT("2.0.0").Or().Caret("3.0.0").Build()
+//	// >=1.2.3 <2.0.0 || ^3.0.0
+//
+// Comparators are ANDed within a comparator set and Or starts a new set.
+// Versions are emitted in normalized form, so prefixes such as "v" or "="
+// cannot change the operator. Errors from any step are reported by Build.
+type RangeBuilder struct {
+	groups [][]string
+	err    error
+}
+
+// NewRangeBuilder returns a builder with one empty comparator set
+func NewRangeBuilder() *RangeBuilder {
+	return &RangeBuilder{groups: [][]string{nil}}
+}
+
+// GT adds a >version comparator to the current set
+func (b *RangeBuilder) GT(version string) *RangeBuilder {
+	return b.add(">", version)
+}
+
+// GTE adds a >=version comparator to the current set
+func (b *RangeBuilder) GTE(version string) *RangeBuilder {
+	return b.add(">=", version)
+}
+
+// LT adds a <version comparator to the current set
+func (b *RangeBuilder) LT(version string) *RangeBuilder {
+	return b.add("<", version)
+}
+
+// LTE adds a <=version comparator to the current set
+func (b *RangeBuilder) LTE(version string) *RangeBuilder {
+	return b.add("<=", version)
+}
+
+// EQ adds an exact version to the current set
+func (b *RangeBuilder) EQ(version string) *RangeBuilder {
+	return b.add("", version)
+}
+
+// Caret adds a ^version range to the current set
+func (b *RangeBuilder) Caret(version string) *RangeBuilder {
+	return b.add("^", version)
+}
+
+// Tilde adds a ~version range to the current set
+func (b *RangeBuilder) Tilde(version string) *RangeBuilder {
+	return b.add("~", version)
+}
+
+// Or starts a new comparator set
+func (b *RangeBuilder) Or() *RangeBuilder {
+	b.groups = append(b.groups, nil)
+	return b
+}
+
+// add appends a comparator to the current set, recording the first invalid version
+func (b *RangeBuilder) add(operator, version string) *RangeBuilder {
+	v, err := (&Ecosystem{}).NewVersion(version)
+	if err != nil {
+		if b.err == nil {
+			b.err = err
+		}
+		return b
+	}
+	last := len(b.groups) - 1
+	b.groups[last] = append(b.groups[last], operator+v.normalize())
+	return b
+}
+
+// String returns the range in npm syntax, without validating it
+func (b *RangeBuilder) String() string {
+	groups := make([]string, 0, len(b.groups))
+	for _, group := range b.groups {
+		groups = append(groups, strings.Join(group, " "))
+	}
+	return strings.Join(groups, " || ")
+}
+
+// Build returns the range. It fails if a version is invalid or a comparator
+// set is empty.
+func (b *RangeBuilder) Build() (*VersionRange, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	for i, group := range b.groups {
+		if len(group) == 0 {
+			return nil, fmt.Errorf("comparator set %d is empty", i+1)
+		}
+	}
+	return (&Ecosystem{}).NewVersionRange(b.String())
+}
@@ -0,0 +1,95 @@
+package npm
+
+import "testing"
+
+func TestRangeBuilder_Build(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func() *RangeBuilder
+		want    string
+		in      []string
+		out     []string
+		wantErr bool
+	}{
+		{
+			name:  "bounded set",
+			build: func() *RangeBuilder { return NewRangeBuilder().GTE("1.2.3").LT("2.0.0") },
+			want:  ">=1.2.3 <2.0.0",
+			in:    []string{"1.2.3", "1.9.9"},
+			out:   []string{"1.2.2", "2.0.0"},
+		},
+		{
+			name:  "or with caret",
+			build: func() *RangeBuilder { return NewRangeBuilder().GTE("1.2.3").LT("2.0.0").Or().Caret("3.0.0") },
+			want:  ">=1.2.3 <2.0.0 || ^3.0.0",
+			in:    []string{"1.5.0", "3.4.0"},
+			out:   []string{"2.5.0", "4.0.0"},
+		},
+		{
+			name:  "tilde and exact",
+			build: func() *RangeBuilder { return NewRangeBuilder().Tilde("1.2.0").Or().EQ("2.0.0-rc.1") },
+			want:  "~1.2.0 || 2.0.0-rc.1",
+			in:    []string{"1.2.7", "2.0.0-rc.1"},
+			out:   []string{"1.3.0", "2.0.0"},
+		},
+		{
+			name:  "exclusive bounds",
+			build: func() *RangeBuilder { return NewRangeBuilder().GT("1.0.0").LTE("1.1.0") },
+			want:  ">1.0.0 <=1.1.0",
+			in:    []string{"1.1.0"},
+			out:   []string{"1.0.0"},
+		},
+		{
+			name:  "prefixes normalized",
+			build: func() *RangeBuilder { return NewRangeBuilder().GT("=1.0.0").LT("v2.0.0") },
+			want:  ">1.0.0 <2.0.0",
+			in:    []string{"1.5.0"},
+			out:   []string{"1.0.0"},
+		},
+		{
+			name:    "range syntax as version",
+			build:   func() *RangeBuilder { return NewRangeBuilder().GTE("1.0.0 || 0.0.0") },
+			wantErr: true,
+		},
+		{
+			name:    "invalid version",
+			build:   func() *RangeBuilder { return NewRangeBuilder().GTE("1.x") },
+			wantErr: true,
+		},
+		{
+			name:    "no comparators",
+			build:   NewRangeBuilder,
+			wantErr: true,
+		},
+		{
+			name:    "empty set after or",
+			build:   func() *RangeBuilder { return NewRangeBuilder().GTE("1.0.0").Or() },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.build().Build()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Build() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.String() != tt.want {
+				t.Errorf("Build() = %q, want %q", got.String(), tt.want)
+			}
+			for _, s := range tt.in {
+				if !got.Contains(mustNewVersion(t, s)) {
+					t.Errorf("Build().Contains(%q) = false, want true", s)
+				}
+			}
+			for _, s := range tt.out {
+				if got.Contains(mustNewVersion(t, s)) {
+					t.Errorf("Build().Contains(%q) = true, want false", s)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package npm_test
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/npm"
+)
+
+// Prereleases sort before the release, and build metadata is ignored.
+func ExampleVersion_Compare() {
+	e := &npm.Ecosystem{}
+
+	var versions []*npm.Version
+	for _, s := range []string{"1.10.0", "1.2.3", "1.2.3-beta.1", "1.2.3-beta.10", "1.2.3-alpha"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		versions = append(versions, v)
+	}
+	slices.SortFunc(versions, (*npm.Version).Compare)
+	fmt.Println(versions)
+	// Output:
+	// [1.2.3-alpha 1.2.3-beta.1 1.2.3-beta.10 1.2.3 1.10.0]
+}
+
+// ^ allows minor and patch updates.
+func ExampleVersionRange_Contains() {
+	e := &npm.Ecosystem{}
+
+	r, err := e.NewVersionRange("^1.2.0")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, s := range []string{"1.9.9", "2.0.0", "2.0.0-rc.1"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(s, r.Contains(v))
+	}
+	// Output:
+	// 1.9.9 true
+	// 2.0.0 false
+	// 2.0.0-rc.1 false
+}
+
+func ExampleNewRangeBuilder() {
+	r, err := npm.NewRangeBuilder().Caret("1.2.0").Or().Tilde("2.1.0").Build()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(r)
+	// Output:
+	// ^1.2.0 || ~2.1.0
+}
+
+func ExampleVersionRange_Exclude() {
+	e := &npm.Ecosystem{}
+
+	r, err := e.NewVersionRange("^1.2.0")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	bad, err := e.NewVersion("1.2.5")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(r.Exclude(bad))
+	// Output:
+	// >=1.2.0 <1.2.5 || >1.2.5 <2.0.0-0
+}
+
+func ExampleVersionRange_Normalize() {
+	r, err := (&npm.Ecosystem{}).NewVersionRange("~1.2 || 2.x")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(r.Normalize())
+	// Output:
+	// >=1.2.0 <1.3.0-0||>=2.0.0 <3.0.0-0
+}
+
+// ParseRangeInto reuses one range's storage across a loop.
+func ExampleEcosystem_ParseRangeInto() {
+	e := &npm.Ecosystem{}
+	v, err := e.NewVersion("1.5.0")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	var r npm.VersionRange
+	for _, s := range []string{"^1.2.0", ">=2.0.0"} {
+		if err := e.ParseRangeInto(&r, s); err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(s, r.Contains(v))
+	}
+	// Output:
+	// ^1.2.0 true
+	// >=2.0.0 false
+}
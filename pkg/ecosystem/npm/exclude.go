@@ -0,0 +1,205 @@
+package npm
+
+import (
+	"slices"
+	"strings"
+)
+
+// emptyRange is the comparator set that no version satisfies
+const emptyRange = "<0.0.0-0"
+
+// bound is one end of an interval; a nil version means unbounded
+type bound struct {
+	version   *Version
+	inclusive bool
+}
+
+// interval is the set of versions a comparator set matches
+type interval struct {
+	lower bound
+	upper bound
+}
+
+// Exclude returns the tightest range matching every version of this range
+// except the given ones, for pinning around known-bad releases. Each
+// comparator set is reduced to its effective bounds and split around each
+// excluded version it contains, so ^1.2.0 excluding 1.2.5 becomes
+// ">=1.2.0 <1.2.5 || >1.2.5 <2.0.0-0". Comparator sets that match nothing
+// are dropped; if none remain the result is "<0.0.0-0".
+func (nr *VersionRange) Exclude(versions ...*Version) *VersionRange {
+	excluded := slices.Clone(versions)
+	slices.SortFunc(excluded, (*Version).Compare)
+
+	var intervals []interval
+	for _, group := range nr.constraintGroups {
+		in, ok := groupInterval(group)
+		if !ok {
+			continue
+		}
+		pieces := []interval{in}
+		for _, v := range excluded {
+			pieces = splitAround(pieces, v)
+		}
+		intervals = append(intervals, pieces...)
+	}
+
+	groups := make([][]*constraint, 0, len(intervals))
+	rendered := make([]string, 0, len(intervals))
+	for _, in := range intervals {
+		group := in.constraints()
+		groups = append(groups, group)
+		rendered = append(rendered, joinConstraints(group))
+	}
+	if len(groups) == 0 {
+		groups = [][]*constraint{{{operator: "<", version: "0.0.0-0"}}}
+		rendered = []string{emptyRange}
+	}
+
+	return &VersionRange{
+		constraintGroups: groups,
+		original:         strings.Join(rendered, " || "),
+	}
+}
+
+// groupInterval intersects the comparators of a group. It reports false when
+// the group matches no version.
+func groupInterval(group []*constraint) (interval, bool) {
+	var in interval
+	e := &Ecosystem{}
+	for _, c := range group {
+		if c.operator == "*" {
+			continue
+		}
+		v, err := e.NewVersion(c.version)
+		if err != nil {
+			return interval{}, false // matches never succeed
+		}
+
+		switch c.operator {
+		case ">", ">=":
+			in.lower = tighterLower(in.lower, bound{version: v, inclusive: c.operator == ">="})
+		case "<", "<=":
+			in.upper = tighterUpper(in.upper, bound{version: v, inclusive: c.operator == "<="})
+		case "=":
+			in.lower = tighterLower(in.lower, bound{version: v, inclusive: true})
+			in.upper = tighterUpper(in.upper, bound{version: v, inclusive: true})
+		default:
+			return interval{}, false
+		}
+	}
+	return in, !in.empty()
+}
+
+// tighterLower returns the more restrictive of two lower bounds
+func tighterLower(a, b bound) bound {
+	if a.version == nil {
+		return b
+	}
+	switch c := a.version.Compare(b.version); {
+	case c < 0:
+		return b
+	case c > 0:
+		return a
+	}
+	return bound{version: a.version, inclusive: a.inclusive && b.inclusive}
+}
+
+// tighterUpper returns the more restrictive of two upper bounds
+func tighterUpper(a, b bound) bound {
+	if a.version == nil {
+		return b
+	}
+	switch c := a.version.Compare(b.version); {
+	case c > 0:
+		return b
+	case c < 0:
+		return a
+	}
+	return bound{version: a.version, inclusive: a.inclusive && b.inclusive}
+}
+
+// splitAround removes v from each interval that contains it
+func splitAround(intervals []interval, v *Version) []interval {
+	var result []interval
+	for _, in := range intervals {
+		if !in.contains(v) {
+			result = append(result, in)
+			continue
+		}
+		below := interval{lower: in.lower, upper: bound{version: v}}
+		above := interval{lower: bound{version: v}, upper: in.upper}
+		for _, piece := range []interval{below, above} {
+			if !piece.empty() {
+				result = append(result, piece)
+			}
+		}
+	}
+	return result
+}
+
+// contains reports whether v lies within the interval
+func (in interval) contains(v *Version) bool {
+	if in.lower.version != nil {
+		c := v.Compare(in.lower.version)
+		if c < 0 || (c == 0 && !in.lower.inclusive) {
+			return false
+		}
+	}
+	if in.upper.version != nil {
+		c := v.Compare(in.upper.version)
+		if c > 0 || (c == 0 && !in.upper.inclusive) {
+			return false
+		}
+	}
+	return true
+}
+
+// empty reports whether no version lies within the interval
+func (in interval) empty() bool {
+	if in.lower.version == nil || in.upper.version == nil {
+		return false
+	}
+	c := in.lower.version.Compare(in.upper.version)
+	return c > 0 || (c == 0 && !(in.lower.inclusive && in.upper.inclusive))
+}
+
+// constraints renders the interval as an AND group of comparators
+func (in interval) constraints() []*constraint {
+	lower, upper := in.lower, in.upper
+	if lower.version != nil && upper.version != nil && lower.version.Compare(upper.version) == 0 {
+		return []*constraint{{operator: "=", version: comparatorVersion(lower.version)}}
+	}
+
+	var group []*constraint
+	if lower.version != nil {
+		op := ">"
+		if lower.inclusive {
+			op = ">="
+		}
+		group = append(group, &constraint{operator: op, version: comparatorVersion(lower.version)})
+	}
+	if upper.version != nil {
+		op := "<"
+		if upper.inclusive {
+			op = "<="
+		}
+		group = append(group, &constraint{operator: op, version: comparatorVersion(upper.version)})
+	}
+	if len(group) == 0 {
+		group = append(group, &constraint{operator: "*", version: "*"})
+	}
+	return group
+}
+
+// joinConstraints renders an AND group in npm range syntax
+func joinConstraints(group []*constraint) string {
+	parts := make([]string, 0, len(group))
+	for _, c := range group {
+		if c.operator == "=" {
+			parts = append(parts, c.version)
+			continue
+		}
+		parts = append(parts, c.String())
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,115 @@
+package npm
+
+import "testing"
+
+func TestVersionRange_Exclude(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		exclude  []string
+		want     string
+	}{
+		{
+			name:     "caret range minus one version",
+			rangeStr: "^1.2.0",
+			exclude:  []string{"1.2.5"},
+			want:     ">=1.2.0 <1.2.5 || >1.2.5 <2.0.0-0",
+		},
+		{
+			name:     "unsorted exclusions",
+			rangeStr: "^1.2.0",
+			exclude:  []string{"1.3.0", "1.2.5"},
+			want:     ">=1.2.0 <1.2.5 || >1.2.5 <1.3.0 || >1.3.0 <2.0.0-0",
+		},
+		{
+			name:     "exclusion outside range is ignored",
+			rangeStr: "~1.2.0",
+			exclude:  []string{"2.0.0"},
+			want:     ">=1.2.0 <1.3.0-0",
+		},
+		{
+			name:     "excluding lower bound",
+			rangeStr: ">=1.0.0 <2.0.0",
+			exclude:  []string{"1.0.0"},
+			want:     ">1.0.0 <2.0.0",
+		},
+		{
+			name:     "excluding inclusive upper bound",
+			rangeStr: ">=1.0.0 <=2.0.0",
+			exclude:  []string{"2.0.0"},
+			want:     ">=1.0.0 <2.0.0",
+		},
+		{
+			name:     "wildcard",
+			rangeStr: "*",
+			exclude:  []string{"1.0.0"},
+			want:     "<1.0.0 || >1.0.0",
+		},
+		{
+			name:     "exact version excluded",
+			rangeStr: "1.2.3 || ^2.0.0",
+			exclude:  []string{"1.2.3"},
+			want:     ">=2.0.0 <3.0.0-0",
+		},
+		{
+			name:     "everything excluded",
+			rangeStr: "1.2.3",
+			exclude:  []string{"1.2.3"},
+			want:     "<0.0.0-0",
+		},
+		{
+			name:     "adjacent exclusions",
+			rangeStr: ">=1.0.0 <=1.0.2",
+			exclude:  []string{"1.0.1"},
+			want:     ">=1.0.0 <1.0.1 || >1.0.1 <=1.0.2",
+		},
+		{
+			name:     "no exclusions",
+			rangeStr: ">1.0.0 <1.0.0",
+			want:     "<0.0.0-0",
+		},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vr, err := e.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("NewVersionRange(%q) error = %v", tt.rangeStr, err)
+			}
+			var excluded []*Version
+			for _, s := range tt.exclude {
+				v, err := e.NewVersion(s)
+				if err != nil {
+					t.Fatalf("NewVersion(%q) error = %v", s, err)
+				}
+				excluded = append(excluded, v)
+			}
+
+			got := vr.Exclude(excluded...)
+			if got.String() != tt.want {
+				t.Errorf("Exclude() = %q, want %q", got.String(), tt.want)
+			}
+
+			reparsed, err := e.NewVersionRange(got.String())
+			if err != nil {
+				t.Fatalf("NewVersionRange(%q) error = %v", got.String(), err)
+			}
+			for _, s := range []string{"0.9.0", "1.0.0", "1.0.1", "1.0.2", "1.2.0", "1.2.3", "1.2.4", "1.2.5", "1.2.6", "1.3.0", "1.9.9", "2.0.0", "2.5.0", "3.0.0"} {
+				v, err := e.NewVersion(s)
+				if err != nil {
+					t.Fatalf("NewVersion(%q) error = %v", s, err)
+				}
+				want := vr.Contains(v)
+				for _, x := range excluded {
+					if v.Compare(x) == 0 {
+						want = false
+					}
+				}
+				if got.Contains(v) != want || reparsed.Contains(v) != want {
+					t.Errorf("Exclude().Contains(%s) = %v, reparsed = %v, want %v", s, got.Contains(v), reparsed.Contains(v), want)
+				}
+			}
+		})
+	}
+}
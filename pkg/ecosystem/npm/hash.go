@@ -0,0 +1,38 @@
+package npm
+
+import "github.com/alowayed/go-univers/pkg/univers"
+
+// Hash returns a content hash of the desugared comparators. It does not
+// change with whitespace, comparator order, duplicates, build metadata or
+// spelling, so "^1.2.0", ">=1.2.0 <2.0.0-0" and "<2.0.0-0 >=1.2.0+build"
+// hash the same. Any range matching every version, such as "*" or "x || 1.x",
+// has a single hash.
+func (nr *VersionRange) Hash() uint64 {
+	var groups [][]univers.HashedConstraint
+	for _, constraintGroup := range nr.constraintGroups {
+		var group []univers.HashedConstraint
+		for _, c := range constraintGroup {
+			if c.operator == "*" {
+				continue
+			}
+			group = append(group, univers.HashedConstraint{Operator: c.operator, Key: c.hashKey()})
+		}
+		if len(group) == 0 {
+			// A group of only "*" matches any version
+			return univers.HashConstraints([][]univers.HashedConstraint{{{Operator: "*"}}})
+		}
+		groups = append(groups, group)
+	}
+	return univers.HashConstraints(groups)
+}
+
+// hashKey returns the sort key of the constraint version, or the version as
+// written when it does not parse
+func (c *constraint) hashKey() []byte {
+	e := &Ecosystem{}
+	v, err := e.NewVersion(c.version)
+	if err != nil {
+		return []byte(c.version)
+	}
+	return v.SortKey()
+}
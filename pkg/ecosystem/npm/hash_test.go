@@ -0,0 +1,45 @@
+package npm
+
+import "testing"
+
+func TestVersionRange_Hash(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		wantSame bool
+	}{
+		{name: "identical", a: "^1.2.0", b: "^1.2.0", wantSame: true},
+		{name: "whitespace", a: ">=1.0.0 <2.0.0", b: "  >=1.0.0   <2.0.0 ", wantSame: true},
+		{name: "comparator order", a: ">=1.0.0 <2.0.0", b: "<2.0.0 >=1.0.0", wantSame: true},
+		{name: "group order", a: "^1.0.0 || ^2.0.0", b: "^2.0.0 || ^1.0.0", wantSame: true},
+		{name: "duplicate groups", a: "^1.0.0 || ^1.0.0", b: "^1.0.0", wantSame: true},
+		{name: "caret desugared", a: "^1.2.0", b: ">=1.2.0 <2.0.0-0", wantSame: true},
+		{name: "tilde desugared", a: "~1.2.3", b: ">=1.2.3 <1.3.0-0", wantSame: true},
+		{name: "explicit equals", a: "1.2.3", b: "=1.2.3", wantSame: true},
+		{name: "build metadata", a: ">=1.2.3+build.1", b: ">=1.2.3", wantSame: true},
+		{name: "v prefix", a: ">=v1.2.3", b: ">=1.2.3", wantSame: true},
+		{name: "wildcard in group", a: "* >=1.0.0", b: ">=1.0.0", wantSame: true},
+		{name: "any version", a: "*", b: "1.x || *", wantSame: true},
+		{name: "different version", a: "^1.2.0", b: "^1.3.0"},
+		{name: "different operator", a: ">1.0.0", b: ">=1.0.0"},
+		{name: "AND versus OR", a: ">=1.0.0 <2.0.0", b: ">=1.0.0 || <2.0.0"},
+		{name: "prerelease", a: ">=1.0.0-alpha", b: ">=1.0.0"},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := e.NewVersionRange(tt.a)
+			if err != nil {
+				t.Fatalf("NewVersionRange(%q) error = %v", tt.a, err)
+			}
+			b, err := e.NewVersionRange(tt.b)
+			if err != nil {
+				t.Fatalf("NewVersionRange(%q) error = %v", tt.b, err)
+			}
+			if got := a.Hash() == b.Hash(); got != tt.wantSame {
+				t.Errorf("Hash(%q) == Hash(%q) = %v, want %v", tt.a, tt.b, got, tt.wantSame)
+			}
+		})
+	}
+}
@@ -1,8 +1,10 @@
 // Package npm provides functionality for working with NPM package versions.
 package npm
 
+import "github.com/alowayed/go-univers/pkg/univers"
+
 const (
-	Name = "npm"
+	Name = string(univers.NPM)
 )
 
 type Ecosystem struct{}
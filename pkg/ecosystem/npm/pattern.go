@@ -0,0 +1,13 @@
+//go:build !univers_noregexp
+
+package npm
+
+import "regexp"
+
+// versionPattern matches NPM version strings
+var versionPattern = regexp.MustCompile(`^v?(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?(?:\+([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?$`)
+
+// matchVersion returns the versionPattern submatches of s, or nil
+func matchVersion(s string) []string {
+	return versionPattern.FindStringSubmatch(s)
+}
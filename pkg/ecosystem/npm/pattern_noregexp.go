@@ -0,0 +1,8 @@
+//go:build univers_noregexp
+
+package npm
+
+// matchVersion returns the versionPattern submatches of s, or nil
+func matchVersion(s string) []string {
+	return scanVersion(s)
+}
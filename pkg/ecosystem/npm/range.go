@@ -2,8 +2,11 @@ package npm
 
 import (
 	"fmt"
-	"strconv"
+	"slices"
 	"strings"
+	"unicode"
+
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 // VersionRange represents an NPM version range with NPM-specific syntax support
@@ -18,72 +21,225 @@ type constraint struct {
 	version  string
 }
 
+// features declares the range syntax accepted by NewVersionRange
+var features = []univers.Feature{
+	{Name: univers.FeatureComparison, Syntax: ">=1.2.3", Description: "Ordering operators >=, >, <=, < and ="},
+	{Name: univers.FeatureExact, Syntax: "1.2.3", Description: "A bare version matches only itself"},
+	{Name: univers.FeatureAnd, Syntax: ">=1.2.3 <2.0.0", Description: "Space-separated comparators must all match"},
+	{Name: univers.FeatureOr, Syntax: "^1.0.0 || ^2.0.0", Description: "Matches if any ||-separated comparator set matches"},
+	{Name: univers.FeatureCaret, Syntax: "^1.2.3", Description: "Allows changes that keep the left-most non-zero component"},
+	{Name: univers.FeatureTilde, Syntax: "~1.2.3", Description: "Allows patch-level changes"},
+	{Name: univers.FeatureWildcard, Syntax: "1.2.x", Description: "X-ranges; x, X and * match any value for that component"},
+	{Name: univers.FeatureHyphen, Syntax: "1.2.3 - 2.3.4", Description: "Inclusive range between two versions"},
+}
+
+// Features returns the range syntax features supported by NewVersionRange
+func (e *Ecosystem) Features() []univers.Feature {
+	return slices.Clone(features)
+}
+
 // NewVersionRange creates a new NPM version range from a range string
 func (e *Ecosystem) NewVersionRange(rangeStr string) (*VersionRange, error) {
+	vr := &VersionRange{}
+	if err := e.ParseRangeInto(vr, rangeStr); err != nil {
+		return nil, err
+	}
+	return vr, nil
+}
+
+// ParseRangeInto parses a range string like NewVersionRange, but stores the
+// result in dst and reuses the constraint storage dst already holds. Hot loops
+// that check many short-lived ranges can parse them all into one VersionRange
+// to cut allocations. The range previously held by dst is overwritten, so it
+// must no longer be in use. On error dst matches no version.
+func (e *Ecosystem) ParseRangeInto(dst *VersionRange, rangeStr string) error {
+	input := rangeStr
 	rangeStr = strings.TrimSpace(rangeStr)
+	groups := dst.constraintGroups[:0]
+	dst.constraintGroups, dst.original = groups, ""
+	if err := univers.CheckLength(input); err != nil {
+		return err
+	}
 	if rangeStr == "" {
-		return nil, fmt.Errorf("empty range string")
+		return univers.WrapParseError(input, fmt.Errorf("empty range string"))
 	}
 
-	constraintGroups, err := parseRangeGroups(rangeStr)
+	groups, err := appendRangeGroups(groups, rangeStr)
+	if err != nil {
+		return univers.WrapParseError(input, err)
+	}
+
+	if err := univers.CheckConstraintGroups(groups); err != nil {
+		return err
+	}
+
+	dst.constraintGroups, dst.original = groups, rangeStr
+	return nil
+}
+
+// maxParenDepth bounds how deeply parentheses may nest in a range
+const maxParenDepth = 32
+
+// appendRangeGroups parses NPM range syntax into constraint groups for OR
+// logic, appending them to groups. Comparator sets are separated by "||"
+// outside parentheses, so a set wrapped in parentheses may hold "||"
+// alternatives of its own. The constraint slices of groups beyond its length
+// are reused.
+func appendRangeGroups(groups [][]*constraint, rangeStr string) ([][]*constraint, error) {
+	p := &rangeParser{src: rangeStr}
+	groups, err := p.appendGroups(groups)
 	if err != nil {
 		return nil, err
 	}
+	if p.pos < len(rangeStr) {
+		// Only an unmatched ")" stops the outermost level early
+		return nil, univers.TokenErrorAt(rangeStr, p.pos, ")", fmt.Errorf("unbalanced parentheses"))
+	}
+	return groups, nil
+}
 
-	return &VersionRange{
-		constraintGroups: constraintGroups,
-		original:         rangeStr,
-	}, nil
+// rangeParser reads a range in a single pass, descending one level for each
+// parenthesized comparator set
+type rangeParser struct {
+	src   string
+	pos   int
+	depth int
 }
 
-// parseRangeGroups parses NPM range syntax into constraint groups for OR logic
-func parseRangeGroups(rangeStr string) ([][]*constraint, error) {
-	// Handle OR logic (||) - each OR'd part becomes a separate group
-	if strings.Contains(rangeStr, "||") {
-		parts := strings.Split(rangeStr, "||")
-		var constraintGroups [][]*constraint
-		for _, part := range parts {
-			constraints, err := parseRange(strings.TrimSpace(part))
-			if err != nil {
-				return nil, err
-			}
-			constraintGroups = append(constraintGroups, constraints)
+// appendGroups parses "||"-separated comparator sets from the current
+// position, appending their groups to groups. It stops at the end of the
+// input or before a ")" that closes the enclosing set.
+func (p *rangeParser) appendGroups(groups [][]*constraint) ([][]*constraint, error) {
+	for {
+		p.skipSpace()
+		var err error
+		if p.pos < len(p.src) && p.src[p.pos] == '(' {
+			groups, err = p.appendParenthesized(groups)
+		} else {
+			groups, err = p.appendComparatorSet(groups)
+		}
+		if err != nil {
+			return nil, err
 		}
-		return constraintGroups, nil
+
+		if !strings.HasPrefix(p.src[p.pos:], "||") {
+			return groups, nil
+		}
+		p.pos += len("||")
 	}
+}
 
-	// Single group (no OR logic)
-	constraints, err := parseRange(rangeStr)
+// appendParenthesized parses a comparator set wrapped in parentheses, such as
+// "(>=1.0.0 <2.0.0)", as the range they enclose
+func (p *rangeParser) appendParenthesized(groups [][]*constraint) ([][]*constraint, error) {
+	open := p.pos
+	if p.depth == maxParenDepth {
+		return nil, univers.TokenErrorAt(p.src, open, "(", fmt.Errorf("parentheses nested deeper than %d", maxParenDepth))
+	}
+	p.depth++
+	p.pos++
+	groups, err := p.appendGroups(groups)
 	if err != nil {
 		return nil, err
 	}
-	return [][]*constraint{constraints}, nil
+	if p.pos == len(p.src) {
+		return nil, univers.TokenErrorAt(p.src, open, "(", fmt.Errorf("unbalanced parentheses"))
+	}
+	p.depth--
+	p.pos++ // skip the ")"
+
+	// The parentheses must enclose the whole set, as in "(a)" but not "(a) b"
+	p.skipSpace()
+	if rest := p.src[p.pos:]; rest != "" && rest[0] != ')' && !strings.HasPrefix(rest, "||") {
+		return nil, univers.TokenErrorAt(p.src, p.pos, rest[:1], fmt.Errorf("parentheses must enclose a whole comparator set"))
+	}
+	return groups, nil
 }
 
-// parseRange parses NPM range syntax into constraints
-func parseRange(rangeStr string) ([]*constraint, error) {
-	// Trim whitespace and remove parentheses
-	rangeStr = strings.TrimSpace(rangeStr)
-	rangeStr = strings.TrimPrefix(rangeStr, "(")
-	rangeStr = strings.TrimSuffix(rangeStr, ")")
+// appendComparatorSet parses the comparator set that runs from the current
+// position to the next "||", ")" or the end of the input and appends its
+// group to groups
+func (p *rangeParser) appendComparatorSet(groups [][]*constraint) ([][]*constraint, error) {
+	start := p.pos
+	for p.pos < len(p.src) && p.src[p.pos] != ')' && !strings.HasPrefix(p.src[p.pos:], "||") {
+		p.pos++
+	}
 
-	// Handle hyphen ranges (1.2.3 - 2.3.4)
-	// Also catch malformed hyphen ranges like "1.2.3 -" or "- 1.2.3"
-	if strings.Contains(rangeStr, " - ") || strings.HasSuffix(rangeStr, " -") || strings.HasPrefix(rangeStr, "- ") {
-		return parseHyphenRange(rangeStr)
+	part := strings.TrimSpace(p.src[start:p.pos])
+	constraints, err := parseRange(reusedGroup(groups), part)
+	if err != nil {
+		return nil, univers.TokenErrorAt(p.src, start, part, err)
 	}
+	return append(groups, constraints), nil
+}
 
-	// Handle space-separated constraints (>=1.0.0 <2.0.0)
-	if strings.Contains(rangeStr, " ") && !strings.HasPrefix(rangeStr, "^") && !strings.HasPrefix(rangeStr, "~") {
-		return parseSpaceSeparatedConstraints(rangeStr)
+// skipSpace advances past any whitespace at the current position
+func (p *rangeParser) skipSpace() {
+	for p.pos < len(p.src) && unicode.IsSpace(rune(p.src[p.pos])) {
+		p.pos++
 	}
+}
 
-	// Handle single constraint
-	return parseSingleConstraint(rangeStr)
+// reusedGroup returns the emptied constraint slice stored just past the end
+// of groups, or nil if there is none
+func reusedGroup(groups [][]*constraint) []*constraint {
+	if len(groups) == cap(groups) {
+		return nil
+	}
+	return groups[:len(groups)+1][len(groups)][:0]
+}
+
+// parseRange parses a comparator set, either a hyphen range or
+// whitespace-separated comparators, into constraints appended to dst. An
+// operator followed by whitespace, as in ">= 1.2.3" or "^ 1.2.3", is still a
+// single comparator, and any whitespace may surround the hyphen of a hyphen
+// range.
+func parseRange(dst []*constraint, rangeStr string) ([]*constraint, error) {
+	if i := strings.IndexAny(rangeStr, "()"); i >= 0 {
+		return nil, univers.TokenErrorAt(rangeStr, i, rangeStr[i:i+1], fmt.Errorf("parentheses must enclose a whole comparator set"))
+	}
+
+	// A single comparator, the common case, needs no splitting
+	if rangeStr != "" && !strings.ContainsFunc(rangeStr, unicode.IsSpace) {
+		dst, err := parseSingleConstraint(dst, rangeStr)
+		if err != nil {
+			return nil, univers.TokenError(rangeStr, err)
+		}
+		return dst, nil
+	}
+
+	fields := univers.ConstraintFields(rangeStr, nil)
+	if len(fields) == 0 {
+		// As in node-semver, an empty comparator set, as in "1.x ||", matches
+		// any version
+		return append(dst, &constraint{operator: "*", version: "*"}), nil
+	}
+
+	for i, f := range fields {
+		if f.Text != "-" {
+			continue
+		}
+		// A hyphen range is the whole set: "1.2.3 - 2.3.4", never
+		// "1.2.3 - 2.3.4 >=1.5.0"
+		if i != 1 || len(fields) != 3 {
+			return nil, univers.TokenErrorAt(rangeStr, f.Offset, f.Text, fmt.Errorf("invalid hyphen range: %s", rangeStr))
+		}
+		return parseHyphenRange(dst, rangeStr, fields[0], fields[2])
+	}
+
+	for _, f := range fields {
+		var err error
+		dst, err = parseSingleConstraint(dst, f.Text)
+		if err != nil {
+			return nil, univers.TokenErrorAt(rangeStr, f.Offset, f.Text, err)
+		}
+	}
+	return dst, nil
 }
 
-// parseSingleConstraint parses a single NPM constraint
-func parseSingleConstraint(c string) ([]*constraint, error) {
+// parseSingleConstraint parses a single NPM constraint into constraints
+// appended to dst, as do the parsers for each constraint syntax below
+func parseSingleConstraint(dst []*constraint, c string) ([]*constraint, error) {
 	c = strings.TrimSpace(c)
 
 	// Check for invalid characters
@@ -93,39 +249,68 @@ func parseSingleConstraint(c string) ([]*constraint, error) {
 
 	// Handle wildcard
 	if c == "*" {
-		return []*constraint{{operator: "*", version: "*"}}, nil
+		return append(dst, &constraint{operator: "*", version: "*"}), nil
 	}
 
 	// Handle caret range (^1.2.3)
 	if strings.HasPrefix(c, "^") {
-		return parseCaretRange(c[1:])
+		return parseCaretRange(dst, c[1:])
 	}
 
 	// Handle tilde range (~1.2.3)
 	if strings.HasPrefix(c, "~") {
-		return parseTildeRange(c[1:])
+		return parseTildeRange(dst, c[1:])
 	}
 
-	// Handle x-range (1.x, 1.2.x)
-	if strings.Contains(c, "x") || strings.Contains(c, "X") {
-		return parseXRange(c)
+	// Handle comparison operators; a bare version is an exact match
+	op, version := "", c
+	for _, candidate := range []string{">=", "<=", "!=", ">", "<", "="} {
+		if strings.HasPrefix(c, candidate) {
+			op, version = candidate, strings.TrimSpace(c[len(candidate):])
+			if version == "" {
+				return nil, fmt.Errorf("missing version after operator %s", op)
+			}
+			break
+		}
 	}
 
-	// Handle comparison operators
-	operators := []string{">=", "<=", "!=", ">", "<", "="}
-	for _, op := range operators {
-		if strings.HasPrefix(c, op) {
-			version := strings.TrimSpace(c[len(op):])
-			return []*constraint{{operator: op, version: version}}, nil
+	p, err := parsePartial(version)
+	if err != nil {
+		return nil, err
+	}
+	if p.n == 3 {
+		if op == "" {
+			op = "="
 		}
+		return append(dst, &constraint{operator: op, version: version}), nil
 	}
-
-	// Default to exact match
-	return []*constraint{{operator: "=", version: c}}, nil
+	return appendPartial(dst, op, p)
 }
 
-// parseCaretRange handles caret ranges (^1.2.3)
-func parseCaretRange(version string) ([]*constraint, error) {
+// parseCaretRange handles caret ranges (^1.2.3). A partial version allows
+// changes below the left-most non-zero component it gives, so ^1.2 means
+// >=1.2.0 <2.0.0-0, ^0.2 means >=0.2.0 <0.3.0-0 and ^0 means >=0.0.0 <1.0.0-0.
+func parseCaretRange(dst []*constraint, version string) ([]*constraint, error) {
+	version = strings.TrimSpace(version)
+	p, err := parsePartial(version)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case p.n == 0:
+		return append(dst, &constraint{operator: "*", version: "*"}), nil
+	case p.n == 1 || (p.n == 2 && p.major != 0):
+		return append(dst,
+			&constraint{operator: ">=", version: fmt.Sprintf("%d.%d.0", p.major, p.minor)},
+			&constraint{operator: "<", version: fmt.Sprintf("%d.0.0-0", p.major+1)},
+		), nil
+	case p.n == 2:
+		return append(dst,
+			&constraint{operator: ">=", version: fmt.Sprintf("0.%d.0", p.minor)},
+			&constraint{operator: "<", version: fmt.Sprintf("0.%d.0-0", p.minor+1)},
+		), nil
+	}
+
 	e := &Ecosystem{}
 	v, err := e.NewVersion(version)
 	if err != nil {
@@ -136,27 +321,49 @@ func parseCaretRange(version string) ([]*constraint, error) {
 	if v.major == 0 {
 		if v.minor == 0 {
 			// ^0.0.3 means >=0.0.3 <0.0.4 (only patch changes)
-			return []*constraint{
-				{operator: ">=", version: v.normalize()},
-				{operator: "<", version: fmt.Sprintf("0.0.%d", v.patch+1)},
-			}, nil
+			return append(dst,
+				&constraint{operator: ">=", version: v.normalize()},
+				&constraint{operator: "<", version: fmt.Sprintf("0.0.%d", v.patch+1)},
+			), nil
 		}
 		// ^0.2.3 means >=0.2.3 <0.3.0-0 (patch and minor changes, excludes prereleases from next minor)
-		return []*constraint{
-			{operator: ">=", version: v.normalize()},
-			{operator: "<", version: fmt.Sprintf("0.%d.0-0", v.minor+1)},
-		}, nil
+		return append(dst,
+			&constraint{operator: ">=", version: v.normalize()},
+			&constraint{operator: "<", version: fmt.Sprintf("0.%d.0-0", v.minor+1)},
+		), nil
 	}
 
 	// ^1.2.3 means >=1.2.3 <2.0.0-0 (excludes prereleases from next major)
-	return []*constraint{
-		{operator: ">=", version: v.normalize()},
-		{operator: "<", version: fmt.Sprintf("%d.0.0-0", v.major+1)},
-	}, nil
+	return append(dst,
+		&constraint{operator: ">=", version: v.normalize()},
+		&constraint{operator: "<", version: fmt.Sprintf("%d.0.0-0", v.major+1)},
+	), nil
 }
 
-// parseTildeRange handles tilde ranges (~1.2.3)
-func parseTildeRange(version string) ([]*constraint, error) {
+// parseTildeRange handles tilde ranges (~1.2.3), also written ~>1.2.3. A
+// partial version allows changes below the last component it gives, so ~1.2
+// means >=1.2.0 <1.3.0-0 and ~1 means >=1.0.0 <2.0.0-0.
+func parseTildeRange(dst []*constraint, version string) ([]*constraint, error) {
+	version = strings.TrimSpace(strings.TrimPrefix(version, ">"))
+	p, err := parsePartial(version)
+	if err != nil {
+		return nil, err
+	}
+	switch p.n {
+	case 0:
+		return append(dst, &constraint{operator: "*", version: "*"}), nil
+	case 1:
+		return append(dst,
+			&constraint{operator: ">=", version: fmt.Sprintf("%d.0.0", p.major)},
+			&constraint{operator: "<", version: fmt.Sprintf("%d.0.0-0", p.major+1)},
+		), nil
+	case 2:
+		return append(dst,
+			&constraint{operator: ">=", version: fmt.Sprintf("%d.%d.0", p.major, p.minor)},
+			&constraint{operator: "<", version: fmt.Sprintf("%d.%d.0-0", p.major, p.minor+1)},
+		), nil
+	}
+
 	e := &Ecosystem{}
 	v, err := e.NewVersion(version)
 	if err != nil {
@@ -164,96 +371,174 @@ func parseTildeRange(version string) ([]*constraint, error) {
 	}
 
 	// ~1.2.3 means >=1.2.3 <1.3.0-0 (excludes prereleases from next minor)
-	return []*constraint{
-		{operator: ">=", version: v.normalize()},
-		{operator: "<", version: fmt.Sprintf("%d.%d.0-0", v.major, v.minor+1)},
-	}, nil
+	return append(dst,
+		&constraint{operator: ">=", version: v.normalize()},
+		&constraint{operator: "<", version: fmt.Sprintf("%d.%d.0-0", v.major, v.minor+1)},
+	), nil
 }
 
-// parseXRange handles x-ranges (1.x, 1.2.x)
-func parseXRange(rangeStr string) ([]*constraint, error) {
-	parts := strings.Split(rangeStr, ".")
-	if len(parts) < 2 {
-		return nil, fmt.Errorf("invalid x-range: %s", rangeStr)
-	}
+// partial is a version that may omit its minor and patch or give them as x,
+// X or *, as comparators, x-ranges and hyphen ranges allow
+type partial struct {
+	major, minor int
+	// n is the number of numeric components given, from 0 for "*" to 3 for
+	// a full version
+	n int
+}
 
-	major, err := strconv.Atoi(parts[0])
-	if err != nil {
-		return nil, fmt.Errorf("invalid major version in x-range: %s", parts[0])
+// parsePartial parses a possibly partial version such as "1", "1.2.x" or
+// "v1.2.3-rc.1". Build metadata is ignored. Only full versions may carry a
+// prerelease.
+func parsePartial(version string) (partial, error) {
+	var p partial
+	core, _, _ := strings.Cut(version, "+")
+	core, _, hasPrerelease := strings.Cut(core, "-")
+	core = strings.TrimPrefix(strings.TrimPrefix(core, "v"), "=")
+
+	wildcard := false
+	for i := 0; core != "" || i == 0; i++ {
+		var part string
+		part, core, _ = strings.Cut(core, ".")
+		if i == 3 {
+			return p, fmt.Errorf("invalid NPM version: %s", version)
+		}
+		// Wildcards only follow numeric components, as in "1.x.x" but not "x.1"
+		if part == "x" || part == "X" || part == "*" {
+			wildcard = true
+			continue
+		}
+		n, ok := parseNum(part)
+		if !ok || wildcard {
+			return p, fmt.Errorf("invalid NPM version: %s", version)
+		}
+		switch p.n {
+		case 0:
+			p.major = n
+		case 1:
+			p.minor = n
+		}
+		p.n++
 	}
 
-	// 1.x means >=1.0.0-0 <2.0.0-0 (includes prereleases in range, excludes prereleases from next major)
-	if len(parts) == 2 && (parts[1] == "x" || parts[1] == "X") {
-		return []*constraint{
-			{operator: ">=", version: fmt.Sprintf("%d.0.0-0", major)},
-			{operator: "<", version: fmt.Sprintf("%d.0.0-0", major+1)},
-		}, nil
+	switch {
+	case p.n == 3 && !ValidVersion(version):
+		return p, fmt.Errorf("invalid NPM version: %s", version)
+	case p.n < 3 && hasPrerelease:
+		return p, fmt.Errorf("prerelease on partial version: %s", version)
 	}
+	return p, nil
+}
 
-	// 1.2.x means >=1.2.0-0 <1.3.0-0 (includes prereleases in range, excludes prereleases from next minor)
-	if len(parts) == 3 && (parts[2] == "x" || parts[2] == "X") {
-		minor, err := strconv.Atoi(parts[1])
-		if err != nil {
-			return nil, fmt.Errorf("invalid minor version in x-range: %s", parts[1])
+// appendPartial appends the comparators that op applied to a partial version
+// desugars to, following node-semver: 1.x, like 1, means >=1.0.0 <2.0.0-0,
+// so neither includes the prereleases of 1.0.0; >1.2 means >=1.3.0; <=1.2
+// means <1.3.0-0; and >=1 and <1 compare with 1.0.0 and 1.0.0-0.
+func appendPartial(dst []*constraint, op string, p partial) ([]*constraint, error) {
+	if p.n == 0 {
+		switch op {
+		case ">", "<":
+			// Nothing is above or below every version
+			return append(dst, &constraint{operator: "<", version: "0.0.0-0"}), nil
 		}
-		return []*constraint{
-			{operator: ">=", version: fmt.Sprintf("%d.%d.0-0", major, minor)},
-			{operator: "<", version: fmt.Sprintf("%d.%d.0-0", major, minor+1)},
-		}, nil
+		return append(dst, &constraint{operator: "*", version: "*"}), nil
 	}
 
-	return nil, fmt.Errorf("unsupported x-range format: %s", rangeStr)
-}
-
-// parseHyphenRange handles hyphen ranges (1.2.3 - 2.3.4)
-func parseHyphenRange(rangeStr string) ([]*constraint, error) {
-	parts := strings.Split(rangeStr, " - ")
-	if len(parts) != 2 {
-		return nil, fmt.Errorf("invalid hyphen range: %s", rangeStr)
+	lower, next := fmt.Sprintf("%d.0.0", p.major), fmt.Sprintf("%d.0.0", p.major+1)
+	if p.n == 2 {
+		lower, next = fmt.Sprintf("%d.%d.0", p.major, p.minor), fmt.Sprintf("%d.%d.0", p.major, p.minor+1)
 	}
 
-	start := strings.TrimSpace(parts[0])
-	end := strings.TrimSpace(parts[1])
+	switch op {
+	case "", "=":
+		return append(dst,
+			&constraint{operator: ">=", version: lower},
+			&constraint{operator: "<", version: next + "-0"},
+		), nil
+	case ">=":
+		return append(dst, &constraint{operator: ">=", version: lower}), nil
+	case ">":
+		return append(dst, &constraint{operator: ">=", version: next}), nil
+	case "<":
+		return append(dst, &constraint{operator: "<", version: lower + "-0"}), nil
+	case "<=":
+		return append(dst, &constraint{operator: "<", version: next + "-0"}), nil
+	}
+	return nil, fmt.Errorf("%s requires a full version", op)
+}
 
-	// Both parts must be non-empty and valid versions
-	if start == "" || end == "" {
-		return nil, fmt.Errorf("invalid hyphen range: %s", rangeStr)
+// parseHyphenRange handles hyphen ranges (1.2.3 - 2.3.4) whose bounds are the
+// fields start and end of rangeStr. A partial start is filled with zeros and
+// a partial end bounds the versions it covers, so 1.2 - 2 means
+// >=1.2.0 <3.0.0-0.
+func parseHyphenRange(dst []*constraint, rangeStr string, start, end univers.Field) ([]*constraint, error) {
+	from, err := parsePartial(start.Text)
+	if err != nil {
+		return nil, univers.TokenErrorAt(rangeStr, start.Offset, start.Text, fmt.Errorf("invalid start version in hyphen range: %s", start.Text))
+	}
+	to, err := parsePartial(end.Text)
+	if err != nil {
+		return nil, univers.TokenErrorAt(rangeStr, end.Offset, end.Text, fmt.Errorf("invalid end version in hyphen range: %s", end.Text))
 	}
 
-	// Validate that both parts are valid versions
-	e := &Ecosystem{}
-	if _, err := e.NewVersion(start); err != nil {
-		return nil, fmt.Errorf("invalid start version in hyphen range: %s", start)
+	n := len(dst)
+	if from.n == 3 {
+		dst = append(dst, &constraint{operator: ">=", version: start.Text})
+	} else if from.n > 0 {
+		dst, _ = appendPartial(dst, ">=", from)
 	}
-	if _, err := e.NewVersion(end); err != nil {
-		return nil, fmt.Errorf("invalid end version in hyphen range: %s", end)
+	if to.n == 3 {
+		dst = append(dst, &constraint{operator: "<=", version: end.Text})
+	} else if to.n > 0 {
+		dst, _ = appendPartial(dst, "<=", to)
 	}
+	if len(dst) == n {
+		// * - * matches any version
+		dst = append(dst, &constraint{operator: "*", version: "*"})
+	}
+	return dst, nil
+}
 
-	return []*constraint{
-		{operator: ">=", version: start},
-		{operator: "<=", version: end},
-	}, nil
+// String returns the string representation of the range
+func (nr *VersionRange) String() string {
+	return nr.original
 }
 
-// parseSpaceSeparatedConstraints handles space-separated constraints (>=1.0.0 <2.0.0)
-func parseSpaceSeparatedConstraints(rangeStr string) ([]*constraint, error) {
-	parts := strings.Fields(rangeStr)
-	var constraints []*constraint
+// Raw returns the range as written, with build metadata and caret, tilde,
+// x-range and hyphen syntax intact. It is the same as String.
+func (nr *VersionRange) Raw() string {
+	return nr.original
+}
 
-	for _, part := range parts {
-		partConstraints, err := parseSingleConstraint(part)
-		if err != nil {
-			return nil, err
+// Normalize returns node-semver's canonical form of the range, as reported by
+// its Range.range property: sugar is desugared to comparators, build metadata
+// is dropped, "=" is omitted, comparator sets are joined with "||" and a range
+// matching any version is "".
+func (nr *VersionRange) Normalize() string {
+	var groups []string
+	for _, constraintGroup := range nr.constraintGroups {
+		var comparators []string
+		for _, c := range constraintGroup {
+			s := c.normalize()
+			if s != "" && !slices.Contains(comparators, s) {
+				comparators = append(comparators, s)
+			}
+		}
+		group := strings.Join(comparators, " ")
+		if group == "" {
+			return "" // any version matches
 		}
-		constraints = append(constraints, partConstraints...)
+		groups = append(groups, group)
 	}
 
-	return constraints, nil
-}
-
-// String returns the string representation of the range
-func (nr *VersionRange) String() string {
-	return nr.original
+	// node-semver drops comparator sets that match nothing unless all do
+	if len(groups) > 1 {
+		kept := slices.DeleteFunc(slices.Clone(groups), func(g string) bool { return g == emptyRange })
+		if len(kept) == 0 {
+			kept = groups[:1]
+		}
+		groups = kept
+	}
+	return strings.Join(groups, "||")
 }
 
 // Contains checks if a version is within this range
@@ -306,3 +591,76 @@ func (c *constraint) matches(version *Version) bool {
 		return false
 	}
 }
+
+// Comparators returns the constraints as an OR of AND groups of comparators,
+// with sugar expanded and versions in canonical form
+func (nr *VersionRange) Comparators() [][]univers.Comparator {
+	groups := make([][]univers.Comparator, 0, len(nr.constraintGroups))
+	for _, constraintGroup := range nr.constraintGroups {
+		group := make([]univers.Comparator, 0, len(constraintGroup))
+		for _, c := range constraintGroup {
+			if c.operator == "*" {
+				continue
+			}
+			version := c.version
+			if v, err := (&Ecosystem{}).NewVersion(c.version); err == nil {
+				version = comparatorVersion(v)
+			}
+			group = append(group, univers.Comparator{Operator: c.operator, Version: version})
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// TraceConstraints returns the parsed constraints as an OR of AND groups,
+// with caret, tilde, x-range and hyphen syntax expanded to comparators.
+func (nr *VersionRange) TraceConstraints() [][]string {
+	groups := make([][]string, 0, len(nr.constraintGroups))
+	for _, constraintGroup := range nr.constraintGroups {
+		group := make([]string, 0, len(constraintGroup))
+		for _, c := range constraintGroup {
+			group = append(group, c.String())
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// TraceMatches reports whether the version satisfies each constraint returned by TraceConstraints
+func (nr *VersionRange) TraceMatches(version *Version) [][]bool {
+	groups := make([][]bool, 0, len(nr.constraintGroups))
+	for _, constraintGroup := range nr.constraintGroups {
+		group := make([]bool, 0, len(constraintGroup))
+		for _, c := range constraintGroup {
+			group = append(group, c.matches(version))
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// normalize returns the constraint in node-semver's canonical comparator
+// form, or "" for the wildcard
+func (c *constraint) normalize() string {
+	if c.operator == "*" {
+		return ""
+	}
+	e := &Ecosystem{}
+	v, err := e.NewVersion(c.version)
+	if err != nil {
+		return c.String()
+	}
+	if c.operator == "=" {
+		return comparatorVersion(v)
+	}
+	return c.operator + comparatorVersion(v)
+}
+
+// String returns the constraint in comparator form
+func (c *constraint) String() string {
+	if c.operator == "*" {
+		return "*"
+	}
+	return c.operator + c.version
+}
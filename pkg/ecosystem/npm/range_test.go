@@ -1,7 +1,12 @@
 package npm
 
 import (
+	"errors"
+	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 func TestEcosystem_NewVersionRange(t *testing.T) {
@@ -96,6 +101,66 @@ func TestEcosystem_NewVersionRange(t *testing.T) {
 			input:        "(>=1.0.0 <2.0.0)",
 			wantOriginal: "(>=1.0.0 <2.0.0)",
 		},
+		{
+			name:         "OR inside parentheses",
+			input:        "(^1.0.0 || ^2.0.0)",
+			wantOriginal: "(^1.0.0 || ^2.0.0)",
+		},
+		{
+			name:         "nested parentheses",
+			input:        "((^1.0.0)) || (1.2.3 - 2.3.4)",
+			wantOriginal: "((^1.0.0)) || (1.2.3 - 2.3.4)",
+		},
+		{
+			name:    "unbalanced opening parenthesis",
+			input:   "(^1.0.0 || ^2.0.0",
+			wantErr: true,
+		},
+		{
+			name:    "unbalanced closing parenthesis",
+			input:   "^1.0.0)",
+			wantErr: true,
+		},
+		{
+			name:    "parentheses inside comparator set",
+			input:   "(>=1.0.0) (<2.0.0)",
+			wantErr: true,
+		},
+		{
+			name:         "hyphen range with tabs",
+			input:        "1.2.3\t-\t2.3.4",
+			wantOriginal: "1.2.3\t-\t2.3.4",
+		},
+		{
+			name:         "hyphen range with partial versions",
+			input:        "1.2 - 2",
+			wantOriginal: "1.2 - 2",
+		},
+		{
+			name:    "hyphen range with extra comparator",
+			input:   "1.2.3 - 2.3.4 >=1.5.0",
+			wantErr: true,
+		},
+		{
+			name:    "parentheses nested too deeply",
+			input:   strings.Repeat("(", maxParenDepth+1) + "1.2.3" + strings.Repeat(")", maxParenDepth+1),
+			wantErr: true,
+		},
+		{
+			name:         "parentheses nested to the limit",
+			input:        strings.Repeat("(", maxParenDepth) + "1.2.3" + strings.Repeat(")", maxParenDepth),
+			wantOriginal: strings.Repeat("(", maxParenDepth) + "1.2.3" + strings.Repeat(")", maxParenDepth),
+		},
+		{
+			name:    "invalid comparator version",
+			input:   ">bogus",
+			wantErr: true,
+		},
+		{
+			name:    "partial version with prerelease",
+			input:   ">=1.2-beta",
+			wantErr: true,
+		},
 		{
 			name:    "invalid characters",
 			input:   "1.2.3@invalid",
@@ -123,6 +188,43 @@ func TestEcosystem_NewVersionRange(t *testing.T) {
 	}
 }
 
+func TestEcosystem_NewVersionRange_ParseError(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantPos   int
+		wantToken string
+	}{
+		{name: "invalid second constraint", input: ">=1.0.0 <2.x.y", wantPos: 8, wantToken: "<2.x.y"},
+		{name: "repeated token", input: ">=1 >=1.x.x.x", wantPos: 4, wantToken: ">=1.x.x.x"},
+		{name: "invalid hyphen end", input: "1.2.3 - bad", wantPos: 8, wantToken: "bad"},
+		{name: "invalid OR group", input: "^1.0.0 || ^bad", wantPos: 10, wantToken: "^bad"},
+		{name: "invalid group in parentheses", input: "^1.0.0 || (>=2.0.0 <bad)", wantPos: 19, wantToken: "<bad"},
+		{name: "unbalanced parenthesis", input: "^1.0.0 || (^2.0.0", wantPos: 10, wantToken: "("},
+		{name: "hyphen range with extra comparator", input: "1.2.3 - 2.3.4 >=1.5.0", wantPos: 6, wantToken: "-"},
+		{name: "leading whitespace", input: "  ~bad", wantPos: 2, wantToken: "~bad"},
+		{name: "spaced operator", input: ">=1.0.0 <  2.x.y", wantPos: 8, wantToken: "<2.x.y"},
+		{name: "operator without version", input: ">=1.0.0 <", wantPos: 8, wantToken: "<"},
+		{name: "empty", input: "", wantPos: 0, wantToken: ""},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := e.NewVersionRange(tt.input)
+
+			var pe *univers.ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("NewVersionRange(%q) error = %v, want *univers.ParseError", tt.input, err)
+			}
+			if pe.Input != tt.input || pe.Pos != tt.wantPos || pe.Token != tt.wantToken {
+				t.Errorf("NewVersionRange(%q) error = {Input: %q, Pos: %d, Token: %q}, want {Input: %q, Pos: %d, Token: %q}",
+					tt.input, pe.Input, pe.Pos, pe.Token, tt.input, tt.wantPos, tt.wantToken)
+			}
+		})
+	}
+}
+
 func TestVersionRange_Contains(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -141,6 +243,17 @@ func TestVersionRange_Contains(t *testing.T) {
 			rangeStr: "1.2.3",
 			version:  "1.2.4",
 		},
+		{
+			name:     "space after operator",
+			rangeStr: ">= 1.2.3",
+			version:  "1.2.3",
+			want:     true,
+		},
+		{
+			name:     "space after operators in a range",
+			rangeStr: ">= 1.2.3 < 2.0.0",
+			version:  "2.0.0",
+		},
 		{
 			name:     "caret range match",
 			rangeStr: "^1.2.3",
@@ -208,6 +321,30 @@ func TestVersionRange_Contains(t *testing.T) {
 			version:  "1.0.0-beta",
 			want:     true,
 		},
+		{
+			name:     "caret range and comparator",
+			rangeStr: "^1.2.3 <1.5.0",
+			version:  "1.4.9",
+			want:     true,
+		},
+		{
+			name:     "caret range and comparator excludes",
+			rangeStr: "^1.2.3 <1.5.0",
+			version:  "1.5.0",
+			want:     false,
+		},
+		{
+			name:     "tilde range and comparator",
+			rangeStr: "~1.2.3 >1.2.4",
+			version:  "1.2.4",
+			want:     false,
+		},
+		{
+			name:     "caret range with space",
+			rangeStr: "^ 1.2.3",
+			version:  "1.9.0",
+			want:     true,
+		},
 		{
 			name:     "caret range with prerelease base",
 			rangeStr: "^1.2.3-alpha",
@@ -288,15 +425,39 @@ func TestVersionRange_Contains(t *testing.T) {
 			want:     true,
 		},
 		{
-			name:     "X-range includes prereleases - major",
+			name:     "X-range excludes prereleases of its lower bound - major",
 			rangeStr: "1.x",
 			version:  "1.0.0-alpha",
-			want:     true,
 		},
 		{
-			name:     "X-range includes prereleases - minor",
+			name:     "X-range excludes prereleases of its lower bound - minor",
 			rangeStr: "1.2.x",
 			version:  "1.2.0-beta",
+		},
+		{
+			name:     "partial version excludes prereleases of its lower bound",
+			rangeStr: "=1.2",
+			version:  "1.2.0-rc.1",
+		},
+		{
+			name:     "major-only version excludes prereleases of its lower bound",
+			rangeStr: "1",
+			version:  "1.0.0-rc.1",
+		},
+		{
+			name:     "partial tilde excludes prereleases of its lower bound",
+			rangeStr: "~1.2",
+			version:  "1.2.0-rc.1",
+		},
+		{
+			name:     "partial caret excludes prereleases of its lower bound",
+			rangeStr: "^1",
+			version:  "1.0.0-rc.1",
+		},
+		{
+			name:     "partial version includes its release",
+			rangeStr: "=1.2",
+			version:  "1.2.0",
 			want:     true,
 		},
 		{
@@ -305,11 +466,115 @@ func TestVersionRange_Contains(t *testing.T) {
 			version:  "2.5.0-rc.1",
 			want:     true,
 		},
+		{
+			name:     "build metadata ignored in comparator",
+			rangeStr: ">=1.2.3+meta",
+			version:  "1.2.3",
+			want:     true,
+		},
+		{
+			name:     "build metadata with x in comparator",
+			rangeStr: "<2.0.0+x86",
+			version:  "1.9.0",
+			want:     true,
+		},
+		{
+			name:     "prerelease with x in comparator",
+			rangeStr: ">=1.0.0-linux",
+			version:  "1.0.0",
+			want:     true,
+		},
+		{
+			name:     "build metadata on exact version",
+			rangeStr: "1.2.3+build.5",
+			version:  "1.2.3+build.6",
+			want:     true,
+		},
+		{
+			name:     "build metadata on x-range",
+			rangeStr: "1.2.x+meta",
+			version:  "1.2.9",
+			want:     true,
+		},
 		{
 			name:     "X-range excludes different major with prerelease",
 			rangeStr: "1.x",
 			version:  "2.0.0-alpha",
 		},
+		{
+			name:     "partial upper bound in OR branch - match",
+			rangeStr: ">=1.2.3 <2 || ^3.0.0",
+			version:  "1.9.9",
+			want:     true,
+		},
+		{
+			name:     "partial upper bound in OR branch - excludes bound",
+			rangeStr: ">=1.2.3 <2 || ^3.0.0",
+			version:  "2.0.0",
+		},
+		{
+			name:     "partial upper bound in OR branch - excludes bound prerelease",
+			rangeStr: ">=1.2.3 <2 || ^3.0.0",
+			version:  "2.0.0-rc.1",
+		},
+		{
+			name:     "partial upper bound in OR branch - other branch",
+			rangeStr: ">=1.2.3 <2 || ^3.0.0",
+			version:  "3.4.0",
+			want:     true,
+		},
+		{
+			name:     "greater than partial skips the rest of it",
+			rangeStr: ">1.2",
+			version:  "1.2.9",
+		},
+		{
+			name:     "less than or equal partial includes the rest of it",
+			rangeStr: "<=1.2",
+			version:  "1.2.9",
+			want:     true,
+		},
+		{
+			name:     "bare partial version",
+			rangeStr: "1.2",
+			version:  "1.2.7",
+			want:     true,
+		},
+		{
+			name:     "partial hyphen range end",
+			rangeStr: "1.2 - 2",
+			version:  "2.9.0",
+			want:     true,
+		},
+		{
+			name:     "partial hyphen range end excludes next major",
+			rangeStr: "1.2 - 2",
+			version:  "3.0.0",
+		},
+		{
+			name:     "hyphen range with mixed whitespace",
+			rangeStr: "1.2.3 \t-  2.3.4",
+			version:  "2.3.4",
+			want:     true,
+		},
+		{
+			name:     "hyphen ranges in OR without spaces",
+			rangeStr: "1.0.0 - 2.0.0||3.0.0 - 4.0.0",
+			version:  "3.5.0",
+			want:     true,
+		},
+		{
+			name:     "OR inside parentheses",
+			rangeStr: "(^1.0.0 || ^2.0.0)",
+			version:  "2.1.0",
+			want:     true,
+		},
+		{
+			name:     "empty comparator set matches any version",
+			rangeStr: "1.x ||",
+			version:  "9.0.0",
+			want:     true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -325,6 +590,212 @@ func TestVersionRange_Contains(t *testing.T) {
 	}
 }
 
+func TestVersionRange_TraceConstraints(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		version  string
+		want     [][]string
+		wantHits [][]bool
+	}{
+		{
+			name:     "caret expands to comparators",
+			rangeStr: "^1.2.3",
+			version:  "2.0.0",
+			want:     [][]string{{">=1.2.3", "<2.0.0-0"}},
+			wantHits: [][]bool{{true, false}},
+		},
+		{
+			name:     "build metadata preserved",
+			rangeStr: "^1.2.3+meta",
+			version:  "1.2.3",
+			want:     [][]string{{">=1.2.3+meta", "<2.0.0-0"}},
+			wantHits: [][]bool{{true, true}},
+		},
+		{
+			name:     "OR groups",
+			rangeStr: "<1.0.0 || >=2.0.0",
+			version:  "1.5.0",
+			want:     [][]string{{"<1.0.0"}, {">=2.0.0"}},
+			wantHits: [][]bool{{false}, {false}},
+		},
+		{
+			name:     "wildcard",
+			rangeStr: "*",
+			version:  "1.0.0",
+			want:     [][]string{{"*"}},
+			wantHits: [][]bool{{true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vr := mustNewVersionRange(t, tt.rangeStr)
+			v := mustNewVersion(t, tt.version)
+
+			if got := vr.TraceConstraints(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("VersionRange{%q}.TraceConstraints() = %v, want %v", tt.rangeStr, got, tt.want)
+			}
+			if got := vr.TraceMatches(v); !reflect.DeepEqual(got, tt.wantHits) {
+				t.Errorf("VersionRange{%q}.TraceMatches(%q) = %v, want %v", tt.rangeStr, tt.version, got, tt.wantHits)
+			}
+		})
+	}
+}
+
+func TestVersionRange_Normalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		want     string
+		wantRaw  string
+	}{
+		{
+			name:     "caret",
+			rangeStr: "^1.2.3",
+			want:     ">=1.2.3 <2.0.0-0",
+		},
+		{
+			name:     "caret with build metadata",
+			rangeStr: "^1.2.3+build.5",
+			want:     ">=1.2.3 <2.0.0-0",
+		},
+		{
+			name:     "comparator with build metadata",
+			rangeStr: ">=1.2.3+meta <2.0.0",
+			want:     ">=1.2.3 <2.0.0",
+		},
+		{
+			name:     "exact version drops operator and v prefix",
+			rangeStr: "=v1.2.3+meta",
+			want:     "1.2.3",
+		},
+		{
+			name:     "tilde and hyphen",
+			rangeStr: "~1.2.3 || 2.0.0 - 2.1.0",
+			want:     ">=1.2.3 <1.3.0-0||>=2.0.0 <=2.1.0",
+		},
+		{
+			name:     "duplicate comparators",
+			rangeStr: ">=1.0.0 >=1.0.0 <2.0.0",
+			want:     ">=1.0.0 <2.0.0",
+		},
+		{
+			name:     "wildcard",
+			rangeStr: "*",
+			want:     "",
+		},
+		{
+			name:     "wildcard comparator set matches any",
+			rangeStr: "^1.0.0 || *",
+			want:     "",
+		},
+		{
+			name:     "wildcard alongside comparators",
+			rangeStr: "* >=1.0.0",
+			want:     ">=1.0.0",
+		},
+		{
+			name:     "empty set dropped",
+			rangeStr: "<0.0.0-0 || ^1.0.0",
+			want:     ">=1.0.0 <2.0.0-0",
+		},
+		{
+			name:     "partial comparators",
+			rangeStr: ">=1.2.3 <2 || >1.2 <=2.3",
+			want:     ">=1.2.3 <2.0.0-0||>=1.3.0 <2.4.0-0",
+		},
+		{
+			name:     "x-range",
+			rangeStr: "1.x || =1.2",
+			want:     ">=1.0.0 <2.0.0-0||>=1.2.0 <1.3.0-0",
+		},
+		{
+			name:     "partial tilde",
+			rangeStr: "~1.2",
+			want:     ">=1.2.0 <1.3.0-0",
+		},
+		{
+			name:     "major-only tilde",
+			rangeStr: "~1",
+			want:     ">=1.0.0 <2.0.0-0",
+		},
+		{
+			name:     "tilde with greater-than",
+			rangeStr: "~>1.2",
+			want:     ">=1.2.0 <1.3.0-0",
+		},
+		{
+			name:     "major-only caret",
+			rangeStr: "^1",
+			want:     ">=1.0.0 <2.0.0-0",
+		},
+		{
+			name:     "caret x-range",
+			rangeStr: "^1.x",
+			want:     ">=1.0.0 <2.0.0-0",
+		},
+		{
+			name:     "partial caret",
+			rangeStr: "^1.2",
+			want:     ">=1.2.0 <2.0.0-0",
+		},
+		{
+			name:     "partial caret with zero major",
+			rangeStr: "^0.2",
+			want:     ">=0.2.0 <0.3.0-0",
+		},
+		{
+			name:     "partial caret with zero major and minor",
+			rangeStr: "^0.0",
+			want:     ">=0.0.0 <0.1.0-0",
+		},
+		{
+			name:     "caret wildcard",
+			rangeStr: "^*",
+			want:     "",
+		},
+		{
+			name:     "partial hyphen range",
+			rangeStr: "1.2 - 2",
+			want:     ">=1.2.0 <3.0.0-0",
+		},
+		{
+			name:     "hyphen range with wildcard start",
+			rangeStr: "* - 2.3.4",
+			want:     "<=2.3.4",
+		},
+		{
+			name:     "parentheses",
+			rangeStr: "(>=1.0.0 <2.0.0) || ((^3.0.0))",
+			want:     ">=1.0.0 <2.0.0||>=3.0.0 <4.0.0-0",
+		},
+		{
+			name:     "whitespace trimmed",
+			rangeStr: "  >=1.2.3+meta  ",
+			want:     ">=1.2.3",
+			wantRaw:  ">=1.2.3+meta",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vr := mustNewVersionRange(t, tt.rangeStr)
+
+			if got := vr.Normalize(); got != tt.want {
+				t.Errorf("VersionRange{%q}.Normalize() = %q, want %q", tt.rangeStr, got, tt.want)
+			}
+			wantRaw := tt.wantRaw
+			if wantRaw == "" {
+				wantRaw = tt.rangeStr
+			}
+			if got := vr.Raw(); got != wantRaw {
+				t.Errorf("VersionRange{%q}.Raw() = %q, want %q", tt.rangeStr, got, wantRaw)
+			}
+		})
+	}
+}
+
 // mustNewVersionRange is a helper function to create a new VersionRange.
 func mustNewVersionRange(t *testing.T, s string) *VersionRange {
 	t.Helper()
@@ -335,3 +806,35 @@ func mustNewVersionRange(t *testing.T, s string) *VersionRange {
 	}
 	return vr
 }
+
+func TestEcosystem_Features(t *testing.T) {
+	e := &Ecosystem{}
+	for _, f := range e.Features() {
+		if _, err := e.NewVersionRange(f.Syntax); err != nil {
+			t.Errorf("Features() %s syntax %q does not parse: %v", f.Name, f.Syntax, err)
+		}
+	}
+}
+
+func BenchmarkEcosystem_ParseRangeInto(b *testing.B) {
+	const rangeStr = ">=1.2.0 <1.5.0 || ^2.1.0"
+	e := &Ecosystem{}
+
+	b.Run("NewVersionRange", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := e.NewVersionRange(rangeStr); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("ParseRangeInto", func(b *testing.B) {
+		b.ReportAllocs()
+		dst := &VersionRange{}
+		for b.Loop() {
+			if err := e.ParseRangeInto(dst, rangeStr); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
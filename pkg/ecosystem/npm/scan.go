@@ -0,0 +1,76 @@
+package npm
+
+import (
+	"strings"
+
+	"github.com/alowayed/go-univers/internal/digits"
+)
+
+// scanVersion is a hand-written equivalent of versionPattern.FindStringSubmatch,
+// used by builds with the univers_noregexp tag.
+func scanVersion(s string) []string {
+	var parts [5]string
+	if !scanVersionParts(s, &parts) {
+		return nil
+	}
+	return []string{s, parts[0], parts[1], parts[2], parts[3], parts[4]}
+}
+
+// scanVersionParts matches s against the versionPattern grammar without
+// allocating, storing the major, minor, patch, prerelease and build
+// submatches in parts
+func scanVersionParts(s string, parts *[5]string) bool {
+	rest := strings.TrimPrefix(s, "v")
+	for i := range 3 {
+		n := digits.Prefix(rest)
+		if n == 0 {
+			return false
+		}
+		parts[i], rest = rest[:n], rest[n:]
+		if i < 2 {
+			if !strings.HasPrefix(rest, ".") {
+				return false
+			}
+			rest = rest[1:]
+		}
+	}
+
+	if strings.HasPrefix(rest, "-") {
+		end := strings.IndexByte(rest, '+')
+		if end < 0 {
+			end = len(rest)
+		}
+		parts[3], rest = rest[1:end], rest[end:]
+		if !scanDottedIdentifiers(parts[3]) {
+			return false
+		}
+	}
+	if strings.HasPrefix(rest, "+") {
+		parts[4], rest = rest[1:], ""
+		if !scanDottedIdentifiers(parts[4]) {
+			return false
+		}
+	}
+	return rest == ""
+}
+
+// scanDottedIdentifiers reports whether s is one or more [0-9A-Za-z-]+
+// identifiers separated by dots
+func scanDottedIdentifiers(s string) bool {
+	run := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '.':
+			if run == 0 {
+				return false
+			}
+			run = 0
+		case (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '-':
+			run++
+		default:
+			return false
+		}
+	}
+	return run > 0
+}
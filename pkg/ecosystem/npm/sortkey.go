@@ -0,0 +1,44 @@
+package npm
+
+import (
+	"encoding/binary"
+	"strings"
+)
+
+// SortKey returns a byte key whose lexicographic order matches Compare.
+//
+// The key holds major, minor and patch as fixed-width integers followed by the
+// prerelease. A release is marked with a byte that sorts after every
+// prerelease; each prerelease identifier is tagged so numeric identifiers sort
+// before alphanumeric ones, and the identifier list is terminated so a shorter
+// list sorts first. Build metadata is ignored, as it is in Compare.
+func (v *Version) SortKey() []byte {
+	key := make([]byte, 0, 32)
+	key = appendSortKeyInt(key, v.major)
+	key = appendSortKeyInt(key, v.minor)
+	key = appendSortKeyInt(key, v.patch)
+
+	if v.prerelease == "" {
+		return append(key, 0x02)
+	}
+
+	key = append(key, 0x01)
+	for _, part := range strings.Split(v.prerelease, ".") {
+		if n, ok := parseNum(part); ok {
+			key = append(key, 0x01)
+			key = appendSortKeyInt(key, n)
+			continue
+		}
+		// Identifiers are limited to [0-9A-Za-z-], so 0x00 can terminate them
+		key = append(key, 0x02)
+		key = append(key, part...)
+		key = append(key, 0x00)
+	}
+	return append(key, 0x00)
+}
+
+// appendSortKeyInt appends n as a fixed-width big-endian integer with the sign
+// bit flipped, so negative values sort before positive ones.
+func appendSortKeyInt(key []byte, n int) []byte {
+	return binary.BigEndian.AppendUint64(key, uint64(n)^(1<<63))
+}
@@ -0,0 +1,48 @@
+package npm
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestVersion_SortKey(t *testing.T) {
+	versions := []string{
+		"0.0.0",
+		"0.0.1",
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.0+build.5",
+		"v1.0.1",
+		"1.2.3",
+		"1.10.0",
+		"2.0.0-0",
+		"2.0.0-a",
+		"2.0.0",
+		"10.0.0",
+	}
+
+	e := &Ecosystem{}
+	parsed := make([]*Version, len(versions))
+	for i, s := range versions {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			t.Fatalf("NewVersion(%q) error = %v", s, err)
+		}
+		parsed[i] = v
+	}
+
+	for _, a := range parsed {
+		for _, b := range parsed {
+			want := a.Compare(b)
+			if got := bytes.Compare(a.SortKey(), b.SortKey()); got != want {
+				t.Errorf("bytes.Compare(SortKey(%q), SortKey(%q)) = %d, want %d", a, b, got, want)
+			}
+		}
+	}
+}
@@ -2,13 +2,12 @@ package npm
 
 import (
 	"fmt"
-	"regexp"
 	"strconv"
 	"strings"
-)
 
-// versionPattern matches NPM version strings
-var versionPattern = regexp.MustCompile(`^v?(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?(?:\+([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?$`)
+	"github.com/alowayed/go-univers/internal/digits"
+	"github.com/alowayed/go-univers/pkg/univers"
+)
 
 // Version represents an NPM package version following semantic versioning
 type Version struct {
@@ -22,6 +21,10 @@ type Version struct {
 
 // NewVersion creates a new NPM version from a string
 func (e *Ecosystem) NewVersion(version string) (*Version, error) {
+	if err := univers.CheckLength(version); err != nil {
+		return nil, err
+	}
+
 	original := version
 	// Trim whitespace first
 	version = strings.TrimSpace(version)
@@ -29,7 +32,7 @@ func (e *Ecosystem) NewVersion(version string) (*Version, error) {
 	version = strings.TrimPrefix(version, "v")
 	version = strings.TrimPrefix(version, "=")
 
-	matches := versionPattern.FindStringSubmatch(version)
+	matches := matchVersion(version)
 	if matches == nil {
 		return nil, fmt.Errorf("invalid NPM version: %s", original)
 	}
@@ -59,11 +62,40 @@ func (e *Ecosystem) NewVersion(version string) (*Version, error) {
 	}, nil
 }
 
+// ValidVersion reports whether NewVersion accepts version, without
+// allocating. It suits ingestion filters that only need to accept or reject
+// versions and never compare them.
+func ValidVersion(version string) bool {
+	if univers.CheckLength(version) != nil {
+		return false
+	}
+	version = strings.TrimSpace(version)
+	version = strings.TrimPrefix(version, "v")
+	version = strings.TrimPrefix(version, "=")
+
+	var parts [5]string
+	return scanVersionParts(version, &parts) && digits.FitsInt(parts[0]) && digits.FitsInt(parts[1]) && digits.FitsInt(parts[2])
+}
+
 // String returns the string representation of the version
 func (v *Version) String() string {
 	return v.original
 }
 
+// PrereleaseChannel returns the normalized channel of the prerelease, e.g.
+// "next" for 19.0.0-next.3, or "" for a release. See univers.SplitPrerelease.
+func (v *Version) PrereleaseChannel() string {
+	channel, _, _ := univers.SplitPrerelease(v.prerelease)
+	return channel
+}
+
+// PrereleaseNumber returns the number after the prerelease channel, e.g. 3
+// for 19.0.0-next.3, and whether there is one.
+func (v *Version) PrereleaseNumber() (int, bool) {
+	_, number, ok := univers.SplitPrerelease(v.prerelease)
+	return number, ok
+}
+
 // normalize returns the normalized form of the version
 func (v *Version) normalize() string {
 	result := fmt.Sprintf("%d.%d.%d", v.major, v.minor, v.patch)
@@ -76,6 +108,16 @@ func (v *Version) normalize() string {
 	return result
 }
 
+// comparatorVersion formats v for a comparator, dropping build metadata,
+// which does not affect precedence
+func comparatorVersion(v *Version) string {
+	s := strconv.Itoa(v.major) + "." + strconv.Itoa(v.minor) + "." + strconv.Itoa(v.patch)
+	if v.prerelease != "" {
+		s += "-" + v.prerelease
+	}
+	return s
+}
+
 // Compare compares this version with another NPM version
 func (v *Version) Compare(other *Version) int {
 	// Compare major.minor.patch
@@ -324,3 +324,50 @@ func mustNewVersion(t *testing.T, version string) *Version {
 	}
 	return v
 }
+
+// TestValidVersion checks that ValidVersion agrees with NewVersion on a corpus
+// and on every short string over the characters that matter to the grammar.
+func BenchmarkValidVersion(b *testing.B) {
+	b.Run("ValidVersion", func(b *testing.B) {
+		for b.Loop() {
+			ValidVersion("1.22.333-rc.1+build.5")
+		}
+	})
+	b.Run("NewVersion", func(b *testing.B) {
+		e := &Ecosystem{}
+		for b.Loop() {
+			_, _ = e.NewVersion("1.22.333-rc.1+build.5")
+		}
+	})
+}
+
+func TestVersion_PrereleaseChannel(t *testing.T) {
+	tests := []struct {
+		version     string
+		wantChannel string
+		wantNumber  int
+		wantOK      bool
+	}{
+		{"19.0.0", "", 0, false},
+		{"19.0.0-next.3", "next", 3, true},
+		{"5.0.0-beta.0", "beta", 0, true},
+		{"1.0.0-canary-a1b2", "canary", 0, false},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			v, err := e.NewVersion(tt.version)
+			if err != nil {
+				t.Fatalf("NewVersion(%q) error = %v", tt.version, err)
+			}
+			if got := v.PrereleaseChannel(); got != tt.wantChannel {
+				t.Errorf("PrereleaseChannel() = %q, want %q", got, tt.wantChannel)
+			}
+			number, ok := v.PrereleaseNumber()
+			if number != tt.wantNumber || ok != tt.wantOK {
+				t.Errorf("PrereleaseNumber() = %d, %v, want %d, %v", number, ok, tt.wantNumber, tt.wantOK)
+			}
+		})
+	}
+}
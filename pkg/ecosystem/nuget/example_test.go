@@ -0,0 +1,66 @@
+package nuget_test
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/nuget"
+)
+
+// Prerelease labels sort before the release.
+func ExampleVersion_Compare() {
+	e := &nuget.Ecosystem{}
+
+	var versions []*nuget.Version
+	for _, s := range []string{"2.0.0", "1.0.0", "1.0.0-beta", "1.0.0-alpha", "1.0.1"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		versions = append(versions, v)
+	}
+	slices.SortFunc(versions, (*nuget.Version).Compare)
+	fmt.Println(versions)
+	// Output:
+	// [1.0.0-alpha 1.0.0-beta 1.0.0 1.0.1 2.0.0]
+}
+
+// Ranges use interval notation.
+func ExampleVersionRange_Contains() {
+	e := &nuget.Ecosystem{}
+
+	r, err := e.NewVersionRange("[1.0.0, 2.0.0)")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, s := range []string{"1.5.0", "2.0.0"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(s, r.Contains(v))
+	}
+	// Output:
+	// 1.5.0 true
+	// 2.0.0 false
+}
+
+func ExampleVersion_IsSemVer2() {
+	e := &nuget.Ecosystem{}
+
+	for _, s := range []string{"1.0.0-beta", "1.0.0-beta.1", "1.0.0+build"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(s, v.IsSemVer2())
+	}
+	// Output:
+	// 1.0.0-beta false
+	// 1.0.0-beta.1 true
+	// 1.0.0+build true
+}
@@ -1,11 +1,19 @@
 // Package nuget provides functionality for working with NuGet (.NET) package versions.
 package nuget
 
+import "github.com/alowayed/go-univers/pkg/univers"
+
 const (
-	Name = "nuget"
+	Name = string(univers.NuGet)
 )
 
-type Ecosystem struct{}
+type Ecosystem struct {
+	// ExcludeSemVer2 makes ranges skip SemVer2-only versions, those with a
+	// dotted prerelease label or build metadata such as "1.0.0-beta.1" or
+	// "1.0.0+abc", the way feeds hide them from clients that predate
+	// SemVer 2.0.0 support.
+	ExcludeSemVer2 bool
+}
 
 func (e *Ecosystem) Name() string {
 	return Name
@@ -2,13 +2,17 @@ package nuget
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 // VersionRange represents a NuGet version range with NuGet-specific syntax support
 type VersionRange struct {
-	constraints []*constraint
-	original    string
+	constraints    []*constraint
+	original       string
+	excludeSemVer2 bool // from Ecosystem.ExcludeSemVer2
 }
 
 // constraint represents a single NuGet version constraint
@@ -17,21 +21,45 @@ type constraint struct {
 	version  *Version
 }
 
+// features declares the range syntax accepted by NewVersionRange
+var features = []univers.Feature{
+	{Name: univers.FeatureMinimum, Syntax: "1.0", Description: "A bare version matches itself and anything newer"},
+	{Name: univers.FeatureInterval, Syntax: "[1.0,2.0)", Description: "Bounds in brackets; [ and ] are inclusive, ( and ) exclusive, and [1.0] is an exact match"},
+	{Name: univers.FeatureComparison, Syntax: ">1.0, <=2.0", Description: "Ordering operators >=, >, <=, < and = within a comma-separated list"},
+	{Name: univers.FeatureNotEqual, Syntax: ">=1.0, !=1.0.1", Description: "Excludes a single version within a comma-separated list"},
+	{Name: univers.FeatureAnd, Syntax: ">=1.0, <2.0", Description: "Comma-separated constraints must all match"},
+}
+
+// Features returns the range syntax features supported by NewVersionRange
+func (e *Ecosystem) Features() []univers.Feature {
+	return slices.Clone(features)
+}
+
 // NewVersionRange creates a new NuGet version range from a range string
 func (e *Ecosystem) NewVersionRange(rangeStr string) (*VersionRange, error) {
+	if err := univers.CheckLength(rangeStr); err != nil {
+		return nil, err
+	}
+
+	input := rangeStr
 	rangeStr = strings.TrimSpace(rangeStr)
 	if rangeStr == "" {
-		return nil, fmt.Errorf("empty range string")
+		return nil, univers.WrapParseError(input, fmt.Errorf("empty range string"))
 	}
 
 	constraints, err := parseRange(e, rangeStr)
 	if err != nil {
+		return nil, univers.WrapParseError(input, err)
+	}
+
+	if err := univers.CheckConstraints(len(constraints)); err != nil {
 		return nil, err
 	}
 
 	return &VersionRange{
-		constraints: constraints,
-		original:    rangeStr,
+		constraints:    constraints,
+		original:       rangeStr,
+		excludeSemVer2: e.ExcludeSemVer2,
 	}, nil
 }
 
@@ -223,7 +251,11 @@ func parseCommaSeparatedConstraints(e *Ecosystem, rangeStr string) ([]*constrain
 	parts := strings.Split(rangeStr, ",")
 	var constraints []*constraint
 
+	offset := 0
 	for _, part := range parts {
+		start := offset + strings.Index(rangeStr[offset:], part)
+		offset = start + len(part)
+
 		part = strings.TrimSpace(part)
 		if part == "" {
 			continue
@@ -232,7 +264,7 @@ func parseCommaSeparatedConstraints(e *Ecosystem, rangeStr string) ([]*constrain
 		// Parse each part as a single constraint
 		partConstraints, err := parseSingleConstraint(e, part)
 		if err != nil {
-			return nil, err
+			return nil, univers.TokenErrorAt(rangeStr, start, part, err)
 		}
 		constraints = append(constraints, partConstraints...)
 	}
@@ -276,6 +308,10 @@ func (nr *VersionRange) String() string {
 
 // Contains checks if a version is within this range
 func (nr *VersionRange) Contains(version *Version) bool {
+	if nr.excludeSemVer2 && version.IsSemVer2() {
+		return false
+	}
+
 	// AND logic: ALL constraints must be satisfied
 	for _, constraint := range nr.constraints {
 		if !constraint.matches(version) {
@@ -285,6 +321,16 @@ func (nr *VersionRange) Contains(version *Version) bool {
 	return true
 }
 
+// Comparators returns the constraints as a single AND group. The
+// ExcludeSemVer2 filter is not a constraint and is not listed.
+func (nr *VersionRange) Comparators() [][]univers.Comparator {
+	group := make([]univers.Comparator, 0, len(nr.constraints))
+	for _, c := range nr.constraints {
+		group = append(group, univers.Comparator{Operator: c.operator, Version: c.version.String()})
+	}
+	return [][]univers.Comparator{group}
+}
+
 // matches checks if the given version matches this constraint
 func (c *constraint) matches(version *Version) bool {
 	comparison := version.Compare(c.version)
@@ -1,6 +1,7 @@
 package nuget
 
 import (
+	"fmt"
 	"testing"
 )
 
@@ -122,6 +123,50 @@ func TestVersionRange_Contains(t *testing.T) {
 	}
 }
 
+func TestVersionRange_Contains_ExcludeSemVer2(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		version  string
+		want     bool
+		wantAll  bool
+	}{
+		{"release", "[1.0.0,2.0.0)", "1.5.0", true, true},
+		{"semver1 prerelease", "[1.0.0-a,2.0.0)", "1.5.0-beta", true, true},
+		{"dotted prerelease", "[1.0.0-a,2.0.0)", "1.5.0-beta.1", false, true},
+		{"build metadata", "[1.0.0,2.0.0)", "1.5.0+build", false, true},
+		{"exact semver2 version", "[1.5.0-beta.1]", "1.5.0-beta.1", false, true},
+		{"outside range", "[1.0.0,2.0.0)", "2.5.0", false, false},
+	}
+
+	e := &Ecosystem{ExcludeSemVer2: true}
+	all := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vr, err := e.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("Failed to parse range %s: %v", tt.rangeStr, err)
+			}
+			allVR, err := all.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("Failed to parse range %s: %v", tt.rangeStr, err)
+			}
+
+			v, err := e.NewVersion(tt.version)
+			if err != nil {
+				t.Fatalf("Failed to parse version %s: %v", tt.version, err)
+			}
+
+			if got := vr.Contains(v); got != tt.want {
+				t.Errorf("ExcludeSemVer2 Contains(%s, %s) = %v, want %v", tt.rangeStr, tt.version, got, tt.want)
+			}
+			if got := allVR.Contains(v); got != tt.wantAll {
+				t.Errorf("Contains(%s, %s) = %v, want %v", tt.rangeStr, tt.version, got, tt.wantAll)
+			}
+		})
+	}
+}
+
 func TestVersionRange_String(t *testing.T) {
 	e := &Ecosystem{}
 
@@ -177,3 +222,36 @@ func TestVersionRange_ParseEdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestEcosystem_Features(t *testing.T) {
+	e := &Ecosystem{}
+	for _, f := range e.Features() {
+		if _, err := e.NewVersionRange(f.Syntax); err != nil {
+			t.Errorf("Features() %s syntax %q does not parse: %v", f.Name, f.Syntax, err)
+		}
+	}
+}
+
+func TestVersionRange_Comparators(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		want     string
+	}{
+		{name: "interval", rangeStr: "[1.0.0,2.0.0)", want: "[[>=1.0.0 <2.0.0]]"},
+		{name: "minimum", rangeStr: "1.0.0", want: "[[>=1.0.0]]"},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vr, err := e.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("Ecosystem.NewVersionRange() error = %v", err)
+			}
+			if got := fmt.Sprint(vr.Comparators()); got != tt.want {
+				t.Errorf("VersionRange.Comparators() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
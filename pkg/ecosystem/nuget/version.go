@@ -5,6 +5,9 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/alowayed/go-univers/internal/digits"
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 // versionPattern matches NuGet version strings following SemVer 2.0 with .NET extensions
@@ -23,6 +26,10 @@ type Version struct {
 
 // NewVersion creates a new NuGet version from a string
 func (e *Ecosystem) NewVersion(version string) (*Version, error) {
+	if err := univers.CheckLength(version); err != nil {
+		return nil, err
+	}
+
 	original := version
 	// Trim whitespace first
 	version = strings.TrimSpace(version)
@@ -74,11 +81,60 @@ func (e *Ecosystem) NewVersion(version string) (*Version, error) {
 	}, nil
 }
 
+// ValidVersion reports whether NewVersion accepts version, without
+// allocating. Feed filters use it to drop malformed package versions before
+// any comparison takes place.
+func ValidVersion(version string) bool {
+	if univers.CheckLength(version) != nil {
+		return false
+	}
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if !versionPattern.MatchString(version) {
+		return false
+	}
+
+	// The pattern guarantees one to four dot-separated numbers up front
+	rest := strings.TrimPrefix(version, "v")
+	for range 4 {
+		n := digits.Prefix(rest)
+		if !digits.FitsInt(rest[:n]) {
+			return false
+		}
+		if !strings.HasPrefix(rest[n:], ".") {
+			break
+		}
+		rest = rest[n+1:]
+	}
+	return true
+}
+
 // String returns the string representation of the version
 func (v *Version) String() string {
 	return v.original
 }
 
+// PrereleaseChannel returns the normalized channel of the prerelease label,
+// such as "preview" for 9.0.0-preview.7.24405.7, or "" for a release. See
+// univers.SplitPrerelease.
+func (v *Version) PrereleaseChannel() string {
+	channel, _, _ := univers.SplitPrerelease(v.prerelease)
+	return channel
+}
+
+// PrereleaseNumber returns the number after the prerelease channel, such as 7
+// for 9.0.0-preview.7.24405.7, and whether there is one.
+func (v *Version) PrereleaseNumber() (int, bool) {
+	_, number, ok := univers.SplitPrerelease(v.prerelease)
+	return number, ok
+}
+
+// IsSemVer2 reports whether the version is only visible to SemVer 2.0.0
+// aware clients, as NuGet decides: it has build metadata or a prerelease
+// label with more than one dot-separated part.
+func (v *Version) IsSemVer2() bool {
+	return v.build != "" || strings.Contains(v.prerelease, ".")
+}
+
 // Compare compares this version with another NuGet version
 func (v *Version) Compare(other *Version) int {
 	// Compare major.minor.patch.revision
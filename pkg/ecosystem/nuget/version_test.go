@@ -239,3 +239,65 @@ func TestVersion_String(t *testing.T) {
 		})
 	}
 }
+
+func TestVersion_IsSemVer2(t *testing.T) {
+	e := &Ecosystem{}
+
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"1.2.3", false},
+		{"1.2.3.4", false},
+		{"1.2.3-beta", false},
+		{"1.2.3-beta-2", false},
+		{"1.2.3-beta.1", true},
+		{"1.0.0+abc", true},
+		{"1.2.3-beta+build", true},
+		{"1.2.3.4-beta.1+build.20230101", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			v, err := e.NewVersion(tt.input)
+			if err != nil {
+				t.Fatalf("Failed to parse version %s: %v", tt.input, err)
+			}
+
+			if got := v.IsSemVer2(); got != tt.want {
+				t.Errorf("IsSemVer2(%s) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersion_PrereleaseChannel(t *testing.T) {
+	tests := []struct {
+		version     string
+		wantChannel string
+		wantNumber  int
+		wantOK      bool
+	}{
+		{"9.0.0", "", 0, false},
+		{"9.0.0-preview.7.24405.7", "preview", 7, true},
+		{"1.0.0-beta2", "beta", 2, true},
+		{"1.0.0-RC", "rc", 0, false},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			v, err := e.NewVersion(tt.version)
+			if err != nil {
+				t.Fatalf("NewVersion(%q) error = %v", tt.version, err)
+			}
+			if got := v.PrereleaseChannel(); got != tt.wantChannel {
+				t.Errorf("PrereleaseChannel() = %q, want %q", got, tt.wantChannel)
+			}
+			number, ok := v.PrereleaseNumber()
+			if number != tt.wantNumber || ok != tt.wantOK {
+				t.Errorf("PrereleaseNumber() = %d, %v, want %d, %v", number, ok, tt.wantNumber, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package pypi_test
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/pypi"
+)
+
+// Pre-releases sort before the final release, and post-releases after it.
+func ExampleVersion_Compare() {
+	e := &pypi.Ecosystem{}
+
+	var versions []*pypi.Version
+	for _, s := range []string{"1.0.post1", "1.0", "1.0rc1", "1.0b1", "1.0a10", "1.0a2"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		versions = append(versions, v)
+	}
+	slices.SortFunc(versions, (*pypi.Version).Compare)
+	fmt.Println(versions)
+	// Output:
+	// [1.0a2 1.0a10 1.0b1 1.0rc1 1.0 1.0.post1]
+}
+
+// ~= allows the last given segment to grow.
+func ExampleVersionRange_Contains() {
+	e := &pypi.Ecosystem{}
+
+	r, err := e.NewVersionRange("~=1.4")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, s := range []string{"1.9", "2.0"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(s, r.Contains(v))
+	}
+	// Output:
+	// 1.9 true
+	// 2.0 false
+}
+
+// Spellings that PEP 440 considers the same version share a key.
+func ExampleVersion_CanonicalKey() {
+	e := &pypi.Ecosystem{}
+
+	for _, s := range []string{"1.0-1", "1.0.post1", "V1.0.0.REV1"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(s, v.CanonicalKey())
+	}
+	// Output:
+	// 1.0-1 1.post1
+	// 1.0.post1 1.post1
+	// V1.0.0.REV1 1.post1
+}
+
+func ExampleParseRequirement() {
+	req, err := pypi.ParseRequirement("requests[security]>=2.20,<3; python_version<'3.10'")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(req.Name, req.Extras, req.Specifier, req.Markers)
+	// Output:
+	// requests [security] >=2.20,<3 python_version<'3.10'
+}
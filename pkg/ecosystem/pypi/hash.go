@@ -0,0 +1,24 @@
+package pypi
+
+import "github.com/alowayed/go-univers/pkg/univers"
+
+// Hash returns a content hash of the specifiers. It does not change with
+// whitespace, specifier order, duplicates or equivalent version spellings, so
+// ">=1.0, <2" and "<2.0.0,>=1.0" hash the same. Arbitrary equality ("===")
+// compares strings, so its version is hashed as written.
+func (pr *VersionRange) Hash() uint64 {
+	group := make([]univers.HashedConstraint, 0, len(pr.constraints))
+	for _, c := range pr.constraints {
+		var key []byte
+		switch {
+		case c.parsed == nil:
+			key = []byte(c.version)
+		case c.upper != nil:
+			key = append(c.parsed.SortKey(), c.upper.SortKey()...)
+		default:
+			key = c.parsed.SortKey()
+		}
+		group = append(group, univers.HashedConstraint{Operator: c.operator, Key: key})
+	}
+	return univers.HashConstraints([][]univers.HashedConstraint{group})
+}
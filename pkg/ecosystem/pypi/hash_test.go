@@ -0,0 +1,40 @@
+package pypi
+
+import "testing"
+
+func TestVersionRange_Hash(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		wantSame bool
+	}{
+		{name: "identical", a: ">=1.0", b: ">=1.0", wantSame: true},
+		{name: "whitespace", a: ">=1.0, <2", b: ">=1.0,<2", wantSame: true},
+		{name: "constraint order", a: ">=1.0,<2", b: "<2,>=1.0", wantSame: true},
+		{name: "release padding", a: ">=1.0,<2", b: ">=1,<2.0.0", wantSame: true},
+		{name: "normalized prerelease", a: ">=1.0alpha1", b: ">=1.0a1", wantSame: true},
+		{name: "compatible release", a: "~=1.4.2", b: ">=1.4.2,<1.5", wantSame: true},
+		{name: "different version", a: ">=1.0", b: ">=1.1"},
+		{name: "different operator", a: ">1.0", b: ">=1.0"},
+		{name: "exclusion prefix", a: "!=1.2.*", b: "!=1.3.*"},
+		{name: "exclusion prefix versus version", a: "!=1.2.*", b: "!=1.2"},
+		{name: "arbitrary equality as written", a: "===1.0", b: "===1.0.0"},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := e.NewVersionRange(tt.a)
+			if err != nil {
+				t.Fatalf("NewVersionRange(%q) error = %v", tt.a, err)
+			}
+			b, err := e.NewVersionRange(tt.b)
+			if err != nil {
+				t.Fatalf("NewVersionRange(%q) error = %v", tt.b, err)
+			}
+			if got := a.Hash() == b.Hash(); got != tt.wantSame {
+				t.Errorf("Hash(%q) == Hash(%q) = %v, want %v", tt.a, tt.b, got, tt.wantSame)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+//go:build !univers_noregexp
+
+package pypi
+
+import "regexp"
+
+var (
+	// versionPattern matches lowercase PyPI version strings according to
+	// PEP 440, including the alternative spellings it normalizes: a "v"
+	// prefix, "-", "_" or "." around pre-, post- and dev-release labels,
+	// omitted label numbers and the implicit post-release "1.0-1"
+	versionPattern = regexp.MustCompile(`^v?(?:([0-9]+)!)?([0-9]+(?:\.[0-9]+)*?)(?:[-_.]?(a|b|rc|alpha|beta|c|pre|preview)[-_.]?([0-9]*))?(?:[-_.]?(post|rev|r)[-_.]?([0-9]*)|-([0-9]+))?(?:[-_.]?(dev)[-_.]?([0-9]*))?(?:\+([a-zA-Z0-9]+(?:[-_.][a-zA-Z0-9]+)*))?$`)
+)
+
+// matchVersion returns the versionPattern submatches of s, or nil
+func matchVersion(s string) []string {
+	return versionPattern.FindStringSubmatch(s)
+}
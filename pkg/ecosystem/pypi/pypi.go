@@ -1,8 +1,10 @@
 // Package pypi provides functionality for working with PyPI package versions following PEP 440.
 package pypi
 
+import "github.com/alowayed/go-univers/pkg/univers"
+
 const (
-	Name = "pypi"
+	Name = string(univers.PyPI)
 )
 
 type Ecosystem struct{}
@@ -2,7 +2,12 @@ package pypi
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 // VersionRange represents a PyPI version range with PEP 440 syntax support
@@ -11,150 +16,312 @@ type VersionRange struct {
 	original    string
 }
 
-// NewVersionRange creates a new PyPI version range from a specifier string
+// features declares the range syntax accepted by NewVersionRange
+var features = []univers.Feature{
+	{Name: univers.FeatureComparison, Syntax: ">=1.2", Description: "Ordering operators >=, >, <=, < and =="},
+	{Name: univers.FeatureNotEqual, Syntax: "!=1.2.1", Description: "Excludes a single version, or a prefix as in !=1.2.*"},
+	{Name: univers.FeatureExact, Syntax: "1.2.3", Description: "A bare version matches only itself"},
+	{Name: univers.FeatureAnd, Syntax: ">=1.0, <2.0", Description: "Comma-separated clauses must all match"},
+	{Name: univers.FeatureCompatibleRelease, Syntax: "~=1.4.2", Description: "Allows the last given release component to increase"},
+	{Name: univers.FeatureWildcard, Syntax: "==1.2.*", Description: "Prefix match on the release segment"},
+	{Name: univers.FeatureArbitraryEquality, Syntax: "===1.0+local", Description: "Compares the version string verbatim"},
+}
+
+// Features returns the range syntax features supported by NewVersionRange
+func (e *Ecosystem) Features() []univers.Feature {
+	return slices.Clone(features)
+}
+
+// NewVersionRange creates a new PyPI version range from a specifier string.
+// The specifier is tokenized and every constraint version is parsed once, so
+// Contains only performs comparisons.
 func (e *Ecosystem) NewVersionRange(specifier string) (*VersionRange, error) {
+	vr := &VersionRange{}
+	if err := e.ParseRangeInto(vr, specifier); err != nil {
+		return nil, err
+	}
+	return vr, nil
+}
+
+// ParseRangeInto parses a specifier like NewVersionRange, but stores the
+// result in dst and reuses the constraint storage dst already holds. Hot loops
+// that check many short-lived ranges can parse them all into one VersionRange
+// to cut allocations. The range previously held by dst is overwritten, so it
+// must no longer be in use. On error dst matches every version, like a range
+// without constraints.
+func (e *Ecosystem) ParseRangeInto(dst *VersionRange, specifier string) error {
+	input := specifier
 	specifier = strings.TrimSpace(specifier)
+	constraints := dst.constraints[:0]
+	dst.constraints, dst.original = constraints, ""
+	if err := univers.CheckLength(input); err != nil {
+		return err
+	}
 	if specifier == "" {
-		return nil, fmt.Errorf("empty specifier string")
+		return univers.WrapParseError(input, fmt.Errorf("empty specifier string"))
 	}
 
-	constraints, err := parseSpecifier(specifier)
+	constraints, err := parseSpecifier(constraints, specifier)
 	if err != nil {
-		return nil, err
+		return univers.WrapParseError(input, err)
+	}
+
+	if err := univers.CheckConstraints(len(constraints)); err != nil {
+		return err
 	}
 
-	return &VersionRange{
-		constraints: constraints,
-		original:    specifier,
-	}, nil
+	dst.constraints, dst.original = constraints, specifier
+	return nil
+}
+
+// specifierOperators lists the PEP 440 comparison operators, longest first
+var specifierOperators = []string{"===", "~=", "==", "!=", "<=", ">=", "<", ">"}
+
+// tokenKind identifies the kind of a specifier token
+type tokenKind int
+
+const (
+	tokenOperator tokenKind = iota
+	tokenVersion
+	tokenComma
+)
+
+// token is a lexical element of a specifier along with its byte offset
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
 }
 
-// parseSpecifier parses PyPI version specifiers
-func parseSpecifier(specifier string) ([]*constraint, error) {
-	// Handle comma-separated constraints (AND logic)
-	if strings.Contains(specifier, ",") {
-		parts := strings.Split(specifier, ",")
-		var allConstraints []*constraint
-		for _, part := range parts {
-			constraints, err := parseSpecifier(strings.TrimSpace(part))
-			if err != nil {
-				return nil, err
+// tokenBuffers recycles the scratch token slices used by parseSpecifier
+var tokenBuffers = sync.Pool{
+	New: func() any { return new([]token) },
+}
+
+// tokenize splits a specifier into operator, version and comma tokens in a
+// single pass, appending them to tokens
+func tokenize(tokens []token, specifier string) ([]token, error) {
+	for i := 0; i < len(specifier); {
+		c := specifier[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", pos: i})
+			i++
+		case strings.IndexByte("=!<>~", c) >= 0:
+			op := ""
+			for _, candidate := range specifierOperators {
+				if strings.HasPrefix(specifier[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				end := i + 1
+				for end < len(specifier) && strings.IndexByte("=!<>~", specifier[end]) >= 0 {
+					end++
+				}
+				return nil, univers.TokenErrorAt(specifier, i, specifier[i:end], fmt.Errorf("invalid operator '%s'", specifier[i:end]))
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: op, pos: i})
+			i += len(op)
+		default:
+			end := i
+			// "!" is allowed inside a version as the epoch separator
+			for end < len(specifier) && strings.IndexByte(" \t,=<>~", specifier[end]) < 0 {
+				end++
 			}
-			allConstraints = append(allConstraints, constraints...)
+			tokens = append(tokens, token{kind: tokenVersion, text: specifier[i:end], pos: i})
+			i = end
 		}
-		return allConstraints, nil
 	}
-
-	// Parse single constraint
-	return parseSingleConstraint(specifier)
+	return tokens, nil
 }
 
-// parseSingleConstraint parses a single PyPI constraint
-func parseSingleConstraint(con string) ([]*constraint, error) {
-	con = strings.TrimSpace(con)
-
-	// Handle PEP 440 operators in correct order
-	operators := []string{"===", "~=", "==", "!=", "<=", ">=", "<", ">"}
-	for _, op := range operators {
-		if strings.HasPrefix(con, op) {
-			version := strings.TrimSpace(con[len(op):])
-			if version == "" {
-				return nil, fmt.Errorf("empty version after operator '%s'", op)
-			}
+// parseSpecifier parses PyPI version specifiers into comma-separated (AND)
+// constraints appended to constraints
+func parseSpecifier(constraints []*constraint, specifier string) ([]*constraint, error) {
+	buf := tokenBuffers.Get().(*[]token)
+	defer func() {
+		clear(*buf)
+		tokenBuffers.Put(buf)
+	}()
 
-			// Handle compatible release operator (~=)
-			if op == "~=" {
-				return parseCompatibleRelease(version)
-			}
+	tokens, err := tokenize((*buf)[:0], specifier)
+	if err != nil {
+		return nil, err
+	}
+	*buf = tokens
 
-			// Handle wildcard in equality (==1.2.* or !=1.2.*)
-			if (op == "==" || op == "!=") && strings.HasSuffix(version, ".*") {
-				return parseWildcardConstraint(op, version)
+	for i := 0; i <= len(tokens); {
+		// Each clause is an optional operator followed by exactly one version
+		start := len(specifier)
+		if i < len(tokens) {
+			start = tokens[i].pos
+		}
+		op := "=="
+		if i < len(tokens) && tokens[i].kind == tokenOperator {
+			op = tokens[i].text
+			i++
+		}
+		if i >= len(tokens) || tokens[i].kind != tokenVersion {
+			clause := strings.TrimSpace(specifier[min(start, len(specifier)):])
+			if end := strings.IndexByte(clause, ','); end >= 0 {
+				clause = strings.TrimSpace(clause[:end])
+			}
+			if op != "==" || clause == "" {
+				return nil, univers.TokenErrorAt(specifier, start, clause, fmt.Errorf("empty version after operator '%s'", op))
 			}
+			return nil, univers.TokenErrorAt(specifier, start, clause, fmt.Errorf("expected version"))
+		}
+		version := tokens[i]
+		i++
+
+		constraints, err = parseClause(constraints, op, version.text)
+		if err != nil {
+			return nil, univers.TokenErrorAt(specifier, version.pos, version.text, err)
+		}
 
-			return []*constraint{{operator: op, version: version}}, nil
+		if i == len(tokens) {
+			break
 		}
+		if tokens[i].kind != tokenComma {
+			return nil, univers.TokenErrorAt(specifier, tokens[i].pos, tokens[i].text, fmt.Errorf("expected ',' between constraints"))
+		}
+		i++
+	}
+
+	return constraints, nil
+}
+
+// parseClause builds the constraints for a single operator and version,
+// appending them to dst as do the parsers for each operator below
+func parseClause(dst []*constraint, op, version string) ([]*constraint, error) {
+	switch {
+	case op == "===":
+		// Arbitrary equality compares strings, the version need not be valid
+		return append(dst, &constraint{operator: op, version: version}), nil
+	case op == "~=":
+		return parseCompatibleRelease(dst, version)
+	case (op == "==" || op == "!=") && strings.HasSuffix(version, ".*"):
+		return parseWildcardConstraint(dst, op, version)
 	}
+	return newConstraint(dst, op, version)
+}
 
-	// Default to equality
-	return []*constraint{{operator: "==", version: con}}, nil
+// newConstraint appends a single constraint with its version pre-parsed
+func newConstraint(dst []*constraint, op, version string) ([]*constraint, error) {
+	e := &Ecosystem{}
+	v, err := e.NewVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	return append(dst, &constraint{operator: op, version: version, parsed: v}), nil
 }
 
 // parseCompatibleRelease handles the ~= operator
-func parseCompatibleRelease(version string) ([]*constraint, error) {
+func parseCompatibleRelease(dst []*constraint, version string) ([]*constraint, error) {
 	e := &Ecosystem{}
 	v, err := e.NewVersion(version)
 	if err != nil {
 		return nil, err
 	}
 
-	// ~=2.2 is equivalent to >=2.2, <3.0
+	// ~=2 is not valid PEP 440 but is treated as >=2, <3
 	if len(v.release) == 1 {
-		upperVersion := fmt.Sprintf("%d.0", v.release[0]+1)
-		return []*constraint{
-			{operator: ">=", version: version},
-			{operator: "<", version: upperVersion},
-		}, nil
+		upper, err := e.NewVersion(fmt.Sprintf("%d.0", v.release[0]+1))
+		if err != nil {
+			return nil, err
+		}
+		return append(dst,
+			&constraint{operator: ">=", version: version, parsed: v},
+			&constraint{operator: "<", version: upper.String(), parsed: upper},
+		), nil
 	}
 
-	// ~=1.4.2 is equivalent to >=1.4.2, <1.5.0
+	// ~=1.4.2 is equivalent to >=1.4.2, ==1.4.*, i.e. <1.5, and ~=2.2 to
+	// >=2.2, ==2.*, i.e. <3: drop the last release component and increment
+	// the one before it
 	if len(v.release) >= 2 {
-		upperVersion := fmt.Sprintf("%d.%d.0", v.release[0], v.release[1]+1)
-		return []*constraint{
-			{operator: ">=", version: version},
-			{operator: "<", version: upperVersion},
-		}, nil
+		prefix := slices.Clone(v.release[:len(v.release)-1])
+		prefix[len(prefix)-1]++
+		parts := make([]string, len(prefix))
+		for i, n := range prefix {
+			parts[i] = strconv.Itoa(n)
+		}
+		upper, err := e.NewVersion(strings.Join(parts, "."))
+		if err != nil {
+			return nil, err
+		}
+		return append(dst,
+			&constraint{operator: ">=", version: version, parsed: v},
+			&constraint{operator: "<", version: upper.String(), parsed: upper},
+		), nil
 	}
 
-	return []*constraint{{operator: ">=", version: version}}, nil
+	return append(dst, &constraint{operator: ">=", version: version, parsed: v}), nil
 }
 
 // parseWildcardConstraint handles wildcard constraints like ==1.2.* or !=1.2.*
-func parseWildcardConstraint(operator, version string) ([]*constraint, error) {
+func parseWildcardConstraint(dst []*constraint, operator, version string) ([]*constraint, error) {
 	// Remove the .* suffix
 	baseVersion := strings.TrimSuffix(version, ".*")
 
 	e := &Ecosystem{}
-	v, err := e.NewVersion(baseVersion + ".0")
+	v, err := e.NewVersion(baseVersion)
 	if err != nil {
 		return nil, err
 	}
+	if v.prerelease != "" || v.postrelease >= 0 || v.dev >= 0 || v.local != "" {
+		return nil, fmt.Errorf("unsupported wildcard constraint: %s%s", operator, version)
+	}
 
-	if operator == "==" {
-		// ==1.2.* means >=1.2.0, <1.3.0
-		if len(v.release) >= 2 {
-			lowerBound := fmt.Sprintf("%d.%d.0", v.release[0], v.release[1])
-			upperBound := fmt.Sprintf("%d.%d.0", v.release[0], v.release[1]+1)
-			return []*constraint{
-				{operator: ">=", version: lowerBound},
-				{operator: "<", version: upperBound},
-			}, nil
-		}
+	// The bounds span the release segments written before the wildcard, so
+	// 1.* spans >=1.0.0, <2.0.0 and 1.2.3.* spans >=1.2.3, <1.2.4. Short
+	// prefixes are padded to three segments.
+	segments := slices.Clone(v.release)
+	for len(segments) < 3 {
+		segments = append(segments, 0)
+	}
+	last := len(v.release) - 1
+	lowerBound := formatWildcardBound(v.epoch, segments)
+	segments[last]++
+	for i := last + 1; i < len(segments); i++ {
+		segments[i] = 0
+	}
+	upperBound := formatWildcardBound(v.epoch, segments)
 
-		// ==1.* means >=1.0.0, <2.0.0
-		if len(v.release) >= 1 {
-			lowerBound := fmt.Sprintf("%d.0.0", v.release[0])
-			upperBound := fmt.Sprintf("%d.0.0", v.release[0]+1)
-			return []*constraint{
-				{operator: ">=", version: lowerBound},
-				{operator: "<", version: upperBound},
-			}, nil
-		}
+	lower, err := e.NewVersion(lowerBound)
+	if err != nil {
+		return nil, err
+	}
+	upper, err := e.NewVersion(upperBound)
+	if err != nil {
+		return nil, err
 	}
 
 	if operator == "!=" {
-		// !=1.2.* means <1.2.0 or >=1.3.0
-		if len(v.release) >= 2 {
-			lowerBound := fmt.Sprintf("%d.%d.0", v.release[0], v.release[1])
-			upperBound := fmt.Sprintf("%d.%d.0", v.release[0], v.release[1]+1)
-			return []*constraint{
-				{operator: "<", version: lowerBound},
-				{operator: ">=", version: upperBound},
-			}, nil
-		}
+		// !=1.2.* means <1.2.0 or >=1.3.0, kept as one constraint
+		return append(dst, &constraint{operator: "!=*", version: version, parsed: lower, upper: upper}), nil
 	}
+	return append(dst,
+		&constraint{operator: ">=", version: lowerBound, parsed: lower},
+		&constraint{operator: "<", version: upperBound, parsed: upper},
+	), nil
+}
 
-	return nil, fmt.Errorf("unsupported wildcard constraint: %s%s", operator, version)
+// formatWildcardBound formats the release segments of a wildcard bound,
+// with its epoch when non-zero
+func formatWildcardBound(epoch int, segments []int) string {
+	parts := make([]string, len(segments))
+	for i, n := range segments {
+		parts[i] = strconv.Itoa(n)
+	}
+	if epoch != 0 {
+		return strconv.Itoa(epoch) + "!" + strings.Join(parts, ".")
+	}
+	return strings.Join(parts, ".")
 }
 
 // String returns the string representation of the range
@@ -173,10 +340,31 @@ func (pr *VersionRange) Contains(version *Version) bool {
 	return true
 }
 
+// Comparators returns the constraints as a single AND group. "==" is spelled
+// "=", a "!=X.*" exclusion keeps its wildcard version, and "===" is kept as is.
+func (pr *VersionRange) Comparators() [][]univers.Comparator {
+	group := make([]univers.Comparator, 0, len(pr.constraints))
+	for _, c := range pr.constraints {
+		switch c.operator {
+		case "==":
+			group = append(group, univers.Comparator{Operator: "=", Version: c.version})
+		case "!=*":
+			group = append(group, univers.Comparator{Operator: "!=", Version: c.version})
+		default:
+			group = append(group, univers.Comparator{Operator: c.operator, Version: c.version})
+		}
+	}
+	return [][]univers.Comparator{group}
+}
+
 // Constraint represents a single PyPI version constraint
 type constraint struct {
 	operator string
 	version  string
+	// parsed is the pre-parsed version, nil only for arbitrary equality
+	parsed *Version
+	// upper is the exclusive upper bound of a !=X.* exclusion
+	upper *Version
 }
 
 // matches checks if the given version matches this constraint
@@ -186,19 +374,15 @@ func (c *constraint) matches(version *Version) bool {
 		return version.String() == c.version
 	}
 
-	e := &Ecosystem{}
-	constraintVersion, err := e.NewVersion(c.version)
-	if err != nil {
-		return false
-	}
-
-	comparison := version.Compare(constraintVersion)
+	comparison := version.Compare(c.parsed)
 
 	switch c.operator {
 	case "==":
 		return comparison == 0
 	case "!=":
 		return comparison != 0
+	case "!=*":
+		return comparison < 0 || version.Compare(c.upper) >= 0
 	case "<":
 		return comparison < 0
 	case "<=":
@@ -264,20 +448,6 @@ func comparePrereleases(aPre string, aNum int, bPre string, bNum int) int {
 	return compareInt(aNum, bNum)
 }
 
-// normalizePrereleaseType returns numeric priority: alpha=1, beta=2, rc=3
-func normalizePrereleaseType(preType string) int {
-	switch strings.ToLower(preType) {
-	case "a", "alpha":
-		return 1
-	case "b", "beta":
-		return 2
-	case "c", "rc":
-		return 3
-	default:
-		return 0
-	}
-}
-
 // comparePostReleases returns -1, 0, or 1 where -1 means no post-release (lower precedence)
 func comparePostReleases(a, b int) int {
 	// -1 means no post-release
@@ -1,6 +1,11 @@
 package pypi
 
-import "testing"
+import (
+	"errors"
+	"testing"
+
+	"github.com/alowayed/go-univers/pkg/univers"
+)
 
 func TestEcosystem_NewVersionRange(t *testing.T) {
 	tests := []struct {
@@ -45,11 +50,37 @@ func TestEcosystem_NewVersionRange(t *testing.T) {
 			wantErr:      false,
 			wantOriginal: "===1.2.3",
 		},
+		{
+			name:         "operator whitespace",
+			input:        ">= 1.0 , != 1.5.*",
+			wantErr:      false,
+			wantOriginal: ">= 1.0 , != 1.5.*",
+		},
 		{
 			name:    "empty specifier",
 			input:   "",
 			wantErr: true,
 		},
+		{
+			name:    "invalid constraint version",
+			input:   ">=1.0, <foo",
+			wantErr: true,
+		},
+		{
+			name:    "missing version after operator",
+			input:   ">=1.0, <",
+			wantErr: true,
+		},
+		{
+			name:    "missing comma",
+			input:   ">=1.0 <2.0",
+			wantErr: true,
+		},
+		{
+			name:    "invalid operator",
+			input:   "=<1.0",
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -140,6 +171,18 @@ func TestVersionRange_Contains(t *testing.T) {
 			version:  "1.3.0",
 			want:     false,
 		},
+		{
+			name:     "compatible release with two components allows minor",
+			rangeStr: "~=2.2",
+			version:  "2.9",
+			want:     true,
+		},
+		{
+			name:     "compatible release with two components excludes next major",
+			rangeStr: "~=2.2",
+			version:  "3.0",
+			want:     false,
+		},
 		{
 			name:     "wildcard constraint match",
 			rangeStr: "==1.2.*",
@@ -152,6 +195,30 @@ func TestVersionRange_Contains(t *testing.T) {
 			version:  "1.3.0",
 			want:     false,
 		},
+		{
+			name:     "major wildcard match",
+			rangeStr: "==1.*",
+			version:  "1.5",
+			want:     true,
+		},
+		{
+			name:     "major wildcard no match",
+			rangeStr: "==1.*",
+			version:  "2.0",
+			want:     false,
+		},
+		{
+			name:     "patch wildcard match",
+			rangeStr: "==1.2.3.*",
+			version:  "1.2.3.9",
+			want:     true,
+		},
+		{
+			name:     "patch wildcard no match",
+			rangeStr: "==1.2.3.*",
+			version:  "1.2.4",
+			want:     false,
+		},
 		{
 			name:     "multiple constraints match",
 			rangeStr: ">=1.0.0, <2.0.0",
@@ -176,12 +243,72 @@ func TestVersionRange_Contains(t *testing.T) {
 			version:  "1.2.3.post1",
 			want:     false,
 		},
+		{
+			name:     "arbitrary equality ignores equivalent spellings",
+			rangeStr: "===1.0.post1",
+			version:  "1.0-1",
+			want:     false,
+		},
+		{
+			name:     "arbitrary equality ignores release padding",
+			rangeStr: "===1.0",
+			version:  "1.0.0",
+			want:     false,
+		},
+		{
+			name:     "arbitrary equality is case-sensitive",
+			rangeStr: "===1.0RC1",
+			version:  "1.0rc1",
+			want:     false,
+		},
+		{
+			name:     "equality folds spellings",
+			rangeStr: "==1.0.post1",
+			version:  "1.0-1",
+			want:     true,
+		},
 		{
 			name:     "prerelease handling",
 			rangeStr: ">=1.0.0a1",
 			version:  "1.0.0b1",
 			want:     true,
 		},
+		{
+			name:     "wildcard exclusion below",
+			rangeStr: "!=1.2.*",
+			version:  "1.1.9",
+			want:     true,
+		},
+		{
+			name:     "wildcard exclusion above",
+			rangeStr: "!=1.2.*",
+			version:  "1.3.0",
+			want:     true,
+		},
+		{
+			name:     "wildcard exclusion no match",
+			rangeStr: "!=1.2.*",
+			version:  "1.2.7",
+			want:     false,
+		},
+		{
+			name:     "major wildcard exclusion no match",
+			rangeStr: "!=1.*",
+			version:  "1.3",
+			want:     false,
+		},
+		{
+			name:     "major wildcard exclusion above",
+			rangeStr: "!=1.*",
+			version:  "2.0",
+			want:     true,
+		},
+		{
+			name:     "requirement with exclusions",
+			rangeStr: ">=1.0, !=1.5.*, !=1.7.2, <2.0",
+			version:  "1.7.3",
+			want:     true,
+		},
 		{
 			name:     "epoch handling",
 			rangeStr: ">=1!1.0.0",
@@ -203,6 +330,67 @@ func TestVersionRange_Contains(t *testing.T) {
 	}
 }
 
+func TestEcosystem_NewVersionRange_ParseError(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantPos int
+		wantTok string
+	}{
+		{
+			name:    "invalid second version",
+			input:   ">=1.0, <foo",
+			wantPos: 8,
+			wantTok: "foo",
+		},
+		{
+			name:    "unexpected version",
+			input:   ">=1.0 2.0",
+			wantPos: 6,
+			wantTok: "2.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &Ecosystem{}
+			_, err := e.NewVersionRange(tt.input)
+			var pe *univers.ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("NewVersionRange(%q) error = %v, want *univers.ParseError", tt.input, err)
+			}
+			if pe.Pos != tt.wantPos || pe.Token != tt.wantTok {
+				t.Errorf("NewVersionRange(%q) error at %d (%q), want %d (%q)", tt.input, pe.Pos, pe.Token, tt.wantPos, tt.wantTok)
+			}
+		})
+	}
+}
+
+func BenchmarkEcosystem_NewVersionRange(b *testing.B) {
+	e := &Ecosystem{}
+	for i := 0; i < b.N; i++ {
+		if _, err := e.NewVersionRange(">=1.4.2, !=1.5.*, !=1.6.0, <2.0.0"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkVersionRange_Contains(b *testing.B) {
+	e := &Ecosystem{}
+	vr, err := e.NewVersionRange(">=1.4.2, !=1.5.*, !=1.6.0, <2.0.0")
+	if err != nil {
+		b.Fatal(err)
+	}
+	v, err := e.NewVersion("1.7.3")
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		vr.Contains(v)
+	}
+}
+
 // mustNewVersionRange is a helper function to create a new VersionRange.
 func mustNewVersionRange(t *testing.T, s string) *VersionRange {
 	t.Helper()
@@ -213,3 +401,35 @@ func mustNewVersionRange(t *testing.T, s string) *VersionRange {
 	}
 	return vr
 }
+
+func TestEcosystem_Features(t *testing.T) {
+	e := &Ecosystem{}
+	for _, f := range e.Features() {
+		if _, err := e.NewVersionRange(f.Syntax); err != nil {
+			t.Errorf("Features() %s syntax %q does not parse: %v", f.Name, f.Syntax, err)
+		}
+	}
+}
+
+func BenchmarkEcosystem_ParseRangeInto(b *testing.B) {
+	const specifier = ">=1.4.2, !=1.5.*, !=1.6.0, <2.0.0"
+	e := &Ecosystem{}
+
+	b.Run("NewVersionRange", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := e.NewVersionRange(specifier); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("ParseRangeInto", func(b *testing.B) {
+		b.ReportAllocs()
+		dst := &VersionRange{}
+		for b.Loop() {
+			if err := e.ParseRangeInto(dst, specifier); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,161 @@
+package pypi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Requirement is a PEP 508 dependency specification such as
+// "requests[security]>=2.20,<3; python_version<'3.10'".
+type Requirement struct {
+	// Name is the project name as written.
+	Name string
+	// Extras are the optional features requested in brackets, as written.
+	Extras []string
+	// Specifier is the version specifier without surrounding parentheses,
+	// or "" when any version is accepted or a URL is given.
+	Specifier string
+	// URL is the direct reference following "@", or "".
+	URL string
+	// Markers is the environment marker expression following ";", or "".
+	// Markers are not evaluated.
+	Markers string
+	// Range is the parsed Specifier, or nil when Specifier is "".
+	Range *VersionRange
+}
+
+// ParseRequirement parses a PEP 508 requirement, as found on a line of a
+// requirements.txt file, into its name, extras, specifier and markers. A
+// trailing "#" comment is ignored. The specifier is parsed with
+// NewVersionRange.
+func ParseRequirement(requirement string) (*Requirement, error) {
+	s := strings.TrimSpace(stripComment(requirement))
+	if s == "" {
+		return nil, fmt.Errorf("empty requirement string")
+	}
+
+	end := 0
+	for end < len(s) && isNameChar(s[end]) {
+		end++
+	}
+	r := &Requirement{Name: s[:end]}
+	if !isValidName(r.Name) {
+		return nil, fmt.Errorf("invalid project name in requirement %q", requirement)
+	}
+	rest := strings.TrimSpace(s[end:])
+
+	if strings.HasPrefix(rest, "[") {
+		closing := strings.IndexByte(rest, ']')
+		if closing == -1 {
+			return nil, fmt.Errorf("unterminated extras in requirement %q", requirement)
+		}
+		if list := strings.TrimSpace(rest[1:closing]); list != "" {
+			for _, extra := range strings.Split(list, ",") {
+				extra = strings.TrimSpace(extra)
+				if !isValidName(extra) {
+					return nil, fmt.Errorf("invalid extra %q in requirement %q", extra, requirement)
+				}
+				r.Extras = append(r.Extras, extra)
+			}
+		}
+		rest = strings.TrimSpace(rest[closing+1:])
+	}
+
+	var markers string
+	var hasMarkers bool
+	if strings.HasPrefix(rest, "@") {
+		// A URL may contain ";", so markers must follow a space
+		url := rest[1:]
+		if i := strings.Index(url, " ;"); i != -1 {
+			url, markers, hasMarkers = url[:i], url[i+2:], true
+		}
+		r.URL = strings.TrimSpace(url)
+		if r.URL == "" {
+			return nil, fmt.Errorf("empty URL in requirement %q", requirement)
+		}
+	} else {
+		var spec string
+		spec, markers, hasMarkers = strings.Cut(rest, ";")
+		spec = strings.TrimSpace(spec)
+		if strings.HasPrefix(spec, "(") {
+			if !strings.HasSuffix(spec, ")") {
+				return nil, fmt.Errorf("unterminated specifier in requirement %q", requirement)
+			}
+			spec = strings.TrimSpace(spec[1 : len(spec)-1])
+		}
+		r.Specifier = spec
+	}
+
+	r.Markers = strings.TrimSpace(markers)
+	if hasMarkers && r.Markers == "" {
+		return nil, fmt.Errorf("empty markers in requirement %q", requirement)
+	}
+
+	if r.Specifier != "" {
+		vr, err := (&Ecosystem{}).NewVersionRange(r.Specifier)
+		if err != nil {
+			return nil, fmt.Errorf("invalid specifier in requirement %q: %w", requirement, err)
+		}
+		r.Range = vr
+	}
+
+	return r, nil
+}
+
+// CanonicalName returns the PEP 503 normalized project name: lowercase, with
+// runs of "-", "_" and "." replaced by a single "-".
+func (r *Requirement) CanonicalName() string {
+	var sb strings.Builder
+	separator := false
+	for i := 0; i < len(r.Name); i++ {
+		c := r.Name[i]
+		if c == '-' || c == '_' || c == '.' {
+			separator = true
+			continue
+		}
+		if separator {
+			sb.WriteByte('-')
+			separator = false
+		}
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
+
+// stripComment removes a requirements.txt comment: a "#" at the start of the
+// line or after whitespace. A "#" elsewhere, as in a URL fragment, is kept.
+func stripComment(s string) string {
+	for i := 0; i < len(s); i++ {
+		if s[i] == '#' && (i == 0 || s[i-1] == ' ' || s[i-1] == '\t') {
+			return s[:i]
+		}
+	}
+	return s
+}
+
+// isValidName reports whether s is a valid PEP 508 project or extra name:
+// letters, digits, "-", "_" and ".", starting and ending with a letter or digit
+func isValidName(s string) bool {
+	if s == "" || !isAlphanumeric(s[0]) || !isAlphanumeric(s[len(s)-1]) {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isNameChar(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// isNameChar reports whether c may appear in a project or extra name
+func isNameChar(c byte) bool {
+	return isAlphanumeric(c) || c == '-' || c == '_' || c == '.'
+}
+
+// isAlphanumeric reports whether c is an ASCII letter or digit
+func isAlphanumeric(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
@@ -0,0 +1,184 @@
+package pypi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRequirement(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		want       *Requirement
+		contains   []string
+		excludes   []string
+		wantErr    bool
+		wantRange  bool
+		wantString string
+	}{
+		{
+			name:  "extras specifier and markers",
+			input: "requests[security]>=2.20,<3; python_version<'3.10'",
+			want: &Requirement{
+				Name:      "requests",
+				Extras:    []string{"security"},
+				Specifier: ">=2.20,<3",
+				Markers:   "python_version<'3.10'",
+			},
+			contains: []string{"2.20", "2.31.0"},
+			excludes: []string{"2.19", "3.0"},
+		},
+		{
+			name:  "name only",
+			input: "django",
+			want:  &Requirement{Name: "django"},
+		},
+		{
+			name:  "multiple extras with spaces",
+			input: "celery [ redis , msgpack ] ~= 5.3",
+			want: &Requirement{
+				Name:      "celery",
+				Extras:    []string{"redis", "msgpack"},
+				Specifier: "~= 5.3",
+			},
+			contains: []string{"5.4"},
+			excludes: []string{"6.0"},
+		},
+		{
+			name:  "parenthesized specifier",
+			input: "name (>=1.0, !=1.5)",
+			want: &Requirement{
+				Name:      "name",
+				Specifier: ">=1.0, !=1.5",
+			},
+			contains: []string{"1.4"},
+			excludes: []string{"1.5"},
+		},
+		{
+			name:  "markers without specifier",
+			input: `pywin32; sys_platform == "win32"`,
+			want: &Requirement{
+				Name:    "pywin32",
+				Markers: `sys_platform == "win32"`,
+			},
+		},
+		{
+			name:  "direct reference with markers",
+			input: "pip @ https://example.com/pip.zip#sha1=da9234ee ; python_version >= '3.8'",
+			want: &Requirement{
+				Name:    "pip",
+				URL:     "https://example.com/pip.zip#sha1=da9234ee",
+				Markers: "python_version >= '3.8'",
+			},
+		},
+		{
+			name:  "trailing comment",
+			input: "numpy==1.26.4  # pinned for ABI",
+			want: &Requirement{
+				Name:      "numpy",
+				Specifier: "==1.26.4",
+			},
+			contains: []string{"1.26.4"},
+			excludes: []string{"1.26.3"},
+		},
+		{
+			name:  "dotted name",
+			input: "zope.interface>=5",
+			want: &Requirement{
+				Name:      "zope.interface",
+				Specifier: ">=5",
+			},
+			contains: []string{"6.0"},
+		},
+		{
+			name:    "empty",
+			input:   "  # just a comment",
+			wantErr: true,
+		},
+		{
+			name:    "invalid name",
+			input:   "-requests>=2",
+			wantErr: true,
+		},
+		{
+			name:    "unterminated extras",
+			input:   "requests[security>=2",
+			wantErr: true,
+		},
+		{
+			name:    "invalid extra",
+			input:   "requests[sec urity]",
+			wantErr: true,
+		},
+		{
+			name:    "invalid specifier",
+			input:   "requests>=two",
+			wantErr: true,
+		},
+		{
+			name:    "empty markers",
+			input:   "requests>=2;",
+			wantErr: true,
+		},
+		{
+			name:    "empty URL",
+			input:   "pip @ ",
+			wantErr: true,
+		},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRequirement(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRequirement(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			vr := got.Range
+			got.Range = nil
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseRequirement(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+			if (vr != nil) != (tt.want.Specifier != "") {
+				t.Fatalf("ParseRequirement(%q).Range = %v, want range for %q", tt.input, vr, tt.want.Specifier)
+			}
+			for _, s := range tt.contains {
+				v, _ := e.NewVersion(s)
+				if !vr.Contains(v) {
+					t.Errorf("Range.Contains(%q) = false, want true", s)
+				}
+			}
+			for _, s := range tt.excludes {
+				v, _ := e.NewVersion(s)
+				if vr.Contains(v) {
+					t.Errorf("Range.Contains(%q) = true, want false", s)
+				}
+			}
+		})
+	}
+}
+
+func TestRequirement_CanonicalName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"requests", "requests"},
+		{"Django", "django"},
+		{"zope.interface", "zope-interface"},
+		{"Foo__Bar-.baz", "foo-bar-baz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Requirement{Name: tt.name}
+			if got := r.CanonicalName(); got != tt.want {
+				t.Errorf("CanonicalName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
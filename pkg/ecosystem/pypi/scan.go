@@ -0,0 +1,123 @@
+package pypi
+
+import (
+	"strings"
+
+	"github.com/alowayed/go-univers/internal/digits"
+)
+
+// scanVersion is a hand-written equivalent of versionPattern.FindStringSubmatch,
+// used by builds with the univers_noregexp tag. The submatches are, in order:
+// epoch, release, pre type, pre number, post type, post number, implicit
+// post number, dev, dev number and local.
+func scanVersion(s string) []string {
+	var parts [10]string
+	if !scanVersionParts(s, &parts) {
+		return nil
+	}
+	return append([]string{s}, parts[:]...)
+}
+
+// segmentLabels are the labels of the pre, post and dev segments, longest
+// first where one label is a prefix of another
+var segmentLabels = [3][]string{
+	{"alpha", "beta", "preview", "pre", "rc", "a", "b", "c"},
+	{"post", "rev", "r"},
+	{"dev"},
+}
+
+// scanVersionParts matches s against the versionPattern grammar without
+// allocating, storing the submatches listed on scanVersion in parts
+func scanVersionParts(s string, parts *[10]string) bool {
+	rest := strings.TrimPrefix(s, "v")
+
+	// Epoch: digits followed by "!"
+	if n := digits.Prefix(rest); n > 0 && n < len(rest) && rest[n] == '!' {
+		parts[0], rest = rest[:n], rest[n+1:]
+	}
+
+	// Release: dot-separated digit runs, as many as possible
+	n := digits.Prefix(rest)
+	if n == 0 {
+		return false
+	}
+	end := n
+	for end < len(rest) && rest[end] == '.' {
+		m := digits.Prefix(rest[end+1:])
+		if m == 0 {
+			break
+		}
+		end += 1 + m
+	}
+	parts[1], rest = rest[:end], rest[end:]
+
+	// Pre, post and dev segments, each a label between optional separators
+	// and then optional digits. A post-release may instead be "-" and digits.
+	for i, labels := range segmentLabels {
+		label, num, next, ok := scanSegment(rest, labels)
+		switch {
+		case ok && i < 2:
+			parts[2+2*i], parts[3+2*i], rest = label, num, next
+		case ok:
+			parts[7], parts[8], rest = label, num, next
+		case i == 1 && strings.HasPrefix(rest, "-") && digits.Prefix(rest[1:]) > 0:
+			n := 1 + digits.Prefix(rest[1:])
+			parts[6], rest = rest[1:n], rest[n:]
+		}
+	}
+
+	// Local version: "+" then alphanumeric runs separated by "-", "_" or "."
+	if strings.HasPrefix(rest, "+") {
+		local := rest[1:]
+		if !scanLocal(local) {
+			return false
+		}
+		parts[9], rest = local, ""
+	}
+
+	return rest == ""
+}
+
+// scanSegment matches an optional separator, one of labels, another
+// optional separator and a possibly empty digit run at the start of s
+func scanSegment(s string, labels []string) (label, num, rest string, ok bool) {
+	body := trimSeparator(s)
+	for _, l := range labels {
+		if !strings.HasPrefix(body, l) {
+			continue
+		}
+		after := trimSeparator(body[len(l):])
+		n := digits.Prefix(after)
+		return l, after[:n], after[n:], true
+	}
+	return "", "", s, false
+}
+
+// trimSeparator removes one leading "-", "_" or "." from s
+func trimSeparator(s string) string {
+	if s != "" && (s[0] == '-' || s[0] == '_' || s[0] == '.') {
+		return s[1:]
+	}
+	return s
+}
+
+// scanLocal reports whether s is alphanumeric runs separated by single "-",
+// "_" or "." characters
+func scanLocal(s string) bool {
+	run := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+			run++
+		case c == '-' || c == '_' || c == '.':
+			if run == 0 {
+				return false
+			}
+			run = 0
+		default:
+			return false
+		}
+	}
+	return run > 0
+}
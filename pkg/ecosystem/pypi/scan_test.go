@@ -0,0 +1,51 @@
+//go:build !univers_noregexp
+
+package pypi
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestScanVersion checks that the hand-written parser used by univers_noregexp
+// builds agrees with versionPattern on a corpus and on every short string over
+// an alphabet of the characters that matter to the grammar.
+func TestScanVersion(t *testing.T) {
+	inputs := []string{"1!2.0.post3.dev4+ubuntu-1", "1.0a1", "1.0alpha1", "1.0.alpha1", "1.0rc1", "1.0c1", "1.0r1", "1.0rev1", "1.0.post", "1.0.dev", "1.0dev1", "1.0+local..x", "1.0+", "1!", "!1.0", "1.0.0.0.0.1", "1.0b2.post345.dev456", "1.0.a", "1.0+abc_def.1-2", "1.0pre1", "1.0.preview2", "1.0prev1", "1.0pre", "v1.0", "vv1.0", "1.0-1", "1.0--1", "1.0_post_1", "1.0a-1", "1.0a.-1", "1.0a.", "1.0-r", "1.0.post-2", "1.0-dev", "1.0rc1-2.dev-3", "1.0_rev", "1.0-1-1"}
+
+	alphabet := []string{"0", "1", ".", "!", "+", "a", "r", "c", "d", "e", "v", "p", "_", "-"}
+	frontier := []string{""}
+	for range 4 {
+		var next []string
+		for _, prefix := range frontier {
+			for _, c := range alphabet {
+				next = append(next, prefix+c)
+			}
+		}
+		inputs = append(inputs, next...)
+		frontier = next
+	}
+
+	for _, input := range inputs {
+		got := scanVersion(input)
+		want := versionPattern.FindStringSubmatch(input)
+		if !slices.Equal(got, want) {
+			t.Errorf("scanVersion(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+// benchmarkVersion exercises every optional part of the grammar
+const benchmarkVersion = "1!2.22.333rc1.post2.dev3+local.5"
+
+func BenchmarkVersionPattern(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		versionPattern.FindStringSubmatch(benchmarkVersion)
+	}
+}
+
+func BenchmarkScanVersion(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		scanVersion(benchmarkVersion)
+	}
+}
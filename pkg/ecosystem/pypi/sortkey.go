@@ -0,0 +1,54 @@
+package pypi
+
+import "encoding/binary"
+
+// SortKey returns a byte key whose lexicographic order matches Compare.
+//
+// The key holds the epoch, the release segment with trailing zeros removed
+// (so 1.0 and 1.0.0 share a key), and tagged pre-, post- and dev-release
+// markers chosen so that pre-releases sort before the release, post-releases
+// after it, and dev releases before their non-dev counterpart. The local
+// version label is ignored, as it is in Compare.
+func (v *Version) SortKey() []byte {
+	key := make([]byte, 0, 48)
+	key = appendSortKeyInt(key, v.epoch)
+
+	release := v.release
+	for len(release) > 0 && release[len(release)-1] == 0 {
+		release = release[:len(release)-1]
+	}
+	for _, n := range release {
+		key = append(key, 0x01)
+		key = appendSortKeyInt(key, n)
+	}
+	key = append(key, 0x00)
+
+	if v.prerelease == "" {
+		key = append(key, 0x02)
+	} else {
+		key = append(key, 0x01, byte(normalizePrereleaseType(v.prerelease)))
+		key = appendSortKeyInt(key, v.preNumber)
+	}
+
+	if v.postrelease == -1 {
+		key = append(key, 0x00)
+	} else {
+		key = append(key, 0x01)
+		key = appendSortKeyInt(key, v.postrelease)
+	}
+
+	if v.dev == -1 {
+		key = append(key, 0x02)
+	} else {
+		key = append(key, 0x01)
+		key = appendSortKeyInt(key, v.dev)
+	}
+
+	return key
+}
+
+// appendSortKeyInt appends n as a fixed-width big-endian integer with the sign
+// bit flipped, so negative values sort before positive ones.
+func appendSortKeyInt(key []byte, n int) []byte {
+	return binary.BigEndian.AppendUint64(key, uint64(n)^(1<<63))
+}
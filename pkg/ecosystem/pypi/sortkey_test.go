@@ -0,0 +1,50 @@
+package pypi
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestVersion_SortKey(t *testing.T) {
+	versions := []string{
+		"0.1",
+		"1.0.dev1",
+		"1.0a1.dev1",
+		"1.0a1",
+		"1.0a2",
+		"1.0b1",
+		"1.0rc1",
+		"1.0rc1.post1",
+		"1.0",
+		"1.0.0",
+		"1.0+local",
+		"1.0.post1.dev1",
+		"1.0.post1",
+		"1.0.post2",
+		"1.0.1",
+		"1.1",
+		"1.10",
+		"2.0",
+		"1!0.1",
+		"1!1.0",
+	}
+
+	e := &Ecosystem{}
+	parsed := make([]*Version, len(versions))
+	for i, s := range versions {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			t.Fatalf("NewVersion(%q) error = %v", s, err)
+		}
+		parsed[i] = v
+	}
+
+	for _, a := range parsed {
+		for _, b := range parsed {
+			want := a.Compare(b)
+			if got := bytes.Compare(a.SortKey(), b.SortKey()); got != want {
+				t.Errorf("bytes.Compare(SortKey(%q), SortKey(%q)) = %d, want %d", a, b, got, want)
+			}
+		}
+	}
+}
@@ -2,14 +2,11 @@ package pypi
 
 import (
 	"fmt"
-	"regexp"
 	"strconv"
 	"strings"
-)
 
-var (
-	// versionPattern matches PyPI version strings according to PEP 440
-	versionPattern = regexp.MustCompile(`^(?:([0-9]+)!)?([0-9]+(?:\.[0-9]+)*?)(?:\.?(a|b|rc|alpha|beta|c)([0-9]+))?(?:\.?(post|rev|r)([0-9]+))?(?:\.?(dev)([0-9]+))?(?:\+([a-zA-Z0-9]+(?:[-_.][a-zA-Z0-9]+)*))?$`)
+	"github.com/alowayed/go-univers/internal/digits"
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 // Version represents a PyPI package version following PEP 440
@@ -26,13 +23,16 @@ type Version struct {
 
 // newVersion creates a new PyPI version from a string
 func (e *Ecosystem) NewVersion(version string) (*Version, error) {
+	if err := univers.CheckLength(version); err != nil {
+		return nil, err
+	}
+
 	version = strings.TrimSpace(version)
 	if version == "" {
 		return nil, fmt.Errorf("empty version string")
 	}
 
-	// Parse using regex
-	matches := versionPattern.FindStringSubmatch(version)
+	matches := matchVersion(lowerASCII(version))
 	if matches == nil {
 		return nil, fmt.Errorf("invalid PyPI version format: %s", version)
 	}
@@ -67,7 +67,7 @@ func (e *Ecosystem) NewVersion(version string) (*Version, error) {
 		pv.release[i] = num
 	}
 
-	// Parse prerelease (groups 3, 4)
+	// Parse prerelease (groups 3, 4), whose number defaults to 0
 	if matches[3] != "" {
 		pv.prerelease = matches[3]
 		if matches[4] != "" {
@@ -79,12 +79,13 @@ func (e *Ecosystem) NewVersion(version string) (*Version, error) {
 		}
 	}
 
-	// Parse post-release (groups 5, 6)
-	if matches[5] != "" {
-		if matches[6] != "" {
-			postNum, err := strconv.Atoi(matches[6])
+	// Parse post-release (groups 5, 6), or the implicit post-release of
+	// "1.0-1" (group 7)
+	if matches[5] != "" || matches[7] != "" {
+		if n := matches[6] + matches[7]; n != "" {
+			postNum, err := strconv.Atoi(n)
 			if err != nil {
-				return nil, fmt.Errorf("invalid post number: %s", matches[6])
+				return nil, fmt.Errorf("invalid post number: %s", n)
 			}
 			pv.postrelease = postNum
 		} else {
@@ -92,12 +93,12 @@ func (e *Ecosystem) NewVersion(version string) (*Version, error) {
 		}
 	}
 
-	// Parse dev release (groups 7, 8)
-	if matches[7] != "" {
-		if matches[8] != "" {
-			devNum, err := strconv.Atoi(matches[8])
+	// Parse dev release (groups 8, 9)
+	if matches[8] != "" {
+		if matches[9] != "" {
+			devNum, err := strconv.Atoi(matches[9])
 			if err != nil {
-				return nil, fmt.Errorf("invalid dev number: %s", matches[8])
+				return nil, fmt.Errorf("invalid dev number: %s", matches[9])
 			}
 			pv.dev = devNum
 		} else {
@@ -105,19 +106,180 @@ func (e *Ecosystem) NewVersion(version string) (*Version, error) {
 		}
 	}
 
-	// Parse local version (group 9)
-	if matches[9] != "" {
-		pv.local = matches[9]
+	// Parse local version (group 10)
+	if matches[10] != "" {
+		pv.local = matches[10]
 	}
 
 	return pv, nil
 }
 
+// ValidVersion reports whether NewVersion accepts version. It checks the PEP
+// 440 grammar without building a Version, and without allocating unless the
+// version has uppercase letters, for filters that only need to accept or
+// reject input.
+func ValidVersion(version string) bool {
+	if univers.CheckLength(version) != nil {
+		return false
+	}
+	var parts [10]string
+	if !scanVersionParts(lowerASCII(strings.TrimSpace(version)), &parts) {
+		return false
+	}
+
+	// Every number must fit an int: the epoch, each release part, and the
+	// pre-, post- and dev-release numbers
+	for _, n := range [...]string{parts[0], parts[3], parts[5], parts[6], parts[8]} {
+		if !digits.FitsInt(n) {
+			return false
+		}
+	}
+	for release := parts[1]; release != ""; {
+		var part string
+		part, release, _ = strings.Cut(release, ".")
+		if !digits.FitsInt(part) {
+			return false
+		}
+	}
+	return true
+}
+
+// lowerASCII returns s with ASCII letters lowercased. PEP 440 versions are
+// case-insensitive, and lowering only ASCII keeps characters such as the
+// Kelvin sign from folding into a valid version.
+func lowerASCII(s string) string {
+	for i := 0; i < len(s); i++ {
+		if 'A' <= s[i] && s[i] <= 'Z' {
+			b := []byte(s)
+			for j := i; j < len(b); j++ {
+				if 'A' <= b[j] && b[j] <= 'Z' {
+					b[j] += 'a' - 'A'
+				}
+			}
+			return string(b)
+		}
+	}
+	return s
+}
+
 // String returns the string representation of the version
 func (v *Version) String() string {
 	return v.original
 }
 
+// CanonicalKey returns the PEP 440 normalized form of the version. Two
+// versions have the same key exactly when PEP 440 considers them the same
+// version, however they were spelled, so the key suits deduplicating an
+// index:
+//
+//   - letters are lowercased and a leading "v" is dropped
+//   - "alpha", "beta", "c", "pre" and "preview" become "a", "b" and "rc"
+//   - the separators around labels are dropped before pre-releases and
+//     become "." before post- and dev-releases, and "rev", "r" and the
+//     implicit "1.0-1" are spelled "post"
+//   - omitted label numbers become 0, and numbers lose leading zeros
+//   - trailing ".0" release components are removed, as is a zero epoch
+//   - local labels are separated by "." with numeric labels unpadded
+//
+// So 1.0-1, 1.0.post1, 1.0_post1 and V1.0.0.REV1 all have the key 1.post1.
+// Unlike Compare, which ignores local versions, the key keeps them: 1.0 and
+// 1.0+ubuntu1 have different keys.
+func (v *Version) CanonicalKey() string {
+	var b strings.Builder
+	if v.epoch != 0 {
+		b.WriteString(strconv.Itoa(v.epoch))
+		b.WriteByte('!')
+	}
+
+	release := v.release
+	for len(release) > 1 && release[len(release)-1] == 0 {
+		release = release[:len(release)-1]
+	}
+	for i, n := range release {
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(strconv.Itoa(n))
+	}
+
+	if v.prerelease != "" {
+		b.WriteString([...]string{"", "a", "b", "rc"}[normalizePrereleaseType(v.prerelease)])
+		b.WriteString(strconv.Itoa(v.preNumber))
+	}
+	if v.postrelease >= 0 {
+		b.WriteString(".post")
+		b.WriteString(strconv.Itoa(v.postrelease))
+	}
+	if v.dev >= 0 {
+		b.WriteString(".dev")
+		b.WriteString(strconv.Itoa(v.dev))
+	}
+
+	if v.local != "" {
+		b.WriteByte('+')
+		for i, label := range strings.FieldsFunc(v.local, func(r rune) bool {
+			return r == '-' || r == '_' || r == '.'
+		}) {
+			if i > 0 {
+				b.WriteByte('.')
+			}
+			if n, err := strconv.Atoi(label); err == nil {
+				label = strconv.Itoa(n)
+			}
+			b.WriteString(label)
+		}
+	}
+	return b.String()
+}
+
+// normalizePrereleaseType returns the priority of a pre-release label in
+// any of the spellings PEP 440 accepts: alpha=1 for "a" and "alpha", beta=2
+// for "b" and "beta", and rc=3 for "rc", "c", "pre" and "preview"
+func normalizePrereleaseType(preType string) int {
+	switch strings.ToLower(preType) {
+	case "a", "alpha":
+		return 1
+	case "b", "beta":
+		return 2
+	case "rc", "c", "pre", "preview":
+		return 3
+	default:
+		return 0
+	}
+}
+
+// PrereleaseChannel returns "alpha", "beta" or "rc" for a pre-release, with
+// PEP 440's alternate spellings ("a", "c", "pre", "preview", ...)
+// normalized, "dev" for a developmental release of a final version such as
+// 1.0.dev3, and "" otherwise.
+func (v *Version) PrereleaseChannel() string {
+	switch normalizePrereleaseType(v.prerelease) {
+	case 1:
+		return "alpha"
+	case 2:
+		return "beta"
+	case 3:
+		return "rc"
+	}
+	if v.dev >= 0 {
+		return "dev"
+	}
+	return ""
+}
+
+// PrereleaseNumber returns the number of the pre-release or, for a
+// developmental release of a final version, of the dev release. ok is false
+// for versions whose channel is "".
+func (v *Version) PrereleaseNumber() (int, bool) {
+	switch {
+	case v.prerelease != "":
+		return v.preNumber, true
+	case v.dev >= 0:
+		return v.dev, true
+	}
+	return 0, false
+}
+
 // Compare compares this version with another PyPI version according to PEP 440
 func (v *Version) Compare(other *Version) int {
 	if v.epoch != other.epoch {
@@ -177,6 +177,43 @@ func TestEcosystem_NewVersion(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name:  "alternative spellings",
+			input: "V1.0-RC_2.Post-3_DEV",
+			want: &Version{
+				release:     []int{1, 0},
+				prerelease:  "rc",
+				preNumber:   2,
+				postrelease: 3,
+				dev:         0,
+				original:    "V1.0-RC_2.Post-3_DEV",
+			},
+		},
+		{
+			name:  "implicit post-release",
+			input: "1.0-1",
+			want: &Version{
+				release:     []int{1, 0},
+				postrelease: 1,
+				dev:         -1,
+				original:    "1.0-1",
+			},
+		},
+		{
+			name:    "implicit post-release needs a number",
+			input:   "1.0-",
+			wantErr: true,
+		},
+		{
+			name:    "unknown label",
+			input:   "1.0-foo1",
+			wantErr: true,
+		},
+		{
+			name:    "segments out of order",
+			input:   "1.0.post1a1",
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -331,3 +368,76 @@ func versionsEqual(a, b *Version) bool {
 
 	return true
 }
+
+func TestVersion_CanonicalKey(t *testing.T) {
+	tests := []struct {
+		versions []string
+		want     string
+	}{
+		{[]string{"1.0-1", "1.0.post1", "1.0_post1", "1.0post1", "1.0-post-1", "1.0.r1", "1.0rev1", "V1.0.0.REV1"}, "1.post1"},
+		{[]string{"1.0a1", "1.0alpha1", "1.0.a.1", "1.0-ALPHA-1", "1.0a01"}, "1a1"},
+		{[]string{"1.0rc1", "1.0c1", "1.0pre1", "1.0preview1", "1.0-RC1"}, "1rc1"},
+		{[]string{"1.0b", "1.0beta0", "1.0.b"}, "1b0"},
+		{[]string{"1.0.dev", "1.0-dev0", "1.0_DEV"}, "1.dev0"},
+		{[]string{"1.0.post", "1.0-r", "1.0.post0"}, "1.post0"},
+		{[]string{"0!1.2.0", "1.2", "01.02"}, "1.2"},
+		{[]string{"2!1.0"}, "2!1"},
+		{[]string{"0.0"}, "0"},
+		{[]string{"1.0+Ubuntu-1", "1.0+ubuntu_1", "1.0+ubuntu.01"}, "1+ubuntu.1"},
+		{[]string{"1.0rc1.post2.dev3+abc"}, "1rc1.post2.dev3+abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			first := mustNewVersion(t, tt.versions[0])
+			for _, version := range tt.versions {
+				v := mustNewVersion(t, version)
+				if got := v.CanonicalKey(); got != tt.want {
+					t.Errorf("CanonicalKey(%q) = %q, want %q", version, got, tt.want)
+				}
+				if got := v.Compare(first); got != 0 {
+					t.Errorf("Compare(%q, %q) = %d, want 0", version, tt.versions[0], got)
+				}
+			}
+		})
+	}
+
+	if a, b := mustNewVersion(t, "1.0").CanonicalKey(), mustNewVersion(t, "1.0+local").CanonicalKey(); a == b {
+		t.Errorf("CanonicalKey() = %q for both 1.0 and 1.0+local, want different keys", a)
+	}
+}
+
+func TestVersion_PrereleaseChannel(t *testing.T) {
+	tests := []struct {
+		version     string
+		wantChannel string
+		wantNumber  int
+		wantOK      bool
+	}{
+		{"1.0", "", 0, false},
+		{"1.0a1", "alpha", 1, true},
+		{"1.0b0", "beta", 0, true},
+		{"1.0c2", "rc", 2, true},
+		{"1.0.preview3", "rc", 3, true},
+		{"1.0rc1.dev4", "rc", 1, true},
+		{"1.0.dev3", "dev", 3, true},
+		{"1.0.post1", "", 0, false},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			v, err := e.NewVersion(tt.version)
+			if err != nil {
+				t.Fatalf("NewVersion(%q) error = %v", tt.version, err)
+			}
+			if got := v.PrereleaseChannel(); got != tt.wantChannel {
+				t.Errorf("PrereleaseChannel() = %q, want %q", got, tt.wantChannel)
+			}
+			number, ok := v.PrereleaseNumber()
+			if number != tt.wantNumber || ok != tt.wantOK {
+				t.Errorf("PrereleaseNumber() = %d, %v, want %d, %v", number, ok, tt.wantNumber, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package rpm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SplitENVR splits a package string such as "openssl-1:1.1.1k-6.el8" or
+// "openssl-1.1.1k-6.el8", as printed by scanners and rpm -q, into the package
+// name and its version.
+//
+// RPM versions and releases cannot contain hyphens, so the name is whatever
+// precedes the epoch's hyphen or, without an epoch, the second-to-last
+// hyphen. Strings where that leaves no name, such as "1:1.0-1" or
+// "1.1.1k-6.el8", are parsed as a plain version and the name is empty.
+func (e *Ecosystem) SplitENVR(s string) (string, *Version, error) {
+	s = strings.TrimSpace(s)
+	name, evr := splitName(s)
+	if name == "" && evr != s {
+		return "", nil, fmt.Errorf("empty package name in %q", s)
+	}
+
+	v, err := e.NewVersion(evr)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid version in %q: %w", s, err)
+	}
+	return name, v, nil
+}
+
+// splitName separates a leading package name from the epoch, version and
+// release in s, returning an empty name when s has none.
+func splitName(s string) (name, evr string) {
+	if colon := strings.IndexByte(s, ':'); colon != -1 {
+		hyphen := strings.LastIndexByte(s[:colon], '-')
+		if hyphen == -1 {
+			return "", s
+		}
+		return s[:hyphen], s[hyphen+1:]
+	}
+
+	last := strings.LastIndexByte(s, '-')
+	if last == -1 {
+		return "", s
+	}
+	hyphen := strings.LastIndexByte(s[:last], '-')
+	if hyphen == -1 {
+		return "", s
+	}
+	return s[:hyphen], s[hyphen+1:]
+}
@@ -0,0 +1,115 @@
+package rpm
+
+import (
+	"testing"
+)
+
+func TestEcosystem_SplitENVR(t *testing.T) {
+	tests := []struct {
+		name     string
+		e        *Ecosystem
+		input    string
+		wantName string
+		wantVer  string
+		wantErr  bool
+	}{
+		{
+			name:     "name with epoch",
+			input:    "openssl-1:1.1.1k-6.el8",
+			wantName: "openssl",
+			wantVer:  "1:1.1.1k-6.el8",
+		},
+		{
+			name:     "name without epoch",
+			input:    "openssl-1.1.1k-6.el8",
+			wantName: "openssl",
+			wantVer:  "1.1.1k-6.el8",
+		},
+		{
+			name:     "hyphenated name with epoch",
+			input:    "python3-libs-0:3.6.8-47.el8",
+			wantName: "python3-libs",
+			wantVer:  "0:3.6.8-47.el8",
+		},
+		{
+			name:     "hyphenated name starting with a digit",
+			input:    "389-ds-base-1.4.3.39-1.module+el8.10.0+21180+eb46bf7a",
+			wantName: "389-ds-base",
+			wantVer:  "1.4.3.39-1.module+el8.10.0+21180+eb46bf7a",
+		},
+		{
+			name:     "surrounding whitespace",
+			input:    "  bash-5.1.8-9.el9 ",
+			wantName: "bash",
+			wantVer:  "5.1.8-9.el9",
+		},
+		{
+			name:    "epoch, version and release only",
+			input:   "1:1.1.1k-6.el8",
+			wantVer: "1:1.1.1k-6.el8",
+		},
+		{
+			name:    "version and release only",
+			input:   "1.1.1k-6.el8",
+			wantVer: "1.1.1k-6.el8",
+		},
+		{
+			name:    "bare version",
+			input:   "1.1.1k",
+			wantVer: "1.1.1k",
+		},
+		{
+			name:     "options apply to the version",
+			e:        &Ecosystem{IgnoreDistTag: true},
+			input:    "openssl-1:1.1.1k-6.el8",
+			wantName: "openssl",
+			wantVer:  "1:1.1.1k-6",
+		},
+		{
+			name:    "empty name before epoch",
+			input:   "-1:1.0-1",
+			wantErr: true,
+		},
+		{
+			name:    "empty name",
+			input:   "-1.0-1",
+			wantErr: true,
+		},
+		{
+			name:    "invalid version",
+			input:   "openssl-1:1.1.1k-6.el8!",
+			wantErr: true,
+		},
+		{
+			name:    "empty string",
+			input:   "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := tt.e
+			if e == nil {
+				e = &Ecosystem{}
+			}
+			name, got, err := e.SplitENVR(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SplitENVR() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if name != tt.wantName {
+				t.Errorf("SplitENVR() name = %q, want %q", name, tt.wantName)
+			}
+			want, err := e.NewVersion(tt.wantVer)
+			if err != nil {
+				t.Fatalf("NewVersion(%q) error = %v", tt.wantVer, err)
+			}
+			if got.Compare(want) != 0 {
+				t.Errorf("SplitENVR() version = %v, want %v", got, want)
+			}
+		})
+	}
+}
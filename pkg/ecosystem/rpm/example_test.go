@@ -0,0 +1,85 @@
+package rpm_test
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/rpm"
+)
+
+// A tilde sorts before the release, and an epoch outranks the rest.
+func ExampleVersion_Compare() {
+	e := &rpm.Ecosystem{}
+
+	var versions []*rpm.Version
+	for _, s := range []string{"2.0-1", "1:1.0-1", "1.0-1", "1.0~rc1-1", "1.0-1.el9"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		versions = append(versions, v)
+	}
+	slices.SortFunc(versions, (*rpm.Version).Compare)
+	fmt.Println(versions)
+	// Output:
+	// [1.0~rc1-1 1.0-1 1.0-1.el9 2.0-1 1:1.0-1]
+}
+
+// Ranges are comparators separated by commas.
+func ExampleVersionRange_Contains() {
+	e := &rpm.Ecosystem{}
+
+	r, err := e.NewVersionRange(">= 1.0, < 2.0")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, s := range []string{"1.5-1.el9", "2.0-1"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(s, r.Contains(v))
+	}
+	// Output:
+	// 1.5-1.el9 true
+	// 2.0-1 false
+}
+
+// Amazon Linux tags are ignored, but Amazon's rebuild counters are not.
+func ExampleProfile() {
+	e := &rpm.Ecosystem{Profile: rpm.ProfileAmazonLinux}
+
+	for _, pair := range [][2]string{
+		{"8.5.0-1.amzn2023.0.2", "8.5.0-1.amzn2.0.2"},
+		{"1.0.2k-24.amzn2.0.6", "1.0.2k-24.el7"},
+	} {
+		a, err := e.NewVersion(pair[0])
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		b, err := e.NewVersion(pair[1])
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(a.Compare(b))
+	}
+	// Output:
+	// 0
+	// 1
+}
+
+func ExampleEcosystem_SplitENVR() {
+	name, v, err := (&rpm.Ecosystem{}).SplitENVR("openssl-1:1.1.1k-6.el8")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(name, v)
+	// Output:
+	// openssl 1:1.1.1k-6.el8
+}
@@ -0,0 +1,95 @@
+package rpm
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Profile names the release conventions of an RPM-based distribution. An
+// Ecosystem with a Profile ignores that distribution's own release tags, so
+// builds of one source for several of its releases compare equal, while
+// tags of other distributions are still compared.
+type Profile string
+
+const (
+	// ProfileAmazonLinux ignores Amazon Linux tags such as "amzn2" and
+	// "amzn2023". The counters Amazon appends for its own rebuilds, as in
+	// "24.amzn2.0.6", are still compared, so a rebuild sorts after the
+	// upstream release it was built from.
+	ProfileAmazonLinux Profile = "amzn"
+
+	// ProfileSUSE ignores the openSUSE Leap and Backports tags "lp155" and
+	// "bp155". Their number is the product release, not part of the package
+	// history: Leap 15.5 ships Backports packages built for 15.4 as well.
+	// SUSE Linux Enterprise codestreams such as the "150500" of
+	// "150500.55.44.1" are ordinary release numbers and still compared.
+	ProfileSUSE Profile = "suse"
+)
+
+// profileDistTags matches the release tag segments each profile ignores
+var profileDistTags = map[Profile]*regexp.Regexp{
+	ProfileAmazonLinux: regexp.MustCompile(`^amzn\d+$`),
+	ProfileSUSE:        regexp.MustCompile(`^(?:bp|lp)\d+$`),
+}
+
+// profileAliases are the other names ParseProfile accepts for each profile
+var profileAliases = map[string]Profile{
+	"amazon":   ProfileAmazonLinux,
+	"opensuse": ProfileSUSE,
+	"sles":     ProfileSUSE,
+}
+
+// Profiles returns the supported profiles in sorted order.
+func Profiles() []Profile {
+	return []Profile{ProfileAmazonLinux, ProfileSUSE}
+}
+
+// ParseProfile returns the profile named name, case-insensitively. Besides
+// the profile names themselves it accepts "amazon" for ProfileAmazonLinux and
+// "opensuse" and "sles" for ProfileSUSE.
+func ParseProfile(name string) (Profile, bool) {
+	name = strings.ToLower(name)
+	if p, ok := profileAliases[name]; ok {
+		return p, true
+	}
+	p := Profile(name)
+	_, ok := profileDistTags[p]
+	return p, ok
+}
+
+// SchemeEcosystem returns the ecosystem for an RPM scheme: "rpm" for the
+// default comparison, or "rpm:" followed by a profile name, such as
+// "rpm:suse", to compare with that profile.
+func SchemeEcosystem(scheme string) (*Ecosystem, error) {
+	base, name, hasProfile := strings.Cut(scheme, ":")
+	if base != Name {
+		return nil, fmt.Errorf("invalid RPM scheme %q: must be %q or %q", scheme, Name, Name+":<profile>")
+	}
+	if !hasProfile {
+		return &Ecosystem{}, nil
+	}
+
+	p, ok := ParseProfile(name)
+	if !ok {
+		return nil, fmt.Errorf("invalid RPM scheme %q: unknown profile %q, want one of %v", scheme, name, Profiles())
+	}
+	return &Ecosystem{Profile: p}, nil
+}
+
+// stripProfileDistTags removes the release tag segments of profile p
+func stripProfileDistTags(p Profile, release string) string {
+	pattern, ok := profileDistTags[p]
+	if !ok {
+		return release
+	}
+
+	segments := strings.Split(release, ".")
+	kept := segments[:0]
+	for _, segment := range segments {
+		if !pattern.MatchString(segment) {
+			kept = append(kept, segment)
+		}
+	}
+	return strings.Join(kept, ".")
+}
@@ -0,0 +1,163 @@
+package rpm
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestVersion_Compare_Profile(t *testing.T) {
+	tests := []struct {
+		profile Profile
+		fixture string
+	}{
+		{profile: ProfileAmazonLinux, fixture: "testdata/compare_amzn.txt"},
+		{profile: ProfileSUSE, fixture: "testdata/compare_suse.txt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.profile), func(t *testing.T) {
+			testCompareFixture(t, &Ecosystem{Profile: tt.profile}, tt.fixture)
+		})
+	}
+}
+
+func TestVersion_Compare_ProfileOnlyOwnTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile Profile
+		v1      string
+		v2      string
+		want    int
+	}{
+		{name: "amazon ignores amazon tags", profile: ProfileAmazonLinux, v1: "1.0-1.amzn2023", v2: "1.0-1.amzn2", want: 0},
+		{name: "amazon keeps suse tags", profile: ProfileAmazonLinux, v1: "1.0-lp155.1", v2: "1.0-lp154.1", want: 1},
+		{name: "suse ignores suse tags", profile: ProfileSUSE, v1: "1.0-lp155.1", v2: "1.0-lp154.1", want: 0},
+		{name: "suse keeps amazon tags", profile: ProfileSUSE, v1: "1.0-1.amzn2023", v2: "1.0-1.amzn2", want: 1},
+		{name: "unknown profile applies none", profile: "gentoo", v1: "1.0-1.amzn2023", v2: "1.0-1.amzn2", want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &Ecosystem{Profile: tt.profile}
+			if got := mustCompare(t, e, tt.v1, tt.v2); got != tt.want {
+				t.Errorf("Compare(%q, %q) = %d, want %d", tt.v1, tt.v2, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseProfile(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   Profile
+		wantOK bool
+	}{
+		{name: "amzn", want: ProfileAmazonLinux, wantOK: true},
+		{name: "Amazon", want: ProfileAmazonLinux, wantOK: true},
+		{name: "suse", want: ProfileSUSE, wantOK: true},
+		{name: "SLES", want: ProfileSUSE, wantOK: true},
+		{name: "opensuse", want: ProfileSUSE, wantOK: true},
+		{name: "fedora"},
+		{name: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseProfile(tt.name)
+			if ok != tt.wantOK || (ok && got != tt.want) {
+				t.Errorf("ParseProfile(%q) = %q, %v, want %q, %v", tt.name, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestSchemeEcosystem(t *testing.T) {
+	tests := []struct {
+		scheme  string
+		want    Profile
+		wantErr bool
+	}{
+		{scheme: "rpm"},
+		{scheme: "rpm:suse", want: ProfileSUSE},
+		{scheme: "rpm:amzn", want: ProfileAmazonLinux},
+		{scheme: "rpm:amazon", want: ProfileAmazonLinux},
+		{scheme: "rpm:fedora", wantErr: true},
+		{scheme: "rpm:", wantErr: true},
+		{scheme: "deb:suse", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scheme, func(t *testing.T) {
+			got, err := SchemeEcosystem(tt.scheme)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SchemeEcosystem(%q) error = %v, wantErr %v", tt.scheme, err, tt.wantErr)
+			}
+			if !tt.wantErr && got.Profile != tt.want {
+				t.Errorf("SchemeEcosystem(%q).Profile = %q, want %q", tt.scheme, got.Profile, tt.want)
+			}
+		})
+	}
+}
+
+// mustCompare parses two versions with e and compares them
+func mustCompare(t *testing.T, e *Ecosystem, a, b string) int {
+	t.Helper()
+	va, err := e.NewVersion(a)
+	if err != nil {
+		t.Fatalf("NewVersion(%q) error: %v", a, err)
+	}
+	vb, err := e.NewVersion(b)
+	if err != nil {
+		t.Fatalf("NewVersion(%q) error: %v", b, err)
+	}
+	return va.Compare(vb)
+}
+
+// testCompareFixture checks every "v1 [<|=|>] v2" line of a fixture file
+// against versions created by e
+func testCompareFixture(t *testing.T, e *Ecosystem, filename string) {
+	t.Helper()
+
+	file, err := os.Open(filename)
+	if err != nil {
+		t.Fatalf("Failed to read fixture file %q: %v", filename, err)
+	}
+	defer file.Close()
+
+	symbolToCompare := map[string]int{"<": -1, "=": 0, ">": 1}
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		t.Run(fmt.Sprintf("%s:%d: %s", filename, lineNumber, line), func(t *testing.T) {
+			parts := strings.Fields(line)
+			if len(parts) != 3 {
+				t.Fatalf("Invalid line format. Expected \"v1 [<|=|>] v2\", got: %q", line)
+			}
+			want, ok := symbolToCompare[parts[1]]
+			if !ok {
+				t.Fatalf("Invalid comparison operator in line: %q", line)
+			}
+
+			if got := mustCompare(t, e, parts[0], parts[2]); got != want {
+				t.Errorf("Compare(%q, %q) = %d, want %d", parts[0], parts[2], got, want)
+			}
+			if got := mustCompare(t, e, parts[2], parts[0]); got != -want {
+				t.Errorf("Compare(%q, %q) = %d, want %d", parts[2], parts[0], got, -want)
+			}
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("Error reading fixture file: %v", err)
+	}
+}
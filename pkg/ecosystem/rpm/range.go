@@ -2,13 +2,17 @@ package rpm
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+	"unicode"
+
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 // VersionRange represents an RPM version range with standard comparison operators
 type VersionRange struct {
-	constraints []*constraint
-	original    string
+	constraintGroups [][]*constraint // OR logic between groups, AND logic within groups
+	original         string
 }
 
 // constraint represents a single RPM version constraint
@@ -17,42 +21,68 @@ type constraint struct {
 	version  *Version
 }
 
+// features declares the range syntax accepted by NewVersionRange
+var features = []univers.Feature{
+	{Name: univers.FeatureComparison, Syntax: ">=1.0-1 <2.0", Description: "Ordering operators >=, >, <=, < and ="},
+	{Name: univers.FeatureNotEqual, Syntax: "!=1.0-2", Description: "Excludes a single version"},
+	{Name: univers.FeatureExact, Syntax: "1:1.0-1.el8", Description: "A bare version matches only itself"},
+	{Name: univers.FeatureAnd, Syntax: ">=1.0, <2.0", Description: "Space- or comma-separated constraints must all match"},
+	{Name: univers.FeatureOr, Syntax: "(pkg < 1.0 or pkg >= 2.0)", Description: "Rich dependencies combining constraints with and, with and or; the package name is ignored"},
+}
+
+// Features returns the range syntax features supported by NewVersionRange
+func (e *Ecosystem) Features() []univers.Feature {
+	return slices.Clone(features)
+}
+
 // NewVersionRange creates a new RPM version range from a range string
 func (e *Ecosystem) NewVersionRange(rangeStr string) (*VersionRange, error) {
+	if err := univers.CheckLength(rangeStr); err != nil {
+		return nil, err
+	}
+
 	original := rangeStr
 	rangeStr = strings.TrimSpace(rangeStr)
 	if rangeStr == "" {
-		return nil, fmt.Errorf("empty range string")
+		return nil, univers.WrapParseError(original, fmt.Errorf("empty range string"))
 	}
 
-	constraints, err := parseRPMConstraints(e, rangeStr)
-	if err != nil {
+	var constraintGroups [][]*constraint
+	if isRichDependency(rangeStr) {
+		groups, err := parseRichDependency(e, rangeStr)
+		if err != nil {
+			return nil, univers.WrapParseError(original, err)
+		}
+		constraintGroups = groups
+	} else {
+		constraints, err := parseRPMConstraints(e, rangeStr)
+		if err != nil {
+			return nil, univers.WrapParseError(original, err)
+		}
+		constraintGroups = [][]*constraint{constraints}
+	}
+
+	if err := univers.CheckConstraintGroups(constraintGroups); err != nil {
 		return nil, err
 	}
 
 	return &VersionRange{
-		constraints: constraints,
-		original:    original,
+		constraintGroups: constraintGroups,
+		original:         original,
 	}, nil
 }
 
 // parseRPMConstraints parses RPM constraint syntax
 func parseRPMConstraints(e *Ecosystem, rangeStr string) ([]*constraint, error) {
-	// Handle multiple constraints separated by spaces, commas, or both (AND logic)
-	// Normalize by replacing commas with spaces, then split by whitespace
-	parts := strings.Fields(strings.ReplaceAll(rangeStr, ",", " "))
+	// Handle multiple constraints separated by spaces, commas, or both (AND
+	// logic). Spec files put a space after the operator, as in ">= 1.0".
+	fields := univers.ConstraintFields(rangeStr, func(r rune) bool { return r == ',' || unicode.IsSpace(r) })
 
 	var constraints []*constraint
-
-	for _, part := range parts {
-		part = strings.TrimSpace(part)
-		if part == "" {
-			continue
-		}
-
-		constraint, err := parseRPMConstraint(e, part)
+	for _, f := range fields {
+		constraint, err := parseRPMConstraint(e, f.Text)
 		if err != nil {
-			return nil, err
+			return nil, univers.TokenErrorAt(rangeStr, f.Offset, f.Text, err)
 		}
 		constraints = append(constraints, constraint)
 	}
@@ -103,14 +133,34 @@ func (vr *VersionRange) String() string {
 
 // Contains checks if a version satisfies this range
 func (vr *VersionRange) Contains(version *Version) bool {
-	// All constraints must be satisfied (AND logic)
-	for _, c := range vr.constraints {
-		if !satisfiesRPMConstraint(version, c) {
-			return false
+	// OR logic between groups: if ANY group is satisfied, return true
+	for _, constraints := range vr.constraintGroups {
+		// AND logic within group: ALL constraints in this group must be satisfied
+		groupSatisfied := true
+		for _, c := range constraints {
+			if !satisfiesRPMConstraint(version, c) {
+				groupSatisfied = false
+				break
+			}
+		}
+		if groupSatisfied {
+			return true
 		}
 	}
+	return false
+}
 
-	return true
+// Comparators returns the OR groups of the range
+func (vr *VersionRange) Comparators() [][]univers.Comparator {
+	groups := make([][]univers.Comparator, 0, len(vr.constraintGroups))
+	for _, constraintGroup := range vr.constraintGroups {
+		group := make([]univers.Comparator, 0, len(constraintGroup))
+		for _, c := range constraintGroup {
+			group = append(group, univers.Comparator{Operator: c.operator, Version: c.version.String()})
+		}
+		groups = append(groups, group)
+	}
+	return groups
 }
 
 // satisfiesRPMConstraint checks if a version satisfies a single constraint
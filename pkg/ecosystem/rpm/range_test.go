@@ -1,6 +1,7 @@
 package rpm
 
 import (
+	"fmt"
 	"testing"
 )
 
@@ -124,8 +125,12 @@ func TestEcosystem_NewVersionRange(t *testing.T) {
 				return
 			}
 
-			if len(got.constraints) != tt.wantCount {
-				t.Errorf("Ecosystem.NewVersionRange() constraint count = %v, want %v", len(got.constraints), tt.wantCount)
+			count := 0
+			for _, group := range got.constraintGroups {
+				count += len(group)
+			}
+			if count != tt.wantCount {
+				t.Errorf("Ecosystem.NewVersionRange() constraint count = %v, want %v", count, tt.wantCount)
 			}
 
 			if got.original != tt.rangeStr {
@@ -149,6 +154,18 @@ func TestVersionRange_Contains(t *testing.T) {
 			version:  "1.2.3",
 			want:     true,
 		},
+		{
+			name:     "space after operator as in spec files",
+			rangeStr: ">= 1:1.0-1",
+			version:  "1:1.2-1",
+			want:     true,
+		},
+		{
+			name:     "space after operators with comma",
+			rangeStr: ">= 1.0, < 2.0",
+			version:  "2.0",
+			want:     false,
+		},
 		{
 			name:     "exact match explicit",
 			rangeStr: "=1.2.3",
@@ -555,3 +572,85 @@ func TestParseRPMConstraints(t *testing.T) {
 		})
 	}
 }
+
+func TestVersionRange_Contains_Options(t *testing.T) {
+	tests := []struct {
+		name      string
+		ecosystem *Ecosystem
+		rangeStr  string
+		version   string
+		want      bool
+	}{
+		{
+			name:      "fixed release on another distro compared lexically by default",
+			ecosystem: &Ecosystem{},
+			rangeStr:  "<1.2.3-5.el9",
+			version:   "1.2.3-5.el8",
+			want:      true,
+		},
+		{
+			name:      "fixed release on another distro with dist tags ignored",
+			ecosystem: &Ecosystem{IgnoreDistTag: true},
+			rangeStr:  "<1.2.3-5.el9",
+			version:   "1.2.3-5.el8",
+			want:      false,
+		},
+		{
+			name:      "architecture ignored in range",
+			ecosystem: &Ecosystem{IgnoreArch: true},
+			rangeStr:  "=1.2.3-1.x86_64",
+			version:   "1.2.3-1.aarch64",
+			want:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vr, err := tt.ecosystem.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("NewVersionRange(%q) error = %v", tt.rangeStr, err)
+			}
+			v, err := tt.ecosystem.NewVersion(tt.version)
+			if err != nil {
+				t.Fatalf("NewVersion(%q) error = %v", tt.version, err)
+			}
+
+			if got := vr.Contains(v); got != tt.want {
+				t.Errorf("VersionRange{%q}.Contains(%q) = %v, want %v", tt.rangeStr, tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEcosystem_Features(t *testing.T) {
+	e := &Ecosystem{}
+	for _, f := range e.Features() {
+		if _, err := e.NewVersionRange(f.Syntax); err != nil {
+			t.Errorf("Features() %s syntax %q does not parse: %v", f.Name, f.Syntax, err)
+		}
+	}
+}
+
+func TestVersionRange_Comparators(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		want     string
+	}{
+		{name: "comparisons", rangeStr: ">=1.0-1 <2.0", want: "[[>=1.0-1 <2.0]]"},
+		{name: "rich dependency", rangeStr: "(pkg < 1.0 or pkg >= 2.0)", want: "[[<1.0] [>=2.0]]"},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vr, err := e.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("Ecosystem.NewVersionRange() error = %v", err)
+			}
+			if got := fmt.Sprint(vr.Comparators()); got != tt.want {
+				t.Errorf("VersionRange.Comparators() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
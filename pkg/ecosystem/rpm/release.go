@@ -0,0 +1,40 @@
+package rpm
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// archPattern matches a trailing architecture segment of a release
+	archPattern = regexp.MustCompile(`\.(?:x86_64|amd64|i[3-6]86|noarch|aarch64|armv7hl|armhfp|ppc64le|ppc64|ppc|s390x|s390|src|nosrc)$`)
+	// modulePattern matches modularity dist tags such as "module+el8.4.0+10000+abcdef12"
+	modulePattern = regexp.MustCompile(`\.?module\+[A-Za-z]+[0-9.]*\+\d+\+[0-9a-f]+`)
+	// distTagPattern matches a single dot-separated distribution tag segment
+	distTagPattern = regexp.MustCompile(`^(?:el|fc|amzn|mga|ol|rhel|sles|suse|lp|bp|mdv|centos)\d+(?:_\d+)*$`)
+)
+
+// normalizeRelease applies the ecosystem's release options.
+func (e *Ecosystem) normalizeRelease(release string) string {
+	if e.IgnoreArch {
+		release = archPattern.ReplaceAllString(release, "")
+	}
+
+	if e.IgnoreDistTag {
+		release = modulePattern.ReplaceAllString(release, "")
+		segments := strings.Split(release, ".")
+		kept := segments[:0]
+		for _, segment := range segments {
+			if !distTagPattern.MatchString(segment) {
+				kept = append(kept, segment)
+			}
+		}
+		release = strings.Join(kept, ".")
+	}
+
+	if e.Profile != "" {
+		release = stripProfileDistTags(e.Profile, release)
+	}
+
+	return release
+}
@@ -0,0 +1,191 @@
+package rpm
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+// richComparators are the version comparators allowed in rich dependencies
+var richComparators = []string{"<=", ">=", "=", "<", ">"}
+
+// richToken is a word or parenthesis of a rich dependency and its byte offset
+type richToken struct {
+	text  string
+	start int
+}
+
+// richParser parses RPM rich (boolean) dependencies such as
+// "(pkg >= 1.0 with pkg < 2.0)" into OR'd groups of AND'd constraints. The
+// package names are ignored. Only "and", "with" and "or" are supported: the
+// conditional operators "if", "unless" and "else", and "without", depend on
+// other packages being installed and have no meaning for a single version.
+type richParser struct {
+	e      *Ecosystem
+	src    string
+	tokens []richToken
+	pos    int
+}
+
+// parseRichDependency parses a parenthesized rich dependency
+func parseRichDependency(e *Ecosystem, s string) ([][]*constraint, error) {
+	p := &richParser{e: e, src: s, tokens: tokenizeRich(s)}
+	groups, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if tok, ok := p.next(); ok {
+		return nil, univers.TokenErrorAt(s, tok.start, tok.text, fmt.Errorf("unexpected %q after rich dependency", tok.text))
+	}
+	return groups, nil
+}
+
+// tokenizeRich splits s on whitespace, with each parenthesis its own token
+func tokenizeRich(s string) []richToken {
+	var tokens []richToken
+	start := -1
+	flush := func(end int) {
+		if start >= 0 {
+			tokens = append(tokens, richToken{text: s[start:end], start: start})
+			start = -1
+		}
+	}
+	for i, r := range s {
+		switch {
+		case r == '(' || r == ')':
+			flush(i)
+			tokens = append(tokens, richToken{text: string(r), start: i})
+		case unicode.IsSpace(r):
+			flush(i)
+		case start < 0:
+			start = i
+		}
+	}
+	flush(len(s))
+	return tokens
+}
+
+// next returns the next token and advances past it
+func (p *richParser) next() (richToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return richToken{}, false
+	}
+	tok := p.tokens[p.pos]
+	p.pos++
+	return tok, true
+}
+
+// peek returns the next token without advancing
+func (p *richParser) peek() (richToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return richToken{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+// parseExpr parses "(" operand { operator operand } ")". As in RPM, different
+// operators cannot be mixed without further parentheses.
+func (p *richParser) parseExpr() ([][]*constraint, error) {
+	open, ok := p.next()
+	if !ok || open.text != "(" {
+		return nil, fmt.Errorf("rich dependency must start with '('")
+	}
+
+	groups, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	var operator string
+	for {
+		tok, ok := p.next()
+		if !ok {
+			return nil, univers.TokenErrorAt(p.src, open.start, open.text, fmt.Errorf("missing ')'"))
+		}
+		if tok.text == ")" {
+			return groups, nil
+		}
+
+		switch tok.text {
+		case "and", "with", "or":
+		case "if", "unless", "else", "without":
+			return nil, univers.TokenErrorAt(p.src, tok.start, tok.text, fmt.Errorf("rich dependency operator %q is not supported", tok.text))
+		default:
+			return nil, univers.TokenErrorAt(p.src, tok.start, tok.text, fmt.Errorf("expected and, with or or, found %q", tok.text))
+		}
+		if operator != "" && tok.text != operator {
+			return nil, univers.TokenErrorAt(p.src, tok.start, tok.text, fmt.Errorf("cannot mix %q and %q without parentheses", operator, tok.text))
+		}
+		operator = tok.text
+
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		if operator == "or" {
+			groups = append(groups, right...)
+		} else {
+			groups = andGroups(groups, right)
+		}
+	}
+}
+
+// parseOperand parses a nested expression or a "name [comparator version]" term
+func (p *richParser) parseOperand() ([][]*constraint, error) {
+	tok, ok := p.peek()
+	if !ok {
+		return nil, fmt.Errorf("missing operand in rich dependency")
+	}
+	if tok.text == "(" {
+		return p.parseExpr()
+	}
+	if tok.text == ")" || isRichOperator(tok.text) || slices.Contains(richComparators, tok.text) {
+		return nil, univers.TokenErrorAt(p.src, tok.start, tok.text, fmt.Errorf("expected package name, found %q", tok.text))
+	}
+	p.pos++ // the package name is ignored
+
+	comparator, ok := p.peek()
+	if !ok || !slices.Contains(richComparators, comparator.text) {
+		// A bare package name allows any version
+		return [][]*constraint{{}}, nil
+	}
+	p.pos++
+
+	tok, ok = p.next()
+	if !ok || tok.text == "(" || tok.text == ")" {
+		return nil, univers.TokenErrorAt(p.src, comparator.start, comparator.text, fmt.Errorf("constraint %s requires version", comparator.text))
+	}
+	version, err := p.e.NewVersion(tok.text)
+	if err != nil {
+		return nil, univers.TokenErrorAt(p.src, tok.start, tok.text, fmt.Errorf("invalid version in constraint: %v", err))
+	}
+	return [][]*constraint{{{operator: comparator.text, version: version}}}, nil
+}
+
+// isRichOperator reports whether s is a boolean operator of rich dependencies
+func isRichOperator(s string) bool {
+	switch s {
+	case "and", "with", "or", "if", "unless", "else", "without":
+		return true
+	}
+	return false
+}
+
+// andGroups distributes AND over the OR'd groups of a and b
+func andGroups(a, b [][]*constraint) [][]*constraint {
+	result := make([][]*constraint, 0, len(a)*len(b))
+	for _, ga := range a {
+		for _, gb := range b {
+			result = append(result, slices.Concat(ga, gb))
+		}
+	}
+	return result
+}
+
+// isRichDependency reports whether s uses rich dependency syntax
+func isRichDependency(s string) bool {
+	return strings.HasPrefix(s, "(")
+}
@@ -0,0 +1,136 @@
+package rpm
+
+import "testing"
+
+func TestVersionRange_Contains_Rich(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		version  string
+		want     bool
+	}{
+		{
+			name:     "with inside range",
+			rangeStr: "(pkg >= 1.0 with pkg < 2.0)",
+			version:  "1.5-1",
+			want:     true,
+		},
+		{
+			name:     "with outside range",
+			rangeStr: "(pkg >= 1.0 with pkg < 2.0)",
+			version:  "2.0",
+			want:     false,
+		},
+		{
+			name:     "and",
+			rangeStr: "(foo > 1:1.0-1 and foo <= 1:3.0)",
+			version:  "1:2.0-1.el9",
+			want:     true,
+		},
+		{
+			name:     "or first alternative",
+			rangeStr: "(pkg < 1.0 or pkg >= 2.0)",
+			version:  "0.9",
+			want:     true,
+		},
+		{
+			name:     "or second alternative",
+			rangeStr: "(pkg < 1.0 or pkg >= 2.0)",
+			version:  "2.1",
+			want:     true,
+		},
+		{
+			name:     "or neither alternative",
+			rangeStr: "(pkg < 1.0 or pkg >= 2.0)",
+			version:  "1.5",
+			want:     false,
+		},
+		{
+			name:     "nested",
+			rangeStr: "((pkg >= 1.0 with pkg < 2.0) or (pkg >= 3.0 with pkg < 4.0))",
+			version:  "3.5",
+			want:     true,
+		},
+		{
+			name:     "nested gap",
+			rangeStr: "((pkg >= 1.0 with pkg < 2.0) or (pkg >= 3.0 with pkg < 4.0))",
+			version:  "2.5",
+			want:     false,
+		},
+		{
+			name:     "and over or",
+			rangeStr: "((pkg < 1.0 or pkg > 2.0) and pkg < 3.0)",
+			version:  "3.5",
+			want:     false,
+		},
+		{
+			name:     "bare package name",
+			rangeStr: "(pkg)",
+			version:  "9.9",
+			want:     true,
+		},
+		{
+			name:     "tilde prerelease",
+			rangeStr: "(pkg >= 1.0 with pkg < 2.0)",
+			version:  "1.0~rc1",
+			want:     false,
+		},
+		{
+			name:     "caret snapshot",
+			rangeStr: "(pkg > 1.0 with pkg < 1.0.1)",
+			version:  "1.0^git1",
+			want:     true,
+		},
+		{
+			name:     "no spaces inside parentheses",
+			rangeStr: "(pkg = 1.0-1)",
+			version:  "1.0-1",
+			want:     true,
+		},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vr, err := e.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("NewVersionRange(%q) error = %v", tt.rangeStr, err)
+			}
+			v, err := e.NewVersion(tt.version)
+			if err != nil {
+				t.Fatalf("NewVersion(%q) error = %v", tt.version, err)
+			}
+			if got := vr.Contains(v); got != tt.want {
+				t.Errorf("VersionRange(%q).Contains(%q) = %v, want %v", tt.rangeStr, tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEcosystem_NewVersionRange_RichErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+	}{
+		{name: "missing closing parenthesis", rangeStr: "(pkg >= 1.0 with pkg < 2.0"},
+		{name: "trailing tokens", rangeStr: "(pkg >= 1.0) pkg"},
+		{name: "mixed operators", rangeStr: "(pkg >= 1.0 and pkg < 2.0 or pkg > 3.0)"},
+		{name: "conditional operator", rangeStr: "(pkg >= 1.0 if other)"},
+		{name: "without", rangeStr: "(pkg without pkg = 1.0)"},
+		{name: "missing version", rangeStr: "(pkg >=)"},
+		{name: "invalid version", rangeStr: "(pkg >= 1.0@bad)"},
+		{name: "missing operand", rangeStr: "(pkg >= 1.0 with)"},
+		{name: "missing package name", rangeStr: "(>= 1.0)"},
+		{name: "unknown operator", rangeStr: "(pkg >= 1.0 xor pkg < 2.0)"},
+		{name: "empty parentheses", rangeStr: "()"},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := e.NewVersionRange(tt.rangeStr); err == nil {
+				t.Errorf("NewVersionRange(%q) error = nil, want error", tt.rangeStr)
+			}
+		})
+	}
+}
@@ -1,11 +1,32 @@
 // Package rpm provides functionality for working with Red Hat Package Manager (RPM) versions.
 package rpm
 
+import "github.com/alowayed/go-univers/pkg/univers"
+
 const (
-	Name = "rpm"
+	Name = string(univers.RPM)
 )
 
-type Ecosystem struct{}
+// Ecosystem creates RPM versions and ranges. The zero value compares releases
+// exactly as rpmvercmp does; the options below relax that for cross-distro matching.
+// Versions compared with each other should be created by the same Ecosystem
+// configuration.
+type Ecosystem struct {
+	// IgnoreDistTag strips distribution tags (e.g. "el8", "el8_4", "fc38",
+	// "amzn2", "module+el8.4.0+10000+abcdef12") from the release before comparison,
+	// so that "1.0-1.el8" and "1.0-1.el9" compare equal.
+	IgnoreDistTag bool
+
+	// IgnoreArch strips a trailing architecture (e.g. "x86_64", "noarch") from
+	// the release before comparison, so that "1.0-1.x86_64" and "1.0-1.i686"
+	// compare equal.
+	IgnoreArch bool
+
+	// Profile applies the release conventions of a distribution, such as
+	// ProfileSUSE, on top of the options above. The zero value, like a name
+	// ParseProfile rejects, applies none.
+	Profile Profile
+}
 
 func (e *Ecosystem) Name() string {
 	return Name
@@ -6,6 +6,9 @@ import (
 	"strconv"
 	"strings"
 	"unicode"
+
+	"github.com/alowayed/go-univers/internal/digits"
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 // versionPattern matches RPM version strings
@@ -16,12 +19,16 @@ var versionPattern = regexp.MustCompile(`^(?:(\d+):)?(.+)$`)
 type Version struct {
 	epoch    int    // optional epoch (defaults to 0)
 	version  string // version part (required)
-	release  string // optional release part
+	release  string // optional release part, normalized by the ecosystem options
 	original string // original version string
 }
 
 // NewVersion creates a new RPM version from a string
 func (e *Ecosystem) NewVersion(version string) (*Version, error) {
+	if err := univers.CheckLength(version); err != nil {
+		return nil, err
+	}
+
 	original := version
 	version = strings.TrimSpace(version)
 
@@ -80,11 +87,41 @@ func (e *Ecosystem) NewVersion(version string) (*Version, error) {
 	return &Version{
 		epoch:    epoch,
 		version:  versionPart,
-		release:  releasePart,
+		release:  e.normalizeRelease(releasePart),
 		original: original,
 	}, nil
 }
 
+// ValidVersion reports whether NewVersion accepts version, checking the
+// epoch, version and release the same way but without allocating.
+func ValidVersion(version string) bool {
+	if univers.CheckLength(version) != nil {
+		return false
+	}
+	version = strings.TrimSpace(version)
+	if version == "" {
+		return false
+	}
+
+	if n := digits.Prefix(version); n > 0 && n < len(version)-1 && version[n] == ':' {
+		if !digits.FitsInt(version[:n]) {
+			return false
+		}
+		version = version[n+1:]
+	}
+
+	// The version before the last hyphen cannot be empty
+	if strings.LastIndexByte(version, '-') == 0 {
+		return false
+	}
+	for _, r := range version {
+		if !isValidRPMVersionChar(r) {
+			return false
+		}
+	}
+	return true
+}
+
 // validateRPMVersionString validates that a version string contains only allowed characters
 // RPM allows alphanumerics and . + - ~ ^ (and : for epochs, but that's handled separately)
 func validateRPMVersionString(s, part string) error {
@@ -131,84 +168,124 @@ func (v *Version) Compare(other *Version) int {
 	return compareRPMVersionString(v.release, other.release)
 }
 
-// compareRPMVersionString compares two RPM version strings using RPM's rules
-// This implements RPM's version comparison algorithm which alternates between
-// comparing non-numeric and numeric segments
+// compareRPMVersionString compares two RPM version strings using rpmvercmp's
+// rules from RPM 4.15 onwards. The strings are split into alternating runs of
+// ASCII letters and digits, with every other character except "~" and "^"
+// acting as a separator. Digit runs compare numerically and sort after letter
+// runs. A "~" sorts before anything, even the end of the string, so 1.0~rc1 <
+// 1.0; a "^" sorts after the end of the string but before anything else, so
+// 1.0 < 1.0^git1 < 1.0.1. Both may follow each other, as in 1.0~rc1^git1.
 func compareRPMVersionString(a, b string) int {
-	i, j := 0, 0
+	if a == b {
+		return 0
+	}
 
+	i, j := 0, 0
 	for i < len(a) || j < len(b) {
-		// Skip separators (. + - ~ ^)
-		for i < len(a) && isSeparator(rune(a[i])) {
+		for i < len(a) && !isAlnum(a[i]) && a[i] != '~' && a[i] != '^' {
 			i++
 		}
-		for j < len(b) && isSeparator(rune(b[j])) {
+		for j < len(b) && !isAlnum(b[j]) && b[j] != '~' && b[j] != '^' {
 			j++
 		}
 
-		// Extract non-digit segments
-		iStart := i
-		for i < len(a) && !unicode.IsDigit(rune(a[i])) && !isSeparator(rune(a[i])) {
+		// Tilde sorts before everything else, including the end of the string
+		aTilde := i < len(a) && a[i] == '~'
+		bTilde := j < len(b) && b[j] == '~'
+		if aTilde || bTilde {
+			if !aTilde {
+				return 1
+			}
+			if !bTilde {
+				return -1
+			}
 			i++
+			j++
+			continue
 		}
-		aNonDigit := a[iStart:i]
 
-		jStart := j
-		for j < len(b) && !unicode.IsDigit(rune(b[j])) && !isSeparator(rune(b[j])) {
+		// Caret sorts after the end of the string but before everything else
+		aCaret := i < len(a) && a[i] == '^'
+		bCaret := j < len(b) && b[j] == '^'
+		if aCaret || bCaret {
+			if i == len(a) {
+				return -1
+			}
+			if j == len(b) {
+				return 1
+			}
+			if !aCaret {
+				return 1
+			}
+			if !bCaret {
+				return -1
+			}
+			i++
 			j++
+			continue
 		}
-		bNonDigit := b[jStart:j]
 
-		// Compare non-digit segments lexicographically
-		// Special case: tilde (~) sorts before anything (including empty string)
-		nonDigitCmp := compareRPMNonDigits(aNonDigit, bNonDigit)
-		if nonDigitCmp != 0 {
-			return nonDigitCmp
+		if i == len(a) || j == len(b) {
+			break
 		}
 
-		// Extract digit segments
-		iStart = i
-		for i < len(a) && unicode.IsDigit(rune(a[i])) {
+		// Take a run of the same kind as a's next character from both strings
+		isRun := isAlpha
+		if isDigit(a[i]) {
+			isRun = isDigit
+		}
+		iStart, jStart := i, j
+		for i < len(a) && isRun(a[i]) {
 			i++
 		}
-		aDigit := a[iStart:i]
-
-		jStart = j
-		for j < len(b) && unicode.IsDigit(rune(b[j])) {
+		for j < len(b) && isRun(b[j]) {
 			j++
 		}
-		bDigit := b[jStart:j]
+		aSegment, bSegment := a[iStart:i], b[jStart:j]
 
-		// Compare digit segments numerically
-		digitCmp := compareRPMDigits(aDigit, bDigit)
-		if digitCmp != 0 {
-			return digitCmp
+		// A digit run is newer than a letter run
+		if bSegment == "" {
+			if isDigit(a[iStart]) {
+				return 1
+			}
+			return -1
+		}
+
+		var cmp int
+		if isDigit(a[iStart]) {
+			cmp = compareRPMDigits(aSegment, bSegment)
+		} else {
+			cmp = strings.Compare(aSegment, bSegment)
+		}
+		if cmp != 0 {
+			return cmp
 		}
 	}
 
-	return 0
+	// The string with characters left over is newer
+	switch {
+	case i >= len(a) && j >= len(b):
+		return 0
+	case i >= len(a):
+		return -1
+	default:
+		return 1
+	}
 }
 
-// isSeparator checks if a character is a separator in RPM versions
-func isSeparator(r rune) bool {
-	return r == '.' || r == '+' || r == '-' || r == '^'
+// isDigit reports whether c is an ASCII digit
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
 }
 
-// compareRPMNonDigits compares non-digit segments with RPM-specific rules
-func compareRPMNonDigits(a, b string) int {
-	// Handle special case where one string starts with tilde
-	aHasTilde := strings.HasPrefix(a, "~")
-	bHasTilde := strings.HasPrefix(b, "~")
-
-	if aHasTilde && !bHasTilde {
-		return -1 // Tilde sorts before non-tilde
-	}
-	if !aHasTilde && bHasTilde {
-		return 1 // Non-tilde sorts after tilde
-	}
+// isAlpha reports whether c is an ASCII letter
+func isAlpha(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
 
-	// Both have tilde or both don't have tilde - compare lexicographically
-	return strings.Compare(a, b)
+// isAlnum reports whether c is an ASCII letter or digit
+func isAlnum(c byte) bool {
+	return isDigit(c) || isAlpha(c)
 }
 
 // compareRPMDigits compares digit strings numerically (leading zeros ignored)
@@ -297,7 +297,7 @@ func TestVersion_Compare(t *testing.T) {
 			name: "release numeric vs alpha",
 			v1:   "1.2.3-1",
 			v2:   "1.2.3-a",
-			want: -1,
+			want: 1,
 		},
 		{
 			name: "release el7 vs el8",
@@ -325,6 +325,50 @@ func TestVersion_Compare(t *testing.T) {
 			v2:   "1.2.3",
 			want: 1,
 		},
+		{
+			name: "caret sorts before next version",
+			v1:   "1.2.3^20240101",
+			v2:   "1.2.4",
+			want: -1,
+		},
+
+		// Tilde and caret combined with release segments
+		{
+			name: "caret version beats higher release",
+			v1:   "1.0^git1-1",
+			v2:   "1.0-9",
+			want: 1,
+		},
+		{
+			name: "tilde version loses to lower release",
+			v1:   "1.0~rc1-9",
+			v2:   "1.0-1",
+			want: -1,
+		},
+		{
+			name: "tilde then caret snapshot of prerelease",
+			v1:   "1.0~rc1^git1-1",
+			v2:   "1.0~rc1-1",
+			want: 1,
+		},
+		{
+			name: "tilde in release",
+			v1:   "1.0-1~beta",
+			v2:   "1.0-1",
+			want: -1,
+		},
+		{
+			name: "caret in release",
+			v1:   "1.0-1^post",
+			v2:   "1.0-1",
+			want: 1,
+		},
+		{
+			name: "caret in release sorts before next release",
+			v1:   "1.0-1^post",
+			v2:   "1.0-1.1",
+			want: -1,
+		},
 
 		// Edge cases from RPM documentation
 		{
@@ -378,6 +422,107 @@ func TestVersion_Compare(t *testing.T) {
 	}
 }
 
+func TestVersion_Compare_Options(t *testing.T) {
+	tests := []struct {
+		name      string
+		ecosystem *Ecosystem
+		v1        string
+		v2        string
+		want      int
+	}{
+		{
+			name:      "dist tags compared by default",
+			ecosystem: &Ecosystem{},
+			v1:        "1.2.3-1.el8",
+			v2:        "1.2.3-1.el9",
+			want:      -1,
+		},
+		{
+			name:      "dist tags ignored",
+			ecosystem: &Ecosystem{IgnoreDistTag: true},
+			v1:        "1.2.3-1.el8",
+			v2:        "1.2.3-1.el9",
+			want:      0,
+		},
+		{
+			name:      "z-stream dist tag ignored but rebuild kept",
+			ecosystem: &Ecosystem{IgnoreDistTag: true},
+			v1:        "4.18.0-348.7.1.el8_5",
+			v2:        "4.18.0-348.7.2.el8",
+			want:      -1,
+		},
+		{
+			name:      "fedora and amazon dist tags ignored",
+			ecosystem: &Ecosystem{IgnoreDistTag: true},
+			v1:        "2.4.6-1.fc38",
+			v2:        "2.4.6-1.amzn2",
+			want:      0,
+		},
+		{
+			name:      "module dist tag ignored",
+			ecosystem: &Ecosystem{IgnoreDistTag: true},
+			v1:        "10.3.28-1.module+el8.3.0+10472+7adc332a",
+			v2:        "10.3.28-1.el8",
+			want:      0,
+		},
+		{
+			name:      "release number still compared with dist tags ignored",
+			ecosystem: &Ecosystem{IgnoreDistTag: true},
+			v1:        "1.2.3-1.el9",
+			v2:        "1.2.3-2.el8",
+			want:      -1,
+		},
+		{
+			name:      "architectures compared by default",
+			ecosystem: &Ecosystem{},
+			v1:        "1.2.3-1.x86_64",
+			v2:        "1.2.3-1.i386",
+			want:      1,
+		},
+		{
+			name:      "architectures ignored",
+			ecosystem: &Ecosystem{IgnoreArch: true},
+			v1:        "1.2.3-1.x86_64",
+			v2:        "1.2.3-1.i386",
+			want:      0,
+		},
+		{
+			name:      "architecture ignored but dist tag compared",
+			ecosystem: &Ecosystem{IgnoreArch: true},
+			v1:        "1.2.3-1.el8.noarch",
+			v2:        "1.2.3-1.el9.aarch64",
+			want:      -1,
+		},
+		{
+			name:      "dist tag and architecture ignored",
+			ecosystem: &Ecosystem{IgnoreDistTag: true, IgnoreArch: true},
+			v1:        "1.2.3-1.el8.x86_64",
+			v2:        "1.2.3-1.el9.aarch64",
+			want:      0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v1, err := tt.ecosystem.NewVersion(tt.v1)
+			if err != nil {
+				t.Fatalf("NewVersion(%q) error = %v", tt.v1, err)
+			}
+			v2, err := tt.ecosystem.NewVersion(tt.v2)
+			if err != nil {
+				t.Fatalf("NewVersion(%q) error = %v", tt.v2, err)
+			}
+
+			if got := v1.Compare(v2); got != tt.want {
+				t.Errorf("Version.Compare(%q, %q) = %v, want %v", tt.v1, tt.v2, got, tt.want)
+			}
+			if got := v1.String(); got != tt.v1 {
+				t.Errorf("Version.String() = %q, want %q", got, tt.v1)
+			}
+		})
+	}
+}
+
 func TestVersion_String(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -405,27 +550,110 @@ func TestVersion_String(t *testing.T) {
 	}
 }
 
-// Test helper function for tilde comparison
-func TestCompareRPMNonDigits(t *testing.T) {
+// TestCompareRPMVersionString checks the vectors of rpm's own rpmvercmp test
+// suite (tests/rpmvercmp.at), including the combined "~" and "^" cases
+func TestCompareRPMVersionString(t *testing.T) {
 	tests := []struct {
-		name string
 		a, b string
 		want int
 	}{
-		{"tilde vs empty", "~", "", -1},
-		{"empty vs tilde", "", "~", 1},
-		{"tilde vs alpha", "~beta", "alpha", -1},
-		{"alpha vs tilde", "alpha", "~beta", 1},
-		{"both tilde", "~alpha", "~beta", -1},
-		{"regular strings", "alpha", "beta", -1},
-		{"equal strings", "alpha", "alpha", 0},
+		{"1.0", "1.0", 0},
+		{"1.0", "2.0", -1},
+		{"2.0", "1.0", 1},
+		{"2.0.1", "2.0.1", 0},
+		{"2.0", "2.0.1", -1},
+		{"2.0.1", "2.0", 1},
+		{"2.0.1a", "2.0.1a", 0},
+		{"2.0.1a", "2.0.1", 1},
+		{"2.0.1", "2.0.1a", -1},
+		{"5.5p1", "5.5p1", 0},
+		{"5.5p1", "5.5p2", -1},
+		{"5.5p2", "5.5p1", 1},
+		{"5.5p10", "5.5p10", 0},
+		{"5.5p1", "5.5p10", -1},
+		{"5.5p10", "5.5p1", 1},
+		{"10xyz", "10.1xyz", -1},
+		{"10.1xyz", "10xyz", 1},
+		{"xyz10", "xyz10", 0},
+		{"xyz10", "xyz10.1", -1},
+		{"xyz10.1", "xyz10", 1},
+		{"xyz.4", "xyz.4", 0},
+		{"xyz.4", "8", -1},
+		{"8", "xyz.4", 1},
+		{"xyz.4", "2", -1},
+		{"2", "xyz.4", 1},
+		{"5.5p2", "5.6p1", -1},
+		{"5.6p1", "5.5p2", 1},
+		{"5.6p1", "6.5p1", -1},
+		{"6.5p1", "5.6p1", 1},
+		{"6.0.rc1", "6.0", 1},
+		{"6.0", "6.0.rc1", -1},
+		{"10b2", "10a1", 1},
+		{"10a2", "10b2", -1},
+		{"1.0aa", "1.0aa", 0},
+		{"1.0a", "1.0aa", -1},
+		{"1.0aa", "1.0a", 1},
+		{"10.0001", "10.0001", 0},
+		{"10.0001", "10.1", 0},
+		{"10.1", "10.0001", 0},
+		{"10.0001", "10.0039", -1},
+		{"10.0039", "10.0001", 1},
+		{"4.999.9", "5.0", -1},
+		{"5.0", "4.999.9", 1},
+		{"20101121", "20101121", 0},
+		{"20101121", "20101122", -1},
+		{"20101122", "20101121", 1},
+		{"2_0", "2_0", 0},
+		{"2.0", "2_0", 0},
+		{"2_0", "2.0", 0},
+		{"a", "a", 0},
+		{"a+", "a+", 0},
+		{"a+", "a_", 0},
+		{"a_", "a+", 0},
+		{"+a", "+a", 0},
+		{"+a", "_a", 0},
+		{"_a", "+a", 0},
+		{"+_", "+_", 0},
+		{"_+", "+_", 0},
+		{"_+", "_", 0},
+		{"+", "_", 0},
+		{"_", "+", 0},
+		{"1.0~rc1", "1.0~rc1", 0},
+		{"1.0~rc1", "1.0", -1},
+		{"1.0", "1.0~rc1", 1},
+		{"1.0~rc1", "1.0~rc2", -1},
+		{"1.0~rc2", "1.0~rc1", 1},
+		{"1.0~rc1~git123", "1.0~rc1~git123", 0},
+		{"1.0~rc1~git123", "1.0~rc1", -1},
+		{"1.0~rc1", "1.0~rc1~git123", 1},
+		{"1.0^", "1.0^", 0},
+		{"1.0^", "1.0", 1},
+		{"1.0", "1.0^", -1},
+		{"1.0^git1", "1.0^git1", 0},
+		{"1.0^git1", "1.0", 1},
+		{"1.0", "1.0^git1", -1},
+		{"1.0^git1", "1.0^git2", -1},
+		{"1.0^git2", "1.0^git1", 1},
+		{"1.0^git1", "1.01", -1},
+		{"1.01", "1.0^git1", 1},
+		{"1.0^20160101", "1.0^20160101", 0},
+		{"1.0^20160101", "1.0.1", -1},
+		{"1.0.1", "1.0^20160101", 1},
+		{"1.0^20160101^git1", "1.0^20160101^git1", 0},
+		{"1.0^20160102", "1.0^20160101^git1", 1},
+		{"1.0^20160101^git1", "1.0^20160102", -1},
+		{"1.0~rc1^git1", "1.0~rc1^git1", 0},
+		{"1.0~rc1^git1", "1.0~rc1", 1},
+		{"1.0~rc1", "1.0~rc1^git1", -1},
+		{"1.0^git1~pre", "1.0^git1~pre", 0},
+		{"1.0^git1", "1.0^git1~pre", 1},
+		{"1.0^git1~pre", "1.0^git1", -1},
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := compareRPMNonDigits(tt.a, tt.b)
-			if got != tt.want {
-				t.Errorf("compareRPMNonDigits(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			if got := compareRPMVersionString(tt.a, tt.b); got != tt.want {
+				t.Errorf("compareRPMVersionString(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
 			}
 		})
 	}
@@ -0,0 +1,328 @@
+package semver
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Comparator is a single operator and version, such as ">=1.2.3".
+type Comparator struct {
+	// Operator is one of "=", "!=", "<", "<=", ">" and ">=", or "*" for the
+	// wildcard, which has no Version.
+	Operator string
+	Version  *Version
+}
+
+// Constraint is a range as a tree: it matches a version when any group
+// matches, and a group matches when all of its comparators do. Ranges
+// parsed by NewVersionRange have a single group.
+type Constraint struct {
+	Groups [][]Comparator
+}
+
+// Constraint returns the parsed comparators of the range.
+func (sr *VersionRange) Constraint() Constraint {
+	group := make([]Comparator, 0, len(sr.constraints))
+	for _, c := range sr.constraints {
+		group = append(group, Comparator{Operator: c.operator, Version: c.version})
+	}
+	return Constraint{Groups: [][]Comparator{group}}
+}
+
+// Contains checks if a version satisfies any group of the constraint
+func (c Constraint) Contains(version *Version) bool {
+	for _, group := range c.Groups {
+		if groupMatches(group, version) {
+			return true
+		}
+	}
+	return false
+}
+
+// groupMatches reports whether version satisfies every comparator in group
+func groupMatches(group []Comparator, version *Version) bool {
+	for _, cmp := range group {
+		if !(&constraint{operator: cmp.Operator, version: cmp.Version}).matches(version) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the constraint in node-semver syntax
+func (c Constraint) String() string {
+	return c.NodeSemver()
+}
+
+// NodeSemver returns the constraint in node-semver syntax: comparators are
+// separated by spaces and groups by " || ". node-semver has no "!=", so a
+// group excluding a version is split into the groups below and above it.
+func (c Constraint) NodeSemver() string {
+	var groups []string
+	for _, group := range c.Groups {
+		for _, split := range splitNotEqual(group) {
+			var comparators []string
+			for _, cmp := range split {
+				if cmp.Operator != "*" {
+					comparators = append(comparators, (&constraint{operator: cmp.Operator, version: cmp.Version}).String())
+				}
+			}
+			if len(comparators) == 0 {
+				comparators = append(comparators, "*")
+			}
+			groups = append(groups, strings.Join(comparators, " "))
+		}
+	}
+	return strings.Join(groups, " || ")
+}
+
+// splitNotEqual rewrites the "!=" comparators of group as the intervals
+// between the excluded versions, each in its own copy of the group: "!=v1
+// !=v2" becomes "<v1", ">v1 <v2" and ">v2". The groups grow linearly with the
+// exclusions, so a long list of them cannot blow up the output.
+func splitNotEqual(group []Comparator) [][]Comparator {
+	first := -1
+	var rest []Comparator
+	var excluded []*Version
+	for _, cmp := range group {
+		if cmp.Operator != "!=" {
+			rest = append(rest, cmp)
+			continue
+		}
+		if first < 0 {
+			first = len(rest)
+		}
+		excluded = append(excluded, cmp.Version)
+	}
+	if first < 0 {
+		return [][]Comparator{group}
+	}
+
+	slices.SortFunc(excluded, func(a, b *Version) int { return a.Compare(b) })
+	excluded = slices.CompactFunc(excluded, func(a, b *Version) bool { return a.Compare(b) == 0 })
+
+	groups := make([][]Comparator, 0, len(excluded)+1)
+	for i := 0; i <= len(excluded); i++ {
+		var bounds []Comparator
+		if i > 0 {
+			bounds = append(bounds, Comparator{Operator: ">", Version: excluded[i-1]})
+		}
+		if i < len(excluded) {
+			bounds = append(bounds, Comparator{Operator: "<", Version: excluded[i]})
+		}
+		groups = append(groups, slices.Concat(rest[:first], bounds, rest[first:]))
+	}
+	return groups
+}
+
+// VERS returns the constraint as a vers:generic range. Groups are merged into
+// disjoint intervals, so the result lists each bound once in version order.
+// VERS cannot express a range that matches nothing, so that is an error.
+func (c Constraint) VERS() (string, error) {
+	var intervals []*interval
+	for _, group := range c.Groups {
+		if iv := groupInterval(group); iv != nil {
+			intervals = append(intervals, iv)
+		}
+	}
+	if len(intervals) == 0 {
+		return "", fmt.Errorf("constraint %q matches no version", c.NodeSemver())
+	}
+
+	// A version excluded by one group stays excluded only if no other group
+	// admits it
+	var holes []*Version
+	for i, iv := range intervals {
+		for _, hole := range iv.holes {
+			if slices.ContainsFunc(holes, func(h *Version) bool { return h.Compare(hole) == 0 }) {
+				continue
+			}
+			admitted := false
+			for j, other := range intervals {
+				admitted = admitted || (j != i && other.contains(hole))
+			}
+			if !admitted {
+				holes = append(holes, hole)
+			}
+		}
+	}
+
+	type item struct {
+		text    string
+		version *Version
+	}
+	var items []item
+	for _, hole := range holes {
+		items = append(items, item{"!=" + hole.String(), hole})
+	}
+	for _, iv := range mergeIntervals(intervals) {
+		switch {
+		case iv.lower != nil && iv.upper != nil && iv.lower.Compare(iv.upper) == 0:
+			items = append(items, item{iv.lower.String(), iv.lower})
+		default:
+			if iv.lower != nil {
+				items = append(items, item{boundOperator(">", iv.lowerInclusive) + iv.lower.String(), iv.lower})
+			}
+			if iv.upper != nil {
+				items = append(items, item{boundOperator("<", iv.upperInclusive) + iv.upper.String(), iv.upper})
+			}
+		}
+	}
+	if len(items) == 0 {
+		return "vers:generic/*", nil
+	}
+
+	slices.SortStableFunc(items, func(a, b item) int { return a.version.Compare(b.version) })
+	texts := make([]string, 0, len(items))
+	for _, it := range items {
+		texts = append(texts, it.text)
+	}
+	return "vers:generic/" + strings.Join(texts, "|"), nil
+}
+
+// boundOperator returns op, with "=" appended when the bound is inclusive
+func boundOperator(op string, inclusive bool) string {
+	if inclusive {
+		return op + "="
+	}
+	return op
+}
+
+// interval is the set of versions one group admits: the versions between
+// lower and upper, where nil is unbounded, other than holes
+type interval struct {
+	lower, upper                   *Version
+	lowerInclusive, upperInclusive bool
+	holes                          []*Version
+}
+
+// groupInterval returns the interval group admits, or nil if it admits nothing
+func groupInterval(group []Comparator) *interval {
+	iv := &interval{}
+	var exact *Version
+	for _, cmp := range group {
+		v := cmp.Version
+		switch cmp.Operator {
+		case "*":
+		case "=":
+			if exact != nil && exact.Compare(v) != 0 {
+				return nil
+			}
+			exact = v
+		case "!=":
+			iv.holes = append(iv.holes, v)
+		case ">", ">=":
+			inclusive := cmp.Operator == ">="
+			if iv.lower == nil || v.Compare(iv.lower) > 0 || (v.Compare(iv.lower) == 0 && !inclusive) {
+				iv.lower, iv.lowerInclusive = v, inclusive
+			}
+		case "<", "<=":
+			inclusive := cmp.Operator == "<="
+			if iv.upper == nil || v.Compare(iv.upper) < 0 || (v.Compare(iv.upper) == 0 && !inclusive) {
+				iv.upper, iv.upperInclusive = v, inclusive
+			}
+		}
+	}
+
+	if exact != nil {
+		if !iv.contains(exact) {
+			return nil
+		}
+		return &interval{lower: exact, upper: exact, lowerInclusive: true, upperInclusive: true}
+	}
+
+	// Holes outside the bounds change nothing, and a hole on an inclusive
+	// bound just makes the bound exclusive
+	holes := iv.holes
+	iv.holes = nil
+	for _, hole := range holes {
+		switch {
+		case !iv.contains(hole):
+		case iv.lower != nil && hole.Compare(iv.lower) == 0:
+			iv.lowerInclusive = false
+		case iv.upper != nil && hole.Compare(iv.upper) == 0:
+			iv.upperInclusive = false
+		default:
+			iv.holes = append(iv.holes, hole)
+		}
+	}
+
+	if iv.lower != nil && iv.upper != nil {
+		c := iv.lower.Compare(iv.upper)
+		if c > 0 || (c == 0 && !(iv.lowerInclusive && iv.upperInclusive)) {
+			return nil
+		}
+	}
+	return iv
+}
+
+// contains reports whether v is within the bounds and not a hole
+func (iv *interval) contains(v *Version) bool {
+	if iv.lower != nil {
+		if c := v.Compare(iv.lower); c < 0 || (c == 0 && !iv.lowerInclusive) {
+			return false
+		}
+	}
+	if iv.upper != nil {
+		if c := v.Compare(iv.upper); c > 0 || (c == 0 && !iv.upperInclusive) {
+			return false
+		}
+	}
+	return !slices.ContainsFunc(iv.holes, func(h *Version) bool { return h.Compare(v) == 0 })
+}
+
+// mergeIntervals returns the union of intervals, ignoring holes, as disjoint
+// intervals sorted by lower bound
+func mergeIntervals(intervals []*interval) []*interval {
+	sorted := make([]*interval, 0, len(intervals))
+	for _, iv := range intervals {
+		sorted = append(sorted, &interval{lower: iv.lower, upper: iv.upper, lowerInclusive: iv.lowerInclusive, upperInclusive: iv.upperInclusive})
+	}
+	slices.SortStableFunc(sorted, func(a, b *interval) int {
+		switch {
+		case a.lower == nil && b.lower == nil:
+			return 0
+		case a.lower == nil:
+			return -1
+		case b.lower == nil:
+			return 1
+		}
+		if c := a.lower.Compare(b.lower); c != 0 {
+			return c
+		}
+		// An inclusive lower bound starts first
+		return boolOrder(b.lowerInclusive) - boolOrder(a.lowerInclusive)
+	})
+
+	merged := []*interval{sorted[0]}
+	for _, iv := range sorted[1:] {
+		last := merged[len(merged)-1]
+		if last.upper != nil && iv.lower != nil {
+			c := iv.lower.Compare(last.upper)
+			if c > 0 || (c == 0 && !last.upperInclusive && !iv.lowerInclusive) {
+				merged = append(merged, iv)
+				continue
+			}
+		}
+		// Overlapping or adjacent: extend the upper bound
+		switch {
+		case last.upper == nil:
+		case iv.upper == nil:
+			last.upper = nil
+		default:
+			if c := iv.upper.Compare(last.upper); c > 0 || (c == 0 && iv.upperInclusive) {
+				last.upper, last.upperInclusive = iv.upper, iv.upperInclusive
+			}
+		}
+	}
+	return merged
+}
+
+// boolOrder returns 1 for true and 0 for false
+func boolOrder(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,207 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// mustConstraint builds a Constraint from groups of comparator strings
+func mustConstraint(t *testing.T, groups ...[]string) Constraint {
+	t.Helper()
+	var c Constraint
+	for _, group := range groups {
+		var comparators []Comparator
+		for _, s := range group {
+			parsed, err := parseSingleConstraint(s)
+			if err != nil {
+				t.Fatalf("parseSingleConstraint(%q) error = %v", s, err)
+			}
+			comparators = append(comparators, Comparator{Operator: parsed[0].operator, Version: parsed[0].version})
+		}
+		c.Groups = append(c.Groups, comparators)
+	}
+	return c
+}
+
+func TestVersionRange_Constraint(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		want     string
+	}{
+		{
+			name:     "space separated",
+			rangeStr: ">=1.0.0 <2.0.0",
+			want:     ">=1.0.0 <2.0.0",
+		},
+		{
+			name:     "comma separated",
+			rangeStr: ">=1.0.0, <2.0.0",
+			want:     ">=1.0.0 <2.0.0",
+		},
+		{
+			name:     "exact",
+			rangeStr: "1.2.3",
+			want:     "=1.2.3",
+		},
+		{
+			name:     "wildcard",
+			rangeStr: "*",
+			want:     "*",
+		},
+		{
+			name:     "not equal splits",
+			rangeStr: ">=1.0.0 !=1.2.4",
+			want:     ">=1.0.0 <1.2.4 || >=1.0.0 >1.2.4",
+		},
+		{
+			name:     "not equal twice",
+			rangeStr: ">=1.0.0 !=1.5.0 !=1.2.4",
+			want:     ">=1.0.0 <1.2.4 || >=1.0.0 >1.2.4 <1.5.0 || >=1.0.0 >1.5.0",
+		},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vr, err := e.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("Ecosystem.NewVersionRange() error = %v", err)
+			}
+			c := vr.Constraint()
+			if len(c.Groups) != 1 {
+				t.Errorf("VersionRange.Constraint() has %d groups, want 1", len(c.Groups))
+			}
+			if got := c.NodeSemver(); got != tt.want {
+				t.Errorf("Constraint.NodeSemver() = %q, want %q", got, tt.want)
+			}
+
+			for _, s := range []string{"0.9.0", "1.0.0", "1.2.3", "1.2.4", "1.5.0", "2.0.0-rc.1", "2.0.0"} {
+				v, err := e.NewVersion(s)
+				if err != nil {
+					t.Fatalf("Failed to parse version %s: %v", s, err)
+				}
+				if got, want := c.Contains(v), vr.Contains(v); got != want {
+					t.Errorf("Constraint.Contains(%s) = %v, want %v", s, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestConstraint_VERS(t *testing.T) {
+	tests := []struct {
+		name    string
+		groups  [][]string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "interval",
+			groups: [][]string{{">=1.0.0", "<2.0.0"}},
+			want:   "vers:generic/>=1.0.0|<2.0.0",
+		},
+		{
+			name:   "tightest bounds win",
+			groups: [][]string{{">1.0.0", ">=1.1.0", "<=3.0.0", "<2.0.0"}},
+			want:   "vers:generic/>=1.1.0|<2.0.0",
+		},
+		{
+			name:   "exact",
+			groups: [][]string{{"1.2.3"}},
+			want:   "vers:generic/1.2.3",
+		},
+		{
+			name:   "wildcard",
+			groups: [][]string{{"*"}},
+			want:   "vers:generic/*",
+		},
+		{
+			name:   "not equal inside interval",
+			groups: [][]string{{">=1.0.0", "!=1.2.4", "<2.0.0"}},
+			want:   "vers:generic/>=1.0.0|!=1.2.4|<2.0.0",
+		},
+		{
+			name:   "not equal on inclusive bound",
+			groups: [][]string{{">=1.0.0", "!=1.0.0"}},
+			want:   "vers:generic/>1.0.0",
+		},
+		{
+			name:   "not equal alone",
+			groups: [][]string{{"!=1.0.0"}},
+			want:   "vers:generic/!=1.0.0",
+		},
+		{
+			name:   "disjoint groups",
+			groups: [][]string{{">=2.0.0", "<3.0.0"}, {">=1.0.0", "<1.5.0"}},
+			want:   "vers:generic/>=1.0.0|<1.5.0|>=2.0.0|<3.0.0",
+		},
+		{
+			name:   "overlapping groups merge",
+			groups: [][]string{{">=1.0.0", "<2.0.0"}, {">=1.5.0", "<3.0.0"}},
+			want:   "vers:generic/>=1.0.0|<3.0.0",
+		},
+		{
+			name:   "adjacent groups merge",
+			groups: [][]string{{">=1.0.0", "<2.0.0"}, {">=2.0.0"}},
+			want:   "vers:generic/>=1.0.0",
+		},
+		{
+			name:   "hole filled by another group",
+			groups: [][]string{{">=1.0.0", "!=1.2.4", "<2.0.0"}, {"1.2.4"}},
+			want:   "vers:generic/>=1.0.0|<2.0.0",
+		},
+		{
+			name:   "empty group dropped",
+			groups: [][]string{{">2.0.0", "<1.0.0"}, {"1.2.3"}},
+			want:   "vers:generic/1.2.3",
+		},
+		{
+			name:    "matches nothing",
+			groups:  [][]string{{">=2.0.0", "<2.0.0"}},
+			wantErr: true,
+		},
+		{
+			name:    "conflicting exact versions",
+			groups:  [][]string{{"1.0.0", "2.0.0"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mustConstraint(t, tt.groups...).VERS()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Constraint.VERS() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Constraint.VERS() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConstraint_NodeSemver(t *testing.T) {
+	c := mustConstraint(t, []string{">=1.0.0", "<1.5.0"}, []string{">=2.0.0", "!=2.1.0"})
+	want := ">=1.0.0 <1.5.0 || >=2.0.0 <2.1.0 || >=2.0.0 >2.1.0"
+	if got := c.NodeSemver(); got != want {
+		t.Errorf("Constraint.NodeSemver() = %q, want %q", got, want)
+	}
+	if got := c.String(); got != want {
+		t.Errorf("Constraint.String() = %q, want %q", got, want)
+	}
+}
+
+func TestConstraint_NodeSemver_ManyExclusions(t *testing.T) {
+	var group []string
+	for i := range 40 {
+		group = append(group, fmt.Sprintf("!=1.%d.0", i))
+	}
+	c := mustConstraint(t, group)
+
+	// Each exclusion adds one group rather than doubling them
+	if got, want := strings.Count(c.NodeSemver(), "||")+1, 41; got != want {
+		t.Errorf("Constraint.NodeSemver() has %d alternatives, want %d", got, want)
+	}
+}
@@ -0,0 +1,69 @@
+package semver_test
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/semver"
+)
+
+// Prerelease identifiers compare field by field.
+func ExampleVersion_Compare() {
+	e := &semver.Ecosystem{}
+
+	var versions []*semver.Version
+	for _, s := range []string{"1.0.0", "1.0.0-rc.1", "1.0.0-alpha.1", "1.0.0-alpha", "1.0.0-alpha.beta"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		versions = append(versions, v)
+	}
+	slices.SortFunc(versions, (*semver.Version).Compare)
+	fmt.Println(versions)
+	// Output:
+	// [1.0.0-alpha 1.0.0-alpha.1 1.0.0-alpha.beta 1.0.0-rc.1 1.0.0]
+}
+
+// Ranges are comparators separated by spaces.
+func ExampleVersionRange_Contains() {
+	e := &semver.Ecosystem{}
+
+	r, err := e.NewVersionRange(">=1.0.0 <2.0.0")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, s := range []string{"1.5.0", "2.0.0"} {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(s, r.Contains(v))
+	}
+	// Output:
+	// 1.5.0 true
+	// 2.0.0 false
+}
+
+func ExampleConstraint_VERS() {
+	r, err := (&semver.Ecosystem{}).NewVersionRange(">=1.0.0 <2.0.0 !=1.5.0")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	c := r.Constraint()
+	fmt.Println(c.NodeSemver())
+
+	vers, err := c.VERS()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(vers)
+	// Output:
+	// >=1.0.0 <2.0.0 <1.5.0 || >=1.0.0 <2.0.0 >1.5.0
+	// vers:generic/>=1.0.0|!=1.5.0|<2.0.0
+}
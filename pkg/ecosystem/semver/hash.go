@@ -0,0 +1,18 @@
+package semver
+
+import "github.com/alowayed/go-univers/pkg/univers"
+
+// Hash returns a content hash of the constraints. It does not change with
+// whitespace, separators, constraint order, duplicates or build metadata, so
+// ">=1.0.0, <2.0.0" and "<2.0.0 >=1.0.0+build" hash the same.
+func (sr *VersionRange) Hash() uint64 {
+	group := make([]univers.HashedConstraint, 0, len(sr.constraints))
+	for _, c := range sr.constraints {
+		var key []byte
+		if c.version != nil {
+			key = c.version.SortKey()
+		}
+		group = append(group, univers.HashedConstraint{Operator: c.operator, Key: key})
+	}
+	return univers.HashConstraints([][]univers.HashedConstraint{group})
+}
@@ -0,0 +1,39 @@
+package semver
+
+import "testing"
+
+func TestVersionRange_Hash(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		wantSame bool
+	}{
+		{name: "identical", a: ">=1.0.0", b: ">=1.0.0", wantSame: true},
+		{name: "separators", a: ">=1.0.0, <2.0.0", b: ">=1.0.0 <2.0.0", wantSame: true},
+		{name: "constraint order", a: ">=1.0.0 <2.0.0", b: "<2.0.0 >=1.0.0", wantSame: true},
+		{name: "duplicates", a: ">=1.0.0 >=1.0.0", b: ">=1.0.0", wantSame: true},
+		{name: "explicit equals", a: "1.2.3", b: "=1.2.3", wantSame: true},
+		{name: "build metadata", a: ">=1.0.0+build", b: ">=1.0.0", wantSame: true},
+		{name: "different version", a: ">=1.0.0", b: ">=1.0.1"},
+		{name: "different operator", a: ">1.0.0", b: ">=1.0.0"},
+		{name: "prerelease", a: "<2.0.0-rc.1", b: "<2.0.0"},
+		{name: "wildcard", a: "*", b: ">=0.0.0"},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := e.NewVersionRange(tt.a)
+			if err != nil {
+				t.Fatalf("NewVersionRange(%q) error = %v", tt.a, err)
+			}
+			b, err := e.NewVersionRange(tt.b)
+			if err != nil {
+				t.Fatalf("NewVersionRange(%q) error = %v", tt.b, err)
+			}
+			if got := a.Hash() == b.Hash(); got != tt.wantSame {
+				t.Errorf("Hash(%q) == Hash(%q) = %v, want %v", tt.a, tt.b, got, tt.wantSame)
+			}
+		})
+	}
+}
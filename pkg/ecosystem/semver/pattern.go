@@ -0,0 +1,32 @@
+//go:build !univers_noregexp
+
+package semver
+
+import "regexp"
+
+// Package-level compiled regular expressions for performance
+var (
+	// versionPattern matches SemVer 2.0.0 version strings
+	// Group 1: major, Group 2: minor, Group 3: patch
+	// Group 4: prerelease (optional), Group 5: build metadata (optional)
+	versionPattern = regexp.MustCompile(`^(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z\-]+(?:\.[0-9A-Za-z\-]+)*))?(?:\+([0-9A-Za-z\-]+(?:\.[0-9A-Za-z\-]+)*))?$`)
+
+	// Patterns for prerelease and build metadata validation
+	validCharsPattern = regexp.MustCompile(`^[0-9A-Za-z\-]+$`)
+	numericPattern    = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// matchVersion returns the versionPattern submatches of s, or nil
+func matchVersion(s string) []string {
+	return versionPattern.FindStringSubmatch(s)
+}
+
+// isIdentifier reports whether s is a non-empty run of alphanumerics and hyphens
+func isIdentifier(s string) bool {
+	return validCharsPattern.MatchString(s)
+}
+
+// isNumeric reports whether s is a non-empty run of ASCII digits
+func isNumeric(s string) bool {
+	return numericPattern.MatchString(s)
+}
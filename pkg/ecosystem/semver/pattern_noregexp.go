@@ -0,0 +1,18 @@
+//go:build univers_noregexp
+
+package semver
+
+// matchVersion returns the versionPattern submatches of s, or nil
+func matchVersion(s string) []string {
+	return scanVersion(s)
+}
+
+// isIdentifier reports whether s is a non-empty run of alphanumerics and hyphens
+func isIdentifier(s string) bool {
+	return scanIdentifier(s)
+}
+
+// isNumeric reports whether s is a non-empty run of ASCII digits
+func isNumeric(s string) bool {
+	return scanNumeric(s)
+}
@@ -2,7 +2,10 @@ package semver
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 // VersionRange represents a SemVer version range with standard comparison operators
@@ -17,15 +20,38 @@ type constraint struct {
 	version  *Version
 }
 
+// features declares the range syntax accepted by NewVersionRange
+var features = []univers.Feature{
+	{Name: univers.FeatureComparison, Syntax: ">=1.2.3", Description: "Ordering operators >=, >, <=, < and ="},
+	{Name: univers.FeatureNotEqual, Syntax: "!=1.2.4", Description: "Excludes a single version"},
+	{Name: univers.FeatureExact, Syntax: "1.2.3", Description: "A bare version matches only itself"},
+	{Name: univers.FeatureAnd, Syntax: ">=1.0.0 <2.0.0", Description: "Space- or comma-separated constraints must all match"},
+	{Name: univers.FeatureWildcard, Syntax: "*", Description: "Matches every version"},
+}
+
+// Features returns the range syntax features supported by NewVersionRange
+func (e *Ecosystem) Features() []univers.Feature {
+	return slices.Clone(features)
+}
+
 // NewVersionRange creates a new SemVer version range from a range string
 func (e *Ecosystem) NewVersionRange(rangeStr string) (*VersionRange, error) {
+	if err := univers.CheckLength(rangeStr); err != nil {
+		return nil, err
+	}
+
+	input := rangeStr
 	rangeStr = strings.TrimSpace(rangeStr)
 	if rangeStr == "" {
-		return nil, fmt.Errorf("empty range string")
+		return nil, univers.WrapParseError(input, fmt.Errorf("empty range string"))
 	}
 
 	constraints, err := parseRange(rangeStr)
 	if err != nil {
+		return nil, univers.WrapParseError(input, err)
+	}
+
+	if err := univers.CheckConstraints(len(constraints)); err != nil {
 		return nil, err
 	}
 
@@ -94,7 +120,11 @@ func parseCommaSeparatedConstraints(rangeStr string) ([]*constraint, error) {
 	parts := strings.Split(rangeStr, ",")
 	var constraints []*constraint
 
+	offset := 0
 	for _, part := range parts {
+		start := offset + strings.Index(rangeStr[offset:], part)
+		offset = start + len(part)
+
 		part = strings.TrimSpace(part)
 		if part == "" {
 			continue
@@ -102,7 +132,7 @@ func parseCommaSeparatedConstraints(rangeStr string) ([]*constraint, error) {
 
 		partConstraints, err := parseSingleConstraint(part)
 		if err != nil {
-			return nil, err
+			return nil, univers.TokenErrorAt(rangeStr, start, part, err)
 		}
 		constraints = append(constraints, partConstraints...)
 	}
@@ -115,14 +145,13 @@ func parseCommaSeparatedConstraints(rangeStr string) ([]*constraint, error) {
 }
 
 // parseSpaceSeparatedConstraints handles space-separated constraints (>=1.0.0 <2.0.0)
+// An operator may be separated from its version by spaces (>= 1.0.0).
 func parseSpaceSeparatedConstraints(rangeStr string) ([]*constraint, error) {
-	parts := strings.Fields(rangeStr)
 	var constraints []*constraint
-
-	for _, part := range parts {
-		partConstraints, err := parseSingleConstraint(part)
+	for _, f := range univers.ConstraintFields(rangeStr, nil) {
+		partConstraints, err := parseSingleConstraint(f.Text)
 		if err != nil {
-			return nil, err
+			return nil, univers.TokenErrorAt(rangeStr, f.Offset, f.Text, err)
 		}
 		constraints = append(constraints, partConstraints...)
 	}
@@ -176,3 +205,41 @@ func (c *constraint) matches(version *Version) bool {
 		return false
 	}
 }
+
+// Comparators returns the constraints as a single AND group, which is empty
+// for the wildcard
+func (sr *VersionRange) Comparators() [][]univers.Comparator {
+	group := make([]univers.Comparator, 0, len(sr.constraints))
+	for _, c := range sr.constraints {
+		if c.operator != "*" && c.version != nil {
+			group = append(group, univers.Comparator{Operator: c.operator, Version: c.version.String()})
+		}
+	}
+	return [][]univers.Comparator{group}
+}
+
+// TraceConstraints returns the parsed constraints as a single AND group
+func (sr *VersionRange) TraceConstraints() [][]string {
+	group := make([]string, 0, len(sr.constraints))
+	for _, c := range sr.constraints {
+		group = append(group, c.String())
+	}
+	return [][]string{group}
+}
+
+// TraceMatches reports whether the version satisfies each constraint returned by TraceConstraints
+func (sr *VersionRange) TraceMatches(version *Version) [][]bool {
+	group := make([]bool, 0, len(sr.constraints))
+	for _, c := range sr.constraints {
+		group = append(group, c.matches(version))
+	}
+	return [][]bool{group}
+}
+
+// String returns the constraint in comparator form
+func (c *constraint) String() string {
+	if c.operator == "*" || c.version == nil {
+		return "*"
+	}
+	return c.operator + c.version.String()
+}
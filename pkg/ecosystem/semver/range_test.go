@@ -1,6 +1,7 @@
 package semver
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -73,6 +74,10 @@ func TestVersionRange_Contains(t *testing.T) {
 		{"exact match - not equal", "1.2.3", "1.2.4", false, false},
 		{"explicit equal - match", "=1.2.3", "1.2.3", true, false},
 		{"explicit equal - no match", "=1.2.3", "1.2.4", false, false},
+		{"space after operator", ">= 1.2.3", "1.2.3", true, false},
+		{"space after operators - in range", ">= 1.2.3 <  2.0.0", "1.9.0", true, false},
+		{"space after operators - above range", ">= 1.2.3 < 2.0.0", "2.0.0", false, false},
+		{"operator without version", ">= 1.2.3 <", "1.2.3", false, true},
 
 		// Wildcards
 		{"wildcard matches everything", "*", "1.2.3", true, false},
@@ -197,3 +202,46 @@ func TestVersionRange_String(t *testing.T) {
 		})
 	}
 }
+
+func TestVersionRange_TraceConstraints(t *testing.T) {
+	tests := []struct {
+		rangeStr string
+		version  string
+		want     [][]string
+		wantHits [][]bool
+	}{
+		{">=1.0.0,<2.0.0,!=1.5.0", "1.5.0", [][]string{{">=1.0.0", "<2.0.0", "!=1.5.0"}}, [][]bool{{true, true, false}}},
+		{"1.2.3", "1.2.3", [][]string{{"=1.2.3"}}, [][]bool{{true}}},
+		{"*", "0.1.0", [][]string{{"*"}}, [][]bool{{true}}},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.rangeStr, func(t *testing.T) {
+			vr, err := e.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("NewVersionRange(%q) unexpected error: %v", tt.rangeStr, err)
+			}
+			v, err := e.NewVersion(tt.version)
+			if err != nil {
+				t.Fatalf("NewVersion(%q) unexpected error: %v", tt.version, err)
+			}
+
+			if got := vr.TraceConstraints(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TraceConstraints() = %v, want %v", got, tt.want)
+			}
+			if got := vr.TraceMatches(v); !reflect.DeepEqual(got, tt.wantHits) {
+				t.Errorf("TraceMatches(%q) = %v, want %v", tt.version, got, tt.wantHits)
+			}
+		})
+	}
+}
+
+func TestEcosystem_Features(t *testing.T) {
+	e := &Ecosystem{}
+	for _, f := range e.Features() {
+		if _, err := e.NewVersionRange(f.Syntax); err != nil {
+			t.Errorf("Features() %s syntax %q does not parse: %v", f.Name, f.Syntax, err)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+//go:build !univers_noregexp
+
+package semver
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestScanVersion checks that the hand-written parser used by univers_noregexp
+// builds agrees with versionPattern on a corpus and on every short string over
+// an alphabet of the characters that matter to the grammar.
+func TestScanVersion(t *testing.T) {
+	inputs := []string{"1.2.3", "v1.2.3", "1.2.3-alpha.1+build.5", "1.2.3-", "1.2.3+", "1.2.3-a..b", "1.2.3-x-y-z.--", "01.002.3", "1.2", "1.2.3.4", "1.0.0-0.3.7", "1.0.0+20130313144700"}
+
+	alphabet := []string{"0", "1", "9", ".", "-", "+", "a", "v", "Z", "_"}
+	frontier := []string{""}
+	for range 5 {
+		var next []string
+		for _, prefix := range frontier {
+			for _, c := range alphabet {
+				next = append(next, prefix+c)
+			}
+		}
+		inputs = append(inputs, next...)
+		frontier = next
+	}
+
+	for _, input := range inputs {
+		got := scanVersion(input)
+		want := versionPattern.FindStringSubmatch(input)
+		if !slices.Equal(got, want) {
+			t.Errorf("scanVersion(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+// benchmarkVersion exercises every optional part of the grammar
+const benchmarkVersion = "1.22.333-rc.1+build.5"
+
+func BenchmarkVersionPattern(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		versionPattern.FindStringSubmatch(benchmarkVersion)
+	}
+}
+
+func BenchmarkScanVersion(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		scanVersion(benchmarkVersion)
+	}
+}
@@ -1,8 +1,10 @@
 // Package semver provides functionality for working with standard Semantic Versioning 2.0.0.
 package semver
 
+import "github.com/alowayed/go-univers/pkg/univers"
+
 const (
-	Name = "semver"
+	Name = string(univers.SemVer)
 )
 
 type Ecosystem struct{}
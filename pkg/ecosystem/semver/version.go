@@ -2,21 +2,11 @@ package semver
 
 import (
 	"fmt"
-	"regexp"
 	"strconv"
 	"strings"
-)
-
-// Package-level compiled regular expressions for performance
-var (
-	// versionPattern matches SemVer 2.0.0 version strings
-	// Group 1: major, Group 2: minor, Group 3: patch
-	// Group 4: prerelease (optional), Group 5: build metadata (optional)
-	versionPattern = regexp.MustCompile(`^(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z\-]+(?:\.[0-9A-Za-z\-]+)*))?(?:\+([0-9A-Za-z\-]+(?:\.[0-9A-Za-z\-]+)*))?$`)
 
-	// Patterns for prerelease and build metadata validation
-	validCharsPattern = regexp.MustCompile(`^[0-9A-Za-z\-]+$`)
-	numericPattern    = regexp.MustCompile(`^[0-9]+$`)
+	"github.com/alowayed/go-univers/internal/digits"
+	"github.com/alowayed/go-univers/pkg/univers"
 )
 
 // Version represents a Semantic Version 2.0.0
@@ -31,6 +21,10 @@ type Version struct {
 
 // NewVersion creates a new SemVer version from a string
 func (e *Ecosystem) NewVersion(version string) (*Version, error) {
+	if err := univers.CheckLength(version); err != nil {
+		return nil, err
+	}
+
 	original := version
 	version = strings.TrimSpace(version)
 
@@ -38,7 +32,7 @@ func (e *Ecosystem) NewVersion(version string) (*Version, error) {
 		return nil, fmt.Errorf("empty version string")
 	}
 
-	matches := versionPattern.FindStringSubmatch(version)
+	matches := matchVersion(version)
 	if matches == nil {
 		return nil, fmt.Errorf("invalid semantic version: %s", original)
 	}
@@ -101,6 +95,35 @@ func (e *Ecosystem) NewVersion(version string) (*Version, error) {
 	}, nil
 }
 
+// ValidVersion reports whether NewVersion accepts version, without
+// allocating. It suits ingestion filters that only need to accept or reject
+// versions and never compare them.
+func ValidVersion(version string) bool {
+	if univers.CheckLength(version) != nil {
+		return false
+	}
+	var parts [5]string
+	if !scanVersionParts(strings.TrimSpace(version), &parts) {
+		return false
+	}
+
+	for _, n := range parts[:3] {
+		if !digits.FitsInt(n) || (len(n) > 1 && n[0] == '0') {
+			return false
+		}
+	}
+
+	// Numeric prerelease identifiers must not have leading zeros
+	for prerelease := parts[3]; prerelease != ""; {
+		var part string
+		part, prerelease, _ = strings.Cut(prerelease, ".")
+		if len(part) > 1 && part[0] == '0' && scanNumeric(part) {
+			return false
+		}
+	}
+	return true
+}
+
 // validatePrerelease validates prerelease identifiers according to SemVer 2.0
 func validatePrerelease(prerelease string) error {
 	parts := strings.Split(prerelease, ".")
@@ -110,12 +133,12 @@ func validatePrerelease(prerelease string) error {
 		}
 
 		// Check for valid characters (alphanumerics and hyphens only)
-		if !validCharsPattern.MatchString(part) {
+		if !isIdentifier(part) {
 			return fmt.Errorf("invalid characters in prerelease identifier: %s", part)
 		}
 
 		// Numeric identifiers must not have leading zeros
-		if numericPattern.MatchString(part) {
+		if isNumeric(part) {
 			if len(part) > 1 && part[0] == '0' {
 				return fmt.Errorf("numeric prerelease identifier cannot have leading zeros: %s", part)
 			}
@@ -133,7 +156,7 @@ func validateBuildMetadata(build string) error {
 		}
 
 		// Check for valid characters (alphanumerics and hyphens only)
-		if !validCharsPattern.MatchString(part) {
+		if !isIdentifier(part) {
 			return fmt.Errorf("invalid characters in build metadata identifier: %s", part)
 		}
 	}
@@ -145,6 +168,21 @@ func (v *Version) String() string {
 	return v.original
 }
 
+// PrereleaseChannel returns the channel of the prerelease, such as "beta"
+// for 1.0.0-beta.2 or "rc" for 1.0.0-RC1, or "" for a release. Channels are
+// normalized as by univers.SplitPrerelease.
+func (v *Version) PrereleaseChannel() string {
+	channel, _, _ := univers.SplitPrerelease(v.prerelease)
+	return channel
+}
+
+// PrereleaseNumber returns the number following the prerelease channel, such
+// as 2 for 1.0.0-beta.2, and whether the version has one.
+func (v *Version) PrereleaseNumber() (int, bool) {
+	_, number, ok := univers.SplitPrerelease(v.prerelease)
+	return number, ok
+}
+
 // Compare compares this version with another SemVer version
 // Returns -1 if this < other, 0 if this == other, 1 if this > other
 func (v *Version) Compare(other *Version) int {
@@ -205,8 +243,8 @@ func comparePrerelease(a, b string) int {
 		}
 
 		// Both parts exist, compare them
-		aIsNum := numericPattern.MatchString(aPart)
-		bIsNum := numericPattern.MatchString(bPart)
+		aIsNum := isNumeric(aPart)
+		bIsNum := isNumeric(bPart)
 
 		if aIsNum && bIsNum {
 			// Both are numeric, compare numerically
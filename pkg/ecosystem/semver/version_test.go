@@ -326,3 +326,36 @@ func TestVersion_String(t *testing.T) {
 		})
 	}
 }
+
+func TestVersion_PrereleaseChannel(t *testing.T) {
+	tests := []struct {
+		version     string
+		wantChannel string
+		wantNumber  int
+		wantOK      bool
+	}{
+		{"1.0.0", "", 0, false},
+		{"1.0.0-alpha", "alpha", 0, false},
+		{"1.0.0-beta.2", "beta", 2, true},
+		{"1.0.0-RC1", "rc", 1, true},
+		{"1.0.0-0.3.7", "", 0, true},
+		{"1.0.0-rc.1+build.5", "rc", 1, true},
+	}
+
+	e := &Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			v, err := e.NewVersion(tt.version)
+			if err != nil {
+				t.Fatalf("NewVersion(%q) error = %v", tt.version, err)
+			}
+			if got := v.PrereleaseChannel(); got != tt.wantChannel {
+				t.Errorf("PrereleaseChannel() = %q, want %q", got, tt.wantChannel)
+			}
+			number, ok := v.PrereleaseNumber()
+			if number != tt.wantNumber || ok != tt.wantOK {
+				t.Errorf("PrereleaseNumber() = %d, %v, want %d, %v", number, ok, tt.wantNumber, tt.wantOK)
+			}
+		})
+	}
+}
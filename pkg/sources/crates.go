@@ -0,0 +1,81 @@
+package sources
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// CratesIndexURL is the sparse index of crates.io.
+const CratesIndexURL = "https://index.crates.io"
+
+// CratesIndex lists crate versions from a Cargo sparse registry index, such as
+// the crates.io index.
+type CratesIndex struct {
+	// Client performs requests. It defaults to http.DefaultClient.
+	Client *http.Client
+	// BaseURL is the index URL. It defaults to CratesIndexURL.
+	BaseURL string
+	// IncludeYanked also lists yanked versions, which Cargo skips when
+	// resolving new dependencies.
+	IncludeYanked bool
+}
+
+// ListVersions returns the versions of crate pkg in index order, which is
+// publication order.
+func (c *CratesIndex) ListVersions(pkg string) ([]string, error) {
+	if pkg == "" {
+		return nil, fmt.Errorf("crates index: empty crate name")
+	}
+
+	url := strings.TrimSuffix(baseURL(c.BaseURL, CratesIndexURL), "/") + "/" + cratesIndexPath(pkg)
+	body, err := get(c.Client, url, "")
+	if err != nil {
+		return nil, fmt.Errorf("crates index: %w", err)
+	}
+
+	// Each line is the JSON record of one published version
+	var versions []string
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(nil, len(body)+1)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var record struct {
+			Vers   string `json:"vers"`
+			Yanked bool   `json:"yanked"`
+		}
+		if err := json.Unmarshal(line, &record); err != nil {
+			return nil, fmt.Errorf("crates index: invalid record for %q: %w", pkg, err)
+		}
+		if record.Yanked && !c.IncludeYanked {
+			continue
+		}
+		versions = append(versions, record.Vers)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("crates index: %w", err)
+	}
+	return versions, nil
+}
+
+// cratesIndexPath returns the path of a crate's file in a Cargo index: names
+// are lowercased and sharded by length and leading characters
+func cratesIndexPath(name string) string {
+	name = strings.ToLower(name)
+	switch len(name) {
+	case 1:
+		return "1/" + name
+	case 2:
+		return "2/" + name
+	case 3:
+		return "3/" + name[:1] + "/" + name
+	default:
+		return name[:2] + "/" + name[2:4] + "/" + name
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/alowayed/go-univers/pkg/sources
+
+go 1.24.4
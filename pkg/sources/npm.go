@@ -0,0 +1,50 @@
+package sources
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// NPMRegistryURL is the public npm registry.
+const NPMRegistryURL = "https://registry.npmjs.org"
+
+// NPMRegistry lists package versions from an npm registry's packument, using
+// the abbreviated metadata format npm install reads.
+type NPMRegistry struct {
+	// Client performs requests. It defaults to http.DefaultClient.
+	Client *http.Client
+	// BaseURL is the registry URL. It defaults to NPMRegistryURL.
+	BaseURL string
+}
+
+// ListVersions returns the published versions of pkg, which may be scoped
+// (e.g. "@types/node"), in ascending string order.
+func (r *NPMRegistry) ListVersions(pkg string) ([]string, error) {
+	if pkg == "" {
+		return nil, fmt.Errorf("npm registry: empty package name")
+	}
+
+	// Scoped packages keep their "@" but escape the slash
+	url := strings.TrimSuffix(baseURL(r.BaseURL, NPMRegistryURL), "/") + "/" + strings.ReplaceAll(pkg, "/", "%2F")
+	body, err := get(r.Client, url, "application/vnd.npm.install-v1+json")
+	if err != nil {
+		return nil, fmt.Errorf("npm registry: %w", err)
+	}
+
+	var packument struct {
+		Versions map[string]json.RawMessage `json:"versions"`
+	}
+	if err := json.Unmarshal(body, &packument); err != nil {
+		return nil, fmt.Errorf("npm registry: invalid packument for %q: %w", pkg, err)
+	}
+
+	versions := make([]string, 0, len(packument.Versions))
+	for v := range packument.Versions {
+		versions = append(versions, v)
+	}
+	slices.Sort(versions)
+	return versions, nil
+}
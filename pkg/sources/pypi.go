@@ -0,0 +1,55 @@
+package sources
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// PyPIURL is the public Python Package Index.
+const PyPIURL = "https://pypi.org"
+
+// PyPIJSON lists package versions from the PyPI JSON API
+// (/pypi/<project>/json).
+type PyPIJSON struct {
+	// Client performs requests. It defaults to http.DefaultClient.
+	Client *http.Client
+	// BaseURL is the index URL. It defaults to PyPIURL.
+	BaseURL string
+	// IncludeEmpty also lists releases without any uploaded files, which pip
+	// can never install.
+	IncludeEmpty bool
+}
+
+// ListVersions returns the released versions of pkg in ascending string order.
+func (p *PyPIJSON) ListVersions(pkg string) ([]string, error) {
+	if pkg == "" {
+		return nil, fmt.Errorf("pypi: empty package name")
+	}
+
+	u := strings.TrimSuffix(baseURL(p.BaseURL, PyPIURL), "/") + "/pypi/" + url.PathEscape(pkg) + "/json"
+	body, err := get(p.Client, u, "application/json")
+	if err != nil {
+		return nil, fmt.Errorf("pypi: %w", err)
+	}
+
+	var project struct {
+		Releases map[string][]json.RawMessage `json:"releases"`
+	}
+	if err := json.Unmarshal(body, &project); err != nil {
+		return nil, fmt.Errorf("pypi: invalid project metadata for %q: %w", pkg, err)
+	}
+
+	versions := make([]string, 0, len(project.Releases))
+	for v, files := range project.Releases {
+		if len(files) == 0 && !p.IncludeEmpty {
+			continue
+		}
+		versions = append(versions, v)
+	}
+	slices.Sort(versions)
+	return versions, nil
+}
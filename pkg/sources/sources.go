@@ -0,0 +1,61 @@
+// Package sources provides univers.VersionSource adapters backed by package
+// registries. It is a separate module so that the core library stays free of
+// network code; import it only where registry lookups are wanted.
+//
+// Each adapter has a Client, defaulting to http.DefaultClient, and a BaseURL,
+// defaulting to the public registry, so that mirrors and test servers can be
+// used instead.
+package sources
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrPackageNotFound is returned, wrapped, when a registry has no package of
+// the requested name.
+var ErrPackageNotFound = errors.New("package not found")
+
+// get fetches url with the given Accept header and returns the response body
+func get(client *http.Client, url, accept string) ([]byte, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("GET %s: %w", url, ErrPackageNotFound)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("GET %s: unexpected status %s", url, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("GET %s: %w", url, err)
+	}
+	return body, nil
+}
+
+// baseURL returns base, or fallback if base is empty
+func baseURL(base, fallback string) string {
+	if base == "" {
+		return fallback
+	}
+	return base
+}
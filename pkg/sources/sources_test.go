@@ -0,0 +1,171 @@
+package sources
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+// newRegistry serves body for path and 404 for anything else, recording the
+// Accept header of the last request
+func newRegistry(t *testing.T, path, body string, accept *string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if accept != nil {
+			*accept = r.Header.Get("Accept")
+		}
+		if r.URL.EscapedPath() != path {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestNPMRegistry_ListVersions(t *testing.T) {
+	var accept string
+	srv := newRegistry(t, "/@types%2Fnode", `{"name":"@types/node","versions":{"20.1.0":{},"18.0.0":{},"20.0.0":{}}}`, &accept)
+	r := &NPMRegistry{Client: srv.Client(), BaseURL: srv.URL}
+
+	got, err := r.ListVersions("@types/node")
+	if err != nil {
+		t.Fatalf("ListVersions() error = %v", err)
+	}
+	if want := []string{"18.0.0", "20.0.0", "20.1.0"}; !slices.Equal(got, want) {
+		t.Errorf("ListVersions() = %v, want %v", got, want)
+	}
+	if accept != "application/vnd.npm.install-v1+json" {
+		t.Errorf("Accept = %q, want abbreviated metadata", accept)
+	}
+
+	if _, err := r.ListVersions("missing"); !errors.Is(err, ErrPackageNotFound) {
+		t.Errorf("ListVersions(missing) error = %v, want ErrPackageNotFound", err)
+	}
+}
+
+func TestPyPIJSON_ListVersions(t *testing.T) {
+	body := `{"info":{"name":"requests"},"releases":{"2.31.0":[{"filename":"a.whl"}],"2.0.0":[{"filename":"b.tar.gz"}],"0.0.1":[]}}`
+	srv := newRegistry(t, "/pypi/requests/json", body, nil)
+
+	tests := []struct {
+		name   string
+		source *PyPIJSON
+		want   []string
+	}{
+		{
+			name:   "releases with files",
+			source: &PyPIJSON{Client: srv.Client(), BaseURL: srv.URL},
+			want:   []string{"2.0.0", "2.31.0"},
+		},
+		{
+			name:   "include empty releases",
+			source: &PyPIJSON{Client: srv.Client(), BaseURL: srv.URL, IncludeEmpty: true},
+			want:   []string{"0.0.1", "2.0.0", "2.31.0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.source.ListVersions("requests")
+			if err != nil {
+				t.Fatalf("ListVersions() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ListVersions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCratesIndex_ListVersions(t *testing.T) {
+	body := `{"name":"serde","vers":"1.0.0","yanked":false}
+{"name":"serde","vers":"1.0.1","yanked":true}
+
+{"name":"serde","vers":"1.0.2","yanked":false}
+`
+	srv := newRegistry(t, "/se/rd/serde", body, nil)
+
+	tests := []struct {
+		name   string
+		source *CratesIndex
+		want   []string
+	}{
+		{
+			name:   "yanked skipped",
+			source: &CratesIndex{Client: srv.Client(), BaseURL: srv.URL},
+			want:   []string{"1.0.0", "1.0.2"},
+		},
+		{
+			name:   "include yanked",
+			source: &CratesIndex{Client: srv.Client(), BaseURL: srv.URL, IncludeYanked: true},
+			want:   []string{"1.0.0", "1.0.1", "1.0.2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.source.ListVersions("Serde")
+			if err != nil {
+				t.Fatalf("ListVersions() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ListVersions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCratesIndexPath(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "a", want: "1/a"},
+		{name: "xz", want: "2/xz"},
+		{name: "syn", want: "3/s/syn"},
+		{name: "Serde", want: "se/rd/serde"},
+		{name: "tokio", want: "to/ki/tokio"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cratesIndexPath(tt.name); got != tt.want {
+				t.Errorf("cratesIndexPath(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListVersions_Errors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/broken", "/pypi/broken/json", "/br/ok/broken":
+			_, _ = w.Write([]byte("not json"))
+		default:
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	sources := map[string]interface {
+		ListVersions(string) ([]string, error)
+	}{
+		"npm":    &NPMRegistry{Client: srv.Client(), BaseURL: srv.URL},
+		"pypi":   &PyPIJSON{Client: srv.Client(), BaseURL: srv.URL},
+		"crates": &CratesIndex{Client: srv.Client(), BaseURL: srv.URL},
+	}
+
+	for name, src := range sources {
+		t.Run(name, func(t *testing.T) {
+			for _, pkg := range []string{"", "broken", "down"} {
+				if _, err := src.ListVersions(pkg); err == nil {
+					t.Errorf("ListVersions(%q) error = nil, want error", pkg)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+// Package cyclonedx evaluates the affected versions of CycloneDX
+// vulnerabilities against the components of a BOM.
+//
+// A CycloneDX vulnerability lists the components it affects by bom-ref, each
+// with a list of exact versions or vers ranges and their status:
+//
+//	"affects": [{
+//	  "ref": "pkg:npm/lodash@4.17.20",
+//	  "versions": [
+//	    {"range": "vers:npm/>=4.0.0|<4.17.21", "status": "affected"},
+//	    {"version": "4.17.21", "status": "unaffected"}
+//	  ]
+//	}]
+//
+// Ranges are evaluated with pkg/spec/vers. Exact versions are compared in the
+// versioning scheme of the component's package URL type, falling back to the
+// scheme of the entry's ranges, and to plain string equality when neither is
+// known.
+package cyclonedx
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alowayed/go-univers/pkg/spec/vers"
+)
+
+// Status is the status of the versions of an affects entry.
+type Status string
+
+const (
+	// StatusAffected versions are affected by the vulnerability. It is the
+	// CycloneDX default when an entry has no status.
+	StatusAffected Status = "affected"
+	// StatusUnaffected versions are not affected by the vulnerability.
+	StatusUnaffected Status = "unaffected"
+	// StatusUnknown versions may or may not be affected.
+	StatusUnknown Status = "unknown"
+)
+
+// Version is an entry of "affects[].versions". Exactly one of Version and
+// Range is set; Range holds a vers range such as "vers:npm/>=1.0.0|<1.2.3".
+type Version struct {
+	Version string `json:"version,omitempty"`
+	Range   string `json:"range,omitempty"`
+	Status  Status `json:"status,omitempty"`
+}
+
+// Affect is an entry of a vulnerability's "affects", naming a component by
+// bom-ref together with the versions the vulnerability applies to.
+type Affect struct {
+	Ref      string    `json:"ref"`
+	Versions []Version `json:"versions,omitempty"`
+}
+
+// Vulnerability is the part of a CycloneDX vulnerability needed to evaluate
+// it. Its JSON encoding matches the CycloneDX schema, so vulnerabilities can
+// be decoded straight from a BOM's "vulnerabilities".
+type Vulnerability struct {
+	BOMRef  string   `json:"bom-ref,omitempty"`
+	ID      string   `json:"id,omitempty"`
+	Affects []Affect `json:"affects,omitempty"`
+}
+
+// Component is the part of a CycloneDX component needed to evaluate
+// vulnerabilities against it, including its nested components.
+type Component struct {
+	BOMRef     string      `json:"bom-ref,omitempty"`
+	Name       string      `json:"name,omitempty"`
+	Version    string      `json:"version,omitempty"`
+	PURL       string      `json:"purl,omitempty"`
+	Components []Component `json:"components,omitempty"`
+}
+
+// BOM is the part of a CycloneDX BOM holding its components and
+// vulnerabilities. Other fields of the document are ignored when decoding.
+type BOM struct {
+	Components      []Component     `json:"components,omitempty"`
+	Vulnerabilities []Vulnerability `json:"vulnerabilities,omitempty"`
+}
+
+// Result is the evaluation of one affects entry against the version of the
+// component it names.
+type Result struct {
+	// Vulnerability is the ID of the vulnerability, or its bom-ref if it has
+	// no ID.
+	Vulnerability string
+	// Ref is the bom-ref of the component.
+	Ref string
+	// Version is the version of the component.
+	Version string
+	// Status is the status of the entries matching Version, or "" if none
+	// matches.
+	Status Status
+	// Affected reports whether Status is StatusAffected.
+	Affected bool
+}
+
+// purlTypeToScheme maps package URL types to the vers scheme of their versions
+var purlTypeToScheme = map[string]string{
+	"apk":      "alpine",
+	"cargo":    "cargo",
+	"composer": "composer",
+	"deb":      "deb",
+	"gem":      "gem",
+	"generic":  "generic",
+	"golang":   "golang",
+	"luarocks": "luarocks",
+	"maven":    "maven",
+	"npm":      "npm",
+	"nuget":    "nuget",
+	"pypi":     "pypi",
+	"rpm":      "rpm",
+}
+
+// Evaluate evaluates every affects entry of the BOM's vulnerabilities against
+// the version of the component it references, in document order.
+//
+// An error is returned if an entry references a component that is not in the
+// BOM or has no version, or if one of its versions or ranges is invalid.
+func Evaluate(bom BOM) ([]Result, error) {
+	components := map[string]Component{}
+	var index func([]Component)
+	index = func(cs []Component) {
+		for _, c := range cs {
+			if c.BOMRef != "" {
+				components[c.BOMRef] = c
+			}
+			index(c.Components)
+		}
+	}
+	index(bom.Components)
+
+	var results []Result
+	for _, vuln := range bom.Vulnerabilities {
+		id := vuln.ID
+		if id == "" {
+			id = vuln.BOMRef
+		}
+		for i, a := range vuln.Affects {
+			c, ok := components[a.Ref]
+			if !ok {
+				return nil, fmt.Errorf("vulnerability %q: affects %d: no component with bom-ref %q", id, i, a.Ref)
+			}
+			if c.Version == "" {
+				return nil, fmt.Errorf("vulnerability %q: affects %d: component %q has no version", id, i, a.Ref)
+			}
+			status, err := a.Status(Scheme(c.PURL), c.Version)
+			if err != nil {
+				return nil, fmt.Errorf("vulnerability %q: affects %d: %w", id, i, err)
+			}
+			results = append(results, Result{
+				Vulnerability: id,
+				Ref:           a.Ref,
+				Version:       c.Version,
+				Status:        status,
+				Affected:      status == StatusAffected,
+			})
+		}
+	}
+	return results, nil
+}
+
+// Status returns the status of version according to a's versions, or "" if
+// none of them matches it. Version entries are compared in scheme, a vers
+// scheme such as "npm"; an empty scheme takes the scheme of a's first range.
+//
+// When several entries match, an unaffected entry wins over an affected one,
+// which wins over an unknown one, so that an advisory can carve fixed
+// versions out of a wider affected range.
+func (a Affect) Status(scheme, version string) (Status, error) {
+	if scheme == "" {
+		scheme = a.rangeScheme()
+	}
+
+	var status Status
+	for i, v := range a.Versions {
+		matched, err := v.matches(scheme, version)
+		if err != nil {
+			return "", fmt.Errorf("version %d: %w", i, err)
+		}
+		if !matched {
+			continue
+		}
+		s := v.Status
+		if s == "" {
+			s = StatusAffected
+		}
+		if precedence(s) > precedence(status) {
+			status = s
+		}
+	}
+	return status, nil
+}
+
+// Scheme returns the vers scheme of a package URL such as
+// "pkg:npm/lodash@4.17.21", or "" if its type has no supported scheme.
+func Scheme(purl string) string {
+	rest, ok := strings.CutPrefix(purl, "pkg:")
+	if !ok {
+		return ""
+	}
+	typ, _, _ := strings.Cut(strings.TrimLeft(rest, "/"), "/")
+	return purlTypeToScheme[strings.ToLower(typ)]
+}
+
+// matches reports whether version is v's exact version or within v's range
+func (v Version) matches(scheme, version string) (bool, error) {
+	switch {
+	case v.Version != "" && v.Range != "":
+		return false, fmt.Errorf("only one of version and range may be set")
+	case v.Range != "":
+		return vers.Contains(v.Range, version)
+	case v.Version != "":
+		if scheme == "" {
+			return v.Version == version, nil
+		}
+		return vers.Contains("vers:"+scheme+"/"+v.Version, version)
+	}
+	return false, fmt.Errorf("one of version and range must be set")
+}
+
+// rangeScheme returns the scheme of the first vers range of a, or ""
+func (a Affect) rangeScheme() string {
+	for _, v := range a.Versions {
+		if rest, ok := strings.CutPrefix(v.Range, "vers:"); ok {
+			scheme, _, _ := strings.Cut(rest, "/")
+			return strings.ToLower(scheme)
+		}
+	}
+	return ""
+}
+
+// precedence orders statuses by how strongly they hold when several entries
+// match the same version
+func precedence(s Status) int {
+	switch s {
+	case StatusUnaffected:
+		return 3
+	case StatusAffected:
+		return 2
+	case StatusUnknown:
+		return 1
+	}
+	return 0
+}
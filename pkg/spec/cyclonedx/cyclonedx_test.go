@@ -0,0 +1,203 @@
+package cyclonedx
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestAffect_Status(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions []Version
+		scheme   string
+		version  string
+		want     Status
+		wantErr  bool
+	}{
+		{
+			name:     "in range",
+			versions: []Version{{Range: "vers:npm/>=4.0.0|<4.17.21", Status: StatusAffected}},
+			version:  "4.17.20",
+			want:     StatusAffected,
+		},
+		{
+			name:     "outside range",
+			versions: []Version{{Range: "vers:npm/>=4.0.0|<4.17.21", Status: StatusAffected}},
+			version:  "4.17.21",
+			want:     "",
+		},
+		{
+			name:     "missing status defaults to affected",
+			versions: []Version{{Range: "vers:pypi/<2.0"}},
+			version:  "1.9",
+			want:     StatusAffected,
+		},
+		{
+			name:     "exact version uses the given scheme",
+			versions: []Version{{Version: "1.0", Status: StatusAffected}},
+			scheme:   "maven",
+			version:  "1.0.0",
+			want:     StatusAffected,
+		},
+		{
+			name:     "exact version takes the scheme of the ranges",
+			versions: []Version{{Range: "vers:pypi/>=3.0", Status: StatusUnknown}, {Version: "2.0", Status: StatusAffected}},
+			version:  "2.0.0",
+			want:     StatusAffected,
+		},
+		{
+			name:     "exact version without a scheme compares strings",
+			versions: []Version{{Version: "2.0", Status: StatusAffected}},
+			version:  "2.0.0",
+			want:     "",
+		},
+		{
+			name: "unaffected wins over affected",
+			versions: []Version{
+				{Range: "vers:deb/>=1.0|<2.0", Status: StatusAffected},
+				{Version: "1.5-1+deb12u1", Status: StatusUnaffected},
+			},
+			version: "1.5-1+deb12u1",
+			want:    StatusUnaffected,
+		},
+		{
+			name: "affected wins over unknown",
+			versions: []Version{
+				{Range: "vers:npm/*", Status: StatusUnknown},
+				{Range: "vers:npm/<2.0.0", Status: StatusAffected},
+			},
+			version: "1.0.0",
+			want:    StatusAffected,
+		},
+		{
+			name:     "invalid range",
+			versions: []Version{{Range: "vers:npm/>=garbage"}},
+			version:  "1.0.0",
+			wantErr:  true,
+		},
+		{
+			name:     "version and range both set",
+			versions: []Version{{Version: "1.0.0", Range: "vers:npm/<2.0.0"}},
+			version:  "1.0.0",
+			wantErr:  true,
+		},
+		{
+			name:     "neither version nor range set",
+			versions: []Version{{Status: StatusAffected}},
+			version:  "1.0.0",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := Affect{Ref: "c", Versions: tt.versions}
+			got, err := a.Status(tt.scheme, tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Status() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Status() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	doc := `{
+		"bomFormat": "CycloneDX",
+		"specVersion": "1.6",
+		"components": [
+			{"bom-ref": "lodash", "name": "lodash", "version": "4.17.20", "purl": "pkg:npm/lodash@4.17.20"},
+			{"bom-ref": "app", "name": "app", "version": "1.0.0", "components": [
+				{"bom-ref": "requests", "name": "requests", "version": "2.31.0", "purl": "pkg:pypi/requests@2.31.0"}
+			]}
+		],
+		"vulnerabilities": [
+			{"id": "CVE-2021-23337", "affects": [{"ref": "lodash", "versions": [
+				{"range": "vers:npm/<4.17.21", "status": "affected"}
+			]}]},
+			{"bom-ref": "vuln-1", "affects": [{"ref": "requests", "versions": [
+				{"range": "vers:pypi/>=2.0|<2.32.0", "status": "affected"},
+				{"version": "2.31", "status": "unaffected"}
+			]}]}
+		]
+	}`
+
+	var bom BOM
+	if err := json.Unmarshal([]byte(doc), &bom); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Evaluate(bom)
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+	want := []Result{
+		{Vulnerability: "CVE-2021-23337", Ref: "lodash", Version: "4.17.20", Status: StatusAffected, Affected: true},
+		{Vulnerability: "vuln-1", Ref: "requests", Version: "2.31.0", Status: StatusUnaffected, Affected: false},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Evaluate() = %+v, want %+v", got, want)
+	}
+}
+
+func TestEvaluate_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		bom  BOM
+	}{
+		{
+			name: "unknown ref",
+			bom: BOM{Vulnerabilities: []Vulnerability{
+				{ID: "CVE-1", Affects: []Affect{{Ref: "missing", Versions: []Version{{Version: "1.0.0"}}}}},
+			}},
+		},
+		{
+			name: "component without version",
+			bom: BOM{
+				Components:      []Component{{BOMRef: "c", Name: "c"}},
+				Vulnerabilities: []Vulnerability{{ID: "CVE-1", Affects: []Affect{{Ref: "c", Versions: []Version{{Version: "1.0.0"}}}}}},
+			},
+		},
+		{
+			name: "invalid range",
+			bom: BOM{
+				Components:      []Component{{BOMRef: "c", Version: "1.0.0"}},
+				Vulnerabilities: []Vulnerability{{ID: "CVE-1", Affects: []Affect{{Ref: "c", Versions: []Version{{Range: "npm/<2.0.0"}}}}}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Evaluate(tt.bom); err == nil {
+				t.Error("Evaluate() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestScheme(t *testing.T) {
+	tests := []struct {
+		purl string
+		want string
+	}{
+		{"pkg:npm/lodash@4.17.21", "npm"},
+		{"pkg:npm/%40angular/core@17.0.0", "npm"},
+		{"pkg:apk/alpine/openssl@3.1.4-r5", "alpine"},
+		{"pkg:deb/debian/openssl@3.0.11-1", "deb"},
+		{"pkg:Maven/org.apache.logging.log4j/log4j-core@2.17.1", "maven"},
+		{"pkg:github/alowayed/go-univers", ""},
+		{"npm/lodash", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.purl, func(t *testing.T) {
+			if got := Scheme(tt.purl); got != tt.want {
+				t.Errorf("Scheme(%q) = %q, want %q", tt.purl, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,278 @@
+// Package osv evaluates the affected ranges of OSV (Open Source Vulnerability)
+// records against package versions.
+//
+// An OSV range is a type and a list of events:
+//
+//	{"type": "ECOSYSTEM", "events": [{"introduced": "0"}, {"fixed": "1.2.3"}]}
+//
+// SEMVER ranges are compared as Semantic Versions, ECOSYSTEM ranges with the
+// versioning rules of the affected package's ecosystem, and GIT ranges hold
+// commit hashes that cannot be compared without the repository history.
+//
+// Supported ecosystems: AlmaLinux, Alpine, Bitnami, Chainguard, ConanCenter,
+// CRAN, crates.io, Debian, Go, Hex, Mageia, Maven, npm, NuGet, openSUSE,
+// Packagist, PyPI, Red Hat, Rocky Linux, RubyGems, SUSE, Ubuntu, Wolfi
+package osv
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/alpine"
+	"github.com/alowayed/go-univers/pkg/ecosystem/cargo"
+	"github.com/alowayed/go-univers/pkg/ecosystem/composer"
+	"github.com/alowayed/go-univers/pkg/ecosystem/conan"
+	"github.com/alowayed/go-univers/pkg/ecosystem/cran"
+	"github.com/alowayed/go-univers/pkg/ecosystem/debian"
+	"github.com/alowayed/go-univers/pkg/ecosystem/gem"
+	"github.com/alowayed/go-univers/pkg/ecosystem/golang"
+	"github.com/alowayed/go-univers/pkg/ecosystem/hex"
+	"github.com/alowayed/go-univers/pkg/ecosystem/maven"
+	"github.com/alowayed/go-univers/pkg/ecosystem/npm"
+	"github.com/alowayed/go-univers/pkg/ecosystem/nuget"
+	"github.com/alowayed/go-univers/pkg/ecosystem/pypi"
+	"github.com/alowayed/go-univers/pkg/ecosystem/rpm"
+	"github.com/alowayed/go-univers/pkg/ecosystem/semver"
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+// RangeType is the type of an OSV range, which determines how its event
+// versions are compared.
+type RangeType string
+
+const (
+	// RangeTypeSemver ranges hold Semantic Versions 2.0.0 without a "v" prefix
+	RangeTypeSemver RangeType = "SEMVER"
+	// RangeTypeEcosystem ranges hold versions of the affected package's ecosystem
+	RangeTypeEcosystem RangeType = "ECOSYSTEM"
+	// RangeTypeGit ranges hold full commit hashes of the repository in Repo
+	RangeTypeGit RangeType = "GIT"
+)
+
+// Event is a single entry of an OSV range's events. Exactly one field is set.
+// An Introduced value of "0" means the range starts before every version.
+type Event struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+	Limit        string `json:"limit,omitempty"`
+}
+
+// Range is an OSV affected range. Its JSON encoding matches the OSV schema, so
+// ranges can be decoded straight from an advisory's "affected[].ranges".
+type Range struct {
+	Type   RangeType `json:"type"`
+	Repo   string    `json:"repo,omitempty"`
+	Events []Event   `json:"events"`
+}
+
+// NotEvaluableError is returned when a range cannot be evaluated against a
+// version, as for GIT ranges whose events are commits. The commits are
+// surfaced so callers can resolve them against the repository themselves.
+type NotEvaluableError struct {
+	Type         RangeType
+	Repo         string
+	Introduced   []string
+	Fixed        []string
+	LastAffected []string
+	Limit        []string
+}
+
+func (e *NotEvaluableError) Error() string {
+	return fmt.Sprintf("%s range of %q cannot be evaluated against versions", e.Type, e.Repo)
+}
+
+// ecosystemToContains maps OSV ecosystem names to their range evaluation
+var ecosystemToContains = map[string]func([]Event, string) (bool, error){
+	"AlmaLinux":   evaluator(&rpm.Ecosystem{}),
+	"Alpine":      evaluator(&alpine.Ecosystem{}),
+	"Bitnami":     evaluator(&semver.Ecosystem{}),
+	"Chainguard":  evaluator(&alpine.Ecosystem{}),
+	"ConanCenter": evaluator(&conan.Ecosystem{}),
+	"CRAN":        evaluator(&cran.Ecosystem{}),
+	"crates.io":   evaluator(&cargo.Ecosystem{}),
+	"Debian":      evaluator(&debian.Ecosystem{}),
+	"Go":          evaluator(&golang.Ecosystem{}),
+	"Hex":         evaluator(&hex.Ecosystem{}),
+	"Mageia":      evaluator(&rpm.Ecosystem{}),
+	"Maven":       evaluator(&maven.Ecosystem{}),
+	"npm":         evaluator(&npm.Ecosystem{}),
+	"NuGet":       evaluator(&nuget.Ecosystem{}),
+	"openSUSE":    evaluator(&rpm.Ecosystem{}),
+	"Packagist":   evaluator(&composer.Ecosystem{}),
+	"PyPI":        evaluator(&pypi.Ecosystem{}),
+	"Red Hat":     evaluator(&rpm.Ecosystem{}),
+	"Rocky Linux": evaluator(&rpm.Ecosystem{}),
+	"RubyGems":    evaluator(&gem.Ecosystem{}),
+	"SUSE":        evaluator(&rpm.Ecosystem{}),
+	"Ubuntu":      evaluator(&debian.Ecosystem{}),
+	"Wolfi":       evaluator(&alpine.Ecosystem{}),
+}
+
+// Contains reports whether version is affected by the range r of a package in
+// the given OSV ecosystem, such as "npm", "PyPI" or "Debian:12". The ecosystem
+// is only consulted for ECOSYSTEM ranges; SEMVER ranges always compare
+// Semantic Versions. GIT ranges return a *NotEvaluableError.
+// Example: Contains("npm", Range{Type: RangeTypeEcosystem, Events: []Event{{Introduced: "0"}, {Fixed: "1.2.3"}}}, "1.0.0") returns true.
+func Contains(ecosystem string, r Range, version string) (bool, error) {
+	if len(r.Events) == 0 {
+		return false, fmt.Errorf("range has no events")
+	}
+
+	switch r.Type {
+	case RangeTypeSemver:
+		return evaluator(&semver.Ecosystem{})(r.Events, version)
+	case RangeTypeEcosystem:
+		// Releases of an ecosystem are given after a colon, as in "Debian:12"
+		name, _, _ := strings.Cut(ecosystem, ":")
+		containsForEcosystem, ok := ecosystemToContains[name]
+		if !ok {
+			return false, fmt.Errorf("ecosystem %q unsupported", ecosystem)
+		}
+		return containsForEcosystem(r.Events, version)
+	case RangeTypeGit:
+		return false, notEvaluable(r)
+	default:
+		return false, fmt.Errorf("range type %q unsupported", r.Type)
+	}
+}
+
+// Ecosystems returns the supported OSV ecosystem names in sorted order.
+func Ecosystems() []string {
+	names := make([]string, 0, len(ecosystemToContains))
+	for name := range ecosystemToContains {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// notEvaluable collects the commits of a range into a NotEvaluableError
+func notEvaluable(r Range) *NotEvaluableError {
+	err := &NotEvaluableError{Type: r.Type, Repo: r.Repo}
+	for _, ev := range r.Events {
+		switch {
+		case ev.Introduced != "":
+			err.Introduced = append(err.Introduced, ev.Introduced)
+		case ev.Fixed != "":
+			err.Fixed = append(err.Fixed, ev.Fixed)
+		case ev.LastAffected != "":
+			err.LastAffected = append(err.LastAffected, ev.LastAffected)
+		case ev.Limit != "":
+			err.Limit = append(err.Limit, ev.Limit)
+		}
+	}
+	return err
+}
+
+// evaluator returns a function evaluating events with the given ecosystem
+func evaluator[V univers.Version[V], VR univers.VersionRange[V]](
+	e univers.Ecosystem[V, VR],
+) func([]Event, string) (bool, error) {
+	return func(events []Event, version string) (bool, error) {
+		return contains(e, events, version)
+	}
+}
+
+// contains implements the OSV evaluation algorithm: events are sorted by
+// version and walked in order, each introduced event at or below the version
+// marking it affected and each fixed or last_affected event below it marking
+// it unaffected. When limits are present the version must also be below one.
+func contains[V univers.Version[V], VR univers.VersionRange[V]](
+	e univers.Ecosystem[V, VR],
+	events []Event,
+	version string,
+) (bool, error) {
+	v, err := e.NewVersion(version)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s version '%s': %w", e.Name(), version, err)
+	}
+
+	type parsedEvent struct {
+		kind    string // "introduced", "fixed", "last_affected" or "limit"
+		version string
+		parsed  V
+		zero    bool // introduced "0", before every version
+	}
+
+	parsed := make([]parsedEvent, 0, len(events))
+	for i, ev := range events {
+		kind, eventVersion, err := eventKind(ev)
+		if err != nil {
+			return false, fmt.Errorf("invalid event %d: %w", i, err)
+		}
+		pe := parsedEvent{kind: kind, version: eventVersion}
+		if kind == "introduced" && eventVersion == "0" {
+			pe.zero = true
+		} else {
+			pe.parsed, err = e.NewVersion(eventVersion)
+			if err != nil {
+				return false, fmt.Errorf("invalid version in %s event '%s': %w", kind, eventVersion, err)
+			}
+		}
+		parsed = append(parsed, pe)
+	}
+
+	slices.SortStableFunc(parsed, func(a, b parsedEvent) int {
+		switch {
+		case a.zero && b.zero:
+			return 0
+		case a.zero:
+			return -1
+		case b.zero:
+			return 1
+		}
+		return a.parsed.Compare(b.parsed)
+	})
+
+	affected := false
+	hasLimit, belowLimit := false, false
+	for _, ev := range parsed {
+		switch ev.kind {
+		case "introduced":
+			if ev.zero || v.Compare(ev.parsed) >= 0 {
+				affected = true
+			}
+		case "fixed":
+			if v.Compare(ev.parsed) >= 0 {
+				affected = false
+			}
+		case "last_affected":
+			if v.Compare(ev.parsed) > 0 {
+				affected = false
+			}
+		case "limit":
+			hasLimit = true
+			if v.Compare(ev.parsed) < 0 {
+				belowLimit = true
+			}
+		}
+	}
+
+	if hasLimit && !belowLimit {
+		return false, nil
+	}
+	return affected, nil
+}
+
+// eventKind returns the name and version of the single field set in ev
+func eventKind(ev Event) (string, string, error) {
+	var kind, version string
+	set := 0
+	for _, f := range []struct{ kind, version string }{
+		{"introduced", ev.Introduced},
+		{"fixed", ev.Fixed},
+		{"last_affected", ev.LastAffected},
+		{"limit", ev.Limit},
+	} {
+		if f.version != "" {
+			kind, version = f.kind, f.version
+			set++
+		}
+	}
+	if set != 1 {
+		return "", "", fmt.Errorf("exactly one of introduced, fixed, last_affected or limit must be set")
+	}
+	return kind, version, nil
+}
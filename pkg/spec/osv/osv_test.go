@@ -0,0 +1,257 @@
+package osv
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestContains(t *testing.T) {
+	introducedFixed := func(introduced, fixed string) Range {
+		return Range{Type: RangeTypeEcosystem, Events: []Event{{Introduced: introduced}, {Fixed: fixed}}}
+	}
+
+	tests := []struct {
+		name      string
+		ecosystem string
+		r         Range
+		version   string
+		want      bool
+		wantErr   bool
+	}{
+		// ECOSYSTEM ranges dispatch by ecosystem
+		{
+			name:      "npm affected",
+			ecosystem: "npm",
+			r:         introducedFixed("0", "1.2.3"),
+			version:   "1.0.0",
+			want:      true,
+		},
+		{
+			name:      "npm fixed",
+			ecosystem: "npm",
+			r:         introducedFixed("0", "1.2.3"),
+			version:   "1.2.3",
+			want:      false,
+		},
+		{
+			name:      "pypi uses PEP 440 ordering",
+			ecosystem: "PyPI",
+			r:         introducedFixed("1.0", "1.1"),
+			version:   "1.1rc1",
+			want:      true,
+		},
+		{
+			name:      "debian release suffix",
+			ecosystem: "Debian:12",
+			r:         introducedFixed("0", "1:2.0-1"),
+			version:   "1:1.9-3",
+			want:      true,
+		},
+		{
+			name:      "debian epoch",
+			ecosystem: "Debian:12",
+			r:         introducedFixed("0", "1:2.0-1"),
+			version:   "3.0-1",
+			want:      true,
+		},
+		{
+			name:      "go versions without v prefix",
+			ecosystem: "Go",
+			r:         introducedFixed("1.2.0", "1.2.5"),
+			version:   "1.2.4",
+			want:      true,
+		},
+		{
+			name:      "maven qualifier",
+			ecosystem: "Maven",
+			r:         introducedFixed("0", "2.0"),
+			version:   "2.0-SNAPSHOT",
+			want:      true,
+		},
+		{
+			name:      "before introduced",
+			ecosystem: "crates.io",
+			r:         introducedFixed("1.0.0", "1.2.0"),
+			version:   "0.9.0",
+			want:      false,
+		},
+		// Event semantics
+		{
+			name:      "last_affected is inclusive",
+			ecosystem: "npm",
+			r:         Range{Type: RangeTypeEcosystem, Events: []Event{{Introduced: "1.0.0"}, {LastAffected: "1.2.0"}}},
+			version:   "1.2.0",
+			want:      true,
+		},
+		{
+			name:      "after last_affected",
+			ecosystem: "npm",
+			r:         Range{Type: RangeTypeEcosystem, Events: []Event{{Introduced: "1.0.0"}, {LastAffected: "1.2.0"}}},
+			version:   "1.2.1",
+			want:      false,
+		},
+		{
+			name:      "introduced only",
+			ecosystem: "npm",
+			r:         Range{Type: RangeTypeEcosystem, Events: []Event{{Introduced: "1.0.0"}}},
+			version:   "9.0.0",
+			want:      true,
+		},
+		{
+			name:      "reintroduced",
+			ecosystem: "npm",
+			r: Range{Type: RangeTypeEcosystem, Events: []Event{
+				{Introduced: "3.0.0"}, {Fixed: "3.1.0"}, {Introduced: "1.0.0"}, {Fixed: "1.1.0"},
+			}},
+			version: "3.0.5",
+			want:    true,
+		},
+		{
+			name:      "between fixed and reintroduced",
+			ecosystem: "npm",
+			r: Range{Type: RangeTypeEcosystem, Events: []Event{
+				{Introduced: "3.0.0"}, {Fixed: "3.1.0"}, {Introduced: "1.0.0"}, {Fixed: "1.1.0"},
+			}},
+			version: "2.0.0",
+			want:    false,
+		},
+		{
+			name:      "below limit",
+			ecosystem: "npm",
+			r:         Range{Type: RangeTypeEcosystem, Events: []Event{{Introduced: "0"}, {Limit: "2.0.0"}}},
+			version:   "1.9.0",
+			want:      true,
+		},
+		{
+			name:      "at limit",
+			ecosystem: "npm",
+			r:         Range{Type: RangeTypeEcosystem, Events: []Event{{Introduced: "0"}, {Limit: "2.0.0"}}},
+			version:   "2.0.0",
+			want:      false,
+		},
+		// SEMVER ranges ignore the ecosystem
+		{
+			name:      "semver",
+			ecosystem: "Go",
+			r:         Range{Type: RangeTypeSemver, Events: []Event{{Introduced: "0"}, {Fixed: "1.0.0"}}},
+			version:   "1.0.0-rc.1",
+			want:      true,
+		},
+		{
+			name:      "semver in unsupported ecosystem",
+			ecosystem: "Unknown",
+			r:         Range{Type: RangeTypeSemver, Events: []Event{{Introduced: "1.0.0"}}},
+			version:   "1.0.0",
+			want:      true,
+		},
+		// Errors
+		{
+			name:      "unsupported ecosystem",
+			ecosystem: "Unknown",
+			r:         introducedFixed("0", "1.0.0"),
+			version:   "1.0.0",
+			wantErr:   true,
+		},
+		{
+			name:      "unsupported range type",
+			ecosystem: "npm",
+			r:         Range{Type: "OTHER", Events: []Event{{Introduced: "0"}}},
+			version:   "1.0.0",
+			wantErr:   true,
+		},
+		{
+			name:      "no events",
+			ecosystem: "npm",
+			r:         Range{Type: RangeTypeEcosystem},
+			version:   "1.0.0",
+			wantErr:   true,
+		},
+		{
+			name:      "event with two fields",
+			ecosystem: "npm",
+			r:         Range{Type: RangeTypeEcosystem, Events: []Event{{Introduced: "0", Fixed: "1.0.0"}}},
+			version:   "1.0.0",
+			wantErr:   true,
+		},
+		{
+			name:      "invalid event version",
+			ecosystem: "npm",
+			r:         introducedFixed("0", "not-a-version"),
+			version:   "1.0.0",
+			wantErr:   true,
+		},
+		{
+			name:      "invalid version",
+			ecosystem: "npm",
+			r:         introducedFixed("0", "1.0.0"),
+			version:   "not-a-version",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Contains(tt.ecosystem, tt.r, tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Contains() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Contains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContains_Git(t *testing.T) {
+	data := `{
+		"type": "GIT",
+		"repo": "https://github.com/example/project",
+		"events": [
+			{"introduced": "0"},
+			{"fixed": "6879efc2c1596d11a6a6ad296f80063b558d5e0f"},
+			{"last_affected": "3ac6d5e9a8e1d3cf6d0ba1ffe4c5d3c4dfd9e2a1"}
+		]
+	}`
+	var r Range
+	if err := json.Unmarshal([]byte(data), &r); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	_, err := Contains("npm", r, "1.0.0")
+	var notEvaluable *NotEvaluableError
+	if !errors.As(err, &notEvaluable) {
+		t.Fatalf("Contains() error = %v, want *NotEvaluableError", err)
+	}
+
+	want := &NotEvaluableError{
+		Type:         RangeTypeGit,
+		Repo:         "https://github.com/example/project",
+		Introduced:   []string{"0"},
+		Fixed:        []string{"6879efc2c1596d11a6a6ad296f80063b558d5e0f"},
+		LastAffected: []string{"3ac6d5e9a8e1d3cf6d0ba1ffe4c5d3c4dfd9e2a1"},
+	}
+	if notEvaluable.Type != want.Type || notEvaluable.Repo != want.Repo ||
+		!slices.Equal(notEvaluable.Introduced, want.Introduced) ||
+		!slices.Equal(notEvaluable.Fixed, want.Fixed) ||
+		!slices.Equal(notEvaluable.LastAffected, want.LastAffected) ||
+		!slices.Equal(notEvaluable.Limit, want.Limit) {
+		t.Errorf("Contains() error = %+v, want %+v", notEvaluable, want)
+	}
+}
+
+func TestEcosystems(t *testing.T) {
+	got := Ecosystems()
+	if !slices.IsSorted(got) {
+		t.Errorf("Ecosystems() = %v, want sorted", got)
+	}
+	for _, name := range []string{"npm", "PyPI", "Go", "Maven", "crates.io", "Debian"} {
+		if !slices.Contains(got, name) {
+			t.Errorf("Ecosystems() = %v, missing %q", got, name)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+// Package purl parses package URLs (purl), the identifiers SBOMs and
+// vulnerability databases use for a package and its version:
+//
+//	pkg:npm/%40angular/core@16.2.0
+//	pkg:maven/org.apache.commons/commons-lang3@3.12.0?type=jar
+//
+// A purl is the type, an optional namespace, the name, and an optional
+// version, qualifiers and subpath:
+//
+//	pkg:type/namespace/name@version?qualifiers#subpath
+//
+// Components are percent-decoded. Ecosystem maps the type to the univers
+// ecosystem that compares the package's versions.
+package purl
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+// PackageURL is a parsed package URL.
+type PackageURL struct {
+	// Type is the package type, such as "npm" or "maven", lowercased.
+	Type string
+	// Namespace is the optional scope, group or owner of the package, such
+	// as "@angular" or "org.apache.commons".
+	Namespace string
+	// Name is the package name.
+	Name string
+	// Version is the package version, or "" if the purl has none.
+	Version string
+	// Qualifiers holds extra data such as "arch" or "distro", keyed by
+	// lowercase name. It is nil when the purl has none.
+	Qualifiers map[string]string
+	// Subpath is a path within the package, such as a Go subpackage.
+	Subpath string
+}
+
+// typeToEcosystem maps purl types to the ecosystem of their versions
+var typeToEcosystem = map[string]univers.EcosystemName{
+	"alpm":     univers.ALPM,
+	"apk":      univers.Alpine,
+	"bazel":    univers.Bazel,
+	"cargo":    univers.Cargo,
+	"composer": univers.Composer,
+	"conan":    univers.Conan,
+	"cpan":     univers.CPAN,
+	"cran":     univers.CRAN,
+	"deb":      univers.Debian,
+	"gem":      univers.Gem,
+	"github":   univers.GitHub,
+	"golang":   univers.Golang,
+	"hex":      univers.Hex,
+	"luarocks": univers.LuaRocks,
+	"maven":    univers.Maven,
+	"npm":      univers.NPM,
+	"nuget":    univers.NuGet,
+	"pypi":     univers.PyPI,
+	"rpm":      univers.RPM,
+}
+
+// Parse parses a package URL such as "pkg:npm/lodash@4.17.21".
+func Parse(s string) (PackageURL, error) {
+	var p PackageURL
+
+	scheme, rest, ok := strings.Cut(s, ":")
+	if !ok || !strings.EqualFold(scheme, "pkg") {
+		return p, fmt.Errorf("invalid purl %q: missing pkg: scheme", s)
+	}
+	rest = strings.TrimLeft(rest, "/")
+
+	rest, subpath, _ := strings.Cut(rest, "#")
+	rest, qualifiers, _ := strings.Cut(rest, "?")
+
+	typ, path, ok := strings.Cut(rest, "/")
+	if !ok || !validType(typ) {
+		return p, fmt.Errorf("invalid purl %q: invalid type %q", s, typ)
+	}
+	p.Type = strings.ToLower(typ)
+
+	// The version follows the last "@" of the final path segment, so a raw
+	// npm scope such as "@angular/core" is not mistaken for one.
+	path = strings.TrimRight(path, "/")
+	if at := strings.LastIndexByte(path, '@'); at > strings.LastIndexByte(path, '/') {
+		version, err := url.PathUnescape(path[at+1:])
+		if err != nil {
+			return p, fmt.Errorf("invalid purl %q: version: %w", s, err)
+		}
+		p.Version = version
+		path = path[:at]
+	}
+
+	var segments []string
+	for _, seg := range strings.Split(path, "/") {
+		if seg == "" {
+			continue
+		}
+		decoded, err := url.PathUnescape(seg)
+		if err != nil {
+			return p, fmt.Errorf("invalid purl %q: %w", s, err)
+		}
+		segments = append(segments, decoded)
+	}
+	if len(segments) == 0 {
+		return p, fmt.Errorf("invalid purl %q: missing name", s)
+	}
+	p.Name = segments[len(segments)-1]
+	p.Namespace = strings.Join(segments[:len(segments)-1], "/")
+
+	if qualifiers != "" {
+		for pair := range strings.SplitSeq(qualifiers, "&") {
+			key, value, _ := strings.Cut(pair, "=")
+			if key == "" || value == "" {
+				continue
+			}
+			decoded, err := url.PathUnescape(value)
+			if err != nil {
+				return p, fmt.Errorf("invalid purl %q: qualifier %q: %w", s, key, err)
+			}
+			if p.Qualifiers == nil {
+				p.Qualifiers = map[string]string{}
+			}
+			p.Qualifiers[strings.ToLower(key)] = decoded
+		}
+	}
+
+	var subpaths []string
+	for _, seg := range strings.Split(subpath, "/") {
+		if seg == "" || seg == "." || seg == ".." {
+			continue
+		}
+		decoded, err := url.PathUnescape(seg)
+		if err != nil {
+			return p, fmt.Errorf("invalid purl %q: subpath: %w", s, err)
+		}
+		subpaths = append(subpaths, decoded)
+	}
+	p.Subpath = strings.Join(subpaths, "/")
+
+	return p, nil
+}
+
+// Ecosystem returns the core ecosystem whose versioning rules apply to the
+// package, and false for types univers has no ecosystem for, such as
+// "generic".
+func (p PackageURL) Ecosystem() (univers.EcosystemName, bool) {
+	name, ok := typeToEcosystem[p.Type]
+	return name, ok
+}
+
+// validType reports whether typ is a purl type: ASCII letters, digits, ".",
+// "+" and "-", not starting with a digit
+func validType(typ string) bool {
+	if typ == "" || (typ[0] >= '0' && typ[0] <= '9') {
+		return false
+	}
+	for _, c := range typ {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '.', c == '+', c == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,110 @@
+package purl
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    PackageURL
+		wantErr bool
+	}{
+		{
+			name:  "name and version",
+			input: "pkg:npm/lodash@4.17.21",
+			want:  PackageURL{Type: "npm", Name: "lodash", Version: "4.17.21"},
+		},
+		{
+			name:  "encoded scope",
+			input: "pkg:npm/%40angular/core@16.2.0",
+			want:  PackageURL{Type: "npm", Namespace: "@angular", Name: "core", Version: "16.2.0"},
+		},
+		{
+			name:  "raw scope",
+			input: "pkg:npm/@angular/core@16.2.0",
+			want:  PackageURL{Type: "npm", Namespace: "@angular", Name: "core", Version: "16.2.0"},
+		},
+		{
+			name:  "raw scope without version",
+			input: "pkg:npm/@angular/core",
+			want:  PackageURL{Type: "npm", Namespace: "@angular", Name: "core"},
+		},
+		{
+			name:  "qualifiers and subpath",
+			input: "pkg:maven/org.apache.commons/commons-lang3@3.12.0?Type=jar&classifier=&repository_url=repo.example.com%2Fmaven#/src/./main/",
+			want: PackageURL{
+				Type:       "maven",
+				Namespace:  "org.apache.commons",
+				Name:       "commons-lang3",
+				Version:    "3.12.0",
+				Qualifiers: map[string]string{"type": "jar", "repository_url": "repo.example.com/maven"},
+				Subpath:    "src/main",
+			},
+		},
+		{
+			name:  "encoded version",
+			input: "pkg:deb/debian/curl@7.88.1-10%2Bdeb12u5?arch=amd64",
+			want:  PackageURL{Type: "deb", Namespace: "debian", Name: "curl", Version: "7.88.1-10+deb12u5", Qualifiers: map[string]string{"arch": "amd64"}},
+		},
+		{
+			name:  "uppercase scheme and type, slashes after scheme",
+			input: "PKG://PyPI/Django@4.2",
+			want:  PackageURL{Type: "pypi", Name: "Django", Version: "4.2"},
+		},
+		{
+			name:  "nested namespace",
+			input: "pkg:golang/github.com/gorilla/mux@v1.8.0",
+			want:  PackageURL{Type: "golang", Namespace: "github.com/gorilla", Name: "mux", Version: "v1.8.0"},
+		},
+		{name: "missing scheme", input: "npm/lodash@4.17.21", wantErr: true},
+		{name: "wrong scheme", input: "http://example.com/lodash", wantErr: true},
+		{name: "missing name", input: "pkg:npm/", wantErr: true},
+		{name: "missing type", input: "pkg:/lodash", wantErr: true},
+		{name: "type starts with digit", input: "pkg:1npm/lodash", wantErr: true},
+		{name: "invalid escape", input: "pkg:npm/lodash@4.17%2", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPackageURL_Ecosystem(t *testing.T) {
+	tests := []struct {
+		typ    string
+		want   univers.EcosystemName
+		wantOK bool
+	}{
+		{"npm", univers.NPM, true},
+		{"apk", univers.Alpine, true},
+		{"deb", univers.Debian, true},
+		{"golang", univers.Golang, true},
+		{"generic", "", false},
+		{"swift", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typ, func(t *testing.T) {
+			got, ok := PackageURL{Type: tt.typ}.Ecosystem()
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Ecosystem() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -3,16 +3,8 @@ package vers
 import (
 	"fmt"
 	"strings"
-
-	"github.com/alowayed/go-univers/pkg/ecosystem/alpine"
 )
 
-// alpineContains implements VERS constraint checking for Alpine ecosystem
-func alpineContains(constraints []string, version string) (bool, error) {
-	e := &alpine.Ecosystem{}
-	return contains(e, constraints, version)
-}
-
 // intervalToAlpineRanges converts an interval to Alpine range syntax
 func intervalToAlpineRanges(interval interval) []string {
 	// Handle exact matches
@@ -3,17 +3,11 @@ package vers
 import (
 	"fmt"
 	"strings"
-
-	"github.com/alowayed/go-univers/pkg/ecosystem/cargo"
 )
 
-// cargoContains implements VERS constraint checking for Cargo (Rust) ecosystem
-func cargoContains(constraints []string, version string) (bool, error) {
-	e := &cargo.Ecosystem{}
-	return contains(e, constraints, version)
-}
-
-// intervalToCargoRanges converts an interval to Cargo range syntax
+// intervalToCargoRanges converts an interval to Cargo range syntax. Each
+// interval is checked as its own Cargo range, so as in Cargo a pre-release
+// only matches an interval with a bound on a pre-release of the same release.
 func intervalToCargoRanges(interval interval) []string {
 	// Handle exact matches
 	if interval.exact != "" {
@@ -153,6 +153,34 @@ func TestContains_Cargo(t *testing.T) {
 			want:      false,
 			wantErr:   false,
 		},
+		{
+			name:      "cargo prerelease of later release excluded",
+			versRange: "vers:cargo/>=1.0.0|<2.0.0",
+			version:   "1.5.0-alpha",
+			want:      false,
+			wantErr:   false,
+		},
+		{
+			name:      "cargo prerelease opted in by same release",
+			versRange: "vers:cargo/>=1.5.0-alpha|<2.0.0",
+			version:   "1.5.0-beta",
+			want:      true,
+			wantErr:   false,
+		},
+		{
+			name:      "cargo prerelease of other release not opted in",
+			versRange: "vers:cargo/>=1.5.0-alpha|<2.0.0",
+			version:   "1.6.0-alpha",
+			want:      false,
+			wantErr:   false,
+		},
+		{
+			name:      "cargo prerelease opted in by upper bound",
+			versRange: "vers:cargo/>=1.0.0|<=2.0.0-rc.2",
+			version:   "2.0.0-rc.1",
+			want:      true,
+			wantErr:   false,
+		},
 		{
 			name:      "cargo with build metadata - equal",
 			versRange: "vers:cargo/>=1.0.0+build123",
@@ -0,0 +1,143 @@
+package vers
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/semver"
+)
+
+// WarningKind classifies the warnings of CheckCompatibility.
+type WarningKind string
+
+const (
+	// WarningInvalidVersion means the version does not parse in the range's
+	// versioning scheme, so Contains would fail on it.
+	WarningInvalidVersion WarningKind = "invalid-version"
+	// WarningForeignSyntax means a version parses in the range's versioning
+	// scheme but is written in another scheme's syntax, so it is probably
+	// read differently from what its author meant.
+	WarningForeignSyntax WarningKind = "foreign-syntax"
+)
+
+// Warning is a suspicious input found by CheckCompatibility.
+type Warning struct {
+	// Kind classifies the warning.
+	Kind WarningKind
+	// Message describes the suspicious input, quoting it.
+	Message string
+}
+
+// String returns the warning's message.
+func (w Warning) String() string {
+	return w.Message
+}
+
+// reinterpretsSemVerPrerelease lists the schemes in which a SemVer
+// pre-release such as "1.0.0-alpha.1" parses with another meaning: a Debian
+// or RPM release, a LuaRocks revision, or a PEP 440 or RubyGems pre-release
+// after normalization
+var reinterpretsSemVerPrerelease = map[string]bool{
+	"alpine":   true,
+	"deb":      true,
+	"gem":      true,
+	"luarocks": true,
+	"pypi":     true,
+	"rpm":      true,
+}
+
+// prereleaseWords are the leading words of SemVer pre-release identifiers
+// that mark a version as a pre-release rather than, say, a package revision
+var prereleaseWords = []string{"alpha", "beta", "rc", "pre", "preview", "dev", "canary", "next", "snapshot", "nightly"}
+
+// CheckCompatibility looks for signs that version and versRange do not use
+// the same versioning scheme, as when an npm version such as
+// "1.0.0-alpha.1" is checked against a "vers:pypi" range. It reports a
+// version that does not parse in the range's scheme, naming the schemes it
+// does parse in, and a version or constraint version written as a SemVer
+// pre-release in a scheme that reads it otherwise.
+//
+// The warnings are advisory: Contains does not consult them. An error is
+// returned only if versRange is invalid, with the same options as Validate.
+func CheckCompatibility(versRange, version string, opts ...Option) ([]Warning, error) {
+	if err := Validate(versRange, opts...); err != nil {
+		return nil, err
+	}
+	s, constraints, err := split(versRange, newOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	scheme, err := lookupScheme(s)
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []Warning
+	if !scheme.valid(version) {
+		msg := fmt.Sprintf("version %q is not a valid %s version", version, s)
+		if others := validSchemes(version); len(others) > 0 {
+			msg += "; it is valid in: " + strings.Join(others, ", ")
+		}
+		warnings = append(warnings, Warning{Kind: WarningInvalidVersion, Message: msg})
+	} else if reinterpretsSemVerPrerelease[s] && isSemVerPrerelease(version) {
+		warnings = append(warnings, Warning{
+			Kind:    WarningForeignSyntax,
+			Message: fmt.Sprintf("version %q is written as a SemVer pre-release, which %s reads differently; it may belong to another versioning scheme", version, s),
+		})
+	}
+
+	if !reinterpretsSemVerPrerelease[s] || isStar(constraints) {
+		return warnings, nil
+	}
+	for i, c := range constraints {
+		c = strings.TrimSpace(c)
+		if c == "" {
+			continue
+		}
+		pc, err := parseConstraint(c)
+		if err != nil || !isSemVerPrerelease(pc.version) {
+			continue
+		}
+		warnings = append(warnings, Warning{
+			Kind:    WarningForeignSyntax,
+			Message: fmt.Sprintf("constraint %d %q is written with a SemVer pre-release, which %s reads differently", i, c, s),
+		})
+	}
+	return warnings, nil
+}
+
+// validSchemes returns the sorted names of the schemes accepting version
+func validSchemes(version string) []string {
+	var names []string
+	for _, s := range schemes {
+		if s.valid(version) {
+			names = append(names, s.name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// isSemVerPrerelease reports whether version is a SemVer version, with or
+// without a "v" prefix, whose pre-release starts with a word such as "alpha"
+// or "rc"
+func isSemVerPrerelease(version string) bool {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if !semver.ValidVersion(version) {
+		return false
+	}
+	version, _, _ = strings.Cut(version, "+")
+	_, prerelease, ok := strings.Cut(version, "-")
+	if !ok {
+		return false
+	}
+	prerelease = strings.ToLower(prerelease)
+	for _, word := range prereleaseWords {
+		if strings.HasPrefix(prerelease, word) {
+			return true
+		}
+	}
+	return false
+}
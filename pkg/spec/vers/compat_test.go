@@ -0,0 +1,107 @@
+package vers
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCheckCompatibility(t *testing.T) {
+	tests := []struct {
+		name      string
+		versRange string
+		version   string
+		opts      []Option
+		want      []Warning
+		wantErr   bool
+	}{
+		{
+			name:      "matching scheme",
+			versRange: "vers:pypi/>=1.0|<2.0",
+			version:   "1.0a1",
+		},
+		{
+			name:      "npm version against pypi range",
+			versRange: "vers:pypi/>=1.0|<2.0",
+			version:   "1.0.0-alpha.beta",
+			want: []Warning{{
+				Kind:    WarningInvalidVersion,
+				Message: `version "1.0.0-alpha.beta" is not a valid pypi version; it is valid in: alpine, cargo, deb, gem, generic, golang, luarocks, maven, npm, nuget, rpm`,
+			}},
+		},
+		{
+			name:      "pypi version against npm range",
+			versRange: "vers:npm/>=1.0.0",
+			version:   "1.0a1",
+			want: []Warning{{
+				Kind:    WarningInvalidVersion,
+				Message: `version "1.0a1" is not a valid npm version; it is valid in: alpine, composer, deb, luarocks, maven, pypi, rpm`,
+			}},
+		},
+		{
+			name:      "version valid nowhere",
+			versRange: "vers:npm/>=1.0.0",
+			version:   "",
+			want: []Warning{{
+				Kind:    WarningInvalidVersion,
+				Message: `version "" is not a valid npm version`,
+			}},
+		},
+		{
+			name:      "semver pre-release in rpm",
+			versRange: "vers:rpm/>=1.0",
+			version:   "1.0.0-rc1",
+			want: []Warning{{
+				Kind:    WarningForeignSyntax,
+				Message: `version "1.0.0-rc1" is written as a SemVer pre-release, which rpm reads differently; it may belong to another versioning scheme`,
+			}},
+		},
+		{
+			name:      "debian revision is not a pre-release",
+			versRange: "vers:deb/>=1.0.0-1",
+			version:   "1.0.0-2",
+		},
+		{
+			name:      "semver pre-release constraint in deb",
+			versRange: "vers:deb/>=1.0.0-rc.1|<2.0.0",
+			version:   "1.5.0-1",
+			want: []Warning{{
+				Kind:    WarningForeignSyntax,
+				Message: `constraint 0 ">=1.0.0-rc.1" is written with a SemVer pre-release, which deb reads differently`,
+			}},
+		},
+		{
+			name:      "semver pre-release in npm",
+			versRange: "vers:npm/>=1.0.0-rc.1",
+			version:   "1.0.0-rc.2",
+		},
+		{
+			name:      "star",
+			versRange: "vers:gem/*",
+			version:   "1.0.0",
+		},
+		{
+			name:      "decoded range",
+			versRange: "vers%3Anpm%2F%3E%3D1.0.0",
+			version:   "1.0.0",
+			opts:      []Option{WithDecoding()},
+		},
+		{
+			name:      "invalid range",
+			versRange: "vers:npm/>=1..0",
+			version:   "1.0.0",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CheckCompatibility(tt.versRange, tt.version, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckCompatibility() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("CheckCompatibility() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
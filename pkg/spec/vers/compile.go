@@ -0,0 +1,206 @@
+package vers
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+// matcher reports whether a version is in a compiled VERS range
+type matcher func(version string) (bool, error)
+
+// CompiledRange is a VERS range that has been validated, normalized and
+// converted to ecosystem ranges once, so that checking many versions against
+// it only parses the versions. It is safe for concurrent use.
+type CompiledRange struct {
+	versRange string
+	match     matcher
+}
+
+// Compile parses a VERS range for repeated Contains checks. It accepts the
+// same options as Contains and reports the errors Contains would report for
+// the range itself; only version errors are left to CompiledRange.Contains.
+// Example: Compile("vers:npm/>=1.2.0|<2.0.0") returns a range whose
+// Contains("1.5.0") returns true.
+func Compile(versRange string, opts ...Option) (*CompiledRange, error) {
+	o := newOptions(opts)
+	s, constraints, err := split(versRange, o)
+	if err != nil {
+		return nil, err
+	}
+
+	match, err := compileConstraints(s, constraints, o)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CompiledRange{versRange: versRange, match: match}, nil
+}
+
+// Contains checks if a version satisfies the range, with the same result as
+// vers.Contains on the original string.
+func (c *CompiledRange) Contains(version string) (bool, error) {
+	return c.match(version)
+}
+
+// String returns the VERS range as passed to Compile.
+func (c *CompiledRange) String() string {
+	return c.versRange
+}
+
+// compileConstraints builds the matcher for the constraints of a scheme
+func compileConstraints(scheme string, constraints []string, o options) (matcher, error) {
+	if isStar(constraints) {
+		return func(string) (bool, error) { return true, nil }, nil
+	}
+
+	s, err := lookupScheme(scheme)
+	if err != nil {
+		return nil, err
+	}
+	return s.compile(constraints, o)
+}
+
+// compiler returns a function compiling VERS constraints for an ecosystem
+func compiler[V univers.Version[V], VR univers.VersionRange[V]](
+	e univers.Ecosystem[V, VR],
+) func([]string, options) (matcher, error) {
+	return func(constraints []string, o options) (matcher, error) {
+		return compile(e, constraints, o)
+	}
+}
+
+// compile implements VERS constraint checking for a given ecosystem. All
+// parsing happens up front; the returned matcher only reads the parsed
+// ranges and exclusions, so it can be shared between goroutines.
+func compile[V univers.Version[V], VR univers.VersionRange[V]](
+	e univers.Ecosystem[V, VR],
+	constraints []string,
+	o options,
+) (matcher, error) {
+	if o.compliance {
+		if err := validateConstraints(e, constraints); err != nil {
+			return nil, fmt.Errorf("invalid constraints: %w", err)
+		}
+	}
+
+	constraints, err := normalizeConstraints(e, constraints)
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalize constraints: %w", err)
+	}
+
+	// Advisories often enumerate affected versions as hundreds of "="
+	// constraints; match those by set membership rather than one range each
+	if allExact(constraints) {
+		return exactMatcher(e, constraints)
+	}
+
+	// Parse VERS constraints and convert to ecosystem ranges
+	ranges, err := toRanges(e, constraints)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert VERS constraints: %w", err)
+	}
+
+	// Parse constraints to check for excludes
+	versConstraints, err := parseConstraints(constraints)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse constraints for exclusion check: %w", err)
+	}
+
+	var excludes []V
+	for _, constraint := range versConstraints {
+		if constraint.operator == "!=" {
+			excludedV, err := e.NewVersion(constraint.version)
+			if err != nil {
+				return nil, fmt.Errorf("invalid version in exclusion constraint '%s': %w", constraint.version, err)
+			}
+			excludes = append(excludes, excludedV)
+		}
+	}
+
+	return func(version string) (bool, error) {
+		v, err := e.NewVersion(version)
+		if err != nil {
+			return false, fmt.Errorf("invalid %s version '%s': %w", e.Name(), version, err)
+		}
+
+		// Check if version is excluded by any != constraints
+		for _, excluded := range excludes {
+			if v.Compare(excluded) == 0 {
+				return false, nil
+			}
+		}
+
+		// VERS interval logic: version satisfies range if it's in ANY interval
+		// If there are no range intervals (only excludes), then version is allowed if not excluded
+		if len(ranges) == 0 {
+			return true, nil
+		}
+
+		for _, r := range ranges {
+			if r.Contains(v) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}, nil
+}
+
+// allExact reports whether normalized constraints are all "=" constraints
+func allExact(constraints []string) bool {
+	if len(constraints) == 0 {
+		return false
+	}
+	for _, c := range constraints {
+		if !strings.HasPrefix(c, "=") {
+			return false
+		}
+	}
+	return true
+}
+
+// exactMatcher builds the matcher for normalized constraints that are all "="
+// constraints. A version matches if it compares equal to one of the pinned
+// versions, as it would against an "=" range. Versions implementing
+// univers.SortKeyer are looked up by key in a set, since equal keys mean equal
+// versions; other versions are found by binary search over the sorted pins.
+func exactMatcher[V univers.Version[V], VR univers.VersionRange[V]](
+	e univers.Ecosystem[V, VR],
+	constraints []string,
+) (matcher, error) {
+	pins := make([]V, 0, len(constraints))
+	for _, c := range constraints {
+		v, err := e.NewVersion(c[len("="):])
+		if err != nil {
+			return nil, fmt.Errorf("invalid version in constraint '%s': %w", c, err)
+		}
+		pins = append(pins, v)
+	}
+
+	var keys map[string]struct{}
+	if _, ok := any(pins[0]).(univers.SortKeyer); ok {
+		keys = make(map[string]struct{}, len(pins))
+		for _, pin := range pins {
+			keys[string(any(pin).(univers.SortKeyer).SortKey())] = struct{}{}
+		}
+	}
+
+	return func(version string) (bool, error) {
+		v, err := e.NewVersion(version)
+		if err != nil {
+			return false, fmt.Errorf("invalid %s version '%s': %w", e.Name(), version, err)
+		}
+
+		if keys != nil {
+			_, ok := keys[string(any(v).(univers.SortKeyer).SortKey())]
+			return ok, nil
+		}
+
+		_, ok := slices.BinarySearchFunc(pins, v, func(pin, target V) int {
+			return pin.Compare(target)
+		})
+		return ok, nil
+	}, nil
+}
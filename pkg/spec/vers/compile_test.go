@@ -0,0 +1,227 @@
+package vers
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		name      string
+		versRange string
+		opts      []Option
+		wantErr   bool
+	}{
+		{name: "npm", versRange: "vers:npm/>=1.2.0|<2.0.0"},
+		{name: "star", versRange: "vers:npm/*"},
+		{name: "decoded", versRange: "vers%3Anpm%2F%3E%3D1.2.0", opts: []Option{WithDecoding()}},
+		{name: "invalid format", versRange: "not-vers-format", wantErr: true},
+		{name: "unsupported scheme", versRange: "vers:unsupported/>=1.0.0", wantErr: true},
+		{name: "invalid constraint version", versRange: "vers:npm/>=not.a.version", wantErr: true},
+		{name: "non-compliant", versRange: "vers:npm/>=1.0.0|>=2.0.0", opts: []Option{WithCompliance()}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Compile(tt.versRange, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Compile(%q) error = %v, wantErr %v", tt.versRange, err, tt.wantErr)
+			}
+			if !tt.wantErr && got.String() != tt.versRange {
+				t.Errorf("Compile(%q).String() = %q", tt.versRange, got.String())
+			}
+		})
+	}
+}
+
+func TestCompiledRange_Contains(t *testing.T) {
+	tests := []struct {
+		versRange string
+		versions  []string
+	}{
+		{
+			versRange: "vers:npm/>=1.2.0|<2.0.0|!=1.5.0",
+			versions:  []string{"1.0.0", "1.2.0", "1.5.0", "1.9.9", "2.0.0", "1.5.0-rc.1", "invalid"},
+		},
+		{
+			versRange: "vers:pypi/>=1.0|<2.0",
+			versions:  []string{"0.9", "1.0", "1.5b1", "1.5", "2.0", "invalid!"},
+		},
+		{
+			versRange: "vers:pypi/>=1.0b1|<2.0",
+			versions:  []string{"1.0a1", "1.0b1", "1.5rc1", "2.0"},
+		},
+		{
+			versRange: "vers:deb/>=1:1.0-1|<1:2.0",
+			versions:  []string{"1.0-1", "1:1.0-1", "1:1.5~rc1", "1:2.0"},
+		},
+		{
+			versRange: "vers:maven/1.0|2.0",
+			versions:  []string{"1.0", "1.0.0", "1.5", "2.0"},
+		},
+		{
+			versRange: "vers:golang/>=v1.2.0|<v1.3.0",
+			versions:  []string{"v1.1.9", "v1.2.0", "v1.2.5", "v1.3.0"},
+		},
+		{
+			versRange: "vers:generic/*",
+			versions:  []string{"1.0.0", "anything"},
+		},
+		{
+			versRange: "vers:rpm/!=1.0-1",
+			versions:  []string{"1.0-1", "1.0-2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.versRange, func(t *testing.T) {
+			compiled, err := Compile(tt.versRange)
+			if err != nil {
+				t.Fatalf("Compile(%q) error = %v", tt.versRange, err)
+			}
+			for _, version := range tt.versions {
+				want, wantErr := Contains(tt.versRange, version)
+				got, err := compiled.Contains(version)
+				if got != want || (err != nil) != (wantErr != nil) {
+					t.Errorf("Contains(%q) = %v, %v, want %v, %v as from vers.Contains", version, got, err, want, wantErr)
+				}
+			}
+		})
+	}
+}
+
+func TestCompiledRange_Contains_Concurrent(t *testing.T) {
+	compiled, err := Compile("vers:npm/>=1.2.0|<2.0.0|!=1.5.0")
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+
+	versions := map[string]bool{"1.0.0": false, "1.2.0": true, "1.5.0": false, "1.9.9": true, "2.0.0": false}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				for version, want := range versions {
+					got, err := compiled.Contains(version)
+					if err != nil || got != want {
+						t.Errorf("Contains(%q) = %v, %v, want %v", version, got, err, want)
+						return
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkCompiledRange_Contains(b *testing.B) {
+	const versRange = "vers:npm/>=1.2.0|<2.0.0|>=3.0.0|<4.0.0|!=3.5.0"
+	compiled, err := Compile(versRange)
+	if err != nil {
+		b.Fatalf("Compile() error = %v", err)
+	}
+
+	b.Run("compiled", func(b *testing.B) {
+		for b.Loop() {
+			_, _ = compiled.Contains("3.2.1")
+		}
+	})
+	b.Run("uncompiled", func(b *testing.B) {
+		for b.Loop() {
+			_, _ = Contains(versRange, "3.2.1")
+		}
+	})
+}
+
+func TestCompiledRange_Contains_Exact(t *testing.T) {
+	tests := []struct {
+		versRange string
+		versions  []string
+		want      []bool
+	}{
+		{
+			versRange: "vers:npm/=1.0.0|=1.0.2|1.1.0-rc.1",
+			versions:  []string{"1.0.0", "1.0.0+build", "1.0.1", "1.0.2", "1.1.0-rc.1", "1.1.0"},
+			want:      []bool{true, true, false, true, true, false},
+		},
+		{
+			versRange: "vers:pypi/=1.0|=1.2",
+			versions:  []string{"1.0", "1.0.0", "1.0+local", "1.1", "1.2", "1.2rc1"},
+			want:      []bool{true, true, true, false, true, false},
+		},
+		{
+			versRange: "vers:maven/=1.0|=2.0-SNAPSHOT",
+			versions:  []string{"1.0", "1.0.0", "1.1", "2.0-SNAPSHOT", "2.0"},
+			want:      []bool{true, true, false, true, false},
+		},
+		{
+			versRange: "vers:deb/=1.0-1|=1:2.0-1",
+			versions:  []string{"1.0-1", "0:1.0-1", "1.00-1", "2.0-1", "1:2.0-1"},
+			want:      []bool{true, true, true, false, true},
+		},
+		{
+			versRange: "vers:rpm/=1.0-1|=1.1-1",
+			versions:  []string{"1.0-1", "1.0-2", "1.1-1"},
+			want:      []bool{true, false, true},
+		},
+		{
+			versRange: "vers:golang/=v1.2.0|=v1.2.1",
+			versions:  []string{"v1.2.0", "v1.2.1", "v1.2.2"},
+			want:      []bool{true, true, false},
+		},
+		{
+			versRange: "vers:nuget/=1.0.0|=1.0.1",
+			versions:  []string{"1.0.0", "1.0.0+b", "1.0.1", "1.0.2"},
+			want:      []bool{true, true, true, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.versRange, func(t *testing.T) {
+			compiled, err := Compile(tt.versRange)
+			if err != nil {
+				t.Fatalf("Compile(%q) error = %v", tt.versRange, err)
+			}
+			for i, version := range tt.versions {
+				got, err := compiled.Contains(version)
+				if err != nil {
+					t.Fatalf("Contains(%q) error = %v", version, err)
+				}
+				if got != tt.want[i] {
+					t.Errorf("Contains(%q) = %v, want %v", version, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkCompile_Exact(b *testing.B) {
+	pins := make([]string, 0, 1000)
+	for i := range 1000 {
+		pins = append(pins, fmt.Sprintf("=1.%d.%d", i/100, i%100))
+	}
+	versRange := "vers:npm/" + strings.Join(pins, "|")
+
+	b.Run("compile", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_, _ = Compile(versRange)
+		}
+	})
+
+	compiled, err := Compile(versRange)
+	if err != nil {
+		b.Fatalf("Compile() error = %v", err)
+	}
+	b.Run("contains", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_, _ = compiled.Contains("1.9.99")
+		}
+	})
+}
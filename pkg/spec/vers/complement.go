@@ -0,0 +1,282 @@
+package vers
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+// Complement returns a VERS range matching exactly the versions versRange
+// does not, for turning "fixed in" ranges into "affected" ranges and back.
+// The result is in canonical form: constraints sorted by version, with range
+// bounds alternating between lower and upper.
+// Example: Complement("vers:npm/>=1.0.0|<2.0.0") returns "vers:npm/<1.0.0|>=2.0.0".
+//
+// VERS cannot express an empty range, so the complement of a range matching
+// every version, such as "vers:npm/*", is an error.
+func Complement(versRange string, opts ...Option) (string, error) {
+	o := newOptions(opts)
+	s, constraints, err := split(versRange, o)
+	if err != nil {
+		return "", err
+	}
+
+	if isStar(constraints) {
+		return "", fmt.Errorf("complement of %q is empty", versRange)
+	}
+
+	scheme, err := lookupScheme(s)
+	if err != nil {
+		return "", err
+	}
+
+	complemented, err := scheme.complement(constraints, o)
+	if err != nil {
+		return "", err
+	}
+	if len(complemented) == 0 {
+		return "", fmt.Errorf("complement of %q is empty", versRange)
+	}
+
+	return "vers:" + s + "/" + strings.Join(complemented, "|"), nil
+}
+
+// complementer returns a function complementing VERS constraints for an ecosystem
+func complementer[V univers.Version[V], VR univers.VersionRange[V]](
+	e univers.Ecosystem[V, VR],
+) func([]string, options) ([]string, error) {
+	return func(constraints []string, o options) ([]string, error) {
+		if o.compliance {
+			if err := validateConstraints(e, constraints); err != nil {
+				return nil, fmt.Errorf("invalid constraints: %w", err)
+			}
+		}
+		constraints, err := normalizeConstraints(e, constraints)
+		if err != nil {
+			return nil, fmt.Errorf("failed to normalize constraints: %w", err)
+		}
+		return complement(e, constraints)
+	}
+}
+
+// complement computes the constraints of the versions not matched by the
+// normalized constraints. The matched set is the union of the intervals and
+// exact versions, less any "!=" exclusions; with no intervals or exact
+// versions it is every version less the exclusions.
+func complement[V univers.Version[V], VR univers.VersionRange[V]](
+	e univers.Ecosystem[V, VR],
+	constraints []string,
+) ([]string, error) {
+	versConstraints, err := parseConstraints(constraints)
+	if err != nil {
+		return nil, err
+	}
+	intervals, err := groupConstraintsIntoIntervals(versConstraints)
+	if err != nil {
+		return nil, err
+	}
+
+	// Versions were validated during normalization, so parsing cannot fail
+	parsed := make(map[string]V)
+	for _, c := range versConstraints {
+		v, err := e.NewVersion(c.version)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version in constraint '%s%s': %w", c.operator, c.version, err)
+		}
+		parsed[c.version] = v
+	}
+	compare := func(a, b string) int {
+		return parsed[a].Compare(parsed[b])
+	}
+
+	for i, in := range intervals {
+		if in.exact != "" {
+			intervals[i] = pointInterval(in.exact)
+		}
+	}
+
+	var excluded []string
+	for _, c := range versConstraints {
+		if c.operator == "!=" {
+			excluded = append(excluded, c.version)
+		}
+	}
+
+	var gaps []interval
+	if len(intervals) == 0 {
+		for _, x := range excluded {
+			gaps = append(gaps, pointInterval(x))
+		}
+	} else {
+		merged := mergeIntervals(intervals, compare)
+		gaps = intervalGaps(merged)
+		for _, x := range excluded {
+			if slices.ContainsFunc(merged, func(in interval) bool { return intervalContains(in, x, compare) }) {
+				gaps = append(gaps, pointInterval(x))
+			}
+		}
+	}
+
+	// Merging joins an excluded version to an adjacent gap, and gaps either
+	// side of a single version become one gap with a "!=" exclusion, so that
+	// no version appears in two constraints
+	var result []constraint
+	var joined []interval
+	for _, g := range mergeIntervals(gaps, compare) {
+		if n := len(joined); n > 0 {
+			prev := &joined[n-1]
+			if prev.upper != "" && g.lower != "" && !prev.upperInclusive && !g.lowerInclusive && compare(prev.upper, g.lower) == 0 {
+				result = append(result, constraint{operator: "!=", version: g.lower})
+				prev.upper, prev.upperInclusive = g.upper, g.upperInclusive
+				continue
+			}
+		}
+		joined = append(joined, g)
+	}
+
+	for _, g := range joined {
+		if g.lower != "" && g.upper != "" && compare(g.lower, g.upper) == 0 {
+			result = append(result, constraint{operator: "=", version: g.lower})
+			continue
+		}
+		if g.lower != "" {
+			result = append(result, constraint{operator: lowerOperator(g.lowerInclusive), version: g.lower})
+		}
+		if g.upper != "" {
+			result = append(result, constraint{operator: upperOperator(g.upperInclusive), version: g.upper})
+		}
+	}
+
+	slices.SortStableFunc(result, func(a, b constraint) int {
+		return compare(a.version, b.version)
+	})
+	constraints = make([]string, 0, len(result))
+	for _, c := range result {
+		if c.operator == "=" {
+			constraints = append(constraints, c.version)
+			continue
+		}
+		constraints = append(constraints, c.operator+c.version)
+	}
+	return constraints, nil
+}
+
+// pointInterval returns the interval holding only version
+func pointInterval(version string) interval {
+	return interval{lower: version, lowerInclusive: true, upper: version, upperInclusive: true}
+}
+
+// mergeIntervals sorts intervals by lower bound and merges those that overlap
+// or touch, returning disjoint intervals in ascending order. An empty lower
+// or upper bound is unbounded.
+func mergeIntervals(intervals []interval, compare func(a, b string) int) []interval {
+	sorted := slices.Clone(intervals)
+	slices.SortStableFunc(sorted, func(a, b interval) int {
+		switch {
+		case a.lower == "" && b.lower == "":
+			return 0
+		case a.lower == "":
+			return -1
+		case b.lower == "":
+			return 1
+		}
+		if c := compare(a.lower, b.lower); c != 0 {
+			return c
+		}
+		if a.lowerInclusive == b.lowerInclusive {
+			return 0
+		}
+		if a.lowerInclusive {
+			return -1
+		}
+		return 1
+	})
+
+	var merged []interval
+	for _, in := range sorted {
+		if len(merged) == 0 {
+			merged = append(merged, in)
+			continue
+		}
+		last := &merged[len(merged)-1]
+		if !touches(*last, in, compare) {
+			merged = append(merged, in)
+			continue
+		}
+		switch {
+		case last.upper == "":
+		case in.upper == "":
+			last.upper, last.upperInclusive = "", false
+		default:
+			c := compare(in.upper, last.upper)
+			if c > 0 || (c == 0 && in.upperInclusive) {
+				last.upper, last.upperInclusive = in.upper, in.upperInclusive
+			}
+		}
+	}
+	return merged
+}
+
+// touches reports whether next, whose lower bound is not below that of
+// prev, overlaps or adjoins prev so that no version lies between them
+func touches(prev, next interval, compare func(a, b string) int) bool {
+	if prev.upper == "" || next.lower == "" {
+		return true
+	}
+	c := compare(next.lower, prev.upper)
+	return c < 0 || (c == 0 && (next.lowerInclusive || prev.upperInclusive))
+}
+
+// intervalGaps returns the intervals between and around disjoint, ascending
+// intervals
+func intervalGaps(merged []interval) []interval {
+	var gaps []interval
+	for i, in := range merged {
+		if in.lower != "" {
+			gap := interval{upper: in.lower, upperInclusive: !in.lowerInclusive}
+			if i > 0 {
+				gap.lower, gap.lowerInclusive = merged[i-1].upper, !merged[i-1].upperInclusive
+			}
+			gaps = append(gaps, gap)
+		}
+	}
+	if last := merged[len(merged)-1]; last.upper != "" {
+		gaps = append(gaps, interval{lower: last.upper, lowerInclusive: !last.upperInclusive})
+	}
+	return gaps
+}
+
+// intervalContains reports whether version lies within the interval
+func intervalContains(in interval, version string, compare func(a, b string) int) bool {
+	if in.lower != "" {
+		c := compare(version, in.lower)
+		if c < 0 || (c == 0 && !in.lowerInclusive) {
+			return false
+		}
+	}
+	if in.upper != "" {
+		c := compare(version, in.upper)
+		if c > 0 || (c == 0 && !in.upperInclusive) {
+			return false
+		}
+	}
+	return true
+}
+
+// lowerOperator returns the VERS comparator for a lower bound
+func lowerOperator(inclusive bool) string {
+	if inclusive {
+		return ">="
+	}
+	return ">"
+}
+
+// upperOperator returns the VERS comparator for an upper bound
+func upperOperator(inclusive bool) string {
+	if inclusive {
+		return "<="
+	}
+	return "<"
+}
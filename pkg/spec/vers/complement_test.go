@@ -0,0 +1,156 @@
+package vers
+
+import "testing"
+
+func TestComplement(t *testing.T) {
+	tests := []struct {
+		name      string
+		versRange string
+		opts      []Option
+		want      string
+		versions  []string
+		wantErr   bool
+	}{
+		{
+			name:      "fixed in becomes affected",
+			versRange: "vers:npm/>=1.2.3",
+			want:      "vers:npm/<1.2.3",
+			versions:  []string{"1.0.0", "1.2.3", "2.0.0"},
+		},
+		{
+			name:      "bounded interval",
+			versRange: "vers:npm/>=1.0.0|<2.0.0",
+			want:      "vers:npm/<1.0.0|>=2.0.0",
+			versions:  []string{"0.9.0", "1.0.0", "1.5.0", "2.0.0"},
+		},
+		{
+			name:      "round trip",
+			versRange: "vers:npm/<1.0.0|>=2.0.0",
+			want:      "vers:npm/>=1.0.0|<2.0.0",
+			versions:  []string{"0.9.0", "1.0.0", "1.5.0", "2.0.0"},
+		},
+		{
+			name:      "several intervals",
+			versRange: "vers:pypi/>=1.0|<1.5|>=2.0|<=2.5",
+			want:      "vers:pypi/<1.0|>=1.5|<2.0|>2.5",
+			versions:  []string{"0.5", "1.0", "1.5", "1.9", "2.0", "2.5", "3.0"},
+		},
+		{
+			name:      "exact version",
+			versRange: "vers:npm/1.2.3",
+			want:      "vers:npm/!=1.2.3",
+			versions:  []string{"1.2.2", "1.2.3", "1.2.4"},
+		},
+		{
+			name:      "exclusions only",
+			versRange: "vers:npm/!=1.0.0|!=2.0.0",
+			want:      "vers:npm/1.0.0|2.0.0",
+			versions:  []string{"0.5.0", "1.0.0", "1.5.0", "2.0.0"},
+		},
+		{
+			name:      "exclusion inside interval",
+			versRange: "vers:npm/>=1.0.0|!=1.5.0|<2.0.0",
+			want:      "vers:npm/<1.0.0|1.5.0|>=2.0.0",
+			versions:  []string{"0.9.0", "1.0.0", "1.5.0", "1.6.0", "2.0.0"},
+		},
+		{
+			name:      "exclusion at interval bound joins gap",
+			versRange: "vers:npm/>=1.0.0|!=1.0.0|<2.0.0",
+			want:      "vers:npm/<=1.0.0|>=2.0.0",
+			versions:  []string{"0.9.0", "1.0.0", "1.0.1", "2.0.0"},
+		},
+		{
+			name:      "exclusion outside range is dropped",
+			versRange: "vers:npm/>=1.0.0|<2.0.0|!=3.0.0",
+			want:      "vers:npm/<1.0.0|>=2.0.0",
+			versions:  []string{"1.5.0", "3.0.0"},
+		},
+		{
+			name:      "overlapping exact version",
+			versRange: "vers:npm/>=1.0.0|<2.0.0|1.5.0",
+			want:      "vers:npm/<1.0.0|>=2.0.0",
+			versions:  []string{"1.5.0", "2.0.0"},
+		},
+		{
+			name:      "exact version and interval",
+			versRange: "vers:npm/1.0.0|>=3.0.0|<4.0.0",
+			want:      "vers:npm/!=1.0.0|<3.0.0|>=4.0.0",
+			versions:  []string{"0.9.0", "1.0.0", "2.0.0", "3.0.0", "4.0.0"},
+		},
+		{
+			name:      "single version gap",
+			versRange: "vers:npm/<1.0.0|>1.0.0",
+			want:      "vers:npm/1.0.0",
+			versions:  []string{"0.9.0", "1.0.0", "1.0.1"},
+		},
+		{
+			name:      "golang",
+			versRange: "vers:golang/>=v1.2.0|<v1.3.0",
+			want:      "vers:golang/<v1.2.0|>=v1.3.0",
+			versions:  []string{"v1.1.0", "v1.2.5", "v1.3.0"},
+		},
+		{
+			name:      "decoding option",
+			versRange: "vers:npm/%3E%3D1.0.0",
+			opts:      []Option{WithDecoding()},
+			want:      "vers:npm/<1.0.0",
+		},
+		{
+			name:      "star has empty complement",
+			versRange: "vers:npm/*",
+			wantErr:   true,
+		},
+		{
+			name:      "range covering all versions has empty complement",
+			versRange: "vers:npm/<1.0.0|>=1.0.0",
+			wantErr:   true,
+		},
+		{
+			name:      "compliance rejects non-alternating bounds",
+			versRange: "vers:npm/>=1.0.0|>=1.5.0|<2.0.0",
+			opts:      []Option{WithCompliance()},
+			wantErr:   true,
+		},
+		{
+			name:      "invalid version",
+			versRange: "vers:npm/>=not-a-version",
+			wantErr:   true,
+		},
+		{
+			name:      "unsupported scheme",
+			versRange: "vers:unknown/>=1.0.0",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Complement(tt.versRange, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Complement(%q) error = %v, wantErr %v", tt.versRange, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Complement(%q) = %q, want %q", tt.versRange, got, tt.want)
+			}
+			if err := Validate(got, WithCompliance()); err != nil {
+				t.Errorf("Validate(%q, WithCompliance()) error = %v", got, err)
+			}
+			for _, v := range tt.versions {
+				in, err := Contains(tt.versRange, v)
+				if err != nil {
+					t.Fatalf("Contains(%q, %q) error = %v", tt.versRange, v, err)
+				}
+				out, err := Contains(got, v)
+				if err != nil {
+					t.Fatalf("Contains(%q, %q) error = %v", got, v, err)
+				}
+				if in == out {
+					t.Errorf("Contains(%q, %q) = %v and Contains(%q, %q) = %v, want opposite", tt.versRange, v, in, got, v, out)
+				}
+			}
+		})
+	}
+}
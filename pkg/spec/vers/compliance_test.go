@@ -0,0 +1,121 @@
+package vers
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// complianceVector is one test case in the package-url test schema, see
+// testdata/compliance/README.md
+type complianceVector struct {
+	Description     string          `json:"description"`
+	TestType        string          `json:"test_type"`
+	Input           json.RawMessage `json:"input"`
+	ExpectedOutput  *bool           `json:"expected_output"`
+	ExpectedFailure bool            `json:"expected_failure"`
+}
+
+// containmentInput is the input of a containment vector
+type containmentInput struct {
+	Vers    string `json:"vers"`
+	Version string `json:"version"`
+}
+
+func loadComplianceVectors(t *testing.T) map[string][]complianceVector {
+	t.Helper()
+
+	paths, err := filepath.Glob(filepath.Join("testdata", "compliance", "*.json"))
+	if err != nil {
+		t.Fatalf("Glob() error = %v", err)
+	}
+	if len(paths) == 0 {
+		t.Fatal("no compliance vectors found in testdata/compliance")
+	}
+
+	files := make(map[string][]complianceVector)
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("ReadFile(%s) error = %v", path, err)
+		}
+		var file struct {
+			Tests []complianceVector `json:"tests"`
+		}
+		if err := json.Unmarshal(data, &file); err != nil {
+			t.Fatalf("Unmarshal(%s) error = %v", path, err)
+		}
+		files[filepath.Base(path)] = file.Tests
+	}
+	return files
+}
+
+func TestCompliance(t *testing.T) {
+	for name, vectors := range loadComplianceVectors(t) {
+		t.Run(name, func(t *testing.T) {
+			for _, tt := range vectors {
+				t.Run(tt.Description, func(t *testing.T) {
+					switch tt.TestType {
+					case "validation":
+						runValidationVector(t, tt)
+					case "containment":
+						runContainmentVector(t, tt)
+					default:
+						t.Skipf("unsupported test_type %q", tt.TestType)
+					}
+				})
+			}
+		})
+	}
+}
+
+func runValidationVector(t *testing.T, tt complianceVector) {
+	var input string
+	if err := json.Unmarshal(tt.Input, &input); err != nil {
+		t.Fatalf("validation input is not a string: %v", err)
+	}
+	skipUnsupported(t, input)
+
+	err := Validate(input, WithCompliance())
+	if (err != nil) != tt.ExpectedFailure {
+		t.Errorf("Validate(%q) error = %v, expected_failure %v", input, err, tt.ExpectedFailure)
+	}
+}
+
+func runContainmentVector(t *testing.T, tt complianceVector) {
+	var input containmentInput
+	if err := json.Unmarshal(tt.Input, &input); err != nil {
+		t.Fatalf("containment input is not an object: %v", err)
+	}
+	skipUnsupported(t, input.Vers)
+
+	got, err := Contains(input.Vers, input.Version, WithCompliance())
+	if (err != nil) != tt.ExpectedFailure {
+		t.Fatalf("Contains(%q, %q) error = %v, expected_failure %v", input.Vers, input.Version, err, tt.ExpectedFailure)
+	}
+	if err != nil {
+		return
+	}
+	if tt.ExpectedOutput == nil {
+		t.Fatal("containment vector has no expected_output")
+	}
+	if got != *tt.ExpectedOutput {
+		t.Errorf("Contains(%q, %q) = %v, want %v", input.Vers, input.Version, got, *tt.ExpectedOutput)
+	}
+}
+
+// skipUnsupported skips vectors for versioning schemes go-univers does not
+// implement, so that upstream files can be copied in unchanged
+func skipUnsupported(t *testing.T, versRange string) {
+	t.Helper()
+
+	s, err := scheme(versRange)
+	if err != nil {
+		return // Invalid strings are part of the vectors
+	}
+	if err := Validate("vers:" + s + "/*"); err != nil && strings.Contains(err.Error(), "unsupported") {
+		t.Skipf("versioning-scheme %q unsupported", s)
+	}
+}
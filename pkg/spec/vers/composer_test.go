@@ -0,0 +1,91 @@
+package vers
+
+import (
+	"testing"
+)
+
+// TestContains_Composer tests VERS functionality specifically for the Composer ecosystem
+func TestContains_Composer(t *testing.T) {
+	tests := []struct {
+		name      string
+		versRange string
+		version   string
+		want      bool
+		wantErr   bool
+	}{
+		{
+			name:      "composer simple range - contained",
+			versRange: "vers:composer/>=1.0.0|<2.0.0",
+			version:   "1.5.0",
+			want:      true,
+		},
+		{
+			name:      "composer simple range - upper bound excluded",
+			versRange: "vers:composer/>=1.0.0|<2.0.0",
+			version:   "2.0.0",
+			want:      false,
+		},
+		{
+			name:      "composer exact match",
+			versRange: "vers:composer/=1.5.0",
+			version:   "1.5.0",
+			want:      true,
+		},
+		{
+			name:      "composer exact match with v prefix",
+			versRange: "vers:composer/=1.5.0",
+			version:   "v1.5.0",
+			want:      true,
+		},
+		{
+			name:      "composer short version",
+			versRange: "vers:composer/>=1.0|<2.0",
+			version:   "1.2",
+			want:      true,
+		},
+		{
+			name:      "composer pre-release below upper bound",
+			versRange: "vers:composer/>=1.0.0|<2.0.0",
+			version:   "2.0.0-beta1",
+			want:      true,
+		},
+		{
+			name:      "composer multiple intervals - in gap",
+			versRange: "vers:composer/>=1.0.0|<1.5.0|>=2.0.0|<3.0.0",
+			version:   "1.7.0",
+			want:      false,
+		},
+		{
+			name:      "composer exclusion",
+			versRange: "vers:composer/>=1.0.0|!=1.5.0|<2.0.0",
+			version:   "1.5.0",
+			want:      false,
+		},
+		// Error cases
+		{
+			name:      "composer invalid version",
+			versRange: "vers:composer/>=1.0.0",
+			version:   "not a version",
+			wantErr:   true,
+		},
+		{
+			name:      "composer invalid constraint version",
+			versRange: "vers:composer/>=not a version",
+			version:   "1.0.0",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Contains(tt.versRange, tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Contains() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Contains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
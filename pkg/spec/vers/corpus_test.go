@@ -0,0 +1,163 @@
+package vers
+
+//go:generate go test -run TestCorpus_Compatibility -update-corpus-report
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+var updateCorpusReport = flag.Bool("update-corpus-report", false, "rewrite testdata/corpus/REPORT.md from the corpus")
+
+var (
+	corpusPath       = filepath.Join("testdata", "corpus", "vulnerablecode.txt")
+	corpusReportPath = filepath.Join("testdata", "corpus", "REPORT.md")
+)
+
+// corpusResult is the outcome of checking one range of the corpus
+type corpusResult struct {
+	versRange string
+	scheme    string
+	// unsupported is set when the range's versioning scheme is not
+	// implemented, and err when the range failed for any other reason
+	unsupported bool
+	err         error
+}
+
+// TestCorpus_Compatibility checks the committed report against the ranges of
+// the corpus, so that a change which stops real-world ranges from parsing
+// fails until the report is regenerated and the drop reviewed
+func TestCorpus_Compatibility(t *testing.T) {
+	ranges := loadCorpus(t)
+	results := make([]corpusResult, 0, len(ranges))
+	for _, r := range ranges {
+		results = append(results, checkCorpusRange(r))
+	}
+	report := corpusReport(results)
+
+	if *updateCorpusReport {
+		if err := os.WriteFile(corpusReportPath, []byte(report), 0o644); err != nil {
+			t.Fatalf("WriteFile(%s) error = %v", corpusReportPath, err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(corpusReportPath)
+	if err != nil {
+		t.Fatalf("ReadFile(%s) error = %v", corpusReportPath, err)
+	}
+	if string(want) != report {
+		t.Errorf("%s is out of date; run go generate ./pkg/spec/vers and review the diff. Now:\n%s", corpusReportPath, report)
+	}
+}
+
+// loadCorpus returns the VERS ranges of the corpus, skipping comments and
+// blank lines
+func loadCorpus(t *testing.T) []string {
+	t.Helper()
+
+	file, err := os.Open(corpusPath)
+	if err != nil {
+		t.Fatalf("Open(%s) error = %v", corpusPath, err)
+	}
+	defer file.Close()
+
+	var ranges []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		ranges = append(ranges, line)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("reading %s: %v", corpusPath, err)
+	}
+	if len(ranges) == 0 {
+		t.Fatalf("no ranges in %s", corpusPath)
+	}
+	return ranges
+}
+
+// checkCorpusRange runs Contains on a range with the version of its first
+// constraint, which is written in the range's own scheme
+func checkCorpusRange(versRange string) corpusResult {
+	result := corpusResult{versRange: versRange}
+	s, err := scheme(versRange)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	result.scheme = s
+
+	_, constraints, _ := strings.Cut(versRange, "/")
+	first, _, _ := strings.Cut(constraints, "|")
+	version := strings.TrimLeft(first, "<>=!")
+	if version == "*" {
+		version = "0"
+	}
+
+	if _, err := Contains(versRange, version); errors.Is(err, ErrUnsupportedScheme) {
+		result.unsupported = true
+	} else {
+		result.err = err
+	}
+	return result
+}
+
+// corpusReport renders the results as the Markdown of REPORT.md
+func corpusReport(results []corpusResult) string {
+	type tally struct{ total, supported, unsupported, failed int }
+	schemes := map[string]*tally{}
+	var all tally
+	var failures []corpusResult
+	for _, r := range results {
+		t := schemes[r.scheme]
+		if t == nil {
+			t = &tally{}
+			schemes[r.scheme] = t
+		}
+		for _, t := range []*tally{t, &all} {
+			t.total++
+			switch {
+			case r.unsupported:
+				t.unsupported++
+			case r.err != nil:
+				t.failed++
+			default:
+				t.supported++
+			}
+		}
+		if r.err != nil {
+			failures = append(failures, r)
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# VERS corpus compatibility\n\n")
+	fmt.Fprintf(&b, "Generated from `vulnerablecode.txt` by `go generate ./pkg/spec/vers`. Do not edit.\n\n")
+	fmt.Fprintf(&b, "Compatibility: %d of %d ranges (%.1f%%)\n\n", all.supported, all.total, 100*float64(all.supported)/float64(all.total))
+	fmt.Fprintf(&b, "| Scheme | Ranges | Supported | Unsupported scheme | Failed |\n")
+	fmt.Fprintf(&b, "|--------|-------:|----------:|-------------------:|-------:|\n")
+	for _, s := range slices.Sorted(maps.Keys(schemes)) {
+		t := schemes[s]
+		fmt.Fprintf(&b, "| %s | %d | %d | %d | %d |\n", s, t.total, t.supported, t.unsupported, t.failed)
+	}
+
+	if len(failures) > 0 {
+		fmt.Fprintf(&b, "\n## Failures\n\n")
+		for _, r := range failures {
+			fmt.Fprintf(&b, "- `%s`: %v\n", r.versRange, r.err)
+		}
+	}
+	return b.String()
+}
@@ -3,16 +3,8 @@ package vers
 import (
 	"fmt"
 	"strings"
-
-	"github.com/alowayed/go-univers/pkg/ecosystem/debian"
 )
 
-// debianContains implements VERS constraint checking for Debian ecosystem
-func debianContains(constraints []string, version string) (bool, error) {
-	e := &debian.Ecosystem{}
-	return contains(e, constraints, version)
-}
-
 // intervalToDebianRanges converts an interval to Debian range syntax
 func intervalToDebianRanges(interval interval) []string {
 	// Handle exact matches
@@ -0,0 +1,60 @@
+package vers
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrUnsupportedScheme is wrapped by the errors of every function taking a
+// VERS string whose versioning scheme has no go-univers ecosystem, so bulk
+// pipelines can count and skip such ranges with errors.Is.
+var ErrUnsupportedScheme = errors.New("unsupported")
+
+// ConstraintError reports a malformed constraint of a VERS range, identified
+// by its zero-based index among the range's "|"-separated constraints. Parse,
+// Contains, Validate and the other functions taking a VERS string report every
+// malformed constraint, not just the first, joined with errors.Join.
+type ConstraintError struct {
+	// Index is the position of the constraint in the range, counting empty
+	// constraints such as the one in ">=1.0.0||<2.0.0".
+	Index int
+	// Constraint is the constraint as written, without surrounding whitespace.
+	Constraint string
+	// Err is the underlying error.
+	Err error
+}
+
+// Error returns the underlying error annotated with the constraint and its
+// index, such as `constraint 7 ">=1..2": invalid version: ...`.
+func (e *ConstraintError) Error() string {
+	return fmt.Sprintf("constraint %d %q: %v", e.Index, e.Constraint, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ConstraintError) Unwrap() error {
+	return e.Err
+}
+
+// ConstraintErrors returns every ConstraintError in err's tree, ordered by
+// index, so data-quality tooling can list all the bad constraints of a range.
+// It returns nil if err has none.
+func ConstraintErrors(err error) []*ConstraintError {
+	var errs []*ConstraintError
+	stack := []error{err}
+	for len(stack) > 0 {
+		e := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		switch e := e.(type) {
+		case *ConstraintError:
+			errs = append(errs, e)
+		case interface{ Unwrap() error }:
+			stack = append(stack, e.Unwrap())
+		case interface{ Unwrap() []error }:
+			stack = append(stack, e.Unwrap()...)
+		}
+	}
+	slices.SortStableFunc(errs, func(a, b *ConstraintError) int { return cmp.Compare(a.Index, b.Index) })
+	return errs
+}
@@ -0,0 +1,74 @@
+package vers
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestConstraintError_Error(t *testing.T) {
+	err := &ConstraintError{Index: 7, Constraint: ">=1..2", Err: errors.New("invalid version")}
+	if got, want := err.Error(), `constraint 7 ">=1..2": invalid version`; got != want {
+		t.Errorf("ConstraintError.Error() = %q, want %q", got, want)
+	}
+}
+
+func TestConstraintErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		versRange string
+		opts      []Option
+		want      []string
+	}{
+		{
+			name:      "one bad version",
+			versRange: "vers:npm/>=1.0.0|<1.5.0|>=2.0.0|>=1..2|<3.0.0",
+			want:      []string{`constraint 3 ">=1..2"`},
+		},
+		{
+			name:      "every bad constraint",
+			versRange: "vers:pypi/>=1.0|~1.2| >=x.y |<2.0",
+			want:      []string{`constraint 1 "~1.2"`, `constraint 2 ">=x.y"`},
+		},
+		{
+			name:      "index counts empty constraints",
+			versRange: "vers:npm/>=1.0.0||<bogus",
+			want:      []string{`constraint 2 "<bogus"`},
+		},
+		{
+			name:      "empty constraint in compliance mode",
+			versRange: "vers:npm/>=1.0.0||<2.0.0",
+			opts:      []Option{WithCompliance()},
+			want:      []string{`constraint 1 ""`},
+		},
+		{
+			name:      "bad version in compliance mode",
+			versRange: "vers:npm/>=1.0.0|<2.0.0.0",
+			opts:      []Option{WithCompliance()},
+			want:      []string{`constraint 1 "<2.0.0.0"`},
+		},
+		{
+			name:      "valid",
+			versRange: "vers:npm/>=1.0.0|<2.0.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.versRange, tt.opts...)
+			var got []string
+			for _, ce := range ConstraintErrors(err) {
+				got = append(got, fmt.Sprintf("constraint %d %q", ce.Index, ce.Constraint))
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ConstraintErrors(%v) = %q, want %q", err, got, tt.want)
+			}
+
+			var ce *ConstraintError
+			if len(tt.want) > 0 && !errors.As(err, &ce) {
+				t.Errorf("errors.As(%v, *ConstraintError) = false", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package vers
+
+import "testing"
+
+// TestContains_Exclude checks that "!=" compares versions with each scheme's
+// own equality rather than by spelling, including in exclude-only ranges
+func TestContains_Exclude(t *testing.T) {
+	tests := []struct {
+		name      string
+		versRange string
+		version   string
+		want      bool
+	}{
+		// Exclude-only ranges match every other version
+		{name: "exclude-only other version", versRange: "vers:npm/!=1.5.0", version: "1.5.1", want: true},
+		{name: "exclude-only excluded version", versRange: "vers:npm/!=1.5.0", version: "1.5.0", want: false},
+		{name: "exclude-only several", versRange: "vers:npm/!=1.5.0|!=1.6.0", version: "1.6.0", want: false},
+
+		// Equal versions with different spellings are excluded
+		{name: "npm build metadata", versRange: "vers:npm/!=1.5.0", version: "1.5.0+build", want: false},
+		{name: "npm v prefix", versRange: "vers:npm/!=1.5.0", version: "v1.5.0", want: false},
+		{name: "npm build metadata in range", versRange: "vers:npm/>=1.0.0|!=1.5.0|<2.0.0", version: "1.5.0+build", want: false},
+		{name: "generic build metadata", versRange: "vers:generic/!=1.5.0", version: "1.5.0+build", want: false},
+		{name: "cargo build metadata", versRange: "vers:cargo/!=1.5.0", version: "1.5.0+build", want: false},
+		{name: "maven trailing zeros", versRange: "vers:maven/!=1.5", version: "1.5.0.0", want: false},
+		{name: "pypi release padding", versRange: "vers:pypi/!=1.5", version: "1.5.0", want: false},
+		{name: "pypi post release", versRange: "vers:pypi/!=1.5", version: "1.5.post1", want: true},
+		{name: "deb implicit epoch", versRange: "vers:deb/!=1.5", version: "0:1.5", want: false},
+		{name: "rpm implicit epoch", versRange: "vers:rpm/!=1.5", version: "0:1.5", want: false},
+		{name: "golang v prefix", versRange: "vers:golang/!=v1.5.0", version: "1.5.0", want: false},
+		{name: "gem trailing zero", versRange: "vers:gem/!=1.5", version: "1.5.0", want: false},
+		{name: "nuget trailing zeros", versRange: "vers:nuget/!=1.5", version: "1.5.0.0", want: false},
+		{name: "luarocks trailing zero", versRange: "vers:luarocks/!=1.5", version: "1.5.0", want: false},
+		{name: "alpine", versRange: "vers:alpine/!=1.5-r0", version: "1.5-r0", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Contains(tt.versRange, tt.version)
+			if err != nil {
+				t.Fatalf("Contains(%q, %q) error = %v", tt.versRange, tt.version, err)
+			}
+			if got != tt.want {
+				t.Errorf("Contains(%q, %q) = %v, want %v", tt.versRange, tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComplement_ExcludeEquivalentSpellings(t *testing.T) {
+	// 1.5 and 1.5.0 are the same Maven version, so the complement holds it once
+	got, err := Complement("vers:maven/!=1.5|!=1.5.0")
+	if err != nil {
+		t.Fatalf("Complement() error = %v", err)
+	}
+	if got != "vers:maven/1.5" && got != "vers:maven/1.5.0" {
+		t.Errorf("Complement() = %q, want a single exact version", got)
+	}
+}
@@ -3,16 +3,8 @@ package vers
 import (
 	"fmt"
 	"strings"
-
-	"github.com/alowayed/go-univers/pkg/ecosystem/gem"
 )
 
-// gemContains implements VERS constraint checking for RubyGems ecosystem
-func gemContains(constraints []string, version string) (bool, error) {
-	e := &gem.Ecosystem{}
-	return contains(e, constraints, version)
-}
-
 // intervalToGemRanges converts an interval to RubyGems range syntax
 func intervalToGemRanges(interval interval) []string {
 	// Handle exact matches
@@ -3,16 +3,8 @@ package vers
 import (
 	"fmt"
 	"strings"
-
-	"github.com/alowayed/go-univers/pkg/ecosystem/golang"
 )
 
-// golangContains implements VERS constraint checking for Go modules ecosystem using 'golang' scheme
-func golangContains(constraints []string, version string) (bool, error) {
-	e := &golang.Ecosystem{}
-	return contains(e, constraints, version)
-}
-
 // intervalToGolangRanges converts an interval to Go module range syntax
 func intervalToGolangRanges(interval interval) []string {
 	// Handle exact matches
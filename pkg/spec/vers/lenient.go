@@ -0,0 +1,43 @@
+package vers
+
+// Result is the outcome of ContainsLenient: whether a version is in a range,
+// or that it could not be told.
+type Result int
+
+const (
+	// Unknown means the range or version could not be evaluated, for
+	// instance because the range's versioning scheme is unsupported.
+	Unknown Result = iota
+	// NotContained means the version is outside the range.
+	NotContained
+	// Contained means the version is in the range.
+	Contained
+)
+
+// String returns "unknown", "not-contained" or "contained".
+func (r Result) String() string {
+	switch r {
+	case NotContained:
+		return "not-contained"
+	case Contained:
+		return "contained"
+	}
+	return "unknown"
+}
+
+// ContainsLenient is like Contains, but reports a range or version it cannot
+// evaluate as Unknown instead of forcing every caller to branch on an error.
+// Bulk pipelines over mixed VERS data can switch on the result and degrade
+// gracefully; the error, non-nil exactly when the result is Unknown, says
+// why, and wraps ErrUnsupportedScheme when the range's versioning scheme has
+// no go-univers ecosystem, so such ranges can be counted and skipped.
+func ContainsLenient(versRange, version string, opts ...Option) (Result, error) {
+	contained, err := Contains(versRange, version, opts...)
+	switch {
+	case err != nil:
+		return Unknown, err
+	case contained:
+		return Contained, nil
+	}
+	return NotContained, nil
+}
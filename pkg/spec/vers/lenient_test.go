@@ -0,0 +1,108 @@
+package vers
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestContainsLenient(t *testing.T) {
+	tests := []struct {
+		name            string
+		versRange       string
+		version         string
+		want            Result
+		wantErr         bool
+		wantUnsupported bool
+	}{
+		{
+			name:      "contained",
+			versRange: "vers:npm/>=1.0.0|<2.0.0",
+			version:   "1.5.0",
+			want:      Contained,
+		},
+		{
+			name:      "not contained",
+			versRange: "vers:npm/>=1.0.0|<2.0.0",
+			version:   "2.0.0",
+			want:      NotContained,
+		},
+		{
+			name:            "unsupported scheme",
+			versRange:       "vers:conan/>=1.0",
+			version:         "1.5",
+			want:            Unknown,
+			wantErr:         true,
+			wantUnsupported: true,
+		},
+		{
+			name:      "invalid version",
+			versRange: "vers:npm/>=1.0.0",
+			version:   "not-a-version",
+			want:      Unknown,
+			wantErr:   true,
+		},
+		{
+			name:      "malformed range",
+			versRange: "npm/>=1.0.0",
+			version:   "1.0.0",
+			want:      Unknown,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ContainsLenient(tt.versRange, tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ContainsLenient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ContainsLenient() = %v, want %v", got, tt.want)
+			}
+			if unsupported := errors.Is(err, ErrUnsupportedScheme); unsupported != tt.wantUnsupported {
+				t.Errorf("errors.Is(%v, ErrUnsupportedScheme) = %v, want %v", err, unsupported, tt.wantUnsupported)
+			}
+		})
+	}
+}
+
+func TestErrUnsupportedScheme(t *testing.T) {
+	const versRange = "vers:unknown/>=1.0"
+
+	_, containsErr := Contains(versRange, "1.0")
+	_, parseErr := Parse(versRange)
+	_, compileErr := Compile(versRange)
+	_, complementErr := Complement(versRange)
+	_, overlapsErr := Overlaps(versRange, versRange)
+
+	for name, err := range map[string]error{
+		"Contains":   containsErr,
+		"Parse":      parseErr,
+		"Compile":    compileErr,
+		"Complement": complementErr,
+		"Overlaps":   overlapsErr,
+		"Validate":   Validate(versRange),
+	} {
+		if !errors.Is(err, ErrUnsupportedScheme) {
+			t.Errorf("%s() error = %v, want ErrUnsupportedScheme", name, err)
+		}
+	}
+	if got, want := containsErr.Error(), `versioning-scheme "unknown" unsupported`; got != want {
+		t.Errorf("Contains() error = %q, want %q", got, want)
+	}
+
+	// Ecosystem names and aliases other than the VERS scheme are not schemes
+	for _, versRange := range []string{"vers:debian/>=1.0", "vers:semver/>=1.0", "vers:go/>=1.0"} {
+		if err := Validate(versRange); !errors.Is(err, ErrUnsupportedScheme) {
+			t.Errorf("Validate(%q) error = %v, want ErrUnsupportedScheme", versRange, err)
+		}
+	}
+}
+
+func TestResult_String(t *testing.T) {
+	for r, want := range map[Result]string{Unknown: "unknown", NotContained: "not-contained", Contained: "contained"} {
+		if got := r.String(); got != want {
+			t.Errorf("Result(%d).String() = %q, want %q", int(r), got, want)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package vers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// intervalToLuarocksRanges converts an interval to LuaRocks constraint syntax
+func intervalToLuarocksRanges(interval interval) []string {
+	// Handle exact matches
+	if interval.exact != "" {
+		return []string{fmt.Sprintf("==%s", interval.exact)}
+	}
+
+	// Exclusions are handled separately, not as LuaRocks constraints
+	if interval.exclude != "" {
+		return []string{} // Return empty - excludes handled in contains function
+	}
+
+	// Handle regular intervals with bounds
+	var parts []string
+	if interval.lower != "" {
+		op := ">"
+		if interval.lowerInclusive {
+			op = ">="
+		}
+		parts = append(parts, fmt.Sprintf("%s%s", op, interval.lower))
+	}
+	if interval.upper != "" {
+		op := "<"
+		if interval.upperInclusive {
+			op = "<="
+		}
+		parts = append(parts, fmt.Sprintf("%s%s", op, interval.upper))
+	}
+
+	if len(parts) > 0 {
+		return []string{strings.Join(parts, ", ")}
+	}
+
+	// Empty interval
+	return []string{}
+}
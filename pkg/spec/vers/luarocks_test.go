@@ -0,0 +1,97 @@
+package vers
+
+import (
+	"testing"
+)
+
+// TestContains_Luarocks tests VERS functionality specifically for the LuaRocks ecosystem
+func TestContains_Luarocks(t *testing.T) {
+	tests := []struct {
+		name      string
+		versRange string
+		version   string
+		want      bool
+		wantErr   bool
+	}{
+		{
+			name:      "luarocks simple range - contained",
+			versRange: "vers:luarocks/>=1.0|<2.0",
+			version:   "1.5.0-1",
+			want:      true,
+		},
+		{
+			name:      "luarocks simple range - not contained",
+			versRange: "vers:luarocks/>=1.0|<2.0",
+			version:   "2.0-1",
+			want:      false,
+		},
+		{
+			name:      "luarocks exact match ignores revision",
+			versRange: "vers:luarocks/=1.0.0",
+			version:   "1.0.0-2",
+			want:      true,
+		},
+		{
+			name:      "luarocks exact match with revision",
+			versRange: "vers:luarocks/=1.0.0-1",
+			version:   "1.0.0-2",
+			want:      false,
+		},
+		{
+			name:      "luarocks revision bound",
+			versRange: "vers:luarocks/>1.0.0-1",
+			version:   "1.0.0-2",
+			want:      true,
+		},
+		{
+			name:      "luarocks scm above releases",
+			versRange: "vers:luarocks/>=3.0",
+			version:   "scm-1",
+			want:      true,
+		},
+		{
+			name:      "luarocks release candidate below release",
+			versRange: "vers:luarocks/<2.0",
+			version:   "2.0rc1-1",
+			want:      true,
+		},
+		{
+			name:      "luarocks not equal",
+			versRange: "vers:luarocks/>=1.0|<2.0|!=1.5-1",
+			version:   "1.5-1",
+			want:      false,
+		},
+		{
+			name:      "luarocks star constraint",
+			versRange: "vers:luarocks/*",
+			version:   "dev-1",
+			want:      true,
+		},
+		// Error cases
+		{
+			name:      "luarocks invalid version",
+			versRange: "vers:luarocks/>=1.0",
+			version:   "1.0+build",
+			wantErr:   true,
+		},
+		{
+			name:      "luarocks invalid constraint version",
+			versRange: "vers:luarocks/>=1.0@",
+			version:   "1.0",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Contains(tt.versRange, tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Contains() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Contains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,16 +1,6 @@
 package vers
 
-import (
-	"fmt"
-
-	"github.com/alowayed/go-univers/pkg/ecosystem/maven"
-)
-
-// mavenContains implements VERS constraint checking for Maven ecosystem
-func mavenContains(constraints []string, version string) (bool, error) {
-	e := &maven.Ecosystem{}
-	return contains(e, constraints, version)
-}
+import "fmt"
 
 // intervalToMavenRanges converts an interval to Maven range syntax
 func intervalToMavenRanges(interval interval) []string {
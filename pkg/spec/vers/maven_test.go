@@ -305,11 +305,11 @@ func TestContains_Maven(t *testing.T) {
 			wantErr:   false,
 		},
 		{
-			name:      "malformed constraint without operator should fail",
+			name:      "constraint without operator means equality",
 			versRange: "vers:maven/1.0.0",
 			version:   "1.0.0",
-			want:      false,
-			wantErr:   true,
+			want:      true,
+			wantErr:   false,
 		},
 		{
 			name:      "constraint with operator but no version should fail",
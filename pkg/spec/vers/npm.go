@@ -7,17 +7,18 @@ import (
 	"github.com/alowayed/go-univers/pkg/ecosystem/npm"
 )
 
-// npmContains implements VERS constraint checking for NPM ecosystem
-func npmContains(constraints []string, version string) (bool, error) {
-	e := &npm.Ecosystem{}
-	return contains(e, constraints, version)
-}
-
-// intervalToNpmRanges converts an interval to NPM range syntax
+// intervalToNpmRanges converts an interval to an NPM comparator set.
+//
+// VERS intervals are defined purely by version precedence, so every version
+// between the bounds is included, prereleases too: >=1.0.0-alpha|<2.0.0
+// contains 1.5.0-beta and 2.0.0-rc.1. Each bound is emitted as an explicit
+// comparator with its full prerelease tag, and no caret, tilde or x-range
+// sugar is used, so the translation never picks up NPM's prerelease
+// exclusion for versions outside the bound's [major, minor, patch] tuple.
 func intervalToNpmRanges(interval interval) []string {
 	// Handle exact matches
 	if interval.exact != "" {
-		return []string{fmt.Sprintf("=%s", interval.exact)}
+		return []string{npmComparator("=", interval.exact)}
 	}
 
 	// Exclusions are handled separately, not as NPM ranges
@@ -32,14 +33,14 @@ func intervalToNpmRanges(interval interval) []string {
 		if interval.lowerInclusive {
 			op = ">="
 		}
-		parts = append(parts, fmt.Sprintf("%s%s", op, interval.lower))
+		parts = append(parts, npmComparator(op, interval.lower))
 	}
 	if interval.upper != "" {
 		op := "<"
 		if interval.upperInclusive {
 			op = "<="
 		}
-		parts = append(parts, fmt.Sprintf("%s%s", op, interval.upper))
+		parts = append(parts, npmComparator(op, interval.upper))
 	}
 
 	if len(parts) > 0 {
@@ -49,3 +50,19 @@ func intervalToNpmRanges(interval interval) []string {
 	// Empty interval
 	return []string{}
 }
+
+// npmComparator renders a single comparator with the bound in canonical form.
+// The "v" prefix and build metadata, which does not take part in precedence,
+// are dropped while the prerelease tag is kept verbatim. Unparseable bounds are
+// left as-is so the NPM range parser reports them.
+func npmComparator(op, version string) string {
+	e := &npm.Ecosystem{}
+	if _, err := e.NewVersion(version); err != nil {
+		return fmt.Sprintf("%s%s", op, version)
+	}
+	bound := strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexByte(bound, '+'); i >= 0 {
+		bound = bound[:i]
+	}
+	return fmt.Sprintf("%s%s", op, bound)
+}
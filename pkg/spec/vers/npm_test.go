@@ -1,6 +1,7 @@
 package vers
 
 import (
+	"slices"
 	"testing"
 )
 
@@ -154,6 +155,48 @@ func TestContains_NPM(t *testing.T) {
 			want:      true,
 			wantErr:   false,
 		},
+		{
+			name:      "npm prerelease below exclusive release upper bound",
+			versRange: "vers:npm/>=1.0.0-alpha|<2.0.0",
+			version:   "2.0.0-rc.1",
+			want:      true,
+			wantErr:   false,
+		},
+		{
+			name:      "npm prerelease of other tuple above exclusive lower bound",
+			versRange: "vers:npm/>1.0.0",
+			version:   "1.0.1-alpha",
+			want:      true,
+			wantErr:   false,
+		},
+		{
+			name:      "npm prerelease below inclusive release lower bound",
+			versRange: "vers:npm/>=1.0.0|<2.0.0",
+			version:   "1.0.0-rc.1",
+			want:      false,
+			wantErr:   false,
+		},
+		{
+			name:      "npm inclusive prerelease upper bound",
+			versRange: "vers:npm/<=2.0.0-rc.1",
+			version:   "2.0.0-rc.1",
+			want:      true,
+			wantErr:   false,
+		},
+		{
+			name:      "npm prerelease above prerelease upper bound",
+			versRange: "vers:npm/<=2.0.0-rc.1",
+			version:   "2.0.0-rc.2",
+			want:      false,
+			wantErr:   false,
+		},
+		{
+			name:      "npm bound with v prefix and build metadata",
+			versRange: "vers:npm/>=v1.0.0-alpha+build.5|<2.0.0",
+			version:   "1.0.0-alpha",
+			want:      true,
+			wantErr:   false,
+		},
 		{
 			name:      "npm invalid version in NPM",
 			versRange: "vers:npm/>=1.0.0",
@@ -176,3 +219,41 @@ func TestContains_NPM(t *testing.T) {
 		})
 	}
 }
+
+func TestIntervalToNpmRanges(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval interval
+		want     []string
+	}{
+		{
+			name:     "prerelease bounds kept verbatim",
+			interval: interval{lower: "1.0.0-alpha", lowerInclusive: true, upper: "2.0.0"},
+			want:     []string{">=1.0.0-alpha <2.0.0"},
+		},
+		{
+			name:     "prefix and build metadata dropped",
+			interval: interval{lower: "v1.0.0-rc.1+build", upper: "2.0.0-0", upperInclusive: true},
+			want:     []string{">1.0.0-rc.1 <=2.0.0-0"},
+		},
+		{
+			name:     "exact prerelease",
+			interval: interval{exact: "1.0.0-beta.2"},
+			want:     []string{"=1.0.0-beta.2"},
+		},
+		{
+			name:     "exclusion",
+			interval: interval{exclude: "1.0.0-beta.2"},
+			want:     []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := intervalToNpmRanges(tt.interval)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("intervalToNpmRanges() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
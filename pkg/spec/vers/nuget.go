@@ -1,16 +1,6 @@
 package vers
 
-import (
-	"fmt"
-
-	"github.com/alowayed/go-univers/pkg/ecosystem/nuget"
-)
-
-// nugetContains implements VERS constraint checking for NuGet ecosystem
-func nugetContains(constraints []string, version string) (bool, error) {
-	e := &nuget.Ecosystem{}
-	return contains(e, constraints, version)
-}
+import "fmt"
 
 // intervalToNugetRanges converts an interval to NuGet range syntax
 func intervalToNugetRanges(interval interval) []string {
@@ -0,0 +1,71 @@
+package vers
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Option configures how a VERS string is interpreted.
+type Option func(*options)
+
+type options struct {
+	decode     bool
+	compliance bool
+}
+
+// WithDecoding tolerates VERS strings copied verbatim from package URL
+// qualifiers. Before validation the string is trimmed, a leading "vers="
+// qualifier key and surrounding quotes are stripped, and percent-encoded
+// characters are decoded, so that "vers=vers%3Anpm%2F%3E%3D1.0.0" is treated
+// as "vers:npm/>=1.0.0".
+func WithDecoding() Option {
+	return func(o *options) {
+		o.decode = true
+	}
+}
+
+// WithCompliance rejects VERS strings that the specification considers
+// invalid but that are accepted by default: empty constraints such as the one
+// in ">=1.0.0||<2.0.0", a version used by more than one constraint, and range
+// comparators that do not alternate between lower and upper bounds once
+// sorted by version, such as ">=1.0.0|>=1.5.0".
+func WithCompliance() Option {
+	return func(o *options) {
+		o.compliance = true
+	}
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// decode normalizes a VERS string taken from a package URL qualifier.
+func decode(versString string) (string, error) {
+	s := strings.TrimSpace(versString)
+	s = strings.TrimPrefix(s, "vers=")
+	s = unquote(s)
+
+	if strings.Contains(s, "%") {
+		decoded, err := url.PathUnescape(s)
+		if err != nil {
+			return "", fmt.Errorf("invalid percent-encoding: %w", err)
+		}
+		// Quotes may themselves have been percent-encoded
+		s = unquote(strings.TrimSpace(decoded))
+	}
+
+	return s, nil
+}
+
+// unquote strips one pair of matching surrounding single or double quotes.
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
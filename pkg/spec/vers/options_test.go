@@ -0,0 +1,86 @@
+package vers
+
+import "testing"
+
+func TestContains_WithDecoding(t *testing.T) {
+	tests := []struct {
+		name      string
+		versRange string
+		version   string
+		want      bool
+		wantErr   bool
+	}{
+		{
+			name:      "plain string unchanged",
+			versRange: "vers:npm/>=1.0.0|<2.0.0",
+			version:   "1.5.0",
+			want:      true,
+		},
+		{
+			name:      "percent-encoded operators",
+			versRange: "vers:npm/%3E%3D1.0.0%7C%3C2.0.0",
+			version:   "1.5.0",
+			want:      true,
+		},
+		{
+			name:      "percent-encoded operators outside range",
+			versRange: "vers:npm/%3E%3D1.0.0%7C%3C2.0.0",
+			version:   "2.0.0",
+			want:      false,
+		},
+		{
+			name:      "fully percent-encoded",
+			versRange: "vers%3Apypi%2F%3E%3D1.0%7C%3C2.0",
+			version:   "1.5",
+			want:      true,
+		},
+		{
+			name:      "vers qualifier key",
+			versRange: "vers=vers:npm/%3E%3D1.0.0",
+			version:   "1.0.0",
+			want:      true,
+		},
+		{
+			name:      "double quoted",
+			versRange: `"vers:maven/>=1.0.0|<=2.0.0"`,
+			version:   "1.5.0",
+			want:      true,
+		},
+		{
+			name:      "single quoted with whitespace",
+			versRange: "  'vers:maven/>=1.0.0|<=2.0.0'  ",
+			version:   "2.0.1",
+			want:      false,
+		},
+		{
+			name:      "percent-encoded quotes",
+			versRange: "%22vers%3Anpm%2F%3E%3D1.0.0%22",
+			version:   "1.2.0",
+			want:      true,
+		},
+		{
+			name:      "invalid percent-encoding",
+			versRange: "vers:npm/%3G1.0.0",
+			version:   "1.0.0",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Contains(tt.versRange, tt.version, WithDecoding())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Contains() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Contains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContains_WithoutDecoding(t *testing.T) {
+	if _, err := Contains("vers:npm/%3E%3D1.0.0", "1.0.0"); err == nil {
+		t.Errorf("Contains() of percent-encoded range without WithDecoding() error = nil, want error")
+	}
+}
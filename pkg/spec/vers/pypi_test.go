@@ -288,6 +288,27 @@ func TestContains_PyPI(t *testing.T) {
 			want:      true,
 			wantErr:   false,
 		},
+		{
+			name:      "pypi prerelease exclusion - separated rc spelling",
+			versRange: "vers:pypi/>=1.0.0|<=2.0.0",
+			version:   "1.5.0_rc1",
+			want:      false,
+			wantErr:   false,
+		},
+		{
+			name:      "pypi prerelease exclusion - preview spelling",
+			versRange: "vers:pypi/>=1.0.0|<=2.0.0",
+			version:   "V1.5.0-PREVIEW1",
+			want:      false,
+			wantErr:   false,
+		},
+		{
+			name:      "pypi prerelease inclusion - separated pre spelling in constraint",
+			versRange: "vers:pypi/>=1.0.0-pre1|<=2.0.0",
+			version:   "1.5.0rc1",
+			want:      true,
+			wantErr:   false,
+		},
 		{
 			name:      "pypi local version with prerelease-like text - should be included",
 			versRange: "vers:pypi/>=1.0.0|<=2.0.0",
@@ -0,0 +1,393 @@
+package vers
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+// MinVersion and MaxVersion bound an Interval that is open-ended below or
+// above: MinVersion sorts before every version of every scheme and MaxVersion
+// after. Neither is a version, so a bound set to either is never inclusive.
+const (
+	MinVersion = "-∞"
+	MaxVersion = "+∞"
+)
+
+// Interval is a contiguous run of versions between two bounds. Parse sets
+// Lower to MinVersion or Upper to MaxVersion for a side that is unbounded, and
+// an interval whose bounds are the same inclusive version holds only that
+// version. An empty bound, as in an Interval built by hand, is unbounded too.
+//
+// An unbounded side is omitted from the JSON form, as other tools write it.
+type Interval struct {
+	Lower          string `json:"lower,omitempty"`
+	LowerInclusive bool   `json:"lower_inclusive"`
+	Upper          string `json:"upper,omitempty"`
+	UpperInclusive bool   `json:"upper_inclusive"`
+}
+
+// LowerUnbounded reports whether the interval extends down to MinVersion
+func (in Interval) LowerUnbounded() bool {
+	return in.Lower == MinVersion || in.Lower == ""
+}
+
+// UpperUnbounded reports whether the interval extends up to MaxVersion
+func (in Interval) UpperUnbounded() bool {
+	return in.Upper == MaxVersion || in.Upper == ""
+}
+
+// MarshalJSON encodes the interval, omitting its unbounded sides
+func (in Interval) MarshalJSON() ([]byte, error) {
+	type plain Interval
+	p := plain(in)
+	if in.LowerUnbounded() {
+		p.Lower, p.LowerInclusive = "", false
+	}
+	if in.UpperUnbounded() {
+		p.Upper, p.UpperInclusive = "", false
+	}
+	return json.Marshal(p)
+}
+
+// UnmarshalJSON decodes an interval, reading an omitted bound as unbounded
+func (in *Interval) UnmarshalJSON(data []byte) error {
+	type plain Interval
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	*in = newInterval(interval{
+		lower:          p.Lower,
+		lowerInclusive: p.LowerInclusive,
+		upper:          p.Upper,
+		upperInclusive: p.UpperInclusive,
+	})
+	return nil
+}
+
+// newInterval converts an interval of the interval algebra, where an empty
+// bound is unbounded, to an Interval
+func newInterval(in interval) Interval {
+	out := Interval{Lower: in.lower, LowerInclusive: in.lowerInclusive, Upper: in.upper, UpperInclusive: in.upperInclusive}
+	if out.LowerUnbounded() {
+		out.Lower, out.LowerInclusive = MinVersion, false
+	}
+	if out.UpperUnbounded() {
+		out.Upper, out.UpperInclusive = MaxVersion, false
+	}
+	return out
+}
+
+// internal converts the Interval to the interval algebra's form
+func (in Interval) internal() interval {
+	out := interval{lower: in.Lower, lowerInclusive: in.LowerInclusive, upper: in.Upper, upperInclusive: in.UpperInclusive}
+	if in.LowerUnbounded() {
+		out.lower, out.lowerInclusive = "", false
+	}
+	if in.UpperUnbounded() {
+		out.upper, out.upperInclusive = "", false
+	}
+	return out
+}
+
+// Range is a parsed VERS range: the versions within any of its intervals,
+// other than the excluded ones. Intervals are disjoint and ascending, and
+// each excluded version lies within one of them.
+//
+// A Range marshals to JSON as
+//
+//	{"scheme": "npm",
+//	 "intervals": [{"lower": "1.0.0", "lower_inclusive": true, "upper": "2.0.0", "upper_inclusive": false}],
+//	 "excludes": ["1.5.0"]}
+//
+// so tools in other languages can exchange ranges without a VERS parser.
+// Unmarshaling checks the versions against the scheme and normalizes the
+// intervals as Parse does.
+type Range struct {
+	Scheme    string     `json:"scheme"`
+	Intervals []Interval `json:"intervals"`
+	Excludes  []string   `json:"excludes,omitempty"`
+
+	// compare orders versions of the scheme; nil until parsed
+	compare func(a, b string) int
+	// compareWith parses further versions of the scheme and returns a
+	// function ordering them and the range's own; nil until parsed
+	compareWith func(versions ...string) (func(a, b string) int, error)
+}
+
+// Parse parses a VERS range into the intervals of versions it matches.
+// Example: Parse("vers:npm/>=1.0.0|!=1.5.0|<2.0.0") returns one interval
+// from 1.0.0 inclusive to 2.0.0 exclusive, with 1.5.0 excluded.
+func Parse(versRange string, opts ...Option) (*Range, error) {
+	o := newOptions(opts)
+	s, constraints, err := split(versRange, o)
+	if err != nil {
+		return nil, err
+	}
+
+	scheme, err := lookupScheme(s)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := scheme.parse(constraints, o)
+	if err != nil {
+		return nil, err
+	}
+	r.Scheme = s
+	return r, nil
+}
+
+// parser returns a function building the Range of VERS constraints for an
+// ecosystem
+func parser[V univers.Version[V], VR univers.VersionRange[V]](
+	e univers.Ecosystem[V, VR],
+) func([]string, options) (*Range, error) {
+	return func(constraints []string, o options) (*Range, error) {
+		parsed := make(map[string]V)
+		r := &Range{compare: func(a, b string) int {
+			return parsed[a].Compare(parsed[b])
+		}}
+		r.compareWith = func(versions ...string) (func(a, b string) int, error) {
+			all := maps.Clone(parsed)
+			for _, version := range versions {
+				if _, ok := all[version]; ok {
+					continue
+				}
+				v, err := e.NewVersion(version)
+				if err != nil {
+					return nil, fmt.Errorf("invalid version %q: %w", version, err)
+				}
+				all[version] = v
+			}
+			return func(a, b string) int { return all[a].Compare(all[b]) }, nil
+		}
+
+		if isStar(constraints) {
+			r.Intervals = []Interval{newInterval(interval{})}
+			return r, nil
+		}
+		if o.compliance {
+			if err := validateConstraints(e, constraints); err != nil {
+				return nil, fmt.Errorf("invalid constraints: %w", err)
+			}
+		}
+		constraints, err := normalizeConstraints(e, constraints)
+		if err != nil {
+			return nil, fmt.Errorf("failed to normalize constraints: %w", err)
+		}
+		versConstraints, err := parseConstraints(constraints)
+		if err != nil {
+			return nil, err
+		}
+		intervals, err := groupConstraintsIntoIntervals(versConstraints)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range versConstraints {
+			v, err := e.NewVersion(c.version)
+			if err != nil {
+				return nil, fmt.Errorf("invalid version in constraint '%s%s': %w", c.operator, c.version, err)
+			}
+			parsed[c.version] = v
+		}
+
+		for i, in := range intervals {
+			if in.exact != "" {
+				intervals[i] = pointInterval(in.exact)
+			}
+		}
+		merged := []interval{{}}
+		if len(intervals) > 0 {
+			merged = mergeIntervals(intervals, r.compare)
+		}
+
+		for _, in := range merged {
+			r.Intervals = append(r.Intervals, newInterval(in))
+		}
+
+		// An exclusion outside every interval removes nothing
+		for _, c := range versConstraints {
+			if c.operator != "!=" || slices.Contains(r.Excludes, c.version) {
+				continue
+			}
+			if slices.ContainsFunc(merged, func(in interval) bool { return intervalContains(in, c.version, r.compare) }) {
+				r.Excludes = append(r.Excludes, c.version)
+			}
+		}
+		slices.SortFunc(r.Excludes, r.compare)
+
+		return r, nil
+	}
+}
+
+// String returns the range as a VERS string with its constraints in version
+// order. A Range built by hand is not validated; use Parse on the result to
+// check it.
+func (r *Range) String() string {
+	type item struct {
+		text, version string
+	}
+
+	var items []item
+	for _, in := range r.Intervals {
+		switch {
+		case !in.LowerUnbounded() && in.Lower == in.Upper && in.LowerInclusive && in.UpperInclusive:
+			items = append(items, item{in.Lower, in.Lower})
+		default:
+			if !in.LowerUnbounded() {
+				items = append(items, item{lowerOperator(in.LowerInclusive) + in.Lower, in.Lower})
+			}
+			if !in.UpperUnbounded() {
+				items = append(items, item{upperOperator(in.UpperInclusive) + in.Upper, in.Upper})
+			}
+		}
+	}
+	for _, x := range r.Excludes {
+		items = append(items, item{"!=" + x, x})
+	}
+	if len(items) == 0 {
+		return "vers:" + r.Scheme + "/*"
+	}
+
+	if r.compare != nil {
+		slices.SortStableFunc(items, func(a, b item) int { return r.compare(a.version, b.version) })
+	}
+	texts := make([]string, 0, len(items))
+	for _, it := range items {
+		texts = append(texts, it.text)
+	}
+	return "vers:" + r.Scheme + "/" + strings.Join(texts, "|")
+}
+
+// Contains reports whether version lies within the range, as Contains does
+// for its VERS string. An open-ended interval holds every version beyond its
+// bound.
+func (r *Range) Contains(version string) (bool, error) {
+	r, err := r.parsed()
+	if err != nil {
+		return false, err
+	}
+	compare, err := r.compareWith(version)
+	if err != nil {
+		return false, err
+	}
+
+	if slices.ContainsFunc(r.Excludes, func(x string) bool { return compare(x, version) == 0 }) {
+		return false, nil
+	}
+	return slices.ContainsFunc(r.Intervals, func(in Interval) bool {
+		return intervalContains(in.internal(), version, compare)
+	}), nil
+}
+
+// Intersect returns the range of versions within both r and other, which
+// must use the same versioning scheme. Unbounded sides compose like any
+// other bound: the intersection of ">=1.0.0" and "<2.0.0" is
+// ">=1.0.0|<2.0.0", and that of "*" and a range is the range.
+//
+// VERS cannot express an empty range, so ranges without a version in common
+// are an error, as for Complement.
+func (r *Range) Intersect(other *Range) (*Range, error) {
+	a, err := r.parsed()
+	if err != nil {
+		return nil, err
+	}
+	b, err := other.parsed()
+	if err != nil {
+		return nil, err
+	}
+	if a.Scheme != b.Scheme {
+		return nil, fmt.Errorf("cannot intersect ranges of versioning-schemes %q and %q", a.Scheme, b.Scheme)
+	}
+
+	versions := slices.Clone(b.Excludes)
+	for _, in := range b.Intervals {
+		if !in.LowerUnbounded() {
+			versions = append(versions, in.Lower)
+		}
+		if !in.UpperUnbounded() {
+			versions = append(versions, in.Upper)
+		}
+	}
+	compare, err := a.compareWith(versions...)
+	if err != nil {
+		return nil, err
+	}
+
+	var intervals []interval
+	for _, x := range a.Intervals {
+		for _, y := range b.Intervals {
+			if in, ok := intersection(x.internal(), y.internal(), compare); ok {
+				intervals = append(intervals, in)
+			}
+		}
+	}
+
+	// An excluded version on an inclusive bound makes the bound exclusive,
+	// which empties an interval holding only that version
+	excludes := slices.Concat(a.Excludes, b.Excludes)
+	var kept []interval
+	for _, in := range mergeIntervals(intervals, compare) {
+		for _, x := range excludes {
+			if in.lower != "" && in.lowerInclusive && compare(in.lower, x) == 0 {
+				in.lowerInclusive = false
+			}
+			if in.upper != "" && in.upperInclusive && compare(in.upper, x) == 0 {
+				in.upperInclusive = false
+			}
+		}
+		if !isEmptyInterval(in, compare) {
+			kept = append(kept, in)
+		}
+	}
+	if len(kept) == 0 {
+		return nil, fmt.Errorf("intersection of %q and %q is empty", a, b)
+	}
+
+	result := &Range{Scheme: a.Scheme, compare: compare}
+	for _, in := range kept {
+		result.Intervals = append(result.Intervals, newInterval(in))
+	}
+	for _, x := range excludes {
+		if !slices.Contains(result.Excludes, x) && slices.ContainsFunc(kept, func(in interval) bool { return intervalContains(in, x, compare) }) {
+			result.Excludes = append(result.Excludes, x)
+		}
+	}
+	return Parse(result.String())
+}
+
+// parsed returns the range, or for a Range built by hand the result of
+// parsing its VERS string
+func (r *Range) parsed() (*Range, error) {
+	if r.compareWith != nil {
+		return r, nil
+	}
+	return Parse(r.String())
+}
+
+// UnmarshalJSON decodes the JSON form of a Range, whether marshaled here or
+// written by another tool, and parses it as Parse would its VERS string.
+func (r *Range) UnmarshalJSON(data []byte) error {
+	type plain Range
+	var decoded plain
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	if len(decoded.Intervals) == 0 {
+		return fmt.Errorf("vers range has no intervals")
+	}
+
+	parsed, err := Parse((*Range)(&decoded).String())
+	if err != nil {
+		return err
+	}
+	*r = *parsed
+	return nil
+}
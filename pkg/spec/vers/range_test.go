@@ -0,0 +1,273 @@
+package vers
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name          string
+		versRange     string
+		wantIntervals []Interval
+		wantExcludes  []string
+		wantString    string
+		wantErr       bool
+	}{
+		{
+			name:          "interval with exclusion",
+			versRange:     "vers:npm/>=1.0.0|!=1.5.0|<2.0.0",
+			wantIntervals: []Interval{{Lower: "1.0.0", LowerInclusive: true, Upper: "2.0.0"}},
+			wantExcludes:  []string{"1.5.0"},
+			wantString:    "vers:npm/>=1.0.0|!=1.5.0|<2.0.0",
+		},
+		{
+			name:          "unsorted constraints",
+			versRange:     "vers:npm/<2.0.0|>=1.0.0",
+			wantIntervals: []Interval{{Lower: "1.0.0", LowerInclusive: true, Upper: "2.0.0"}},
+			wantString:    "vers:npm/>=1.0.0|<2.0.0",
+		},
+		{
+			name:      "exact versions and open intervals",
+			versRange: "vers:pypi/<1.0|1.5|>2.0",
+			wantIntervals: []Interval{
+				{Lower: MinVersion, Upper: "1.0"},
+				{Lower: "1.5", LowerInclusive: true, Upper: "1.5", UpperInclusive: true},
+				{Lower: "2.0", Upper: MaxVersion},
+			},
+			wantString: "vers:pypi/<1.0|1.5|>2.0",
+		},
+		{
+			name:          "exclusion outside the intervals",
+			versRange:     "vers:npm/>=1.0.0|<2.0.0|!=3.0.0",
+			wantIntervals: []Interval{{Lower: "1.0.0", LowerInclusive: true, Upper: "2.0.0"}},
+			wantString:    "vers:npm/>=1.0.0|<2.0.0",
+		},
+		{
+			name:          "exclude-only range",
+			versRange:     "vers:deb/!=1.0-1",
+			wantIntervals: []Interval{{Lower: MinVersion, Upper: MaxVersion}},
+			wantExcludes:  []string{"1.0-1"},
+			wantString:    "vers:deb/!=1.0-1",
+		},
+		{
+			name:          "star",
+			versRange:     "vers:maven/*",
+			wantIntervals: []Interval{{Lower: MinVersion, Upper: MaxVersion}},
+			wantString:    "vers:maven/*",
+		},
+		{
+			name:          "overlapping exact versions merge",
+			versRange:     "vers:maven/1.0|>=1.0.0|<=2.0",
+			wantIntervals: []Interval{{Lower: "1.0", LowerInclusive: true, Upper: "2.0", UpperInclusive: true}},
+			wantString:    "vers:maven/>=1.0|<=2.0",
+		},
+		{name: "unsupported scheme", versRange: "vers:unknown/>=1.0", wantErr: true},
+		{name: "invalid version", versRange: "vers:npm/>=bogus", wantErr: true},
+		{name: "invalid vers string", versRange: "npm/>=1.0.0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.versRange)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got.Intervals, tt.wantIntervals) {
+				t.Errorf("Parse(%q).Intervals = %+v, want %+v", tt.versRange, got.Intervals, tt.wantIntervals)
+			}
+			if !reflect.DeepEqual(got.Excludes, tt.wantExcludes) {
+				t.Errorf("Parse(%q).Excludes = %v, want %v", tt.versRange, got.Excludes, tt.wantExcludes)
+			}
+			if s := got.String(); s != tt.wantString {
+				t.Errorf("Parse(%q).String() = %q, want %q", tt.versRange, s, tt.wantString)
+			}
+		})
+	}
+}
+
+func TestRange_JSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		versRange string
+		wantJSON  string
+	}{
+		{
+			name:      "interval with exclusion",
+			versRange: "vers:npm/>=1.0.0|!=1.5.0|<2.0.0",
+			wantJSON:  `{"scheme":"npm","intervals":[{"lower":"1.0.0","lower_inclusive":true,"upper":"2.0.0","upper_inclusive":false}],"excludes":["1.5.0"]}`,
+		},
+		{
+			name:      "open intervals",
+			versRange: "vers:pypi/<1.0|>=2.0",
+			wantJSON:  `{"scheme":"pypi","intervals":[{"lower_inclusive":false,"upper":"1.0","upper_inclusive":false},{"lower":"2.0","lower_inclusive":true,"upper_inclusive":false}]}`,
+		},
+		{
+			name:      "star",
+			versRange: "vers:generic/*",
+			wantJSON:  `{"scheme":"generic","intervals":[{"lower_inclusive":false,"upper_inclusive":false}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Parse(tt.versRange)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			data, err := json.Marshal(r)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			if string(data) != tt.wantJSON {
+				t.Errorf("json.Marshal() = %s, want %s", data, tt.wantJSON)
+			}
+
+			var got Range
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if got.String() != r.String() {
+				t.Errorf("round trip = %q, want %q", got.String(), r.String())
+			}
+		})
+	}
+}
+
+func TestRange_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "normalizes overlapping intervals",
+			data: `{"scheme":"npm","intervals":[{"lower":"1.0.0","lower_inclusive":true,"upper":"2.0.0"},{"lower":"1.5.0","lower_inclusive":true,"upper":"3.0.0"}]}`,
+			want: "vers:npm/>=1.0.0|<3.0.0",
+		},
+		{
+			name: "exclusions only",
+			data: `{"scheme":"npm","intervals":[{}],"excludes":["1.5.0"]}`,
+			want: "vers:npm/!=1.5.0",
+		},
+		{
+			name: "unbounded sides as sentinels",
+			data: `{"scheme":"npm","intervals":[{"lower":"-∞","upper":"2.0.0"},{"lower":"3.0.0","upper":"+∞"}]}`,
+			want: "vers:npm/<2.0.0|>3.0.0",
+		},
+		{name: "no intervals", data: `{"scheme":"npm"}`, wantErr: true},
+		{name: "invalid version", data: `{"scheme":"npm","intervals":[{"lower":"bogus"}]}`, wantErr: true},
+		{name: "unsupported scheme", data: `{"scheme":"unknown","intervals":[{}]}`, wantErr: true},
+		{name: "malformed", data: `{"scheme":`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Range
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("json.Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.String() != tt.want {
+				t.Errorf("json.Unmarshal() = %q, want %q", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRange_Contains(t *testing.T) {
+	tests := []struct {
+		name    string
+		r       *Range
+		version string
+		want    bool
+		wantErr bool
+	}{
+		{name: "open above", r: mustParse(t, "vers:npm/>=1.0.0"), version: "99.0.0", want: true},
+		{name: "below open above", r: mustParse(t, "vers:npm/>=1.0.0"), version: "0.9.0", want: false},
+		{name: "open below", r: mustParse(t, "vers:pypi/<1.0"), version: "0.0.1", want: true},
+		{name: "star", r: mustParse(t, "vers:maven/*"), version: "1.0", want: true},
+		{name: "excluded", r: mustParse(t, "vers:npm/>=1.0.0|!=1.5.0|<2.0.0"), version: "1.5.0", want: false},
+		{
+			name:    "built with sentinels",
+			r:       &Range{Scheme: "npm", Intervals: []Interval{{Lower: "1.0.0", LowerInclusive: true, Upper: MaxVersion}}},
+			version: "3.0.0",
+			want:    true,
+		},
+		{
+			name:    "built with empty bounds",
+			r:       &Range{Scheme: "npm", Intervals: []Interval{{Upper: "1.0.0"}}},
+			version: "1.0.0",
+			want:    false,
+		},
+		{name: "invalid version", r: mustParse(t, "vers:npm/>=1.0.0"), version: "bogus", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.r.Contains(tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Range.Contains(%q) error = %v, wantErr %v", tt.version, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Range.Contains(%q) = %v, want %v", tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRange_Intersect(t *testing.T) {
+	tests := []struct {
+		name    string
+		r       string
+		other   string
+		want    string
+		wantErr bool
+	}{
+		{name: "open-ended sides", r: "vers:npm/>=1.0.0", other: "vers:npm/<2.0.0", want: "vers:npm/>=1.0.0|<2.0.0"},
+		{name: "both open above", r: "vers:npm/>=1.0.0", other: "vers:npm/>2.0.0", want: "vers:npm/>2.0.0"},
+		{name: "star", r: "vers:pypi/*", other: "vers:pypi/>=1.0|<2.0", want: "vers:pypi/>=1.0|<2.0"},
+		{name: "star with star", r: "vers:maven/*", other: "vers:maven/*", want: "vers:maven/*"},
+		{
+			name:  "several intervals",
+			r:     "vers:npm/<1.0.0|>=2.0.0",
+			other: "vers:npm/>=0.5.0|<3.0.0",
+			want:  "vers:npm/>=0.5.0|<1.0.0|>=2.0.0|<3.0.0",
+		},
+		{name: "exclusion kept", r: "vers:npm/>=1.0.0|!=1.5.0", other: "vers:npm/<2.0.0", want: "vers:npm/>=1.0.0|!=1.5.0|<2.0.0"},
+		{name: "exclusion on a bound", r: "vers:npm/!=1.0.0", other: "vers:npm/>=1.0.0", want: "vers:npm/>1.0.0"},
+		{name: "exclusion of an exact version", r: "vers:npm/!=1.0.0", other: "vers:npm/1.0.0|2.0.0", want: "vers:npm/2.0.0"},
+		{name: "touching bounds", r: "vers:npm/<=1.0.0", other: "vers:npm/>=1.0.0", want: "vers:npm/1.0.0"},
+		{name: "disjoint", r: "vers:npm/<1.0.0", other: "vers:npm/>=1.0.0", wantErr: true},
+		{name: "different schemes", r: "vers:npm/>=1.0.0", other: "vers:pypi/>=1.0.0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mustParse(t, tt.r).Intersect(mustParse(t, tt.other))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Range.Intersect() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.String() != tt.want {
+				t.Errorf("Range.Intersect() = %q, want %q", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func mustParse(t *testing.T, versRange string) *Range {
+	t.Helper()
+	r, err := Parse(versRange)
+	if err != nil {
+		t.Fatalf("Parse(%q) error = %v", versRange, err)
+	}
+	return r
+}
@@ -0,0 +1,248 @@
+package vers
+
+import (
+	"fmt"
+
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+// Overlaps reports whether some version satisfies both VERS ranges, for
+// detecting conflicting policies or advisories that affect the same releases.
+// Both ranges must use the same versioning scheme.
+// Example: Overlaps("vers:npm/>=1.0.0|<2.0.0", "vers:npm/>=1.5.0") returns true.
+//
+// Ranges are compared as sets of intervals, assuming a version exists between
+// any two distinct versions. ">1.0.0|<1.0.1" therefore overlaps "vers:npm/*"
+// even though no release may fall between the bounds.
+func Overlaps(range1, range2 string, opts ...Option) (bool, error) {
+	sets, compare, err := relate(range1, range2, opts)
+	if err != nil {
+		return false, err
+	}
+
+	for _, a := range sets[0] {
+		for _, b := range sets[1] {
+			if intersects(a, b, compare) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// Covers reports whether range1 matches every version range2 matches, for
+// merging advisories or checking that a policy subsumes another. Both ranges
+// must use the same versioning scheme, and are compared as for Overlaps.
+// Example: Covers("vers:npm/>=1.0.0|<2.0.0", "vers:npm/>=1.2.0|<1.3.0") returns true.
+func Covers(range1, range2 string, opts ...Option) (bool, error) {
+	sets, compare, err := relate(range1, range2, opts)
+	if err != nil {
+		return false, err
+	}
+
+	for _, b := range sets[1] {
+		covered := false
+		for _, a := range sets[0] {
+			if encloses(a, b, compare) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// relate splits two VERS ranges of one scheme into the disjoint intervals of
+// versions each matches, with a function comparing their bound versions
+func relate(range1, range2 string, opts []Option) ([2][]interval, func(a, b string) int, error) {
+	o := newOptions(opts)
+	s1, constraints1, err := split(range1, o)
+	if err != nil {
+		return [2][]interval{}, nil, err
+	}
+	s2, constraints2, err := split(range2, o)
+	if err != nil {
+		return [2][]interval{}, nil, err
+	}
+	if s1 != s2 {
+		return [2][]interval{}, nil, fmt.Errorf("cannot relate ranges of versioning-schemes %q and %q", s1, s2)
+	}
+
+	scheme, err := lookupScheme(s1)
+	if err != nil {
+		return [2][]interval{}, nil, err
+	}
+	return scheme.relate([2][]string{constraints1, constraints2}, o)
+}
+
+// relater returns a function computing the matched intervals of VERS
+// constraints for an ecosystem
+func relater[V univers.Version[V], VR univers.VersionRange[V]](
+	e univers.Ecosystem[V, VR],
+) func([2][]string, options) ([2][]interval, func(a, b string) int, error) {
+	return func(constraintSets [2][]string, o options) ([2][]interval, func(a, b string) int, error) {
+		var sets [2][]interval
+		parsed := make(map[string]V)
+		compare := func(a, b string) int {
+			return parsed[a].Compare(parsed[b])
+		}
+
+		for i, constraints := range constraintSets {
+			if isStar(constraints) {
+				sets[i] = []interval{{}}
+				continue
+			}
+			if o.compliance {
+				if err := validateConstraints(e, constraints); err != nil {
+					return sets, nil, fmt.Errorf("invalid constraints: %w", err)
+				}
+			}
+			constraints, err := normalizeConstraints(e, constraints)
+			if err != nil {
+				return sets, nil, fmt.Errorf("failed to normalize constraints: %w", err)
+			}
+			sets[i], err = matchedIntervals(e, constraints, parsed, compare)
+			if err != nil {
+				return sets, nil, err
+			}
+		}
+		return sets, compare, nil
+	}
+}
+
+// matchedIntervals returns the disjoint, ascending intervals of versions
+// matched by normalized constraints: the union of their intervals and exact
+// versions, or every version if there are none, less any "!=" exclusions.
+// Each constraint version is parsed into parsed, which compare reads.
+func matchedIntervals[V univers.Version[V], VR univers.VersionRange[V]](
+	e univers.Ecosystem[V, VR],
+	constraints []string,
+	parsed map[string]V,
+	compare func(a, b string) int,
+) ([]interval, error) {
+	versConstraints, err := parseConstraints(constraints)
+	if err != nil {
+		return nil, err
+	}
+	intervals, err := groupConstraintsIntoIntervals(versConstraints)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range versConstraints {
+		v, err := e.NewVersion(c.version)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version in constraint '%s%s': %w", c.operator, c.version, err)
+		}
+		parsed[c.version] = v
+	}
+
+	for i, in := range intervals {
+		if in.exact != "" {
+			intervals[i] = pointInterval(in.exact)
+		}
+	}
+
+	matched := []interval{{}}
+	if len(intervals) > 0 {
+		matched = mergeIntervals(intervals, compare)
+	}
+
+	// Split the interval holding each excluded version around it
+	for _, c := range versConstraints {
+		if c.operator != "!=" {
+			continue
+		}
+		var split []interval
+		for _, in := range matched {
+			if !intervalContains(in, c.version, compare) {
+				split = append(split, in)
+				continue
+			}
+			below := interval{lower: in.lower, lowerInclusive: in.lowerInclusive, upper: c.version}
+			above := interval{lower: c.version, upper: in.upper, upperInclusive: in.upperInclusive}
+			for _, part := range []interval{below, above} {
+				if !isEmptyInterval(part, compare) {
+					split = append(split, part)
+				}
+			}
+		}
+		matched = split
+	}
+
+	return matched, nil
+}
+
+// isEmptyInterval reports whether no version lies within the interval
+func isEmptyInterval(in interval, compare func(a, b string) int) bool {
+	if in.lower == "" || in.upper == "" {
+		return false
+	}
+	c := compare(in.lower, in.upper)
+	return c > 0 || (c == 0 && !(in.lowerInclusive && in.upperInclusive))
+}
+
+// intersects reports whether some version lies within both intervals
+func intersects(a, b interval, compare func(a, b string) int) bool {
+	_, ok := intersection(a, b, compare)
+	return ok
+}
+
+// intersection returns the versions within both intervals, and whether there
+// are any
+func intersection(a, b interval, compare func(a, b string) int) (interval, bool) {
+	lower, lowerInclusive := a.lower, a.lowerInclusive
+	if b.lower != "" {
+		c := 1
+		if lower != "" {
+			c = compare(b.lower, lower)
+		}
+		if c > 0 {
+			lower, lowerInclusive = b.lower, b.lowerInclusive
+		} else if c == 0 {
+			lowerInclusive = lowerInclusive && b.lowerInclusive
+		}
+	}
+
+	upper, upperInclusive := a.upper, a.upperInclusive
+	if b.upper != "" {
+		c := -1
+		if upper != "" {
+			c = compare(b.upper, upper)
+		}
+		if c < 0 {
+			upper, upperInclusive = b.upper, b.upperInclusive
+		} else if c == 0 {
+			upperInclusive = upperInclusive && b.upperInclusive
+		}
+	}
+
+	in := interval{lower: lower, lowerInclusive: lowerInclusive, upper: upper, upperInclusive: upperInclusive}
+	return in, !isEmptyInterval(in, compare)
+}
+
+// encloses reports whether every version within inner lies within outer
+func encloses(outer, inner interval, compare func(a, b string) int) bool {
+	if outer.lower != "" {
+		if inner.lower == "" {
+			return false
+		}
+		c := compare(outer.lower, inner.lower)
+		if c > 0 || (c == 0 && !outer.lowerInclusive && inner.lowerInclusive) {
+			return false
+		}
+	}
+	if outer.upper != "" {
+		if inner.upper == "" {
+			return false
+		}
+		c := compare(outer.upper, inner.upper)
+		if c < 0 || (c == 0 && !outer.upperInclusive && inner.upperInclusive) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+package vers
+
+import "testing"
+
+func TestOverlaps(t *testing.T) {
+	tests := []struct {
+		name    string
+		range1  string
+		range2  string
+		want    bool
+		wantErr bool
+	}{
+		{name: "nested", range1: "vers:npm/>=1.0.0|<2.0.0", range2: "vers:npm/>=1.2.0|<1.3.0", want: true},
+		{name: "partial", range1: "vers:npm/>=1.0.0|<2.0.0", range2: "vers:npm/>=1.5.0", want: true},
+		{name: "disjoint", range1: "vers:npm/<1.0.0", range2: "vers:npm/>=2.0.0", want: false},
+		{name: "touching exclusive bound", range1: "vers:npm/<1.0.0", range2: "vers:npm/>=1.0.0", want: false},
+		{name: "touching inclusive bounds", range1: "vers:npm/<=1.0.0", range2: "vers:npm/>=1.0.0", want: true},
+		{name: "exact inside interval", range1: "vers:npm/1.5.0", range2: "vers:npm/>=1.0.0|<2.0.0", want: true},
+		{name: "exact outside interval", range1: "vers:npm/2.5.0", range2: "vers:npm/>=1.0.0|<2.0.0", want: false},
+		{name: "exact excluded", range1: "vers:npm/1.5.0", range2: "vers:npm/>=1.0.0|<2.0.0|!=1.5.0", want: false},
+		{name: "exclusion leaves neighbours", range1: "vers:npm/>=1.5.0|<=1.6.0", range2: "vers:npm/>=1.0.0|<2.0.0|!=1.5.0", want: true},
+		{name: "exclude-only range", range1: "vers:npm/!=1.5.0", range2: "vers:npm/1.5.0", want: false},
+		{name: "second interval", range1: "vers:npm/<1.0.0|>=3.0.0", range2: "vers:npm/>=3.5.0|<4.0.0", want: true},
+		{name: "star", range1: "vers:npm/*", range2: "vers:npm/>=9.0.0", want: true},
+		{name: "scheme ordering", range1: "vers:deb/>=1:1.0", range2: "vers:deb/<2.0", want: false},
+		{name: "equivalent spellings", range1: "vers:maven/1.0", range2: "vers:maven/>=1.0.0|<=1.0.0", want: true},
+		{name: "pypi prerelease bound", range1: "vers:pypi/<2.0", range2: "vers:pypi/>=2.0rc1", want: true},
+		{name: "different schemes", range1: "vers:npm/>=1.0.0", range2: "vers:pypi/>=1.0", wantErr: true},
+		{name: "unsupported scheme", range1: "vers:unknown/>=1.0", range2: "vers:unknown/>=1.0", wantErr: true},
+		{name: "invalid range", range1: "vers:npm/>=1.0.0", range2: "npm/>=1.0.0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Overlaps(tt.range1, tt.range2)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Overlaps() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Overlaps(%q, %q) = %v, want %v", tt.range1, tt.range2, got, tt.want)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got, _ := Overlaps(tt.range2, tt.range1); got != tt.want {
+				t.Errorf("Overlaps(%q, %q) = %v, want %v", tt.range2, tt.range1, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCovers(t *testing.T) {
+	tests := []struct {
+		name    string
+		range1  string
+		range2  string
+		want    bool
+		wantErr bool
+	}{
+		{name: "nested", range1: "vers:npm/>=1.0.0|<2.0.0", range2: "vers:npm/>=1.2.0|<1.3.0", want: true},
+		{name: "reverse nested", range1: "vers:npm/>=1.2.0|<1.3.0", range2: "vers:npm/>=1.0.0|<2.0.0", want: false},
+		{name: "equal", range1: "vers:npm/>=1.0.0|<2.0.0", range2: "vers:npm/>=1.0.0|<2.0.0", want: true},
+		{name: "inclusive upper not covered", range1: "vers:npm/>=1.0.0|<2.0.0", range2: "vers:npm/>=1.0.0|<=2.0.0", want: false},
+		{name: "exclusive lower covered", range1: "vers:npm/>=1.0.0", range2: "vers:npm/>1.0.0", want: true},
+		{name: "partial overlap", range1: "vers:npm/>=1.0.0|<2.0.0", range2: "vers:npm/>=1.5.0", want: false},
+		{name: "exclusion breaks cover", range1: "vers:npm/>=1.0.0|<2.0.0|!=1.5.0", range2: "vers:npm/>=1.2.0|<1.8.0", want: false},
+		{name: "exclusion outside covered range", range1: "vers:npm/>=1.0.0|<2.0.0|!=1.9.0", range2: "vers:npm/>=1.2.0|<1.8.0", want: true},
+		{name: "exclusions on both sides", range1: "vers:npm/>=1.0.0|<2.0.0|!=1.5.0", range2: "vers:npm/>=1.2.0|<1.8.0|!=1.5.0", want: true},
+		{name: "multiple intervals", range1: "vers:npm/<1.0.0|>=3.0.0", range2: "vers:npm/0.5.0|>=3.1.0|<3.2.0", want: true},
+		{name: "interval spanning gap", range1: "vers:npm/<1.0.0|>=3.0.0", range2: "vers:npm/>=0.5.0|<3.5.0", want: false},
+		{name: "star covers everything", range1: "vers:npm/*", range2: "vers:npm/<1.0.0|>=3.0.0", want: true},
+		{name: "nothing short of star covers star", range1: "vers:npm/>=0.0.0", range2: "vers:npm/*", want: false},
+		{name: "exclude-only covers exact elsewhere", range1: "vers:npm/!=1.5.0", range2: "vers:npm/1.4.0", want: true},
+		{name: "scheme equality", range1: "vers:maven/>=1.0|<2.0", range2: "vers:maven/1.0.0", want: true},
+		{name: "different schemes", range1: "vers:npm/>=1.0.0", range2: "vers:gem/>=1.0.0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Covers(tt.range1, tt.range2)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Covers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Covers(%q, %q) = %v, want %v", tt.range1, tt.range2, got, tt.want)
+			}
+		})
+	}
+}
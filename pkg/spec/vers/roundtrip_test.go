@@ -0,0 +1,128 @@
+package vers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/cargo"
+	"github.com/alowayed/go-univers/pkg/ecosystem/composer"
+	"github.com/alowayed/go-univers/pkg/ecosystem/gem"
+	"github.com/alowayed/go-univers/pkg/ecosystem/nuget"
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+// roundTripRanges are checked in every round-trip scheme. They cover each
+// shape of interval an emitter receives: exact, bounded on one or both sides
+// with either inclusivity, several intervals, and exclusions.
+var roundTripRanges = []string{
+	"=1.5.0",
+	"1.0.0|1.5.0|2.0.0",
+	">=1.0.0",
+	">1.0.0",
+	"<=2.0.0",
+	"<2.0.0",
+	">=1.0.0|<2.0.0",
+	">1.0.0|<=2.0.0",
+	">1.0.0|<2.0.0",
+	">=1.0.0|<=2.0.0",
+	">=1.0.0|<1.5.0|>=2.0.0|<3.0.0",
+	"<1.0.0|>=2.0.0",
+	">=1.0.0|!=1.5.0|<2.0.0",
+	"!=1.5.0",
+}
+
+// roundTripVersions sit on, between and outside the bounds of
+// roundTripRanges
+var roundTripVersions = []string{
+	"0.9.0", "1.0.0", "1.0.1", "1.4.9", "1.5.0", "1.5.1", "1.9.9", "2.0.0", "2.0.1", "2.5.0", "3.0.0", "3.1.0",
+}
+
+func TestToRanges_RoundTrip(t *testing.T) {
+	t.Run("cargo", func(t *testing.T) { testRoundTrip(t, "cargo", &cargo.Ecosystem{}, intervalToCargoRanges) })
+	t.Run("composer", func(t *testing.T) { testRoundTrip(t, "composer", &composer.Ecosystem{}, intervalToComposerRanges) })
+	t.Run("gem", func(t *testing.T) { testRoundTrip(t, "gem", &gem.Ecosystem{}, intervalToGemRanges) })
+	t.Run("nuget", func(t *testing.T) { testRoundTrip(t, "nuget", &nuget.Ecosystem{}, intervalToNugetRanges) })
+}
+
+// testRoundTrip checks that the native ranges emit builds from each interval
+// of roundTripRanges contain exactly the versions the interval's bounds
+// admit, and that Contains on the VERS range agrees with the intervals
+func testRoundTrip[V univers.Version[V], VR univers.VersionRange[V]](
+	t *testing.T,
+	scheme string,
+	e univers.Ecosystem[V, VR],
+	emit func(interval) []string,
+) {
+	t.Helper()
+	compare := versionCompare(e)
+
+	for _, constraintStr := range roundTripRanges {
+		versRange := "vers:" + scheme + "/" + constraintStr
+		constraints, err := parseConstraints(strings.Split(constraintStr, "|"))
+		if err != nil {
+			t.Fatalf("parseConstraints(%q) error = %v", constraintStr, err)
+		}
+		intervals, err := groupConstraintsIntoIntervals(constraints)
+		if err != nil {
+			t.Fatalf("groupConstraintsIntoIntervals(%q) error = %v", constraintStr, err)
+		}
+
+		for _, versionStr := range roundTripVersions {
+			v, err := e.NewVersion(versionStr)
+			if err != nil {
+				t.Fatalf("NewVersion(%q) error = %v", versionStr, err)
+			}
+
+			excluded := false
+			for _, c := range constraints {
+				if c.operator == "!=" && compare(versionStr, c.version) == 0 {
+					excluded = true
+				}
+			}
+
+			want, matched := false, false
+			for _, iv := range intervals {
+				if iv.exclude != "" {
+					continue
+				}
+				in := intervalContains(iv, versionStr, compare)
+				if iv.exact != "" {
+					in = compare(versionStr, iv.exact) == 0
+				}
+				matched = true
+				want = want || in
+
+				for _, rangeStr := range emit(iv) {
+					r, err := e.NewVersionRange(rangeStr)
+					if err != nil {
+						t.Fatalf("%s: NewVersionRange(%q) error = %v", versRange, rangeStr, err)
+					}
+					if got := r.Contains(v); got != in {
+						t.Errorf("%s: native range %q Contains(%q) = %v, want %v", versRange, rangeStr, versionStr, got, in)
+					}
+				}
+			}
+			// A range of only exclusions matches every other version
+			if !matched {
+				want = true
+			}
+			want = want && !excluded
+
+			got, err := Contains(versRange, versionStr)
+			if err != nil {
+				t.Fatalf("Contains(%q, %q) error = %v", versRange, versionStr, err)
+			}
+			if got != want {
+				t.Errorf("Contains(%q, %q) = %v, want %v", versRange, versionStr, got, want)
+			}
+		}
+	}
+}
+
+// versionCompare compares two version strings of e, which the test has
+// already checked parse
+func versionCompare[V univers.Version[V], VR univers.VersionRange[V]](e univers.Ecosystem[V, VR]) func(a, b string) int {
+	return func(a, b string) int {
+		return univers.MustNewVersion(e, a).Compare(univers.MustNewVersion(e, b))
+	}
+}
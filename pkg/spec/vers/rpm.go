@@ -3,16 +3,8 @@ package vers
 import (
 	"fmt"
 	"strings"
-
-	"github.com/alowayed/go-univers/pkg/ecosystem/rpm"
 )
 
-// rpmContains implements VERS constraint checking for RPM ecosystem
-func rpmContains(constraints []string, version string) (bool, error) {
-	e := &rpm.Ecosystem{}
-	return contains(e, constraints, version)
-}
-
 // intervalToRpmRanges converts an interval to RPM range syntax
 func intervalToRpmRanges(interval interval) []string {
 	// Handle exact matches
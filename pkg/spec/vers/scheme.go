@@ -0,0 +1,89 @@
+package vers
+
+import (
+	"fmt"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/alpine"
+	"github.com/alowayed/go-univers/pkg/ecosystem/cargo"
+	"github.com/alowayed/go-univers/pkg/ecosystem/composer"
+	"github.com/alowayed/go-univers/pkg/ecosystem/debian"
+	"github.com/alowayed/go-univers/pkg/ecosystem/gem"
+	"github.com/alowayed/go-univers/pkg/ecosystem/golang"
+	"github.com/alowayed/go-univers/pkg/ecosystem/luarocks"
+	"github.com/alowayed/go-univers/pkg/ecosystem/maven"
+	"github.com/alowayed/go-univers/pkg/ecosystem/npm"
+	"github.com/alowayed/go-univers/pkg/ecosystem/nuget"
+	"github.com/alowayed/go-univers/pkg/ecosystem/pypi"
+	"github.com/alowayed/go-univers/pkg/ecosystem/rpm"
+	"github.com/alowayed/go-univers/pkg/ecosystem/semver"
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+// versScheme holds the operations on VERS constraints of one supported
+// versioning scheme, bound to the ecosystem that implements it
+type versScheme struct {
+	// name is the VERS versioning-scheme, such as "deb" for Debian
+	name       string
+	valid      func(version string) bool
+	validate   func([]string, options) error
+	compile    func([]string, options) (matcher, error)
+	complement func([]string, options) ([]string, error)
+	relate     func([2][]string, options) ([2][]interval, func(a, b string) int, error)
+	parse      func([]string, options) (*Range, error)
+}
+
+// newVersScheme binds the VERS operations to ecosystem e, whose versions
+// valid accepts
+func newVersScheme[V univers.Version[V], VR univers.VersionRange[V]](
+	name string,
+	e univers.Ecosystem[V, VR],
+	valid func(string) bool,
+) versScheme {
+	return versScheme{
+		name:       name,
+		valid:      valid,
+		validate:   validator(e),
+		compile:    compiler(e),
+		complement: complementer(e),
+		relate:     relater(e),
+		parse:      parser(e),
+	}
+}
+
+// schemes holds every supported versioning scheme, keyed by the ecosystem
+// that implements it
+var schemes = map[univers.EcosystemName]versScheme{
+	univers.Alpine:   newVersScheme("alpine", &alpine.Ecosystem{}, alpine.ValidVersion),
+	univers.Cargo:    newVersScheme("cargo", &cargo.Ecosystem{}, cargo.ValidVersion),
+	univers.Composer: newVersScheme("composer", &composer.Ecosystem{}, composer.ValidVersion),
+	univers.Debian:   newVersScheme("deb", &debian.Ecosystem{}, debian.ValidVersion),
+	univers.Gem:      newVersScheme("gem", &gem.Ecosystem{}, gem.ValidVersion),
+	univers.Golang:   newVersScheme("golang", &golang.Ecosystem{}, golang.ValidVersion),
+	univers.LuaRocks: newVersScheme("luarocks", &luarocks.Ecosystem{}, luarocks.ValidVersion),
+	univers.Maven:    newVersScheme("maven", &maven.Ecosystem{}, maven.ValidVersion),
+	univers.NPM:      newVersScheme("npm", &npm.Ecosystem{}, npm.ValidVersion),
+	univers.NuGet:    newVersScheme("nuget", &nuget.Ecosystem{}, nuget.ValidVersion),
+	univers.PyPI:     pypiScheme(),
+	univers.RPM:      newVersScheme("rpm", &rpm.Ecosystem{}, rpm.ValidVersion),
+	// 'generic' is the correct VERS scheme for semver
+	univers.SemVer: newVersScheme("generic", &semver.Ecosystem{}, semver.ValidVersion),
+}
+
+// pypiScheme is the pypi versioning scheme, which compiles with PEP 440
+// prerelease exclusion
+func pypiScheme() versScheme {
+	s := newVersScheme("pypi", &pypi.Ecosystem{}, pypi.ValidVersion)
+	s.compile = pypiCompile
+	return s
+}
+
+// lookupScheme returns the supported versioning scheme called name. Only the
+// VERS name of a scheme is accepted, so "deb" is but "debian" is not.
+func lookupScheme(name string) (versScheme, error) {
+	if n, ok := univers.ParseEcosystemName(name); ok {
+		if s, ok := schemes[n]; ok && s.name == name {
+			return s, nil
+		}
+	}
+	return versScheme{}, fmt.Errorf("versioning-scheme %q %w", name, ErrUnsupportedScheme)
+}
@@ -3,16 +3,8 @@ package vers
 import (
 	"fmt"
 	"strings"
-
-	"github.com/alowayed/go-univers/pkg/ecosystem/semver"
 )
 
-// semverContains implements VERS constraint checking for generic SemVer ecosystem
-func semverContains(constraints []string, version string) (bool, error) {
-	e := &semver.Ecosystem{}
-	return contains(e, constraints, version)
-}
-
 // intervalToSemverRanges converts an interval to SemVer range syntax
 func intervalToSemverRanges(interval interval) []string {
 	// Handle exact matches
@@ -9,13 +9,32 @@
 //	vers:pypi/>=1.2.3|<=2.0.0
 //	vers:golang/>=v1.2.3|<=v2.0.0
 //
-// Supported ecosystems: alpine, cargo, deb, gem, maven, npm, nuget, pypi, rpm, generic, golang
-// Supported operators: >=, <=, >, <, =, !=
+// Supported ecosystems: alpine, cargo, deb, gem, luarocks, maven, npm, nuget, pypi, rpm, generic, golang
+// Supported operators: >=, <=, >, <, =, !=. A version without an operator means =.
+//
+// Versions are matched with the scheme's own comparison, never by spelling:
+// "!=1.5" excludes the Maven version "1.5.0" and "!=1.5.0" excludes the npm
+// version "1.5.0+build". A range of only "!=" constraints matches every
+// version except the excluded ones. A range of only "=" constraints, like the
+// lists of affected versions advisories publish, is matched by set membership,
+// so enumerations of thousands of versions stay cheap to check.
+//
+// By default some VERS strings that the specification rejects are tolerated,
+// such as empty constraints or consecutive lower bounds. WithCompliance
+// rejects them; the vectors in testdata/compliance exercise that mode.
+//
+// Two rules of the specification apply in every mode and changed results
+// from earlier releases: a version without an operator, such as "1.0.0", is
+// an "=" constraint rather than an error, and range constraints that
+// alternate between lower and upper bounds once sorted pair up in order, so
+// "vers:npm/<1.0.0|>=2.0.0" contains 0.5.0 and 2.5.0 where it used to contain
+// neither.
 //
 // This package provides stateless functions for working with VERS notation.
 package vers
 
 import (
+	"errors"
 	"fmt"
 	"slices"
 	"strings"
@@ -133,9 +152,12 @@ func normalizeConstraints[V univers.Version[V], VR univers.VersionRange[V]](
 	}
 
 	var vcs []versionConstraint
+	var errs []error
 	seen := make(map[string]bool) // Track unique constraint strings
 
-	for _, c := range constraints {
+	for i, c := range constraints {
+		written := strings.TrimSpace(c)
+
 		// VERS spec: Remove all whitespace (not significant)
 		c = strings.Map(func(r rune) rune {
 			if unicode.IsSpace(r) {
@@ -172,12 +194,20 @@ func normalizeConstraints[V univers.Version[V], VR univers.VersionRange[V]](
 			}
 		}
 
+		// VERS spec: A version without a comparator means equality
 		if operator == "" {
-			return nil, fmt.Errorf("invalid constraint format '%s': no operator found", c)
+			if err := checkBareVersion(c); err != nil {
+				errs = append(errs, &ConstraintError{Index: i, Constraint: written, Err: fmt.Errorf("invalid constraint format: %w", err)})
+				continue
+			}
+			operator = "="
+			versionStr = c
+			c = operator + c
 		}
 
 		if versionStr == "" {
-			return nil, fmt.Errorf("invalid constraint format '%s': missing version", c)
+			errs = append(errs, &ConstraintError{Index: i, Constraint: written, Err: fmt.Errorf("missing version")})
+			continue
 		}
 
 		// VERS spec: Ensure versions are unique - use full constraint string as key
@@ -187,7 +217,8 @@ func normalizeConstraints[V univers.Version[V], VR univers.VersionRange[V]](
 
 		v, err := e.NewVersion(versionStr)
 		if err != nil {
-			return nil, fmt.Errorf("invalid version in constraint '%s': %w", c, err)
+			errs = append(errs, &ConstraintError{Index: i, Constraint: written, Err: fmt.Errorf("invalid version: %w", err)})
+			continue
 		}
 
 		vcs = append(vcs, versionConstraint{
@@ -196,6 +227,9 @@ func normalizeConstraints[V univers.Version[V], VR univers.VersionRange[V]](
 		})
 		seen[c] = true
 	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
 
 	if len(vcs) == 0 {
 		return []string{}, nil
@@ -228,62 +262,76 @@ func normalizeConstraints[V univers.Version[V], VR univers.VersionRange[V]](
 	return sorted, nil
 }
 
-// contains implements VERS constraint checking for a given ecosystem.
-func contains[V univers.Version[V], VR univers.VersionRange[V]](
+// validateConstraints applies the VERS specification's validation rules that
+// the lenient default tolerates: each version occurs in only one constraint,
+// and once sorted by version the range
+// comparators, ignoring "=" and "!=", alternate between greater-than and
+// less-than.
+func validateConstraints[V univers.Version[V], VR univers.VersionRange[V]](
 	e univers.Ecosystem[V, VR],
 	constraints []string,
-	version string,
-) (bool, error) {
-	// Parse the version using the ecosystem
-	v, err := e.NewVersion(version)
-	if err != nil {
-		return false, fmt.Errorf("invalid %s version '%s': %w", e.Name(), version, err)
-	}
-
-	constraints, err = normalizeConstraints(e, constraints)
-	if err != nil {
-		return false, fmt.Errorf("failed to normalize constraints: %w", err)
-	}
-
-	// Parse VERS constraints and convert to ecosystem ranges
-	ranges, err := toRanges(e, constraints)
-	if err != nil {
-		return false, fmt.Errorf("failed to convert VERS constraints: %w", err)
+) error {
+	type parsedConstraint struct {
+		constraint
+		parsed V
 	}
 
-	// Parse constraints to check for excludes
-	versConstraints, err := parseConstraints(constraints)
-	if err != nil {
-		return false, fmt.Errorf("failed to parse constraints for exclusion check: %w", err)
-	}
-
-	// Check if version is excluded by any != constraints
-	for _, constraint := range versConstraints {
-		if constraint.operator == "!=" {
-			excludedV, err := e.NewVersion(constraint.version)
-			if err != nil {
-				return false, fmt.Errorf("invalid version in exclusion constraint '%s': %w", constraint.version, err)
-			}
-			if v.Compare(excludedV) == 0 {
-				return false, nil // Version is explicitly excluded
+	parsed := make([]parsedConstraint, 0, len(constraints))
+	var errs []error
+	for i, c := range constraints {
+		written := strings.TrimSpace(c)
+		c = strings.Map(func(r rune) rune {
+			if unicode.IsSpace(r) {
+				return -1
 			}
+			return r
+		}, c)
+		if c == "" || c == "*" {
+			continue
 		}
-	}
 
-	// VERS interval logic: version satisfies range if it's in ANY interval
-	// If there are no range intervals (only excludes), then version is allowed if not excluded
-	if len(ranges) == 0 {
-		return true, nil // No ranges means all versions allowed (except excludes, which we already checked)
+		pc, err := parseConstraint(c)
+		if err != nil {
+			errs = append(errs, &ConstraintError{Index: i, Constraint: written, Err: err})
+			continue
+		}
+		v, err := e.NewVersion(pc.version)
+		if err != nil {
+			errs = append(errs, &ConstraintError{Index: i, Constraint: written, Err: fmt.Errorf("invalid version: %w", err)})
+			continue
+		}
+		parsed = append(parsed, parsedConstraint{constraint: pc, parsed: v})
 	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	slices.SortStableFunc(parsed, func(a, b parsedConstraint) int {
+		return a.parsed.Compare(b.parsed)
+	})
 
-	// Check if version is in any allowed range
-	for _, r := range ranges {
-		if r.Contains(v) {
-			return true, nil
+	var previous *parsedConstraint
+	for i := range parsed {
+		c := &parsed[i]
+		if i > 0 && parsed[i-1].parsed.Compare(c.parsed) == 0 {
+			return fmt.Errorf("version '%s' is used by more than one constraint", c.version)
 		}
+		if c.operator == "=" || c.operator == "!=" {
+			continue
+		}
+		if previous != nil && isLowerBound(previous.operator) == isLowerBound(c.operator) {
+			return fmt.Errorf("constraints '%s%s' and '%s%s' do not alternate between lower and upper bounds",
+				previous.operator, previous.version, c.operator, c.version)
+		}
+		previous = c
 	}
 
-	return false, nil
+	return nil
+}
+
+// isLowerBound reports whether operator is ">" or ">="
+func isLowerBound(operator string) bool {
+	return operator == ">" || operator == ">="
 }
 
 // toRanges converts VERS constraints to ecosystem-specific ranges
@@ -309,28 +357,32 @@ func toRanges[V univers.Version[V], VR univers.VersionRange[V]](
 		// Create ecosystem-specific range strings from intervals
 		var rangeStrs []string
 
-		switch e.Name() {
-		case "alpine":
+		switch univers.EcosystemName(e.Name()) {
+		case univers.Alpine:
 			rangeStrs = intervalToAlpineRanges(interval)
-		case "cargo":
+		case univers.Cargo:
 			rangeStrs = intervalToCargoRanges(interval)
-		case "debian":
+		case univers.Composer:
+			rangeStrs = intervalToComposerRanges(interval)
+		case univers.Debian:
 			rangeStrs = intervalToDebianRanges(interval)
-		case "gem":
+		case univers.Gem:
 			rangeStrs = intervalToGemRanges(interval)
-		case "maven":
+		case univers.LuaRocks:
+			rangeStrs = intervalToLuarocksRanges(interval)
+		case univers.Maven:
 			rangeStrs = intervalToMavenRanges(interval)
-		case "npm":
+		case univers.NPM:
 			rangeStrs = intervalToNpmRanges(interval)
-		case "nuget":
+		case univers.NuGet:
 			rangeStrs = intervalToNugetRanges(interval)
-		case "pypi":
+		case univers.PyPI:
 			rangeStrs = intervalToPypiRanges(interval)
-		case "rpm":
+		case univers.RPM:
 			rangeStrs = intervalToRpmRanges(interval)
-		case "semver":
+		case univers.SemVer:
 			rangeStrs = intervalToSemverRanges(interval)
-		case "golang":
+		case univers.Golang:
 			rangeStrs = intervalToGolangRanges(interval)
 		default:
 			// For unsupported ecosystems, return error
@@ -405,7 +457,21 @@ func parseConstraint(constraintStr string) (constraint, error) {
 		}
 	}
 
-	return constraint{}, fmt.Errorf("no valid operator found in constraint")
+	// VERS spec: A version without a comparator means equality
+	if err := checkBareVersion(constraintStr); err != nil {
+		return constraint{}, err
+	}
+	return constraint{operator: "=", version: constraintStr}, nil
+}
+
+// checkBareVersion rejects constraints without a VERS comparator that start
+// with another ecosystem's operator, such as "~1.0" or "^1.0", rather than
+// treating them as versions.
+func checkBareVersion(constraintStr string) error {
+	if constraintStr != "" && strings.ContainsRune("<>=!~^", rune(constraintStr[0])) {
+		return fmt.Errorf("unknown comparator in constraint")
+	}
+	return nil
 }
 
 // groupConstraintsIntoIntervals groups VERS constraints into intervals according to the specification
@@ -439,6 +505,13 @@ func groupConstraintsIntoIntervals(constraints []constraint) ([]interval, error)
 
 	// Excludes are handled separately in the contains function, not as intervals
 
+	// VERS spec: When the sorted range constraints alternate between lower
+	// and upper bounds, each lower bound pairs with the upper bound after it.
+	// A leading upper bound and a trailing lower bound are open-ended.
+	if alternating, ok := alternatingIntervals(constraints); ok {
+		return append(intervals, alternating...), nil
+	}
+
 	// Handle range constraints (lower/upper bounds)
 	if len(lowerBounds) > 0 || len(upperBounds) > 0 {
 		// For VERS spec compliance, we need to analyze the constraint pattern:
@@ -524,6 +597,50 @@ func groupConstraintsIntoIntervals(constraints []constraint) ([]interval, error)
 	return intervals, nil
 }
 
+// alternatingIntervals builds intervals with the pairwise algorithm of the
+// VERS specification. It reports false when the range constraints, which must
+// already be sorted by version, do not alternate between lower and upper
+// bounds.
+func alternatingIntervals(constraints []constraint) ([]interval, bool) {
+	var bounds []constraint
+	for _, c := range constraints {
+		if c.operator != "=" && c.operator != "!=" {
+			bounds = append(bounds, c)
+		}
+	}
+	for i := 1; i < len(bounds); i++ {
+		if isLowerBound(bounds[i-1].operator) == isLowerBound(bounds[i].operator) {
+			return nil, false
+		}
+	}
+
+	var intervals []interval
+	for i := 0; i < len(bounds); i++ {
+		c := bounds[i]
+		if !isLowerBound(c.operator) {
+			intervals = append(intervals, interval{
+				upper:          c.version,
+				upperInclusive: c.operator == "<=",
+			})
+			continue
+		}
+
+		in := interval{
+			lower:          c.version,
+			lowerInclusive: c.operator == ">=",
+		}
+		if i+1 < len(bounds) {
+			next := bounds[i+1]
+			in.upper = next.version
+			in.upperInclusive = next.operator == "<="
+			i++
+		}
+		intervals = append(intervals, in)
+	}
+
+	return intervals, true
+}
+
 // shouldMergeConstraints determines whether constraints should be merged (most restrictive)
 // or create multiple intervals based on the constraint pattern
 func shouldMergeConstraints(lowerBounds, upperBounds []constraint) bool {
@@ -564,62 +681,115 @@ func shouldMergeConstraints(lowerBounds, upperBounds []constraint) bool {
 
 // Contains checks if a version satisfies a VERS range using the stateless API.
 // Example: Contains("vers:maven/>=1.0.0|<=2.0.0", "1.5.0") returns true.
-func Contains(versRange, version string) (bool, error) {
+func Contains(versRange, version string, opts ...Option) (bool, error) {
+	o := newOptions(opts)
+	s, constraints, err := split(versRange, o)
+	if err != nil {
+		return false, err
+	}
+
+	compiled, err := compileConstraints(s, constraints, o)
+	if err != nil {
+		return false, err
+	}
+	return compiled(version)
+}
+
+// Validate checks that versRange is a well-formed VERS string whose
+// constraint versions parse in its versioning scheme. With WithCompliance it
+// also applies the validation rules of the VERS specification.
+// Example: Validate("vers:npm/>=1.0.0|>=2.0.0", WithCompliance()) returns an
+// error because the two lower bounds do not alternate with an upper bound.
+func Validate(versRange string, opts ...Option) error {
+	o := newOptions(opts)
+	s, constraints, err := split(versRange, o)
+	if err != nil {
+		return err
+	}
+
+	if isStar(constraints) {
+		return nil
+	}
+
+	scheme, err := lookupScheme(s)
+	if err != nil {
+		return err
+	}
+	return scheme.validate(constraints, o)
+}
+
+// validator returns a function validating VERS constraints for an ecosystem
+func validator[V univers.Version[V], VR univers.VersionRange[V]](
+	e univers.Ecosystem[V, VR],
+) func([]string, options) error {
+	return func(constraints []string, o options) error {
+		if o.compliance {
+			if err := validateConstraints(e, constraints); err != nil {
+				return fmt.Errorf("invalid constraints: %w", err)
+			}
+		}
+		if _, err := normalizeConstraints(e, constraints); err != nil {
+			return fmt.Errorf("failed to normalize constraints: %w", err)
+		}
+		return nil
+	}
+}
+
+// split validates a VERS string and returns its versioning scheme and its
+// constraints. Constraints are not trimmed.
+func split(versRange string, o options) (string, []string, error) {
+	if err := univers.CheckLength(versRange); err != nil {
+		return "", nil, fmt.Errorf("invalid vers string: %w", err)
+	}
+	if o.decode {
+		decoded, err := decode(versRange)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid vers string: %w", err)
+		}
+		versRange = decoded
+	}
+
 	if err := valid(versRange); err != nil {
-		return false, fmt.Errorf("invalid vers string: %w", err)
+		return "", nil, fmt.Errorf("invalid vers string: %w", err)
 	}
 
 	s, err := scheme(versRange)
 	if err != nil {
-		return false, fmt.Errorf("invalid vers versioning-scheme (valid: 'npm', 'deb', etc): %w", err)
+		return "", nil, fmt.Errorf("invalid vers versioning-scheme (valid: 'npm', 'deb', etc): %w", err)
 	}
 
 	// Extract constraints part from VERS string
 	remaining := versRange[len("vers:"):] // Remove "vers:"
 	parts := strings.SplitN(remaining, "/", 2)
-	constraintsStr := parts[1]
+	constraints := strings.Split(parts[1], "|")
+	if err := univers.CheckConstraints(len(constraints)); err != nil {
+		return "", nil, fmt.Errorf("invalid vers string: %w", err)
+	}
 
-	constraints := strings.Split(constraintsStr, "|")
+	// VERS spec: Constraints are never empty, though by default stray
+	// separators such as ">=1.0.0||<2.0.0" are tolerated
+	if o.compliance {
+		for i, c := range constraints {
+			if strings.TrimSpace(c) == "" {
+				return "", nil, fmt.Errorf("invalid vers string: %w", &ConstraintError{Index: i, Constraint: "", Err: fmt.Errorf("empty constraint")})
+			}
+		}
+	}
+
+	return s, constraints, nil
+}
 
-	// Handle special constraints like "*" (match all versions)
-	// Check if there's a star and all other constraints are empty after trimming
+// isStar reports whether the only non-empty constraint is the star "*",
+// which matches all versions
+func isStar(constraints []string) bool {
 	hasStarConstraint := false
-	hasNonEmptyNonStarConstraint := false
 	for _, c := range constraints {
 		trimmed := strings.TrimSpace(c)
 		if trimmed == "*" {
 			hasStarConstraint = true
 		} else if trimmed != "" {
-			hasNonEmptyNonStarConstraint = true
+			return false
 		}
 	}
-
-	if hasStarConstraint && !hasNonEmptyNonStarConstraint {
-		return true, nil
-	}
-
-	if len(constraints) == 0 {
-		return false, fmt.Errorf("empty constraints in VERS range")
-	}
-
-	schemeToContains := map[string]func([]string, string) (bool, error){
-		"alpine":  alpineContains,
-		"cargo":   cargoContains,
-		"deb":     debianContains,
-		"gem":     gemContains,
-		"maven":   mavenContains,
-		"npm":     npmContains,
-		"nuget":   nugetContains,
-		"pypi":    pypiContains,
-		"rpm":     rpmContains,
-		"generic": semverContains, // 'generic' is the correct VERS scheme for semver
-		"golang":  golangContains,
-	}
-
-	containsForEcosystem, ok := schemeToContains[s]
-	if !ok {
-		return false, fmt.Errorf("versioning-scheme %q unsupported", s)
-	}
-
-	return containsForEcosystem(constraints, version)
+	return hasStarConstraint
 }
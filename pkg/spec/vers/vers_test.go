@@ -194,3 +194,71 @@ func TestContains(t *testing.T) {
 		})
 	}
 }
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name          string
+		versRange     string
+		opts          []Option
+		wantErr       bool
+		wantCompliant bool
+	}{
+		{
+			name:          "valid range",
+			versRange:     "vers:npm/>=1.0.0|<2.0.0",
+			wantCompliant: true,
+		},
+		{
+			name:          "bare version",
+			versRange:     "vers:npm/1.0.0",
+			wantCompliant: true,
+		},
+		{
+			name:          "star",
+			versRange:     "vers:npm/*",
+			wantCompliant: true,
+		},
+		{
+			name:      "non-alternating bounds are tolerated by default",
+			versRange: "vers:npm/>=1.0.0|>=1.5.0|<2.0.0",
+		},
+		{
+			name:      "empty constraint is tolerated by default",
+			versRange: "vers:npm/>=1.0.0||<2.0.0",
+		},
+		{
+			name:      "duplicate version is tolerated by default",
+			versRange: "vers:npm/>=1.0.0|<=1.0.0",
+		},
+		{
+			name:          "decoding option",
+			versRange:     "vers:npm/%3E%3D1.0.0",
+			opts:          []Option{WithDecoding()},
+			wantCompliant: true,
+		},
+		{
+			name:      "invalid version",
+			versRange: "vers:npm/>=1.x.y",
+			wantErr:   true,
+		},
+		{
+			name:      "unsupported scheme",
+			versRange: "vers:unsupported/>=1.0.0",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.versRange, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			err = Validate(tt.versRange, append(tt.opts, WithCompliance())...)
+			if (err == nil) != tt.wantCompliant {
+				t.Errorf("Validate(WithCompliance()) error = %v, want compliant %v", err, tt.wantCompliant)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package univers
+
+import "fmt"
+
+// NewAnyRange parses rangeStr in ecosystem e and returns it with its types
+// erased, as the ranges of an ecosystem from LookupEcosystem are. Ranges of
+// every ecosystem share the AnyVersionRange type, so they can be kept
+// together in one slice or map and checked against version strings with
+// ContainsString:
+//
+//	ranges := map[string]*univers.AnyVersionRange{}
+//	ranges["npm"], _ = univers.NewAnyRange(&npm.Ecosystem{}, "^1.2.0")
+//	ranges["pypi"], _ = univers.NewAnyRange(&pypi.Ecosystem{}, "~=2.1")
+func NewAnyRange[V Version[V], VR VersionRange[V]](e Ecosystem[V, VR], rangeStr string) (*AnyVersionRange, error) {
+	return eraseEcosystem(e).NewVersionRange(rangeStr)
+}
+
+// WrapRange returns an already parsed range with its types erased. e parses
+// the versions later passed to ContainsString.
+func WrapRange[V Version[V], VR VersionRange[V]](e Ecosystem[V, VR], vr VR) *AnyVersionRange {
+	return eraseEcosystem(e).wrapRange(vr)
+}
+
+// eraseEcosystem adapts e to AnyEcosystem under its own name
+func eraseEcosystem[V Version[V], VR VersionRange[V]](e Ecosystem[V, VR]) *anyEcosystem[V, VR] {
+	return &anyEcosystem[V, VR]{name: e.Name(), e: e}
+}
+
+// ContainsString parses version in the range's ecosystem and reports whether
+// the range contains it. It returns an error if the version is invalid.
+func (r *AnyVersionRange) ContainsString(version string) (bool, error) {
+	v, err := r.newVersion(version)
+	if err != nil {
+		return false, fmt.Errorf("%s range %q: %w", r.ecosystem, r.str, err)
+	}
+	return r.Contains(v), nil
+}
@@ -0,0 +1,83 @@
+package univers_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/maven"
+	"github.com/alowayed/go-univers/pkg/ecosystem/npm"
+	"github.com/alowayed/go-univers/pkg/ecosystem/pypi"
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+func TestAnyVersionRange_ContainsString(t *testing.T) {
+	ranges := map[string]*univers.AnyVersionRange{}
+	for name, parse := range map[string]func() (*univers.AnyVersionRange, error){
+		"npm":   func() (*univers.AnyVersionRange, error) { return univers.NewAnyRange(&npm.Ecosystem{}, "^1.2.0") },
+		"pypi":  func() (*univers.AnyVersionRange, error) { return univers.NewAnyRange(&pypi.Ecosystem{}, "~=2.1") },
+		"maven": func() (*univers.AnyVersionRange, error) { return univers.NewAnyRange(&maven.Ecosystem{}, "[1.0,2.0)") },
+	} {
+		r, err := parse()
+		if err != nil {
+			t.Fatalf("NewAnyRange() for %s error = %v", name, err)
+		}
+		ranges[name] = r
+	}
+
+	tests := []struct {
+		name      string
+		ecosystem string
+		version   string
+		want      bool
+		wantErr   string
+	}{
+		{name: "npm inside", ecosystem: "npm", version: "1.9.0", want: true},
+		{name: "npm outside", ecosystem: "npm", version: "2.0.0", want: false},
+		{name: "pypi inside", ecosystem: "pypi", version: "2.9", want: true},
+		{name: "pypi outside", ecosystem: "pypi", version: "3.0", want: false},
+		{name: "maven inside", ecosystem: "maven", version: "1.5-SNAPSHOT", want: true},
+		{name: "maven outside", ecosystem: "maven", version: "2.0", want: false},
+		{name: "invalid version", ecosystem: "npm", version: "not-a-version", wantErr: `npm range "^1.2.0"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := ranges[tt.ecosystem]
+			got, err := r.ContainsString(tt.version)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ContainsString(%q) error = %v, want containing %q", tt.version, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ContainsString(%q) error = %v", tt.version, err)
+			}
+			if got != tt.want {
+				t.Errorf("%s %q ContainsString(%q) = %v, want %v", tt.ecosystem, r, tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewAnyRange_Invalid(t *testing.T) {
+	if _, err := univers.NewAnyRange(&npm.Ecosystem{}, "~~1.0"); err == nil {
+		t.Error("NewAnyRange(\"~~1.0\") error = nil, want error")
+	}
+}
+
+func TestWrapRange(t *testing.T) {
+	e := &npm.Ecosystem{}
+	vr := univers.MustNewVersionRange(e, ">=1.0.0 <2.0.0")
+
+	r := univers.WrapRange(e, vr)
+	if got := r.String(); got != ">=1.0.0 <2.0.0" {
+		t.Errorf("String() = %q, want %q", got, ">=1.0.0 <2.0.0")
+	}
+	if got, err := r.ContainsString("1.5.0"); err != nil || !got {
+		t.Errorf("ContainsString(%q) = %v, %v, want true, nil", "1.5.0", got, err)
+	}
+	if r.Unwrap() != any(vr) {
+		t.Errorf("Unwrap() did not return the wrapped range")
+	}
+}
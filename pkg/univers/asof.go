@@ -0,0 +1,52 @@
+package univers
+
+import (
+	"fmt"
+	"time"
+)
+
+// Release is a version string together with the time it was published.
+type Release struct {
+	// Version is the version string as published by the registry.
+	Version string
+	// Time is when the version was published. A zero Time means unknown.
+	Time time.Time
+}
+
+// LatestAsOf returns the highest version satisfying rangeStr among the releases
+// published at or before t, i.e. the version the range would have resolved to at
+// that moment. The boolean result is false when no release qualifies.
+//
+// Releases whose version fails to parse or whose publication time is unknown are
+// skipped, since they cannot be placed in the timeline.
+func LatestAsOf[V Version[V], VR VersionRange[V]](
+	e Ecosystem[V, VR],
+	rangeStr string,
+	releases []Release,
+	t time.Time,
+) (V, bool, error) {
+	var latest V
+	r, err := e.NewVersionRange(rangeStr)
+	if err != nil {
+		return latest, false, fmt.Errorf("failed to parse range %q: %w", rangeStr, err)
+	}
+
+	found := false
+	for _, rel := range releases {
+		if rel.Time.IsZero() || rel.Time.After(t) {
+			continue
+		}
+		v, err := e.NewVersion(rel.Version)
+		if err != nil {
+			continue
+		}
+		if !r.Contains(v) {
+			continue
+		}
+		if !found || After(v, latest) {
+			latest = v
+			found = true
+		}
+	}
+	return latest, found, nil
+}
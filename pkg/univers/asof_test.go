@@ -0,0 +1,79 @@
+package univers_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/npm"
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+func TestLatestAsOf(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, time.January, d, 0, 0, 0, 0, time.UTC) }
+	releases := []univers.Release{
+		{Version: "1.0.0", Time: day(1)},
+		{Version: "1.2.0", Time: day(10)},
+		{Version: "2.0.0", Time: day(5)},
+		{Version: "1.1.0", Time: day(3)},
+		{Version: "1.3.0"},
+		{Version: "not-a-version", Time: day(2)},
+	}
+
+	tests := []struct {
+		name     string
+		rangeStr string
+		at       time.Time
+		want     string
+		wantOK   bool
+		wantErr  bool
+	}{
+		{
+			name:     "latest in range before later release",
+			rangeStr: "^1.0.0",
+			at:       day(9),
+			want:     "1.1.0",
+			wantOK:   true,
+		},
+		{
+			name:     "release at exact time included",
+			rangeStr: "^1.0.0",
+			at:       day(10),
+			want:     "1.2.0",
+			wantOK:   true,
+		},
+		{
+			name:     "highest version not most recent",
+			rangeStr: ">=1.0.0",
+			at:       day(31),
+			want:     "2.0.0",
+			wantOK:   true,
+		},
+		{
+			name:     "nothing published yet",
+			rangeStr: ">=1.0.0",
+			at:       day(0),
+			wantOK:   false,
+		},
+		{
+			name:     "invalid range",
+			rangeStr: "",
+			at:       day(31),
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := univers.LatestAsOf(&npm.Ecosystem{}, tt.rangeStr, releases, tt.at)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LatestAsOf() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if ok != tt.wantOK {
+				t.Fatalf("LatestAsOf() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && got.String() != tt.want {
+				t.Errorf("LatestAsOf() = %q, want %q", got.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package univers
+
+import (
+	"errors"
+	"sync"
+)
+
+// negativeCache remembers parse errors for a bounded number of inputs,
+// evicting the oldest entry once full.
+type negativeCache struct {
+	mu    sync.Mutex
+	size  int
+	errs  map[string]error
+	order []string
+}
+
+func newNegativeCache(size int) *negativeCache {
+	return &negativeCache{size: size, errs: make(map[string]error)}
+}
+
+// get returns the cached error for s, or nil if s has not failed before.
+func (c *negativeCache) get(s string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.errs[s]
+}
+
+func (c *negativeCache) put(s string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.errs[s]; ok {
+		return
+	}
+	if len(c.order) >= c.size {
+		delete(c.errs, c.order[0])
+		c.order = c.order[1:]
+	}
+	c.errs[s] = err
+	c.order = append(c.order, s)
+}
+
+// negativeCachedEcosystem wraps an ecosystem and short-circuits inputs that
+// previously failed to parse.
+type negativeCachedEcosystem[V Version[V], VR VersionRange[V]] struct {
+	e        Ecosystem[V, VR]
+	versions *negativeCache
+	ranges   *negativeCache
+}
+
+// WithNegativeCache wraps an ecosystem so that inputs which fail to parse are
+// remembered and return the same error on later calls without being parsed
+// again. Audits of large lockfiles tend to hit the same malformed versions and
+// ranges many times; successful parses are not cached, and neither are
+// errors wrapping ErrInputTooLong or ErrTooManyConstraints, as SetLimits can
+// make the same input parse later. The returned ecosystem implements Featurer
+// by forwarding to e. At most size failed
+// versions and size failed ranges are remembered, oldest first evicted. The
+// returned ecosystem is safe for concurrent use if e is.
+func WithNegativeCache[V Version[V], VR VersionRange[V]](e Ecosystem[V, VR], size int) Ecosystem[V, VR] {
+	if size <= 0 {
+		return e
+	}
+	return &negativeCachedEcosystem[V, VR]{
+		e:        e,
+		versions: newNegativeCache(size),
+		ranges:   newNegativeCache(size),
+	}
+}
+
+func (n *negativeCachedEcosystem[V, VR]) Name() string {
+	return n.e.Name()
+}
+
+// Features forwards to the wrapped ecosystem when it implements Featurer,
+// and returns nil otherwise.
+func (n *negativeCachedEcosystem[V, VR]) Features() []Feature {
+	if f, ok := n.e.(Featurer); ok {
+		return f.Features()
+	}
+	return nil
+}
+
+func (n *negativeCachedEcosystem[V, VR]) NewVersion(s string) (V, error) {
+	if err := n.versions.get(s); err != nil {
+		var zero V
+		return zero, err
+	}
+	v, err := n.e.NewVersion(s)
+	if cacheable(err) {
+		n.versions.put(s, err)
+	}
+	return v, err
+}
+
+func (n *negativeCachedEcosystem[V, VR]) NewVersionRange(s string) (VR, error) {
+	if err := n.ranges.get(s); err != nil {
+		var zero VR
+		return zero, err
+	}
+	r, err := n.e.NewVersionRange(s)
+	if cacheable(err) {
+		n.ranges.put(s, err)
+	}
+	return r, err
+}
+
+// cacheable reports whether err is a parse error to remember. Errors from
+// the limits are not, since they depend on the limits in force rather than
+// the input alone.
+func cacheable(err error) bool {
+	return err != nil && !errors.Is(err, ErrInputTooLong) && !errors.Is(err, ErrTooManyConstraints)
+}
@@ -0,0 +1,120 @@
+package univers
+
+import (
+	"errors"
+	"testing"
+)
+
+// countingEcosystem counts parse calls to the wrapped intEcosystem
+type countingEcosystem struct {
+	intEcosystem
+	versions, ranges int
+}
+
+func (c *countingEcosystem) NewVersion(s string) (*intVersion, error) {
+	c.versions++
+	return c.intEcosystem.NewVersion(s)
+}
+
+func (c *countingEcosystem) NewVersionRange(s string) (*intRange, error) {
+	c.ranges++
+	return c.intEcosystem.NewVersionRange(s)
+}
+
+func TestWithNegativeCache(t *testing.T) {
+	inner := &countingEcosystem{}
+	e := WithNegativeCache[*intVersion, *intRange](inner, 2)
+
+	_, firstErr := e.NewVersion("bad")
+	if firstErr == nil {
+		t.Fatalf("NewVersion(%q) error = nil, want error", "bad")
+	}
+	_, secondErr := e.NewVersion("bad")
+	if !errors.Is(secondErr, firstErr) {
+		t.Errorf("cached NewVersion() error = %v, want %v", secondErr, firstErr)
+	}
+	if inner.versions != 1 {
+		t.Errorf("inner NewVersion calls = %d, want 1", inner.versions)
+	}
+
+	// Successful parses are never cached
+	for range 2 {
+		if _, err := e.NewVersion("1"); err != nil {
+			t.Fatalf("NewVersion(%q) error = %v", "1", err)
+		}
+	}
+	if inner.versions != 3 {
+		t.Errorf("inner NewVersion calls = %d, want 3", inner.versions)
+	}
+
+	// The oldest failure is evicted once the cache is full
+	for _, s := range []string{"bad", "worse", "worst", "bad"} {
+		if _, err := e.NewVersionRange(s); err == nil {
+			t.Fatalf("NewVersionRange(%q) error = nil, want error", s)
+		}
+	}
+	if inner.ranges != 4 {
+		t.Errorf("inner NewVersionRange calls = %d, want 4", inner.ranges)
+	}
+	if _, err := e.NewVersionRange("worst"); err == nil {
+		t.Fatalf("NewVersionRange(%q) error = nil, want error", "worst")
+	}
+	if inner.ranges != 4 {
+		t.Errorf("inner NewVersionRange calls = %d, want 4 after cache hit", inner.ranges)
+	}
+}
+
+// limitedEcosystem is a countingEcosystem that checks the input limits first
+type limitedEcosystem struct {
+	countingEcosystem
+}
+
+func (l *limitedEcosystem) NewVersion(s string) (*intVersion, error) {
+	l.versions++
+	if err := CheckLength(s); err != nil {
+		return nil, err
+	}
+	return l.intEcosystem.NewVersion(s)
+}
+
+func (l *limitedEcosystem) Features() []Feature {
+	return []Feature{{Name: FeatureComparison, Syntax: ">=1"}}
+}
+
+func TestWithNegativeCache_Limits(t *testing.T) {
+	old := CurrentLimits()
+	t.Cleanup(func() { SetLimits(old) })
+
+	inner := &limitedEcosystem{}
+	e := WithNegativeCache[*intVersion, *intRange](inner, 2)
+
+	SetLimits(Limits{MaxInputLength: 2})
+	if _, err := e.NewVersion("123"); !errors.Is(err, ErrInputTooLong) {
+		t.Fatalf("NewVersion(%q) error = %v, want %v", "123", err, ErrInputTooLong)
+	}
+
+	// The limit error is not remembered, so raising the limit takes effect
+	SetLimits(old)
+	if _, err := e.NewVersion("123"); err != nil {
+		t.Errorf("NewVersion(%q) after SetLimits error = %v", "123", err)
+	}
+	if inner.versions != 2 {
+		t.Errorf("inner NewVersion calls = %d, want 2", inner.versions)
+	}
+}
+
+func TestWithNegativeCache_Features(t *testing.T) {
+	e := WithNegativeCache[*intVersion, *intRange](&limitedEcosystem{}, 2)
+	f, ok := e.(Featurer)
+	if !ok {
+		t.Fatalf("WithNegativeCache() does not implement Featurer")
+	}
+	if got := f.Features(); len(got) != 1 || got[0].Name != FeatureComparison {
+		t.Errorf("Features() = %+v, want the wrapped ecosystem's features", got)
+	}
+
+	e = WithNegativeCache[*intVersion, *intRange](&intEcosystem{}, 2)
+	if got := e.(Featurer).Features(); got != nil {
+		t.Errorf("Features() = %+v without a wrapped Featurer, want nil", got)
+	}
+}
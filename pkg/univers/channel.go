@@ -0,0 +1,60 @@
+package univers
+
+import (
+	"strconv"
+	"strings"
+)
+
+// channelAliases maps abbreviated prerelease channel names to their full
+// spelling, so "1.0a1", "1.0.0-alpha.1" and "1.0.0.ALPHA1" share a channel
+var channelAliases = map[string]string{
+	"a":  "alpha",
+	"b":  "beta",
+	"c":  "rc",
+	"cr": "rc",
+	"m":  "milestone",
+}
+
+// SplitPrerelease splits a prerelease label such as "beta.2", "RC1" or
+// "alpha-3" into its channel and the number that follows it. The channel is
+// the label's leading run of letters, lowercased, with abbreviations spelled
+// out: "a" is "alpha", "b" is "beta", "c" and "cr" are "rc" and "m" is
+// "milestone". Other words, such as "nightly" or "preview", are kept as they
+// are.
+//
+// The number may follow the channel directly or after a ".", "-" or "_"
+// separator. The channel is "" for a label that starts with a digit, such as
+// "0.3.7", whose leading number is then returned. ok is false when no number
+// follows the channel.
+func SplitPrerelease(label string) (channel string, number int, ok bool) {
+	i := 0
+	for i < len(label) && isLetter(label[i]) {
+		i++
+	}
+	channel = strings.ToLower(label[:i])
+	if alias, found := channelAliases[channel]; found {
+		channel = alias
+	}
+
+	rest := label[i:]
+	if channel != "" && rest != "" && strings.IndexByte(".-_", rest[0]) >= 0 {
+		rest = rest[1:]
+	}
+	j := 0
+	for j < len(rest) && isASCIIDigit(rest[j]) {
+		j++
+	}
+	if j == 0 || (j < len(rest) && isLetter(rest[j])) {
+		return channel, 0, false
+	}
+	n, err := strconv.Atoi(rest[:j])
+	if err != nil {
+		return channel, 0, false
+	}
+	return channel, n, true
+}
+
+// isLetter reports whether c is an ASCII letter of either case
+func isLetter(c byte) bool {
+	return isASCIILetter(c) || 'A' <= c && c <= 'Z'
+}
@@ -0,0 +1,44 @@
+package univers_test
+
+import (
+	"testing"
+
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+func TestSplitPrerelease(t *testing.T) {
+	tests := []struct {
+		label       string
+		wantChannel string
+		wantNumber  int
+		wantOK      bool
+	}{
+		{"alpha", "alpha", 0, false},
+		{"alpha.1", "alpha", 1, true},
+		{"beta-2", "beta", 2, true},
+		{"rc_3", "rc", 3, true},
+		{"RC1", "rc", 1, true},
+		{"a1", "alpha", 1, true},
+		{"b", "beta", 0, false},
+		{"c2", "rc", 2, true},
+		{"CR1", "rc", 1, true},
+		{"M3", "milestone", 3, true},
+		{"nightly.20240101", "nightly", 20240101, true},
+		{"preview.7.24405.7", "preview", 7, true},
+		{"beta.x", "beta", 0, false},
+		{"beta.2rc", "beta", 0, false},
+		{"0.3.7", "", 0, true},
+		{"20191109021931-daa7c04131f5", "", 20191109021931, true},
+		{"", "", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.label, func(t *testing.T) {
+			channel, number, ok := univers.SplitPrerelease(tt.label)
+			if channel != tt.wantChannel || number != tt.wantNumber || ok != tt.wantOK {
+				t.Errorf("SplitPrerelease(%q) = %q, %d, %v, want %q, %d, %v",
+					tt.label, channel, number, ok, tt.wantChannel, tt.wantNumber, tt.wantOK)
+			}
+		})
+	}
+}
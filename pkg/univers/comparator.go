@@ -0,0 +1,24 @@
+package univers
+
+// Comparator is a single operator and version of a range, such as ">=" and
+// "1.2.3". Operators are "=", "!=", "<", "<=", ">" and ">=" unless the
+// ecosystem has an operator with no equivalent among them.
+type Comparator struct {
+	Operator string
+	Version  string
+}
+
+// String returns the comparator as the operator followed by the version.
+func (c Comparator) String() string {
+	return c.Operator + c.Version
+}
+
+// ComparatorRange is implemented by the version ranges of every ecosystem, so
+// tooling can inspect a parsed range without knowing its type. Shorthands such
+// as caret, tilde and wildcard ranges are expanded where comparators can
+// express them; see each ecosystem's Comparators for the operators it keeps.
+type ComparatorRange interface {
+	// Comparators returns the constraints as an OR of AND groups. An empty
+	// group matches every version.
+	Comparators() [][]Comparator
+}
@@ -0,0 +1,46 @@
+package univers
+
+// Before reports whether a sorts before b, reading better than
+// a.Compare(b) < 0 at call sites.
+func Before[V Version[V]](a, b V) bool {
+	return a.Compare(b) < 0
+}
+
+// After reports whether a sorts after b.
+func After[V Version[V]](a, b V) bool {
+	return a.Compare(b) > 0
+}
+
+// Equal reports whether a and b are the same version in their ecosystem's
+// ordering, which may differ from their spellings: npm's "1.0.0" and
+// "v1.0.0", or PyPI's "1.0" and "1.0.0", are equal.
+func Equal[V Version[V]](a, b V) bool {
+	return a.Compare(b) == 0
+}
+
+// Inclusivity selects which bounds of an interval Between includes. The
+// values are bit flags, so IncludeBoth is IncludeLower|IncludeUpper.
+type Inclusivity int
+
+const (
+	// IncludeNeither is the open interval (lower, upper).
+	IncludeNeither Inclusivity = 0
+	// IncludeLower is the half-open interval [lower, upper), the usual
+	// shape of an affected range that ends at a fix.
+	IncludeLower Inclusivity = 1 << 0
+	// IncludeUpper is the half-open interval (lower, upper].
+	IncludeUpper Inclusivity = 1 << 1
+	// IncludeBoth is the closed interval [lower, upper].
+	IncludeBoth = IncludeLower | IncludeUpper
+)
+
+// Between reports whether v lies between lower and upper, with the bounds
+// included as selected by inclusivity. It answers "is v in [a, b)" without
+// building a range string. No version is between bounds in the wrong order.
+func Between[V Version[V]](v, lower, upper V, inclusivity Inclusivity) bool {
+	if c := v.Compare(lower); c < 0 || (c == 0 && inclusivity&IncludeLower == 0) {
+		return false
+	}
+	c := v.Compare(upper)
+	return c < 0 || (c == 0 && inclusivity&IncludeUpper != 0)
+}
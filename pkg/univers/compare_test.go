@@ -0,0 +1,84 @@
+package univers_test
+
+import (
+	"testing"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/npm"
+	"github.com/alowayed/go-univers/pkg/ecosystem/pypi"
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+func TestBeforeAfterEqual(t *testing.T) {
+	tests := []struct {
+		name       string
+		a, b       string
+		wantBefore bool
+		wantAfter  bool
+		wantEqual  bool
+	}{
+		{name: "older", a: "1.0.0", b: "1.1.0", wantBefore: true},
+		{name: "newer", a: "2.0.0", b: "1.1.0", wantAfter: true},
+		{name: "prerelease first", a: "1.0.0-rc.1", b: "1.0.0", wantBefore: true},
+		{name: "same", a: "1.0.0", b: "1.0.0", wantEqual: true},
+		{name: "different spelling", a: "v1.0.0", b: "1.0.0", wantEqual: true},
+	}
+
+	e := &npm.Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := univers.MustNewVersion(e, tt.a)
+			b := univers.MustNewVersion(e, tt.b)
+			if got := univers.Before(a, b); got != tt.wantBefore {
+				t.Errorf("Before(%s, %s) = %v, want %v", tt.a, tt.b, got, tt.wantBefore)
+			}
+			if got := univers.After(a, b); got != tt.wantAfter {
+				t.Errorf("After(%s, %s) = %v, want %v", tt.a, tt.b, got, tt.wantAfter)
+			}
+			if got := univers.Equal(a, b); got != tt.wantEqual {
+				t.Errorf("Equal(%s, %s) = %v, want %v", tt.a, tt.b, got, tt.wantEqual)
+			}
+		})
+	}
+}
+
+func TestEqual_PyPI(t *testing.T) {
+	e := &pypi.Ecosystem{}
+	if !univers.Equal(univers.MustNewVersion(e, "1.0"), univers.MustNewVersion(e, "1.0.0")) {
+		t.Errorf("Equal(1.0, 1.0.0) = false, want true")
+	}
+}
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		name         string
+		v            string
+		lower, upper string
+		inclusivity  univers.Inclusivity
+		want         bool
+	}{
+		{name: "inside", v: "1.5.0", lower: "1.0.0", upper: "2.0.0", inclusivity: univers.IncludeNeither, want: true},
+		{name: "at lower included", v: "1.0.0", lower: "1.0.0", upper: "2.0.0", inclusivity: univers.IncludeLower, want: true},
+		{name: "at lower excluded", v: "1.0.0", lower: "1.0.0", upper: "2.0.0", inclusivity: univers.IncludeUpper, want: false},
+		{name: "at upper included", v: "2.0.0", lower: "1.0.0", upper: "2.0.0", inclusivity: univers.IncludeUpper, want: true},
+		{name: "at upper excluded", v: "2.0.0", lower: "1.0.0", upper: "2.0.0", inclusivity: univers.IncludeLower, want: false},
+		{name: "closed", v: "2.0.0", lower: "1.0.0", upper: "2.0.0", inclusivity: univers.IncludeBoth, want: true},
+		{name: "prerelease of upper", v: "2.0.0-rc.1", lower: "1.0.0", upper: "2.0.0", inclusivity: univers.IncludeLower, want: true},
+		{name: "below", v: "0.9.0", lower: "1.0.0", upper: "2.0.0", inclusivity: univers.IncludeBoth, want: false},
+		{name: "above", v: "2.0.1", lower: "1.0.0", upper: "2.0.0", inclusivity: univers.IncludeBoth, want: false},
+		{name: "single point", v: "v1.0.0", lower: "1.0.0", upper: "1.0.0", inclusivity: univers.IncludeBoth, want: true},
+		{name: "empty half-open", v: "1.0.0", lower: "1.0.0", upper: "1.0.0", inclusivity: univers.IncludeLower, want: false},
+		{name: "bounds reversed", v: "1.5.0", lower: "2.0.0", upper: "1.0.0", inclusivity: univers.IncludeBoth, want: false},
+	}
+
+	e := &npm.Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := univers.MustNewVersion(e, tt.v)
+			lower := univers.MustNewVersion(e, tt.lower)
+			upper := univers.MustNewVersion(e, tt.upper)
+			if got := univers.Between(v, lower, upper, tt.inclusivity); got != tt.want {
+				t.Errorf("Between(%s, %s, %s, %d) = %v, want %v", tt.v, tt.lower, tt.upper, tt.inclusivity, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package univers
+
+import (
+	"fmt"
+	"slices"
+)
+
+// EnumerateOptions controls how VersionsInRange orders and pages its results.
+// The zero value returns every matching version in ascending order.
+type EnumerateOptions struct {
+	// Offset is the number of matching versions to skip.
+	Offset int
+	// Limit is the maximum number of versions to return. A non-positive Limit
+	// returns all remaining versions.
+	Limit int
+	// Descending returns the newest versions first.
+	Descending bool
+	// Yanked reports versions withdrawn by their registry. Matching ones are
+	// left out unless YankPolicy allows them; nil means none are withdrawn.
+	Yanked func(version string) bool
+	// YankPolicy decides when a yanked version is kept. Nil uses
+	// PipYankPolicy, which keeps only exactly pinned versions.
+	YankPolicy YankPolicy
+}
+
+// VersionPage is a page of versions matching a range.
+type VersionPage[V Version[V]] struct {
+	// Versions contains the matching versions for the requested page, sorted.
+	Versions []V
+	// Total is the number of matching versions before paging was applied.
+	Total int
+	// Invalid contains the input versions the ecosystem could not parse, in input order.
+	Invalid []string
+}
+
+// VersionsInRange parses rangeStr and returns the versions that satisfy it, sorted
+// and paged according to opts.
+//
+// Versions that fail to parse are skipped and reported in VersionPage.Invalid
+// rather than failing the whole call, since version listings from registries
+// commonly contain entries that do not follow the ecosystem's scheme.
+func VersionsInRange[V Version[V], VR VersionRange[V]](
+	e Ecosystem[V, VR],
+	rangeStr string,
+	versions []string,
+	opts EnumerateOptions,
+) (VersionPage[V], error) {
+	var page VersionPage[V]
+	if opts.Offset < 0 {
+		return page, fmt.Errorf("invalid offset %d: must not be negative", opts.Offset)
+	}
+
+	r, err := e.NewVersionRange(rangeStr)
+	if err != nil {
+		return page, fmt.Errorf("failed to parse range %q: %w", rangeStr, err)
+	}
+
+	resolve := resolveOptions{yanked: opts.Yanked, policy: opts.YankPolicy}
+	var matched []V
+	for _, s := range versions {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			page.Invalid = append(page.Invalid, s)
+			continue
+		}
+		if r.Contains(v) && !resolve.skip(r, s) {
+			matched = append(matched, v)
+		}
+	}
+
+	slices.SortStableFunc(matched, V.Compare)
+	if opts.Descending {
+		slices.Reverse(matched)
+	}
+
+	page.Total = len(matched)
+	start := min(opts.Offset, len(matched))
+	end := len(matched)
+	if opts.Limit > 0 {
+		end = min(start+opts.Limit, end)
+	}
+	page.Versions = matched[start:end]
+	return page, nil
+}
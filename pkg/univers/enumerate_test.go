@@ -0,0 +1,104 @@
+package univers_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/npm"
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+func TestVersionsInRange(t *testing.T) {
+	versions := []string{"2.0.0", "1.0.0", "not-a-version", "1.5.0", "0.9.0", "1.2.0", "1.9.9"}
+
+	tests := []struct {
+		name        string
+		rangeStr    string
+		opts        univers.EnumerateOptions
+		want        []string
+		wantTotal   int
+		wantInvalid []string
+		wantErr     bool
+	}{
+		{
+			name:        "all matches sorted",
+			rangeStr:    ">=1.0.0 <2.0.0",
+			want:        []string{"1.0.0", "1.2.0", "1.5.0", "1.9.9"},
+			wantTotal:   4,
+			wantInvalid: []string{"not-a-version"},
+		},
+		{
+			name:        "limit",
+			rangeStr:    ">=1.0.0 <2.0.0",
+			opts:        univers.EnumerateOptions{Limit: 2},
+			want:        []string{"1.0.0", "1.2.0"},
+			wantTotal:   4,
+			wantInvalid: []string{"not-a-version"},
+		},
+		{
+			name:        "offset and limit",
+			rangeStr:    ">=1.0.0 <2.0.0",
+			opts:        univers.EnumerateOptions{Offset: 2, Limit: 1},
+			want:        []string{"1.5.0"},
+			wantTotal:   4,
+			wantInvalid: []string{"not-a-version"},
+		},
+		{
+			name:        "offset past end",
+			rangeStr:    ">=1.0.0 <2.0.0",
+			opts:        univers.EnumerateOptions{Offset: 10},
+			want:        []string{},
+			wantTotal:   4,
+			wantInvalid: []string{"not-a-version"},
+		},
+		{
+			name:        "descending",
+			rangeStr:    ">=1.0.0",
+			opts:        univers.EnumerateOptions{Limit: 2, Descending: true},
+			want:        []string{"2.0.0", "1.9.9"},
+			wantTotal:   5,
+			wantInvalid: []string{"not-a-version"},
+		},
+		{
+			name:        "no matches",
+			rangeStr:    ">=3.0.0",
+			want:        []string{},
+			wantTotal:   0,
+			wantInvalid: []string{"not-a-version"},
+		},
+		{
+			name:     "invalid range",
+			rangeStr: "",
+			wantErr:  true,
+		},
+		{
+			name:     "negative offset",
+			rangeStr: ">=1.0.0",
+			opts:     univers.EnumerateOptions{Offset: -1},
+			wantErr:  true,
+		},
+	}
+
+	e := &npm.Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := univers.VersionsInRange(e, tt.rangeStr, versions, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("VersionsInRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if gotVersions := versionStrings(got.Versions); !slices.Equal(gotVersions, tt.want) {
+				t.Errorf("VersionsInRange().Versions = %v, want %v", gotVersions, tt.want)
+			}
+			if got.Total != tt.wantTotal {
+				t.Errorf("VersionsInRange().Total = %d, want %d", got.Total, tt.wantTotal)
+			}
+			if !slices.Equal(got.Invalid, tt.wantInvalid) {
+				t.Errorf("VersionsInRange().Invalid = %v, want %v", got.Invalid, tt.wantInvalid)
+			}
+		})
+	}
+}
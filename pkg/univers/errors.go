@@ -0,0 +1,140 @@
+package univers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ParseError describes a failure to parse a version or range string, including
+// where in the input the problem was found.
+type ParseError struct {
+	// Input is the complete string that was being parsed.
+	Input string
+	// Pos is the byte offset of Token within Input.
+	Pos int
+	// Token is the part of Input that could not be parsed. It is empty when the
+	// input is empty or only whitespace.
+	Token string
+	// Err is the underlying error.
+	Err error
+}
+
+// Error returns the underlying error annotated with the input and position.
+func (e *ParseError) Error() string {
+	if e.Token == "" {
+		return fmt.Sprintf("parsing %q: %v", e.Input, e.Err)
+	}
+	return fmt.Sprintf("parsing %q at position %d (%q): %v", e.Input, e.Pos, e.Token, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// TokenError attributes err to token, a substring of the input being parsed.
+// Parsers call it from helpers that only see a single token or sub-expression;
+// WrapParseError later resolves the position against the complete input. If err
+// already carries a ParseError from a nested token, its position is rebased onto
+// token instead, so the innermost token is the one reported. A nil err returns
+// nil.
+func TokenError(token string, err error) error {
+	return TokenErrorAt(token, 0, token, err)
+}
+
+// TokenErrorAt is like TokenError for a token of src that starts at or after
+// byte offset. Parsers that split src into tokens use it to report the exact
+// occurrence of a token that also appears earlier in src.
+func TokenErrorAt(src string, offset int, token string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	pos := min(offset, len(src))
+	if i := strings.Index(src[pos:], token); i >= 0 {
+		pos += i
+	}
+
+	var pe *ParseError
+	if errors.As(err, &pe) {
+		pe.rebase(token)
+		pe.Pos += pos
+		pe.Input = src
+		return err
+	}
+	return &ParseError{Input: src, Pos: pos, Token: token, Err: err}
+}
+
+// WrapParseError attaches the complete input to err. If err carries a
+// ParseError from TokenError, its position is resolved relative to input;
+// otherwise err is wrapped in a ParseError whose token is the whole trimmed
+// input. A nil err returns nil.
+func WrapParseError(input string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var pe *ParseError
+	if errors.As(err, &pe) {
+		pe.rebase(input)
+		return err
+	}
+
+	// No token was identified, so attribute the error to the whole trimmed input
+	token := strings.TrimSpace(input)
+	return &ParseError{Input: input, Pos: strings.Index(input, token), Token: token, Err: err}
+}
+
+// rebase makes the error relative to outer, a string containing e.Input.
+// Identical substrings parse identically, so the first occurrence is the one
+// that failed.
+func (e *ParseError) rebase(outer string) {
+	if e.Input == outer {
+		return
+	}
+	if offset := strings.Index(outer, e.Input); offset >= 0 {
+		e.Pos += offset
+	} else {
+		// The parser rewrote the input, so fall back to locating the token itself
+		e.Pos = max(strings.Index(outer, e.Token), 0)
+	}
+	e.Input = outer
+}
+
+// errorJSON is the JSON form of an error written by ErrorJSON
+type errorJSON struct {
+	Message string  `json:"message"`
+	Input   *string `json:"input,omitempty"`
+	Pos     *int    `json:"pos,omitempty"`
+	Token   *string `json:"token,omitempty"`
+}
+
+// ErrorJSON encodes err as a JSON object for web APIs and gateway services:
+// {"error": {"message": ...}}. When err carries a ParseError, the object also
+// has its "input", "pos" and "token", so clients can highlight the problem.
+// A nil err encodes as {"error": null}.
+func ErrorJSON(err error) []byte {
+	var body struct {
+		Error *errorJSON `json:"error"`
+	}
+	if err != nil {
+		body.Error = &errorJSON{Message: err.Error()}
+		var pe *ParseError
+		if errors.As(err, &pe) {
+			body.Error.Input = &pe.Input
+			body.Error.Pos = &pe.Pos
+			body.Error.Token = &pe.Token
+		}
+	}
+
+	// Constraints are full of '<' and '>', so keep them readable rather than
+	// HTML-escaped. Strings and ints always encode, so the error is ignored.
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(body)
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+}
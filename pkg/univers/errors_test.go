@@ -0,0 +1,135 @@
+package univers
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestWrapParseError(t *testing.T) {
+	base := errors.New("bad constraint")
+
+	tests := []struct {
+		name      string
+		input     string
+		err       error
+		wantPos   int
+		wantToken string
+		wantMsg   string
+	}{
+		{
+			name:      "untokenized error covers trimmed input",
+			input:     "  >=x  ",
+			err:       base,
+			wantPos:   2,
+			wantToken: ">=x",
+			wantMsg:   `parsing "  >=x  " at position 2 (">=x"): bad constraint`,
+		},
+		{
+			name:    "empty input",
+			input:   "",
+			err:     base,
+			wantMsg: `parsing "": bad constraint`,
+		},
+		{
+			name:      "token error",
+			input:     ">=1.0 <bad",
+			err:       TokenError("<bad", base),
+			wantPos:   6,
+			wantToken: "<bad",
+		},
+		{
+			name:      "token error wrapped by caller",
+			input:     ">=1.0 <bad",
+			err:       fmt.Errorf("invalid constraint: %w", TokenError("<bad", base)),
+			wantPos:   6,
+			wantToken: "<bad",
+		},
+		{
+			name:      "token error at offset skips earlier occurrence",
+			input:     " >=, >=",
+			err:       TokenErrorAt(">=, >=", 3, ">=", base),
+			wantPos:   5,
+			wantToken: ">=",
+		},
+		{
+			name:      "nested token errors report innermost token",
+			input:     "^1.0 || >=2.0 <x",
+			err:       TokenErrorAt("^1.0 || >=2.0 <x", 7, ">=2.0 <x", TokenError("<x", base)),
+			wantPos:   14,
+			wantToken: "<x",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := WrapParseError(tt.input, tt.err)
+
+			var pe *ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("WrapParseError() = %v, want *ParseError", err)
+			}
+			if pe.Input != tt.input {
+				t.Errorf("Input = %q, want %q", pe.Input, tt.input)
+			}
+			if pe.Pos != tt.wantPos {
+				t.Errorf("Pos = %d, want %d", pe.Pos, tt.wantPos)
+			}
+			if pe.Token != tt.wantToken {
+				t.Errorf("Token = %q, want %q", pe.Token, tt.wantToken)
+			}
+			if !errors.Is(err, base) {
+				t.Errorf("errors.Is(err, base) = false, want true")
+			}
+			if tt.wantMsg != "" && err.Error() != tt.wantMsg {
+				t.Errorf("Error() = %q, want %q", err.Error(), tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestWrapParseError_Nil(t *testing.T) {
+	if err := WrapParseError("x", nil); err != nil {
+		t.Errorf("WrapParseError(nil) = %v, want nil", err)
+	}
+	if err := TokenError("x", nil); err != nil {
+		t.Errorf("TokenError(nil) = %v, want nil", err)
+	}
+}
+
+func TestErrorJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "nil",
+			err:  nil,
+			want: `{"error":null}`,
+		},
+		{
+			name: "plain error",
+			err:  errors.New("no command specified"),
+			want: `{"error":{"message":"no command specified"}}`,
+		},
+		{
+			name: "wrapped parse error",
+			err:  fmt.Errorf("invalid range: %w", WrapParseError(">=1.0 <bad", TokenError("<bad", errors.New("bad constraint")))),
+			want: `{"error":{"message":"invalid range: parsing \">=1.0 <bad\" at position 6 (\"<bad\"): bad constraint","input":">=1.0 <bad","pos":6,"token":"<bad"}}`,
+		},
+		{
+			name: "parse error at start of input",
+			err:  WrapParseError("", errors.New("empty version")),
+			want: `{"error":{"message":"parsing \"\": empty version","input":"","pos":0,"token":""}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(ErrorJSON(tt.err)); got != tt.want {
+				t.Errorf("ErrorJSON() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package univers
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Max returns the highest of the given versions as it appeared in the input.
+//
+// Every version that fails to parse is reported in the returned error, joined
+// with errors.Join, and the maximum of the remaining versions is still
+// returned, so batch callers get both the result and all rejects in one pass.
+// The result is empty only when no version parses.
+func Max[V Version[V], VR VersionRange[V]](e Ecosystem[V, VR], versions []string) (string, error) {
+	return extremum(e, versions, 1)
+}
+
+// Min returns the lowest of the given versions as it appeared in the input.
+// Invalid versions are handled as for Max.
+func Min[V Version[V], VR VersionRange[V]](e Ecosystem[V, VR], versions []string) (string, error) {
+	return extremum(e, versions, -1)
+}
+
+// extremum returns the version whose comparison against every other version
+// has the given sign, preferring the first on ties
+func extremum[V Version[V], VR VersionRange[V]](e Ecosystem[V, VR], versions []string, sign int) (string, error) {
+	if len(versions) == 0 {
+		return "", fmt.Errorf("no versions given")
+	}
+
+	var (
+		best     V
+		bestStr  string
+		found    bool
+		rejected []error
+	)
+	for _, s := range versions {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			rejected = append(rejected, fmt.Errorf("invalid %s version %q: %w", e.Name(), s, err))
+			continue
+		}
+		if !found || v.Compare(best)*sign > 0 {
+			best, bestStr, found = v, s, true
+		}
+	}
+	return bestStr, errors.Join(rejected...)
+}
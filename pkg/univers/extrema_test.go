@@ -0,0 +1,105 @@
+package univers_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/pypi"
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+func TestMax(t *testing.T) {
+	tests := []struct {
+		name        string
+		versions    []string
+		want        string
+		wantRejects []string
+		wantErr     bool
+	}{
+		{
+			name:     "highest version",
+			versions: []string{"1.0", "2.0rc1", "1.10", "2.0"},
+			want:     "2.0",
+		},
+		{
+			name:     "equal versions keep first spelling",
+			versions: []string{"1.0", "1.0.0"},
+			want:     "1.0",
+		},
+		{
+			name:        "all invalid inputs reported",
+			versions:    []string{"bad-1", "1.0", "bad-2", "3.0"},
+			want:        "3.0",
+			wantRejects: []string{"bad-1", "bad-2"},
+			wantErr:     true,
+		},
+		{
+			name:        "no valid versions",
+			versions:    []string{"bad-1"},
+			want:        "",
+			wantRejects: []string{"bad-1"},
+			wantErr:     true,
+		},
+		{
+			name:     "empty input",
+			versions: nil,
+			want:     "",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := univers.Max(&pypi.Ecosystem{}, tt.versions)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Max() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Max() = %q, want %q", got, tt.want)
+			}
+			for _, reject := range tt.wantRejects {
+				if !strings.Contains(err.Error(), reject) {
+					t.Errorf("Max() error = %v, want it to mention %q", err, reject)
+				}
+			}
+		})
+	}
+}
+
+func TestMin(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions []string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "lowest version",
+			versions: []string{"1.0", "1.0a1", "0.9", "1.0.post1"},
+			want:     "0.9",
+		},
+		{
+			name:     "prerelease sorts before release",
+			versions: []string{"1.0", "1.0b2", "1.0a1"},
+			want:     "1.0a1",
+		},
+		{
+			name:     "invalid inputs reported",
+			versions: []string{"2.0", "bad", "1.5"},
+			want:     "1.5",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := univers.Min(&pypi.Ecosystem{}, tt.versions)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Min() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Min() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
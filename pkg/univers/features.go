@@ -0,0 +1,134 @@
+package univers
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Names of range syntax features shared across ecosystems, so that feature
+// lists from different ecosystems can be lined up in a compatibility matrix.
+const (
+	// FeatureComparison is a version prefixed by an ordering operator, e.g. >=1.2.3.
+	FeatureComparison = "comparison"
+	// FeatureNotEqual excludes a single version, e.g. !=1.2.3.
+	FeatureNotEqual = "not-equal"
+	// FeatureExact is a version without an operator that matches only itself.
+	FeatureExact = "exact"
+	// FeatureMinimum is a version without an operator that matches itself and anything newer.
+	FeatureMinimum = "minimum"
+	// FeatureAnd intersects several constraints, e.g. >=1.0 <2.0.
+	FeatureAnd = "and"
+	// FeatureOr unions several constraint groups, e.g. 1.x || 2.x.
+	FeatureOr = "or"
+	// FeatureCaret allows changes that do not modify the left-most non-zero component, e.g. ^1.2.3.
+	FeatureCaret = "caret"
+	// FeatureTilde allows patch-level changes, e.g. ~1.2.3.
+	FeatureTilde = "tilde"
+	// FeaturePessimistic allows the last given component to increase, e.g. ~> 1.2.
+	FeaturePessimistic = "pessimistic"
+	// FeatureCompatibleRelease is the PEP 440 compatible release clause, e.g. ~=1.2.
+	FeatureCompatibleRelease = "compatible-release"
+	// FeatureWildcard matches any value for the starred components, e.g. 1.2.* or *.
+	FeatureWildcard = "wildcard"
+	// FeatureHyphen is an inclusive range between two versions, e.g. 1.2.3 - 2.3.4.
+	FeatureHyphen = "hyphen"
+	// FeatureInterval is mathematical interval notation, e.g. [1.0,2.0).
+	FeatureInterval = "interval"
+	// FeatureFuzzy matches versions sharing the given prefix, e.g. ~1.2.
+	FeatureFuzzy = "fuzzy"
+	// FeatureStability filters on a stability flag, e.g. @stable.
+	FeatureStability = "stability"
+	// FeatureArbitraryEquality compares version strings verbatim, e.g. ===1.0.
+	FeatureArbitraryEquality = "arbitrary-equality"
+)
+
+// Feature describes one piece of range syntax accepted by an ecosystem.
+type Feature struct {
+	// Name identifies the feature, usually one of the Feature* constants.
+	Name string
+	// Syntax is an example range using the feature. It is accepted by the
+	// ecosystem's NewVersionRange.
+	Syntax string
+	// Description explains what the syntax matches in this ecosystem.
+	Description string
+}
+
+// Featurer is optionally implemented by ecosystems that declare the range
+// syntax features their parser supports. Documentation generators and the CLI
+// use it to describe each ecosystem without hand-maintained tables.
+type Featurer interface {
+	// Features returns the supported range syntax features.
+	Features() []Feature
+}
+
+// featureOrder is the row order of FeatureMatrix for the shared feature names.
+var featureOrder = []string{
+	FeatureComparison,
+	FeatureNotEqual,
+	FeatureExact,
+	FeatureMinimum,
+	FeatureAnd,
+	FeatureOr,
+	FeatureCaret,
+	FeatureTilde,
+	FeaturePessimistic,
+	FeatureCompatibleRelease,
+	FeatureWildcard,
+	FeatureHyphen,
+	FeatureInterval,
+	FeatureFuzzy,
+	FeatureStability,
+	FeatureArbitraryEquality,
+}
+
+// FeatureMatrix renders a Markdown table with one row per feature and one
+// column per ecosystem, keyed by ecosystem name. Cells hold the example syntax
+// of supported features and are empty otherwise. Shared feature names come
+// first in their declaration order, followed by any other names sorted.
+func FeatureMatrix(features map[string][]Feature) string {
+	ecosystems := slices.Sorted(maps.Keys(features))
+
+	syntax := make(map[string]map[string]string)
+	var extra []string
+	for _, eco := range ecosystems {
+		for _, f := range features[eco] {
+			if _, ok := syntax[f.Name]; !ok {
+				syntax[f.Name] = make(map[string]string)
+				if !slices.Contains(featureOrder, f.Name) {
+					extra = append(extra, f.Name)
+				}
+			}
+			syntax[f.Name][eco] = f.Syntax
+		}
+	}
+	slices.Sort(extra)
+
+	var b strings.Builder
+	b.WriteString("| Feature |")
+	for _, eco := range ecosystems {
+		fmt.Fprintf(&b, " %s |", eco)
+	}
+	b.WriteString("\n|---|")
+	b.WriteString(strings.Repeat("---|", len(ecosystems)))
+	b.WriteString("\n")
+
+	for _, name := range append(slices.Clone(featureOrder), extra...) {
+		row, ok := syntax[name]
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(&b, "| %s |", name)
+		for _, eco := range ecosystems {
+			if s, ok := row[eco]; ok {
+				fmt.Fprintf(&b, " `%s` |", strings.ReplaceAll(s, "|", `\|`))
+			} else {
+				b.WriteString(" |")
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
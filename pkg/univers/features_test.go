@@ -0,0 +1,49 @@
+package univers_test
+
+import (
+	"testing"
+
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+func TestFeatureMatrix(t *testing.T) {
+	tests := []struct {
+		name     string
+		features map[string][]univers.Feature
+		want     string
+	}{
+		{
+			name:     "empty",
+			features: nil,
+			want:     "| Feature |\n|---|\n",
+		},
+		{
+			name: "rows in declaration order, columns sorted",
+			features: map[string][]univers.Feature{
+				"npm": {
+					{Name: univers.FeatureOr, Syntax: "1.x || 2.x"},
+					{Name: univers.FeatureComparison, Syntax: ">=1.2.3"},
+				},
+				"maven": {
+					{Name: univers.FeatureInterval, Syntax: "[1.0,2.0)"},
+					{Name: "custom", Syntax: "1.0+"},
+				},
+			},
+			want: "| Feature | maven | npm |\n" +
+				"|---|---|---|\n" +
+				"| comparison | | `>=1.2.3` |\n" +
+				"| or | | `1.x \\|\\| 2.x` |\n" +
+				"| interval | `[1.0,2.0)` | |\n" +
+				"| custom | `1.0+` | |\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := univers.FeatureMatrix(tt.features)
+			if got != tt.want {
+				t.Errorf("FeatureMatrix() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
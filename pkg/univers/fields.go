@@ -0,0 +1,55 @@
+package univers
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Field is a constraint split out of a range string by ConstraintFields.
+type Field struct {
+	// Text is the constraint, with any whitespace after its operator removed.
+	Text string
+	// Offset is the byte offset of the constraint within the range string.
+	Offset int
+}
+
+// ConstraintFields splits s at each run of runes for which isSep returns true,
+// or at whitespace if isSep is nil, and joins every field made only of
+// operator characters (<, >, =, !, ~, ^) to the field after it. Manifests
+// write ">= 1.2.3" as often as ">=1.2.3", so both yield the single field
+// ">=1.2.3", and ">= 1.0 < 2.0" yields ">=1.0" and "<2.0". An operator with
+// no field after it is returned on its own, for the caller to reject.
+func ConstraintFields(s string, isSep func(rune) bool) []Field {
+	if isSep == nil {
+		isSep = unicode.IsSpace
+	}
+
+	var (
+		fields  []Field
+		pending *Field
+		offset  int
+	)
+	for _, text := range strings.FieldsFunc(s, isSep) {
+		start := offset + strings.Index(s[offset:], text)
+		offset = start + len(text)
+
+		if pending != nil {
+			text, start = pending.Text+text, pending.Offset
+			pending = nil
+		}
+		if isOperator(text) {
+			pending = &Field{Text: text, Offset: start}
+			continue
+		}
+		fields = append(fields, Field{Text: text, Offset: start})
+	}
+	if pending != nil {
+		fields = append(fields, *pending)
+	}
+	return fields
+}
+
+// isOperator reports whether s consists only of operator characters
+func isOperator(s string) bool {
+	return strings.Trim(s, "<>=!~^") == ""
+}
@@ -0,0 +1,65 @@
+package univers_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+func TestConstraintFields(t *testing.T) {
+	isComma := func(r rune) bool { return r == ',' || r == ' ' }
+
+	tests := []struct {
+		name  string
+		input string
+		isSep func(rune) bool
+		want  []univers.Field
+	}{
+		{
+			name:  "no space after operator",
+			input: ">=1.0 <2.0",
+			want:  []univers.Field{{Text: ">=1.0", Offset: 0}, {Text: "<2.0", Offset: 6}},
+		},
+		{
+			name:  "space after operator",
+			input: ">= 1.0 <  2.0",
+			want:  []univers.Field{{Text: ">=1.0", Offset: 0}, {Text: "<2.0", Offset: 7}},
+		},
+		{
+			name:  "pessimistic operator",
+			input: "~> 1.2",
+			want:  []univers.Field{{Text: "~>1.2", Offset: 0}},
+		},
+		{
+			name:  "custom separators",
+			input: ">= 1.0, < 2.0",
+			isSep: isComma,
+			want:  []univers.Field{{Text: ">=1.0", Offset: 0}, {Text: "<2.0", Offset: 8}},
+		},
+		{
+			name:  "keyword is not an operator",
+			input: ">= 1.0 and < 2.0",
+			want:  []univers.Field{{Text: ">=1.0", Offset: 0}, {Text: "and", Offset: 7}, {Text: "<2.0", Offset: 11}},
+		},
+		{
+			name:  "trailing operator kept",
+			input: ">=1.0 <",
+			want:  []univers.Field{{Text: ">=1.0", Offset: 0}, {Text: "<", Offset: 6}},
+		},
+		{
+			name:  "empty",
+			input: "  ",
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := univers.ConstraintFields(tt.input, tt.isSep)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ConstraintFields(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package univers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/fnv"
+	"slices"
+)
+
+// Hasher is optionally implemented by version ranges that can compute a
+// content hash of their canonicalized constraints, for use as a dedup key or
+// cache index. Ranges that differ only in spelling, such as whitespace,
+// constraint order, duplicate constraints or equivalent version strings like
+// "1.0" and "1.0.0" where the ecosystem treats them as equal, hash the same.
+// Ranges with different constraints hash differently, barring collisions,
+// even when they happen to match the same versions.
+//
+// Hashes are deterministic across processes and platforms but, like sort
+// keys, are not guaranteed to be stable across releases of this module.
+//
+// Only the ranges of the cargo, debian, npm, pypi and semver ecosystems
+// implement Hasher. Check for it with a type assertion rather than assuming
+// every range has a hash.
+type Hasher interface {
+	// Hash returns the content hash of the range.
+	Hash() uint64
+}
+
+// HashedConstraint is one comparator of a range in the form hashed by
+// HashConstraints.
+type HashedConstraint struct {
+	// Operator is the comparator in a canonical spelling.
+	Operator string
+	// Key identifies the version. Versions that compare equal must have equal
+	// keys, as SortKeyer keys do.
+	Key []byte
+}
+
+// HashConstraints returns the 64-bit FNV-1a hash of a range given as an OR of
+// AND groups of constraints. Neither the order of constraints within a group,
+// the order of groups, nor duplicates affect the hash.
+func HashConstraints(groups [][]HashedConstraint) uint64 {
+	encodedGroups := make([][]byte, 0, len(groups))
+	for _, group := range groups {
+		encoded := make([][]byte, 0, len(group))
+		for _, c := range group {
+			var b []byte
+			b = appendLengthPrefixed(b, []byte(c.Operator))
+			b = appendLengthPrefixed(b, c.Key)
+			encoded = append(encoded, b)
+		}
+		encodedGroups = append(encodedGroups, joinSorted(encoded))
+	}
+
+	h := fnv.New64a()
+	h.Write(joinSorted(encodedGroups))
+	return h.Sum64()
+}
+
+// joinSorted sorts and deduplicates the items and concatenates them with
+// length prefixes, so that the result is independent of input order
+func joinSorted(items [][]byte) []byte {
+	slices.SortFunc(items, bytes.Compare)
+	items = slices.CompactFunc(items, bytes.Equal)
+
+	var b []byte
+	b = binary.AppendUvarint(b, uint64(len(items)))
+	for _, item := range items {
+		b = appendLengthPrefixed(b, item)
+	}
+	return b
+}
+
+// appendLengthPrefixed appends data preceded by its length
+func appendLengthPrefixed(b, data []byte) []byte {
+	b = binary.AppendUvarint(b, uint64(len(data)))
+	return append(b, data...)
+}
@@ -0,0 +1,78 @@
+package univers
+
+import "testing"
+
+func TestHashConstraints(t *testing.T) {
+	ge1 := HashedConstraint{Operator: ">=", Key: []byte{1}}
+	lt2 := HashedConstraint{Operator: "<", Key: []byte{2}}
+	eq3 := HashedConstraint{Operator: "=", Key: []byte{3}}
+
+	tests := []struct {
+		name     string
+		a, b     [][]HashedConstraint
+		wantSame bool
+	}{
+		{
+			name:     "identical",
+			a:        [][]HashedConstraint{{ge1, lt2}},
+			b:        [][]HashedConstraint{{ge1, lt2}},
+			wantSame: true,
+		},
+		{
+			name:     "constraint order",
+			a:        [][]HashedConstraint{{ge1, lt2}},
+			b:        [][]HashedConstraint{{lt2, ge1}},
+			wantSame: true,
+		},
+		{
+			name:     "group order",
+			a:        [][]HashedConstraint{{ge1, lt2}, {eq3}},
+			b:        [][]HashedConstraint{{eq3}, {lt2, ge1}},
+			wantSame: true,
+		},
+		{
+			name:     "duplicates",
+			a:        [][]HashedConstraint{{ge1, ge1, lt2}, {eq3}, {eq3}},
+			b:        [][]HashedConstraint{{ge1, lt2}, {eq3}},
+			wantSame: true,
+		},
+		{
+			name: "different operator",
+			a:    [][]HashedConstraint{{ge1}},
+			b:    [][]HashedConstraint{{{Operator: ">", Key: []byte{1}}}},
+		},
+		{
+			name: "different key",
+			a:    [][]HashedConstraint{{ge1}},
+			b:    [][]HashedConstraint{{{Operator: ">=", Key: []byte{2}}}},
+		},
+		{
+			name: "AND versus OR",
+			a:    [][]HashedConstraint{{ge1, lt2}},
+			b:    [][]HashedConstraint{{ge1}, {lt2}},
+		},
+		{
+			name: "field boundaries",
+			a:    [][]HashedConstraint{{{Operator: ">", Key: []byte("=1")}}},
+			b:    [][]HashedConstraint{{{Operator: ">=", Key: []byte("1")}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ha, hb := HashConstraints(tt.a), HashConstraints(tt.b)
+			if (ha == hb) != tt.wantSame {
+				t.Errorf("HashConstraints() = %#x and %#x, want same = %v", ha, hb, tt.wantSame)
+			}
+		})
+	}
+}
+
+func TestHashConstraints_Deterministic(t *testing.T) {
+	// The hash is persisted by callers, so its value must not change by accident
+	groups := [][]HashedConstraint{{{Operator: ">=", Key: []byte("1.0.0")}}}
+	const want = uint64(0xd3cdffaae304b29c)
+	if got := HashConstraints(groups); got != want {
+		t.Errorf("HashConstraints() = %#x, want %#x", got, want)
+	}
+}
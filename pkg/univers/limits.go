@@ -0,0 +1,78 @@
+package univers
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// ErrInputTooLong is wrapped by the error a parser returns for a version or
+// range longer than Limits.MaxInputLength.
+var ErrInputTooLong = errors.New("input too long")
+
+// ErrTooManyConstraints is wrapped by the error a parser returns for a range
+// with more constraints than Limits.MaxConstraints.
+var ErrTooManyConstraints = errors.New("too many constraints")
+
+// Limits bounds the input every NewVersion and NewVersionRange accepts, so
+// that untrusted advisory data cannot make parsing arbitrarily expensive.
+type Limits struct {
+	// MaxInputLength is the longest version or range string accepted, in
+	// bytes. Zero means no limit.
+	MaxInputLength int
+	// MaxConstraints is the most constraints a range may hold, counted across
+	// all of its OR groups. Zero means no limit.
+	MaxConstraints int
+}
+
+// DefaultLimits are the limits in effect until SetLimits is called. They are
+// far above anything found in real advisories or manifests.
+var DefaultLimits = Limits{
+	MaxInputLength: 16 << 10,
+	MaxConstraints: 1024,
+}
+
+// limits holds the Limits set by SetLimits
+var limits atomic.Pointer[Limits]
+
+// SetLimits replaces the limits applied by every parser. It is safe to call
+// concurrently with parsing, which sees either the old or the new limits.
+func SetLimits(l Limits) {
+	limits.Store(&l)
+}
+
+// CurrentLimits returns the limits applied by every parser.
+func CurrentLimits() Limits {
+	if l := limits.Load(); l != nil {
+		return *l
+	}
+	return DefaultLimits
+}
+
+// CheckLength returns an error wrapping ErrInputTooLong if s is longer than
+// the current MaxInputLength. Parsers call it before doing any other work.
+func CheckLength(s string) error {
+	if limit := CurrentLimits().MaxInputLength; limit > 0 && len(s) > limit {
+		return fmt.Errorf("%w: %d bytes exceeds the limit of %d", ErrInputTooLong, len(s), limit)
+	}
+	return nil
+}
+
+// CheckConstraints returns an error wrapping ErrTooManyConstraints if n is
+// more than the current MaxConstraints.
+func CheckConstraints(n int) error {
+	if limit := CurrentLimits().MaxConstraints; limit > 0 && n > limit {
+		return fmt.Errorf("%w: %d exceeds the limit of %d", ErrTooManyConstraints, n, limit)
+	}
+	return nil
+}
+
+// CheckConstraintGroups is CheckConstraints for a range held as OR groups of
+// AND constraints, counting the constraints of every group.
+func CheckConstraintGroups[T any](groups [][]T) error {
+	n := 0
+	for _, group := range groups {
+		n += len(group)
+	}
+	return CheckConstraints(n)
+}
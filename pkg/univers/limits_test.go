@@ -0,0 +1,126 @@
+package univers_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/maven"
+	"github.com/alowayed/go-univers/pkg/ecosystem/npm"
+	"github.com/alowayed/go-univers/pkg/ecosystem/pypi"
+	"github.com/alowayed/go-univers/pkg/ecosystem/semver"
+	"github.com/alowayed/go-univers/pkg/spec/vers"
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+// setLimits applies l for the rest of the test
+func setLimits(t *testing.T, l univers.Limits) {
+	t.Helper()
+	old := univers.CurrentLimits()
+	univers.SetLimits(l)
+	t.Cleanup(func() { univers.SetLimits(old) })
+}
+
+func TestCurrentLimits(t *testing.T) {
+	if got := univers.CurrentLimits(); got != univers.DefaultLimits {
+		t.Errorf("CurrentLimits() = %+v, want %+v", got, univers.DefaultLimits)
+	}
+	setLimits(t, univers.Limits{MaxInputLength: 8})
+	if got := univers.CurrentLimits(); got.MaxInputLength != 8 || got.MaxConstraints != 0 {
+		t.Errorf("CurrentLimits() = %+v after SetLimits", got)
+	}
+}
+
+func TestCheckLength(t *testing.T) {
+	setLimits(t, univers.Limits{MaxInputLength: 5})
+	if err := univers.CheckLength("1.2.3"); err != nil {
+		t.Errorf("CheckLength(%q) error = %v", "1.2.3", err)
+	}
+	if err := univers.CheckLength("1.2.3a"); !errors.Is(err, univers.ErrInputTooLong) {
+		t.Errorf("CheckLength(%q) error = %v, want ErrInputTooLong", "1.2.3a", err)
+	}
+
+	setLimits(t, univers.Limits{})
+	if err := univers.CheckLength(strings.Repeat("1", 1<<20)); err != nil {
+		t.Errorf("CheckLength() with no limit error = %v", err)
+	}
+}
+
+func TestCheckConstraintGroups(t *testing.T) {
+	setLimits(t, univers.Limits{MaxConstraints: 3})
+	if err := univers.CheckConstraintGroups([][]int{{1, 2}, {3}}); err != nil {
+		t.Errorf("CheckConstraintGroups() error = %v", err)
+	}
+	if err := univers.CheckConstraintGroups([][]int{{1, 2}, {3, 4}}); !errors.Is(err, univers.ErrTooManyConstraints) {
+		t.Errorf("CheckConstraintGroups() error = %v, want ErrTooManyConstraints", err)
+	}
+}
+
+func TestLimits_Parsers(t *testing.T) {
+	setLimits(t, univers.Limits{MaxInputLength: 64, MaxConstraints: 4})
+
+	longVersion := "1." + strings.Repeat("0", 64)
+	tests := []struct {
+		name    string
+		parse   func() error
+		wantErr error
+	}{
+		{
+			name:    "npm long version",
+			parse:   func() error { _, err := (&npm.Ecosystem{}).NewVersion(longVersion); return err },
+			wantErr: univers.ErrInputTooLong,
+		},
+		{
+			name: "npm many alternatives",
+			parse: func() error {
+				_, err := (&npm.Ecosystem{}).NewVersionRange("1.0.0 || 2.0.0 || 3.0.0 || 4.0.0 || 5.0.0")
+				return err
+			},
+			wantErr: univers.ErrTooManyConstraints,
+		},
+		{
+			name: "npm within limits",
+			parse: func() error {
+				_, err := (&npm.Ecosystem{}).NewVersionRange("1.0.0 || 2.0.0 || 3.0.0 || 4.0.0")
+				return err
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "pypi many constraints",
+			parse:   func() error { _, err := (&pypi.Ecosystem{}).NewVersionRange("!=1,!=2,!=3,!=4,!=5"); return err },
+			wantErr: univers.ErrTooManyConstraints,
+		},
+		{
+			name:    "maven long range",
+			parse:   func() error { _, err := (&maven.Ecosystem{}).NewVersionRange("[" + longVersion + ",)"); return err },
+			wantErr: univers.ErrInputTooLong,
+		},
+		{
+			name:    "semver long range",
+			parse:   func() error { _, err := (&semver.Ecosystem{}).NewVersionRange(">=" + longVersion); return err },
+			wantErr: univers.ErrInputTooLong,
+		},
+		{
+			name:    "vers many constraints",
+			parse:   func() error { _, err := vers.Contains("vers:npm/1.0.0|1.0.1|1.0.2|1.0.3|1.0.4", "1.0.0"); return err },
+			wantErr: univers.ErrTooManyConstraints,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.parse()
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("parse error = %v", err)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("parse error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if npm.ValidVersion(longVersion) {
+		t.Errorf("npm.ValidVersion() = true for a version over the limit")
+	}
+}
@@ -0,0 +1,125 @@
+package univers
+
+import "expvar"
+
+// Metric names a counter reported to a Metrics implementation.
+type Metric string
+
+const (
+	// MetricVersionParsed counts version strings parsed successfully.
+	MetricVersionParsed Metric = "version_parsed"
+	// MetricVersionInvalid counts version strings that failed to parse.
+	MetricVersionInvalid Metric = "version_invalid"
+	// MetricRangeParsed counts range strings parsed successfully.
+	MetricRangeParsed Metric = "range_parsed"
+	// MetricRangeInvalid counts range strings that failed to parse.
+	MetricRangeInvalid Metric = "range_invalid"
+	// MetricContains counts Contains calls on parsed ranges.
+	MetricContains Metric = "contains"
+	// MetricCacheHit counts inputs answered by WithNegativeCache without
+	// being parsed again.
+	MetricCacheHit Metric = "cache_hit"
+)
+
+// Metrics receives counters from ecosystems wrapped by WithMetrics. Inc is
+// called once per event and must be safe for concurrent use if the ecosystem
+// is used concurrently. Adapters for Prometheus, OpenTelemetry and the like
+// only need to map ecosystem and metric to a labeled counter.
+type Metrics interface {
+	Inc(ecosystem string, metric Metric)
+}
+
+// MetricsFunc adapts a function to the Metrics interface.
+type MetricsFunc func(ecosystem string, metric Metric)
+
+// Inc calls f(ecosystem, metric).
+func (f MetricsFunc) Inc(ecosystem string, metric Metric) {
+	f(ecosystem, metric)
+}
+
+// ExpvarMetrics publishes counters to an expvar.Map under keys of the form
+// "npm.version_invalid". Create the map with expvar.NewMap to serve it from
+// /debug/vars.
+type ExpvarMetrics struct {
+	Map *expvar.Map
+}
+
+// Inc adds one to the ecosystem's counter for metric.
+func (m ExpvarMetrics) Inc(ecosystem string, metric Metric) {
+	m.Map.Add(ecosystem+"."+string(metric), 1)
+}
+
+// MeteredVersionRange wraps a version range and counts its Contains calls.
+type MeteredVersionRange[V Version[V], VR VersionRange[V]] struct {
+	r         VR
+	ecosystem string
+	metrics   Metrics
+}
+
+// Contains checks if a version is within the wrapped range and counts the call.
+func (m *MeteredVersionRange[V, VR]) Contains(version V) bool {
+	m.metrics.Inc(m.ecosystem, MetricContains)
+	return m.r.Contains(version)
+}
+
+// String returns the original string representation of the wrapped range.
+func (m *MeteredVersionRange[V, VR]) String() string {
+	return m.r.String()
+}
+
+// Unwrap returns the wrapped version range.
+func (m *MeteredVersionRange[V, VR]) Unwrap() VR {
+	return m.r
+}
+
+// meteredEcosystem wraps an ecosystem and reports counters for each operation.
+type meteredEcosystem[V Version[V], VR VersionRange[V]] struct {
+	e       Ecosystem[V, VR]
+	metrics Metrics
+}
+
+// WithMetrics wraps an ecosystem so that parses, by outcome, and Contains
+// calls are counted in metrics. Long-running services can then watch the
+// share of invalid versions in their input without instrumenting each call
+// site. When e was returned by WithNegativeCache, inputs it answers from the
+// cache are also counted as MetricCacheHit.
+func WithMetrics[V Version[V], VR VersionRange[V]](
+	e Ecosystem[V, VR],
+	metrics Metrics,
+) Ecosystem[V, *MeteredVersionRange[V, VR]] {
+	return &meteredEcosystem[V, VR]{e: e, metrics: metrics}
+}
+
+func (m *meteredEcosystem[V, VR]) Name() string {
+	return m.e.Name()
+}
+
+func (m *meteredEcosystem[V, VR]) NewVersion(s string) (V, error) {
+	name := m.e.Name()
+	if c, ok := m.e.(*negativeCachedEcosystem[V, VR]); ok && c.versions.get(s) != nil {
+		m.metrics.Inc(name, MetricCacheHit)
+	}
+
+	v, err := m.e.NewVersion(s)
+	if err != nil {
+		m.metrics.Inc(name, MetricVersionInvalid)
+		return v, err
+	}
+	m.metrics.Inc(name, MetricVersionParsed)
+	return v, nil
+}
+
+func (m *meteredEcosystem[V, VR]) NewVersionRange(s string) (*MeteredVersionRange[V, VR], error) {
+	name := m.e.Name()
+	if c, ok := m.e.(*negativeCachedEcosystem[V, VR]); ok && c.ranges.get(s) != nil {
+		m.metrics.Inc(name, MetricCacheHit)
+	}
+
+	r, err := m.e.NewVersionRange(s)
+	if err != nil {
+		m.metrics.Inc(name, MetricRangeInvalid)
+		return nil, err
+	}
+	m.metrics.Inc(name, MetricRangeParsed)
+	return &MeteredVersionRange[V, VR]{r: r, ecosystem: name, metrics: m.metrics}, nil
+}
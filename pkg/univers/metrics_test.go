@@ -0,0 +1,90 @@
+package univers_test
+
+import (
+	"expvar"
+	"reflect"
+	"testing"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/npm"
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+func TestWithMetrics(t *testing.T) {
+	tests := []struct {
+		name  string
+		cache bool
+		want  map[univers.Metric]int
+	}{
+		{
+			name: "plain ecosystem",
+			want: map[univers.Metric]int{
+				univers.MetricVersionParsed:  1,
+				univers.MetricVersionInvalid: 2,
+				univers.MetricRangeParsed:    1,
+				univers.MetricRangeInvalid:   1,
+				univers.MetricContains:       2,
+			},
+		},
+		{
+			name:  "negative cache",
+			cache: true,
+			want: map[univers.Metric]int{
+				univers.MetricVersionParsed:  1,
+				univers.MetricVersionInvalid: 2,
+				univers.MetricRangeParsed:    1,
+				univers.MetricRangeInvalid:   1,
+				univers.MetricContains:       2,
+				univers.MetricCacheHit:       1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var e univers.Ecosystem[*npm.Version, *npm.VersionRange] = &npm.Ecosystem{}
+			if tt.cache {
+				e = univers.WithNegativeCache(e, 10)
+			}
+			got := map[univers.Metric]int{}
+			metered := univers.WithMetrics(e, univers.MetricsFunc(func(ecosystem string, metric univers.Metric) {
+				if ecosystem != npm.Name {
+					t.Errorf("Inc() ecosystem = %q, want %q", ecosystem, npm.Name)
+				}
+				got[metric]++
+			}))
+
+			r, err := metered.NewVersionRange("^1.0.0")
+			if err != nil {
+				t.Fatalf("NewVersionRange() error = %v", err)
+			}
+			v, err := metered.NewVersion("1.2.0")
+			if err != nil {
+				t.Fatalf("NewVersion() error = %v", err)
+			}
+			r.Contains(v)
+			r.Contains(v)
+			metered.NewVersion("bad")
+			metered.NewVersion("bad")
+			metered.NewVersionRange("")
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("counters = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpvarMetrics_Inc(t *testing.T) {
+	m := univers.ExpvarMetrics{Map: new(expvar.Map)}
+	e := univers.WithMetrics(&npm.Ecosystem{}, m)
+	e.NewVersion("1.0.0")
+	e.NewVersion("bad")
+	e.NewVersion("worse")
+
+	for key, want := range map[string]string{"npm.version_parsed": "1", "npm.version_invalid": "2"} {
+		got := m.Map.Get(key)
+		if got == nil || got.String() != want {
+			t.Errorf("Map.Get(%q) = %v, want %s", key, got, want)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package univers
+
+import "fmt"
+
+// MustNewVersion is like e.NewVersion but panics if the version does not
+// parse. It is meant for tests and package-level variables holding known-good
+// versions, not for user input.
+func MustNewVersion[V Version[V], VR VersionRange[V]](e Ecosystem[V, VR], version string) V {
+	v, err := e.NewVersion(version)
+	if err != nil {
+		panic(fmt.Sprintf("univers: MustNewVersion(%q) for %s: %v", version, e.Name(), err))
+	}
+	return v
+}
+
+// MustNewVersionRange is like e.NewVersionRange but panics if the range does
+// not parse. Like MustNewVersion, it is meant for tests and package-level
+// variables, for example:
+//
+//	var affected = univers.MustNewVersionRange(&npm.Ecosystem{}, ">=1.2.0 <1.4.2")
+func MustNewVersionRange[V Version[V], VR VersionRange[V]](e Ecosystem[V, VR], rangeStr string) VR {
+	vr, err := e.NewVersionRange(rangeStr)
+	if err != nil {
+		panic(fmt.Sprintf("univers: MustNewVersionRange(%q) for %s: %v", rangeStr, e.Name(), err))
+	}
+	return vr
+}
@@ -0,0 +1,50 @@
+package univers_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/npm"
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+func TestMustNewVersion(t *testing.T) {
+	e := &npm.Ecosystem{}
+
+	if got := univers.MustNewVersion(e, "1.2.3").String(); got != "1.2.3" {
+		t.Errorf("MustNewVersion(%q).String() = %q", "1.2.3", got)
+	}
+
+	wantPanic(t, `MustNewVersion("not-a-version") for npm`, func() {
+		univers.MustNewVersion(e, "not-a-version")
+	})
+}
+
+func TestMustNewVersionRange(t *testing.T) {
+	e := &npm.Ecosystem{}
+
+	vr := univers.MustNewVersionRange(e, ">=1.2.0 <1.4.2")
+	if !vr.Contains(univers.MustNewVersion(e, "1.3.0")) {
+		t.Errorf("MustNewVersionRange(%q).Contains(%q) = false, want true", ">=1.2.0 <1.4.2", "1.3.0")
+	}
+
+	wantPanic(t, `MustNewVersionRange("~~1.0") for npm`, func() {
+		univers.MustNewVersionRange(e, "~~1.0")
+	})
+}
+
+// wantPanic fails the test unless f panics with a message containing want
+func wantPanic(t *testing.T, want string, f func()) {
+	t.Helper()
+	defer func() {
+		t.Helper()
+		r := recover()
+		if r == nil {
+			t.Fatalf("expected panic containing %q", want)
+		}
+		if msg, _ := r.(string); !strings.Contains(msg, want) {
+			t.Errorf("panic = %v, want message containing %q", r, want)
+		}
+	}()
+	f()
+}
@@ -0,0 +1,105 @@
+package univers
+
+import (
+	"maps"
+	"slices"
+)
+
+// EcosystemName is the canonical name of a core ecosystem, as returned by its
+// Ecosystem.Name method and accepted by the CLI.
+type EcosystemName string
+
+// Canonical names of the core ecosystems under pkg/ecosystem
+const (
+	Alpine     EcosystemName = "alpine"
+	ALPM       EcosystemName = "alpm"
+	Apache     EcosystemName = "apache"
+	Bazel      EcosystemName = "bazel"
+	Cargo      EcosystemName = "cargo"
+	Composer   EcosystemName = "composer"
+	Conan      EcosystemName = "conan"
+	CPAN       EcosystemName = "cpan"
+	CRAN       EcosystemName = "cran"
+	Debian     EcosystemName = "debian"
+	Gem        EcosystemName = "gem"
+	GenericWin EcosystemName = "generic-win"
+	Gentoo     EcosystemName = "gentoo"
+	GitHub     EcosystemName = "github"
+	Golang     EcosystemName = "golang"
+	GoVer      EcosystemName = "gover"
+	Hex        EcosystemName = "hex"
+	LuaRocks   EcosystemName = "luarocks"
+	Mattermost EcosystemName = "mattermost"
+	Maven      EcosystemName = "maven"
+	MSVer      EcosystemName = "msver"
+	NPM        EcosystemName = "npm"
+	NuGet      EcosystemName = "nuget"
+	PyPI       EcosystemName = "pypi"
+	RPM        EcosystemName = "rpm"
+	SemVer     EcosystemName = "semver"
+)
+
+// ecosystemNames lists every core ecosystem name in sorted order
+var ecosystemNames = []EcosystemName{
+	Alpine, ALPM, Apache, Bazel, Cargo, Composer, Conan, CPAN, CRAN, Debian,
+	Gem, GenericWin, Gentoo, GitHub, Golang, GoVer, Hex, LuaRocks, Mattermost,
+	Maven, MSVer, NPM, NuGet, PyPI, RPM, SemVer,
+}
+
+// ecosystemAliases maps alternative names, such as VERS schemes and common
+// package manager names, to the canonical ecosystem name
+var ecosystemAliases = map[string]EcosystemName{
+	"apk":       Alpine,
+	"arch":      ALPM,
+	"bzlmod":    Bazel,
+	"crates":    Cargo,
+	"deb":       Debian,
+	"ebuild":    Gentoo,
+	"generic":   SemVer,
+	"go":        Golang,
+	"gomod":     Golang,
+	"packagist": Composer,
+	"rubygems":  Gem,
+}
+
+// EcosystemNames returns the names of all core ecosystems in sorted order.
+func EcosystemNames() []EcosystemName {
+	return slices.Clone(ecosystemNames)
+}
+
+// EcosystemAliases returns the alternative names accepted by
+// ParseEcosystemName, mapped to the ecosystem they stand for.
+func EcosystemAliases() map[string]EcosystemName {
+	return maps.Clone(ecosystemAliases)
+}
+
+// ParseEcosystemName returns the core ecosystem called name, which may be a
+// canonical name or an alias. For example "go", "gomod" and "golang" all
+// return Golang.
+func ParseEcosystemName(name string) (EcosystemName, bool) {
+	if n, ok := ecosystemAliases[name]; ok {
+		return n, true
+	}
+	n := EcosystemName(name)
+	if _, found := slices.BinarySearch(ecosystemNames, n); !found {
+		return "", false
+	}
+	return n, true
+}
+
+// Aliases returns the sorted alternative names of the ecosystem.
+func (n EcosystemName) Aliases() []string {
+	var aliases []string
+	for alias, canonical := range ecosystemAliases {
+		if canonical == n {
+			aliases = append(aliases, alias)
+		}
+	}
+	slices.Sort(aliases)
+	return aliases
+}
+
+// String returns the name as a string.
+func (n EcosystemName) String() string {
+	return string(n)
+}
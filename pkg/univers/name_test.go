@@ -0,0 +1,68 @@
+package univers
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseEcosystemName(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   EcosystemName
+		wantOK bool
+	}{
+		{name: "npm", want: NPM, wantOK: true},
+		{name: "golang", want: Golang, wantOK: true},
+		{name: "go", want: Golang, wantOK: true},
+		{name: "gomod", want: Golang, wantOK: true},
+		{name: "deb", want: Debian, wantOK: true},
+		{name: "generic", want: SemVer, wantOK: true},
+		{name: "arch", want: ALPM, wantOK: true},
+		{name: "NPM", wantOK: false},
+		{name: "unknown", wantOK: false},
+		{name: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseEcosystemName(tt.name)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("ParseEcosystemName(%q) = %q, %v, want %q, %v", tt.name, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestEcosystemNames(t *testing.T) {
+	names := EcosystemNames()
+	if !slices.IsSorted(names) {
+		t.Errorf("EcosystemNames() = %v, want sorted", names)
+	}
+	for alias, canonical := range EcosystemAliases() {
+		if !slices.Contains(names, canonical) {
+			t.Errorf("alias %q maps to unknown ecosystem %q", alias, canonical)
+		}
+		if _, ok := slices.BinarySearch(names, EcosystemName(alias)); ok {
+			t.Errorf("alias %q shadows an ecosystem name", alias)
+		}
+	}
+}
+
+func TestEcosystemName_Aliases(t *testing.T) {
+	tests := []struct {
+		name EcosystemName
+		want []string
+	}{
+		{name: Golang, want: []string{"go", "gomod"}},
+		{name: Debian, want: []string{"deb"}},
+		{name: NPM, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.name), func(t *testing.T) {
+			if got := tt.name.Aliases(); !slices.Equal(got, tt.want) {
+				t.Errorf("Aliases() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package univers
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+)
+
+// Nearest returns the candidate closest to target, as it appeared in the
+// input, for suggestions such as "did you mean 2.3.4?" when target is not a
+// published version. Target does not have to be valid in e.
+//
+// Closeness is measured on the numeric release components, read as for
+// SameRelease: the candidate sharing the longest prefix of components with
+// target wins, then the one whose first differing component is nearest in
+// value, then the one whose prerelease or other qualifier shares the most
+// leading tokens with target's, as "beta.2" does with "beta.3". Missing
+// components count as zero, so "2.3" is as close to "2.3.0" as can be.
+// Remaining ties go to a release over a prerelease, then to the higher
+// version, since it is the likelier fix.
+//
+// Invalid candidates are reported as for Max. The result is empty only when
+// no candidate parses.
+func Nearest[V Version[V], VR VersionRange[V]](e Ecosystem[V, VR], target string, candidates []string) (string, error) {
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("no candidates given")
+	}
+
+	want := splitRelease(target)
+	var (
+		best     nearestCandidate[V]
+		found    bool
+		rejected []error
+	)
+	for _, s := range candidates {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			rejected = append(rejected, fmt.Errorf("invalid %s version %q: %w", e.Name(), s, err))
+			continue
+		}
+		c := newNearestCandidate(want, s, v)
+		if !found || c.closerThan(best) {
+			best, found = c, true
+		}
+	}
+	return best.input, errors.Join(rejected...)
+}
+
+// nearestCandidate is a parsed candidate with its distance to the target
+type nearestCandidate[V Version[V]] struct {
+	input           string
+	version         V
+	shared          int    // leading numeric components equal to the target's
+	gap             uint64 // difference at the first unequal component
+	qualifierShared int    // leading qualifier tokens equal to the target's
+	qualifierDiffer bool   // qualifiers differ once aliases are normalized
+	prerelease      bool   // has a qualifier
+}
+
+func newNearestCandidate[V Version[V]](want releaseParts, input string, v V) nearestCandidate[V] {
+	got := splitRelease(input)
+	c := nearestCandidate[V]{input: input, version: v}
+
+	n := max(len(want.core), len(got.core))
+	for c.shared < n {
+		a, b := component(want.core, c.shared), component(got.core, c.shared)
+		if a != b {
+			c.gap = max(a, b) - min(a, b)
+			break
+		}
+		c.shared++
+	}
+
+	wq, gq := canonicalQualifier(want.qualifier), canonicalQualifier(got.qualifier)
+	for c.qualifierShared < min(len(wq), len(gq)) && wq[c.qualifierShared] == gq[c.qualifierShared] {
+		c.qualifierShared++
+	}
+	c.qualifierDiffer = !slices.Equal(wq, gq)
+	c.prerelease = len(gq) > 0
+	return c
+}
+
+// closerThan reports whether c is a better suggestion than other
+func (c nearestCandidate[V]) closerThan(other nearestCandidate[V]) bool {
+	if d := cmp.Compare(c.shared, other.shared); d != 0 {
+		return d > 0
+	}
+	if d := cmp.Compare(c.gap, other.gap); d != 0 {
+		return d < 0
+	}
+	if d := cmp.Compare(c.qualifierShared, other.qualifierShared); d != 0 {
+		return d > 0
+	}
+	if c.qualifierDiffer != other.qualifierDiffer {
+		return !c.qualifierDiffer
+	}
+	if c.prerelease != other.prerelease {
+		return !c.prerelease
+	}
+	return After(c.version, other.version)
+}
+
+// component returns the i-th numeric component of core, zero past its end
+// and saturated at the largest uint64
+func component(core []string, i int) uint64 {
+	if i >= len(core) {
+		return 0
+	}
+	n, err := strconv.ParseUint(core[i], 10, 64)
+	if err != nil {
+		return math.MaxUint64
+	}
+	return n
+}
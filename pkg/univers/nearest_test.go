@@ -0,0 +1,110 @@
+package univers_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/npm"
+	"github.com/alowayed/go-univers/pkg/ecosystem/pypi"
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+func TestNearest(t *testing.T) {
+	tests := []struct {
+		name        string
+		target      string
+		candidates  []string
+		want        string
+		wantRejects []string
+		wantErr     bool
+	}{
+		{
+			name:       "exact match",
+			target:     "2.3.4",
+			candidates: []string{"2.3.3", "2.3.4", "2.3.5"},
+			want:       "2.3.4",
+		},
+		{
+			name:       "unpublished patch suggests the nearest patch",
+			target:     "2.3.9",
+			candidates: []string{"1.9.9", "2.3.4", "2.4.0", "3.0.0"},
+			want:       "2.3.4",
+		},
+		{
+			name:       "shared prefix beats numeric gap",
+			target:     "2.3.0",
+			candidates: []string{"2.2.0", "2.3.40"},
+			want:       "2.3.40",
+		},
+		{
+			name:       "equal gap prefers the higher version",
+			target:     "2.3.5",
+			candidates: []string{"2.3.4", "2.3.6"},
+			want:       "2.3.6",
+		},
+		{
+			name:       "prerelease of the missing release",
+			target:     "2.4.0",
+			candidates: []string{"2.3.0", "2.4.0-rc.1", "2.5.0"},
+			want:       "2.4.0-rc.1",
+		},
+		{
+			name:       "release preferred over prerelease",
+			target:     "2.4.1",
+			candidates: []string{"2.4.0-rc.1", "2.4.2"},
+			want:       "2.4.2",
+		},
+		{
+			name:       "matching prerelease preferred",
+			target:     "2.0.0-beta.3",
+			candidates: []string{"2.0.0-alpha.1", "2.0.0-beta.2", "2.0.0"},
+			want:       "2.0.0-beta.2",
+		},
+		{
+			name:       "invalid target still gets a suggestion",
+			target:     "v2.3",
+			candidates: []string{"1.0.0", "2.3.1", "2.4.0"},
+			want:       "2.3.1",
+		},
+		{
+			name:        "invalid candidates reported",
+			target:      "1.2.3",
+			candidates:  []string{"bad", "1.2.0"},
+			want:        "1.2.0",
+			wantRejects: []string{"bad"},
+			wantErr:     true,
+		},
+		{
+			name:    "no candidates",
+			target:  "1.2.3",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := univers.Nearest(&npm.Ecosystem{}, tt.target, tt.candidates)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Nearest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Nearest() = %q, want %q", got, tt.want)
+			}
+			for _, reject := range tt.wantRejects {
+				if !strings.Contains(err.Error(), reject) {
+					t.Errorf("Nearest() error = %v, want it to mention %q", err, reject)
+				}
+			}
+		})
+	}
+}
+
+func TestNearest_Pypi(t *testing.T) {
+	got, err := univers.Nearest(&pypi.Ecosystem{}, "4.2.30", []string{"4.1.13", "4.2", "4.2.16", "5.0"})
+	if err != nil {
+		t.Fatalf("Nearest() error = %v", err)
+	}
+	if got != "4.2.16" {
+		t.Errorf("Nearest() = %q, want %q", got, "4.2.16")
+	}
+}
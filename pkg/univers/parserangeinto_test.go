@@ -0,0 +1,105 @@
+package univers_test
+
+import (
+	"testing"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/maven"
+	"github.com/alowayed/go-univers/pkg/ecosystem/npm"
+	"github.com/alowayed/go-univers/pkg/ecosystem/pypi"
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+// parseRangeIntoCase checks an ecosystem's ParseRangeInto against its
+// NewVersionRange, parsing every range into the same destination
+type parseRangeIntoCase struct {
+	check func(t *testing.T, tt parseRangeIntoCase)
+	// ranges are parsed in order, so later ones reuse the storage of earlier
+	// ones, and each is checked against versions
+	ranges   []string
+	versions []string
+	invalid  string
+	// matchesAfterError is whether the destination matches every version,
+	// rather than none, once parsing invalid fails
+	matchesAfterError bool
+}
+
+// rangeParserInto is an ecosystem with a ParseRangeInto method
+type rangeParserInto[V univers.Version[V], VR univers.VersionRange[V]] interface {
+	univers.Ecosystem[V, VR]
+	ParseRangeInto(dst VR, rangeStr string) error
+}
+
+// checkParseRangeInto adapts an ecosystem to parseRangeIntoCase.check
+func checkParseRangeInto[V univers.Version[V], R any, VR interface {
+	*R
+	univers.VersionRange[V]
+}](e rangeParserInto[V, VR]) func(*testing.T, parseRangeIntoCase) {
+	return func(t *testing.T, tt parseRangeIntoCase) {
+		versions := make([]V, len(tt.versions))
+		for i, s := range tt.versions {
+			versions[i] = univers.MustNewVersion(e, s)
+		}
+
+		dst := VR(new(R))
+		for _, rangeStr := range tt.ranges {
+			if err := e.ParseRangeInto(dst, rangeStr); err != nil {
+				t.Fatalf("ParseRangeInto(%q) error = %v", rangeStr, err)
+			}
+			want := univers.MustNewVersionRange(e, rangeStr)
+			if dst.String() != want.String() {
+				t.Errorf("ParseRangeInto(%q) = %q, want %q", rangeStr, dst, want)
+			}
+			for i, v := range versions {
+				if got := dst.Contains(v); got != want.Contains(v) {
+					t.Errorf("ParseRangeInto(%q).Contains(%q) = %v, want %v", rangeStr, tt.versions[i], got, !got)
+				}
+			}
+		}
+
+		err := e.ParseRangeInto(dst, tt.invalid)
+		if err == nil {
+			t.Fatalf("ParseRangeInto(%q) error = nil, want error", tt.invalid)
+		}
+		if _, want := e.NewVersionRange(tt.invalid); want == nil || err.Error() != want.Error() {
+			t.Errorf("ParseRangeInto(%q) error = %v, want %v", tt.invalid, err, want)
+		}
+		if dst.String() != "" {
+			t.Errorf("ParseRangeInto(%q) after error = %q, want \"\"", tt.invalid, dst)
+		}
+		for i, v := range versions {
+			if got := dst.Contains(v); got != tt.matchesAfterError {
+				t.Errorf("ParseRangeInto(%q) after error: Contains(%q) = %v, want %v", tt.invalid, tt.versions[i], got, tt.matchesAfterError)
+			}
+		}
+	}
+}
+
+func TestParseRangeInto(t *testing.T) {
+	tests := map[string]parseRangeIntoCase{
+		"maven": {
+			check:    checkParseRangeInto[*maven.Version, maven.VersionRange](&maven.Ecosystem{}),
+			ranges:   []string{"[1.0,2.0)", "1.5", "(,1.0]", "[1.2]", "(1.0,)"},
+			versions: []string{"0.9", "1.0", "1.2", "1.5", "1.9", "2.0", "3.0"},
+			invalid:  "[1.0,2.0",
+		},
+		"npm": {
+			check:    checkParseRangeInto[*npm.Version, npm.VersionRange](&npm.Ecosystem{}),
+			ranges:   []string{">=1.2.0 <1.5.0 || ^2.1.0 || 3.x", "^1.2.3", "1.2.3 - 2.3.4", ">=1.0.0 <2.0.0 || >=3.0.0 <4.0.0 || ~5.1.0 || 6.2.x", "*"},
+			versions: []string{"0.9.0", "1.2.3", "1.4.0", "2.1.5", "2.4.0", "3.0.5", "4.0.0", "5.1.3", "6.2.1"},
+			invalid:  ">=1.0.0 || ~~1.0",
+		},
+		"pypi": {
+			check:             checkParseRangeInto[*pypi.Version, pypi.VersionRange](&pypi.Ecosystem{}),
+			ranges:            []string{">=1.4.2, !=1.5.*, !=1.6.0, <2.0.0", "~=1.4.2", "==2.*", ">=1.0, <3.0, !=2.1, !=2.2, !=2.3", "===1.0+local"},
+			versions:          []string{"0.9", "1.0+local", "1.4.5", "1.5.2", "1.6.0", "1.9", "2.0", "2.2", "2.9", "3.0"},
+			invalid:           ">=1.0, <=",
+			matchesAfterError: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tt.check(t, tt)
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package univers
+
+import (
+	"fmt"
+)
+
+// PolicyRule is one constraint of a Policy. Rules are ecosystem-neutral and
+// are built with NoPrereleases, MaxMajorsBehind and MustSatisfy; Named gives a
+// rule the name its violations are reported under.
+type PolicyRule struct {
+	// Name identifies the rule in violations, such as "no-prereleases".
+	Name string
+
+	kind     policyKind
+	majors   int
+	rangeStr string
+}
+
+type policyKind int
+
+const (
+	policyNoPrereleases policyKind = iota
+	policyMaxMajorsBehind
+	policyMustSatisfy
+)
+
+// NoPrereleases rejects prereleases, judged as by SortVersions: a version is
+// a prerelease when it sorts below its own numeric release, so 1.0.0-rc.1 and
+// 1.0.dev1 are rejected but Maven's 1.0-sp1 is not.
+func NoPrereleases() PolicyRule {
+	return PolicyRule{Name: "no-prereleases", kind: policyNoPrereleases}
+}
+
+// MaxMajorsBehind rejects versions whose major version, the leading numeric
+// component, is more than n below that of the latest available release.
+// Prereleases are not counted as the latest release, and the rule does not
+// apply when no available version has a numeric major.
+func MaxMajorsBehind(n int) PolicyRule {
+	return PolicyRule{Name: "max-majors-behind", kind: policyMaxMajorsBehind, majors: n}
+}
+
+// MustSatisfy rejects versions outside rangeStr, which is parsed in the
+// ecosystem the policy is checked in.
+func MustSatisfy(rangeStr string) PolicyRule {
+	return PolicyRule{Name: "satisfies", kind: policyMustSatisfy, rangeStr: rangeStr}
+}
+
+// Named returns a copy of the rule reported as name.
+func (r PolicyRule) Named(name string) PolicyRule {
+	r.Name = name
+	return r
+}
+
+// Policy is a named set of rules a version must pass, such as an
+// organization's "production" preset.
+type Policy struct {
+	Name  string
+	Rules []PolicyRule
+}
+
+// PolicyViolation is a rule of a policy that a version failed.
+type PolicyViolation struct {
+	Policy  string
+	Rule    string
+	Version string
+	// Reason explains the failure, such as "1.0.0 is outside >=2.0.0".
+	Reason string
+}
+
+// String returns the violation as "policy/rule: reason".
+func (v PolicyViolation) String() string {
+	return fmt.Sprintf("%s/%s: %s", v.Policy, v.Rule, v.Reason)
+}
+
+// CheckPolicy evaluates version against every rule of p and returns the
+// violations in rule order, or none when the version passes. available lists
+// the published versions of the package, which MaxMajorsBehind compares
+// against; available versions that fail to parse are skipped.
+//
+// An error is returned when version or the range of a MustSatisfy rule is not
+// valid in e.
+func CheckPolicy[V Version[V], VR VersionRange[V]](e Ecosystem[V, VR], p Policy, version string, available []string) ([]PolicyViolation, error) {
+	v, err := e.NewVersion(version)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s version %q: %w", e.Name(), version, err)
+	}
+
+	var violations []PolicyViolation
+	for _, rule := range p.Rules {
+		reason, err := checkRule(e, rule, version, v, available)
+		if err != nil {
+			return nil, fmt.Errorf("policy %q rule %q: %w", p.Name, rule.Name, err)
+		}
+		if reason != "" {
+			violations = append(violations, PolicyViolation{Policy: p.Name, Rule: rule.Name, Version: version, Reason: reason})
+		}
+	}
+	return violations, nil
+}
+
+// checkRule returns why version v, parsed from s, fails rule, or "" if it
+// passes
+func checkRule[V Version[V], VR VersionRange[V]](e Ecosystem[V, VR], rule PolicyRule, s string, v V, available []string) (string, error) {
+	switch rule.kind {
+	case policyNoPrereleases:
+		if stream, prerelease := releaseStream(e, s, v); prerelease {
+			return fmt.Sprintf("%s is a prerelease of %s", s, stream), nil
+		}
+	case policyMaxMajorsBehind:
+		latest, ok := latestRelease(e, available)
+		if !ok {
+			return "", nil
+		}
+		have, want := splitRelease(s).core, splitRelease(latest).core
+		if len(have) == 0 || len(want) == 0 {
+			return "", nil
+		}
+		if behind := component(want, 0) - min(component(have, 0), component(want, 0)); behind > uint64(rule.majors) {
+			return fmt.Sprintf("%s is %d major versions behind %s", s, behind, latest), nil
+		}
+	case policyMustSatisfy:
+		r, err := e.NewVersionRange(rule.rangeStr)
+		if err != nil {
+			return "", fmt.Errorf("invalid %s range %q: %w", e.Name(), rule.rangeStr, err)
+		}
+		if !r.Contains(v) {
+			return fmt.Sprintf("%s is outside %s", s, rule.rangeStr), nil
+		}
+	}
+	return "", nil
+}
+
+// latestRelease returns the highest of versions that parses and is not a
+// prerelease, as written
+func latestRelease[V Version[V], VR VersionRange[V]](e Ecosystem[V, VR], versions []string) (string, bool) {
+	var (
+		latest  string
+		latestV V
+		found   bool
+	)
+	for _, s := range versions {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			continue
+		}
+		if _, prerelease := releaseStream(e, s, v); prerelease {
+			continue
+		}
+		if !found || After(v, latestV) {
+			latest, latestV, found = s, v, true
+		}
+	}
+	return latest, found
+}
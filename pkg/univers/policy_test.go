@@ -0,0 +1,108 @@
+package univers_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/maven"
+	"github.com/alowayed/go-univers/pkg/ecosystem/npm"
+	"github.com/alowayed/go-univers/pkg/ecosystem/pypi"
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+var production = univers.Policy{
+	Name: "production",
+	Rules: []univers.PolicyRule{
+		univers.NoPrereleases(),
+		univers.MaxMajorsBehind(1),
+		univers.MustSatisfy(">=2.0.0").Named("minimum"),
+	},
+}
+
+func TestCheckPolicy(t *testing.T) {
+	available := []string{"1.0.0", "2.0.0", "3.0.0", "4.0.0", "5.0.0-rc.1", "not-a-version"}
+
+	tests := []struct {
+		name    string
+		version string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "passes",
+			version: "3.1.0",
+		},
+		{
+			name:    "one major behind is allowed",
+			version: "3.0.0",
+		},
+		{
+			name:    "ahead of latest",
+			version: "5.0.0",
+		},
+		{
+			name:    "prerelease",
+			version: "4.1.0-beta.1",
+			want:    []string{"production/no-prereleases: 4.1.0-beta.1 is a prerelease of 4.1.0"},
+		},
+		{
+			name:    "too far behind and outside range",
+			version: "1.2.0",
+			want: []string{
+				"production/max-majors-behind: 1.2.0 is 3 major versions behind 4.0.0",
+				"production/minimum: 1.2.0 is outside >=2.0.0",
+			},
+		},
+		{
+			name:    "invalid version",
+			version: "bogus",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations, err := univers.CheckPolicy(&npm.Ecosystem{}, production, tt.version, available)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var got []string
+			for _, v := range violations {
+				if v.Version != tt.version {
+					t.Errorf("violation version = %q, want %q", v.Version, tt.version)
+				}
+				got = append(got, v.String())
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("CheckPolicy() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckPolicy_Ecosystems(t *testing.T) {
+	policy := univers.Policy{Name: "stable", Rules: []univers.PolicyRule{univers.NoPrereleases(), univers.MaxMajorsBehind(0)}}
+
+	pypiViolations, err := univers.CheckPolicy(&pypi.Ecosystem{}, policy, "2.0.dev1", []string{"1.0", "2.0"})
+	if err != nil {
+		t.Fatalf("CheckPolicy(pypi) error = %v", err)
+	}
+	if len(pypiViolations) != 1 || pypiViolations[0].Rule != "no-prereleases" {
+		t.Errorf("CheckPolicy(pypi) = %v, want a no-prereleases violation", pypiViolations)
+	}
+
+	mavenViolations, err := univers.CheckPolicy(&maven.Ecosystem{}, policy, "2.0-sp1", []string{"1.0", "2.0", "3.0-alpha-1"})
+	if err != nil {
+		t.Fatalf("CheckPolicy(maven) error = %v", err)
+	}
+	if len(mavenViolations) != 0 {
+		t.Errorf("CheckPolicy(maven) = %v, want none", mavenViolations)
+	}
+}
+
+func TestCheckPolicy_InvalidRange(t *testing.T) {
+	policy := univers.Policy{Name: "broken", Rules: []univers.PolicyRule{univers.MustSatisfy("~~1.0")}}
+	if _, err := univers.CheckPolicy(&npm.Ecosystem{}, policy, "1.0.0", nil); err == nil {
+		t.Error("CheckPolicy() error = nil, want invalid range error")
+	}
+}
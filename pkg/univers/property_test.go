@@ -0,0 +1,271 @@
+package univers_test
+
+import (
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/alpine"
+	"github.com/alowayed/go-univers/pkg/ecosystem/alpm"
+	"github.com/alowayed/go-univers/pkg/ecosystem/apache"
+	"github.com/alowayed/go-univers/pkg/ecosystem/bazel"
+	"github.com/alowayed/go-univers/pkg/ecosystem/cargo"
+	"github.com/alowayed/go-univers/pkg/ecosystem/composer"
+	"github.com/alowayed/go-univers/pkg/ecosystem/conan"
+	"github.com/alowayed/go-univers/pkg/ecosystem/cpan"
+	"github.com/alowayed/go-univers/pkg/ecosystem/cran"
+	"github.com/alowayed/go-univers/pkg/ecosystem/debian"
+	"github.com/alowayed/go-univers/pkg/ecosystem/gem"
+	"github.com/alowayed/go-univers/pkg/ecosystem/genericwin"
+	"github.com/alowayed/go-univers/pkg/ecosystem/gentoo"
+	"github.com/alowayed/go-univers/pkg/ecosystem/github"
+	"github.com/alowayed/go-univers/pkg/ecosystem/golang"
+	"github.com/alowayed/go-univers/pkg/ecosystem/gover"
+	"github.com/alowayed/go-univers/pkg/ecosystem/hex"
+	"github.com/alowayed/go-univers/pkg/ecosystem/luarocks"
+	"github.com/alowayed/go-univers/pkg/ecosystem/mattermost"
+	"github.com/alowayed/go-univers/pkg/ecosystem/maven"
+	"github.com/alowayed/go-univers/pkg/ecosystem/msver"
+	"github.com/alowayed/go-univers/pkg/ecosystem/npm"
+	"github.com/alowayed/go-univers/pkg/ecosystem/nuget"
+	"github.com/alowayed/go-univers/pkg/ecosystem/pypi"
+	"github.com/alowayed/go-univers/pkg/ecosystem/rpm"
+	"github.com/alowayed/go-univers/pkg/ecosystem/semver"
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+// gen produces a random string from a small version grammar. Numbers are
+// kept small so that generated versions often share prefixes and compare
+// equal, where ordering bugs tend to hide.
+type gen func(r *rand.Rand) string
+
+// lit picks one of options
+func lit(options ...string) gen {
+	return func(r *rand.Rand) string { return options[r.IntN(len(options))] }
+}
+
+// num produces a number from 0 to max
+func num(max int) gen {
+	return func(r *rand.Rand) string { return strconv.Itoa(r.IntN(max + 1)) }
+}
+
+// seq concatenates the output of parts
+func seq(parts ...gen) gen {
+	return func(r *rand.Rand) string {
+		var b strings.Builder
+		for _, p := range parts {
+			b.WriteString(p(r))
+		}
+		return b.String()
+	}
+}
+
+// opt produces the output of g half of the time and "" otherwise
+func opt(g gen) gen {
+	return func(r *rand.Rand) string {
+		if r.IntN(2) == 0 {
+			return ""
+		}
+		return g(r)
+	}
+}
+
+// rep joins from min to max outputs of g with sep
+func rep(g gen, sep string, min, max int) gen {
+	return func(r *rand.Rand) string {
+		parts := make([]string, min+r.IntN(max-min+1))
+		for i := range parts {
+			parts[i] = g(r)
+		}
+		return strings.Join(parts, sep)
+	}
+}
+
+// Grammar fragments shared by several ecosystems
+var (
+	semverCore       = rep(num(2), ".", 3, 3)
+	semverPrerelease = seq(lit("-"), rep(lit("alpha", "beta", "rc", "0", "1", "2", "x-1"), ".", 1, 2))
+	semverVersion    = seq(semverCore, opt(semverPrerelease), opt(seq(lit("+"), lit("build", "001", "sha.5114f85"))))
+)
+
+// propertyEcosystems maps each core ecosystem to a check that Compare is a
+// total order over versions from a generator for its grammar
+var propertyEcosystems = map[string]func(t *testing.T){
+	"alpine": totalOrderChecker(&alpine.Ecosystem{}, seq(
+		rep(num(3), ".", 1, 3), opt(lit("a", "b")),
+		opt(seq(lit("_alpha", "_beta", "_pre", "_rc", "_p", "_cvs", "_git"), opt(num(3)))),
+		opt(seq(lit("-r"), num(3))),
+	)),
+	// As in vercmp, a version without pkgrel equals every pkgrel of it, so
+	// 1.0-2 = 1.0 = 1.0-1 and equality is not transitive by design. Generated
+	// versions always have a pkgrel.
+	"alpm": totalOrderChecker(&alpm.Ecosystem{}, seq(
+		opt(seq(num(2), lit(":"))), rep(num(3), ".", 1, 3),
+		opt(seq(lit("a", "b", "rc", "alpha", "beta", "pre"), opt(num(2)))),
+		lit("-"), num(3),
+	)),
+	"apache": totalOrderChecker(&apache.Ecosystem{}, seq(
+		rep(num(3), ".", 3, 3), opt(seq(lit("-", "."), lit("M", "RC", "beta", "alpha", "dev", "SNAPSHOT"), opt(num(2)))),
+	)),
+	"bazel": totalOrderChecker(&bazel.Ecosystem{}, seq(
+		rep(num(3), ".", 1, 3), opt(seq(lit("-"), lit("rc1", "rc2", "alpha", "beta.1"))), opt(seq(lit(".bcr."), num(3))),
+	)),
+	"cargo": totalOrderChecker(&cargo.Ecosystem{}, semverVersion),
+	"composer": totalOrderChecker(&composer.Ecosystem{}, seq(
+		opt(lit("v")), rep(num(3), ".", 1, 4),
+		opt(seq(lit("-alpha", "-beta", "-RC", "-patch", "-dev", "-b", "-pl"), opt(num(3)))),
+	)),
+	"conan": totalOrderChecker(&conan.Ecosystem{}, seq(
+		rep(num(3), ".", 1, 4), opt(seq(lit("-"), lit("alpha", "beta", "rc1", "pre.2"))),
+	)),
+	"cpan": totalOrderChecker(&cpan.Ecosystem{}, func(r *rand.Rand) string {
+		if r.IntN(2) == 0 {
+			return seq(num(3), lit("."), lit("0", "1", "10", "23", "3", "001", "002", "010", "100"))(r)
+		}
+		return seq(lit("v"), rep(num(3), ".", 2, 4))(r)
+	}),
+	"cran": totalOrderChecker(&cran.Ecosystem{}, seq(num(3), rep(seq(lit(".", "-"), num(3)), "", 1, 3))),
+	"debian": totalOrderChecker(&debian.Ecosystem{}, seq(
+		opt(seq(num(2), lit(":"))), rep(num(3), ".", 1, 3),
+		opt(seq(lit("~rc", "~beta", "+dfsg", "+git", "a", "~"), opt(num(2)))),
+		opt(seq(lit("-"), num(3), opt(lit("ubuntu1", "+deb12u1", "~bpo1", ".1")))),
+	)),
+	"gem": totalOrderChecker(&gem.Ecosystem{}, seq(
+		rep(num(3), ".", 1, 4), opt(seq(lit(".", "-", ""), lit("alpha", "beta", "rc", "pre", "a"), opt(num(2)))),
+	)),
+	"generic-win": totalOrderChecker(&genericwin.Ecosystem{}, rep(num(3), ".", 2, 4)),
+	"gentoo": totalOrderChecker(&gentoo.Ecosystem{}, seq(
+		rep(num(3), ".", 1, 3), opt(lit("a", "b", "z")),
+		opt(seq(lit("_alpha", "_beta", "_pre", "_rc", "_p"), opt(num(3)))),
+		opt(seq(lit("-r"), num(3))),
+	)),
+	"github": totalOrderChecker(&github.Ecosystem{}, seq(lit("v", ""), semverCore, opt(semverPrerelease))),
+	"golang": totalOrderChecker(&golang.Ecosystem{}, seq(lit("v"), semverCore, opt(semverPrerelease), opt(lit("+incompatible")))),
+	"gover": totalOrderChecker(&gover.Ecosystem{}, seq(
+		lit("go", ""), lit("1"), lit("."), num(23), opt(seq(lit("."), num(3))), opt(seq(lit("rc", "beta"), lit("1", "2"))),
+	)),
+	"hex": totalOrderChecker(&hex.Ecosystem{}, semverVersion),
+	// A LuaRocks version without a revision equals every revision of it, like
+	// alpm's pkgrel, so generated versions always have one.
+	"luarocks": totalOrderChecker(&luarocks.Ecosystem{}, seq(
+		rep(num(3), ".", 1, 3), opt(lit("alpha", "beta", "rc1", "scm")), lit("-"), num(3),
+	)),
+	"mattermost": totalOrderChecker(&mattermost.Ecosystem{}, seq(
+		lit("v", ""), semverCore, opt(lit("-rc1", "-rc2", "-esr", "-beta")),
+	)),
+	"maven": totalOrderChecker(&maven.Ecosystem{}, seq(
+		rep(num(3), ".", 1, 3),
+		opt(seq(lit("-", "."), lit("alpha", "beta", "milestone", "rc", "cr", "snapshot", "SNAPSHOT", "ga", "final", "sp", "foo", "M", "a", "b"), opt(seq(lit("-", ""), num(2))))),
+		opt(seq(lit("-"), num(3))),
+	)),
+	"msver": totalOrderChecker(&msver.Ecosystem{}, rep(num(3), ".", 2, 4)),
+	"npm":   totalOrderChecker(&npm.Ecosystem{}, seq(lit("v", ""), semverVersion)),
+	"nuget": totalOrderChecker(&nuget.Ecosystem{}, seq(
+		rep(num(3), ".", 1, 4), opt(seq(lit("-"), rep(lit("alpha", "beta", "RC", "rc", "1", "2"), ".", 1, 2))),
+	)),
+	"pypi": totalOrderChecker(&pypi.Ecosystem{}, seq(
+		opt(seq(num(1), lit("!"))), rep(num(3), ".", 1, 3),
+		opt(seq(lit("a", "b", "rc"), num(2))), opt(seq(lit(".post"), num(2))), opt(seq(lit(".dev"), num(2))),
+		opt(seq(lit("+"), lit("local", "ubuntu.1", "1"))),
+	)),
+	"rpm": totalOrderChecker(&rpm.Ecosystem{}, seq(
+		opt(seq(num(2), lit(":"))), rep(num(3), ".", 1, 3),
+		opt(seq(lit("~rc", "~beta", "^git", "a", "_p"), opt(num(2)))),
+		opt(seq(lit("-"), num(3), opt(lit(".el9", ".el8_10", ".fc40", ".1")))),
+	)),
+	"semver": totalOrderChecker(&semver.Ecosystem{}, semverVersion),
+}
+
+// TestCompare_TotalOrder checks for every core ecosystem that Compare is
+// reflexive, antisymmetric and transitive over generated versions, and that
+// sorting agrees with it.
+func TestCompare_TotalOrder(t *testing.T) {
+	for _, name := range univers.EcosystemNames() {
+		check, ok := propertyEcosystems[string(name)]
+		if !ok {
+			t.Errorf("no version generator for %s", name)
+			continue
+		}
+		t.Run(string(name), check)
+	}
+}
+
+// propertySamples is the number of versions generated per ecosystem
+const propertySamples = 100
+
+// totalOrderChecker returns a check that Compare is a total order over
+// versions produced by g that parse in e
+func totalOrderChecker[V univers.Version[V], VR univers.VersionRange[V]](e univers.Ecosystem[V, VR], g gen) func(*testing.T) {
+	return func(t *testing.T) {
+		rng := rand.New(rand.NewPCG(4944, 1))
+		var (
+			inputs   []string
+			versions []V
+		)
+		for range propertySamples {
+			s := g(rng)
+			v, err := e.NewVersion(s)
+			if err != nil {
+				continue
+			}
+			inputs = append(inputs, s)
+			versions = append(versions, v)
+		}
+		if len(versions) < propertySamples/2 {
+			t.Fatalf("only %d of %d generated versions parse, fix the generator", len(versions), propertySamples)
+		}
+
+		sign := func(i, j int) int {
+			switch c := versions[i].Compare(versions[j]); {
+			case c < 0:
+				return -1
+			case c > 0:
+				return 1
+			default:
+				return 0
+			}
+		}
+		for i := range versions {
+			if c := sign(i, i); c != 0 {
+				t.Errorf("Compare(%q, %q) = %d, want 0", inputs[i], inputs[i], c)
+			}
+			for j := range versions {
+				if sign(i, j) != -sign(j, i) {
+					t.Errorf("Compare(%q, %q) = %d but Compare(%q, %q) = %d", inputs[i], inputs[j], sign(i, j), inputs[j], inputs[i], sign(j, i))
+				}
+				for k := range versions {
+					if sign(i, j) <= 0 && sign(j, k) <= 0 && sign(i, k) > 0 {
+						t.Errorf("%q <= %q <= %q but %q > %q", inputs[i], inputs[j], inputs[k], inputs[i], inputs[k])
+					}
+				}
+			}
+		}
+		if t.Failed() {
+			return
+		}
+
+		order := make([]int, len(versions))
+		for i := range order {
+			order[i] = i
+		}
+		rng.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+		slices.SortFunc(order, func(i, j int) int { return versions[i].Compare(versions[j]) })
+		for n := 1; n < len(order); n++ {
+			if sign(order[n-1], order[n]) > 0 {
+				t.Errorf("sorted %q before %q, which Compare orders after it", inputs[order[n-1]], inputs[order[n]])
+			}
+		}
+
+		sorted, err := univers.SortVersions(e, inputs, univers.SortPrecedence)
+		if err != nil {
+			t.Fatalf("SortVersions() error = %v", err)
+		}
+		for n := 1; n < len(sorted); n++ {
+			if univers.After(univers.MustNewVersion(e, sorted[n-1]), univers.MustNewVersion(e, sorted[n])) {
+				t.Errorf("SortVersions() put %q before %q", sorted[n-1], sorted[n])
+			}
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package univers
+
+import (
+	"fmt"
+	"time"
+)
+
+// RangeAge describes when the releases a range matches were published, for
+// supply-chain risk scoring. Its zero value means no dated release matched.
+type RangeAge struct {
+	// Matching is the number of dated releases the range contains.
+	Matching int
+	// Oldest and Latest are the earliest and latest publication times among
+	// the matching releases.
+	Oldest, Latest time.Time
+	// Newest is the highest matching version, as published, and NewestTime
+	// when it was published. The highest version is not always the most
+	// recently published one, for example when an old branch gets a patch.
+	Newest     string
+	NewestTime time.Time
+}
+
+// Span returns the time between the first and last matching release.
+func (a RangeAge) Span() time.Duration {
+	return a.Latest.Sub(a.Oldest)
+}
+
+// NewestAge returns how long before now the highest matching version was
+// published, or 0 when no release matched.
+func (a RangeAge) NewestAge(now time.Time) time.Duration {
+	if a.Matching == 0 {
+		return 0
+	}
+	return now.Sub(a.NewestTime)
+}
+
+// AgeOfRange reports when the releases matching rangeStr were published: how
+// many there are, the span of dates they cover and the publication time of
+// the highest of them.
+//
+// As for LatestAsOf, releases whose version fails to parse or whose
+// publication time is unknown are skipped.
+func AgeOfRange[V Version[V], VR VersionRange[V]](e Ecosystem[V, VR], rangeStr string, releases []Release) (RangeAge, error) {
+	var age RangeAge
+	var newest V
+	err := eachDatedRelease(e, rangeStr, releases, func(v V, rel Release) {
+		if age.Matching == 0 || rel.Time.Before(age.Oldest) {
+			age.Oldest = rel.Time
+		}
+		if age.Matching == 0 || rel.Time.After(age.Latest) {
+			age.Latest = rel.Time
+		}
+		if age.Matching == 0 || After(v, newest) {
+			newest, age.Newest, age.NewestTime = v, rel.Version, rel.Time
+		}
+		age.Matching++
+	})
+	return age, err
+}
+
+// PermitsAfter reports whether rangeStr contains any of the releases
+// published after cutoff, such as versions too new to have been vetted.
+// Releases whose version fails to parse or whose publication time is unknown
+// are skipped. A range without an upper bound also admits versions not yet
+// published, which releases cannot show.
+func PermitsAfter[V Version[V], VR VersionRange[V]](e Ecosystem[V, VR], rangeStr string, releases []Release, cutoff time.Time) (bool, error) {
+	permits := false
+	err := eachDatedRelease(e, rangeStr, releases, func(_ V, rel Release) {
+		permits = permits || rel.Time.After(cutoff)
+	})
+	return permits, err
+}
+
+// eachDatedRelease calls f for each release with a known time whose version
+// parses and is contained in rangeStr
+func eachDatedRelease[V Version[V], VR VersionRange[V]](e Ecosystem[V, VR], rangeStr string, releases []Release, f func(V, Release)) error {
+	r, err := e.NewVersionRange(rangeStr)
+	if err != nil {
+		return fmt.Errorf("failed to parse range %q: %w", rangeStr, err)
+	}
+	for _, rel := range releases {
+		if rel.Time.IsZero() {
+			continue
+		}
+		v, err := e.NewVersion(rel.Version)
+		if err != nil || !r.Contains(v) {
+			continue
+		}
+		f(v, rel)
+	}
+	return nil
+}
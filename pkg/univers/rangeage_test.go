@@ -0,0 +1,123 @@
+package univers_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/npm"
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+// ageReleases has a patch of an old branch published after a newer minor
+var ageReleases = []univers.Release{
+	{Version: "1.0.0", Time: day(1)},
+	{Version: "1.1.0", Time: day(3)},
+	{Version: "1.2.0", Time: day(10)},
+	{Version: "1.0.1", Time: day(15)},
+	{Version: "2.0.0", Time: day(20)},
+	{Version: "1.3.0"},
+	{Version: "not-a-version", Time: day(2)},
+}
+
+func day(d int) time.Time {
+	return time.Date(2024, time.January, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestAgeOfRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		want     univers.RangeAge
+		wantSpan time.Duration
+		wantErr  bool
+	}{
+		{
+			name:     "newest version is not the latest release",
+			rangeStr: "^1.0.0",
+			want: univers.RangeAge{
+				Matching:   4,
+				Oldest:     day(1),
+				Latest:     day(15),
+				Newest:     "1.2.0",
+				NewestTime: day(10),
+			},
+			wantSpan: 14 * 24 * time.Hour,
+		},
+		{
+			name:     "single release",
+			rangeStr: "2.0.0",
+			want: univers.RangeAge{
+				Matching:   1,
+				Oldest:     day(20),
+				Latest:     day(20),
+				Newest:     "2.0.0",
+				NewestTime: day(20),
+			},
+		},
+		{
+			name:     "no match",
+			rangeStr: ">=3.0.0",
+		},
+		{
+			name:     "invalid range",
+			rangeStr: "~~1.0",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := univers.AgeOfRange(&npm.Ecosystem{}, tt.rangeStr, ageReleases)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AgeOfRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("AgeOfRange() = %+v, want %+v", got, tt.want)
+			}
+			if span := got.Span(); span != tt.wantSpan {
+				t.Errorf("Span() = %v, want %v", span, tt.wantSpan)
+			}
+		})
+	}
+}
+
+func TestRangeAge_NewestAge(t *testing.T) {
+	age, err := univers.AgeOfRange(&npm.Ecosystem{}, "^1.0.0", ageReleases)
+	if err != nil {
+		t.Fatalf("AgeOfRange() error = %v", err)
+	}
+	if got, want := age.NewestAge(day(31)), 21*24*time.Hour; got != want {
+		t.Errorf("NewestAge() = %v, want %v", got, want)
+	}
+	if got := (univers.RangeAge{}).NewestAge(day(31)); got != 0 {
+		t.Errorf("NewestAge() of no match = %v, want 0", got)
+	}
+}
+
+func TestPermitsAfter(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		cutoff   time.Time
+		want     bool
+		wantErr  bool
+	}{
+		{name: "patch after cutoff", rangeStr: "^1.0.0", cutoff: day(12), want: true},
+		{name: "nothing after cutoff", rangeStr: "^1.0.0", cutoff: day(15), want: false},
+		{name: "newer release outside range", rangeStr: "~1.2.0", cutoff: day(12), want: false},
+		{name: "undated release ignored", rangeStr: "~1.3.0", cutoff: day(1), want: false},
+		{name: "invalid range", rangeStr: "~~1.0", cutoff: day(1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := univers.PermitsAfter(&npm.Ecosystem{}, tt.rangeStr, ageReleases, tt.cutoff)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PermitsAfter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("PermitsAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package univers_test
+
+import (
+	"bufio"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/debian"
+	"github.com/alowayed/go-univers/pkg/ecosystem/gover"
+	"github.com/alowayed/go-univers/pkg/ecosystem/maven"
+	"github.com/alowayed/go-univers/pkg/ecosystem/npm"
+	"github.com/alowayed/go-univers/pkg/ecosystem/pypi"
+	"github.com/alowayed/go-univers/pkg/ecosystem/rpm"
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+// realworldEcosystems maps the ecosystem prefix of each file in
+// testdata/realworld to the checks for its versions
+var realworldEcosystems = map[string]struct {
+	sort     func(t *testing.T, versions []string)
+	contains func(rangeStr string, versions []string) ([]string, error)
+}{
+	"deb":   {sortChecker(&debian.Ecosystem{}), containing(&debian.Ecosystem{})},
+	"gover": {sortChecker(&gover.Ecosystem{}), containing(&gover.Ecosystem{})},
+	"maven": {sortChecker(&maven.Ecosystem{}), containing(&maven.Ecosystem{})},
+	"npm":   {sortChecker(&npm.Ecosystem{}), containing(&npm.Ecosystem{})},
+	"pypi":  {sortChecker(&pypi.Ecosystem{}), containing(&pypi.Ecosystem{})},
+	"rpm":   {sortChecker(&rpm.Ecosystem{}), containing(&rpm.Ecosystem{})},
+}
+
+func TestRealWorld_Sort(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "realworld", "*.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Fatal("no corpus files found in testdata/realworld")
+	}
+
+	for _, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), ".txt")
+		t.Run(name, func(t *testing.T) {
+			prefix, _, _ := strings.Cut(name, "_")
+			checks, ok := realworldEcosystems[prefix]
+			if !ok {
+				t.Fatalf("no ecosystem for corpus prefix %q", prefix)
+			}
+			checks.sort(t, readCorpus(t, path))
+		})
+	}
+}
+
+func TestRealWorld_Contains(t *testing.T) {
+	tests := []struct {
+		name     string
+		corpus   string
+		rangeStr string
+		want     []string
+	}{
+		{
+			name:     "lodash prototype pollution fixes",
+			corpus:   "npm_lodash",
+			rangeStr: ">=4.17.12 <4.17.21",
+			want:     []string{"4.17.12", "4.17.13", "4.17.14", "4.17.15", "4.17.16", "4.17.19", "4.17.20"},
+		},
+		{
+			name:     "lodash caret from a release candidate",
+			corpus:   "npm_lodash",
+			rangeStr: "^1.0.0-rc.2 <1.1.0",
+			want:     []string{"1.0.0-rc.2", "1.0.0-rc.3", "1.0.0", "1.0.1", "1.0.2"},
+		},
+		{
+			name:     "lodash tilde",
+			corpus:   "npm_lodash",
+			rangeStr: "~3.9.0",
+			want:     []string{"3.9.0", "3.9.1", "3.9.2", "3.9.3"},
+		},
+		{
+			name:     "django compatible release",
+			corpus:   "pypi_django",
+			rangeStr: "~=3.2.0",
+			want:     []string{"3.2", "3.2.25"},
+		},
+		{
+			name:     "django 4.2 pre-releases and release",
+			corpus:   "pypi_django",
+			rangeStr: ">=4.2a1,<4.2.1",
+			want:     []string{"4.2a1", "4.2b1", "4.2rc1", "4.2"},
+		},
+		{
+			name:     "log4j CVE-2021-45046 second interval",
+			corpus:   "maven_log4j-core",
+			rangeStr: "[2.13.0,2.16.0)",
+			want:     []string{"2.13.0", "2.13.1", "2.13.2", "2.13.3", "2.14.0", "2.14.1", "2.15.0"},
+		},
+		{
+			name:     "log4j first affected releases",
+			corpus:   "maven_log4j-core",
+			rangeStr: "[2.0-beta9,2.0.1]",
+			want:     []string{"2.0-beta9", "2.0-rc1", "2.0-rc2", "2.0", "2.0.1"},
+		},
+		{
+			name:     "openssl bookworm backports before unstable",
+			corpus:   "deb_openssl",
+			rangeStr: ">= 3.0.0, << 3.0.11-1",
+			want:     []string{"3.0.11-1~deb12u1", "3.0.11-1~deb12u2"},
+		},
+		{
+			name:     "kernel RHEL 8.10 updates",
+			corpus:   "rpm_kernel",
+			rangeStr: ">=4.18.0-553.el8_10 <5.0",
+			want:     []string{"4.18.0-553.el8_10", "4.18.0-553.5.1.el8_10"},
+		},
+		{
+			name:     "go 1.21 release candidates and releases",
+			corpus:   "gover_go",
+			rangeStr: ">= go1.21rc2, < go1.22rc1",
+			want:     []string{"1.21rc2", "1.21.0", "1.21.13"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prefix, _, _ := strings.Cut(tt.corpus, "_")
+			versions := readCorpus(t, filepath.Join("testdata", "realworld", tt.corpus+".txt"))
+			got, err := realworldEcosystems[prefix].contains(tt.rangeStr, versions)
+			if err != nil {
+				t.Fatalf("contains(%q) error = %v", tt.rangeStr, err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("contains(%q) = %q, want %q", tt.rangeStr, got, tt.want)
+			}
+		})
+	}
+}
+
+// readCorpus returns the versions of a corpus file, skipping comments and
+// blank lines
+func readCorpus(t *testing.T, path string) []string {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var versions []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		versions = append(versions, line)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return versions
+}
+
+// sortChecker returns a check that versions, listed in ascending order, parse
+// in e, strictly increase, and come back in that order from SortVersions
+// whatever order they are given in
+func sortChecker[V univers.Version[V], VR univers.VersionRange[V]](e univers.Ecosystem[V, VR]) func(*testing.T, []string) {
+	return func(t *testing.T, versions []string) {
+		t.Helper()
+		parsed := make([]V, 0, len(versions))
+		for _, s := range versions {
+			v, err := e.NewVersion(s)
+			if err != nil {
+				t.Fatalf("NewVersion(%q) error = %v", s, err)
+			}
+			parsed = append(parsed, v)
+		}
+		for i := 1; i < len(parsed); i++ {
+			if got := parsed[i-1].Compare(parsed[i]); got >= 0 {
+				t.Errorf("Compare(%q, %q) = %d, want -1", versions[i-1], versions[i], got)
+			}
+			if got := parsed[i].Compare(parsed[i-1]); got <= 0 {
+				t.Errorf("Compare(%q, %q) = %d, want 1", versions[i], versions[i-1], got)
+			}
+		}
+
+		reversed := slices.Clone(versions)
+		slices.Reverse(reversed)
+		shuffled := slices.Clone(versions)
+		rng := rand.New(rand.NewPCG(1, 2))
+		rng.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+
+		for name, input := range map[string][]string{"sorted": versions, "reversed": reversed, "shuffled": shuffled} {
+			got, err := univers.SortVersions(e, input, univers.SortPrecedence)
+			if err != nil {
+				t.Fatalf("SortVersions(%s) error = %v", name, err)
+			}
+			if !slices.Equal(got, versions) {
+				t.Errorf("SortVersions(%s) = %q, want %q", name, got, versions)
+			}
+		}
+	}
+}
+
+// containing returns a function listing the versions a range of e contains
+func containing[V univers.Version[V], VR univers.VersionRange[V]](e univers.Ecosystem[V, VR]) func(string, []string) ([]string, error) {
+	return func(rangeStr string, versions []string) ([]string, error) {
+		r, err := e.NewVersionRange(rangeStr)
+		if err != nil {
+			return nil, err
+		}
+		var matched []string
+		for _, s := range versions {
+			v, err := e.NewVersion(s)
+			if err != nil {
+				return nil, err
+			}
+			if r.Contains(v) {
+				matched = append(matched, s)
+			}
+		}
+		return matched, nil
+	}
+}
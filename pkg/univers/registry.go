@@ -0,0 +1,173 @@
+package univers
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// AnyVersion is a type-erased Version produced by a registered ecosystem.
+// It allows ecosystems added through RegisterEcosystem to be used without
+// knowing their concrete types at compile time.
+type AnyVersion struct {
+	ecosystem string
+	value     any
+	compare   func(other any) int
+	str       string
+}
+
+// Compare compares this version with another version from the same ecosystem.
+// It panics if the versions come from different ecosystems.
+func (v *AnyVersion) Compare(other *AnyVersion) int {
+	if v.ecosystem != other.ecosystem {
+		panic(fmt.Sprintf("univers: cannot compare %s version with %s version", v.ecosystem, other.ecosystem))
+	}
+	return v.compare(other.value)
+}
+
+// String returns the original string representation of the version.
+func (v *AnyVersion) String() string {
+	return v.str
+}
+
+// Unwrap returns the underlying ecosystem-specific version.
+func (v *AnyVersion) Unwrap() any {
+	return v.value
+}
+
+// AnyVersionRange is a type-erased VersionRange produced by a registered
+// ecosystem, NewAnyRange or WrapRange. Ranges of every ecosystem share this
+// type.
+type AnyVersionRange struct {
+	ecosystem  string
+	value      any
+	contains   func(version any) bool
+	newVersion func(s string) (*AnyVersion, error)
+	str        string
+}
+
+// Contains checks if a version is within this range. Versions from a
+// different ecosystem are never contained.
+func (r *AnyVersionRange) Contains(version *AnyVersion) bool {
+	if r.ecosystem != version.ecosystem {
+		return false
+	}
+	return r.contains(version.value)
+}
+
+// String returns the original string representation of the version range.
+func (r *AnyVersionRange) String() string {
+	return r.str
+}
+
+// Unwrap returns the underlying ecosystem-specific version range.
+func (r *AnyVersionRange) Unwrap() any {
+	return r.value
+}
+
+// AnyEcosystem is a type-erased Ecosystem returned by LookupEcosystem.
+type AnyEcosystem = Ecosystem[*AnyVersion, *AnyVersionRange]
+
+// anyEcosystem adapts a typed Ecosystem to AnyEcosystem.
+type anyEcosystem[V Version[V], VR VersionRange[V]] struct {
+	name string
+	e    Ecosystem[V, VR]
+}
+
+func (a *anyEcosystem[V, VR]) Name() string {
+	return a.name
+}
+
+func (a *anyEcosystem[V, VR]) NewVersion(s string) (*AnyVersion, error) {
+	v, err := a.e.NewVersion(s)
+	if err != nil {
+		return nil, err
+	}
+	return &AnyVersion{
+		ecosystem: a.name,
+		value:     v,
+		compare:   func(other any) int { return v.Compare(other.(V)) },
+		str:       v.String(),
+	}, nil
+}
+
+func (a *anyEcosystem[V, VR]) NewVersionRange(s string) (*AnyVersionRange, error) {
+	r, err := a.e.NewVersionRange(s)
+	if err != nil {
+		return nil, err
+	}
+	return a.wrapRange(r), nil
+}
+
+// wrapRange erases the types of r, a range parsed by the wrapped ecosystem
+func (a *anyEcosystem[V, VR]) wrapRange(r VR) *AnyVersionRange {
+	return &AnyVersionRange{
+		ecosystem:  a.name,
+		value:      r,
+		contains:   func(version any) bool { return r.Contains(version.(V)) },
+		newVersion: a.NewVersion,
+		str:        r.String(),
+	}
+}
+
+// Features forwards to the wrapped ecosystem when it implements Featurer,
+// and returns nil otherwise.
+func (a *anyEcosystem[V, VR]) Features() []Feature {
+	if f, ok := a.e.(Featurer); ok {
+		return f.Features()
+	}
+	return nil
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]AnyEcosystem{}
+)
+
+// RegisterEcosystem makes an ecosystem available by name through LookupEcosystem.
+// It is intended for ecosystems living outside the core packages, such as those
+// under pkg/contrib, which typically call it from an init function.
+// Returns an error if the name is empty, already registered, or the name or
+// alias of a core ecosystem.
+func RegisterEcosystem[V Version[V], VR VersionRange[V]](name string, e Ecosystem[V, VR]) error {
+	if name == "" {
+		return fmt.Errorf("ecosystem name cannot be empty")
+	}
+	if e == nil {
+		return fmt.Errorf("ecosystem %q cannot be nil", name)
+	}
+	if core, ok := ParseEcosystemName(name); ok {
+		return fmt.Errorf("ecosystem %q is reserved for the core %s ecosystem", name, core)
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, ok := registry[name]; ok {
+		return fmt.Errorf("ecosystem %q already registered", name)
+	}
+	registry[name] = &anyEcosystem[V, VR]{name: name, e: e}
+	return nil
+}
+
+// LookupEcosystem returns the registered ecosystem with the given name.
+func LookupEcosystem(name string) (AnyEcosystem, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	e, ok := registry[name]
+	return e, ok
+}
+
+// RegisteredEcosystems returns the names of all registered ecosystems in sorted order.
+func RegisteredEcosystems() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
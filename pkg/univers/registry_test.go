@@ -0,0 +1,202 @@
+package univers
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+// intVersion is a minimal ecosystem used to exercise the registry.
+type intVersion struct{ n int }
+
+func (v *intVersion) Compare(other *intVersion) int {
+	switch {
+	case v.n < other.n:
+		return -1
+	case v.n > other.n:
+		return 1
+	}
+	return 0
+}
+
+func (v *intVersion) String() string { return strconv.Itoa(v.n) }
+
+type intRange struct{ min *intVersion }
+
+func (r *intRange) Contains(v *intVersion) bool { return v.Compare(r.min) >= 0 }
+
+func (r *intRange) String() string { return ">=" + r.min.String() }
+
+type intEcosystem struct{}
+
+func (e *intEcosystem) Name() string { return "int" }
+
+func (e *intEcosystem) NewVersion(s string) (*intVersion, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid int version: %s", s)
+	}
+	return &intVersion{n: n}, nil
+}
+
+func (e *intEcosystem) NewVersionRange(s string) (*intRange, error) {
+	if len(s) < 2 || s[:2] != ">=" {
+		return nil, fmt.Errorf("invalid int range: %s", s)
+	}
+	v, err := e.NewVersion(s[2:])
+	if err != nil {
+		return nil, err
+	}
+	return &intRange{min: v}, nil
+}
+
+func TestRegisterEcosystem(t *testing.T) {
+	tests := []struct {
+		name    string
+		regName string
+		e       Ecosystem[*intVersion, *intRange]
+		wantErr bool
+	}{
+		{
+			name:    "new ecosystem",
+			regName: "test-register",
+			e:       &intEcosystem{},
+		},
+		{
+			name:    "duplicate name",
+			regName: "test-register",
+			e:       &intEcosystem{},
+			wantErr: true,
+		},
+		{
+			name:    "empty name",
+			regName: "",
+			e:       &intEcosystem{},
+			wantErr: true,
+		},
+		{
+			name:    "core ecosystem name",
+			regName: "npm",
+			e:       &intEcosystem{},
+			wantErr: true,
+		},
+		{
+			name:    "core ecosystem alias",
+			regName: "gomod",
+			e:       &intEcosystem{},
+			wantErr: true,
+		},
+		{
+			name:    "nil ecosystem",
+			regName: "test-register-nil",
+			e:       nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := RegisterEcosystem(tt.regName, tt.e)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RegisterEcosystem() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLookupEcosystem(t *testing.T) {
+	if err := RegisterEcosystem("test-lookup", &intEcosystem{}); err != nil {
+		t.Fatalf("RegisterEcosystem() error = %v", err)
+	}
+
+	if _, ok := LookupEcosystem("test-missing"); ok {
+		t.Errorf("LookupEcosystem(%q) found, want not found", "test-missing")
+	}
+
+	e, ok := LookupEcosystem("test-lookup")
+	if !ok {
+		t.Fatalf("LookupEcosystem(%q) not found", "test-lookup")
+	}
+	if got := e.Name(); got != "test-lookup" {
+		t.Errorf("Name() = %q, want %q", got, "test-lookup")
+	}
+
+	v1, err := e.NewVersion("1")
+	if err != nil {
+		t.Fatalf("NewVersion() error = %v", err)
+	}
+	v2, err := e.NewVersion("2")
+	if err != nil {
+		t.Fatalf("NewVersion() error = %v", err)
+	}
+	if got := v1.Compare(v2); got != -1 {
+		t.Errorf("Compare() = %d, want -1", got)
+	}
+	if got := v2.String(); got != "2" {
+		t.Errorf("String() = %q, want %q", got, "2")
+	}
+	if _, ok := v1.Unwrap().(*intVersion); !ok {
+		t.Errorf("Unwrap() = %T, want *intVersion", v1.Unwrap())
+	}
+
+	r, err := e.NewVersionRange(">=2")
+	if err != nil {
+		t.Fatalf("NewVersionRange() error = %v", err)
+	}
+	if r.Contains(v1) {
+		t.Errorf("Contains(%s) = true, want false", v1)
+	}
+	if !r.Contains(v2) {
+		t.Errorf("Contains(%s) = false, want true", v2)
+	}
+	if got, err := r.ContainsString("3"); err != nil || !got {
+		t.Errorf("ContainsString(%q) = %v, %v, want true, nil", "3", got, err)
+	}
+	if _, err := r.ContainsString("x"); err == nil {
+		t.Errorf("ContainsString(%q) error = nil, want error", "x")
+	}
+
+	// A range erased by NewAnyRange has the same type and ecosystem as one
+	// from the registry under the ecosystem's own name
+	if err := RegisterEcosystem("int", &intEcosystem{}); err != nil {
+		t.Fatalf("RegisterEcosystem() error = %v", err)
+	}
+	registered, _ := LookupEcosystem("int")
+	v3, err := registered.NewVersion("3")
+	if err != nil {
+		t.Fatalf("NewVersion() error = %v", err)
+	}
+	wrapped, err := NewAnyRange[*intVersion, *intRange](&intEcosystem{}, ">=2")
+	if err != nil {
+		t.Fatalf("NewAnyRange() error = %v", err)
+	}
+	if !wrapped.Contains(v3) {
+		t.Errorf("NewAnyRange() range Contains(%s) = false for a registry version, want true", v3)
+	}
+
+	if _, err := e.NewVersion("x"); err == nil {
+		t.Errorf("NewVersion(%q) error = nil, want error", "x")
+	}
+}
+
+func TestRegisteredEcosystems(t *testing.T) {
+	for _, name := range []string{"test-list-b", "test-list-a"} {
+		if err := RegisterEcosystem(name, &intEcosystem{}); err != nil {
+			t.Fatalf("RegisterEcosystem(%q) error = %v", name, err)
+		}
+	}
+
+	got := RegisteredEcosystems()
+	idxA, idxB := -1, -1
+	for i, name := range got {
+		switch name {
+		case "test-list-a":
+			idxA = i
+		case "test-list-b":
+			idxB = i
+		}
+	}
+	if idxA == -1 || idxB == -1 || idxA > idxB {
+		t.Errorf("RegisteredEcosystems() = %v, want sorted and containing test-list-a and test-list-b", got)
+	}
+}
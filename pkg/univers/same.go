@@ -0,0 +1,209 @@
+package univers
+
+import (
+	"slices"
+	"strings"
+)
+
+// Confidence is how likely two version strings are to denote the same release.
+type Confidence int
+
+const (
+	// ConfidenceNone means the versions denote different releases.
+	ConfidenceNone Confidence = iota
+	// ConfidenceLow means the upstream versions match but one side carries a
+	// packaging revision, as in "1.2.3" and "1.2.3-1".
+	ConfidenceLow
+	// ConfidenceMedium means the versions match once trailing zero components,
+	// prerelease spellings and zero revisions are normalized, as in "1.2" and
+	// "1.2.0", "1.0a1" and "1.0-alpha.1", or "1.2.3" and "1.2.3-0".
+	ConfidenceMedium
+	// ConfidenceHigh means the versions differ only cosmetically: case, a "v"
+	// prefix, build metadata, separators or a release marker such as
+	// "1.2.3.RELEASE".
+	ConfidenceHigh
+	// ConfidenceExact means the version strings are identical.
+	ConfidenceExact
+)
+
+// String returns the lowercase name of the confidence level.
+func (c Confidence) String() string {
+	switch c {
+	case ConfidenceLow:
+		return "low"
+	case ConfidenceMedium:
+		return "medium"
+	case ConfidenceHigh:
+		return "high"
+	case ConfidenceExact:
+		return "exact"
+	}
+	return "none"
+}
+
+// releaseMarkers are qualifiers that mark a final release rather than a
+// prerelease, such as Spring's "1.2.3.RELEASE" or JBoss's "1.2.3.Final".
+var releaseMarkers = []string{"final", "ga", "release"}
+
+// qualifierAliases maps alternate prerelease spellings to a canonical one.
+var qualifierAliases = map[string]string{
+	"a":       "alpha",
+	"b":       "beta",
+	"c":       "rc",
+	"cr":      "rc",
+	"pre":     "rc",
+	"preview": "rc",
+}
+
+// SameRelease reports how likely two version strings, possibly written in
+// different ecosystems' notations, denote the same upstream release. It is a
+// heuristic for matching advisory data across registries and does not parse
+// either string with an ecosystem's rules.
+func SameRelease(a, b string) Confidence {
+	if a == b {
+		return ConfidenceExact
+	}
+
+	va, vb := splitRelease(a), splitRelease(b)
+	if slices.Equal(va.core, vb.core) && slices.Equal(va.qualifier, vb.qualifier) {
+		return ConfidenceHigh
+	}
+
+	if !slices.Equal(numericCore(va.core), numericCore(vb.core)) {
+		return ConfidenceNone
+	}
+	qa, qb := canonicalQualifier(va.qualifier), canonicalQualifier(vb.qualifier)
+	if slices.Equal(qa, qb) {
+		return ConfidenceMedium
+	}
+	if (len(qa) == 0 && isRevision(qb)) || (len(qb) == 0 && isRevision(qa)) {
+		return ConfidenceLow
+	}
+	return ConfidenceNone
+}
+
+// releaseParts is a version string split for SameRelease.
+type releaseParts struct {
+	core      []string // dot-separated numeric components
+	qualifier []string // remaining alphabetic and numeric tokens
+}
+
+// splitRelease lowercases s, drops a "v" prefix, build metadata and release
+// markers, and splits the rest into a numeric core and qualifier tokens.
+func splitRelease(s string) releaseParts {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if i := strings.IndexByte(s, '+'); i != -1 {
+		s = s[:i]
+	}
+	if len(s) > 1 && s[0] == 'v' && isASCIIDigit(s[1]) {
+		s = s[1:]
+	}
+
+	var p releaseParts
+	i := 0
+	for i < len(s) && isASCIIDigit(s[i]) {
+		j := i
+		for j < len(s) && isASCIIDigit(s[j]) {
+			j++
+		}
+		p.core = append(p.core, s[i:j])
+		i = j
+		if i+1 < len(s) && s[i] == '.' && isASCIIDigit(s[i+1]) {
+			i++
+			continue
+		}
+		break
+	}
+
+	for _, tok := range tokenize(s[i:]) {
+		if !slices.Contains(releaseMarkers, tok) {
+			p.qualifier = append(p.qualifier, tok)
+		}
+	}
+	return p
+}
+
+// tokenize splits s into runs of letters and runs of digits, dropping
+// separators, so "-rc.1" and "rc1" both yield ["rc", "1"].
+func tokenize(s string) []string {
+	var tokens []string
+	start := -1
+	for i := 0; i <= len(s); i++ {
+		if start != -1 && (i == len(s) || !sameClass(s[start], s[i])) {
+			tok := s[start:i]
+			if isASCIIDigit(tok[0]) {
+				tok = trimLeadingZeros(tok)
+			}
+			tokens = append(tokens, tok)
+			start = -1
+		}
+		if start == -1 && i < len(s) && (isASCIIDigit(s[i]) || isASCIILetter(s[i])) {
+			start = i
+		}
+	}
+	return tokens
+}
+
+// canonicalQualifier rewrites prerelease aliases to one spelling and drops a
+// zero revision such as "-0" or "-r0".
+func canonicalQualifier(q []string) []string {
+	if slices.Equal(q, []string{"0"}) || slices.Equal(q, []string{"r", "0"}) {
+		return nil
+	}
+	out := make([]string, len(q))
+	for i, tok := range q {
+		if alias, ok := qualifierAliases[tok]; ok {
+			tok = alias
+		}
+		out[i] = tok
+	}
+	return out
+}
+
+// isRevision reports whether q is a packaging revision like "-1" or "-r2".
+func isRevision(q []string) bool {
+	switch len(q) {
+	case 1:
+		return isASCIIDigit(q[0][0])
+	case 2:
+		return q[0] == "r" && isASCIIDigit(q[1][0])
+	}
+	return false
+}
+
+// numericCore drops leading zeros from each component and trailing zero
+// components, so "1.2", "1.02" and "1.2.0" match.
+func numericCore(core []string) []string {
+	out := make([]string, len(core))
+	for i, c := range core {
+		out[i] = trimLeadingZeros(c)
+	}
+	for len(out) > 0 && out[len(out)-1] == "0" {
+		out = out[:len(out)-1]
+	}
+	return out
+}
+
+// trimLeadingZeros drops leading zeros from a digit run, keeping one digit.
+func trimLeadingZeros(s string) string {
+	trimmed := strings.TrimLeft(s, "0")
+	if trimmed == "" {
+		return "0"
+	}
+	return trimmed
+}
+
+// sameClass reports whether a and b are both digits or both letters.
+func sameClass(a, b byte) bool {
+	return (isASCIIDigit(a) && isASCIIDigit(b)) || (isASCIILetter(a) && isASCIILetter(b))
+}
+
+// isASCIIDigit reports whether c is an ASCII digit.
+func isASCIIDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// isASCIILetter reports whether c is a lowercase ASCII letter.
+func isASCIILetter(c byte) bool {
+	return 'a' <= c && c <= 'z'
+}
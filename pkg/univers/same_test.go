@@ -0,0 +1,46 @@
+package univers
+
+import "testing"
+
+func TestSameRelease(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want Confidence
+	}{
+		{"identical", "1.2.3", "1.2.3", ConfidenceExact},
+		{"v prefix", "1.2.3", "v1.2.3", ConfidenceHigh},
+		{"uppercase v prefix", "V1.2.3", "1.2.3", ConfidenceHigh},
+		{"spring release marker", "1.2.3.RELEASE", "1.2.3", ConfidenceHigh},
+		{"jboss final marker", "v1.2.3", "1.2.3.Final", ConfidenceHigh},
+		{"build metadata", "1.2.3+build.7", "1.2.3", ConfidenceHigh},
+		{"prerelease separators", "1.0.0-rc1", "1.0.0rc1", ConfidenceHigh},
+		{"prerelease case", "1.0.0-RC.1", "1.0.0-rc1", ConfidenceHigh},
+		{"zero prerelease", "1.2.3-0", "1.2.3", ConfidenceMedium},
+		{"alpine zero revision", "1.2.3-r0", "v1.2.3", ConfidenceMedium},
+		{"trailing zero component", "1.2", "1.2.0", ConfidenceMedium},
+		{"four part version", "1.2.3.0", "1.2.3", ConfidenceMedium},
+		{"leading zeros", "1.02.3", "1.2.3", ConfidenceMedium},
+		{"alpha alias", "1.0a1", "1.0.0-alpha.1", ConfidenceMedium},
+		{"maven cr alias", "2.0-CR2", "2.0rc2", ConfidenceMedium},
+		{"packaging revision", "1.2.3-1", "1.2.3", ConfidenceLow},
+		{"alpine revision", "1.2.3", "1.2.3-r4", ConfidenceLow},
+		{"different patch", "1.2.3", "1.2.4", ConfidenceNone},
+		{"prerelease vs release", "1.2.3-rc1", "1.2.3", ConfidenceNone},
+		{"different prerelease number", "1.0rc1", "1.0rc2", ConfidenceNone},
+		{"different prerelease kind", "1.0a1", "1.0b1", ConfidenceNone},
+		{"different revisions", "1.2.3-1", "1.2.3-2", ConfidenceNone},
+		{"non-numeric", "latest", "stable", ConfidenceNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SameRelease(tt.a, tt.b); got != tt.want {
+				t.Errorf("SameRelease(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+			if got := SameRelease(tt.b, tt.a); got != tt.want {
+				t.Errorf("SameRelease(%q, %q) = %v, want %v", tt.b, tt.a, got, tt.want)
+			}
+		})
+	}
+}
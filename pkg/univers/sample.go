@@ -0,0 +1,167 @@
+package univers
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// sampleSeparators are the operators, delimiters, quotes and whitespace used by
+// the supported range syntaxes, which split a range string into version literals.
+const sampleSeparators = "\t\n\f\r ,|&()[]{}\"<>=!^*"
+
+// Samples holds representative versions generated around the boundaries of a range.
+type Samples[V Version[V]] struct {
+	// Inside contains generated versions satisfying the range, in ascending order.
+	Inside []V
+	// Outside contains generated versions not satisfying the range, in ascending order.
+	Outside []V
+}
+
+// Sample generates representative versions at and around the boundaries of a range,
+// such as the bounds themselves and versions just inside or just outside them.
+//
+// Candidates are derived from the version literals in the range string by bumping
+// their numeric components up and down; candidates the ecosystem cannot parse are
+// skipped. At most n versions are returned in each of Inside and Outside, spread
+// evenly across the sorted candidates and always keeping the lowest and highest.
+// A non-positive n returns all candidates.
+func Sample[V Version[V], VR VersionRange[V]](e Ecosystem[V, VR], r VR, n int) Samples[V] {
+	seen := map[string]bool{}
+	var versions []V
+	literals := strings.FieldsFunc(r.String(), func(c rune) bool { return strings.ContainsRune(sampleSeparators, c) })
+	for _, literal := range literals {
+		for _, candidate := range sampleCandidates(literal) {
+			if seen[candidate] {
+				continue
+			}
+			seen[candidate] = true
+
+			v, err := e.NewVersion(candidate)
+			if err != nil {
+				continue
+			}
+			versions = append(versions, v)
+		}
+	}
+
+	slices.SortStableFunc(versions, V.Compare)
+	versions = slices.CompactFunc(versions, Equal[V])
+
+	var s Samples[V]
+	for _, v := range versions {
+		if r.Contains(v) {
+			s.Inside = append(s.Inside, v)
+		} else {
+			s.Outside = append(s.Outside, v)
+		}
+	}
+	s.Inside = spread(s.Inside, n)
+	s.Outside = spread(s.Outside, n)
+	return s
+}
+
+// sampleCandidates returns the literal itself plus variants with its numeric
+// core bumped up and down at each position.
+func sampleCandidates(literal string) []string {
+	start, end, ok := sampleCore(literal)
+	if !ok {
+		return nil
+	}
+
+	prefix, core, suffix := literal[:start], literal[start:end], literal[end:]
+	parts := strings.Split(core, ".")
+	nums := make([]int, len(parts))
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return []string{literal}
+		}
+		nums[i] = n
+	}
+
+	variants := [][]int{}
+	for i := range nums {
+		// Bump up, keeping and zeroing the following components
+		up := slices.Clone(nums)
+		up[i]++
+		variants = append(variants, up)
+		upZero := slices.Clone(up)
+		for j := i + 1; j < len(upZero); j++ {
+			upZero[j] = 0
+		}
+		variants = append(variants, upZero)
+
+		// Bump down, keeping and maximising the following components
+		if nums[i] > 0 {
+			down := slices.Clone(nums)
+			down[i]--
+			variants = append(variants, down)
+			downMax := slices.Clone(down)
+			for j := i + 1; j < len(downMax); j++ {
+				downMax[j] = 99
+			}
+			variants = append(variants, downMax)
+		}
+	}
+
+	candidates := []string{literal}
+	if suffix != "" {
+		candidates = append(candidates, prefix+core)
+	}
+	for _, variant := range variants {
+		strs := make([]string, len(variant))
+		for i, n := range variant {
+			strs[i] = strconv.Itoa(n)
+		}
+		joined := strings.Join(strs, ".")
+		candidates = append(candidates, prefix+joined+suffix)
+		if suffix != "" {
+			candidates = append(candidates, prefix+joined)
+		}
+	}
+	return candidates
+}
+
+// sampleCore locates the first dotted numeric core of a version literal, such
+// as "1.2.3" in "v1.2.3-rc.1".
+func sampleCore(literal string) (start, end int, ok bool) {
+	start = strings.IndexAny(literal, "0123456789")
+	if start < 0 {
+		return 0, 0, false
+	}
+	end = start + digitRun(literal[start:])
+	for end+1 < len(literal) && literal[end] == '.' {
+		n := digitRun(literal[end+1:])
+		if n == 0 {
+			break
+		}
+		end += 1 + n
+	}
+	return start, end, true
+}
+
+// digitRun returns the length of the leading run of ASCII digits in s
+func digitRun(s string) int {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return i
+}
+
+// spread returns at most n elements evenly spaced across vs, keeping the first and last.
+func spread[V any](vs []V, n int) []V {
+	if n <= 0 || len(vs) <= n {
+		return vs
+	}
+	if n == 1 {
+		return vs[:1]
+	}
+
+	out := make([]V, 0, n)
+	for i := range n {
+		out = append(out, vs[i*(len(vs)-1)/(n-1)])
+	}
+	return out
+}
@@ -0,0 +1,89 @@
+package univers_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/maven"
+	"github.com/alowayed/go-univers/pkg/ecosystem/npm"
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+func TestSample(t *testing.T) {
+	tests := []struct {
+		name        string
+		rangeStr    string
+		n           int
+		wantInside  []string
+		wantOutside []string
+	}{
+		{
+			name:        "lower bound only",
+			rangeStr:    ">=1.2.3",
+			wantInside:  []string{"1.2.3", "1.2.4", "1.3.0", "1.3.3", "2.0.0", "2.2.3"},
+			wantOutside: []string{"0.2.3", "0.99.99", "1.1.3", "1.1.99", "1.2.2"},
+		},
+		{
+			name:        "bounded range",
+			rangeStr:    ">=1.0.0 <2.0.0",
+			wantInside:  []string{"1.0.0", "1.0.1", "1.1.0", "1.99.99"},
+			wantOutside: []string{"0.0.0", "0.99.99", "2.0.0", "2.0.1", "2.1.0", "3.0.0"},
+		},
+		{
+			name:        "limited to n",
+			rangeStr:    ">=1.2.3",
+			n:           2,
+			wantInside:  []string{"1.2.3", "2.2.3"},
+			wantOutside: []string{"0.2.3", "1.2.2"},
+		},
+	}
+
+	e := &npm.Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := e.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("NewVersionRange(%q) error = %v", tt.rangeStr, err)
+			}
+
+			got := univers.Sample(e, r, tt.n)
+			if inside := versionStrings(got.Inside); !slices.Equal(inside, tt.wantInside) {
+				t.Errorf("Sample().Inside = %v, want %v", inside, tt.wantInside)
+			}
+			if outside := versionStrings(got.Outside); !slices.Equal(outside, tt.wantOutside) {
+				t.Errorf("Sample().Outside = %v, want %v", outside, tt.wantOutside)
+			}
+		})
+	}
+}
+
+func TestSample_Classification(t *testing.T) {
+	e := &maven.Ecosystem{}
+	r, err := e.NewVersionRange("[1.0,2.0)")
+	if err != nil {
+		t.Fatalf("NewVersionRange() error = %v", err)
+	}
+
+	got := univers.Sample(e, r, 0)
+	if len(got.Inside) == 0 || len(got.Outside) == 0 {
+		t.Fatalf("Sample() = %+v, want both inside and outside samples", got)
+	}
+	for _, v := range got.Inside {
+		if !r.Contains(v) {
+			t.Errorf("Sample().Inside contains %s, which is outside %s", v, r)
+		}
+	}
+	for _, v := range got.Outside {
+		if r.Contains(v) {
+			t.Errorf("Sample().Outside contains %s, which is inside %s", v, r)
+		}
+	}
+}
+
+func versionStrings[V univers.Version[V]](vs []V) []string {
+	out := make([]string, 0, len(vs))
+	for _, v := range vs {
+		out = append(out, v.String())
+	}
+	return out
+}
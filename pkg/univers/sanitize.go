@@ -0,0 +1,124 @@
+package univers
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// SanitizeOptions controls how SanitizeRange rewrites a range.
+type SanitizeOptions struct {
+	// AndSeparator, when set, joins the constraints of each AND group, e.g.
+	// ", " for cargo and PyPI or " " for npm, replacing whichever of commas or
+	// spaces the input used. When empty, a comma separator is kept as ", "
+	// and a space as " ".
+	AndSeparator string
+}
+
+// sanitizeReplacer maps typographic characters copied from web pages and
+// documents to their ASCII equivalents, and drops quotes and invisible
+// characters
+var sanitizeReplacer = strings.NewReplacer(
+	// Hyphens, dashes and the minus sign
+	"\u2010", "-", "\u2011", "-", "\u2012", "-", "\u2013", "-", "\u2014", "-", "\u2015", "-", "\u2212", "-",
+	"≥", ">=", "≤", "<=", "≠", "!=",
+	"‘", "", "’", "", "“", "", "”", "", "\"", "", "`", "",
+	// Zero-width spaces and joiners, the word joiner and the byte order mark
+	"\u200b", "", "\u200c", "", "\u200d", "", "\u2060", "", "\ufeff", "",
+)
+
+// sanitizeOperators are the comparison operators joined to a version that
+// follows them after a space
+var sanitizeOperators = []string{">=", "<=", ">", "<", "=", "==", "===", "!=", "~", "~>", "~=", "^"}
+
+// SanitizeRange fixes common mistakes in ranges copied from advisories and web
+// pages so that they parse: typographic dashes become "-", non-breaking and
+// other unicode spaces become plain spaces, ≥, ≤ and ≠ become >=, <= and !=,
+// quotes and zero-width characters are removed, and an operator separated
+// from its version, as in ">= 1.0.0", is joined to it. "||" alternatives,
+// hyphen ranges and bracketed intervals such as "[1.0, 2.0)" are kept.
+//
+// SanitizeRange is opt-in and never called by the parsers, since it can
+// change the meaning of input a parser would otherwise reject. For example,
+//
+//	SanitizeRange("≥ 1.0.0 , < 2.0.0", SanitizeOptions{AndSeparator: " "})
+//
+// returns ">=1.0.0 <2.0.0".
+func SanitizeRange(s string, opts SanitizeOptions) string {
+	s = sanitizeReplacer.Replace(s)
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return ' '
+		}
+		return r
+	}, s)
+
+	// Split into tokens, noting whether a comma separated each from the last
+	var tokens []string
+	var commas []bool
+	comma := false
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == ' ':
+			i++
+		case c == ',':
+			comma = true
+			i++
+		case c == '[' || c == '(':
+			// An interval runs to its closing bracket, commas included
+			end := strings.IndexAny(s[i+1:], "])")
+			if end < 0 {
+				end = len(s)
+			} else {
+				end += i + 2
+			}
+			tokens = append(tokens, strings.ReplaceAll(s[i:end], " ", ""))
+			commas = append(commas, comma)
+			comma = false
+			i = end
+		default:
+			end := strings.IndexAny(s[i:], " ,")
+			if end < 0 {
+				end = len(s)
+			} else {
+				end += i
+			}
+			tokens = append(tokens, s[i:end])
+			commas = append(commas, comma)
+			comma = false
+			i = end
+		}
+	}
+
+	var b strings.Builder
+	last := ""
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		sep := commas[i]
+		if slices.Contains(sanitizeOperators, token) && i+1 < len(tokens) && !isRangeSeparator(tokens[i+1]) {
+			i++
+			token += tokens[i]
+		}
+
+		if b.Len() > 0 {
+			switch {
+			case isRangeSeparator(token) || isRangeSeparator(last):
+				b.WriteString(" ")
+			case opts.AndSeparator != "":
+				b.WriteString(opts.AndSeparator)
+			case sep:
+				b.WriteString(", ")
+			default:
+				b.WriteString(" ")
+			}
+		}
+		b.WriteString(token)
+		last = token
+	}
+	return b.String()
+}
+
+// isRangeSeparator reports whether token joins constraints other than by AND
+func isRangeSeparator(token string) bool {
+	return token == "||" || token == "|" || token == "-"
+}
@@ -0,0 +1,101 @@
+package univers_test
+
+import (
+	"testing"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/maven"
+	"github.com/alowayed/go-univers/pkg/ecosystem/npm"
+	"github.com/alowayed/go-univers/pkg/ecosystem/pypi"
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+func TestSanitizeRange(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		opts  univers.SanitizeOptions
+		want  string
+	}{
+		{name: "already clean", input: ">=1.0.0 <2.0.0", want: ">=1.0.0 <2.0.0"},
+		{name: "non-breaking spaces", input: ">=\u00a01.0.0\u00a0<\u202f2.0.0", want: ">=1.0.0 <2.0.0"},
+		{name: "unicode operators", input: "≥1.0 ≤2.0 ≠1.5", want: ">=1.0 <=2.0 !=1.5"},
+		{name: "en dash hyphen range", input: "1.0.0 – 2.0.0", want: "1.0.0 - 2.0.0"},
+		{name: "smart quotes", input: "“^1.2.3”", want: "^1.2.3"},
+		{name: "zero-width characters", input: "\ufeff>=1.0\u200b", want: ">=1.0"},
+		{name: "comma kept", input: ">= 1.0,< 2.0", want: ">=1.0, <2.0"},
+		{name: "commas to spaces", input: ">=1.0, <2.0", opts: univers.SanitizeOptions{AndSeparator: " "}, want: ">=1.0 <2.0"},
+		{name: "spaces to commas", input: ">= 1.0 < 2.0", opts: univers.SanitizeOptions{AndSeparator: ", "}, want: ">=1.0, <2.0"},
+		{name: "alternatives kept", input: ">= 1.0 < 2.0 || ^3.0", opts: univers.SanitizeOptions{AndSeparator: ","}, want: ">=1.0,<2.0 || ^3.0"},
+		{name: "interval kept", input: "[1.0 , 2.0) , [3.0,)", want: "[1.0,2.0), [3.0,)"},
+		{name: "empty", input: " \u00a0 ", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := univers.SanitizeRange(tt.input, tt.opts); got != tt.want {
+				t.Errorf("SanitizeRange(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeRange_Contains(t *testing.T) {
+	tests := []struct {
+		name     string
+		contains func(rangeStr, version string) (bool, error)
+		input    string
+		opts     univers.SanitizeOptions
+		version  string
+	}{
+		{
+			name:     "npm",
+			contains: contains(&npm.Ecosystem{}),
+			input:    "1.2.0 – 2.0.0",
+			version:  "1.5.0",
+		},
+		{
+			name:     "pypi",
+			contains: contains(&pypi.Ecosystem{}),
+			input:    "“>= 1.0 < 2.0”",
+			opts:     univers.SanitizeOptions{AndSeparator: ", "},
+			version:  "1.5",
+		},
+		{
+			name:     "maven",
+			contains: contains(&maven.Ecosystem{}),
+			input:    "“[1.0,\u00a02.0)”",
+			version:  "1.5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if ok, err := tt.contains(tt.input, tt.version); err == nil && ok {
+				t.Fatalf("unsanitized %q contains %q, want a failure", tt.input, tt.version)
+			}
+			sanitized := univers.SanitizeRange(tt.input, tt.opts)
+			ok, err := tt.contains(sanitized, tt.version)
+			if err != nil {
+				t.Fatalf("sanitized %q error = %v", sanitized, err)
+			}
+			if !ok {
+				t.Errorf("sanitized %q does not contain %q", sanitized, tt.version)
+			}
+		})
+	}
+}
+
+// contains returns a function checking a version against a range of e
+func contains[V univers.Version[V], VR univers.VersionRange[V]](e univers.Ecosystem[V, VR]) func(rangeStr, version string) (bool, error) {
+	return func(rangeStr, version string) (bool, error) {
+		r, err := e.NewVersionRange(rangeStr)
+		if err != nil {
+			return false, err
+		}
+		v, err := e.NewVersion(version)
+		if err != nil {
+			return false, err
+		}
+		return r.Contains(v), nil
+	}
+}
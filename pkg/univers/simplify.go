@@ -0,0 +1,142 @@
+package univers
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// SimplifyRange returns the shortest range in e's syntax that matches exactly
+// versions among the versions of universe, like node-semver's simplifyRange.
+// It turns enumerated lists of affected versions into readable ranges: with
+// a universe of 1.0.0, 1.1.0, 1.2.0 and 2.0.0, the npm versions 1.1.0 and
+// 1.2.0 simplify to ">=1.1.0 <=1.2.0", and 1.0.0, 1.1.0 and 2.0.0 to
+// "<=1.1.0 || =2.0.0".
+//
+// Consecutive runs of selected versions become "*", "<=b", ">=a", "=a" or
+// ">=a <=b" intervals. Runs are joined with alternatives where the syntax has
+// them; otherwise a single interval with "!=" exclusions is used. Whatever
+// the form, the result is parsed and checked against the universe, so rules
+// such as npm's pre-release matching cannot make it match a different set;
+// when no interval form works, the versions are listed as alternatives.
+//
+// Versions not in universe are added to it, and versions that compare equal
+// count as one. Cargo, npm, PyPI and SemVer are supported.
+func SimplifyRange[V Version[V], VR VersionRange[V]](e Ecosystem[V, VR], universe, versions []string) (string, error) {
+	syntax, ok := translateSyntaxes[e.Name()]
+	if !ok {
+		return "", fmt.Errorf("%s ranges are not supported", e.Name())
+	}
+	if len(versions) == 0 {
+		return "", fmt.Errorf("no versions given")
+	}
+
+	parse := func(ss []string) ([]V, error) {
+		vs := make([]V, 0, len(ss))
+		for _, s := range ss {
+			v, err := e.NewVersion(s)
+			if err != nil {
+				return nil, fmt.Errorf("invalid version %q: %w", s, err)
+			}
+			vs = append(vs, v)
+		}
+		slices.SortFunc(vs, V.Compare)
+		return slices.CompactFunc(vs, Equal[V]), nil
+	}
+
+	selected, err := parse(versions)
+	if err != nil {
+		return "", err
+	}
+	all, err := parse(append(slices.Clone(universe), versions...))
+	if err != nil {
+		return "", err
+	}
+	in := make([]bool, len(all))
+	for i, v := range all {
+		_, in[i] = slices.BinarySearchFunc(selected, v, V.Compare)
+	}
+
+	// Runs of consecutive selected versions, as indexes into all
+	type run struct{ first, last int }
+	var runs []run
+	for i := range all {
+		switch {
+		case !in[i]:
+		case len(runs) > 0 && runs[len(runs)-1].last == i-1:
+			runs[len(runs)-1].last = i
+		default:
+			runs = append(runs, run{i, i})
+		}
+	}
+
+	var candidates []string
+	if syntax.or != "" || len(runs) == 1 {
+		groups := make([]string, 0, len(runs))
+		for _, r := range runs {
+			groups = append(groups, simplifyInterval(syntax, all, r.first, r.last))
+		}
+		candidates = append(candidates, strings.Join(groups, syntax.or))
+	}
+	if syntax.notEqual && len(runs) > 1 {
+		first, last := runs[0].first, runs[len(runs)-1].last
+		var comparators []string
+		if interval := simplifyInterval(syntax, all, first, last); interval != syntax.any {
+			comparators = append(comparators, interval)
+		}
+		for i := first; i <= last; i++ {
+			if !in[i] {
+				comparators = append(comparators, "!="+all[i].String())
+			}
+		}
+		candidates = append(candidates, strings.Join(comparators, syntax.and))
+	}
+	if syntax.or != "" {
+		exact := make([]string, 0, len(selected))
+		for _, v := range selected {
+			exact = append(exact, syntax.equal+v.String())
+		}
+		candidates = append(candidates, strings.Join(exact, syntax.or))
+	}
+
+	slices.SortStableFunc(candidates, func(a, b string) int { return cmp.Compare(len(a), len(b)) })
+	for _, c := range candidates {
+		if matchesExactly(e, c, all, in) {
+			return c, nil
+		}
+	}
+	return "", fmt.Errorf("no %s range matches exactly the given versions", e.Name())
+}
+
+// simplifyInterval spells the versions all[first] to all[last] as a range,
+// leaving out bounds at either end of all
+func simplifyInterval[V Version[V]](syntax translateSyntax, all []V, first, last int) string {
+	lower, upper := ">="+all[first].String(), "<="+all[last].String()
+	switch {
+	case first == last:
+		return syntax.equal + all[first].String()
+	case first == 0 && last == len(all)-1 && syntax.any != "":
+		return syntax.any
+	case first == 0:
+		return upper
+	case last == len(all)-1:
+		return lower
+	}
+	return lower + syntax.and + upper
+}
+
+// matchesExactly reports whether rangeStr parses and matches the versions of
+// all for which in is true, and no others
+func matchesExactly[V Version[V], VR VersionRange[V]](e Ecosystem[V, VR], rangeStr string, all []V, in []bool) bool {
+	r, err := e.NewVersionRange(rangeStr)
+	if err != nil {
+		return false
+	}
+	for i, v := range all {
+		if r.Contains(v) != in[i] {
+			return false
+		}
+	}
+	return true
+}
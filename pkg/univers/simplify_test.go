@@ -0,0 +1,139 @@
+package univers_test
+
+import (
+	"testing"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/cargo"
+	"github.com/alowayed/go-univers/pkg/ecosystem/maven"
+	"github.com/alowayed/go-univers/pkg/ecosystem/npm"
+	"github.com/alowayed/go-univers/pkg/ecosystem/pypi"
+	"github.com/alowayed/go-univers/pkg/ecosystem/semver"
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+func TestSimplifyRange(t *testing.T) {
+	universe := []string{"1.0.0", "1.0.1", "1.1.0", "1.2.0", "2.0.0", "2.1.0"}
+
+	tests := []struct {
+		name     string
+		simplify func(universe, versions []string) (string, error)
+		universe []string
+		versions []string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "every version",
+			simplify: simplifier(&npm.Ecosystem{}),
+			versions: universe,
+			want:     "*",
+		},
+		{
+			name:     "oldest versions",
+			simplify: simplifier(&npm.Ecosystem{}),
+			versions: []string{"1.0.0", "1.0.1", "1.1.0"},
+			want:     "<=1.1.0",
+		},
+		{
+			name:     "newest versions",
+			simplify: simplifier(&npm.Ecosystem{}),
+			versions: []string{"2.1.0", "1.2.0", "2.0.0"},
+			want:     ">=1.2.0",
+		},
+		{
+			name:     "middle run",
+			simplify: simplifier(&npm.Ecosystem{}),
+			versions: []string{"1.0.1", "1.1.0"},
+			want:     ">=1.0.1 <=1.1.0",
+		},
+		{
+			name:     "single version",
+			simplify: simplifier(&npm.Ecosystem{}),
+			versions: []string{"1.1.0"},
+			want:     "=1.1.0",
+		},
+		{
+			name:     "two runs",
+			simplify: simplifier(&npm.Ecosystem{}),
+			versions: []string{"1.0.0", "1.0.1", "2.0.0", "2.1.0"},
+			want:     "<=1.0.1 || >=2.0.0",
+		},
+		{
+			name:     "gap listed exactly",
+			simplify: simplifier(&npm.Ecosystem{}),
+			versions: []string{"1.0.1", "1.2.0"},
+			want:     "=1.0.1 || =1.2.0",
+		},
+		{
+			name:     "semver exclusion",
+			simplify: simplifier(&semver.Ecosystem{}),
+			versions: []string{"1.0.0", "1.0.1", "1.1.0", "2.0.0", "2.1.0"},
+			want:     "!=1.2.0",
+		},
+		{
+			name:     "cargo exclusions",
+			simplify: simplifier(&cargo.Ecosystem{}),
+			versions: []string{"1.0.0", "1.0.1", "2.0.0"},
+			want:     "<=2.0.0, !=1.1.0, !=1.2.0",
+		},
+		{
+			name:     "pypi every version",
+			simplify: simplifier(&pypi.Ecosystem{}),
+			versions: universe,
+			want:     "<=2.1.0",
+		},
+		{
+			name:     "version outside the universe",
+			simplify: simplifier(&npm.Ecosystem{}),
+			versions: []string{"2.1.0", "3.0.0"},
+			want:     ">=2.1.0",
+		},
+		{
+			name:     "cargo prerelease outside any interval",
+			simplify: simplifier(&cargo.Ecosystem{}),
+			universe: []string{"1.0.0", "1.5.0-beta", "2.0.0"},
+			versions: []string{"1.0.0", "1.5.0-beta", "2.0.0"},
+			wantErr:  true,
+		},
+		{
+			name:     "no versions",
+			simplify: simplifier(&npm.Ecosystem{}),
+			wantErr:  true,
+		},
+		{
+			name:     "invalid version",
+			simplify: simplifier(&npm.Ecosystem{}),
+			versions: []string{"not-a-version"},
+			wantErr:  true,
+		},
+		{
+			name:     "unsupported ecosystem",
+			simplify: simplifier(&maven.Ecosystem{}),
+			versions: []string{"1.0.0"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := universe
+			if tt.universe != nil {
+				u = tt.universe
+			}
+			got, err := tt.simplify(u, tt.versions)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SimplifyRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("SimplifyRange() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// simplifier returns SimplifyRange bound to e
+func simplifier[V univers.Version[V], VR univers.VersionRange[V]](e univers.Ecosystem[V, VR]) func(universe, versions []string) (string, error) {
+	return func(universe, versions []string) (string, error) {
+		return univers.SimplifyRange(e, universe, versions)
+	}
+}
@@ -0,0 +1,144 @@
+package univers
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// SortMode selects the order SortVersions returns versions in.
+type SortMode int
+
+const (
+	// SortPrecedence orders versions by Compare alone.
+	SortPrecedence SortMode = iota
+	// SortStableFirst lists every release before any prerelease, each group
+	// in precedence order, for views that should lead with stable versions.
+	SortStableFirst
+	// SortStream groups versions by release stream, the release they lead up
+	// to, and orders streams by that release. Within a stream prereleases come
+	// first, then the release and its post-releases, so 1.2.0-rc1 sits right
+	// before 1.2.0 and after every 1.1.x version.
+	SortStream
+)
+
+// String returns the name of the mode.
+func (m SortMode) String() string {
+	switch m {
+	case SortPrecedence:
+		return "precedence"
+	case SortStableFirst:
+		return "stable-first"
+	case SortStream:
+		return "stream"
+	default:
+		return fmt.Sprintf("SortMode(%d)", int(m))
+	}
+}
+
+// sortEntry is a parsed version with its release stream
+type sortEntry[V Version[V]] struct {
+	input      string
+	version    V
+	stream     V
+	prerelease bool
+}
+
+// SortVersions returns versions sorted in the given mode, as they appeared in
+// the input. Versions that compare equal keep their input order.
+//
+// A version's stream is its leading numeric release, such as 1.2.0 for
+// 1.2.0-rc1, parsed in the same ecosystem; a version is a prerelease when it
+// sorts below that release. This follows each ecosystem's own rules, so
+// Maven's 1.2.0-sp1 and PyPI's 1.2.0.post1 are releases of the 1.2.0 stream
+// while 1.2.0-rc1 and 1.2.0.dev1 are prereleases. A version without a
+// parsable numeric release is a release in a stream of its own.
+//
+// Invalid versions are reported as for Max: all of them are joined in the
+// returned error and the remaining versions are still sorted.
+func SortVersions[V Version[V], VR VersionRange[V]](e Ecosystem[V, VR], versions []string, mode SortMode) ([]string, error) {
+	var (
+		entries  []sortEntry[V]
+		rejected []error
+	)
+	for _, s := range versions {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			rejected = append(rejected, fmt.Errorf("invalid %s version %q: %w", e.Name(), s, err))
+			continue
+		}
+		entry := sortEntry[V]{input: s, version: v}
+		entry.stream, entry.prerelease = releaseStream(e, s, v)
+		entries = append(entries, entry)
+	}
+
+	slices.SortStableFunc(entries, func(a, b sortEntry[V]) int {
+		switch mode {
+		case SortStableFirst:
+			if a.prerelease != b.prerelease {
+				return compareBool(a.prerelease, b.prerelease)
+			}
+		case SortStream:
+			if c := a.stream.Compare(b.stream); c != 0 {
+				return c
+			}
+			if a.prerelease != b.prerelease {
+				return compareBool(b.prerelease, a.prerelease)
+			}
+		}
+		return a.version.Compare(b.version)
+	})
+
+	sorted := make([]string, len(entries))
+	for i, entry := range entries {
+		sorted[i] = entry.input
+	}
+	return sorted, errors.Join(rejected...)
+}
+
+// releaseStream returns the stream of version v, parsed from s, and whether
+// v is a prerelease of it. A version without a parsable numeric release is
+// its own stream.
+func releaseStream[V Version[V], VR VersionRange[V]](e Ecosystem[V, VR], s string, v V) (V, bool) {
+	stream, err := e.NewVersion(releasePrefix(strings.TrimSpace(s)))
+	if err != nil {
+		return v, false
+	}
+	return stream, Before(v, stream)
+}
+
+// releasePrefix returns the leading numeric release of s, including a "v"
+// prefix, such as "v1.2.0" for "v1.2.0-rc.1". It is empty if s does not
+// start with a number.
+func releasePrefix(s string) string {
+	i := 0
+	if len(s) > 1 && (s[0] == 'v' || s[0] == 'V') && isASCIIDigit(s[1]) {
+		i = 1
+	}
+	end := 0
+	for i < len(s) && isASCIIDigit(s[i]) {
+		for i < len(s) && isASCIIDigit(s[i]) {
+			i++
+		}
+		end = i
+		if i+1 < len(s) && s[i] == '.' && isASCIIDigit(s[i+1]) {
+			i++
+			continue
+		}
+		break
+	}
+	return s[:end]
+}
+
+// compareBool orders false before true
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
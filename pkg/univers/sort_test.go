@@ -0,0 +1,117 @@
+package univers_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/golang"
+	"github.com/alowayed/go-univers/pkg/ecosystem/maven"
+	"github.com/alowayed/go-univers/pkg/ecosystem/npm"
+	"github.com/alowayed/go-univers/pkg/ecosystem/pypi"
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+func TestSortVersions(t *testing.T) {
+	versions := []string{"1.2.0", "2.0.0-beta.1", "1.1.1", "1.2.0-rc.1", "1.2.1", "1.1.0", "2.0.0-alpha"}
+
+	tests := []struct {
+		name string
+		mode univers.SortMode
+		want []string
+	}{
+		{
+			name: "precedence",
+			mode: univers.SortPrecedence,
+			want: []string{"1.1.0", "1.1.1", "1.2.0-rc.1", "1.2.0", "1.2.1", "2.0.0-alpha", "2.0.0-beta.1"},
+		},
+		{
+			name: "stable first",
+			mode: univers.SortStableFirst,
+			want: []string{"1.1.0", "1.1.1", "1.2.0", "1.2.1", "1.2.0-rc.1", "2.0.0-alpha", "2.0.0-beta.1"},
+		},
+		{
+			name: "stream",
+			mode: univers.SortStream,
+			want: []string{"1.1.0", "1.1.1", "1.2.0-rc.1", "1.2.0", "1.2.1", "2.0.0-alpha", "2.0.0-beta.1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := univers.SortVersions(&npm.Ecosystem{}, versions, tt.mode)
+			if err != nil {
+				t.Fatalf("SortVersions() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("SortVersions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortVersions_EcosystemStability(t *testing.T) {
+	t.Run("pypi", func(t *testing.T) {
+		versions := []string{"1.2.0.post1", "1.2.0", "1.2.0rc1", "1.1.0", "1.2.0b1", "1.3.0a1"}
+		want := []string{"1.1.0", "1.2.0", "1.2.0.post1", "1.2.0b1", "1.2.0rc1", "1.3.0a1"}
+		got, err := univers.SortVersions(&pypi.Ecosystem{}, versions, univers.SortStableFirst)
+		if err != nil {
+			t.Fatalf("SortVersions() error = %v", err)
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("SortVersions() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("maven", func(t *testing.T) {
+		versions := []string{"1.2.0-sp1", "1.2.0-rc1", "1.2.0", "1.1.0"}
+		want := []string{"1.1.0", "1.2.0", "1.2.0-sp1", "1.2.0-rc1"}
+		got, err := univers.SortVersions(&maven.Ecosystem{}, versions, univers.SortStableFirst)
+		if err != nil {
+			t.Fatalf("SortVersions() error = %v", err)
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("SortVersions() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("golang v prefix", func(t *testing.T) {
+		versions := []string{"v1.2.0", "v1.2.0-rc.1", "v1.1.0"}
+		want := []string{"v1.1.0", "v1.2.0", "v1.2.0-rc.1"}
+		got, err := univers.SortVersions(&golang.Ecosystem{}, versions, univers.SortStableFirst)
+		if err != nil {
+			t.Fatalf("SortVersions() error = %v", err)
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("SortVersions() = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestSortVersions_Invalid(t *testing.T) {
+	got, err := univers.SortVersions(&npm.Ecosystem{}, []string{"2.0.0", "bogus", "1.0.0"}, univers.SortStream)
+	if err == nil || !strings.Contains(err.Error(), `"bogus"`) {
+		t.Errorf("SortVersions() error = %v, want error naming \"bogus\"", err)
+	}
+	if want := []string{"1.0.0", "2.0.0"}; !slices.Equal(got, want) {
+		t.Errorf("SortVersions() = %v, want %v", got, want)
+	}
+}
+
+func TestSortMode_String(t *testing.T) {
+	tests := []struct {
+		mode univers.SortMode
+		want string
+	}{
+		{mode: univers.SortPrecedence, want: "precedence"},
+		{mode: univers.SortStableFirst, want: "stable-first"},
+		{mode: univers.SortStream, want: "stream"},
+		{mode: univers.SortMode(9), want: "SortMode(9)"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.mode.String(); got != tt.want {
+			t.Errorf("SortMode(%d).String() = %q, want %q", int(tt.mode), got, tt.want)
+		}
+	}
+}
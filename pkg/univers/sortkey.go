@@ -0,0 +1,19 @@
+package univers
+
+// SortKeyer is optionally implemented by versions that can be mapped to a byte
+// key whose lexicographic order matches Compare. For versions a and b of the
+// same ecosystem, bytes.Compare(a.SortKey(), b.SortKey()) equals a.Compare(b),
+// so keys can be precomputed and stored in a database column used for ORDER BY
+// or range scans.
+//
+// Keys are only comparable within a single ecosystem and are not guaranteed to
+// be stable across releases of this module; store the module version alongside
+// them if they are persisted.
+//
+// Only the versions of the cargo, debian, npm, pypi and semver ecosystems
+// implement SortKeyer. Versions of other ecosystems must be ordered with
+// Compare.
+type SortKeyer interface {
+	// SortKey returns the order-preserving key for the version.
+	SortKey() []byte
+}
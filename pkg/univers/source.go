@@ -0,0 +1,64 @@
+package univers
+
+import "fmt"
+
+// VersionSource lists the published versions of a package, typically by
+// querying a registry. Reference adapters for the npm registry, the PyPI JSON
+// API and the crates.io index live in the optional
+// github.com/alowayed/go-univers/pkg/sources module, which keeps network code
+// out of this one.
+type VersionSource interface {
+	// ListVersions returns the published versions of pkg in any order.
+	ListVersions(pkg string) ([]string, error)
+}
+
+// VersionSourceFunc adapts a function to the VersionSource interface, e.g. to
+// serve versions from a cache or a test fixture.
+type VersionSourceFunc func(pkg string) ([]string, error)
+
+// ListVersions calls f(pkg).
+func (f VersionSourceFunc) ListVersions(pkg string) ([]string, error) {
+	return f(pkg)
+}
+
+// MaxSatisfying returns the highest published version of pkg satisfying
+// rangeStr, listing versions from src. The boolean result is false when no
+// version qualifies. Published versions that fail to parse are skipped, since
+// registries hold versions predating their ecosystem's current rules. Pass
+// WithYanked to leave out withdrawn versions the way pip does.
+func MaxSatisfying[V Version[V], VR VersionRange[V]](
+	e Ecosystem[V, VR],
+	src VersionSource,
+	pkg string,
+	rangeStr string,
+	opts ...ResolveOption,
+) (V, bool, error) {
+	var o resolveOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var best V
+	r, err := e.NewVersionRange(rangeStr)
+	if err != nil {
+		return best, false, fmt.Errorf("failed to parse range %q: %w", rangeStr, err)
+	}
+
+	versions, err := src.ListVersions(pkg)
+	if err != nil {
+		return best, false, fmt.Errorf("failed to list versions of %q: %w", pkg, err)
+	}
+
+	found := false
+	for _, s := range versions {
+		v, err := e.NewVersion(s)
+		if err != nil || !r.Contains(v) || o.skip(r, s) {
+			continue
+		}
+		if !found || After(v, best) {
+			best = v
+			found = true
+		}
+	}
+	return best, found, nil
+}
@@ -0,0 +1,79 @@
+package univers_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/npm"
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+func TestMaxSatisfying(t *testing.T) {
+	published := map[string][]string{
+		"left-pad": {"1.0.0", "1.3.0", "2.0.0", "1.2.0", "not-a-version"},
+	}
+	src := univers.VersionSourceFunc(func(pkg string) ([]string, error) {
+		versions, ok := published[pkg]
+		if !ok {
+			return nil, errors.New("package not found")
+		}
+		return versions, nil
+	})
+
+	tests := []struct {
+		name     string
+		pkg      string
+		rangeStr string
+		want     string
+		wantOK   bool
+		wantErr  bool
+	}{
+		{
+			name:     "highest in range",
+			pkg:      "left-pad",
+			rangeStr: "^1.0.0",
+			want:     "1.3.0",
+			wantOK:   true,
+		},
+		{
+			name:     "highest overall",
+			pkg:      "left-pad",
+			rangeStr: ">=1.0.0",
+			want:     "2.0.0",
+			wantOK:   true,
+		},
+		{
+			name:     "no version satisfies",
+			pkg:      "left-pad",
+			rangeStr: ">=3.0.0",
+			wantOK:   false,
+		},
+		{
+			name:     "invalid range",
+			pkg:      "left-pad",
+			rangeStr: ">=x.y.z.w",
+			wantErr:  true,
+		},
+		{
+			name:     "source error",
+			pkg:      "missing",
+			rangeStr: "*",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := univers.MaxSatisfying(&npm.Ecosystem{}, src, tt.pkg, tt.rangeStr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MaxSatisfying() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if ok != tt.wantOK {
+				t.Fatalf("MaxSatisfying() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && got.String() != tt.want {
+				t.Errorf("MaxSatisfying() = %q, want %q", got.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package univers
+
+import "fmt"
+
+// DefaultTag is the tag ResolveTagged prefers when no tag is given, matching npm.
+const DefaultTag = "latest"
+
+// ResolveTagged resolves spec against published versions and named tags the way
+// npm resolves a dependency against a package's dist-tags:
+//
+//   - if spec is itself a tag name (e.g. "next"), the tagged version is returned;
+//   - otherwise spec is parsed as a range, and the version tagged preferTag is
+//     returned when the range contains it (preferTag defaults to DefaultTag);
+//   - otherwise the highest version satisfying the range is returned.
+//
+// Tagged versions are considered together with versions, so tags may point at
+// versions missing from the listing. Versions that fail to parse are skipped.
+func ResolveTagged[V Version[V], VR VersionRange[V]](
+	e Ecosystem[V, VR],
+	spec string,
+	versions []string,
+	tags map[string]string,
+	preferTag string,
+) (V, error) {
+	var zero V
+	if tagged, ok := tags[spec]; ok {
+		v, err := e.NewVersion(tagged)
+		if err != nil {
+			return zero, fmt.Errorf("invalid %s version %q for tag %q: %w", e.Name(), tagged, spec, err)
+		}
+		return v, nil
+	}
+
+	r, err := e.NewVersionRange(spec)
+	if err != nil {
+		return zero, fmt.Errorf("failed to parse range %q: %w", spec, err)
+	}
+
+	if preferTag == "" {
+		preferTag = DefaultTag
+	}
+	if tagged, ok := tags[preferTag]; ok {
+		if v, err := e.NewVersion(tagged); err == nil && r.Contains(v) {
+			return v, nil
+		}
+	}
+
+	var best V
+	found := false
+	candidates := append([]string(nil), versions...)
+	for _, tagged := range tags {
+		candidates = append(candidates, tagged)
+	}
+	for _, s := range candidates {
+		v, err := e.NewVersion(s)
+		if err != nil || !r.Contains(v) {
+			continue
+		}
+		if !found || After(v, best) {
+			best, found = v, true
+		}
+	}
+	if !found {
+		return zero, fmt.Errorf("no %s version satisfies %q", e.Name(), spec)
+	}
+	return best, nil
+}
@@ -0,0 +1,93 @@
+package univers_test
+
+import (
+	"testing"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/npm"
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+func TestResolveTagged(t *testing.T) {
+	versions := []string{"1.0.0", "1.1.0", "1.2.0", "2.0.0-beta.1", "not-a-version"}
+	tags := map[string]string{
+		"latest": "1.1.0",
+		"next":   "2.0.0-beta.1",
+		"canary": "3.0.0-canary.7",
+	}
+
+	tests := []struct {
+		name      string
+		spec      string
+		tags      map[string]string
+		preferTag string
+		want      string
+		wantErr   bool
+	}{
+		{
+			name: "tag name",
+			spec: "next",
+			tags: tags,
+			want: "2.0.0-beta.1",
+		},
+		{
+			name: "tag outside listing",
+			spec: "canary",
+			tags: tags,
+			want: "3.0.0-canary.7",
+		},
+		{
+			name: "latest preferred when satisfying",
+			spec: "^1.0.0",
+			tags: tags,
+			want: "1.1.0",
+		},
+		{
+			name: "highest when latest outside range",
+			spec: "~1.2.0",
+			tags: tags,
+			want: "1.2.0",
+		},
+		{
+			name:      "custom preferred tag",
+			spec:      ">=1.0.0",
+			tags:      tags,
+			preferTag: "next",
+			want:      "2.0.0-beta.1",
+		},
+		{
+			name: "no tags",
+			spec: "^1.0.0",
+			want: "1.2.0",
+		},
+		{
+			name:    "no satisfying version",
+			spec:    "^4.0.0",
+			tags:    tags,
+			wantErr: true,
+		},
+		{
+			name:    "invalid tagged version",
+			spec:    "broken",
+			tags:    map[string]string{"broken": "nope"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid range",
+			spec:    "",
+			tags:    tags,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := univers.ResolveTagged(&npm.Ecosystem{}, tt.spec, versions, tt.tags, tt.preferTag)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveTagged() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.String() != tt.want {
+				t.Errorf("ResolveTagged() = %q, want %q", got.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package univers
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrTraceUnsupported is wrapped by the error WithTrace returns for an
+// ecosystem whose ranges do not implement Traceable.
+var ErrTraceUnsupported = errors.New("ranges do not implement Traceable")
+
+// TraceOp identifies the operation a TraceEvent describes.
+type TraceOp string
+
+const (
+	// TraceNewVersion is emitted after a version string is parsed.
+	TraceNewVersion TraceOp = "NewVersion"
+	// TraceNewVersionRange is emitted after a range string is parsed.
+	TraceNewVersionRange TraceOp = "NewVersionRange"
+	// TraceContains is emitted after a range is checked against a version.
+	TraceContains TraceOp = "Contains"
+)
+
+// TraceEvent records a single parse or match decision made by a traced ecosystem.
+type TraceEvent struct {
+	Op        TraceOp
+	Ecosystem string
+	// Input is the version string for TraceNewVersion and the range string otherwise.
+	Input string
+	// Version is the version checked by TraceContains.
+	Version string
+	// Constraints holds the parsed constraints of the range as an OR of AND groups.
+	Constraints [][]string
+	// Matches reports, for each entry in Constraints, whether Version satisfied it.
+	// Only set for TraceContains.
+	Matches [][]bool
+	// Contained is the result of TraceContains.
+	Contained bool
+	// Err is the parse error, if any.
+	Err error
+}
+
+// Traceable is optionally implemented by version ranges that can describe how
+// they were parsed and which of their constraints a version satisfies.
+type Traceable[V any] interface {
+	// TraceConstraints returns the parsed constraints as an OR of AND groups.
+	TraceConstraints() [][]string
+	// TraceMatches reports whether version satisfies each constraint returned
+	// by TraceConstraints.
+	TraceMatches(version V) [][]bool
+}
+
+// TracedVersionRange wraps a version range and emits a TraceEvent on every Contains call.
+type TracedVersionRange[V Version[V], VR VersionRange[V]] struct {
+	r         VR
+	ecosystem string
+	trace     func(TraceEvent)
+}
+
+// Contains checks if a version is within the wrapped range and emits a TraceContains event.
+func (t *TracedVersionRange[V, VR]) Contains(version V) bool {
+	contained := t.r.Contains(version)
+
+	event := TraceEvent{
+		Op:        TraceContains,
+		Ecosystem: t.ecosystem,
+		Input:     t.r.String(),
+		Version:   version.String(),
+		Contained: contained,
+	}
+	if tr, ok := any(t.r).(Traceable[V]); ok {
+		event.Constraints = tr.TraceConstraints()
+		event.Matches = tr.TraceMatches(version)
+	}
+	t.trace(event)
+
+	return contained
+}
+
+// String returns the original string representation of the wrapped range.
+func (t *TracedVersionRange[V, VR]) String() string {
+	return t.r.String()
+}
+
+// Unwrap returns the wrapped version range.
+func (t *TracedVersionRange[V, VR]) Unwrap() VR {
+	return t.r
+}
+
+// tracedEcosystem wraps an ecosystem and emits trace events for each operation.
+type tracedEcosystem[V Version[V], VR VersionRange[V]] struct {
+	e     Ecosystem[V, VR]
+	trace func(TraceEvent)
+}
+
+// WithTrace wraps an ecosystem so that every parse and Contains call emits a
+// TraceEvent to trace, with the parsed constraints of the range and
+// per-constraint results. It is intended for debugging why a version is or
+// isn't matched by a range.
+//
+// Only ecosystems whose ranges implement Traceable, currently npm and semver,
+// can be traced. For any other ecosystem WithTrace returns an error wrapping
+// ErrTraceUnsupported.
+func WithTrace[V Version[V], VR VersionRange[V]](
+	e Ecosystem[V, VR],
+	trace func(TraceEvent),
+) (Ecosystem[V, *TracedVersionRange[V, VR]], error) {
+	var r VR
+	if _, ok := any(r).(Traceable[V]); !ok {
+		return nil, fmt.Errorf("cannot trace %s: %w", e.Name(), ErrTraceUnsupported)
+	}
+	return &tracedEcosystem[V, VR]{e: e, trace: trace}, nil
+}
+
+func (t *tracedEcosystem[V, VR]) Name() string {
+	return t.e.Name()
+}
+
+func (t *tracedEcosystem[V, VR]) NewVersion(s string) (V, error) {
+	v, err := t.e.NewVersion(s)
+	t.trace(TraceEvent{
+		Op:        TraceNewVersion,
+		Ecosystem: t.e.Name(),
+		Input:     s,
+		Err:       err,
+	})
+	return v, err
+}
+
+func (t *tracedEcosystem[V, VR]) NewVersionRange(s string) (*TracedVersionRange[V, VR], error) {
+	r, err := t.e.NewVersionRange(s)
+
+	event := TraceEvent{
+		Op:        TraceNewVersionRange,
+		Ecosystem: t.e.Name(),
+		Input:     s,
+		Err:       err,
+	}
+	if err == nil {
+		if tr, ok := any(r).(Traceable[V]); ok {
+			event.Constraints = tr.TraceConstraints()
+		}
+	}
+	t.trace(event)
+
+	if err != nil {
+		return nil, err
+	}
+	return &TracedVersionRange[V, VR]{r: r, ecosystem: t.e.Name(), trace: t.trace}, nil
+}
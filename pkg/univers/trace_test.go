@@ -0,0 +1,82 @@
+package univers_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/maven"
+	"github.com/alowayed/go-univers/pkg/ecosystem/npm"
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+func TestWithTrace(t *testing.T) {
+	var events []univers.TraceEvent
+	e, err := univers.WithTrace(&npm.Ecosystem{}, func(event univers.TraceEvent) {
+		events = append(events, event)
+	})
+	if err != nil {
+		t.Fatalf("WithTrace() error = %v", err)
+	}
+
+	if got := e.Name(); got != npm.Name {
+		t.Errorf("Name() = %q, want %q", got, npm.Name)
+	}
+
+	r, err := e.NewVersionRange("^1.2.0 || 3.0.0")
+	if err != nil {
+		t.Fatalf("NewVersionRange() error = %v", err)
+	}
+	v, err := e.NewVersion("1.5.0")
+	if err != nil {
+		t.Fatalf("NewVersion() error = %v", err)
+	}
+	if !r.Contains(v) {
+		t.Errorf("Contains() = false, want true")
+	}
+	if _, err := e.NewVersion("bad"); err == nil {
+		t.Errorf("NewVersion(%q) error = nil, want error", "bad")
+	}
+	if _, err := e.NewVersionRange(""); err == nil {
+		t.Errorf("NewVersionRange(%q) error = nil, want error", "")
+	}
+
+	constraints := [][]string{{">=1.2.0", "<2.0.0-0"}, {"=3.0.0"}}
+	want := []univers.TraceEvent{
+		{Op: univers.TraceNewVersionRange, Ecosystem: "npm", Input: "^1.2.0 || 3.0.0", Constraints: constraints},
+		{Op: univers.TraceNewVersion, Ecosystem: "npm", Input: "1.5.0"},
+		{
+			Op:          univers.TraceContains,
+			Ecosystem:   "npm",
+			Input:       "^1.2.0 || 3.0.0",
+			Version:     "1.5.0",
+			Constraints: constraints,
+			Matches:     [][]bool{{true, true}, {false}},
+			Contained:   true,
+		},
+	}
+
+	if len(events) != 5 {
+		t.Fatalf("got %d events, want 5: %+v", len(events), events)
+	}
+	if !reflect.DeepEqual(events[:3], want) {
+		t.Errorf("events = %+v, want %+v", events[:3], want)
+	}
+	for _, event := range events[3:] {
+		if event.Err == nil {
+			t.Errorf("event %+v has nil Err, want parse error", event)
+		}
+	}
+	if got := r.Unwrap().String(); got != "^1.2.0 || 3.0.0" {
+		t.Errorf("Unwrap().String() = %q, want %q", got, "^1.2.0 || 3.0.0")
+	}
+}
+
+func TestWithTrace_Unsupported(t *testing.T) {
+	_, err := univers.WithTrace(&maven.Ecosystem{}, func(univers.TraceEvent) {
+		t.Error("trace called for an unsupported ecosystem")
+	})
+	if !errors.Is(err, univers.ErrTraceUnsupported) {
+		t.Errorf("WithTrace() error = %v, want %v", err, univers.ErrTraceUnsupported)
+	}
+}
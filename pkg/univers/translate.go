@@ -0,0 +1,197 @@
+package univers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TranslateError is returned by TranslateRange when a range has no
+// equivalent in the target ecosystem, such as alternatives ("||") for an
+// ecosystem without them or a prerelease PEP 440 cannot spell.
+type TranslateError struct {
+	// From and To are the source and target ecosystem names.
+	From, To string
+	// Range is the range being translated.
+	Range string
+	// Reason describes what could not be translated.
+	Reason string
+}
+
+// Error returns the reason along with the range and ecosystems.
+func (e *TranslateError) Error() string {
+	return fmt.Sprintf("cannot translate %s range %q to %s: %s", e.From, e.Range, e.To, e.Reason)
+}
+
+// translateSyntax describes how an ecosystem spells a range of comparators
+type translateSyntax struct {
+	// and and or join comparators within a group and groups; or is empty
+	// when the ecosystem has no alternatives
+	and, or string
+	// any is a range matching every version, or empty if there is none
+	any string
+	// equal is the spelling of the "=" operator
+	equal string
+	// notEqual reports whether "!=" is supported
+	notEqual bool
+	// toSemVer and fromSemVer convert versions to and from SemVer 2.0
+	toSemVer, fromSemVer func(string) (string, bool)
+}
+
+// translateSyntaxes lists the ecosystems TranslateRange converts between:
+// the SemVer family, and PyPI for versions that are plain releases
+var translateSyntaxes = map[string]translateSyntax{
+	string(Cargo):  {and: ", ", any: "*", equal: "=", notEqual: true, toSemVer: semVerCore, fromSemVer: semVerCore},
+	string(NPM):    {and: " ", or: " || ", any: "*", equal: "=", toSemVer: semVerCore, fromSemVer: semVerCore},
+	string(PyPI):   {and: ", ", equal: "==", notEqual: true, toSemVer: pep440ToSemVer, fromSemVer: semVerToPEP440},
+	string(SemVer): {and: " ", any: "*", equal: "=", notEqual: true, toSemVer: semVerCore, fromSemVer: semVerCore},
+}
+
+// TranslateRange rewrites rangeStr, a range in from's syntax, into a range
+// with the same bounds in to's syntax. Caret, tilde, wildcard and compatible
+// release (~=) ranges are expanded to comparators first, so
+// TranslateRange(&npm.Ecosystem{}, &cargo.Ecosystem{}, "^1.2.3") returns
+// ">=1.2.3, <2.0.0-0" and a PyPI "~=1.4.2" becomes the SemVer ">=1.4.2 <1.5.0".
+//
+// Pre-release matching is not carried over: the translated range matches
+// pre-releases by to's rules. Cargo only matches a pre-release when a
+// comparator names a pre-release of the same version, so the npm "^1.2.3"
+// matches 1.5.0-beta but its Cargo translation does not. Releases match the
+// same in both ranges.
+//
+// Cargo, npm, PyPI and SemVer are supported. A range that the target cannot
+// express returns a *TranslateError; an invalid rangeStr returns from's parse
+// error.
+func TranslateRange[FV Version[FV], FR VersionRange[FV], TV Version[TV], TR VersionRange[TV]](
+	from Ecosystem[FV, FR], to Ecosystem[TV, TR], rangeStr string,
+) (string, error) {
+	fail := func(format string, args ...any) error {
+		return &TranslateError{From: from.Name(), To: to.Name(), Range: rangeStr, Reason: fmt.Sprintf(format, args...)}
+	}
+
+	src, ok := translateSyntaxes[from.Name()]
+	if !ok {
+		return "", fail("%s ranges are not supported", from.Name())
+	}
+	dst, ok := translateSyntaxes[to.Name()]
+	if !ok {
+		return "", fail("%s ranges are not supported", to.Name())
+	}
+
+	r, err := from.NewVersionRange(rangeStr)
+	if err != nil {
+		return "", err
+	}
+	cr, ok := any(r).(ComparatorRange)
+	if !ok {
+		return "", fail("%s ranges do not list their comparators", from.Name())
+	}
+
+	groups := cr.Comparators()
+	if len(groups) > 1 && dst.or == "" {
+		return "", fail("%s has no syntax for alternatives", to.Name())
+	}
+
+	translated := make([]string, 0, len(groups))
+	for _, group := range groups {
+		comparators := make([]string, 0, len(group))
+		for _, c := range group {
+			op := c.Operator
+			switch op {
+			case "=":
+				op = dst.equal
+			case "!=":
+				if !dst.notEqual {
+					return "", fail("%s has no != operator", to.Name())
+				}
+			case "<", "<=", ">", ">=":
+			default:
+				return "", fail("operator %s has no equivalent", op)
+			}
+
+			semver, ok := src.toSemVer(c.Version)
+			if !ok {
+				return "", fail("version %s has no SemVer equivalent", c.Version)
+			}
+			version, ok := dst.fromSemVer(semver)
+			if !ok {
+				return "", fail("version %s has no %s equivalent", semver, to.Name())
+			}
+			comparators = append(comparators, op+version)
+		}
+
+		if len(comparators) == 0 {
+			if dst.any == "" {
+				return "", fail("%s has no syntax for any version", to.Name())
+			}
+			comparators = append(comparators, dst.any)
+		}
+		translated = append(translated, strings.Join(comparators, dst.and))
+	}
+
+	out := strings.Join(translated, dst.or)
+	if _, err := to.NewVersionRange(out); err != nil {
+		return "", fail("%s does not accept %q: %v", to.Name(), out, err)
+	}
+	return out, nil
+}
+
+// semVerCore returns the SemVer 2.0 version s without build metadata, or
+// false if s is not one
+func semVerCore(s string) (string, bool) {
+	s, _, _ = strings.Cut(s, "+")
+	core, pre, hasPre := strings.Cut(s, "-")
+	for i := range 3 {
+		part, rest, more := strings.Cut(core, ".")
+		if part == "" || strings.Trim(part, "0123456789") != "" || (len(part) > 1 && part[0] == '0') || more != (i < 2) {
+			return "", false
+		}
+		core = rest
+	}
+	if hasPre && (pre == "" || strings.Trim(pre, "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz-.") != "") {
+		return "", false
+	}
+	return s, true
+}
+
+// pep440ToSemVer converts a PEP 440 release of up to three components, with
+// an optional ".dev0" marking the lowest version of the release, to SemVer
+func pep440ToSemVer(s string) (string, bool) {
+	s = strings.TrimPrefix(strings.ToLower(s), "v")
+	release, dev := strings.CutSuffix(s, ".dev0")
+
+	parts := strings.Split(release, ".")
+	if len(parts) > 3 {
+		return "", false
+	}
+	for i, part := range parts {
+		if part == "" || strings.Trim(part, "0123456789") != "" {
+			return "", false
+		}
+		if parts[i] = strings.TrimLeft(part, "0"); parts[i] == "" {
+			parts[i] = "0"
+		}
+	}
+	for len(parts) < 3 {
+		parts = append(parts, "0")
+	}
+
+	semver := strings.Join(parts, ".")
+	if dev {
+		semver += "-0"
+	}
+	return semver, true
+}
+
+// semVerToPEP440 converts a SemVer release to PEP 440. The "-0" prerelease,
+// the lowest version of a release, becomes ".dev0"; other prereleases have
+// no equivalent.
+func semVerToPEP440(s string) (string, bool) {
+	core, pre, hasPre := strings.Cut(s, "-")
+	switch {
+	case !hasPre:
+		return core, true
+	case pre == "0":
+		return core + ".dev0", true
+	}
+	return "", false
+}
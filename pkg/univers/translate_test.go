@@ -0,0 +1,223 @@
+package univers_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/cargo"
+	"github.com/alowayed/go-univers/pkg/ecosystem/maven"
+	"github.com/alowayed/go-univers/pkg/ecosystem/npm"
+	"github.com/alowayed/go-univers/pkg/ecosystem/pypi"
+	"github.com/alowayed/go-univers/pkg/ecosystem/semver"
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+func TestTranslateRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		translate func(string) (string, error)
+		rangeStr  string
+		want      string
+		wantErr   bool
+		// wantTranslateErr expects a *TranslateError rather than a parse error
+		wantTranslateErr bool
+	}{
+		{
+			name: "npm caret to cargo",
+			translate: func(s string) (string, error) {
+				return univers.TranslateRange(&npm.Ecosystem{}, &cargo.Ecosystem{}, s)
+			},
+			rangeStr: "^1.2.3",
+			want:     ">=1.2.3, <2.0.0-0",
+		},
+		{
+			name: "npm alternatives stay alternatives",
+			translate: func(s string) (string, error) {
+				return univers.TranslateRange(&npm.Ecosystem{}, &npm.Ecosystem{}, s)
+			},
+			rangeStr: "~1.2.0 || >=3.0.0",
+			want:     ">=1.2.0 <1.3.0-0 || >=3.0.0",
+		},
+		{
+			name: "cargo tilde to npm",
+			translate: func(s string) (string, error) {
+				return univers.TranslateRange(&cargo.Ecosystem{}, &npm.Ecosystem{}, s)
+			},
+			rangeStr: "~1.2",
+			want:     ">=1.2.0 <1.3.0-0",
+		},
+		{
+			name: "cargo caret on zero major to semver",
+			translate: func(s string) (string, error) {
+				return univers.TranslateRange(&cargo.Ecosystem{}, &semver.Ecosystem{}, s)
+			},
+			rangeStr: "^0.2.3",
+			want:     ">=0.2.3 <0.3.0-0",
+		},
+		{
+			name: "semver not equal to cargo",
+			translate: func(s string) (string, error) {
+				return univers.TranslateRange(&semver.Ecosystem{}, &cargo.Ecosystem{}, s)
+			},
+			rangeStr: ">=1.0.0 !=1.2.4 <2.0.0",
+			want:     ">=1.0.0, !=1.2.4, <2.0.0",
+		},
+		{
+			name: "semver wildcard to npm",
+			translate: func(s string) (string, error) {
+				return univers.TranslateRange(&semver.Ecosystem{}, &npm.Ecosystem{}, s)
+			},
+			rangeStr: "*",
+			want:     "*",
+		},
+		{
+			name: "pypi compatible release to semver",
+			translate: func(s string) (string, error) {
+				return univers.TranslateRange(&pypi.Ecosystem{}, &semver.Ecosystem{}, s)
+			},
+			rangeStr: "~=1.4.2",
+			want:     ">=1.4.2 <1.5.0",
+		},
+		{
+			name: "pypi exact to npm",
+			translate: func(s string) (string, error) {
+				return univers.TranslateRange(&pypi.Ecosystem{}, &npm.Ecosystem{}, s)
+			},
+			rangeStr: "==1.4",
+			want:     "=1.4.0",
+		},
+		{
+			name: "npm caret to pypi",
+			translate: func(s string) (string, error) {
+				return univers.TranslateRange(&npm.Ecosystem{}, &pypi.Ecosystem{}, s)
+			},
+			rangeStr: "^1.2.3",
+			want:     ">=1.2.3, <2.0.0.dev0",
+		},
+		// Error cases
+		{
+			name: "alternatives into cargo",
+			translate: func(s string) (string, error) {
+				return univers.TranslateRange(&npm.Ecosystem{}, &cargo.Ecosystem{}, s)
+			},
+			rangeStr:         "1.0.0 || 2.0.0",
+			wantErr:          true,
+			wantTranslateErr: true,
+		},
+		{
+			name: "not equal into npm",
+			translate: func(s string) (string, error) {
+				return univers.TranslateRange(&semver.Ecosystem{}, &npm.Ecosystem{}, s)
+			},
+			rangeStr:         "!=1.0.0",
+			wantErr:          true,
+			wantTranslateErr: true,
+		},
+		{
+			name: "prerelease into pypi",
+			translate: func(s string) (string, error) {
+				return univers.TranslateRange(&semver.Ecosystem{}, &pypi.Ecosystem{}, s)
+			},
+			rangeStr:         ">=1.0.0-beta.1",
+			wantErr:          true,
+			wantTranslateErr: true,
+		},
+		{
+			name: "pypi post release",
+			translate: func(s string) (string, error) {
+				return univers.TranslateRange(&pypi.Ecosystem{}, &semver.Ecosystem{}, s)
+			},
+			rangeStr:         ">=1.0.post1",
+			wantErr:          true,
+			wantTranslateErr: true,
+		},
+		{
+			name: "pypi wildcard exclusion",
+			translate: func(s string) (string, error) {
+				return univers.TranslateRange(&pypi.Ecosystem{}, &cargo.Ecosystem{}, s)
+			},
+			rangeStr:         "!=1.2.*",
+			wantErr:          true,
+			wantTranslateErr: true,
+		},
+		{
+			name: "match all into pypi",
+			translate: func(s string) (string, error) {
+				return univers.TranslateRange(&npm.Ecosystem{}, &pypi.Ecosystem{}, s)
+			},
+			rangeStr:         "*",
+			wantErr:          true,
+			wantTranslateErr: true,
+		},
+		{
+			name: "unsupported ecosystem",
+			translate: func(s string) (string, error) {
+				return univers.TranslateRange(&maven.Ecosystem{}, &npm.Ecosystem{}, s)
+			},
+			rangeStr:         "[1.0,2.0)",
+			wantErr:          true,
+			wantTranslateErr: true,
+		},
+		{
+			name: "invalid source range",
+			translate: func(s string) (string, error) {
+				return univers.TranslateRange(&npm.Ecosystem{}, &cargo.Ecosystem{}, s)
+			},
+			rangeStr: "^bogus",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.translate(tt.rangeStr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TranslateRange(%q) error = %v, wantErr %v", tt.rangeStr, err, tt.wantErr)
+			}
+			var translateErr *univers.TranslateError
+			if errors.As(err, &translateErr) != tt.wantTranslateErr {
+				t.Errorf("TranslateRange(%q) error = %v, want *TranslateError %v", tt.rangeStr, err, tt.wantTranslateErr)
+			}
+			if got != tt.want {
+				t.Errorf("TranslateRange(%q) = %q, want %q", tt.rangeStr, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestTranslateRange_PreReleases pins that TranslateRange carries over the
+// bounds of a range but not its pre-release matching: npm matches
+// pre-releases within the bounds, while Cargo matches them only when a
+// comparator names a pre-release of the same version.
+func TestTranslateRange_PreReleases(t *testing.T) {
+	npmEcosystem, cargoEcosystem := &npm.Ecosystem{}, &cargo.Ecosystem{}
+
+	translated, err := univers.TranslateRange(npmEcosystem, cargoEcosystem, "^1.2.3")
+	if err != nil {
+		t.Fatalf("TranslateRange(%q) error = %v", "^1.2.3", err)
+	}
+	from := univers.MustNewVersionRange(npmEcosystem, "^1.2.3")
+	to := univers.MustNewVersionRange(cargoEcosystem, translated)
+
+	tests := []struct {
+		version  string
+		wantFrom bool
+		wantTo   bool
+	}{
+		{version: "1.2.3", wantFrom: true, wantTo: true},
+		{version: "1.9.0", wantFrom: true, wantTo: true},
+		{version: "2.0.0", wantFrom: false, wantTo: false},
+		{version: "1.5.0-beta", wantFrom: true, wantTo: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			if got := from.Contains(univers.MustNewVersion(npmEcosystem, tt.version)); got != tt.wantFrom {
+				t.Errorf("npm %q Contains(%q) = %v, want %v", "^1.2.3", tt.version, got, tt.wantFrom)
+			}
+			if got := to.Contains(univers.MustNewVersion(cargoEcosystem, tt.version)); got != tt.wantTo {
+				t.Errorf("cargo %q Contains(%q) = %v, want %v", translated, tt.version, got, tt.wantTo)
+			}
+		})
+	}
+}
@@ -0,0 +1,297 @@
+package univers_test
+
+import (
+	"testing"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/alpine"
+	"github.com/alowayed/go-univers/pkg/ecosystem/alpm"
+	"github.com/alowayed/go-univers/pkg/ecosystem/apache"
+	"github.com/alowayed/go-univers/pkg/ecosystem/bazel"
+	"github.com/alowayed/go-univers/pkg/ecosystem/cargo"
+	"github.com/alowayed/go-univers/pkg/ecosystem/composer"
+	"github.com/alowayed/go-univers/pkg/ecosystem/conan"
+	"github.com/alowayed/go-univers/pkg/ecosystem/cpan"
+	"github.com/alowayed/go-univers/pkg/ecosystem/cran"
+	"github.com/alowayed/go-univers/pkg/ecosystem/debian"
+	"github.com/alowayed/go-univers/pkg/ecosystem/gem"
+	"github.com/alowayed/go-univers/pkg/ecosystem/genericwin"
+	"github.com/alowayed/go-univers/pkg/ecosystem/gentoo"
+	"github.com/alowayed/go-univers/pkg/ecosystem/github"
+	"github.com/alowayed/go-univers/pkg/ecosystem/golang"
+	"github.com/alowayed/go-univers/pkg/ecosystem/gover"
+	"github.com/alowayed/go-univers/pkg/ecosystem/hex"
+	"github.com/alowayed/go-univers/pkg/ecosystem/luarocks"
+	"github.com/alowayed/go-univers/pkg/ecosystem/mattermost"
+	"github.com/alowayed/go-univers/pkg/ecosystem/maven"
+	"github.com/alowayed/go-univers/pkg/ecosystem/msver"
+	"github.com/alowayed/go-univers/pkg/ecosystem/npm"
+	"github.com/alowayed/go-univers/pkg/ecosystem/nuget"
+	"github.com/alowayed/go-univers/pkg/ecosystem/pypi"
+	"github.com/alowayed/go-univers/pkg/ecosystem/rpm"
+	"github.com/alowayed/go-univers/pkg/ecosystem/semver"
+)
+
+// validVersionCase checks an ecosystem's ValidVersion against its NewVersion
+type validVersionCase struct {
+	parses func(string) bool
+	valid  func(string) bool
+	// inputs are checked along with every string of up to length characters
+	// drawn from alphabet
+	inputs   []string
+	alphabet []string
+	length   int
+	// noAllocs are inputs on which ValidVersion must not allocate
+	noAllocs []string
+}
+
+// parses adapts an ecosystem's NewVersion to report whether it succeeds
+func parses[V any](newVersion func(string) (V, error)) func(string) bool {
+	return func(s string) bool {
+		_, err := newVersion(s)
+		return err == nil
+	}
+}
+
+// validVersionEcosystems holds, for every ecosystem, inputs that exercise the
+// edges of its grammar and an alphabet of the characters that matter to it
+var validVersionEcosystems = map[string]validVersionCase{
+	"alpine": {
+		parses:   parses((&alpine.Ecosystem{}).NewVersion),
+		valid:    alpine.ValidVersion,
+		inputs:   []string{"1.0", " 1.2.3a_alpha1_p2~abc123-r4 ", "1.0bc", "abc", "1.0_", "1.0-r", "1.0_rc9223372036854775808", "1.0-r9223372036854775808", "9223372036854775808.1", "1.0~99999999999999999999999", "1.0_foo_bar1"},
+		alphabet: []string{"0", "1", ".", "_", "a", "p", "~", "-", "r", "A", " "},
+		length:   5,
+		noAllocs: []string{"1.2.3a_alpha1_p2~abc123-r4", "1.0bc"},
+	},
+	"alpm": {
+		parses:   parses((&alpm.Ecosystem{}).NewVersion),
+		valid:    alpm.ValidVersion,
+		inputs:   []string{"1.0", " 1:2.3-4 ", ":1.0", "+1:1.0", "-1:1.0", "-0:1.0", "+:1.0", "1:", "1::2", "1.0-", "-1", "1.0-a-1", "1.0-\u0663", "9223372036854775807:1.0", "9223372036854775808:1.0", "-9223372036854775808:1.0", "1.0-9223372036854775808", "1.0_rc1+git-\u00e9", "1.0\xff"},
+		alphabet: []string{"0", "1", ":", "-", "+", ".", "a", "\u0663", "\xff", " "},
+		length:   5,
+		noAllocs: []string{"1:2.3_rc1+git-4", "-1:1.0"},
+	},
+	"apache": {
+		parses:   parses((&apache.Ecosystem{}).NewVersion),
+		valid:    apache.ValidVersion,
+		inputs:   []string{"2.4.41", " 2.4.41-RC1 ", "9.0.0.M1", "2.4.41-v20230415", "2.4.41-RCv12345678", "2.4", "2.4.41-", "2.4.41-RC9223372036854775808", "9223372036854775808.0.0", "2.4.41_RC1"},
+		alphabet: []string{"0", "1", ".", "-", "R", "v", "_", " "},
+		length:   6,
+		noAllocs: []string{"2.4.41-v20230415", "2.4"},
+	},
+	"bazel": {
+		parses:   parses((&bazel.Ecosystem{}).NewVersion),
+		valid:    bazel.ValidVersion,
+		inputs:   []string{"1.0", " 1.0a-rc.1+build-5 ", "20210324.2", "1.0-rc.99999999999999999999-x", "1.0+a-99999999999999999999", "1.0-99999999999999999999", "99999999999999999999.1", "9223372036854775807", "1..0", "1.0-", ""},
+		alphabet: []string{"0", "1", ".", "-", "+", "a", "_", " "},
+		length:   6,
+		noAllocs: []string{"1.0a-rc.1+build-5", "1..0"},
+	},
+	"cargo": {
+		parses:   parses((&cargo.Ecosystem{}).NewVersion),
+		valid:    cargo.ValidVersion,
+		inputs:   []string{"1.2.3", " 1.2.3-alpha.1+build.5 ", "v1.2.3", "1.2", "1.2.3-", "1.2.3-a..b", "", "9223372036854775807.0.0", "9223372036854775808.0.0", "1.0.0-99999999999999999999999"},
+		alphabet: []string{"0", "1", ".", "-", "+", "a", " "},
+		length:   6,
+		noAllocs: []string{"1.2.3-alpha.1+build.5", "1.2"},
+	},
+	"composer": {
+		parses:   parses((&composer.Ecosystem{}).NewVersion),
+		valid:    composer.ValidVersion,
+		inputs:   []string{"1.2.3", " v1.2.3.4.5 ", "1.2.3-alpha.1", "1.0a1", "1.0pl1", "1.2.3-RC1+build.5", "dev-main", "dev-", "main", "feature/x", "feature/", "1.x-dev", "1.2.x", "foo", "1.0.0.0.99999999999999999999", "1.0.0.99999999999999999999", "1.0-alpha.99999999999999999999", "1.0RC99999999999999999999", "1.0+99999999999999999999"},
+		alphabet: []string{"0", "1", ".", "-", "+", "a", "v", "d", "e", "/", " "},
+		length:   5,
+		noAllocs: []string{"v1.2.3.4-RC.1+build.5", "dev-main", "feature/x", "1.2.x"},
+	},
+	"conan": {
+		parses:   parses((&conan.Ecosystem{}).NewVersion),
+		valid:    conan.ValidVersion,
+		inputs:   []string{"1.2.3", " 1.2.3.A-Alpha.1+Build-5 ", "1.2.3-01", "1.2.3+01", "01.2", "1.2.3-0", "1.2.3-a+b+c", "1..2", "1.2-", "K", "\u212a", "1.0-\u212a", "1.\u0130", "1.0\xff", "", "1.0_1"},
+		alphabet: []string{"0", "1", ".", "-", "+", "a", "A", "\u212a", "\xff", " "},
+		length:   5,
+		noAllocs: []string{"1.2.3.A-Alpha.1+Build-5", "1.2.3-01"},
+	},
+	"cpan": {
+		parses:   parses((&cpan.Ecosystem{}).NewVersion),
+		valid:    cpan.ValidVersion,
+		inputs:   []string{"1.002003", " v1.2.3 ", "1.2_3", "1_2.3", "1.2_", "_1", "1__2", "1_2_3", "v1", "v", "1.", ".1", "1.2.3_4", "9223372036854775807", "9223372036854775808", "922337203685477580_7", "922337203685477580_8", "0000000000000000000009_223372036854775807", "1.99999999999999999999999", "1.2.99999999999999999999999", "v1.99999999999999999999999"},
+		alphabet: []string{"0", "1", ".", "_", "v", "a", " "},
+		length:   6,
+		noAllocs: []string{"v1.2_3", "1.002003", "1__2"},
+	},
+	"cran": {
+		parses:   parses((&cran.Ecosystem{}).NewVersion),
+		valid:    cran.ValidVersion,
+		inputs:   []string{"1.0", " 1.0-2.3 ", "1", "1.", "1..0", "-1.0", "9223372036854775807.0", "9223372036854775808.0", "0000000000000000000000000001.0", "1.0-99999999999999999999"},
+		alphabet: []string{"0", "1", ".", "-", "a", " "},
+		length:   6,
+		noAllocs: []string{"1.0-2.3", "1..0"},
+	},
+	"debian": {
+		parses:   parses((&debian.Ecosystem{}).NewVersion),
+		valid:    debian.ValidVersion,
+		inputs:   []string{"1.0", " 1:2.3-4ubuntu1 ", "1:", ":1", "1::2", "1.0-", "-1", "a1.0", "1.0-a-b", "1:2:3", "1.0\n-1", "1.0-1\n", "1:\n", "9223372036854775807:1.0", "9223372036854775808:1.0", "1.0~rc1+dfsg-1\u00e9", "1.0\xff"},
+		alphabet: []string{"0", "1", ":", "-", ".", "~", "a", "\u00e9", "\xff", "\n", " "},
+		length:   5,
+		noAllocs: []string{"1:2.3~rc1+dfsg-4ubuntu1", "a1.0"},
+	},
+	"gem": {
+		parses:   parses((&gem.Ecosystem{}).NewVersion),
+		valid:    gem.ValidVersion,
+		inputs:   []string{"1.0", " v1.2.3.pre1-x+y ", "vv1.0", "v", "1.0.a", "1.0a", "1.0-", "1..0", "1.0-x86_64-linux", "1.0-x86_64-linux-", "1.0--java", "1.0-java+b"},
+		alphabet: []string{"0", "1", ".", "-", "+", "a", "v", " "},
+		length:   5,
+		noAllocs: []string{"v1.2.3.pre1-x+y", "vv1.0", "1.0-x86_64-linux"},
+	},
+	"genericwin": {
+		parses:   parses((&genericwin.Ecosystem{}).NewVersion),
+		valid:    genericwin.ValidVersion,
+		inputs:   []string{"6.3.9600", " 10.0.14393.0 (WinBuild.160101.0800) ", "6.3(x)", "6.3x", "6.3 x", "6.3\tx", "6", "6.", ".6", "6..3", "18446744073709551615.0", "18446744073709551616.0"},
+		alphabet: []string{"0", "1", ".", "(", "x", " ", "\t"},
+		length:   6,
+		noAllocs: []string{"10.0.14393.0 (WinBuild.160101.0800)", "6.3x"},
+	},
+	"gentoo": {
+		parses:   parses((&gentoo.Ecosystem{}).NewVersion),
+		valid:    gentoo.ValidVersion,
+		inputs:   []string{"1.2.3", " 1.2.3b_rc1-r2 ", "1.2.3_p", "1.2.3-r", "1.2.3_x1", "1.2.3.4.5.6.7.8.9.10.11", "1.2.3.4.5.6.7.8.9.10.11.12", "9223372036854775808", "1_p9223372036854775808", "1-r9223372036854775808", "1_p-r1"},
+		alphabet: []string{"0", "1", ".", "_", "p", "-", "r", "B", " "},
+		length:   5,
+		noAllocs: []string{"1.2.3b_rc1-r2", "1.2.3_x1"},
+	},
+	"github": {
+		parses:   parses((&github.Ecosystem{}).NewVersion),
+		valid:    github.ValidVersion,
+		inputs:   []string{"1.2.3", " v1.2.3-beta.1 ", "release-1.2.3", "rel-1.2.3.RC2", "2023.04.15", "v2023.4.5", "2023.13.01", "2023.00.01", "2023.12.32", "2023.12.00", "20234.1.1", "1.2.3-", "1.2.3-beta.", "1.2.3-beta.99999999999999999999", "9223372036854775808.0.0", "release-v1.2.3"},
+		alphabet: []string{"0", "1", "2", ".", "-", "a", "v", " "},
+		length:   6,
+		noAllocs: []string{"release-1.2.3-beta.1", "2023.04.15", "2023.13.01"},
+	},
+	"golang": {
+		parses:   parses((&golang.Ecosystem{}).NewVersion),
+		valid:    golang.ValidVersion,
+		inputs:   []string{"v1.2.3", " 1.2.3 ", "vv1.2.3", "v", "", "v1.2.3-beta.1+build", "v1.2", "v9223372036854775807.0.0", "v9223372036854775808.0.0"},
+		alphabet: []string{"0", "1", ".", "-", "+", "a", "v", " "},
+		length:   5,
+		noAllocs: []string{"1.2.3-beta.1+build", "v1.2"},
+	},
+	"gover": {
+		parses:   parses((&gover.Ecosystem{}).NewVersion),
+		valid:    gover.ValidVersion,
+		inputs:   []string{"go1.22.3", "1.22", " go1.21rc2 ", "go1.21.5-bigcorp", "go", "", "1.21.3rc1", "1.021"},
+		alphabet: []string{"0", "1", ".", "r", "c", "g", "o", "-"},
+		length:   5,
+		noAllocs: []string{"go1.22rc1", "go1.21.5-bigcorp"},
+	},
+	"hex": {
+		parses:   parses((&hex.Ecosystem{}).NewVersion),
+		valid:    hex.ValidVersion,
+		inputs:   []string{"1.2.3", " 1.2.3-rc.1+build.5 ", "1.2", "1", "1.2.3-.a", "1.2.3-a.", "1.2.3-a..b", "1.2.3+..", "1.2.3-", "9223372036854775808.0", "1.9223372036854775808", "1.2.9223372036854775808", "1.2.3-99999999999999999999"},
+		alphabet: []string{"0", "1", ".", "-", "+", "a", " "},
+		length:   6,
+		noAllocs: []string{"1.2.3-rc.1+build.5", "1.2", "1.2.3-a..b"},
+	},
+	"luarocks": {
+		parses:   parses((&luarocks.Ecosystem{}).NewVersion),
+		valid:    luarocks.ValidVersion,
+		inputs:   []string{"1.0-1", " 1.0rc1-2 ", "scm-1", "-1", "1.0-", ".1", "1..0", "1.0_a", "1.0+", "1125899906842624", "1125899906842625", "1.0-9223372036854775807", "1.0-9223372036854775808"},
+		alphabet: []string{"0", "1", ".", "-", "_", "a", "+", " "},
+		length:   6,
+		noAllocs: []string{"1.0rc1-2", "1.0+"},
+	},
+	"mattermost": {
+		parses:   parses((&mattermost.Ecosystem{}).NewVersion),
+		valid:    mattermost.ValidVersion,
+		inputs:   []string{"v8.1.5", " 10.12.0-rc2 ", "8.1.5-esr", "8.1.5-esr3", "8.1", "08.1.5", "9223372036854775807.0.0", "9223372036854775808.0.0", "1.9223372036854775808.0", "1.0.9223372036854775808", "1.0.0-rc9223372036854775807", "1.0.0-rc9223372036854775808", "1.0.0-esr9223372036854775808", "1.0.0-beta"},
+		alphabet: []string{"0", "1", ".", "-", "v", "rc"},
+		length:   6,
+		noAllocs: []string{"v8.1.5", "1.0.0-rc2"},
+	},
+	"maven": {
+		parses:   parses((&maven.Ecosystem{}).NewVersion),
+		valid:    maven.ValidVersion,
+		inputs:   []string{"1.0", " ", "a", "A", "b", "m", "x", "ALPHA", "Snapshot", "\u0130", "s\u0130", "\u212a", "${project.version}", "1.0-${x}", "$", "\u0663", "gA", "fin\u0130l", "\u00ff"},
+		alphabet: []string{"a", "r", "c", "x", "$", "{", "}", "1"},
+		length:   3,
+		noAllocs: []string{"1.0", "alpha", "ReLeAsE"},
+	},
+	"msver": {
+		parses:   parses((&msver.Ecosystem{}).NewVersion),
+		valid:    msver.ValidVersion,
+		inputs:   []string{"1.0", " 1.2.3.4 ", "1", "1.2.3.4.5", "1..2", "2147483647.0", "2147483648.0", "0002147483647.1", "1.-1", "1.+1", "1.a"},
+		alphabet: []string{"0", "1", ".", "-", " "},
+		length:   6,
+		noAllocs: []string{"1.0", "1.2.3.4"},
+	},
+	"npm": {
+		parses:   parses((&npm.Ecosystem{}).NewVersion),
+		valid:    npm.ValidVersion,
+		inputs:   []string{"1.2.3", " v1.2.3 ", "=1.2.3", "v=1.2.3", "=v1.2.3", "vv1.2.3", "1.2.3-alpha.1+build.5", "1.2.3-", "1.2.3-a..b", "1.2", "", "9223372036854775807.0.0", "9223372036854775808.0.0", "00000000000000000000001.0.0"},
+		alphabet: []string{"0", "1", ".", "-", "+", "a", "v", "=", " "},
+		length:   5,
+		noAllocs: []string{"1.2.3-alpha.1+build.5", "1.2.x"},
+	},
+	"nuget": {
+		parses:   parses((&nuget.Ecosystem{}).NewVersion),
+		valid:    nuget.ValidVersion,
+		inputs:   []string{"1.2.3.4", " v1.2 ", "vv1", "1.2.3.4.5", "1.0.0-beta.1+build", "1-", "", "9223372036854775807.1", "1.9223372036854775808", "1.2.3-99999999999999999999"},
+		alphabet: []string{"0", "1", ".", "-", "+", "a", "v", " "},
+		length:   5,
+		noAllocs: []string{"1.2.3.4-beta.1+build", "1.2.3.4.5"},
+	},
+	"pypi": {
+		parses:   parses((&pypi.Ecosystem{}).NewVersion),
+		valid:    pypi.ValidVersion,
+		inputs:   []string{"1.0", " 1!2.0.post1.dev3+local.1 ", "1.0a1", "1.0.rc1", "1.0preview2", "1.0-1", "1.0+", "1.0+a..b", "v1.0", "V1.0RC1", "1.0_post_1", "1.0.dev", "1.0a", "", "9223372036854775807", "9223372036854775808", "1.9223372036854775808", "1.0rc9223372036854775808", "9223372036854775808!1.0"},
+		alphabet: []string{"0", "1", ".", "!", "+", "a", "r", "c", "-", " "},
+		length:   5,
+		noAllocs: []string{"1!2.0.post1.dev3+local.1", "1.0-1"},
+	},
+	"rpm": {
+		parses:   parses((&rpm.Ecosystem{}).NewVersion),
+		valid:    rpm.ValidVersion,
+		inputs:   []string{"1.0", " 1:2.3-4.el8 ", "1:", ":1", "1::2", "1.0-", "-1", "a1.0", "1.0-a-b", "1.0\n-1", "9223372036854775807:1.0", "9223372036854775808:1.0", "1.0^git1~rc1_2-1\u00e9", "1.0\xff"},
+		alphabet: []string{"0", "1", ":", "-", ".", "_", "a", "\u00e9", "\xff", "\n", " "},
+		length:   5,
+		noAllocs: []string{"1:2.3^git1~rc1_2-4.el8", "-1"},
+	},
+	"semver": {
+		parses:   parses((&semver.Ecosystem{}).NewVersion),
+		valid:    semver.ValidVersion,
+		inputs:   []string{"1.2.3", " 1.2.3 ", "v1.2.3", "1.2.3-alpha.1+build.5", "1.2.3-01", "1.2.3-0a", "1.2.3+01", "01.2.3", "1.2.3-", "1.2.3-a..b", "", "9223372036854775807.0.0", "9223372036854775808.0.0"},
+		alphabet: []string{"0", "1", ".", "-", "+", "a", " "},
+		length:   6,
+		noAllocs: []string{"1.2.3-alpha.1+build.5", "1.2.3-01"},
+	},
+}
+
+func TestValidVersion(t *testing.T) {
+	for name, tc := range validVersionEcosystems {
+		t.Run(name, func(t *testing.T) {
+			inputs := tc.inputs
+			frontier := []string{""}
+			for range tc.length {
+				var next []string
+				for _, prefix := range frontier {
+					for _, c := range tc.alphabet {
+						next = append(next, prefix+c)
+					}
+				}
+				inputs = append(inputs, next...)
+				frontier = next
+			}
+
+			for _, input := range inputs {
+				if got, want := tc.valid(input), tc.parses(input); got != want {
+					t.Errorf("ValidVersion(%q) = %v, want %v", input, got, want)
+				}
+			}
+
+			for _, input := range tc.noAllocs {
+				if allocs := testing.AllocsPerRun(10, func() { tc.valid(input) }); allocs != 0 {
+					t.Errorf("ValidVersion(%q) allocates %v times, want 0", input, allocs)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package univers
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// VersionSet is an immutable, sorted set of parsed versions for answering
+// many queries against one large static catalog, such as every version a
+// registry has published. Lookups binary-search the sorted versions rather
+// than comparing against each of them.
+type VersionSet[V Version[V]] struct {
+	versions []V
+	parse    func(string) (V, error)
+}
+
+// NewVersionSet parses versions in ecosystem e and returns them as a set.
+// Versions that compare equal, such as "1.0" and "1.0.0" where the ecosystem
+// treats them alike, are kept once, as the first of them in the input.
+//
+// Invalid versions are reported as for Max: all of them are joined in the
+// returned error, and the set still holds the remaining versions.
+func NewVersionSet[V Version[V], VR VersionRange[V]](e Ecosystem[V, VR], versions []string) (*VersionSet[V], error) {
+	parsed := make([]V, 0, len(versions))
+	var errs []error
+	for _, s := range versions {
+		v, err := e.NewVersion(s)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid version %q: %w", s, err))
+			continue
+		}
+		parsed = append(parsed, v)
+	}
+
+	slices.SortStableFunc(parsed, V.Compare)
+	parsed = slices.CompactFunc(parsed, func(a, b V) bool { return a.Compare(b) == 0 })
+
+	return &VersionSet[V]{versions: parsed, parse: e.NewVersion}, errors.Join(errs...)
+}
+
+// Len returns the number of versions in the set.
+func (s *VersionSet[V]) Len() int {
+	return len(s.versions)
+}
+
+// Versions returns the versions in the set in ascending order. The caller
+// must not modify the returned slice.
+func (s *VersionSet[V]) Versions() []V {
+	return s.versions
+}
+
+// Has reports whether the set holds a version that compares equal to v.
+func (s *VersionSet[V]) Has(v V) bool {
+	i := s.lowerBound(v)
+	return i < len(s.versions) && s.versions[i].Compare(v) == 0
+}
+
+// Between returns the versions from lower, inclusive, up to upper,
+// exclusive, in ascending order. The caller must not modify the returned
+// slice.
+func (s *VersionSet[V]) Between(lower, upper V) []V {
+	lo, hi := s.lowerBound(lower), s.lowerBound(upper)
+	if lo >= hi {
+		return nil
+	}
+	return s.versions[lo:hi:hi]
+}
+
+// CountInRange returns how many versions of the set r contains.
+//
+// When r implements ComparatorRange, as the ranges of every ecosystem in
+// this module do, each AND group of comparators is turned into an interval
+// of the sorted versions by binary search, and "!=" comparators remove
+// single versions from it, so the count costs O(log n) per comparator. A
+// range with other operators, or with comparator versions that do not
+// parse, is counted by calling Contains on every version.
+func (s *VersionSet[V]) CountInRange(r VersionRange[V]) int {
+	if cr, ok := r.(ComparatorRange); ok {
+		if n, ok := s.countComparators(cr.Comparators()); ok {
+			return n
+		}
+	}
+	n := 0
+	for _, v := range s.versions {
+		if r.Contains(v) {
+			n++
+		}
+	}
+	return n
+}
+
+// indexInterval is the half-open interval [lo, hi) of set indices matching
+// an AND group, less the indices in excluded
+type indexInterval struct {
+	lo, hi   int
+	excluded []int
+}
+
+// contains reports whether index i is in the interval and not excluded
+func (in indexInterval) contains(i int) bool {
+	return in.lo <= i && i < in.hi && !slices.Contains(in.excluded, i)
+}
+
+// countComparators counts the versions matching OR-of-AND comparator groups
+// without visiting each version, or reports false if an operator or version
+// cannot be handled that way
+func (s *VersionSet[V]) countComparators(groups [][]Comparator) (int, bool) {
+	intervals := make([]indexInterval, 0, len(groups))
+	for _, group := range groups {
+		in, ok := s.groupInterval(group)
+		if !ok {
+			return 0, false
+		}
+		if in.lo < in.hi {
+			intervals = append(intervals, in)
+		}
+	}
+
+	// Size of the union of the intervals, ignoring exclusions
+	sorted := slices.Clone(intervals)
+	slices.SortFunc(sorted, func(a, b indexInterval) int { return a.lo - b.lo })
+	n, end := 0, 0
+	for _, in := range sorted {
+		lo := max(in.lo, end)
+		if in.hi > lo {
+			n += in.hi - lo
+			end = in.hi
+		}
+	}
+
+	// An excluded index still counts when another group contains it
+	var excluded []int
+	for _, in := range intervals {
+		for _, i := range in.excluded {
+			if slices.Contains(excluded, i) {
+				continue
+			}
+			excluded = append(excluded, i)
+			if !slices.ContainsFunc(intervals, func(other indexInterval) bool { return other.contains(i) }) {
+				n--
+			}
+		}
+	}
+	return n, true
+}
+
+// groupInterval returns the indices matching every comparator of an AND group
+func (s *VersionSet[V]) groupInterval(group []Comparator) (indexInterval, bool) {
+	in := indexInterval{lo: 0, hi: len(s.versions)}
+	var notEqual []V
+	for _, c := range group {
+		v, err := s.parse(c.Version)
+		if err != nil {
+			return indexInterval{}, false
+		}
+		switch c.Operator {
+		case "=":
+			in.lo, in.hi = max(in.lo, s.lowerBound(v)), min(in.hi, s.upperBound(v))
+		case ">=":
+			in.lo = max(in.lo, s.lowerBound(v))
+		case ">":
+			in.lo = max(in.lo, s.upperBound(v))
+		case "<":
+			in.hi = min(in.hi, s.lowerBound(v))
+		case "<=":
+			in.hi = min(in.hi, s.upperBound(v))
+		case "!=":
+			notEqual = append(notEqual, v)
+		default:
+			return indexInterval{}, false
+		}
+	}
+	for _, v := range notEqual {
+		for i := s.lowerBound(v); i < s.upperBound(v); i++ {
+			if in.lo <= i && i < in.hi && !slices.Contains(in.excluded, i) {
+				in.excluded = append(in.excluded, i)
+			}
+		}
+	}
+	return in, true
+}
+
+// lowerBound returns the index of the first version not below v
+func (s *VersionSet[V]) lowerBound(v V) int {
+	return sort.Search(len(s.versions), func(i int) bool { return s.versions[i].Compare(v) >= 0 })
+}
+
+// upperBound returns the index of the first version above v
+func (s *VersionSet[V]) upperBound(v V) int {
+	return sort.Search(len(s.versions), func(i int) bool { return s.versions[i].Compare(v) > 0 })
+}
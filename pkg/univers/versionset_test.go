@@ -0,0 +1,173 @@
+package univers_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/gem"
+	"github.com/alowayed/go-univers/pkg/ecosystem/maven"
+	"github.com/alowayed/go-univers/pkg/ecosystem/npm"
+	"github.com/alowayed/go-univers/pkg/ecosystem/pypi"
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+var npmCatalog = []string{
+	"0.9.0", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.1.0", "1.2.0-beta.1", "1.2.0",
+	"1.2.1", "1.2.5", "1.3.0", "1.10.0", "2.0.0-alpha.1", "2.0.0", "2.1.0", "3.0.0",
+}
+
+func TestNewVersionSet(t *testing.T) {
+	e := &npm.Ecosystem{}
+
+	set, err := univers.NewVersionSet(e, []string{"2.0.0", "1.0.0", "v1.0.0", "bogus", "1.5.0", "also-bogus"})
+	if err == nil || !strings.Contains(err.Error(), `"bogus"`) || !strings.Contains(err.Error(), `"also-bogus"`) {
+		t.Errorf("NewVersionSet() error = %v, want both invalid versions", err)
+	}
+
+	var got []string
+	for _, v := range set.Versions() {
+		got = append(got, v.String())
+	}
+	if want := "1.0.0 1.5.0 2.0.0"; strings.Join(got, " ") != want {
+		t.Errorf("Versions() = %v, want %v", got, want)
+	}
+	if set.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", set.Len())
+	}
+}
+
+func TestVersionSet_Has(t *testing.T) {
+	e := &npm.Ecosystem{}
+	set, err := univers.NewVersionSet(e, npmCatalog)
+	if err != nil {
+		t.Fatalf("NewVersionSet() error = %v", err)
+	}
+
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"0.9.0", true},
+		{"1.2.0-beta.1", true},
+		{"v1.10.0", true},
+		{"3.0.0", true},
+		{"1.2.2", false},
+		{"0.1.0", false},
+		{"4.0.0", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			if got := set.Has(univers.MustNewVersion(e, tt.version)); got != tt.want {
+				t.Errorf("Has(%q) = %v, want %v", tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersionSet_Between(t *testing.T) {
+	e := &npm.Ecosystem{}
+	set, err := univers.NewVersionSet(e, npmCatalog)
+	if err != nil {
+		t.Fatalf("NewVersionSet() error = %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		lower, upper string
+		want         string
+	}{
+		{"inclusive lower exclusive upper", "1.2.0", "1.3.0", "1.2.0 1.2.1 1.2.5"},
+		{"bounds not in set", "1.2.2", "1.9.0", "1.2.5 1.3.0"},
+		{"prereleases in between", "1.0.0-alpha", "1.0.0", "1.0.0-rc.1"},
+		{"below everything", "0.0.1", "0.9.0", ""},
+		{"empty when upper below lower", "2.0.0", "1.0.0", ""},
+		{"everything", "0.0.0", "9.0.0", strings.Join(npmCatalog, " ")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, v := range set.Between(univers.MustNewVersion(e, tt.lower), univers.MustNewVersion(e, tt.upper)) {
+				got = append(got, v.String())
+			}
+			if strings.Join(got, " ") != tt.want {
+				t.Errorf("Between(%q, %q) = %v, want %v", tt.lower, tt.upper, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersionSet_CountInRange(t *testing.T) {
+	tests := []struct {
+		name  string
+		count func() (got, linear int)
+		want  int
+	}{
+		{"npm caret", countBoth(&npm.Ecosystem{}, npmCatalog, "^1.2.0"), 5},
+		{"npm or", countBoth(&npm.Ecosystem{}, npmCatalog, "<1.0.0 || >=2.0.0"), 5},
+		{"npm overlapping or", countBoth(&npm.Ecosystem{}, npmCatalog, ">=1.0.0 <1.3.0 || >=1.2.0 <2.0.0"), 10},
+		{"npm exact", countBoth(&npm.Ecosystem{}, npmCatalog, "1.2.1"), 1},
+		{"npm none", countBoth(&npm.Ecosystem{}, npmCatalog, ">=4.0.0"), 0},
+		{"npm any", countBoth(&npm.Ecosystem{}, npmCatalog, "*"), len(npmCatalog)},
+		{"pypi exclusion", countBoth(&pypi.Ecosystem{}, []string{"1.0", "1.1", "1.2", "1.3", "2.0"}, ">=1.0,!=1.2,<2.0"), 3},
+		{"pypi compatible", countBoth(&pypi.Ecosystem{}, []string{"1.3", "1.4.1", "1.4.2", "1.4.9", "1.5.0"}, "~=1.4.2"), 2},
+		{"maven interval", countBoth(&maven.Ecosystem{}, []string{"0.9", "1.0-SNAPSHOT", "1.0", "1.5", "2.0", "3.0"}, "[1.0,2.0)"), 2},
+		{"gem pessimistic", countBoth(&gem.Ecosystem{}, []string{"2.1", "2.2", "2.2.5", "2.9", "3.0"}, "~> 2.2"), 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, linear := tt.count()
+			if got != tt.want || linear != tt.want {
+				t.Errorf("CountInRange() = %d, Contains on every version = %d, want %d", got, linear, tt.want)
+			}
+		})
+	}
+}
+
+// countBoth returns a function counting the catalog versions in rangeStr
+// with CountInRange and with Contains on every version
+func countBoth[V univers.Version[V], VR univers.VersionRange[V]](e univers.Ecosystem[V, VR], catalog []string, rangeStr string) func() (int, int) {
+	return func() (int, int) {
+		set, err := univers.NewVersionSet(e, catalog)
+		if err != nil {
+			panic(err)
+		}
+		r := univers.MustNewVersionRange(e, rangeStr)
+		linear := 0
+		for _, v := range set.Versions() {
+			if r.Contains(v) {
+				linear++
+			}
+		}
+		return set.CountInRange(r), linear
+	}
+}
+
+// countingRange counts the calls to Contains of the range it wraps
+type countingRange struct {
+	*npm.VersionRange
+	calls int
+}
+
+func (r *countingRange) Contains(v *npm.Version) bool {
+	r.calls++
+	return r.VersionRange.Contains(v)
+}
+
+func TestVersionSet_CountInRange_Comparators(t *testing.T) {
+	e := &npm.Ecosystem{}
+	set, err := univers.NewVersionSet(e, npmCatalog)
+	if err != nil {
+		t.Fatalf("NewVersionSet() error = %v", err)
+	}
+
+	r := &countingRange{VersionRange: univers.MustNewVersionRange(e, ">=1.0.0 <2.0.0 || 3.0.0")}
+	if got := set.CountInRange(r); got != 11 {
+		t.Errorf("CountInRange() = %d, want 11", got)
+	}
+	if r.calls != 0 {
+		t.Errorf("CountInRange() called Contains %d times, want 0", r.calls)
+	}
+}
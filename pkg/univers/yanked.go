@@ -0,0 +1,68 @@
+package univers
+
+// YankPolicy decides whether resolution helpers may select a version its
+// registry has withdrawn, e.g. a release yanked from PyPI or crates.io. It is
+// called with the parsed range and the withdrawn version, which the range
+// already contains.
+type YankPolicy func(r any, version string) bool
+
+// PipYankPolicy mirrors pip (PEP 592): a yanked release is only selected when
+// the range pins it exactly, i.e. every alternative of the range has an "=" or
+// "===" comparator. Ranges that do not implement ComparatorRange never select
+// yanked versions.
+func PipYankPolicy(r any, version string) bool {
+	cr, ok := r.(ComparatorRange)
+	if !ok {
+		return false
+	}
+	groups := cr.Comparators()
+	if len(groups) == 0 {
+		return false
+	}
+	for _, group := range groups {
+		if !pinsExactly(group) {
+			return false
+		}
+	}
+	return true
+}
+
+// pinsExactly reports whether an AND group holds an exact-version comparator
+func pinsExactly(group []Comparator) bool {
+	for _, c := range group {
+		if c.Operator == "=" || c.Operator == "===" {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveOption configures MaxSatisfying.
+type ResolveOption func(*resolveOptions)
+
+type resolveOptions struct {
+	yanked func(version string) bool
+	policy YankPolicy
+}
+
+// WithYanked marks the published versions for which yanked returns true as
+// withdrawn. They are skipped unless policy allows them for the range; a nil
+// policy uses PipYankPolicy.
+func WithYanked(yanked func(version string) bool, policy YankPolicy) ResolveOption {
+	return func(o *resolveOptions) {
+		o.yanked = yanked
+		o.policy = policy
+	}
+}
+
+// skip reports whether version s should be left out of resolution for r
+func (o *resolveOptions) skip(r any, s string) bool {
+	if o.yanked == nil || !o.yanked(s) {
+		return false
+	}
+	policy := o.policy
+	if policy == nil {
+		policy = PipYankPolicy
+	}
+	return !policy(r, s)
+}
@@ -0,0 +1,165 @@
+package univers_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/alowayed/go-univers/pkg/ecosystem/golang"
+	"github.com/alowayed/go-univers/pkg/ecosystem/pypi"
+	"github.com/alowayed/go-univers/pkg/univers"
+)
+
+func TestPipYankPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		version  string
+		want     bool
+	}{
+		{name: "exact pin", rangeStr: "==1.2.0", version: "1.2.0", want: true},
+		{name: "arbitrary equality", rangeStr: "===1.2.0", version: "1.2.0", want: true},
+		{name: "pin with exclusion", rangeStr: "==1.2.0,!=1.3.0", version: "1.2.0", want: true},
+		{name: "lower bound", rangeStr: ">=1.0", version: "1.2.0", want: false},
+		{name: "wildcard", rangeStr: "==1.2.*", version: "1.2.0", want: false},
+		{name: "compatible release", rangeStr: "~=1.2", version: "1.2.0", want: false},
+	}
+
+	e := &pypi.Ecosystem{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := e.NewVersionRange(tt.rangeStr)
+			if err != nil {
+				t.Fatalf("NewVersionRange(%q) error = %v", tt.rangeStr, err)
+			}
+			if got := univers.PipYankPolicy(r, tt.version); got != tt.want {
+				t.Errorf("PipYankPolicy(%q, %q) = %v, want %v", tt.rangeStr, tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaxSatisfying_Yanked(t *testing.T) {
+	src := univers.VersionSourceFunc(func(string) ([]string, error) {
+		return []string{"1.0.0", "1.1.0", "1.2.0", "2.0.0"}, nil
+	})
+	yanked := func(v string) bool { return v == "1.2.0" || v == "2.0.0" }
+	allowAll := func(any, string) bool { return true }
+
+	tests := []struct {
+		name     string
+		rangeStr string
+		opts     []univers.ResolveOption
+		want     string
+		wantOK   bool
+	}{
+		{
+			name:     "no metadata",
+			rangeStr: "<2.0",
+			want:     "1.2.0",
+			wantOK:   true,
+		},
+		{
+			name:     "yanked skipped",
+			rangeStr: "<2.0",
+			opts:     []univers.ResolveOption{univers.WithYanked(yanked, nil)},
+			want:     "1.1.0",
+			wantOK:   true,
+		},
+		{
+			name:     "yanked pinned exactly",
+			rangeStr: "==1.2.0",
+			opts:     []univers.ResolveOption{univers.WithYanked(yanked, nil)},
+			want:     "1.2.0",
+			wantOK:   true,
+		},
+		{
+			name:     "only yanked versions match",
+			rangeStr: ">=2.0",
+			opts:     []univers.ResolveOption{univers.WithYanked(yanked, nil)},
+			wantOK:   false,
+		},
+		{
+			name:     "custom policy",
+			rangeStr: ">=1.0",
+			opts:     []univers.ResolveOption{univers.WithYanked(yanked, allowAll)},
+			want:     "2.0.0",
+			wantOK:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := univers.MaxSatisfying(&pypi.Ecosystem{}, src, "pkg", tt.rangeStr, tt.opts...)
+			if err != nil {
+				t.Fatalf("MaxSatisfying() error = %v", err)
+			}
+			if ok != tt.wantOK {
+				t.Fatalf("MaxSatisfying() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && got.String() != tt.want {
+				t.Errorf("MaxSatisfying() = %q, want %q", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestVersionsInRange_Yanked(t *testing.T) {
+	versions := []string{"1.0.0", "1.1.0", "1.2.0"}
+	yanked := func(v string) bool { return v == "1.1.0" }
+
+	tests := []struct {
+		name     string
+		rangeStr string
+		want     []string
+	}{
+		{name: "yanked left out", rangeStr: ">=1.0", want: []string{"1.0.0", "1.2.0"}},
+		{name: "yanked pinned exactly", rangeStr: "==1.1.0", want: []string{"1.1.0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := univers.VersionsInRange(&pypi.Ecosystem{}, tt.rangeStr, versions, univers.EnumerateOptions{Yanked: yanked})
+			if err != nil {
+				t.Fatalf("VersionsInRange() error = %v", err)
+			}
+			var got []string
+			for _, v := range page.Versions {
+				got = append(got, v.String())
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("VersionsInRange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaxSatisfying_Retracted(t *testing.T) {
+	src := univers.VersionSourceFunc(func(string) ([]string, error) {
+		return []string{"v1.0.0", "v1.1.0", "v1.2.0", "v1.2.1"}, nil
+	})
+	retractions, err := golang.ParseRetractions("retract [v1.2.0, v1.2.1]")
+	if err != nil {
+		t.Fatalf("ParseRetractions() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		rangeStr string
+		want     string
+	}{
+		{name: "retracted skipped", rangeStr: ">=v1.0.0", want: "v1.1.0"},
+		{name: "retracted version requested", rangeStr: "v1.2.0", want: "v1.2.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := univers.MaxSatisfying(&golang.Ecosystem{}, src, "example.com/m", tt.rangeStr, univers.WithYanked(retractions.Retracted, nil))
+			if err != nil || !ok {
+				t.Fatalf("MaxSatisfying() = %v, %v, %v", got, ok, err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("MaxSatisfying() = %q, want %q", got.String(), tt.want)
+			}
+		})
+	}
+}